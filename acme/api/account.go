@@ -57,6 +57,25 @@ func (a *AccountService) Get(accountURL string) (acme.Account, error) {
 	return account, nil
 }
 
+// Update Updates the contacts of an account, no contact removes the contacts of the account.
+func (a *AccountService) Update(accountURL string, req acme.AccountUpdate) (acme.Account, error) {
+	if len(accountURL) == 0 {
+		return acme.Account{}, errors.New("account[update]: empty URL")
+	}
+
+	if req.Contact == nil {
+		// sent as an empty array, not as null.
+		req.Contact = []string{}
+	}
+
+	var account acme.Account
+	_, err := a.core.post(accountURL, req, &account)
+	if err != nil {
+		return acme.Account{}, err
+	}
+	return account, nil
+}
+
 // Deactivate Deactivates an account.
 func (a *AccountService) Deactivate(accountURL string) error {
 	if len(accountURL) == 0 {
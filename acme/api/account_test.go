@@ -0,0 +1,72 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"testing"
+
+	"github.com/go-acme/lego/v3/acme"
+	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountService_Update(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		contact  []string
+		expected string
+	}{
+		{
+			desc:     "email",
+			contact:  []string{"mailto:new@example.com"},
+			expected: `{"contact":["mailto:new@example.com"]}`,
+		},
+		{
+			desc:     "empty contact",
+			contact:  []string{},
+			expected: `{"contact":[]}`,
+		},
+		{
+			desc:     "nil contact",
+			expected: `{"contact":[]}`,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			mux, apiURL, tearDown := tester.SetupFakeAPI()
+			defer tearDown()
+
+			// small value keeps test fast
+			privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+			require.NoError(t, err, "Could not generate test key")
+
+			var body []byte
+			mux.HandleFunc("/account/1", func(w http.ResponseWriter, r *http.Request) {
+				body, err = readSignedBody(r, privateKey)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				err = tester.WriteJSONResponse(w, acme.Account{Status: acme.StatusValid, Contact: test.contact})
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+					return
+				}
+			})
+
+			core, err := New(http.DefaultClient, "lego-test", apiURL+"/dir", apiURL+"/account/1", privateKey)
+			require.NoError(t, err)
+
+			account, err := core.Accounts.Update(apiURL+"/account/1", acme.AccountUpdate{Contact: test.contact})
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, string(body))
+			assert.Equal(t, acme.StatusValid, account.Status)
+		})
+	}
+}
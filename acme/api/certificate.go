@@ -6,12 +6,16 @@ import (
 	"errors"
 	"io/ioutil"
 	"net/http"
+	"strings"
 
 	"github.com/go-acme/lego/v3/acme"
 	"github.com/go-acme/lego/v3/certcrypto"
 	"github.com/go-acme/lego/v3/log"
 )
 
+// ErrNoARI is returned when the CA doesn't support the ACME Renewal Information (ARI).
+var ErrNoARI = errors.New("renewalInfo[get]: the CA doesn't support ARI")
+
 // maxBodySize is the maximum size of body that we will read.
 const maxBodySize = 1024 * 1024
 
@@ -53,6 +57,26 @@ func (c *CertificateService) Revoke(req acme.RevokeCertMessage) error {
 	return err
 }
 
+// GetRenewalInfo Returns the renewal information (ARI) of a certificate.
+// The certID is the unique identifier of the certificate (see certificate.MakeARICertID).
+// - https://datatracker.ietf.org/doc/draft-ietf-acme-ari/
+func (c *CertificateService) GetRenewalInfo(certID string) (*acme.RenewalInfoResponse, error) {
+	renewalInfoURL := c.core.GetDirectory().RenewalInfo
+	if renewalInfoURL == "" {
+		return nil, ErrNoARI
+	}
+
+	var info acme.RenewalInfoResponse
+	resp, err := c.core.doer.Get(strings.TrimSuffix(renewalInfoURL, "/")+"/"+certID, &info)
+	if err != nil {
+		return nil, err
+	}
+
+	info.RetryAfter = getRetryAfter(resp)
+
+	return &info, nil
+}
+
 // get Returns the certificate and the "up" link.
 func (c *CertificateService) get(certURL string) ([]byte, string, error) {
 	if len(certURL) == 0 {
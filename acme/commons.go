@@ -28,7 +28,10 @@ type Directory struct {
 	NewAuthzURL   string `json:"newAuthz"`
 	RevokeCertURL string `json:"revokeCert"`
 	KeyChangeURL  string `json:"keyChange"`
-	Meta          Meta   `json:"meta"`
+	// renewalInfo (optional, string): the URL of the ACME Renewal Information (ARI) endpoint.
+	// - https://datatracker.ietf.org/doc/draft-ietf-acme-ari/
+	RenewalInfo string `json:"renewalInfo,omitempty"`
+	Meta        Meta   `json:"meta"`
 }
 
 // Meta the ACME meta object (related to Directory).
@@ -57,6 +60,12 @@ type Meta struct {
 	ExternalAccountRequired bool `json:"externalAccountRequired"`
 }
 
+// AccountUpdate the payload of an account update request (RFC 8555, section 7.3.2).
+// The contact field is always sent: an empty array removes the contacts of the account.
+type AccountUpdate struct {
+	Contact []string `json:"contact"`
+}
+
 // ExtendedAccount a extended Account.
 type ExtendedAccount struct {
 	Account
@@ -265,6 +274,21 @@ type CSRMessage struct {
 	Csr string `json:"csr"`
 }
 
+// Revocation reasons (RFC5280 section 5.3.1).
+// - https://tools.ietf.org/html/rfc5280#section-5.3.1
+const (
+	CRLReasonUnspecified          uint = 0
+	CRLReasonKeyCompromise        uint = 1
+	CRLReasonCACompromise         uint = 2
+	CRLReasonAffiliationChanged   uint = 3
+	CRLReasonSuperseded           uint = 4
+	CRLReasonCessationOfOperation uint = 5
+	CRLReasonCertificateHold      uint = 6
+	CRLReasonRemoveFromCRL        uint = 8
+	CRLReasonPrivilegeWithdrawn   uint = 9
+	CRLReasonAACompromise         uint = 10
+)
+
 // RevokeCertMessage a certificate revocation message
 // - https://tools.ietf.org/html/draft-ietf-acme-acme-16#section-7.6
 // - https://tools.ietf.org/html/rfc5280#section-5.3.1
@@ -282,3 +306,24 @@ type RevokeCertMessage struct {
 	// The problem document detail SHOULD indicate which reasonCodes are allowed.
 	Reason *uint `json:"reason,omitempty"`
 }
+
+// RenewalInfoResponse the ACME Renewal Information (ARI) of a certificate.
+// - https://datatracker.ietf.org/doc/draft-ietf-acme-ari/
+type RenewalInfoResponse struct {
+	// suggestedWindow (required, object):
+	// The window within which the certificate should be renewed.
+	SuggestedWindow Window `json:"suggestedWindow"`
+
+	// explanationURL (optional, string):
+	// A URL pointing to a page which may explain why the suggested renewal window is what it is.
+	ExplanationURL string `json:"explanationURL,omitempty"`
+
+	// Contains the value of the response header `Retry-After`
+	RetryAfter string `json:"-"`
+}
+
+// Window a time window.
+type Window struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
@@ -310,6 +310,12 @@ func (c *Certifier) checkResponse(order acme.Order, certRes *Resource, bundle bo
 
 // Revoke takes a PEM encoded certificate or bundle and tries to revoke it at the CA.
 func (c *Certifier) Revoke(cert []byte) error {
+	return c.RevokeWithReason(cert, nil)
+}
+
+// RevokeWithReason takes a PEM encoded certificate or bundle and tries to revoke it at the CA.
+// The reason is one of the revocation reasons (acme.CRLReason*), nil to omit it.
+func (c *Certifier) RevokeWithReason(cert []byte, reason *uint) error {
 	certificates, err := certcrypto.ParsePEMBundle(cert)
 	if err != nil {
 		return err
@@ -322,6 +328,7 @@ func (c *Certifier) Revoke(cert []byte) error {
 
 	revokeMsg := acme.RevokeCertMessage{
 		Certificate: base64.RawURLEncoding.EncodeToString(x509Cert.Raw),
+		Reason:      reason,
 	}
 
 	return c.core.Certificates.Revoke(revokeMsg)
@@ -393,6 +400,14 @@ func (c *Certifier) Renew(certRes Resource, bundle, mustStaple bool) (*Resource,
 //
 // If the []byte and/or ocsp.Response return values are nil, the OCSP status may be assumed OCSPUnknown.
 func (c *Certifier) GetOCSP(bundle []byte) ([]byte, *ocsp.Response, error) {
+	return FetchOCSP(c.core.HTTPClient, bundle)
+}
+
+// FetchOCSP takes a PEM encoded cert or cert bundle returning the raw OCSP response,
+// the parsed response, and an error, if any.
+// It is the same as Certifier.GetOCSP, without ACME client: the HTTP client is used for the OCSP request
+// and to get the issuer certificate if the bundle only contains the issued certificate.
+func FetchOCSP(httpClient *http.Client, bundle []byte) ([]byte, *ocsp.Response, error) {
 	certificates, err := certcrypto.ParsePEMBundle(bundle)
 	if err != nil {
 		return nil, nil, err
@@ -416,7 +431,7 @@ func (c *Certifier) GetOCSP(bundle []byte) ([]byte, *ocsp.Response, error) {
 			return nil, nil, errors.New("no issuing certificate URL")
 		}
 
-		resp, errC := c.core.HTTPClient.Get(issuedCert.IssuingCertificateURL[0])
+		resp, errC := httpClient.Get(issuedCert.IssuingCertificateURL[0])
 		if errC != nil {
 			return nil, nil, errC
 		}
@@ -445,7 +460,7 @@ func (c *Certifier) GetOCSP(bundle []byte) ([]byte, *ocsp.Response, error) {
 		return nil, nil, err
 	}
 
-	resp, err := c.core.HTTPClient.Post(issuedCert.OCSPServer[0], "application/ocsp-request", bytes.NewReader(ocspReq))
+	resp, err := httpClient.Post(issuedCert.OCSPServer[0], "application/ocsp-request", bytes.NewReader(ocspReq))
 	if err != nil {
 		return nil, nil, err
 	}
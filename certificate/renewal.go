@@ -0,0 +1,140 @@
+package certificate
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/go-acme/lego/v3/acme"
+)
+
+// RenewalInfoRequest contains the necessary renewal information.
+type RenewalInfoRequest struct {
+	Cert *x509.Certificate
+}
+
+// RenewalInfoResponse is a wrapper around acme.RenewalInfoResponse that provides a method for determining when to renew a certificate.
+type RenewalInfoResponse struct {
+	acme.RenewalInfoResponse
+
+	// RetryAfter header indicating the polling interval that the ACME server recommends.
+	// Conforming clients SHOULD query the renewalInfo URL again after the RetryAfter period has passed.
+	RetryAfter time.Duration
+}
+
+// ShouldRenewAt determines the optimal renewal time based on the current time (UTC), the renewal window suggested by ARI, and the client's willingness to sleep.
+// It returns a pointer to a time.Time value indicating when the renewal should be attempted or nil if deferred until the next normal wake time.
+// This method implements the RECOMMENDED algorithm described in draft-ietf-acme-ari.
+//
+// - (4.1-11. Getting Renewal Information) https://datatracker.ietf.org/doc/draft-ietf-acme-ari/
+func (r *RenewalInfoResponse) ShouldRenewAt(now time.Time, willingToSleep time.Duration) *time.Time {
+	// Explicitly convert all times to UTC.
+	now = now.UTC()
+	start := r.SuggestedWindow.Start.UTC()
+	end := r.SuggestedWindow.End.UTC()
+
+	// Select a uniform random time within the suggested window.
+	window := end.Sub(start)
+
+	selectedTime := start
+	if window > 0 {
+		selectedTime = start.Add(time.Duration(rand.Int63n(int64(window))))
+	}
+
+	// If the selected time is in the past, attempt renewal immediately.
+	if selectedTime.Before(now) {
+		return &now
+	}
+
+	// Otherwise, if the client can schedule itself to attempt renewal at exactly the selected time, do so.
+	willingToSleepUntil := now.Add(willingToSleep)
+	if willingToSleepUntil.After(selectedTime) || willingToSleepUntil.Equal(selectedTime) {
+		return &selectedTime
+	}
+
+	// Otherwise, sleep until the next normal wake time, re-check ARI, and return to Step 1.
+	return nil
+}
+
+// GetRenewalInfo sends a request to the ACME server's renewalInfo endpoint to obtain a suggested renewal window.
+// The certificate must contain the Authority Key Identifier extension.
+// The returned error is api.ErrNoARI if the CA doesn't support the ACME Renewal Information.
+//
+// - (4.1-11. Getting Renewal Information) https://datatracker.ietf.org/doc/draft-ietf-acme-ari/
+func (c *Certifier) GetRenewalInfo(req RenewalInfoRequest) (*RenewalInfoResponse, error) {
+	certID, err := MakeARICertID(req.Cert)
+	if err != nil {
+		return nil, fmt.Errorf("error making certID: %v", err)
+	}
+
+	info, err := c.core.Certificates.GetRenewalInfo(certID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RenewalInfoResponse{RenewalInfoResponse: *info}
+
+	if info.RetryAfter != "" {
+		response.RetryAfter, err = parseRetryAfter(info.RetryAfter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
+
+// MakeARICertID constructs a certificate identifier as described in draft-ietf-acme-ari-03, section 4.1:
+// the base64url-encoded keyIdentifier of the Authority Key Identifier extension and
+// the base64url-encoded bytes of the DER encoding of the serial number, separated by a dot.
+//
+// - (4.1. Getting Renewal Information) https://datatracker.ietf.org/doc/draft-ietf-acme-ari/
+func MakeARICertID(leaf *x509.Certificate) (string, error) {
+	if leaf == nil {
+		return "", errors.New("leaf certificate is nil")
+	}
+
+	if len(leaf.AuthorityKeyId) == 0 {
+		return "", errors.New("missing authority key identifier")
+	}
+
+	// Marshal the serial number into DER.
+	der, err := asn1.Marshal(leaf.SerialNumber)
+	if err != nil {
+		return "", err
+	}
+
+	// Check if the DER encoded bytes are sufficient (at least 3 bytes: tag, length, and value).
+	if len(der) < 3 {
+		return "", errors.New("invalid DER encoding of serial number")
+	}
+
+	// Extract only the integer bytes from the DER encoded Serial Number
+	// Skipping the first 2 bytes (tag and length).
+	serial := base64.RawURLEncoding.EncodeToString(der[2:])
+
+	// Convert the Authority Key Identifier to base64url encoding without padding.
+	aki := base64.RawURLEncoding.EncodeToString(leaf.AuthorityKeyId)
+
+	// Construct the final identifier by concatenating AKI and Serial Number.
+	return fmt.Sprintf("%s.%s", aki, serial), nil
+}
+
+// parseRetryAfter parses the value of the header Retry-After: a number of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	date, err := time.Parse(time.RFC1123, value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid Retry-After header: %s", value)
+	}
+
+	return time.Until(date), nil
+}
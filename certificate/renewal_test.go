@@ -0,0 +1,131 @@
+package certificate
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v3/acme/api"
+	"github.com/go-acme/lego/v3/certcrypto"
+	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ariLeafCertID = "aYhba4dGQEHhs3uEe6CuLN4ByNQ.AIdlQyE"
+
+func generateARILeaf(t *testing.T) *x509.Certificate {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:   big.NewInt(0x87654321),
+		Subject:        pkix.Name{CommonName: "example.com"},
+		NotBefore:      time.Now(),
+		NotAfter:       time.Now().Add(90 * 24 * time.Hour),
+		AuthorityKeyId: []byte{0x69, 0x88, 0x5b, 0x6b, 0x87, 0x46, 0x40, 0x41, 0xe1, 0xb3, 0x7b, 0x84, 0x7b, 0xa0, 0xae, 0x2c, 0xde, 0x01, 0xc8, 0xd4},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert
+}
+
+func TestMakeARICertID(t *testing.T) {
+	certID, err := MakeARICertID(generateARILeaf(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, ariLeafCertID, certID)
+
+	_, err = MakeARICertID(&x509.Certificate{SerialNumber: big.NewInt(1)})
+	require.EqualError(t, err, "missing authority key identifier")
+}
+
+func TestCertifier_GetRenewalInfo(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	mux.HandleFunc("/renewalInfo/"+ariLeafCertID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "21600")
+		_, _ = w.Write([]byte(`{"suggestedWindow":{"start":"2020-03-17T17:51:09Z","end":"2020-03-17T18:21:09Z"},"explanationURL":"https://aricapable.ca/docs"}`))
+	})
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", key)
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048})
+
+	ri, err := certifier.GetRenewalInfo(RenewalInfoRequest{Cert: generateARILeaf(t)})
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Date(2020, time.March, 17, 17, 51, 9, 0, time.UTC), ri.SuggestedWindow.Start)
+	assert.Equal(t, time.Date(2020, time.March, 17, 18, 21, 9, 0, time.UTC), ri.SuggestedWindow.End)
+	assert.Equal(t, "https://aricapable.ca/docs", ri.ExplanationURL)
+	assert.Equal(t, 6*time.Hour, ri.RetryAfter)
+}
+
+func TestRenewalInfoResponse_ShouldRenewAt(t *testing.T) {
+	now := time.Now().UTC()
+
+	testCases := []struct {
+		desc           string
+		start, end     time.Time
+		willingToSleep time.Duration
+		assert         func(t *testing.T, renewAt *time.Time)
+	}{
+		{
+			desc:  "window in the past",
+			start: now.Add(-2 * time.Hour),
+			end:   now.Add(-time.Hour),
+			assert: func(t *testing.T, renewAt *time.Time) {
+				require.NotNil(t, renewAt)
+				assert.Equal(t, now, *renewAt)
+			},
+		},
+		{
+			desc:           "window within the sleep duration",
+			start:          now.Add(time.Hour),
+			end:            now.Add(2 * time.Hour),
+			willingToSleep: 3 * time.Hour,
+			assert: func(t *testing.T, renewAt *time.Time) {
+				require.NotNil(t, renewAt)
+				assert.False(t, renewAt.Before(now.Add(time.Hour)))
+				assert.True(t, renewAt.Before(now.Add(2*time.Hour)))
+			},
+		},
+		{
+			desc:           "window after the sleep duration",
+			start:          now.Add(time.Hour),
+			end:            now.Add(2 * time.Hour),
+			willingToSleep: 30 * time.Minute,
+			assert: func(t *testing.T, renewAt *time.Time) {
+				assert.Nil(t, renewAt)
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			ri := RenewalInfoResponse{}
+			ri.SuggestedWindow.Start = test.start
+			ri.SuggestedWindow.End = test.end
+
+			test.assert(t, ri.ShouldRenewAt(now, test.willingToSleep))
+		})
+	}
+}
@@ -3,9 +3,12 @@ package dns01
 import (
 	"crypto/sha256"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v3/acme"
@@ -49,6 +52,23 @@ type Challenge struct {
 	provider   challenge.Provider
 	preCheck   preCheck
 	dnsTimeout time.Duration
+
+	// records the presented records of each FQDN, shared by several authorizations (CNAME, wildcard and apex, ...).
+	recordsMu sync.Mutex
+	records   map[string]*recordSet
+}
+
+// recordSet the values presented on a FQDN.
+type recordSet struct {
+	// values counts the authorizations using each value.
+	values map[string]int
+	// cleanUps the records to remove when the FQDN is no longer used.
+	cleanUps []recordCleanUp
+}
+
+// recordCleanUp the arguments of the clean up of a record by the provider.
+type recordCleanUp struct {
+	domain, token, keyAuth string
 }
 
 func NewChallenge(core *api.Core, validate ValidateFunc, provider challenge.Provider, opts ...ChallengeOption) *Challenge {
@@ -58,6 +78,7 @@ func NewChallenge(core *api.Core, validate ValidateFunc, provider challenge.Prov
 		provider:   provider,
 		preCheck:   newPreCheck(),
 		dnsTimeout: 10 * time.Second,
+		records:    make(map[string]*recordSet),
 	}
 
 	for _, opt := range opts {
@@ -91,11 +112,35 @@ func (c *Challenge) PreSolve(authz acme.Authorization) error {
 		return err
 	}
 
+	fqdn, value := GetRecord(authz.Identifier.Value, keyAuth)
+	fqdn = ToFqdn(fqdn)
+
+	c.recordsMu.Lock()
+	defer c.recordsMu.Unlock()
+
+	records, ok := c.records[fqdn]
+	if !ok {
+		records = &recordSet{values: make(map[string]int)}
+		c.records[fqdn] = records
+	}
+
+	if records.values[value] > 0 {
+		// The same record is already presented for another authorization: the record is presented only once.
+		log.Infof("[%s] acme: The TXT record %s is already presented", domain, fqdn)
+		records.values[value]++
+		return nil
+	}
+
 	err = c.provider.Present(authz.Identifier.Value, chlng.Token, keyAuth)
 	if err != nil {
+		if len(records.values) == 0 {
+			delete(c.records, fqdn)
+		}
 		return fmt.Errorf("[%s] acme: error presenting token: %s", domain, err)
 	}
 
+	records.values[value] = 1
+
 	return nil
 }
 
@@ -155,7 +200,45 @@ func (c *Challenge) CleanUp(authz acme.Authorization) error {
 		return err
 	}
 
-	return c.provider.CleanUp(authz.Identifier.Value, chlng.Token, keyAuth)
+	fqdn, value := GetRecord(authz.Identifier.Value, keyAuth)
+	fqdn = ToFqdn(fqdn)
+
+	c.recordsMu.Lock()
+	defer c.recordsMu.Unlock()
+
+	records, ok := c.records[fqdn]
+	if !ok || records.values[value] == 0 {
+		// The record has not been presented by this challenge.
+		return c.provider.CleanUp(authz.Identifier.Value, chlng.Token, keyAuth)
+	}
+
+	records.values[value]--
+	if records.values[value] == 0 {
+		delete(records.values, value)
+		records.cleanUps = append(records.cleanUps, recordCleanUp{domain: authz.Identifier.Value, token: chlng.Token, keyAuth: keyAuth})
+	}
+
+	// The records of a FQDN are removed only when the FQDN is no longer used by another authorization:
+	// some providers remove all the TXT records of a FQDN.
+	if len(records.values) > 0 {
+		return nil
+	}
+
+	delete(c.records, fqdn)
+
+	var errs []string
+	for _, r := range records.cleanUps {
+		err = c.provider.CleanUp(r.domain, r.token, r.keyAuth)
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+
+	return nil
 }
 
 func (c *Challenge) Sequential() (bool, time.Duration) {
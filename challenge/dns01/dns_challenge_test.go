@@ -287,3 +287,100 @@ func TestChallenge_CleanUp(t *testing.T) {
 		})
 	}
 }
+
+type providerCountMock struct {
+	present, cleanUp int
+}
+
+func (p *providerCountMock) Present(domain, token, keyAuth string) error {
+	p.present++
+	return nil
+}
+
+func (p *providerCountMock) CleanUp(domain, token, keyAuth string) error {
+	p.cleanUp++
+	return nil
+}
+
+func TestChallenge_sameFqdn(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	provider := &providerCountMock{}
+	chlg := NewChallenge(core, nil, provider)
+
+	// the apex and the wildcard use the same FQDN, with different tokens (different values).
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{
+			Value: "example.com",
+		},
+		Challenges: []acme.Challenge{
+			{Type: challenge.DNS01.String(), Token: "token-apex"},
+		},
+	}
+	wildcard := acme.Authorization{
+		Identifier: acme.Identifier{
+			Value: "example.com",
+		},
+		Wildcard: true,
+		Challenges: []acme.Challenge{
+			{Type: challenge.DNS01.String(), Token: "token-wildcard"},
+		},
+	}
+
+	require.NoError(t, chlg.PreSolve(authz))
+	require.NoError(t, chlg.PreSolve(wildcard))
+	require.Equal(t, 2, provider.present)
+
+	require.NoError(t, chlg.CleanUp(authz))
+	require.Equal(t, 0, provider.cleanUp)
+
+	require.NoError(t, chlg.CleanUp(wildcard))
+	require.Equal(t, 2, provider.cleanUp)
+
+	// the FQDN is no longer used.
+	require.NoError(t, chlg.PreSolve(authz))
+	require.Equal(t, 3, provider.present)
+
+	require.NoError(t, chlg.CleanUp(authz))
+	require.Equal(t, 3, provider.cleanUp)
+}
+
+func TestChallenge_identicalRecords(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	provider := &providerCountMock{}
+	chlg := NewChallenge(core, nil, provider)
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{
+			Value: "example.com",
+		},
+		Challenges: []acme.Challenge{
+			{Type: challenge.DNS01.String(), Token: "token"},
+		},
+	}
+
+	require.NoError(t, chlg.PreSolve(authz))
+	require.NoError(t, chlg.PreSolve(authz))
+	require.Equal(t, 1, provider.present)
+
+	require.NoError(t, chlg.CleanUp(authz))
+	require.Equal(t, 0, provider.cleanUp)
+
+	require.NoError(t, chlg.CleanUp(authz))
+	require.Equal(t, 1, provider.cleanUp)
+}
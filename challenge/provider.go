@@ -26,3 +26,12 @@ type ProviderTimeout interface {
 	Provider
 	Timeout() (timeout, interval time.Duration)
 }
+
+// ProviderCheck allows for implementing a Provider
+// able to check its credentials with a harmless authenticated
+// request to its API (i.e. reading the account), without
+// creating a record.
+type ProviderCheck interface {
+	Provider
+	CheckCredentials() error
+}
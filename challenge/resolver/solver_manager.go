@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff/v3"
@@ -27,12 +28,15 @@ func (a byType) Less(i, j int) bool { return a[i].Type > a[j].Type }
 type SolverManager struct {
 	core    *api.Core
 	solvers map[challenge.Type]solver
+	// domainChallenges the challenges allowed for a domain and its subdomains.
+	domainChallenges map[string][]challenge.Type
 }
 
 func NewSolversManager(core *api.Core) *SolverManager {
 	return &SolverManager{
-		solvers: map[challenge.Type]solver{},
-		core:    core,
+		solvers:          map[challenge.Type]solver{},
+		domainChallenges: map[string][]challenge.Type{},
+		core:             core,
 	}
 }
 
@@ -59,12 +63,44 @@ func (c *SolverManager) Remove(chlgType challenge.Type) {
 	delete(c.solvers, chlgType)
 }
 
+// SetDomainChallenges restricts the challenges used to validate a domain and its subdomains,
+// the types are in order of preference.
+// The most specific domain is used, the other domains are validated by any of the available challenges.
+func (c *SolverManager) SetDomainChallenges(domain string, types ...challenge.Type) {
+	domain = strings.TrimSuffix(strings.ToLower(strings.TrimPrefix(domain, "*.")), ".")
+
+	if len(types) == 0 {
+		delete(c.domainChallenges, domain)
+		return
+	}
+
+	c.domainChallenges[domain] = types
+}
+
 // Checks all challenges from the server in order and returns the first matching solver.
 func (c *SolverManager) chooseSolver(authz acme.Authorization) solver {
 	// Allow to have a deterministic challenge order
 	sort.Sort(byType(authz.Challenges))
 
 	domain := challenge.GetTargetedDomain(authz)
+
+	if types, ok := c.lookupDomainChallenges(authz.Identifier.Value); ok {
+		for _, chlgType := range types {
+			if !hasChallenge(authz, chlgType) {
+				log.Infof("[%s] acme: The challenge %s is not offered by the CA", domain, chlgType)
+				continue
+			}
+
+			if solvr, ok := c.solvers[chlgType]; ok {
+				log.Infof("[%s] acme: use %s solver", domain, chlgType)
+				return solvr
+			}
+			log.Infof("[%s] acme: Could not find solver for: %s", domain, chlgType)
+		}
+
+		return nil
+	}
+
 	for _, chlg := range authz.Challenges {
 		if solvr, ok := c.solvers[challenge.Type(chlg.Type)]; ok {
 			log.Infof("[%s] acme: use %s solver", domain, chlg.Type)
@@ -76,6 +112,34 @@ func (c *SolverManager) chooseSolver(authz acme.Authorization) solver {
 	return nil
 }
 
+// lookupDomainChallenges returns the challenges allowed for the most specific domain matching the identifier.
+func (c *SolverManager) lookupDomainChallenges(identifier string) ([]challenge.Type, bool) {
+	name := strings.TrimSuffix(strings.ToLower(identifier), ".")
+
+	for {
+		if types, ok := c.domainChallenges[name]; ok {
+			return types, true
+		}
+
+		i := strings.Index(name, ".")
+		if i < 0 {
+			return nil, false
+		}
+
+		name = name[i+1:]
+	}
+}
+
+func hasChallenge(authz acme.Authorization, chlgType challenge.Type) bool {
+	for _, chlg := range authz.Challenges {
+		if challenge.Type(chlg.Type) == chlgType {
+			return true
+		}
+	}
+
+	return false
+}
+
 func validate(core *api.Core, domain string, chlg acme.Challenge) error {
 	chlng, err := core.Challenges.New(chlg.URL)
 	if err != nil {
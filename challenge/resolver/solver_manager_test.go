@@ -11,6 +11,7 @@ import (
 
 	"github.com/go-acme/lego/v3/acme"
 	"github.com/go-acme/lego/v3/acme/api"
+	"github.com/go-acme/lego/v3/challenge"
 	"github.com/go-acme/lego/v3/platform/tester"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -177,3 +178,54 @@ func validateNoBody(privateKey *rsa.PrivateKey, r *http.Request) error {
 	}
 	return nil
 }
+
+type fakeSolver string
+
+func (s fakeSolver) Solve(acme.Authorization) error { return nil }
+
+func TestSolverManager_chooseSolver(t *testing.T) {
+	manager := NewSolversManager(nil)
+	manager.solvers[challenge.HTTP01] = fakeSolver("http")
+	manager.solvers[challenge.DNS01] = fakeSolver("dns")
+
+	manager.SetDomainChallenges("internal.example.com", challenge.DNS01)
+	manager.SetDomainChallenges("*.legacy.example.com", challenge.TLSALPN01, challenge.HTTP01)
+	manager.SetDomainChallenges("tls.example.com", challenge.TLSALPN01)
+
+	testCases := []struct {
+		desc     string
+		domain   string
+		wildcard bool
+		expected solver
+	}{
+		{desc: "no rule", domain: "example.com", expected: fakeSolver("http")},
+		{desc: "rule", domain: "internal.example.com", expected: fakeSolver("dns")},
+		{desc: "subdomain", domain: "a.b.internal.example.com", expected: fakeSolver("dns")},
+		{desc: "wildcard", domain: "internal.example.com", wildcard: true, expected: fakeSolver("dns")},
+		{desc: "suffix only", domain: "notinternal.example.com", expected: fakeSolver("http")},
+		{desc: "preference", domain: "www.legacy.example.com", expected: fakeSolver("http")},
+		{desc: "no solver", domain: "tls.example.com"},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			authz := acme.Authorization{
+				Identifier: acme.Identifier{Type: "dns", Value: test.domain},
+				Wildcard:   test.wildcard,
+				Challenges: []acme.Challenge{{Type: "dns-01"}, {Type: "http-01"}, {Type: "tls-alpn-01"}},
+			}
+
+			assert.Equal(t, test.expected, manager.chooseSolver(authz))
+		})
+	}
+
+	manager.SetDomainChallenges("internal.example.com")
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{Type: "dns", Value: "internal.example.com"},
+		Challenges: []acme.Challenge{{Type: "dns-01"}, {Type: "http-01"}},
+	}
+
+	assert.Equal(t, fakeSolver("http"), manager.chooseSolver(authz))
+}
@@ -9,14 +9,15 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/url"
-	"os"
-	"path/filepath"
+	"path"
 	"strings"
 
 	"github.com/go-acme/lego/v3/certcrypto"
+	"github.com/go-acme/lego/v3/internal/pkcs8"
 	"github.com/go-acme/lego/v3/lego"
 	"github.com/go-acme/lego/v3/log"
 	"github.com/go-acme/lego/v3/registration"
+	"github.com/go-acme/lego/v3/storage"
 	"github.com/urfave/cli"
 )
 
@@ -28,37 +29,36 @@ const (
 
 // AccountsStorage A storage for account data.
 //
+// The files are stored by the backend of the storage ("storage" option, the "path" folder by default),
+// the fields are the names of the files in the storage.
+//
 // rootPath:
 //
-//     ./.lego/accounts/
-//          │      └── root accounts directory
-//          └── "path" option
+//     accounts/
+//          └── root accounts directory
 //
 // rootUserPath:
 //
-//     ./.lego/accounts/localhost_14000/hubert@hubert.com/
-//          │      │             │             └── userID ("email" option)
-//          │      │             └── CA server ("server" option)
-//          │      └── root accounts directory
-//          └── "path" option
+//     accounts/localhost_14000/hubert@hubert.com/
+//          │             │             └── userID ("email" option)
+//          │             └── CA server ("server" option)
+//          └── root accounts directory
 //
 // keysPath:
 //
-//     ./.lego/accounts/localhost_14000/hubert@hubert.com/keys/
-//          │      │             │             │           └── root keys directory
-//          │      │             │             └── userID ("email" option)
-//          │      │             └── CA server ("server" option)
-//          │      └── root accounts directory
-//          └── "path" option
+//     accounts/localhost_14000/hubert@hubert.com/keys/
+//          │             │             │           └── root keys directory
+//          │             │             └── userID ("email" option)
+//          │             └── CA server ("server" option)
+//          └── root accounts directory
 //
 // accountFilePath:
 //
-//     ./.lego/accounts/localhost_14000/hubert@hubert.com/account.json
-//          │      │             │             │             └── account file
-//          │      │             │             └── userID ("email" option)
-//          │      │             └── CA server ("server" option)
-//          │      └── root accounts directory
-//          └── "path" option
+//     accounts/localhost_14000/hubert@hubert.com/account.json
+//          │             │             │             └── account file
+//          │             │             └── userID ("email" option)
+//          │             └── CA server ("server" option)
+//          └── root accounts directory
 //
 type AccountsStorage struct {
 	userID          string
@@ -66,50 +66,66 @@ type AccountsStorage struct {
 	rootUserPath    string
 	keysPath        string
 	accountFilePath string
+	keyPassphrase   []byte
+	backend         storage.Backend
 	ctx             *cli.Context
 }
 
 // NewAccountsStorage Creates a new AccountsStorage.
-func NewAccountsStorage(ctx *cli.Context) *AccountsStorage {
+func NewAccountsStorage(ctx *cli.Context) (*AccountsStorage, error) {
 	// TODO: move to account struct? Currently MUST pass email.
-	email := getEmail(ctx)
+	email, err := getEmail(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	serverURL, err := url.Parse(ctx.GlobalString("server"))
+	return newAccountsStorage(ctx, getServer(ctx), email)
+}
+
+// newAccountsStorage Creates a new AccountsStorage for an account (email) of a CA server.
+func newAccountsStorage(ctx *cli.Context, server, email string) (*AccountsStorage, error) {
+	serverURL, err := url.Parse(server)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
-	rootPath := filepath.Join(ctx.GlobalString("path"), baseAccountsRootFolderName)
-	serverPath := strings.NewReplacer(":", "_", "/", string(os.PathSeparator)).Replace(serverURL.Host)
-	accountsPath := filepath.Join(rootPath, serverPath)
-	rootUserPath := filepath.Join(accountsPath, email)
+	serverPath := strings.NewReplacer(":", "_").Replace(serverURL.Host)
+	rootUserPath := path.Join(baseAccountsRootFolderName, serverPath, email)
+
+	keyPassphrase, err := getAccountKeyPassphrase(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	backend, err := newStorageBackend(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	return &AccountsStorage{
 		userID:          email,
-		rootPath:        rootPath,
+		rootPath:        baseAccountsRootFolderName,
 		rootUserPath:    rootUserPath,
-		keysPath:        filepath.Join(rootUserPath, baseKeysFolderName),
-		accountFilePath: filepath.Join(rootUserPath, accountFileName),
+		keysPath:        path.Join(rootUserPath, baseKeysFolderName),
+		accountFilePath: path.Join(rootUserPath, accountFileName),
+		keyPassphrase:   keyPassphrase,
+		backend:         backend,
 		ctx:             ctx,
-	}
+	}, nil
 }
 
-func (s *AccountsStorage) ExistsAccountFilePath() bool {
-	accountFile := filepath.Join(s.rootUserPath, accountFileName)
-	if _, err := os.Stat(accountFile); os.IsNotExist(err) {
-		return false
-	} else if err != nil {
-		log.Fatal(err)
-	}
-	return true
+func (s *AccountsStorage) ExistsAccountFilePath() (bool, error) {
+	return storage.Exists(s.backend, s.accountFilePath)
 }
 
+// GetRootPath returns the location of the accounts directory.
 func (s *AccountsStorage) GetRootPath() string {
-	return s.rootPath
+	return s.backend.Location(s.rootPath)
 }
 
+// GetRootUserPath returns the location of the directory of the account.
 func (s *AccountsStorage) GetRootUserPath() string {
-	return s.rootUserPath
+	return s.backend.Location(s.rootUserPath)
 }
 
 func (s *AccountsStorage) GetUserID() string {
@@ -122,19 +138,19 @@ func (s *AccountsStorage) Save(account *Account) error {
 		return err
 	}
 
-	return ioutil.WriteFile(s.accountFilePath, jsonBytes, filePerm)
+	return s.backend.Save(s.accountFilePath, jsonBytes)
 }
 
-func (s *AccountsStorage) LoadAccount(privateKey crypto.PrivateKey) *Account {
-	fileBytes, err := ioutil.ReadFile(s.accountFilePath)
+func (s *AccountsStorage) LoadAccount(privateKey crypto.PrivateKey) (*Account, error) {
+	fileBytes, err := s.backend.Load(s.accountFilePath)
 	if err != nil {
-		log.Fatalf("Could not load file for account %s -> %v", s.userID, err)
+		return nil, fmt.Errorf("could not load file for account %s: %v", s.userID, err)
 	}
 
 	var account Account
 	err = json.Unmarshal(fileBytes, &account)
 	if err != nil {
-		log.Fatalf("Could not parse file for account %s -> %v", s.userID, err)
+		return nil, fmt.Errorf("could not parse file for account %s: %v", s.userID, err)
 	}
 
 	account.key = privateKey
@@ -142,92 +158,186 @@ func (s *AccountsStorage) LoadAccount(privateKey crypto.PrivateKey) *Account {
 	if account.Registration == nil || account.Registration.Body.Status == "" {
 		reg, err := tryRecoverRegistration(s.ctx, privateKey)
 		if err != nil {
-			log.Fatalf("Could not load account for %s. Registration is nil -> %#v", s.userID, err)
+			return nil, fmt.Errorf("could not load account for %s, registration is nil: %v", s.userID, err)
 		}
 
 		account.Registration = reg
 		err = s.Save(&account)
 		if err != nil {
-			log.Fatalf("Could not save account for %s. Registration is nil -> %#v", s.userID, err)
+			return nil, fmt.Errorf("could not save account for %s: %v", s.userID, err)
 		}
 	}
 
-	return &account
+	return &account, nil
 }
 
-func (s *AccountsStorage) GetPrivateKey(keyType certcrypto.KeyType) crypto.PrivateKey {
-	accKeyPath := filepath.Join(s.keysPath, s.userID+".key")
+// GetPrivateKeyPath returns the location of the private key of the account.
+func (s *AccountsStorage) GetPrivateKeyPath() string {
+	return s.backend.Location(s.getPrivateKeyName())
+}
+
+func (s *AccountsStorage) getPrivateKeyName() string {
+	return path.Join(s.keysPath, s.userID+".key")
+}
+
+func (s *AccountsStorage) GetPrivateKey(keyType certcrypto.KeyType) (crypto.PrivateKey, error) {
+	accKeyPath := s.GetPrivateKeyPath()
+
+	exists, err := storage.Exists(s.backend, s.getPrivateKeyName())
+	if err != nil {
+		return nil, err
+	}
 
-	if _, err := os.Stat(accKeyPath); os.IsNotExist(err) {
+	if !exists {
 		log.Printf("No key found for account %s. Generating a %s key.", s.userID, keyType)
-		s.createKeysFolder()
 
-		privateKey, err := generatePrivateKey(accKeyPath, keyType)
+		privateKey, err := s.generatePrivateKey(keyType)
 		if err != nil {
-			log.Fatalf("Could not generate RSA private account key for account %s: %v", s.userID, err)
+			return nil, fmt.Errorf("could not generate the private account key for account %s: %v", s.userID, err)
 		}
 
 		log.Printf("Saved key to %s", accKeyPath)
-		return privateKey
+		return privateKey, nil
 	}
 
-	privateKey, err := loadPrivateKey(accKeyPath)
+	keyBytes, err := s.readPrivateKey()
 	if err != nil {
-		log.Fatalf("Could not load RSA private key from file %s: %v", accKeyPath, err)
+		return nil, fmt.Errorf("could not load the private key from file %s: %v", accKeyPath, err)
 	}
 
-	return privateKey
-}
+	privateKey, err := parsePrivateKey(keyBytes, s.keyPassphrase)
+	if err != nil {
+		return nil, fmt.Errorf("could not load the private key from file %s: %v", accKeyPath, err)
+	}
+
+	// a passphrase has been defined for an existing account: the plaintext private key is replaced by the encrypted private key.
+	if len(s.keyPassphrase) > 0 && !isEncryptedPrivateKey(keyBytes) {
+		log.Printf("Encrypting the private key of the account %s.", s.userID)
 
-func (s *AccountsStorage) createKeysFolder() {
-	if err := createNonExistingFolder(s.keysPath); err != nil {
-		log.Fatalf("Could not check/create directory for account %s: %v", s.userID, err)
+		err = s.savePrivateKey(privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("could not save the encrypted private key to %s: %v", accKeyPath, err)
+		}
 	}
+
+	return privateKey, nil
 }
 
-func generatePrivateKey(file string, keyType certcrypto.KeyType) (crypto.PrivateKey, error) {
+// generatePrivateKey generates and saves the private key of the account.
+func (s *AccountsStorage) generatePrivateKey(keyType certcrypto.KeyType) (crypto.PrivateKey, error) {
 	privateKey, err := certcrypto.GeneratePrivateKey(keyType)
 	if err != nil {
 		return nil, err
 	}
 
-	certOut, err := os.Create(file)
+	err = s.savePrivateKey(privateKey)
 	if err != nil {
 		return nil, err
 	}
-	defer certOut.Close()
 
-	pemKey := certcrypto.PEMBlock(privateKey)
-	err = pem.Encode(certOut, pemKey)
+	return privateKey, nil
+}
+
+// loadPrivateKey loads the private key of the account.
+func (s *AccountsStorage) loadPrivateKey() (crypto.PrivateKey, error) {
+	keyBytes, err := s.readPrivateKey()
 	if err != nil {
 		return nil, err
 	}
 
-	return privateKey, nil
+	return parsePrivateKey(keyBytes, s.keyPassphrase)
 }
 
-func loadPrivateKey(file string) (crypto.PrivateKey, error) {
-	keyBytes, err := ioutil.ReadFile(file)
-	if err != nil {
-		return nil, err
+// readPrivateKey reads the PEM encoded private key of the account.
+func (s *AccountsStorage) readPrivateKey() ([]byte, error) {
+	keyBytes, err := s.backend.Load(s.getPrivateKeyName())
+	if err == storage.ErrNotFound {
+		return nil, fmt.Errorf("open %s: %v", s.GetPrivateKeyPath(), err)
 	}
 
+	return keyBytes, err
+}
+
+// savePrivateKey saves the private key of the account (PEM), encrypted with the passphrase if defined (encrypted PKCS#8).
+func (s *AccountsStorage) savePrivateKey(privateKey crypto.PrivateKey) error {
+	keyBlock := certcrypto.PEMBlock(privateKey)
+
+	if len(s.keyPassphrase) > 0 {
+		var err error
+		keyBlock, err = pkcs8.EncryptPrivateKey(privateKey, s.keyPassphrase)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.backend.Save(s.getPrivateKeyName(), pem.EncodeToMemory(keyBlock))
+}
+
+// parsePrivateKey parses a PEM encoded private key of an account, the passphrase is required if the private key is encrypted.
+func parsePrivateKey(keyBytes, passphrase []byte) (crypto.PrivateKey, error) {
 	keyBlock, _ := pem.Decode(keyBytes)
+	if keyBlock == nil {
+		return nil, errors.New("invalid PEM block")
+	}
 
 	switch keyBlock.Type {
 	case "RSA PRIVATE KEY":
 		return x509.ParsePKCS1PrivateKey(keyBlock.Bytes)
 	case "EC PRIVATE KEY":
 		return x509.ParseECPrivateKey(keyBlock.Bytes)
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	case pkcs8.PEMType:
+		if len(passphrase) == 0 {
+			return nil, errors.New("the private key is encrypted, a passphrase is required (--account-key.passphrase or --account-key.passphrase-file)")
+		}
+		return pkcs8.DecryptPrivateKey(keyBlock.Bytes, passphrase)
 	}
 
 	return nil, errors.New("unknown private key type")
 }
 
+// isEncryptedPrivateKey returns true if a PEM encoded private key is encrypted.
+func isEncryptedPrivateKey(keyBytes []byte) bool {
+	keyBlock, _ := pem.Decode(keyBytes)
+	return keyBlock != nil && keyBlock.Type == pkcs8.PEMType
+}
+
+// getAccountKeyPassphrase returns the passphrase encrypting the private keys of the accounts (--account-key.passphrase or --account-key.passphrase-file),
+// nil if the private keys are not encrypted.
+func getAccountKeyPassphrase(ctx *cli.Context) ([]byte, error) {
+	passphrase := ctx.GlobalString("account-key.passphrase")
+	filename := ctx.GlobalString("account-key.passphrase-file")
+
+	if passphrase != "" && filename != "" {
+		return nil, errors.New("--account-key.passphrase and --account-key.passphrase-file cannot be used together")
+	}
+
+	if filename == "" {
+		if passphrase == "" {
+			return nil, nil
+		}
+		return []byte(passphrase), nil
+	}
+
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the passphrase of the account keys: %v", err)
+	}
+
+	// the final line break of the file is not a part of the passphrase.
+	content = []byte(strings.TrimRight(string(content), "\r\n"))
+	if len(content) == 0 {
+		return nil, fmt.Errorf("the passphrase file %s is empty", filename)
+	}
+
+	return content, nil
+}
+
 func tryRecoverRegistration(ctx *cli.Context, privateKey crypto.PrivateKey) (*registration.Resource, error) {
 	// couldn't load account but got a key. Try to look the account up.
 	config := lego.NewConfig(&Account{key: privateKey})
-	config.CADirURL = ctx.GlobalString("server")
+	config.CADirURL = getServer(ctx)
 	config.UserAgent = fmt.Sprintf("lego-cli/%s", ctx.App.Version)
 
 	client, err := lego.NewClient(config)
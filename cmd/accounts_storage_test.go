@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-acme/lego/v3/certcrypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountsStorage_GetPrivateKey_passphrase(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-account")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	globalArgs := []string{"--path=" + dir, "--server=https://acme.example.com/directory", "--email=foo@example.com"}
+
+	// an existing account with a plaintext private key.
+	plain, err := NewAccountsStorage(newAccountContext(t, "show", globalArgs, nil))
+	require.NoError(t, err)
+
+	privateKey, err := plain.generatePrivateKey(certcrypto.EC256)
+	require.NoError(t, err)
+
+	keyBytes, err := plain.readPrivateKey()
+	require.NoError(t, err)
+	assert.False(t, isEncryptedPrivateKey(keyBytes))
+
+	// the private key is encrypted when a passphrase is defined.
+	encrypted, err := NewAccountsStorage(newAccountContext(t, "show", append(globalArgs, "--account-key.passphrase=secret"), nil))
+	require.NoError(t, err)
+
+	actual, err := encrypted.GetPrivateKey(certcrypto.EC256)
+	require.NoError(t, err)
+	assert.Equal(t, privateKey, actual)
+
+	keyBytes, err = encrypted.readPrivateKey()
+	require.NoError(t, err)
+	assert.True(t, isEncryptedPrivateKey(keyBytes))
+
+	loaded, err := encrypted.loadPrivateKey()
+	require.NoError(t, err)
+	assert.Equal(t, privateKey, loaded)
+
+	_, err = plain.loadPrivateKey()
+	require.EqualError(t, err, "the private key is encrypted, a passphrase is required (--account-key.passphrase or --account-key.passphrase-file)")
+}
+
+func Test_getAccountKeyPassphrase(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-account")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "passphrase")
+	require.NoError(t, ioutil.WriteFile(filename, []byte("secret\n"), 0600))
+
+	testCases := []struct {
+		desc      string
+		args      []string
+		expected  []byte
+		expectErr string
+	}{
+		{
+			desc: "no passphrase",
+		},
+		{
+			desc:     "passphrase",
+			args:     []string{"--account-key.passphrase=secret"},
+			expected: []byte("secret"),
+		},
+		{
+			desc:     "passphrase file",
+			args:     []string{"--account-key.passphrase-file=" + filename},
+			expected: []byte("secret"),
+		},
+		{
+			desc:      "passphrase and passphrase file",
+			args:      []string{"--account-key.passphrase=secret", "--account-key.passphrase-file=" + filename},
+			expectErr: "--account-key.passphrase and --account-key.passphrase-file cannot be used together",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			passphrase, err := getAccountKeyPassphrase(newAccountContext(t, "show", test.args, nil))
+			if test.expectErr != "" {
+				require.EqualError(t, err, test.expectErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, passphrase)
+		})
+	}
+}
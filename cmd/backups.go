@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v3/log"
+	"github.com/go-acme/lego/v3/storage"
+	"github.com/urfave/cli"
+)
+
+const baseBackupsFolderName = "backups"
+
+func createBackupFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.IntFlag{
+			Name:  "backups",
+			Usage: "Keep this number of backups of the previous files of the certificate (certificate, private key...) on renewal, in the backups directory (0 to not keep backups).",
+		},
+	}
+}
+
+// backupResource copies the current files of a certificate in the backups directory (backups/<domain>/<timestamp>.<file>),
+// then removes the oldest backups to keep only the last backups.
+func (s *CertificatesStorage) backupResource(domain string, keep int) error {
+	safe, err := sanitizedDomain(domain)
+	if err != nil {
+		return err
+	}
+
+	dir := path.Join(baseBackupsFolderName, safe)
+	date := strconv.FormatInt(time.Now().Unix(), 10)
+
+	var saved bool
+	for _, extension := range resourceExtensions {
+		name, err := s.getName(domain, extension)
+		if err != nil {
+			return err
+		}
+
+		data, err := s.backend.Load(name)
+		if err == storage.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		baseName, err := s.archiveBaseName(domain, extension)
+		if err != nil {
+			return err
+		}
+
+		err = s.backend.Save(path.Join(dir, date+"."+baseName), data)
+		if err != nil {
+			return err
+		}
+
+		saved = true
+	}
+
+	if saved {
+		log.Infof("[%s] The previous files of the certificate have been saved in %s", domain, s.backend.Location(dir))
+	}
+
+	return s.pruneBackups(dir, keep)
+}
+
+// pruneBackups removes the files of the oldest backups of a directory to keep only the last backups.
+func (s *CertificatesStorage) pruneBackups(dir string, keep int) error {
+	names, err := s.listFiles(dir, "")
+	if err != nil {
+		return err
+	}
+
+	backups := map[int64][]string{}
+	for _, name := range names {
+		date, err := strconv.ParseInt(strings.SplitN(path.Base(name), ".", 2)[0], 10, 64)
+		if err != nil {
+			// not a backup.
+			continue
+		}
+
+		backups[date] = append(backups[date], name)
+	}
+
+	var dates []int64
+	for date := range backups {
+		dates = append(dates, date)
+	}
+
+	sort.Slice(dates, func(i, j int) bool { return dates[i] > dates[j] })
+
+	for i := keep; i < len(dates); i++ {
+		for _, name := range backups[dates[i]] {
+			err = s.backend.Delete(name)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v3/certificate"
+	"github.com/go-acme/lego/v3/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+func TestCertificatesStorage_backupResource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-backups")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backend := storage.NewFileSystem(dir)
+	certsStorage := &CertificatesStorage{backend: backend}
+
+	// the previous backups.
+	for _, name := range []string{"1.example.com.crt", "1.example.com.key", "2.example.com.crt", "2.example.com.key", "3.example.com.crt", "3.example.com.key"} {
+		require.NoError(t, backend.Save("backups/example.com/"+name, []byte(name)))
+	}
+
+	// the current files.
+	require.NoError(t, certsStorage.WriteFile("example.com", ".crt", []byte("cert")))
+	require.NoError(t, certsStorage.WriteFile("example.com", ".key", []byte("key")))
+	require.NoError(t, certsStorage.WriteFile("example.com", ".json", []byte("{}")))
+
+	now := time.Now().Unix()
+
+	require.NoError(t, certsStorage.backupResource("example.com", 2))
+
+	names, err := backend.List("backups/")
+	require.NoError(t, err)
+
+	// the second may have changed during the backup.
+	date := strconv.FormatInt(now, 10)
+	if exists, _ := storage.Exists(backend, "backups/example.com/"+date+".example.com.crt"); !exists {
+		date = strconv.FormatInt(now+1, 10)
+	}
+
+	expected := []string{
+		"backups/example.com/" + date + ".example.com.crt",
+		"backups/example.com/" + date + ".example.com.json",
+		"backups/example.com/" + date + ".example.com.key",
+		"backups/example.com/3.example.com.crt",
+		"backups/example.com/3.example.com.key",
+	}
+	assert.ElementsMatch(t, expected, names)
+
+	data, err := backend.Load("backups/example.com/" + date + ".example.com.key")
+	require.NoError(t, err)
+	assert.Equal(t, "key", string(data))
+
+	// the current files are kept.
+	exists, err := storage.Exists(backend, "certificates/example.com.key")
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func Test_storeCertificate_backups(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-backups")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	app := cli.NewApp()
+	app.Flags = CreateFlags("")
+
+	// the daemon saves the certificates as renew.
+	command := createDaemon()
+
+	globalSet, err := newFlagSet(app.Name, app.Flags, nil)
+	require.NoError(t, err)
+
+	commandSet, err := newFlagSet(command.Name, command.Flags, []string{"--backups=1"})
+	require.NoError(t, err)
+
+	ctx := cli.NewContext(app, commandSet, cli.NewContext(app, globalSet, nil))
+
+	backend := storage.NewFileSystem(dir)
+	certsStorage := &CertificatesStorage{backend: backend}
+
+	// the current files.
+	require.NoError(t, certsStorage.WriteFile("example.com", ".crt", []byte("previous")))
+
+	certRes := &certificate.Resource{Domain: "example.com", Certificate: []byte("renewed")}
+
+	result, err := storeCertificate(ctx, certsStorage, certRes, statusObtained)
+	require.NoError(t, err)
+
+	assert.Equal(t, statusObtained, result.Status)
+
+	names, err := backend.List("backups/")
+	require.NoError(t, err)
+	require.Len(t, names, 1)
+
+	data, err := backend.Load(names[0])
+	require.NoError(t, err)
+	assert.Equal(t, "previous", string(data))
+
+	data, err = certsStorage.ReadFile("example.com", ".crt")
+	require.NoError(t, err)
+	assert.Equal(t, "renewed", string(data))
+}
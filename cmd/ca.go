@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v3/lego"
+	"github.com/go-acme/lego/v3/log"
+	"github.com/urfave/cli"
+)
+
+// Names of the CA presets.
+const (
+	caLetsEncrypt        = "letsencrypt"
+	caLetsEncryptStaging = "letsencrypt-staging"
+	caZeroSSL            = "zerossl"
+	caBuypass            = "buypass"
+)
+
+// zeroSSLEABURL the endpoint of the ZeroSSL API providing the EAB credentials of an email.
+var zeroSSLEABURL = "https://api.zerossl.com/acme/eab-credentials-email"
+
+// caPreset a CA with a known ACME directory.
+type caPreset struct {
+	directory string
+	// staging the directory of the staging environment, empty if the CA doesn't provide one.
+	staging string
+	// eab the CA requires External Account Binding.
+	eab bool
+	// eabCredentials gets the EAB credentials of an email (optional).
+	eabCredentials func(email string) (string, string, error)
+}
+
+var caPresets = map[string]caPreset{
+	caLetsEncrypt:        {directory: lego.LEDirectoryProduction, staging: lego.LEDirectoryStaging},
+	caLetsEncryptStaging: {directory: lego.LEDirectoryStaging},
+	caZeroSSL:            {directory: "https://acme.zerossl.com/v2/DV90", eab: true, eabCredentials: zeroSSLEABCredentials},
+	caBuypass:            {directory: "https://api.buypass.com/acme/directory", staging: "https://api.test4.buypass.no/acme/directory"},
+}
+
+// caPresetNames returns the sorted names of the CA presets.
+func caPresetNames() []string {
+	var names []string
+	for name := range caPresets {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// getCAPreset returns the CA preset selected by --ca or --staging, nil if the CA is defined by --server.
+// With --dry-run, the staging environment of the CA is used, unless the CA is defined by --server.
+func getCAPreset(ctx *cli.Context) (*caPreset, error) {
+	name := strings.ToLower(ctx.GlobalString("ca"))
+	staging := ctx.GlobalBool("staging") || (isDryRun(ctx) && !ctx.GlobalIsSet("server"))
+
+	if name == "" && !staging {
+		return nil, nil
+	}
+
+	if ctx.GlobalIsSet("server") {
+		return nil, errors.New("--server cannot be used with --ca or --staging")
+	}
+
+	if name == "" {
+		name = caLetsEncrypt
+	}
+
+	preset, ok := caPresets[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported CA: %s (supported: %s)", name, strings.Join(caPresetNames(), ", "))
+	}
+
+	if !staging || name == caLetsEncryptStaging {
+		return &preset, nil
+	}
+
+	if preset.staging == "" {
+		return nil, fmt.Errorf("the CA %s doesn't provide a staging environment", name)
+	}
+
+	preset.directory = preset.staging
+
+	return &preset, nil
+}
+
+// getServer returns the URL of the ACME directory: the directory of the CA preset (--ca, --staging) or --server.
+func getServer(ctx *cli.Context) string {
+	preset, err := getCAPreset(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if preset != nil {
+		return preset.directory
+	}
+
+	return ctx.GlobalString("server")
+}
+
+// requiresEAB returns true if the account must be registered with External Account Binding (--eab or the CA preset).
+func requiresEAB(ctx *cli.Context) bool {
+	if ctx.GlobalBool("eab") {
+		return true
+	}
+
+	preset, err := getCAPreset(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return preset != nil && preset.eab
+}
+
+// getEABCredentials returns the EAB credentials (--kid and --hmac),
+// or the credentials provided by the CA preset for the email if the options are not defined.
+func getEABCredentials(ctx *cli.Context) (string, string, error) {
+	kid := ctx.GlobalString("kid")
+	hmacEncoded := ctx.GlobalString("hmac")
+
+	if kid != "" && hmacEncoded != "" {
+		return kid, hmacEncoded, nil
+	}
+
+	preset, err := getCAPreset(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	if kid != "" || hmacEncoded != "" || preset == nil || preset.eabCredentials == nil {
+		return "", "", errors.New("requires arguments --kid and --hmac")
+	}
+
+	email, err := getEmail(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	log.Printf("Requesting the External Account Binding credentials of %s", email)
+
+	return preset.eabCredentials(email)
+}
+
+// zeroSSLEABCredentials gets the EAB credentials of an email from the ZeroSSL API.
+func zeroSSLEABCredentials(email string) (string, string, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	resp, err := client.PostForm(zeroSSLEABURL, url.Values{"email": {email}})
+	if err != nil {
+		return "", "", fmt.Errorf("zerossl: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Success bool   `json:"success"`
+		KID     string `json:"eab_kid"`
+		HMAC    string `json:"eab_hmac_key"`
+		Error   struct {
+			Code int    `json:"code"`
+			Type string `json:"type"`
+		} `json:"error"`
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return "", "", fmt.Errorf("zerossl: unable to read the EAB credentials (HTTP %d): %v", resp.StatusCode, err)
+	}
+
+	if !result.Success || result.KID == "" || result.HMAC == "" {
+		return "", "", fmt.Errorf("zerossl: unable to get the EAB credentials: %d %s", result.Error.Code, result.Error.Type)
+	}
+
+	return result.KID, result.HMAC, nil
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-acme/lego/v3/lego"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+func Test_getCAPreset(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		args      []string
+		expected  string
+		eab       bool
+		expectErr string
+	}{
+		{
+			desc:     "server",
+			args:     []string{"--server=https://acme.example.com/directory"},
+			expected: "",
+		},
+		{
+			desc:     "staging",
+			args:     []string{"--staging"},
+			expected: lego.LEDirectoryStaging,
+		},
+		{
+			desc:     "letsencrypt",
+			args:     []string{"--ca=letsencrypt"},
+			expected: lego.LEDirectoryProduction,
+		},
+		{
+			desc:     "letsencrypt-staging",
+			args:     []string{"--ca=letsencrypt-staging", "--staging"},
+			expected: lego.LEDirectoryStaging,
+		},
+		{
+			desc:     "buypass staging",
+			args:     []string{"--ca=Buypass", "--staging"},
+			expected: "https://api.test4.buypass.no/acme/directory",
+		},
+		{
+			desc:     "zerossl",
+			args:     []string{"--ca=zerossl"},
+			expected: "https://acme.zerossl.com/v2/DV90",
+			eab:      true,
+		},
+		{
+			desc:      "zerossl staging",
+			args:      []string{"--ca=zerossl", "--staging"},
+			expectErr: "the CA zerossl doesn't provide a staging environment",
+		},
+		{
+			desc:      "unknown",
+			args:      []string{"--ca=foo"},
+			expectErr: "unsupported CA: foo (supported: buypass, letsencrypt, letsencrypt-staging, zerossl)",
+		},
+		{
+			desc:     "dry-run",
+			args:     []string{"--dry-run"},
+			expected: lego.LEDirectoryStaging,
+		},
+		{
+			desc:     "buypass dry-run",
+			args:     []string{"--ca=buypass", "--dry-run"},
+			expected: "https://api.test4.buypass.no/acme/directory",
+		},
+		{
+			desc:     "server dry-run",
+			args:     []string{"--server=https://acme.example.com/directory", "--dry-run"},
+			expected: "",
+		},
+		{
+			desc:      "zerossl dry-run",
+			args:      []string{"--ca=zerossl", "--dry-run"},
+			expectErr: "the CA zerossl doesn't provide a staging environment",
+		},
+		{
+			desc:      "server and staging",
+			args:      []string{"--server=https://acme.example.com/directory", "--staging"},
+			expectErr: "--server cannot be used with --ca or --staging",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			set, err := newFlagSet("lego", CreateFlags(""), test.args)
+			require.NoError(t, err)
+
+			preset, err := getCAPreset(cli.NewContext(cli.NewApp(), set, nil))
+			if test.expectErr != "" {
+				require.EqualError(t, err, test.expectErr)
+				return
+			}
+
+			require.NoError(t, err)
+
+			if test.expected == "" {
+				assert.Nil(t, preset)
+				return
+			}
+
+			require.NotNil(t, preset)
+			assert.Equal(t, test.expected, preset.directory)
+			assert.Equal(t, test.eab, preset.eab)
+		})
+	}
+}
+
+func Test_zeroSSLEABCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.FormValue("email") != "foo@example.com" {
+			_, _ = rw.Write([]byte(`{"success":false,"error":{"code":2900,"type":"invalid_email"}}`))
+			return
+		}
+
+		_, _ = rw.Write([]byte(`{"success":true,"eab_kid":"kid123","eab_hmac_key":"hmac456"}`))
+	}))
+	defer server.Close()
+
+	defer func(u string) { zeroSSLEABURL = u }(zeroSSLEABURL)
+	zeroSSLEABURL = server.URL
+
+	kid, hmacEncoded, err := zeroSSLEABCredentials("foo@example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, "kid123", kid)
+	assert.Equal(t, "hmac456", hmacEncoded)
+
+	_, _, err = zeroSSLEABCredentials("bar@example.com")
+	require.EqualError(t, err, "zerossl: unable to get the EAB credentials: 2900 invalid_email")
+}
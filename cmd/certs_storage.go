@@ -2,18 +2,21 @@ package cmd
 
 import (
 	"bytes"
+	"crypto"
 	"crypto/x509"
 	"encoding/json"
-	"io/ioutil"
-	"os"
-	"path/filepath"
+	"fmt"
+	"path"
+	"sort"
 	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/go-acme/lego/v3/certcrypto"
 	"github.com/go-acme/lego/v3/certificate"
-	"github.com/go-acme/lego/v3/log"
+	"github.com/go-acme/lego/v3/internal/pkcs12"
+	"github.com/go-acme/lego/v3/storage"
 	"github.com/urfave/cli"
 	"golang.org/x/net/idna"
 )
@@ -23,69 +26,113 @@ const (
 	baseArchivesFolderName     = "archives"
 )
 
+// resourceExtensions the extensions of the files of a certificate written by SaveResource.
+var resourceExtensions = []string{".crt", ".issuer.crt", ".key", ".pem", ".haproxy.pem", ".pfx", ".jks", ".p12", ".json"}
+
 // CertificatesStorage a certificates storage.
 //
-// rootPath:
+// The files are stored by the backend of the storage ("storage" option, the "path" folder by default):
 //
-//     ./.lego/certificates/
-//          │      └── root certificates directory
-//          └── "path" option
+//     certificates/
+//          └── certificates directory
 //
-// archivePath:
+//     archives/
+//          └── archived certificates directory
 //
-//     ./.lego/archives/
-//          │      └── archived certificates directory
-//          └── "path" option
+//     backups/
+//          └── backups of the previous files of the certificates (--backups)
 //
+// The certificate, the issuer certificate, the private key, the .pem, the .haproxy.pem, the .pfx and the keystore files can be written elsewhere (--file-layout),
+// in the storage or in the local file system (absolute paths).
+// The metadata (.json) and the record of the names of these files (.layout.json) are always in the certificates directory.
 type CertificatesStorage struct {
-	rootPath    string
-	archivePath string
+	backend     storage.Backend
 	pem         bool
-	filename    string // Deprecated
+	haproxy     bool
+	pfx         bool
+	pfxPassword string
+	pfxFormat   string
+
+	keyStore         string
+	keyStoreAlias    string
+	keyStorePassword string
+
+	layout map[string]*template.Template
+	// layoutNames the names of the files of the certificates being saved with the templates, by domain.
+	layoutNames map[string]map[string]string
+
+	filename string // Deprecated
 }
 
 // NewCertificatesStorage create a new certificates storage.
-func NewCertificatesStorage(ctx *cli.Context) *CertificatesStorage {
-	return &CertificatesStorage{
-		rootPath:    filepath.Join(ctx.GlobalString("path"), baseCertificatesFolderName),
-		archivePath: filepath.Join(ctx.GlobalString("path"), baseArchivesFolderName),
-		pem:         ctx.GlobalBool("pem"),
-		filename:    ctx.GlobalString("filename"),
+func NewCertificatesStorage(ctx *cli.Context) (*CertificatesStorage, error) {
+	layout, err := parseFileLayout(ctx.GlobalStringSlice("file-layout"))
+	if err != nil {
+		return nil, err
 	}
-}
 
-func (s *CertificatesStorage) CreateRootFolder() {
-	err := createNonExistingFolder(s.rootPath)
-	if err != nil {
-		log.Fatalf("Could not check/create path: %v", err)
+	keyStore := ctx.GlobalString("keystore")
+	if keyStore != "" {
+		if _, err = keyStoreExtension(keyStore); err != nil {
+			return nil, err
+		}
 	}
-}
 
-func (s *CertificatesStorage) CreateArchiveFolder() {
-	err := createNonExistingFolder(s.archivePath)
+	backend, err := newStorageBackend(ctx)
 	if err != nil {
-		log.Fatalf("Could not check/create path: %v", err)
+		return nil, err
 	}
+
+	if len(layout) > 0 {
+		backend = newLayoutBackend(backend)
+	}
+
+	return &CertificatesStorage{
+		backend:          backend,
+		pem:              ctx.GlobalBool("pem"),
+		haproxy:          ctx.GlobalBool("haproxy"),
+		pfx:              ctx.GlobalBool("pfx"),
+		pfxPassword:      ctx.GlobalString("pfx-pass"),
+		pfxFormat:        ctx.GlobalString("pfx-format"),
+		keyStore:         keyStore,
+		keyStoreAlias:    ctx.GlobalString("keystore.alias"),
+		keyStorePassword: ctx.GlobalString("keystore.password"),
+		layout:           layout,
+		filename:         ctx.GlobalString("filename"),
+	}, nil
 }
 
+// GetRootPath returns the location of the certificates directory.
 func (s *CertificatesStorage) GetRootPath() string {
-	return s.rootPath
+	return s.backend.Location(baseCertificatesFolderName)
 }
 
-func (s *CertificatesStorage) SaveResource(certRes *certificate.Resource) {
+func (s *CertificatesStorage) SaveResource(certRes *certificate.Resource) error {
 	domain := certRes.Domain
 
+	layoutNames, err := s.newLayoutNames(certRes)
+	if err != nil {
+		return err
+	}
+
+	if layoutNames != nil {
+		if s.layoutNames == nil {
+			s.layoutNames = map[string]map[string]string{}
+		}
+		s.layoutNames[domain] = layoutNames
+	}
+
 	// We store the certificate, private key and metadata in different files
 	// as web servers would not be able to work with a combined file.
-	err := s.WriteFile(domain, ".crt", certRes.Certificate)
+	err = s.WriteFile(domain, ".crt", certRes.Certificate)
 	if err != nil {
-		log.Fatalf("Unable to save Certificate for domain %s\n\t%v", domain, err)
+		return fmt.Errorf("unable to save Certificate for domain %s: %v", domain, err)
 	}
 
 	if certRes.IssuerCertificate != nil {
 		err = s.WriteFile(domain, ".issuer.crt", certRes.IssuerCertificate)
 		if err != nil {
-			log.Fatalf("Unable to save IssuerCertificate for domain %s\n\t%v", domain, err)
+			return fmt.Errorf("unable to save IssuerCertificate for domain %s: %v", domain, err)
 		}
 	}
 
@@ -93,62 +140,96 @@ func (s *CertificatesStorage) SaveResource(certRes *certificate.Resource) {
 		// if we were given a CSR, we don't know the private key
 		err = s.WriteFile(domain, ".key", certRes.PrivateKey)
 		if err != nil {
-			log.Fatalf("Unable to save PrivateKey for domain %s\n\t%v", domain, err)
+			return fmt.Errorf("unable to save PrivateKey for domain %s: %v", domain, err)
 		}
 
 		if s.pem {
 			err = s.WriteFile(domain, ".pem", bytes.Join([][]byte{certRes.Certificate, certRes.PrivateKey}, nil))
 			if err != nil {
-				log.Fatalf("Unable to save Certificate and PrivateKey in .pem for domain %s\n\t%v", domain, err)
+				return fmt.Errorf("unable to save Certificate and PrivateKey in .pem for domain %s: %v", domain, err)
+			}
+		}
+
+		if s.haproxy {
+			err = s.WriteHAProxyFile(domain, certRes)
+			if err != nil {
+				return fmt.Errorf("unable to save the HAProxy .pem file for domain %s: %v", domain, err)
 			}
 		}
-	} else if s.pem {
-		// we don't have the private key; can't write the .pem file
-		log.Fatalf("Unable to save pem without private key for domain %s\n\t%v; are you using a CSR?", domain, err)
+
+		if s.pfx {
+			err = s.WritePFXFile(domain, certRes)
+			if err != nil {
+				return fmt.Errorf("unable to save PFX certificate for domain %s: %v", domain, err)
+			}
+		}
+
+		if s.keyStore != "" {
+			err = s.WriteKeyStoreFile(domain, certRes)
+			if err != nil {
+				return fmt.Errorf("unable to save the keystore for domain %s: %v", domain, err)
+			}
+		}
+	} else if s.pem || s.haproxy || s.pfx || s.keyStore != "" {
+		// we don't have the private key; can't write the .pem, .haproxy.pem, .pfx or keystore files
+		return fmt.Errorf("unable to save pem, haproxy, pfx or keystore without private key for domain %s; are you using a CSR?", domain)
+	}
+
+	if layoutNames != nil {
+		err = s.saveLayoutRecord(domain, layoutNames)
+		if err != nil {
+			return fmt.Errorf("unable to save the names of the files for domain %s: %v", domain, err)
+		}
 	}
 
 	jsonBytes, err := json.MarshalIndent(certRes, "", "\t")
 	if err != nil {
-		log.Fatalf("Unable to marshal CertResource for domain %s\n\t%v", domain, err)
+		return fmt.Errorf("unable to marshal CertResource for domain %s: %v", domain, err)
 	}
 
 	err = s.WriteFile(domain, ".json", jsonBytes)
 	if err != nil {
-		log.Fatalf("Unable to save CertResource for domain %s\n\t%v", domain, err)
+		return fmt.Errorf("unable to save CertResource for domain %s: %v", domain, err)
 	}
+
+	return nil
 }
 
-func (s *CertificatesStorage) ReadResource(domain string) certificate.Resource {
+func (s *CertificatesStorage) ReadResource(domain string) (certificate.Resource, error) {
 	raw, err := s.ReadFile(domain, ".json")
 	if err != nil {
-		log.Fatalf("Error while loading the meta data for domain %s\n\t%v", domain, err)
+		return certificate.Resource{}, fmt.Errorf("error while loading the meta data for domain %s: %v", domain, err)
 	}
 
 	var resource certificate.Resource
 	if err = json.Unmarshal(raw, &resource); err != nil {
-		log.Fatalf("Error while marshaling the meta data for domain %s\n\t%v", domain, err)
+		return certificate.Resource{}, fmt.Errorf("error while marshaling the meta data for domain %s: %v", domain, err)
 	}
 
-	return resource
+	return resource, nil
 }
 
-func (s *CertificatesStorage) ExistsFile(domain, extension string) bool {
-	filename := sanitizedDomain(domain) + extension
-	filePath := filepath.Join(s.rootPath, filename)
-
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return false
-	} else if err != nil {
-		log.Fatal(err)
+func (s *CertificatesStorage) ExistsFile(domain, extension string) (bool, error) {
+	name, err := s.getStoredName(domain, extension)
+	if err != nil {
+		return false, err
 	}
-	return true
+
+	return storage.Exists(s.backend, name)
 }
 
 func (s *CertificatesStorage) ReadFile(domain, extension string) ([]byte, error) {
-	filename := sanitizedDomain(domain) + extension
-	filePath := filepath.Join(s.rootPath, filename)
+	name, err := s.getStoredName(domain, extension)
+	if err != nil {
+		return nil, err
+	}
 
-	return ioutil.ReadFile(filePath)
+	data, err := s.backend.Load(name)
+	if err == storage.ErrNotFound {
+		return nil, fmt.Errorf("open %s: %v", s.backend.Location(name), err)
+	}
+
+	return data, err
 }
 
 func (s *CertificatesStorage) ReadCertificate(domain, extension string) ([]*x509.Certificate, error) {
@@ -162,43 +243,237 @@ func (s *CertificatesStorage) ReadCertificate(domain, extension string) ([]*x509
 }
 
 func (s *CertificatesStorage) WriteFile(domain, extension string, data []byte) error {
-	var baseFileName string
+	name, err := s.getName(domain, extension)
+	if err != nil {
+		return err
+	}
+
+	return s.backend.Save(name, data)
+}
+
+// GetFileName returns the location of the file written for a domain and an extension,
+// an empty string if the name of the file cannot be determined (invalid domain).
+func (s *CertificatesStorage) GetFileName(domain, extension string) string {
+	name, err := s.getName(domain, extension)
+	if err != nil {
+		return ""
+	}
+
+	return s.backend.Location(name)
+}
+
+// getName returns the name in the storage of the file written for a domain and an extension.
+func (s *CertificatesStorage) getName(domain, extension string) (string, error) {
+	name, ok, err := s.layoutName(domain, extension)
+	if ok || err != nil {
+		return name, err
+	}
+
 	if s.filename != "" {
-		baseFileName = s.filename
-	} else {
-		baseFileName = sanitizedDomain(domain)
+		return path.Join(baseCertificatesFolderName, s.filename+extension), nil
 	}
 
-	filePath := filepath.Join(s.rootPath, baseFileName+extension)
+	baseFileName, err := sanitizedDomain(domain)
+	if err != nil {
+		return "", err
+	}
 
-	return ioutil.WriteFile(filePath, data, filePerm)
+	return path.Join(baseCertificatesFolderName, baseFileName+extension), nil
 }
 
-func (s *CertificatesStorage) MoveToArchive(domain string) error {
-	matches, err := filepath.Glob(filepath.Join(s.rootPath, sanitizedDomain(domain)+".*"))
+// getStoredName returns the name in the storage of the file read for a domain and an extension.
+// Unlike getName, the deprecated filename option is ignored.
+func (s *CertificatesStorage) getStoredName(domain, extension string) (string, error) {
+	name, ok, err := s.layoutName(domain, extension)
+	if ok || err != nil {
+		return name, err
+	}
+
+	baseFileName, err := sanitizedDomain(domain)
+	if err != nil {
+		return "", err
+	}
+
+	return path.Join(baseCertificatesFolderName, baseFileName+extension), nil
+}
+
+// WriteHAProxyFile writes the private key, the certificate and the issuer certificates in a single PEM file (.haproxy.pem),
+// in the order expected by HAProxy.
+func (s *CertificatesStorage) WriteHAProxyFile(domain string, certRes *certificate.Resource) error {
+	certificates, err := certcrypto.ParsePEMBundle(certRes.Certificate)
+	if err != nil {
+		return fmt.Errorf("unable to parse the certificate: %v", err)
+	}
+
+	data := [][]byte{certRes.PrivateKey, certRes.Certificate}
+
+	// the certificate is not a bundle (--no-bundle).
+	if len(certificates) == 1 && certRes.IssuerCertificate != nil {
+		data = append(data, certRes.IssuerCertificate)
+	}
+
+	return s.WriteFile(domain, ".haproxy.pem", bytes.Join(data, nil))
+}
+
+// WritePFXFile writes the certificate, the issuer certificates and the private key in a PKCS#12 file (.pfx).
+func (s *CertificatesStorage) WritePFXFile(domain string, certRes *certificate.Resource) error {
+	privateKey, certificates, err := parseResourceChain(certRes)
 	if err != nil {
 		return err
 	}
 
-	for _, oldFile := range matches {
-		date := strconv.FormatInt(time.Now().Unix(), 10)
-		filename := date + "." + filepath.Base(oldFile)
-		newFile := filepath.Join(s.archivePath, filename)
+	encoder, err := pkcs12.NewEncoder(s.pfxFormat)
+	if err != nil {
+		return err
+	}
+
+	pfxBytes, err := encoder.Encode(privateKey, certificates, "", s.pfxPassword)
+	if err != nil {
+		return fmt.Errorf("unable to encode the PFX file: %v", err)
+	}
+
+	return s.WriteFile(domain, ".pfx", pfxBytes)
+}
+
+// parseResourceChain returns the private key and the certificates (the certificate, then the issuer certificates) of a resource.
+func parseResourceChain(certRes *certificate.Resource) (crypto.PrivateKey, []*x509.Certificate, error) {
+	certificates, err := certcrypto.ParsePEMBundle(certRes.Certificate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse the certificate: %v", err)
+	}
+
+	// the certificate is not a bundle (--no-bundle).
+	if len(certificates) == 1 && certRes.IssuerCertificate != nil {
+		caCerts, err := certcrypto.ParsePEMBundle(certRes.IssuerCertificate)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to parse the issuer certificate: %v", err)
+		}
+
+		certificates = append(certificates, caCerts...)
+	}
+
+	privateKey, err := certcrypto.ParsePEMPrivateKey(certRes.PrivateKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse the private key: %v", err)
+	}
+
+	return privateKey, certificates, nil
+}
+
+func (s *CertificatesStorage) MoveToArchive(domain string) error {
+	date := strconv.FormatInt(time.Now().Unix(), 10)
+
+	// the record of the names is moved last: it defines the names of the other files.
+	for _, extension := range append(resourceExtensions, ".key-rotation.json", layoutRecordExtension) {
+		oldName, err := s.getStoredName(domain, extension)
+		if err != nil {
+			return err
+		}
+
+		exists, err := storage.Exists(s.backend, oldName)
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			continue
+		}
+
+		baseName, err := s.archiveBaseName(domain, extension)
+		if err != nil {
+			return err
+		}
 
-		err = os.Rename(oldFile, newFile)
+		err = storage.Move(s.backend, oldName, path.Join(baseArchivesFolderName, date+"."+baseName))
 		if err != nil {
 			return err
 		}
 	}
 
+	delete(s.layoutNames, domain)
+
 	return nil
 }
 
+// listDomains returns the domains of the certificates of the storage (the .crt, the .json and the .layout.json files of the certificates directory),
+// the files of a certificate written elsewhere (--file-layout) are found with the .json and the .layout.json files.
+func (s *CertificatesStorage) listDomains() ([]string, error) {
+	names, err := s.listFiles(baseCertificatesFolderName, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var domains []string
+	seen := map[string]bool{}
+
+	for _, name := range names {
+		base := path.Base(name)
+
+		var domain string
+		switch {
+		case strings.HasSuffix(base, ".issuer.crt"), strings.HasSuffix(base, ".key-rotation.json"):
+			continue
+		case strings.HasSuffix(base, layoutRecordExtension):
+			domain = strings.TrimSuffix(base, layoutRecordExtension)
+		case strings.HasSuffix(base, ".crt"):
+			domain = strings.TrimSuffix(base, ".crt")
+		case strings.HasSuffix(base, ".json"):
+			domain = strings.TrimSuffix(base, ".json")
+		default:
+			continue
+		}
+
+		if !seen[domain] {
+			seen[domain] = true
+			domains = append(domains, domain)
+		}
+	}
+
+	sort.Strings(domains)
+
+	return domains, nil
+}
+
+// listCertificates returns the names of the certificates (.crt files, without the issuer certificates) of a directory of the storage.
+func (s *CertificatesStorage) listCertificates(dir string) ([]string, error) {
+	names, err := s.listFiles(dir, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var certificates []string
+	for _, name := range names {
+		if strings.HasSuffix(name, ".crt") && !strings.HasSuffix(name, ".issuer.crt") {
+			certificates = append(certificates, name)
+		}
+	}
+
+	return certificates, nil
+}
+
+// listFiles returns the names of the files of a directory of the storage (not the subdirectories) starting with a prefix.
+func (s *CertificatesStorage) listFiles(dir, prefix string) ([]string, error) {
+	names, err := s.backend.List(dir + "/" + prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, name := range names {
+		if path.Dir(name) == dir {
+			files = append(files, name)
+		}
+	}
+
+	return files, nil
+}
+
 // sanitizedDomain Make sure no funny chars are in the cert names (like wildcards ;))
-func sanitizedDomain(domain string) string {
+func sanitizedDomain(domain string) (string, error) {
 	safe, err := idna.ToASCII(strings.Replace(domain, "*", "_", -1))
 	if err != nil {
-		log.Fatal(err)
+		return "", fmt.Errorf("invalid domain %s: %v", domain, err)
 	}
-	return safe
+
+	return safe, nil
 }
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-acme/lego/v3/certcrypto"
+	"github.com/go-acme/lego/v3/certificate"
+	"github.com/go-acme/lego/v3/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/pkcs12"
+)
+
+func TestCertificatesStorage_WritePFXFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-pfx")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	cert, err := certcrypto.GeneratePemCert(privateKey, "example.com", nil)
+	require.NoError(t, err)
+
+	issuerKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	issuer, err := certcrypto.GeneratePemCert(issuerKey, "issuer.example.com", nil)
+	require.NoError(t, err)
+
+	certRes := &certificate.Resource{
+		Domain:            "example.com",
+		Certificate:       cert,
+		IssuerCertificate: issuer,
+		PrivateKey:        certcrypto.PEMEncode(privateKey),
+	}
+
+	// the SHA256 format (the default) is not supported by golang.org/x/crypto/pkcs12, it is tested by the internal/pkcs12 package.
+	testCases := []string{"RC2", "DES"}
+
+	for _, format := range testCases {
+		format := format
+		t.Run(format, func(t *testing.T) {
+			certsStorage := &CertificatesStorage{backend: storage.NewFileSystem(dir), pfx: true, pfxPassword: "secret", pfxFormat: format}
+
+			err := certsStorage.WritePFXFile(certRes.Domain, certRes)
+			require.NoError(t, err)
+
+			data, err := ioutil.ReadFile(filepath.Join(dir, baseCertificatesFolderName, "example.com.pfx"))
+			require.NoError(t, err)
+
+			key, certs, _ := decodePFX(t, data, "secret")
+
+			assert.Equal(t, privateKey, key)
+			require.Len(t, certs, 2)
+			assert.Equal(t, []string{"example.com"}, certs[0].DNSNames)
+			assert.Equal(t, []string{"issuer.example.com"}, certs[1].DNSNames)
+		})
+	}
+}
+
+func TestCertificatesStorage_WritePFXFile_defaultFormat(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-pfx")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	cert, err := certcrypto.GeneratePemCert(privateKey, "example.com", nil)
+	require.NoError(t, err)
+
+	certRes := &certificate.Resource{
+		Domain:      "example.com",
+		Certificate: cert,
+		PrivateKey:  certcrypto.PEMEncode(privateKey),
+	}
+
+	certsStorage := &CertificatesStorage{backend: storage.NewFileSystem(dir), pfx: true, pfxPassword: "secret"}
+
+	err = certsStorage.WritePFXFile(certRes.Domain, certRes)
+	require.NoError(t, err)
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, baseCertificatesFolderName, "example.com.pfx"))
+	require.NoError(t, err)
+
+	// the MAC of the default format (PBES2) is SHA-256.
+	_, err = pkcs12.ToPEM(data, "secret")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown digest algorithm: 2.16.840.1.101.3.4.2.1")
+}
+
+func TestCertificatesStorage_WriteHAProxyFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-haproxy")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	cert, err := certcrypto.GeneratePemCert(privateKey, "example.com", nil)
+	require.NoError(t, err)
+
+	issuerKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	issuer, err := certcrypto.GeneratePemCert(issuerKey, "issuer.example.com", nil)
+	require.NoError(t, err)
+
+	key := certcrypto.PEMEncode(privateKey)
+
+	testCases := []struct {
+		desc        string
+		certificate []byte
+	}{
+		{desc: "bundle", certificate: append(append([]byte{}, cert...), issuer...)},
+		{desc: "no bundle", certificate: cert},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			certsStorage := &CertificatesStorage{backend: storage.NewFileSystem(dir), haproxy: true}
+
+			certRes := &certificate.Resource{
+				Domain:            "example.com",
+				Certificate:       test.certificate,
+				IssuerCertificate: issuer,
+				PrivateKey:        key,
+			}
+
+			err := certsStorage.WriteHAProxyFile(certRes.Domain, certRes)
+			require.NoError(t, err)
+
+			data, err := ioutil.ReadFile(filepath.Join(dir, baseCertificatesFolderName, "example.com.haproxy.pem"))
+			require.NoError(t, err)
+
+			expected := string(key) + string(cert) + string(issuer)
+			assert.Equal(t, expected, string(data))
+		})
+	}
+}
+
+func TestCertificatesStorage_SaveResource_withoutPrivateKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-certificates")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	certsStorage := &CertificatesStorage{backend: storage.NewFileSystem(dir), pem: true}
+
+	certRes := &certificate.Resource{Domain: "example.com", Certificate: []byte("cert")}
+
+	err = certsStorage.SaveResource(certRes)
+	require.EqualError(t, err, "unable to save pem, haproxy, pfx or keystore without private key for domain example.com; are you using a CSR?")
+
+	_, err = certsStorage.ReadResource("example.com")
+	require.Error(t, err)
+}
+
+// decodePFX decodes a PKCS#12 file (RC2 or DES format) with a RSA private key,
+// returns the private key, the certificates and the friendly name of the private key.
+func decodePFX(t *testing.T, data []byte, password string) (crypto.PrivateKey, []*x509.Certificate, string) {
+	t.Helper()
+
+	blocks, err := pkcs12.ToPEM(data, password)
+	require.NoError(t, err)
+
+	var privateKey crypto.PrivateKey
+	var certs []*x509.Certificate
+	var friendlyName string
+
+	for _, block := range blocks {
+		switch block.Type {
+		case "PRIVATE KEY":
+			privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+			require.NoError(t, err)
+
+			friendlyName = block.Headers["friendlyName"]
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			require.NoError(t, err)
+
+			certs = append(certs, cert)
+		}
+	}
+
+	return privateKey, certs, friendlyName
+}
@@ -8,7 +8,13 @@ func CreateCommands() []cli.Command {
 		createRun(),
 		createRevoke(),
 		createRenew(),
+		createDaemon(),
 		createDNSHelp(),
+		createDNS(),
 		createList(),
+		createAccount(),
+		createOCSP(),
+		createCheck(),
+		createInit(),
 	}
 }
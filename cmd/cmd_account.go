@@ -0,0 +1,559 @@
+package cmd
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v3/log"
+	"github.com/go-acme/lego/v3/registration"
+	"github.com/go-acme/lego/v3/storage"
+	"github.com/urfave/cli"
+	"golang.org/x/crypto/scrypt"
+	jose "gopkg.in/square/go-jose.v2"
+)
+
+// accountExportVersion the version of the format of the exported accounts.
+const accountExportVersion = 1
+
+// Parameters of the derivation of the encryption key (scrypt).
+const (
+	exportScryptN    = 1 << 15
+	exportScryptR    = 8
+	exportScryptP    = 1
+	exportKeyLength  = 32
+	exportSaltLength = 16
+	exportKDFScrypt  = "scrypt"
+)
+
+// accountExport the content of an exported account.
+type accountExport struct {
+	Server       string                 `json:"server"`
+	Email        string                 `json:"email"`
+	Registration *registration.Resource `json:"registration"`
+	PrivateKey   string                 `json:"privateKey"`
+	ExportedAt   time.Time              `json:"exportedAt"`
+}
+
+// encryptedAccountFile the file of an exported account: the account encrypted with AES-256-GCM,
+// the encryption key is derived from the password with scrypt.
+type encryptedAccountFile struct {
+	Version int    `json:"version"`
+	KDF     string `json:"kdf"`
+	N       int    `json:"n"`
+	R       int    `json:"r"`
+	P       int    `json:"p"`
+	Salt    []byte `json:"salt"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+func createAccount() cli.Command {
+	passwordFlag := cli.StringFlag{
+		Name:   "password",
+		Usage:  "The password used to encrypt the account file.",
+		EnvVar: "LEGO_ACCOUNT_PASSWORD",
+	}
+
+	return cli.Command{
+		Name:  "account",
+		Usage: "Manage the accounts",
+		Subcommands: []cli.Command{
+			{
+				Name:   "show",
+				Usage:  "Display the account designated by --email and --server (registration, contact, key thumbprint)",
+				Action: accountShowAction,
+			},
+			{
+				Name:   "update",
+				Usage:  "Update the email (contact) of the account designated by --email and --server",
+				Action: accountUpdateAction,
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "email",
+						Usage: "The new email of the account, the account is moved to the folder of the new email.",
+					},
+				},
+			},
+			{
+				Name:   "export",
+				Usage:  "Export an account (private key and registration) to an encrypted file",
+				Action: accountExportAction,
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "file, f",
+						Usage: "The file of the exported account.",
+					},
+					passwordFlag,
+				},
+			},
+			{
+				Name:   "import",
+				Usage:  "Import an account from an encrypted file created by 'account export'",
+				Action: accountImportAction,
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "file, f",
+						Usage: "The file of the exported account.",
+					},
+					passwordFlag,
+					cli.BoolFlag{
+						Name:  "overwrite",
+						Usage: "Replace the account if it already exists.",
+					},
+				},
+			},
+		},
+	}
+}
+
+func accountShowAction(ctx *cli.Context) error {
+	accountsStorage, err := NewAccountsStorage(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	account, err := readStoredAccount(accountsStorage)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	details, err := newAccountDetails(accountsStorage, account, getServer(ctx))
+	if err != nil {
+		log.Fatalf("Could not read the private key of the account %s: %v", accountsStorage.GetUserID(), err)
+	}
+
+	if isJSONOutput(ctx) {
+		return printJSON(details)
+	}
+
+	fmt.Println("Account:", details.Email)
+	fmt.Println("  Server:", details.Server)
+	fmt.Println("  URL:", details.URL)
+	fmt.Println("  Status:", details.Status)
+	fmt.Println("  Contact:", strings.Join(details.Contact, ", "))
+	fmt.Println("  Key type:", details.KeyType)
+	fmt.Println("  Key thumbprint:", details.Thumbprint)
+	fmt.Println("  Path:", details.Path)
+
+	return nil
+}
+
+func accountUpdateAction(ctx *cli.Context) error {
+	email := ctx.String("email")
+	if email == "" {
+		log.Fatal("Please specify the new email of the account with --email")
+	}
+
+	accountsStorage, err := NewAccountsStorage(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	account, err := readStoredAccount(accountsStorage)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if account.Registration == nil || account.Registration.URI == "" {
+		log.Fatalf("The account %s is not registered.", accountsStorage.GetUserID())
+	}
+
+	newStorage, err := newAccountsStorage(ctx, getServer(ctx), email)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if email != accountsStorage.GetUserID() {
+		exists, err := newStorage.ExistsAccountFilePath()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if exists {
+			log.Fatalf("Account %s already exists.", email)
+		}
+	}
+
+	account.Email = email
+
+	keyType, err := getKeyType(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := newClient(ctx, account, keyType)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	reg, err := client.Registration.UpdateRegistration()
+	if err != nil {
+		log.Fatalf("Could not update the account %s: %v", accountsStorage.GetUserID(), err)
+	}
+
+	account.Registration = reg
+
+	if email != accountsStorage.GetUserID() {
+		err = moveAccount(accountsStorage, newStorage)
+		if err != nil {
+			log.Fatalf("The account has been updated, but could not be moved to %s: %v", newStorage.GetRootUserPath(), err)
+		}
+	}
+
+	err = newStorage.Save(account)
+	if err != nil {
+		log.Fatalf("Could not save the account %s: %v", email, err)
+	}
+
+	log.Printf("The account %s has been updated: %s", email, strings.Join(reg.Body.Contact, ", "))
+
+	return nil
+}
+
+// accountDetails the information of an account (JSON output of account show).
+type accountDetails struct {
+	Email      string   `json:"email"`
+	Server     string   `json:"server"`
+	URL        string   `json:"url,omitempty"`
+	Status     string   `json:"status,omitempty"`
+	Contact    []string `json:"contact,omitempty"`
+	KeyType    string   `json:"keyType"`
+	Thumbprint string   `json:"thumbprint"`
+	Path       string   `json:"path"`
+}
+
+func newAccountDetails(accountsStorage *AccountsStorage, account *Account, server string) (*accountDetails, error) {
+	thumbprint, err := keyThumbprint(account.key)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &accountDetails{
+		Email:      account.Email,
+		Server:     server,
+		KeyType:    keyTypeName(account.key),
+		Thumbprint: thumbprint,
+		Path:       accountsStorage.GetRootUserPath(),
+	}
+
+	if account.Registration != nil {
+		details.URL = account.Registration.URI
+		details.Status = account.Registration.Body.Status
+		details.Contact = account.Registration.Body.Contact
+	}
+
+	return details, nil
+}
+
+// readStoredAccount reads the account and its private key, without recovering the registration.
+func readStoredAccount(accountsStorage *AccountsStorage) (*Account, error) {
+	exists, err := accountsStorage.ExistsAccountFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("account %s does not exist", accountsStorage.GetUserID())
+	}
+
+	data, err := accountsStorage.backend.Load(accountsStorage.accountFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not load file for account %s -> %v", accountsStorage.GetUserID(), err)
+	}
+
+	var account Account
+	err = json.Unmarshal(data, &account)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse file for account %s -> %v", accountsStorage.GetUserID(), err)
+	}
+
+	account.key, err = accountsStorage.loadPrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("could not load the private key of the account %s -> %v", accountsStorage.GetUserID(), err)
+	}
+
+	return &account, nil
+}
+
+// moveAccount moves the files of an account to the folder of another email, the private key is renamed after the email.
+func moveAccount(from, to *AccountsStorage) error {
+	names, err := from.backend.List(from.rootUserPath + "/")
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		newName := to.rootUserPath + strings.TrimPrefix(name, from.rootUserPath)
+		if name == from.getPrivateKeyName() {
+			newName = to.getPrivateKeyName()
+		}
+
+		err = storage.Move(from.backend, name, newName)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// keyThumbprint returns the JWK thumbprint (RFC 7638) of the public key of a private key.
+func keyThumbprint(privateKey crypto.PrivateKey) (string, error) {
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return "", errors.New("unsupported private key type")
+	}
+
+	thumbprint, err := (&jose.JSONWebKey{Key: signer.Public()}).Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(thumbprint), nil
+}
+
+// keyTypeName returns the name of the type of a private key (as --key-type).
+func keyTypeName(privateKey crypto.PrivateKey) string {
+	switch key := privateKey.(type) {
+	case *ecdsa.PrivateKey:
+		return fmt.Sprintf("ec%d", key.Curve.Params().BitSize)
+	case *rsa.PrivateKey:
+		return fmt.Sprintf("rsa%d", key.N.BitLen())
+	default:
+		return "unknown"
+	}
+}
+
+func accountExportAction(ctx *cli.Context) error {
+	filename, password := accountFileOptions(ctx)
+
+	accountsStorage, err := NewAccountsStorage(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	exists, err := accountsStorage.ExistsAccountFilePath()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if !exists {
+		log.Fatalf("Account %s does not exist.", accountsStorage.GetUserID())
+	}
+
+	data, err := accountsStorage.backend.Load(accountsStorage.accountFilePath)
+	if err != nil {
+		log.Fatalf("Could not load file for account %s -> %v", accountsStorage.GetUserID(), err)
+	}
+
+	var account Account
+	err = json.Unmarshal(data, &account)
+	if err != nil {
+		log.Fatalf("Could not parse file for account %s -> %v", accountsStorage.GetUserID(), err)
+	}
+
+	keyBytes, err := accountsStorage.readPrivateKey()
+	if err != nil {
+		log.Fatalf("Could not load the private key of the account %s -> %v", accountsStorage.GetUserID(), err)
+	}
+
+	export := &accountExport{
+		Server:       getServer(ctx),
+		Email:        accountsStorage.GetUserID(),
+		Registration: account.Registration,
+		PrivateKey:   string(keyBytes),
+		ExportedAt:   time.Now().UTC(),
+	}
+
+	content, err := encryptAccount(export, password)
+	if err != nil {
+		log.Fatalf("Could not encrypt the account %s: %v", accountsStorage.GetUserID(), err)
+	}
+
+	err = ioutil.WriteFile(filename, content, filePerm)
+	if err != nil {
+		log.Fatalf("Could not write the account file: %v", err)
+	}
+
+	log.Printf("The account %s (%s) has been exported to %s", export.Email, export.Server, filename)
+
+	return nil
+}
+
+func accountImportAction(ctx *cli.Context) error {
+	filename, password := accountFileOptions(ctx)
+
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		log.Fatalf("Could not read the account file: %v", err)
+	}
+
+	export, err := decryptAccount(content, password)
+	if err != nil {
+		log.Fatalf("Could not decrypt the account file %s: %v", filename, err)
+	}
+
+	accountsStorage, err := newAccountsStorage(ctx, export.Server, export.Email)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	exists, err := accountsStorage.ExistsAccountFilePath()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if exists && !ctx.Bool("overwrite") {
+		log.Fatalf("Account %s (%s) already exists. Use --overwrite to replace it.", export.Email, export.Server)
+	}
+
+	privateKey, err := parsePrivateKey([]byte(export.PrivateKey), accountsStorage.keyPassphrase)
+	if err != nil {
+		log.Fatalf("Invalid private key for account %s: %v", export.Email, err)
+	}
+
+	err = accountsStorage.savePrivateKey(privateKey)
+	if err != nil {
+		log.Fatalf("Could not save the private key of the account %s: %v", export.Email, err)
+	}
+
+	err = accountsStorage.Save(&Account{Email: export.Email, Registration: export.Registration})
+	if err != nil {
+		log.Fatalf("Could not save the account %s: %v", export.Email, err)
+	}
+
+	log.Printf("The account %s (%s) has been imported to %s", export.Email, export.Server, accountsStorage.GetRootUserPath())
+
+	return nil
+}
+
+// accountFileOptions returns the file and the password of an account export, both are required.
+func accountFileOptions(ctx *cli.Context) (string, string) {
+	filename := ctx.String("file")
+	if filename == "" {
+		log.Fatal("Please specify --file/-f")
+	}
+
+	password := ctx.String("password")
+	if password == "" {
+		log.Fatal("Please specify --password (or the environment variable LEGO_ACCOUNT_PASSWORD)")
+	}
+
+	return filename, password
+}
+
+// encryptAccount encrypts an exported account with a password.
+func encryptAccount(export *accountExport, password string) ([]byte, error) {
+	plaintext, err := json.Marshal(export)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &encryptedAccountFile{
+		Version: accountExportVersion,
+		KDF:     exportKDFScrypt,
+		N:       exportScryptN,
+		R:       exportScryptR,
+		P:       exportScryptP,
+		Salt:    make([]byte, exportSaltLength),
+	}
+
+	if _, err = io.ReadFull(rand.Reader, file.Salt); err != nil {
+		return nil, err
+	}
+
+	aead, err := newAccountCipher(file, password)
+	if err != nil {
+		return nil, err
+	}
+
+	file.Nonce = make([]byte, aead.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, file.Nonce); err != nil {
+		return nil, err
+	}
+
+	file.Data = aead.Seal(nil, file.Nonce, plaintext, nil)
+
+	return json.MarshalIndent(file, "", "\t")
+}
+
+// decryptAccount decrypts an exported account with a password.
+func decryptAccount(content []byte, password string) (*accountExport, error) {
+	var file encryptedAccountFile
+	err := json.Unmarshal(content, &file)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account file: %v", err)
+	}
+
+	if file.Version != accountExportVersion {
+		return nil, fmt.Errorf("unsupported account file version: %d", file.Version)
+	}
+
+	aead, err := newAccountCipher(&file, password)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(file.Nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid account file: invalid nonce")
+	}
+
+	plaintext, err := aead.Open(nil, file.Nonce, file.Data, nil)
+	if err != nil {
+		return nil, errors.New("wrong password or corrupted file")
+	}
+
+	var export accountExport
+	err = json.Unmarshal(plaintext, &export)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account: %v", err)
+	}
+
+	if export.Server == "" || export.Email == "" || export.PrivateKey == "" {
+		return nil, errors.New("invalid account: the server, the email and the private key are required")
+	}
+
+	return &export, nil
+}
+
+// newAccountCipher creates the AES-256-GCM cipher with the key derived from the password.
+func newAccountCipher(file *encryptedAccountFile, password string) (cipher.AEAD, error) {
+	if file.KDF != exportKDFScrypt {
+		return nil, fmt.Errorf("unsupported key derivation function: %s", file.KDF)
+	}
+
+	// the parameters come from the imported file: higher values than the exported ones could exhaust the memory or the CPU.
+	if file.N > exportScryptN || file.R > exportScryptR || file.P > exportScryptP {
+		return nil, fmt.Errorf("unsupported scrypt parameters: N=%d, r=%d, p=%d (maximum: N=%d, r=%d, p=%d)",
+			file.N, file.R, file.P, exportScryptN, exportScryptR, exportScryptP)
+	}
+
+	key, err := scrypt.Key([]byte(password), file.Salt, file.N, file.R, file.P, exportKeyLength)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return aead, nil
+}
@@ -6,16 +6,38 @@ import (
 )
 
 func Before(ctx *cli.Context) error {
+	err := setupOutput(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = setupLogging(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = loadDomainsFile(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = checkKubernetesTemplates(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	if len(ctx.GlobalString("path")) == 0 {
 		log.Fatal("Could not determine current working directory. Please pass --path.")
 	}
 
-	err := createNonExistingFolder(ctx.GlobalString("path"))
-	if err != nil {
-		log.Fatalf("Could not check/create path: %v", err)
+	if isFileSystemStorage(ctx) {
+		err = createNonExistingFolder(ctx.GlobalString("path"))
+		if err != nil {
+			log.Fatalf("Could not check/create path: %v", err)
+		}
 	}
 
-	if len(ctx.GlobalString("server")) == 0 {
+	if len(getServer(ctx)) == 0 {
 		log.Fatal("Could not determine current working server. Please pass --server.")
 	}
 
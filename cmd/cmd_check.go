@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+)
+
+// Nagios states of the check command, the value is the exit code.
+const (
+	checkOK       = 0
+	checkWarning  = 1
+	checkCritical = 2
+	checkUnknown  = 3
+)
+
+var checkStateNames = map[int]string{
+	checkOK:       "OK",
+	checkWarning:  "WARNING",
+	checkCritical: "CRITICAL",
+	checkUnknown:  "UNKNOWN",
+}
+
+// checkStateSeverity the order of the states to compute the state of the check: CRITICAL > UNKNOWN > WARNING > OK.
+var checkStateSeverity = map[int]int{
+	checkOK:       0,
+	checkWarning:  1,
+	checkUnknown:  2,
+	checkCritical: 3,
+}
+
+// checkResult the result of the check command (JSON output).
+type checkResult struct {
+	State        string             `json:"state"`
+	Certificates []checkCertificate `json:"certificates"`
+}
+
+// checkCertificate the expiration of a certificate.
+type checkCertificate struct {
+	Name          string     `json:"name"`
+	Source        string     `json:"source"`
+	State         string     `json:"state"`
+	NotAfter      *time.Time `json:"notAfter,omitempty"`
+	DaysRemaining *int       `json:"daysRemaining,omitempty"`
+	Error         string     `json:"error,omitempty"`
+
+	state int
+}
+
+func createCheck() cli.Command {
+	return cli.Command{
+		Name:   "check",
+		Usage:  "Check the expiration of the certificates (Nagios compatible)",
+		Action: check,
+		Description: "Checks the certificates designated by --domains/-d (all the certificates of the storage by default) and the certificates of the servers (--remote).\n" +
+			"   The exit code is the Nagios state: 0 OK, 1 WARNING, 2 CRITICAL, 3 UNKNOWN (the certificate cannot be read).",
+		Flags: []cli.Flag{
+			cli.IntFlag{
+				Name:  "warning, w",
+				Usage: "The number of days left on a certificate to return the WARNING state.",
+				Value: 21,
+			},
+			cli.IntFlag{
+				Name:  "critical, c",
+				Usage: "The number of days left on a certificate to return the CRITICAL state.",
+				Value: 7,
+			},
+			cli.StringSliceFlag{
+				Name:  "remote",
+				Usage: "Check the certificate presented by a server (TLS). Supported: host:port or host (port 443). Can be specified multiple times.",
+			},
+			cli.DurationFlag{
+				Name:  "remote.timeout",
+				Usage: "The timeout of the TLS connection to a server.",
+				Value: 10 * time.Second,
+			},
+		},
+	}
+}
+
+func check(ctx *cli.Context) error {
+	if ctx.Int("critical") > ctx.Int("warning") {
+		return cli.NewExitError("the critical threshold must be lower than the warning threshold", checkUnknown)
+	}
+
+	var certificates []checkCertificate
+
+	// the storage is checked if domains are defined or if no servers are defined.
+	if len(ctx.GlobalStringSlice("domains")) > 0 || len(ctx.StringSlice("remote")) == 0 {
+		certificates = append(certificates, checkStoredCertificates(ctx)...)
+	}
+
+	for _, remote := range ctx.StringSlice("remote") {
+		certificates = append(certificates, checkRemoteCertificate(ctx, remote))
+	}
+
+	state := checkState(certificates)
+
+	if isJSONOutput(ctx) {
+		result := checkResult{State: checkStateNames[state], Certificates: certificates}
+		if result.Certificates == nil {
+			result.Certificates = []checkCertificate{}
+		}
+
+		if err := printJSON(result); err != nil {
+			return cli.NewExitError(err.Error(), checkUnknown)
+		}
+	} else {
+		fmt.Print(checkReport(state, certificates, ctx.Int("warning"), ctx.Int("critical")))
+	}
+
+	if state == checkOK {
+		return nil
+	}
+
+	return cli.NewExitError("", state)
+}
+
+// checkStoredCertificates checks the certificates of the storage.
+func checkStoredCertificates(ctx *cli.Context) []checkCertificate {
+	certsStorage, err := NewCertificatesStorage(ctx)
+	if err != nil {
+		return []checkCertificate{{Source: "storage", Error: err.Error(), state: checkUnknown}}
+	}
+
+	domains := ctx.GlobalStringSlice("domains")
+	if len(domains) == 0 {
+		infos, err := readCertificates(ctx)
+		if err != nil {
+			return []checkCertificate{{Name: certsStorage.GetRootPath(), Source: "storage", Error: err.Error(), state: checkUnknown}}
+		}
+
+		for _, info := range infos {
+			domains = append(domains, info.domain)
+		}
+	}
+
+	var certificates []checkCertificate
+	for _, domain := range domains {
+		result := checkCertificate{Name: domain, Source: certsStorage.GetFileName(domain, ".crt")}
+
+		certs, err := certsStorage.ReadCertificate(domain, ".crt")
+		if err != nil {
+			result.Error = err.Error()
+			result.state = checkUnknown
+		} else {
+			result.setCertificate(certs[0], ctx.Int("warning"), ctx.Int("critical"))
+		}
+
+		result.State = checkStateNames[result.state]
+		certificates = append(certificates, result)
+	}
+
+	return certificates
+}
+
+// checkRemoteCertificate checks the certificate presented by a server.
+func checkRemoteCertificate(ctx *cli.Context, remote string) checkCertificate {
+	address := remote
+	if _, _, err := net.SplitHostPort(remote); err != nil {
+		address = net.JoinHostPort(remote, "443")
+	}
+
+	result := checkCertificate{Name: remote, Source: "tls://" + address}
+
+	cert, err := fetchRemoteCertificate(address, ctx.Duration("remote.timeout"))
+	if err != nil {
+		result.Error = err.Error()
+		result.state = checkUnknown
+	} else {
+		result.setCertificate(cert, ctx.Int("warning"), ctx.Int("critical"))
+	}
+
+	result.State = checkStateNames[result.state]
+
+	return result
+}
+
+// fetchRemoteCertificate returns the certificate presented by a server.
+// The certificate is not verified: the expiration of an invalid certificate is also checked.
+func fetchRemoteCertificate(address string, timeout time.Duration) (*x509.Certificate, error) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+
+	conn, err := tls.DialWithDialer(dialer, "tcp", address, &tls.Config{
+		ServerName:         host,
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	peerCertificates := conn.ConnectionState().PeerCertificates
+	if len(peerCertificates) == 0 {
+		return nil, fmt.Errorf("no certificate presented by %s", address)
+	}
+
+	return peerCertificates[0], nil
+}
+
+// setCertificate sets the expiration and the state of a certificate.
+func (c *checkCertificate) setCertificate(cert *x509.Certificate, warning, critical int) {
+	notAfter := cert.NotAfter.UTC()
+	days := daysRemaining(notAfter)
+
+	c.NotAfter = &notAfter
+	c.DaysRemaining = &days
+
+	switch {
+	case days <= critical || time.Now().After(notAfter):
+		c.state = checkCritical
+	case days <= warning:
+		c.state = checkWarning
+	default:
+		c.state = checkOK
+	}
+}
+
+// checkState returns the most severe state of the certificates, UNKNOWN if there are no certificates.
+func checkState(certificates []checkCertificate) int {
+	if len(certificates) == 0 {
+		return checkUnknown
+	}
+
+	state := checkOK
+	for _, c := range certificates {
+		if checkStateSeverity[c.state] > checkStateSeverity[state] {
+			state = c.state
+		}
+	}
+
+	return state
+}
+
+// checkReport creates the Nagios report: the status line with the performance data, then a line per certificate.
+func checkReport(state int, certificates []checkCertificate, warning, critical int) string {
+	var summary string
+
+	switch {
+	case len(certificates) == 0:
+		summary = "no certificates found"
+	case state == checkOK:
+		summary = fmt.Sprintf("%d certificate(s) valid for more than %d days", len(certificates), warning)
+	default:
+		var problems []string
+		for _, c := range certificates {
+			if c.state == checkOK {
+				continue
+			}
+
+			if c.Error != "" {
+				problems = append(problems, fmt.Sprintf("%s: %s", c.Name, c.Error))
+			} else {
+				problems = append(problems, fmt.Sprintf("%s expires in %d days", c.Name, *c.DaysRemaining))
+			}
+		}
+
+		summary = strings.Join(problems, ", ")
+	}
+
+	var perfData []string
+	for _, c := range certificates {
+		if c.DaysRemaining != nil {
+			perfData = append(perfData, fmt.Sprintf("'%s'=%d;%d;%d", c.Name, *c.DaysRemaining, warning, critical))
+		}
+	}
+
+	report := fmt.Sprintf("CERTIFICATES %s - %s", checkStateNames[state], summary)
+	if len(perfData) > 0 {
+		report += " | " + strings.Join(perfData, " ")
+	}
+	report += "\n"
+
+	for _, c := range certificates {
+		if c.Error != "" {
+			report += fmt.Sprintf("%s: %s (%s): %s\n", c.State, c.Name, c.Source, c.Error)
+			continue
+		}
+
+		report += fmt.Sprintf("%s: %s (%s) expires on %s (%d days remaining)\n", c.State, c.Name, c.Source, c.NotAfter.Format(time.RFC3339), *c.DaysRemaining)
+	}
+
+	return report
+}
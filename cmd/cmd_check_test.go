@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_checkCertificate_setCertificate(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		notAfter time.Time
+		expected int
+	}{
+		{desc: "ok", notAfter: time.Now().Add(30*24*time.Hour + time.Hour), expected: checkOK},
+		{desc: "warning", notAfter: time.Now().Add(21*24*time.Hour + time.Hour), expected: checkWarning},
+		{desc: "critical", notAfter: time.Now().Add(7*24*time.Hour + time.Hour), expected: checkCritical},
+		{desc: "expired", notAfter: time.Now().Add(-time.Hour), expected: checkCritical},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			c := checkCertificate{}
+			c.setCertificate(&x509.Certificate{NotAfter: test.notAfter}, 21, 7)
+
+			assert.Equal(t, test.expected, c.state)
+		})
+	}
+}
+
+func Test_checkState(t *testing.T) {
+	assert.Equal(t, checkUnknown, checkState(nil))
+	assert.Equal(t, checkOK, checkState([]checkCertificate{{state: checkOK}}))
+	assert.Equal(t, checkWarning, checkState([]checkCertificate{{state: checkOK}, {state: checkWarning}}))
+	assert.Equal(t, checkUnknown, checkState([]checkCertificate{{state: checkUnknown}, {state: checkWarning}}))
+	assert.Equal(t, checkCritical, checkState([]checkCertificate{{state: checkCritical}, {state: checkUnknown}}))
+}
+
+func Test_checkReport(t *testing.T) {
+	notAfter := time.Date(2020, time.June, 1, 12, 0, 0, 0, time.UTC)
+	days := 5
+
+	certificates := []checkCertificate{
+		{Name: "example.com", Source: "/tmp/example.com.crt", State: "CRITICAL", NotAfter: &notAfter, DaysRemaining: &days, state: checkCritical},
+		{Name: "example.org:8443", Source: "tls://example.org:8443", State: "UNKNOWN", Error: "connection refused", state: checkUnknown},
+	}
+
+	expected := `CERTIFICATES CRITICAL - example.com expires in 5 days, example.org:8443: connection refused | 'example.com'=5;21;7
+CRITICAL: example.com (/tmp/example.com.crt) expires on 2020-06-01T12:00:00Z (5 days remaining)
+UNKNOWN: example.org:8443 (tls://example.org:8443): connection refused
+`
+
+	assert.Equal(t, expected, checkReport(checkCritical, certificates, 21, 7))
+}
+
+func Test_fetchRemoteCertificate(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	notAfter := time.Now().Add(10 * 24 * time.Hour).UTC().Truncate(time.Second)
+
+	cert := createTestCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}, nil, key, key)
+
+	server := httptest.NewUnstartedServer(http.NotFoundHandler())
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{cert.Raw}, PrivateKey: key}}}
+	server.StartTLS()
+	defer server.Close()
+
+	remote, err := fetchRemoteCertificate(strings.TrimPrefix(server.URL, "https://"), 5*time.Second)
+	require.NoError(t, err)
+
+	assert.Equal(t, notAfter, remote.NotAfter)
+}
@@ -0,0 +1,407 @@
+package cmd
+
+import (
+	"crypto"
+	"crypto/x509"
+	"fmt"
+	"math/big"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/cenkalti/backoff/v3"
+	"github.com/go-acme/lego/v3/certcrypto"
+	"github.com/go-acme/lego/v3/certificate"
+	"github.com/go-acme/lego/v3/lego"
+	"github.com/go-acme/lego/v3/log"
+	"github.com/urfave/cli"
+)
+
+func createDaemon() cli.Command {
+	return cli.Command{
+		Name:  "daemon",
+		Usage: "Obtain a certificate, then keep running to renew it before its expiration",
+		Before: func(ctx *cli.Context) error {
+			if len(ctx.GlobalStringSlice("domains")) == 0 && !ctx.GlobalIsSet("manifest") && !isServiceControl(ctx) {
+				log.Fatal("Please specify --domains/-d (or --manifest)")
+			}
+			if isDryRun(ctx) {
+				log.Fatal("The daemon doesn't support --dry-run, use 'run' or 'renew'.")
+			}
+			return nil
+		},
+		Action: daemon,
+		Flags: append([]cli.Flag{
+			cli.IntFlag{
+				Name:  "days",
+				Value: 30,
+				Usage: "The number of days left on a certificate to renew it.",
+			},
+			cli.DurationFlag{
+				Name:  "jitter",
+				Value: 12 * time.Hour,
+				Usage: "The maximum delay subtracted from the renewal date to spread the renewals (the delay is derived from the serial number of the certificate).",
+			},
+			cli.DurationFlag{
+				Name:  "check-interval",
+				Value: 24 * time.Hour,
+				Usage: "The maximum waiting time between two checks of the certificate.",
+			},
+			cli.DurationFlag{
+				Name:  "max-backoff",
+				Value: 6 * time.Hour,
+				Usage: "The maximum waiting time between two attempts after a failure.",
+			},
+			cli.BoolFlag{
+				Name:  "ari-disable",
+				Usage: "Do not use the renewal information (ARI) of the CA: only the number of days left on the certificate is used to renew it.",
+			},
+			cli.BoolFlag{
+				Name:  "reuse-key",
+				Usage: "Used to indicate you want to reuse your current private key for the new certificate.",
+			},
+			cli.BoolFlag{
+				Name:  "no-bundle",
+				Usage: "Do not create a certificate bundle by adding the issuers certificate to the new certificate.",
+			},
+			cli.BoolFlag{
+				Name:  "must-staple",
+				Usage: "Include the OCSP must staple TLS extension in the CSR and generated certificate.",
+			},
+			cli.StringFlag{
+				Name:  "renew-hook",
+				Usage: "Define a hook. The hook is executed only when the certificates are effectively obtained or renewed.",
+			},
+			cli.DurationFlag{
+				Name:  "renew-hook.timeout",
+				Usage: "The maximum execution time of the renew-hook.",
+				Value: 30 * time.Second,
+			},
+		}, append(append(append(createKeyRotationFlags(), createBackupFlags()...), createHookFlags()...), createServiceFlags()...)...),
+	}
+}
+
+func daemon(ctx *cli.Context) error {
+	if handled, err := handleService(ctx); handled {
+		return err
+	}
+
+	stop := make(chan struct{})
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		sig := <-signals
+		log.Printf("Received %s, stopping.", sig)
+		close(stop)
+	}()
+
+	return runDaemon(ctx, stop)
+}
+
+// runDaemon obtains and renews the certificates until the stop channel is closed.
+// Each certificate has its own schedule, the renewals are not concurrent.
+func runDaemon(ctx *cli.Context, stop <-chan struct{}) error {
+	certs, err := newDaemonCertificates(ctx)
+	if err != nil {
+		return err
+	}
+
+	mu := &sync.Mutex{}
+	wg := &sync.WaitGroup{}
+
+	for _, cert := range certs {
+		wg.Add(1)
+		go func(cert *daemonCertificate) {
+			defer wg.Done()
+			cert.run(mu, stop)
+		}(cert)
+	}
+
+	wg.Wait()
+
+	return nil
+}
+
+// daemonCertificate a certificate kept renewed by the daemon.
+type daemonCertificate struct {
+	ctx          *cli.Context
+	client       *lego.Client
+	certsStorage *CertificatesStorage
+	// entry the entry of the manifest of the certificate, its environment is applied during the renewals.
+	entry *manifestEntry
+}
+
+// newDaemonCertificates creates the certificate defined by the command line, or the certificates of the manifest (--manifest).
+func newDaemonCertificates(ctx *cli.Context) ([]*daemonCertificate, error) {
+	if !ctx.GlobalIsSet("manifest") {
+		cert, err := newDaemonCertificate(ctx, &manifestEntry{})
+		if err != nil {
+			return nil, err
+		}
+
+		return []*daemonCertificate{cert}, nil
+	}
+
+	manifest, err := readManifest(ctx.GlobalString("manifest"))
+	if err != nil {
+		return nil, err
+	}
+
+	var certs []*daemonCertificate
+	for i, options := range manifest.Certificates {
+		entry, err := newManifestEntry(ctx.App.Flags, ctx.Command.Flags, manifest.Global, options)
+		if err != nil {
+			return nil, fmt.Errorf("manifest: certificate #%d: %v", i+1, err)
+		}
+
+		certCtx, err := entry.newContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("manifest: certificate #%d: %v", i+1, err)
+		}
+
+		if len(certCtx.GlobalStringSlice("domains")) == 0 {
+			return nil, fmt.Errorf("manifest: certificate #%d: domains are required", i+1)
+		}
+
+		// the DNS providers read their credentials from the environment when they are created.
+		restore := entry.setEnv()
+		cert, err := newDaemonCertificate(certCtx, entry)
+		restore()
+
+		if err != nil {
+			return nil, fmt.Errorf("manifest: certificate #%d: %v", i+1, err)
+		}
+
+		certs = append(certs, cert)
+	}
+
+	return certs, nil
+}
+
+// newDaemonCertificate creates the client and the storage of a certificate, and registers the account if needed.
+func newDaemonCertificate(ctx *cli.Context, entry *manifestEntry) (*daemonCertificate, error) {
+	accountsStorage, err := NewAccountsStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	account, client, err := setup(ctx, accountsStorage)
+	if err != nil {
+		return nil, err
+	}
+
+	err = setupChallenges(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Registration == nil {
+		err = registerAccount(ctx, client, account, accountsStorage)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	certsStorage, err := NewCertificatesStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &daemonCertificate{ctx: ctx, client: client, certsStorage: certsStorage, entry: entry}, nil
+}
+
+// run renews the certificate until the stop channel is closed, the failed attempts are retried with an exponential backoff.
+// The mutex is held during the renewals.
+func (c *daemonCertificate) run(mu sync.Locker, stop <-chan struct{}) {
+	domain := c.ctx.GlobalStringSlice("domains")[0]
+
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = time.Minute
+	bo.MaxInterval = c.ctx.Duration("max-backoff")
+	bo.MaxElapsedTime = 0
+
+	for {
+		next, err := c.renew(mu)
+		if err != nil {
+			wait := bo.NextBackOff()
+			log.Printf("[%s] Could not obtain the certificate, next attempt in %s:\n\t%v", domain, wait, err)
+			next = time.Now().Add(wait)
+
+			notifyDaemonFailure(c.ctx, c.certsStorage, domain, err)
+		} else {
+			bo.Reset()
+			log.Infof("[%s] Next check of the certificate at %s", domain, next.Format(time.RFC3339))
+		}
+
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-timer.C:
+		case <-stop:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+func (c *daemonCertificate) renew(mu sync.Locker) (time.Time, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	restore := c.entry.setEnv()
+	defer restore()
+
+	return daemonRenew(c.ctx, c.client, c.certsStorage)
+}
+
+// daemonRenew obtains the certificate if it doesn't exist or if it must be renewed,
+// and returns the date of the next check.
+func daemonRenew(ctx *cli.Context, client *lego.Client, certsStorage *CertificatesStorage) (time.Time, error) {
+	domains := ctx.GlobalStringSlice("domains")
+	domain := domains[0]
+
+	exists, err := certsStorage.ExistsFile(domain, ".crt")
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var certDomains []string
+	var privateKey crypto.PrivateKey
+	var rotation *keyRotation
+
+	if exists {
+		certificates, err := certsStorage.ReadCertificate(domain, ".crt")
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		cert := certificates[0]
+
+		renewAt := renewalTime(cert, ctx.Int("days"), ctx.Duration("jitter"))
+
+		// the renewal suggested by the CA (ARI) is used if it's before the next check.
+		ariRenewAt := ariRenewalTime(ctx, client, cert, domain, ctx.Duration("check-interval"))
+		if ariRenewAt != nil && ariRenewAt.Before(renewAt) {
+			renewAt = *ariRenewAt
+		}
+
+		if time.Now().Before(renewAt) {
+			return nextCheck(renewAt, ctx.Duration("check-interval")), nil
+		}
+
+		// This is just meant to be informal for the user.
+		timeLeft := cert.NotAfter.Sub(time.Now().UTC())
+		log.Infof("[%s] acme: Trying renewal with %d hours remaining", domain, int(timeLeft.Hours()))
+
+		certDomains = certcrypto.ExtractDomains(cert)
+
+		rotation, err = newKeyRotation(ctx, certsStorage, domain)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		privateKey = rotation.privateKey
+	}
+
+	request := certificate.ObtainRequest{
+		Domains:    merge(certDomains, domains),
+		Bundle:     !ctx.Bool("no-bundle"),
+		PrivateKey: privateKey,
+		MustStaple: ctx.Bool("must-staple"),
+	}
+
+	certRes, err := obtainWithHooks(ctx, newRequestHookMeta(request.Domains), func() (*certificate.Resource, error) {
+		return client.Certificate.Obtain(request)
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if rotation != nil {
+		if err = rotation.commit(certRes.PrivateKey); err != nil {
+			log.Warnf("[%s] Unable to save the state of the private key: %v", domain, err)
+		}
+	}
+
+	result, err := storeCertificate(ctx, certsStorage, certRes, statusObtained)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	notify(ctx, notifyEventSuccess, result)
+
+	err = launchPostHooks(ctx, result)
+	if err != nil {
+		log.Printf("[%s] The hook has failed: %v", domain, err)
+	}
+
+	cert, err := certcrypto.ParsePEMCertificate(certRes.Certificate)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	renewAt := renewalTime(cert, ctx.Int("days"), ctx.Duration("jitter"))
+	if !renewAt.After(time.Now()) {
+		log.Printf("[%s] The lifetime of the certificate is shorter than the number of days defined to perform the renewal.", domain)
+		return time.Now().Add(ctx.Duration("check-interval")), nil
+	}
+
+	return nextCheck(renewAt, ctx.Duration("check-interval")), nil
+}
+
+// notifyDaemonFailure sends the failure notification,
+// and the expiry notification if the current certificate expires soon.
+func notifyDaemonFailure(ctx *cli.Context, certsStorage *CertificatesStorage, domain string, cause error) {
+	notify(ctx, notifyEventFailure, &certificateResult{
+		Domain:  domain,
+		Domains: ctx.GlobalStringSlice("domains"),
+		Status:  statusFailed,
+		Error:   cause.Error(),
+	})
+
+	exists, err := certsStorage.ExistsFile(domain, ".crt")
+	if err != nil || !exists {
+		return
+	}
+
+	certificates, err := certsStorage.ReadCertificate(domain, ".crt")
+	if err != nil {
+		return
+	}
+
+	notifyExpiry(ctx, newStoredCertificateResult(certsStorage, domain, certificates[0], statusFailed))
+}
+
+// renewalTime returns the date of the renewal of a certificate:
+// the number of days before the expiration, minus a delay lower than the jitter.
+// The delay is derived from the serial number to be the same between two checks and two executions.
+func renewalTime(cert *x509.Certificate, days int, jitter time.Duration) time.Time {
+	renewAt := cert.NotAfter.Add(-time.Duration(days) * 24 * time.Hour)
+
+	if jitter <= 0 || cert.SerialNumber == nil {
+		return renewAt
+	}
+
+	delay := new(big.Int).Mod(new(big.Int).Abs(cert.SerialNumber), big.NewInt(int64(jitter)))
+
+	return renewAt.Add(-time.Duration(delay.Int64()))
+}
+
+// nextCheck returns the date of the renewal, limited by the check interval.
+func nextCheck(renewAt time.Time, interval time.Duration) time.Time {
+	now := time.Now()
+
+	if renewAt.Before(now) {
+		return now
+	}
+
+	if interval > 0 && renewAt.After(now.Add(interval)) {
+		return now.Add(interval)
+	}
+
+	return renewAt
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+func Test_renewalTime(t *testing.T) {
+	notAfter := time.Date(2020, time.March, 31, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc     string
+		serial   *big.Int
+		days     int
+		jitter   time.Duration
+		expected time.Time
+	}{
+		{
+			desc:     "no jitter",
+			serial:   big.NewInt(123),
+			days:     30,
+			expected: time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:     "jitter",
+			serial:   big.NewInt(int64(90 * time.Minute)),
+			days:     30,
+			jitter:   time.Hour,
+			expected: time.Date(2020, time.February, 29, 23, 30, 0, 0, time.UTC),
+		},
+		{
+			desc:     "no serial",
+			days:     10,
+			jitter:   time.Hour,
+			expected: time.Date(2020, time.March, 21, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			cert := &x509.Certificate{NotAfter: notAfter, SerialNumber: test.serial}
+
+			assert.Equal(t, test.expected, renewalTime(cert, test.days, test.jitter))
+		})
+	}
+}
+
+func Test_nextCheck(t *testing.T) {
+	now := time.Now()
+
+	next := nextCheck(now.Add(-time.Hour), 24*time.Hour)
+	assert.WithinDuration(t, now, next, time.Minute)
+
+	next = nextCheck(now.Add(time.Hour), 24*time.Hour)
+	assert.Equal(t, now.Add(time.Hour), next)
+
+	next = nextCheck(now.Add(48*time.Hour), 24*time.Hour)
+	assert.WithinDuration(t, now.Add(24*time.Hour), next, time.Minute)
+}
+
+func Test_newDaemonCertificates_manifest(t *testing.T) {
+	file, err := ioutil.TempFile("", "lego-manifest-*.toml")
+	require.NoError(t, err)
+	defer func() { _ = os.Remove(file.Name()) }()
+
+	_, err = file.WriteString("[[certificates]]\n  csr = \"./example.csr\"\n")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	app := cli.NewApp()
+	app.Flags = CreateFlags("")
+
+	command := createDaemon()
+
+	globalSet, err := newFlagSet(app.Name, app.Flags, []string{"--path=/tmp/lego", "--manifest=" + file.Name()})
+	require.NoError(t, err)
+
+	commandSet, err := newFlagSet(command.Name, command.Flags, nil)
+	require.NoError(t, err)
+
+	ctx := cli.NewContext(app, commandSet, cli.NewContext(app, globalSet, nil))
+	ctx.Command = command
+
+	_, err = newDaemonCertificates(ctx)
+	require.EqualError(t, err, "manifest: certificate #1: domains are required")
+}
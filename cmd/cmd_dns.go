@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-acme/lego/v3/challenge"
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/apidebug"
+	"github.com/go-acme/lego/v3/providers/dns"
+	"github.com/urfave/cli"
+)
+
+// Status of the steps of the check of a DNS provider.
+const (
+	dnsCheckOK      = "ok"
+	dnsCheckFailed  = "failed"
+	dnsCheckSkipped = "skipped"
+)
+
+// dnsCheckResult the result of the check of a DNS provider (JSON output).
+type dnsCheckResult struct {
+	Provider string         `json:"provider"`
+	Domain   string         `json:"domain,omitempty"`
+	OK       bool           `json:"ok"`
+	Steps    []dnsCheckStep `json:"steps"`
+}
+
+// dnsCheckStep the result of a step of the check.
+type dnsCheckStep struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	// Hint what is probably wrong when the step has failed.
+	Hint string `json:"hint,omitempty"`
+}
+
+// dnsErrorHints the hints associated to the messages of the errors returned by the APIs of the DNS providers.
+// The first matching hint is used.
+var dnsErrorHints = []struct {
+	patterns []string
+	hint     string
+}{
+	{
+		patterns: []string{"401", "unauthorized", "unauthenticated", "authentication", "invalid token", "invalid api key", "invalid credentials"},
+		hint:     "the credentials are rejected by the API: check their values, and that they are not expired or revoked",
+	},
+	{
+		patterns: []string{"403", "forbidden", "permission", "not authorized", "access denied", "insufficient"},
+		hint:     "the credentials are accepted but lack a permission: check the permissions (scopes) of the credentials",
+	},
+	{
+		patterns: []string{"zone", "404", "not found"},
+		hint:     "the zone of the domain is not found: check that the zone is managed by the account of the credentials",
+	},
+	{
+		patterns: []string{"no such host", "connection refused", "timeout"},
+		hint:     "the API cannot be reached: check the network and the endpoint of the provider",
+	},
+}
+
+func createDNS() cli.Command {
+	return cli.Command{
+		Name:  "dns",
+		Usage: "Manage the DNS providers",
+		Subcommands: []cli.Command{
+			{
+				Name:   "check",
+				Usage:  "Check the credentials of a DNS provider",
+				Action: dnsCheck,
+				Description: "Creates the DNS provider from the environment variables, checks the credentials with a harmless authenticated request (if supported by the provider),\n" +
+					"   then creates and removes a test TXT record for --domain.",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "dns",
+						Usage: "The DNS provider to check (the global option --dns by default). Run 'lego dnshelp' for help on usage.",
+					},
+					cli.StringFlag{
+						Name:  "domain",
+						Usage: "The domain of the test TXT record (_acme-challenge.<domain>), the test record is skipped if not defined.",
+					},
+				},
+			},
+		},
+	}
+}
+
+func dnsCheck(ctx *cli.Context) error {
+	name := ctx.String("dns")
+	if name == "" {
+		name = ctx.GlobalString("dns")
+	}
+
+	if name == "" {
+		return cli.NewExitError("the DNS provider is required (--dns)", 1)
+	}
+
+	if name == "manual" {
+		return cli.NewExitError("the manual DNS provider cannot be checked", 1)
+	}
+
+	apidebug.Install()
+
+	result := checkDNSProvider(name, ctx.String("domain"), dns.NewDNSChallengeProviderByName)
+
+	if isJSONOutput(ctx) {
+		if err := printJSON(result); err != nil {
+			return err
+		}
+	} else {
+		fmt.Print(dnsCheckReport(result))
+	}
+
+	if result.OK {
+		return nil
+	}
+
+	return cli.NewExitError("", 1)
+}
+
+// checkDNSProvider creates the DNS provider, checks its credentials, then creates and removes a test TXT record.
+func checkDNSProvider(name, domain string, newProvider func(name string) (challenge.Provider, error)) *dnsCheckResult {
+	result := &dnsCheckResult{Provider: name, Domain: domain, OK: true}
+
+	addStep := func(step dnsCheckStep) {
+		if step.Status == dnsCheckFailed {
+			result.OK = false
+		}
+
+		result.Steps = append(result.Steps, step)
+	}
+
+	missing, defined := lookupDNSCredentials(name)
+
+	provider, err := newProvider(name)
+	if err != nil {
+		step := dnsCheckStep{Name: "configuration", Status: dnsCheckFailed, Detail: err.Error()}
+		if len(missing) > 0 {
+			step.Hint = fmt.Sprintf("the environment variables %s are not defined, run 'lego dnshelp -c %s' for help on usage", strings.Join(missing, ", "), name)
+		}
+
+		addStep(step)
+
+		return result
+	}
+
+	step := dnsCheckStep{Name: "configuration", Status: dnsCheckOK}
+	if len(defined) > 0 {
+		step.Detail = "credentials: " + strings.Join(defined, ", ")
+	}
+	addStep(step)
+
+	if checker, ok := provider.(challenge.ProviderCheck); ok {
+		if err = checker.CheckCredentials(); err != nil {
+			addStep(dnsCheckStep{Name: "authentication", Status: dnsCheckFailed, Detail: err.Error(), Hint: dnsErrorHint(err)})
+			return result
+		}
+
+		addStep(dnsCheckStep{Name: "authentication", Status: dnsCheckOK})
+	} else {
+		addStep(dnsCheckStep{Name: "authentication", Status: dnsCheckSkipped, Detail: "not supported by the provider, checked by the test record"})
+	}
+
+	if domain == "" {
+		addStep(dnsCheckStep{Name: "record", Status: dnsCheckSkipped, Detail: "no domain (--domain)"})
+		return result
+	}
+
+	keyAuth, err := newDNSCheckKeyAuth()
+	if err != nil {
+		addStep(dnsCheckStep{Name: "record", Status: dnsCheckFailed, Detail: err.Error()})
+		return result
+	}
+
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	if err = provider.Present(domain, "", keyAuth); err != nil {
+		addStep(dnsCheckStep{Name: "record", Status: dnsCheckFailed, Detail: err.Error(), Hint: dnsErrorHint(err)})
+		return result
+	}
+
+	addStep(dnsCheckStep{Name: "record", Status: dnsCheckOK, Detail: "TXT record created: " + fqdn})
+
+	if err = provider.CleanUp(domain, "", keyAuth); err != nil {
+		addStep(dnsCheckStep{
+			Name:   "cleanup",
+			Status: dnsCheckFailed,
+			Detail: err.Error(),
+			Hint:   fmt.Sprintf("the TXT record %s must be removed manually; %s", fqdn, dnsErrorHint(err)),
+		})
+
+		return result
+	}
+
+	addStep(dnsCheckStep{Name: "cleanup", Status: dnsCheckOK, Detail: "TXT record removed: " + fqdn})
+
+	return result
+}
+
+// lookupDNSCredentials returns the environment variables of the credentials of a DNS provider which are not defined, and the defined ones.
+// A variable is also defined by the file variant (<name>_FILE).
+func lookupDNSCredentials(name string) (missing, defined []string) {
+	for _, key := range dnsCredentials(name) {
+		if os.Getenv(key) != "" || os.Getenv(key+"_FILE") != "" {
+			defined = append(defined, key)
+		} else {
+			missing = append(missing, key)
+		}
+	}
+
+	return missing, defined
+}
+
+// dnsErrorHint returns what is probably wrong according to the message of an error.
+func dnsErrorHint(err error) string {
+	msg := strings.ToLower(err.Error())
+
+	for _, h := range dnsErrorHints {
+		for _, pattern := range h.patterns {
+			if strings.Contains(msg, pattern) {
+				return h.hint
+			}
+		}
+	}
+
+	return "check the credentials and their permissions to edit the DNS records of the zone"
+}
+
+// newDNSCheckKeyAuth creates a random value for the test TXT record.
+func newDNSCheckKeyAuth() (string, error) {
+	b := make([]byte, 16)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return "lego-dns-check-" + hex.EncodeToString(b), nil
+}
+
+// dnsCheckReport returns the text report of the check of a DNS provider.
+func dnsCheckReport(result *dnsCheckResult) string {
+	b := &strings.Builder{}
+
+	for _, step := range result.Steps {
+		_, _ = fmt.Fprintf(b, "[%-7s] %s", step.Status, step.Name)
+		if step.Detail != "" {
+			_, _ = fmt.Fprintf(b, ": %s", step.Detail)
+		}
+		b.WriteString("\n")
+
+		if step.Hint != "" {
+			_, _ = fmt.Fprintf(b, "          -> %s\n", step.Hint)
+		}
+	}
+
+	if result.OK {
+		_, _ = fmt.Fprintf(b, "The DNS provider %s is correctly configured.\n", result.Provider)
+	} else {
+		_, _ = fmt.Fprintf(b, "The DNS provider %s is not correctly configured.\n", result.Provider)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/go-acme/lego/v3/challenge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDNSProvider struct {
+	checkErr   error
+	presentErr error
+	cleanUpErr error
+
+	presented bool
+	cleaned   bool
+}
+
+func (p *fakeDNSProvider) Present(domain, token, keyAuth string) error {
+	p.presented = true
+	return p.presentErr
+}
+
+func (p *fakeDNSProvider) CleanUp(domain, token, keyAuth string) error {
+	p.cleaned = true
+	return p.cleanUpErr
+}
+
+type fakeDNSProviderCheck struct {
+	fakeDNSProvider
+}
+
+func (p *fakeDNSProviderCheck) CheckCredentials() error {
+	return p.checkErr
+}
+
+func Test_checkDNSProvider(t *testing.T) {
+	provider := &fakeDNSProviderCheck{}
+
+	result := checkDNSProvider("digitalocean", "example.com", func(string) (challenge.Provider, error) {
+		return provider, nil
+	})
+
+	assert.True(t, result.OK)
+	require.Len(t, result.Steps, 4)
+
+	for i, name := range []string{"configuration", "authentication", "record", "cleanup"} {
+		assert.Equal(t, name, result.Steps[i].Name)
+		assert.Equal(t, dnsCheckOK, result.Steps[i].Status)
+	}
+
+	assert.True(t, provider.presented)
+	assert.True(t, provider.cleaned)
+}
+
+func Test_checkDNSProvider_configuration(t *testing.T) {
+	defer func(value string, ok bool) {
+		if ok {
+			_ = os.Setenv("DO_AUTH_TOKEN", value)
+		} else {
+			_ = os.Unsetenv("DO_AUTH_TOKEN")
+		}
+	}(os.LookupEnv("DO_AUTH_TOKEN"))
+
+	_ = os.Unsetenv("DO_AUTH_TOKEN")
+
+	result := checkDNSProvider("digitalocean", "example.com", func(string) (challenge.Provider, error) {
+		return nil, errors.New("digitalocean: some credentials information are missing: DO_AUTH_TOKEN")
+	})
+
+	assert.False(t, result.OK)
+	require.Len(t, result.Steps, 1)
+	assert.Equal(t, dnsCheckFailed, result.Steps[0].Status)
+	assert.Equal(t, "the environment variables DO_AUTH_TOKEN are not defined, run 'lego dnshelp -c digitalocean' for help on usage", result.Steps[0].Hint)
+}
+
+func Test_checkDNSProvider_authentication(t *testing.T) {
+	provider := &fakeDNSProviderCheck{fakeDNSProvider{checkErr: errors.New("digitalocean: HTTP 401: unauthorized: Unable to authenticate you.")}}
+
+	result := checkDNSProvider("digitalocean", "example.com", func(string) (challenge.Provider, error) {
+		return provider, nil
+	})
+
+	assert.False(t, result.OK)
+	require.Len(t, result.Steps, 2)
+	assert.Equal(t, dnsCheckFailed, result.Steps[1].Status)
+	assert.Equal(t, dnsErrorHints[0].hint, result.Steps[1].Hint)
+
+	// the record is not created with invalid credentials.
+	assert.False(t, provider.presented)
+}
+
+func Test_checkDNSProvider_record(t *testing.T) {
+	provider := &fakeDNSProvider{presentErr: errors.New("foo: HTTP 403: Forbidden")}
+
+	result := checkDNSProvider("foo", "example.com", func(string) (challenge.Provider, error) {
+		return provider, nil
+	})
+
+	assert.False(t, result.OK)
+	require.Len(t, result.Steps, 3)
+	assert.Equal(t, dnsCheckSkipped, result.Steps[1].Status)
+	assert.Equal(t, dnsCheckFailed, result.Steps[2].Status)
+	assert.Equal(t, dnsErrorHints[1].hint, result.Steps[2].Hint)
+	assert.False(t, provider.cleaned)
+}
+
+func Test_checkDNSProvider_noDomain(t *testing.T) {
+	provider := &fakeDNSProvider{}
+
+	result := checkDNSProvider("foo", "", func(string) (challenge.Provider, error) {
+		return provider, nil
+	})
+
+	assert.True(t, result.OK)
+	require.Len(t, result.Steps, 3)
+	assert.Equal(t, dnsCheckSkipped, result.Steps[2].Status)
+	assert.False(t, provider.presented)
+}
+
+func Test_dnsErrorHint(t *testing.T) {
+	testCases := []struct {
+		err      string
+		expected string
+	}{
+		{err: "HTTP 401: unauthorized", expected: dnsErrorHints[0].hint},
+		{err: "API error: Forbidden", expected: dnsErrorHints[1].hint},
+		{err: "could not find zone for domain", expected: dnsErrorHints[2].hint},
+		{err: "dial tcp: lookup api.example.com: no such host", expected: dnsErrorHints[3].hint},
+		{err: "foo", expected: "check the credentials and their permissions to edit the DNS records of the zone"},
+	}
+
+	for _, test := range testCases {
+		assert.Equal(t, test.expected, dnsErrorHint(errors.New(test.err)), test.err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"text/tabwriter"
 
+	"github.com/go-acme/lego/v3/providers/dns"
 	"github.com/urfave/cli"
 )
 
@@ -39,6 +40,13 @@ func dnsHelp(ctx *cli.Context) error {
 		ew.writeln("All DNS codes:")
 		ew.writef("\t%s\n", allDNSCodes())
 		ew.writeln()
+
+		if registered := dns.RegisteredProviders(); len(registered) > 0 {
+			ew.writeln("Third-party DNS codes:")
+			ew.writef("\t%s\n", strings.Join(registered, ", "))
+			ew.writeln()
+		}
+
 		ew.writeln("More information: https://go-acme.github.io/lego/dns")
 
 		if ew.err != nil {
@@ -48,6 +56,13 @@ func dnsHelp(ctx *cli.Context) error {
 		return w.Flush()
 	}
 
+	for _, name := range dns.RegisteredProviders() {
+		if name == code {
+			fmt.Printf("%q is a third-party DNS provider, see its documentation for the configuration.\n", code)
+			return nil
+		}
+	}
+
 	return displayDNSHelp(strings.ToLower(code))
 }
 
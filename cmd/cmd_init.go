@@ -0,0 +1,508 @@
+package cmd
+
+import (
+	"bufio"
+	"crypto"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/go-acme/lego/v3/certcrypto"
+	"github.com/go-acme/lego/v3/lego"
+	"github.com/go-acme/lego/v3/providers/dns"
+	"github.com/go-acme/lego/v3/registration"
+	"github.com/urfave/cli"
+)
+
+// Challenges proposed by the init command.
+const (
+	initChallengeHTTP = "http"
+	initChallengeTLS  = "tls"
+	initChallengeDNS  = "dns"
+)
+
+var initKeyTypes = []string{"rsa2048", "rsa4096", "rsa8192", "ec256", "ec384"}
+
+func createInit() cli.Command {
+	return cli.Command{
+		Name:   "init",
+		Usage:  "Create a manifest interactively",
+		Action: initManifest,
+		Description: "Asks for the email, the CA, the domains, the key type, the challenge and the credentials of the DNS provider,\n" +
+			"   validates them (the CA and the challenge are set up, no account is registered and no certificate is requested),\n" +
+			"   then writes a manifest used by the next runs: lego --manifest=lego.toml run",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "file, f",
+				Usage: "The manifest file to create.",
+				Value: "lego.toml",
+			},
+			cli.BoolFlag{
+				Name:  "overwrite",
+				Usage: "Replace an existing manifest.",
+			},
+		},
+	}
+}
+
+func initManifest(ctx *cli.Context) error {
+	filename := ctx.String("file")
+
+	if _, err := os.Stat(filename); err == nil && !ctx.Bool("overwrite") {
+		return fmt.Errorf("the manifest %s already exists, use --overwrite to replace it", filename)
+	}
+
+	p := newPrompt(os.Stdin, os.Stdout)
+
+	manifest, err := initWizard(p)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println("Validating the configuration...")
+
+	err = validateInitManifest(ctx.App, manifest)
+	if err != nil {
+		fmt.Printf("The configuration is not valid: %v\n", err)
+
+		write, errP := p.askBool("Write the manifest anyway?", false)
+		if errP != nil {
+			return errP
+		}
+
+		if !write {
+			return errors.New("the manifest has not been written")
+		}
+	} else {
+		fmt.Println("The configuration is valid.")
+	}
+
+	err = writeManifest(filename, manifest)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("The manifest has been written to %s, to obtain the certificate:\n", filename)
+	fmt.Printf("\tlego --manifest=%s run\n", filename)
+
+	return nil
+}
+
+// initWizard asks the options of the manifest.
+// The options of the account are global, the options of the certificate are in the first certificate.
+func initWizard(p *prompt) (*Manifest, error) {
+	global := make(map[string]interface{})
+	cert := make(map[string]interface{})
+
+	email, err := p.askRequired("Email used for registration and recovery contact", func(value string) error {
+		if !strings.Contains(value, "@") {
+			return errors.New("invalid email address")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	global["email"] = email
+
+	err = askCA(p, global)
+	if err != nil {
+		return nil, err
+	}
+
+	domains, err := p.askRequired("Domains of the certificate (comma separated)", nil)
+	if err != nil {
+		return nil, err
+	}
+	cert["domains"] = splitList(domains)
+
+	keyType, err := p.askChoice("Key type", initKeyTypes, "ec384")
+	if err != nil {
+		return nil, err
+	}
+	global["key-type"] = keyType
+
+	err = askChallenge(p, cert)
+	if err != nil {
+		return nil, err
+	}
+
+	accepted, err := p.askBool("Do you accept the terms of service of the CA?", true)
+	if err != nil {
+		return nil, err
+	}
+	global["accept-tos"] = accepted
+
+	return &Manifest{Global: global, Certificates: []map[string]interface{}{cert}}, nil
+}
+
+// askCA asks the CA: a preset (and its staging environment) or the URL of an ACME directory.
+func askCA(p *prompt, global map[string]interface{}) error {
+	ca, err := p.askRequired(fmt.Sprintf("CA (%s, or the URL of an ACME directory)", strings.Join(caPresetNames(), ", ")), func(value string) error {
+		if _, ok := caPresets[strings.ToLower(value)]; ok {
+			return nil
+		}
+
+		u, errU := url.Parse(value)
+		if errU != nil || u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("unsupported CA: %s", value)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	preset, ok := caPresets[strings.ToLower(ca)]
+	if !ok {
+		global["server"] = ca
+	} else {
+		global["ca"] = strings.ToLower(ca)
+
+		if preset.staging != "" {
+			staging, errS := p.askBool("Use the staging environment (to test the configuration)?", false)
+			if errS != nil {
+				return errS
+			}
+
+			if staging {
+				global["staging"] = true
+			}
+		}
+	}
+
+	question := "External Account Binding key identifier (empty if not required)"
+	if preset.eab && preset.eabCredentials != nil {
+		question = "External Account Binding key identifier (empty to request the credentials of the email)"
+	}
+
+	kid, err := p.ask(question, "")
+	if err != nil {
+		return err
+	}
+
+	if kid == "" {
+		return nil
+	}
+
+	hmacEncoded, err := p.askRequired("External Account Binding HMAC key", nil)
+	if err != nil {
+		return err
+	}
+
+	global["eab"] = true
+	global["kid"] = kid
+	global["hmac"] = hmacEncoded
+
+	return nil
+}
+
+// askChallenge asks the challenge and its options, and the credentials of the DNS provider.
+func askChallenge(p *prompt, cert map[string]interface{}) error {
+	challenge, err := p.askChoice("Challenge", []string{initChallengeHTTP, initChallengeTLS, initChallengeDNS}, initChallengeHTTP)
+	if err != nil {
+		return err
+	}
+
+	switch challenge {
+	case initChallengeHTTP:
+		cert["http"] = true
+
+		webroot, errW := p.ask("Webroot of the HTTP challenge (empty to use the built-in server)", "")
+		if errW != nil {
+			return errW
+		}
+
+		if webroot != "" {
+			cert["http.webroot"] = webroot
+		}
+
+	case initChallengeTLS:
+		cert["tls"] = true
+
+	case initChallengeDNS:
+		codes := append(splitList(allDNSCodes()), dns.RegisteredProviders()...)
+
+		code, errC := p.askChoice("DNS provider (lego dnshelp to list the providers)", codes, "")
+		if errC != nil {
+			return errC
+		}
+		cert["dns"] = code
+
+		if isRegisteredDNSProvider(code) {
+			fmt.Fprintf(p.out, "%q is a third-party DNS provider, see its documentation for the configuration.\n", code)
+		} else {
+			fmt.Fprintln(p.out)
+			_ = displayDNSHelp(code)
+		}
+
+		env, errE := askEnv(p)
+		if errE != nil {
+			return errE
+		}
+
+		if len(env) > 0 {
+			cert["env"] = env
+		}
+	}
+
+	return nil
+}
+
+// askEnv asks the environment variables (NAME=value) until an empty line.
+func askEnv(p *prompt) (map[string]interface{}, error) {
+	env := make(map[string]interface{})
+
+	fmt.Fprintln(p.out, "Credentials of the DNS provider, one NAME=value per line, an empty line to finish")
+	fmt.Fprintln(p.out, "(the variables already defined in the environment are used if they are not defined here):")
+
+	for {
+		line, err := p.ask("", "")
+		if err != nil {
+			return nil, err
+		}
+
+		if line == "" {
+			return env, nil
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			fmt.Fprintln(p.out, "Invalid variable, the expected format is NAME=value.")
+			continue
+		}
+
+		env[strings.TrimSpace(parts[0])] = parts[1]
+	}
+}
+
+func isRegisteredDNSProvider(code string) bool {
+	for _, name := range dns.RegisteredProviders() {
+		if name == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateInitManifest validates the options of the manifest without side effects:
+// the directory of the CA is fetched and the challenge providers are created,
+// no account is registered and no certificate is requested.
+func validateInitManifest(app *cli.App, manifest *Manifest) error {
+	for i, options := range manifest.Certificates {
+		entry, err := newManifestEntry(app.Flags, nil, manifest.Global, options)
+		if err != nil {
+			return fmt.Errorf("certificate #%d: %v", i+1, err)
+		}
+
+		err = entry.validate(app)
+		if err != nil {
+			return fmt.Errorf("certificate #%d: %v", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+func (e *manifestEntry) validate(app *cli.App) error {
+	restore := e.setEnv()
+	defer restore()
+
+	set, err := newFlagSet(app.Name, app.Flags, e.globalArgs)
+	if err != nil {
+		return err
+	}
+
+	ctx := cli.NewContext(app, set, nil)
+
+	if !ctx.GlobalBool("accept-tos") {
+		return errors.New("the terms of service of the CA must be accepted")
+	}
+
+	if _, err = getCAPreset(ctx); err != nil {
+		return err
+	}
+
+	if ctx.GlobalIsSet("http.webroot") {
+		if _, err = os.Stat(ctx.GlobalString("http.webroot")); err != nil {
+			return fmt.Errorf("invalid webroot: %v", err)
+		}
+	}
+
+	if ctx.GlobalIsSet("dns") {
+		if _, err = setupDNSProvider(ctx); err != nil {
+			return err
+		}
+	}
+
+	privateKey, err := certcrypto.GeneratePrivateKey(certcrypto.EC256)
+	if err != nil {
+		return err
+	}
+
+	config := lego.NewConfig(&initAccount{email: ctx.GlobalString("email"), key: privateKey})
+	config.CADirURL = getServer(ctx)
+	config.UserAgent = fmt.Sprintf("lego-cli/%s", app.Version)
+
+	client, err := lego.NewClient(config)
+	if err != nil {
+		return fmt.Errorf("could not reach the CA: %v", err)
+	}
+
+	if client.GetExternalAccountRequired() && !requiresEAB(ctx) {
+		return errors.New("the CA requires External Account Binding")
+	}
+
+	return nil
+}
+
+// initAccount a temporary account used to reach the CA during the validation.
+type initAccount struct {
+	email string
+	key   crypto.PrivateKey
+}
+
+func (a *initAccount) GetEmail() string                        { return a.email }
+func (a *initAccount) GetPrivateKey() crypto.PrivateKey        { return a.key }
+func (a *initAccount) GetRegistration() *registration.Resource { return nil }
+
+func writeManifest(filename string, manifest *Manifest) error {
+	// the manifest can contain the credentials of the DNS provider.
+	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, filePerm)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = fmt.Fprintf(file, "# Created by lego init, usage: lego --manifest=%s run\n\n", filename)
+	if err != nil {
+		return err
+	}
+
+	return toml.NewEncoder(file).Encode(manifest)
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// prompt reads the answers of the questions asked on the console.
+type prompt struct {
+	reader *bufio.Reader
+	out    io.Writer
+}
+
+func newPrompt(in io.Reader, out io.Writer) *prompt {
+	return &prompt{reader: bufio.NewReader(in), out: out}
+}
+
+// ask asks a question, returns the default value if the answer is empty.
+func (p *prompt) ask(question, defaultValue string) (string, error) {
+	switch {
+	case question == "":
+		fmt.Fprint(p.out, "> ")
+	case defaultValue == "":
+		fmt.Fprintf(p.out, "%s: ", question)
+	default:
+		fmt.Fprintf(p.out, "%s [%s]: ", question, defaultValue)
+	}
+
+	text, err := p.reader.ReadString('\n')
+	if err != nil && (err != io.EOF || text == "") {
+		return "", fmt.Errorf("could not read from console: %v", err)
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return defaultValue, nil
+	}
+
+	return text, nil
+}
+
+// askRequired asks a question until the answer is not empty and valid.
+func (p *prompt) askRequired(question string, validate func(string) error) (string, error) {
+	for {
+		text, err := p.ask(question, "")
+		if err != nil {
+			return "", err
+		}
+
+		if text == "" {
+			fmt.Fprintln(p.out, "A value is required.")
+			continue
+		}
+
+		if validate != nil {
+			if err = validate(text); err != nil {
+				fmt.Fprintf(p.out, "%v.\n", err)
+				continue
+			}
+		}
+
+		return text, nil
+	}
+}
+
+// askChoice asks a question until the answer is one of the choices (case insensitive).
+func (p *prompt) askChoice(question string, choices []string, defaultValue string) (string, error) {
+	if len(choices) <= 5 {
+		question = fmt.Sprintf("%s (%s)", question, strings.Join(choices, ", "))
+	}
+
+	for {
+		text, err := p.ask(question, defaultValue)
+		if err != nil {
+			return "", err
+		}
+
+		for _, choice := range choices {
+			if strings.EqualFold(choice, text) {
+				return choice, nil
+			}
+		}
+
+		if text == "" {
+			fmt.Fprintln(p.out, "A value is required.")
+		} else {
+			fmt.Fprintf(p.out, "Unsupported value: %s.\n", text)
+		}
+	}
+}
+
+// askBool asks a yes/no question.
+func (p *prompt) askBool(question string, defaultValue bool) (bool, error) {
+	defaultText := "n"
+	if defaultValue {
+		defaultText = "y"
+	}
+
+	for {
+		text, err := p.ask(question+" (y/n)", defaultText)
+		if err != nil {
+			return false, err
+		}
+
+		switch strings.ToLower(text) {
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		default:
+			fmt.Fprintln(p.out, "Your input was invalid. Please answer with y or n.")
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+func Test_initWizard(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		input    []string
+		expected *Manifest
+	}{
+		{
+			desc: "http challenge",
+			input: []string{
+				"foo", "foo@example.com",
+				"letsencrypt", "y",
+				"",
+				"example.com, www.example.com",
+				"",
+				"http", "/var/www",
+				"",
+			},
+			expected: &Manifest{
+				Global: map[string]interface{}{
+					"email":      "foo@example.com",
+					"ca":         "letsencrypt",
+					"staging":    true,
+					"key-type":   "ec384",
+					"accept-tos": true,
+				},
+				Certificates: []map[string]interface{}{{
+					"domains":      []string{"example.com", "www.example.com"},
+					"http":         true,
+					"http.webroot": "/var/www",
+				}},
+			},
+		},
+		{
+			desc: "dns challenge",
+			input: []string{
+				"foo@example.com",
+				"https://acme.example.com/directory",
+				"kid", "hmac",
+				"example.com",
+				"RSA4096",
+				"dns", "foo", "manual",
+				"FOO", "FOO=bar=baz", "",
+				"n",
+			},
+			expected: &Manifest{
+				Global: map[string]interface{}{
+					"email":      "foo@example.com",
+					"server":     "https://acme.example.com/directory",
+					"eab":        true,
+					"kid":        "kid",
+					"hmac":       "hmac",
+					"key-type":   "rsa4096",
+					"accept-tos": false,
+				},
+				Certificates: []map[string]interface{}{{
+					"domains": []string{"example.com"},
+					"dns":     "manual",
+					"env":     map[string]interface{}{"FOO": "bar=baz"},
+				}},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			p := newPrompt(strings.NewReader(strings.Join(test.input, "\n")+"\n"), ioutil.Discard)
+
+			manifest, err := initWizard(p)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, manifest)
+		})
+	}
+}
+
+func Test_initWizard_endOfInput(t *testing.T) {
+	p := newPrompt(strings.NewReader("foo@example.com\n"), ioutil.Discard)
+
+	_, err := initWizard(p)
+	require.EqualError(t, err, "could not read from console: EOF")
+}
+
+func Test_prompt_askBool(t *testing.T) {
+	out := &bytes.Buffer{}
+	p := newPrompt(strings.NewReader("maybe\nyes\n\n"), out)
+
+	value, err := p.askBool("Continue?", false)
+	require.NoError(t, err)
+	assert.True(t, value)
+
+	value, err = p.askBool("Continue?", false)
+	require.NoError(t, err)
+	assert.False(t, value)
+
+	assert.Contains(t, out.String(), "Continue? (y/n) [n]: ")
+	assert.Contains(t, out.String(), "Your input was invalid.")
+}
+
+func Test_writeManifest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-init")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "lego.toml")
+
+	manifest := &Manifest{
+		Global: map[string]interface{}{"email": "foo@example.com", "accept-tos": true},
+		Certificates: []map[string]interface{}{{
+			"domains":      []string{"example.com"},
+			"http":         true,
+			"http.webroot": "/var/www",
+			"env":          map[string]interface{}{"FOO": "bar"},
+		}},
+	}
+
+	err = writeManifest(filename, manifest)
+	require.NoError(t, err)
+
+	info, err := os.Stat(filename)
+	require.NoError(t, err)
+	assert.Equal(t, filePerm, info.Mode().Perm())
+
+	read, err := readManifest(filename)
+	require.NoError(t, err)
+
+	entry, err := newManifestEntry(CreateFlags(""), nil, read.Global, read.Certificates[0])
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"--accept-tos=true", "--email=foo@example.com", "--domains=example.com", "--http=true", "--http.webroot=/var/www"}, entry.globalArgs)
+	assert.Equal(t, map[string]string{"FOO": "bar"}, entry.env)
+}
+
+func Test_validateInitManifest(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	app := cli.NewApp()
+	app.Flags = CreateFlags("")
+
+	manifest := &Manifest{
+		Global: map[string]interface{}{
+			"email":      "foo@example.com",
+			"server":     apiURL + "/dir",
+			"accept-tos": true,
+		},
+		Certificates: []map[string]interface{}{{
+			"domains": []string{"example.com"},
+			"dns":     "manual",
+		}},
+	}
+
+	err := validateInitManifest(app, manifest)
+	require.NoError(t, err)
+
+	manifest.Global["accept-tos"] = false
+
+	err = validateInitManifest(app, manifest)
+	require.EqualError(t, err, "certificate #1: the terms of service of the CA must be accepted")
+
+	manifest.Global["accept-tos"] = true
+	manifest.Certificates[0]["dns"] = "foo"
+
+	err = validateInitManifest(app, manifest)
+	require.EqualError(t, err, "certificate #1: unrecognized DNS provider: foo")
+}
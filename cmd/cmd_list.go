@@ -1,14 +1,16 @@
 package cmd
 
 import (
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"net/url"
-	"path/filepath"
+	"path"
 	"strings"
+	"time"
 
 	"github.com/go-acme/lego/v3/certcrypto"
+	"github.com/go-acme/lego/v3/storage"
 	"github.com/urfave/cli"
 )
 
@@ -22,11 +24,45 @@ func createList() cli.Command {
 				Name:  "accounts, a",
 				Usage: "Display accounts.",
 			},
+			cli.BoolFlag{
+				Name:  "names, n",
+				Usage: "Display certificate names only.",
+			},
 		},
 	}
 }
 
+// listResult the certificates and the accounts (JSON output).
+type listResult struct {
+	Certificates []certificateInfo `json:"certificates"`
+	Accounts     []accountInfo     `json:"accounts,omitempty"`
+}
+
+type certificateInfo struct {
+	Name          string    `json:"name"`
+	Domains       []string  `json:"domains"`
+	SANs          []string  `json:"sans"`
+	Issuer        string    `json:"issuer"`
+	Serial        string    `json:"serial"`
+	NotAfter      time.Time `json:"notAfter"`
+	DaysRemaining int       `json:"daysRemaining"`
+	Path          string    `json:"path"`
+
+	// domain the domain in the storage (i.e. _.example.com).
+	domain string
+}
+
+type accountInfo struct {
+	Email  string `json:"email"`
+	Server string `json:"server"`
+	Path   string `json:"path"`
+}
+
 func list(ctx *cli.Context) error {
+	if isJSONOutput(ctx) {
+		return listJSON(ctx)
+	}
+
 	if ctx.Bool("accounts") {
 		if err := listAccount(ctx); err != nil {
 			return err
@@ -36,86 +72,196 @@ func list(ctx *cli.Context) error {
 	return listCertificates(ctx)
 }
 
-func listCertificates(ctx *cli.Context) error {
-	certsStorage := NewCertificatesStorage(ctx)
+func listJSON(ctx *cli.Context) error {
+	var result listResult
+
+	if ctx.Bool("accounts") {
+		accounts, err := readAccounts(ctx)
+		if err != nil {
+			return err
+		}
+
+		result.Accounts = accounts
+	}
+
+	certificates, err := readCertificates(ctx)
+	if err != nil {
+		return err
+	}
+
+	if ctx.Bool("names") {
+		names := []string{}
+		for _, info := range certificates {
+			names = append(names, info.Name)
+		}
+
+		return printJSON(names)
+	}
+
+	result.Certificates = certificates
 
-	matches, err := filepath.Glob(filepath.Join(certsStorage.GetRootPath(), "*.crt"))
+	return printJSON(result)
+}
+
+func listCertificates(ctx *cli.Context) error {
+	certificates, err := readCertificates(ctx)
 	if err != nil {
 		return err
 	}
 
-	if len(matches) == 0 {
+	if ctx.Bool("names") {
+		for _, info := range certificates {
+			fmt.Println(info.Name)
+		}
+
+		return nil
+	}
+
+	if len(certificates) == 0 {
 		fmt.Println("No certificates found.")
 		return nil
 	}
 
 	fmt.Println("Found the following certs:")
-	for _, filename := range matches {
-		if strings.HasSuffix(filename, ".issuer.crt") {
-			continue
+	for _, info := range certificates {
+		fmt.Println("  Certificate Name:", info.Name)
+		fmt.Println("    Domains:", strings.Join(info.Domains, ", "))
+		fmt.Println("    SANs:", strings.Join(info.SANs, ", "))
+		fmt.Println("    Issuer:", info.Issuer)
+		fmt.Println("    Serial Number:", info.Serial)
+		fmt.Printf("    Expiry Date: %s (%d days remaining)\n", info.NotAfter, info.DaysRemaining)
+		fmt.Println("    Certificate Path:", info.Path)
+		fmt.Println()
+	}
+
+	return nil
+}
+
+func readCertificates(ctx *cli.Context) ([]certificateInfo, error) {
+	certsStorage, err := NewCertificatesStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	domains, err := certsStorage.listDomains()
+	if err != nil {
+		return nil, err
+	}
+
+	certificates := []certificateInfo{}
+	for _, domain := range domains {
+		name, err := certsStorage.getStoredName(domain, ".crt")
+		if err != nil {
+			return nil, err
 		}
 
-		data, err := ioutil.ReadFile(filename)
+		data, err := certsStorage.backend.Load(name)
+		if err == storage.ErrNotFound {
+			continue
+		}
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		pCert, err := certcrypto.ParsePEMCertificate(data)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		fmt.Println("  Certificate Name:", pCert.Subject.CommonName)
-		fmt.Println("    Domains:", strings.Join(pCert.DNSNames, ", "))
-		fmt.Println("    Expiry Date:", pCert.NotAfter)
-		fmt.Println("    Certificate Path:", filename)
-		fmt.Println()
+		info := newCertificateInfo(pCert, certsStorage.backend.Location(name))
+		info.domain = domain
+
+		certificates = append(certificates, info)
 	}
 
-	return nil
+	return certificates, nil
 }
 
-func listAccount(ctx *cli.Context) error {
-	// fake email, needed by NewAccountsStorage
-	if err := ctx.GlobalSet("email", "unknown"); err != nil {
-		return err
+func newCertificateInfo(cert *x509.Certificate, filename string) certificateInfo {
+	sans := cert.DNSNames
+	if sans == nil {
+		sans = []string{}
 	}
 
-	accountsStorage := NewAccountsStorage(ctx)
+	return certificateInfo{
+		Name:          cert.Subject.CommonName,
+		Domains:       certcrypto.ExtractDomains(cert),
+		SANs:          sans,
+		Issuer:        cert.Issuer.CommonName,
+		Serial:        fmt.Sprintf("%x", cert.SerialNumber),
+		NotAfter:      cert.NotAfter,
+		DaysRemaining: int(time.Until(cert.NotAfter).Hours() / 24),
+		Path:          filename,
+	}
+}
 
-	matches, err := filepath.Glob(filepath.Join(accountsStorage.GetRootPath(), "*", "*", "*.json"))
+func listAccount(ctx *cli.Context) error {
+	accounts, err := readAccounts(ctx)
 	if err != nil {
 		return err
 	}
 
-	if len(matches) == 0 {
+	if len(accounts) == 0 {
 		fmt.Println("No accounts found.")
 		return nil
 	}
 
 	fmt.Println("Found the following accounts:")
-	for _, filename := range matches {
-		data, err := ioutil.ReadFile(filename)
+	for _, info := range accounts {
+		fmt.Println("  Email:", info.Email)
+		fmt.Println("  Server:", info.Server)
+		fmt.Println("  Path:", info.Path)
+		fmt.Println()
+	}
+
+	return nil
+}
+
+func readAccounts(ctx *cli.Context) ([]accountInfo, error) {
+	// fake email, needed by NewAccountsStorage
+	if err := ctx.GlobalSet("email", "unknown"); err != nil {
+		return nil, err
+	}
+
+	accountsStorage, err := NewAccountsStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := accountsStorage.backend.List(baseAccountsRootFolderName + "/")
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := []accountInfo{}
+	for _, name := range names {
+		// accounts/<server>/<email>/account.json
+		if strings.Count(name, "/") != 3 || path.Ext(name) != ".json" {
+			continue
+		}
+
+		data, err := accountsStorage.backend.Load(name)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		var account Account
 		err = json.Unmarshal(data, &account)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		uri, err := url.Parse(account.Registration.URI)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		fmt.Println("  Email:", account.Email)
-		fmt.Println("  Server:", uri.Host)
-		fmt.Println("  Path:", filepath.Dir(filename))
-		fmt.Println()
+		accounts = append(accounts, accountInfo{
+			Email:  account.Email,
+			Server: uri.Host,
+			Path:   accountsStorage.backend.Location(path.Dir(name)),
+		})
 	}
 
-	return nil
+	return accounts, nil
 }
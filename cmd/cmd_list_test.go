@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v3/certcrypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newCertificateInfo(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	pemCert, err := certcrypto.GeneratePemCert(privateKey, "example.com", nil)
+	require.NoError(t, err)
+
+	cert, err := certcrypto.ParsePEMCertificate(pemCert)
+	require.NoError(t, err)
+
+	cert.NotAfter = time.Now().Add(45*24*time.Hour + time.Hour)
+
+	info := newCertificateInfo(cert, "/tmp/lego/certificates/example.com.crt")
+
+	expected := certificateInfo{
+		Name:          "ACME Challenge TEMP",
+		Domains:       []string{"ACME Challenge TEMP", "example.com"},
+		SANs:          []string{"example.com"},
+		Issuer:        "ACME Challenge TEMP",
+		Serial:        cert.SerialNumber.Text(16),
+		NotAfter:      cert.NotAfter,
+		DaysRemaining: 45,
+		Path:          "/tmp/lego/certificates/example.com.crt",
+	}
+	assert.Equal(t, expected, info)
+}
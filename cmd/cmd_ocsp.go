@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-acme/lego/v3/certificate"
+	"github.com/go-acme/lego/v3/log"
+	"github.com/urfave/cli"
+	"golang.org/x/crypto/ocsp"
+)
+
+// OCSP statuses.
+const (
+	ocspStatusGood         = "Good"
+	ocspStatusRevoked      = "Revoked"
+	ocspStatusUnknown      = "Unknown"
+	ocspStatusServerFailed = "ServerFailed"
+)
+
+// ocspResult the OCSP status of a certificate.
+type ocspResult struct {
+	Domain           string     `json:"domain"`
+	Status           string     `json:"status,omitempty"`
+	ThisUpdate       *time.Time `json:"thisUpdate,omitempty"`
+	NextUpdate       *time.Time `json:"nextUpdate,omitempty"`
+	RevokedAt        *time.Time `json:"revokedAt,omitempty"`
+	RevocationReason string     `json:"revocationReason,omitempty"`
+	Error            string     `json:"error,omitempty"`
+}
+
+func createOCSP() cli.Command {
+	return cli.Command{
+		Name:   "ocsp",
+		Usage:  "Display the OCSP status of the certificates",
+		Action: ocspStatus,
+		Description: "Fetches the OCSP responses of the certificates designated by --domains/-d (all the certificates of the storage by default).\n" +
+			"   The exit code is 1 if a certificate is not Good, or if the OCSP response cannot be fetched.",
+	}
+}
+
+func ocspStatus(ctx *cli.Context) error {
+	certsStorage, err := NewCertificatesStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	domains := ctx.GlobalStringSlice("domains")
+	if len(domains) == 0 {
+		certificates, err := readCertificates(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, info := range certificates {
+			domains = append(domains, info.domain)
+		}
+	}
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	if ctx.GlobalIsSet("http-timeout") {
+		httpClient.Timeout = time.Duration(ctx.GlobalInt("http-timeout")) * time.Second
+	}
+
+	var results []ocspResult
+	var failed int
+
+	for _, domain := range domains {
+		result := fetchOCSPStatus(httpClient, certsStorage, domain)
+		if result.Status != ocspStatusGood {
+			failed++
+		}
+
+		results = append(results, result)
+	}
+
+	if isJSONOutput(ctx) {
+		if results == nil {
+			results = []ocspResult{}
+		}
+
+		if err := printJSON(results); err != nil {
+			return err
+		}
+	} else {
+		printOCSPResults(results)
+	}
+
+	if failed > 0 {
+		return cli.NewExitError("", 1)
+	}
+
+	return nil
+}
+
+// fetchOCSPStatus fetches the OCSP response of a certificate of the storage.
+func fetchOCSPStatus(httpClient *http.Client, certsStorage *CertificatesStorage, domain string) ocspResult {
+	result := ocspResult{Domain: domain}
+
+	bundle, err := certsStorage.ReadFile(domain, ".crt")
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	// the issuer certificate (if it exists) is added if the certificate is not a bundle (--no-bundle).
+	certificates, err := certsStorage.ReadCertificate(domain, ".crt")
+	if err == nil && len(certificates) == 1 {
+		issuer, errI := certsStorage.ReadFile(domain, ".issuer.crt")
+		if errI == nil {
+			bundle = append(bundle, issuer...)
+		}
+	}
+
+	_, resp, err := certificate.FetchOCSP(httpClient, bundle)
+	if err != nil {
+		log.Printf("[%s] Could not get the OCSP response: %v", domain, err)
+		result.Error = err.Error()
+		return result
+	}
+
+	return newOCSPResult(domain, resp)
+}
+
+// newOCSPResult creates the result of an OCSP response.
+func newOCSPResult(domain string, resp *ocsp.Response) ocspResult {
+	result := ocspResult{Domain: domain}
+
+	switch resp.Status {
+	case ocsp.Good:
+		result.Status = ocspStatusGood
+	case ocsp.Revoked:
+		result.Status = ocspStatusRevoked
+
+		revokedAt := resp.RevokedAt.UTC()
+		result.RevokedAt = &revokedAt
+		result.RevocationReason = revocationReasonName(uint(resp.RevocationReason))
+	case ocsp.ServerFailed:
+		result.Status = ocspStatusServerFailed
+	default:
+		result.Status = ocspStatusUnknown
+	}
+
+	if !resp.ThisUpdate.IsZero() {
+		thisUpdate := resp.ThisUpdate.UTC()
+		result.ThisUpdate = &thisUpdate
+	}
+
+	if !resp.NextUpdate.IsZero() {
+		nextUpdate := resp.NextUpdate.UTC()
+		result.NextUpdate = &nextUpdate
+	}
+
+	return result
+}
+
+func printOCSPResults(results []ocspResult) {
+	if len(results) == 0 {
+		fmt.Println("No certificates found.")
+		return
+	}
+
+	for _, result := range results {
+		if result.Error != "" {
+			fmt.Printf("%s: Error: %s\n", result.Domain, result.Error)
+			continue
+		}
+
+		fmt.Printf("%s: %s\n", result.Domain, result.Status)
+
+		if result.ThisUpdate != nil {
+			fmt.Println("    This Update:", result.ThisUpdate.Format(time.RFC3339))
+		}
+
+		if result.NextUpdate != nil {
+			fmt.Println("    Next Update:", result.NextUpdate.Format(time.RFC3339))
+		}
+
+		if result.RevokedAt != nil {
+			fmt.Println("    Revoked At:", result.RevokedAt.Format(time.RFC3339))
+			fmt.Println("    Revocation Reason:", result.RevocationReason)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v3/certcrypto"
+	"github.com/go-acme/lego/v3/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+)
+
+func Test_fetchOCSPStatus(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-ocsp")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	issuerKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	issuer := createTestCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}, nil, issuerKey, issuerKey)
+
+	thisUpdate := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	revokedAt := thisUpdate.Add(-time.Hour)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, errR := ioutil.ReadAll(req.Body)
+		require.NoError(t, errR)
+
+		ocspReq, errR := ocsp.ParseRequest(body)
+		require.NoError(t, errR)
+
+		resp, errR := ocsp.CreateResponse(issuer, issuer, ocsp.Response{
+			Status:           ocsp.Revoked,
+			SerialNumber:     ocspReq.SerialNumber,
+			ThisUpdate:       thisUpdate,
+			NextUpdate:       thisUpdate.Add(24 * time.Hour),
+			RevokedAt:        revokedAt,
+			RevocationReason: ocsp.KeyCompromise,
+		}, issuerKey)
+		require.NoError(t, errR)
+
+		_, _ = rw.Write(resp)
+	}))
+	defer server.Close()
+
+	leafKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	leaf := createTestCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		OCSPServer:   []string{server.URL},
+	}, issuer, leafKey, issuerKey)
+
+	certsStorage := &CertificatesStorage{backend: storage.NewFileSystem(dir)}
+
+	// the certificate is not a bundle: the issuer certificate is read from the .issuer.crt file.
+	require.NoError(t, certsStorage.WriteFile("example.com", ".crt", certcrypto.PEMEncode(certcrypto.DERCertificateBytes(leaf.Raw))))
+	require.NoError(t, certsStorage.WriteFile("example.com", ".issuer.crt", certcrypto.PEMEncode(certcrypto.DERCertificateBytes(issuer.Raw))))
+
+	result := fetchOCSPStatus(server.Client(), certsStorage, "example.com")
+
+	nextUpdate := thisUpdate.Add(24 * time.Hour)
+
+	expected := ocspResult{
+		Domain:           "example.com",
+		Status:           ocspStatusRevoked,
+		ThisUpdate:       &thisUpdate,
+		NextUpdate:       &nextUpdate,
+		RevokedAt:        &revokedAt,
+		RevocationReason: "keyCompromise",
+	}
+	assert.Equal(t, expected, result)
+
+	result = fetchOCSPStatus(server.Client(), certsStorage, "example.org")
+	assert.Equal(t, "example.org", result.Domain)
+	assert.Empty(t, result.Status)
+	assert.NotEmpty(t, result.Error)
+}
+
+func createTestCertificate(t *testing.T, template, parent *x509.Certificate, key *rsa.PrivateKey, signer crypto.Signer) *x509.Certificate {
+	t.Helper()
+
+	if parent == nil {
+		parent = template
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), signer)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert
+}
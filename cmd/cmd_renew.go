@@ -1,15 +1,12 @@
 package cmd
 
 import (
-	"context"
-	"crypto"
 	"crypto/x509"
 	"errors"
 	"fmt"
-	"os/exec"
-	"strings"
 	"time"
 
+	"github.com/go-acme/lego/v3/acme/api"
 	"github.com/go-acme/lego/v3/certcrypto"
 	"github.com/go-acme/lego/v3/certificate"
 	"github.com/go-acme/lego/v3/lego"
@@ -17,12 +14,22 @@ import (
 	"github.com/urfave/cli"
 )
 
+// exitCodeNotDue the exit code of the renew command when the certificate doesn't need to be renewed (--distinct-exit-codes).
+const exitCodeNotDue = 2
+
+// errNotDue is returned when the certificate doesn't need to be renewed.
+var errNotDue = errors.New("the certificate doesn't need to be renewed")
+
 func createRenew() cli.Command {
 	return cli.Command{
 		Name:   "renew",
 		Usage:  "Renew a certificate",
 		Action: renew,
 		Before: func(ctx *cli.Context) error {
+			if ctx.GlobalIsSet("manifest") {
+				return nil
+			}
+
 			// we require either domains or csr, but not both
 			hasDomains := len(ctx.GlobalStringSlice("domains")) > 0
 			hasCsr := len(ctx.GlobalString("csr")) > 0
@@ -34,16 +41,25 @@ func createRenew() cli.Command {
 			}
 			return nil
 		},
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			cli.IntFlag{
 				Name:  "days",
 				Value: 30,
 				Usage: "The number of days left on a certificate to renew it.",
 			},
+			cli.BoolFlag{
+				Name:  "distinct-exit-codes",
+				Usage: "Use distinct exit codes: 0 when the certificate is renewed, 1 when an error occurs, 2 when the certificate doesn't need to be renewed.",
+			},
+			cli.BoolFlag{
+				Name:  "ari-disable",
+				Usage: "Do not use the renewal information (ARI) of the CA: only the number of days left on the certificate is used to renew it.",
+			},
 			cli.BoolFlag{
 				Name:  "reuse-key",
 				Usage: "Used to indicate you want to reuse your current private key for the new certificate.",
 			},
+
 			cli.BoolFlag{
 				Name:  "no-bundle",
 				Usage: "Do not create a certificate bundle by adding the issuers certificate to the new certificate.",
@@ -56,19 +72,61 @@ func createRenew() cli.Command {
 				Name:  "renew-hook",
 				Usage: "Define a hook. The hook is executed only when the certificates are effectively renewed.",
 			},
-		},
+			cli.DurationFlag{
+				Name:  "renew-hook.timeout",
+				Usage: "The maximum execution time of the renew-hook.",
+				Value: 30 * time.Second,
+			},
+		}, append(append(createKeyRotationFlags(), createBackupFlags()...), createHookFlags()...)...),
 	}
 }
 
 func renew(ctx *cli.Context) error {
-	account, client := setup(ctx, NewAccountsStorage(ctx))
-	setupChallenges(ctx, client)
+	if ctx.GlobalIsSet("manifest") {
+		return renewExitCode(ctx, renewManifest(ctx))
+	}
+
+	return renewExitCode(ctx, renewCertificate(ctx))
+}
+
+// renewManifest renews the certificates of the manifest, errNotDue is returned if none of the certificates needs to be renewed.
+func renewManifest(ctx *cli.Context) error {
+	return runManifest(ctx, renewCertificate)
+}
+
+// renewCertificate renews a certificate, errNotDue is returned if the certificate doesn't need to be renewed.
+func renewCertificate(ctx *cli.Context) error {
+	accountsStorage, err := NewAccountsStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	account, client, err := setup(ctx, accountsStorage)
+	if err != nil {
+		return err
+	}
+
+	err = setupChallenges(ctx, client)
+	if err != nil {
+		return err
+	}
 
 	if account.Registration == nil {
-		log.Fatalf("Account %s is not registered. Use 'run' to register a new account.\n", account.Email)
+		if !isDryRun(ctx) {
+			return fmt.Errorf("account %s is not registered, use 'run' to register a new account", account.Email)
+		}
+
+		// the account of the staging environment is registered by the first dry-run.
+		err = registerAccount(ctx, client, account, accountsStorage)
+		if err != nil {
+			return err
+		}
 	}
 
-	certsStorage := NewCertificatesStorage(ctx)
+	certsStorage, err := NewCertificatesStorage(ctx)
+	if err != nil {
+		return err
+	}
 
 	bundle := !ctx.Bool("no-bundle")
 
@@ -90,13 +148,24 @@ func renewForDomains(ctx *cli.Context, client *lego.Client, certsStorage *Certif
 	// as web servers would not be able to work with a combined file.
 	certificates, err := certsStorage.ReadCertificate(domain, ".crt")
 	if err != nil {
-		log.Fatalf("Error while loading the certificate for domain %s\n\t%v", domain, err)
+		return failedResult(ctx, &certificateResult{Domain: domain}, fmt.Errorf("error while loading the certificate for domain %s\n\t%v", domain, err))
 	}
 
 	cert := certificates[0]
 
-	if !needRenewal(cert, domain, ctx.Int("days")) {
-		return nil
+	due, err := needRenewal(cert, domain, ctx.Int("days"))
+	if err != nil {
+		return failedResult(ctx, &certificateResult{Domain: domain}, err)
+	}
+
+	due = due || ariRenewalTime(ctx, client, cert, domain, 0) != nil
+	if !due {
+		if !isDryRun(ctx) {
+			printSkippedResult(ctx, certsStorage, domain, cert)
+			return errNotDue
+		}
+
+		log.Printf("[%s] dry-run: the certificate doesn't need to be renewed, the renewal is simulated to test the configuration.", domain)
 	}
 
 	// This is just meant to be informal for the user.
@@ -105,39 +174,39 @@ func renewForDomains(ctx *cli.Context, client *lego.Client, certsStorage *Certif
 
 	certDomains := certcrypto.ExtractDomains(cert)
 
-	var privateKey crypto.PrivateKey
-	if ctx.Bool("reuse-key") {
-		keyBytes, errR := certsStorage.ReadFile(domain, ".key")
-		if errR != nil {
-			log.Fatalf("Error while loading the private key for domain %s\n\t%v", domain, errR)
-		}
-
-		privateKey, errR = certcrypto.ParsePEMPrivateKey(keyBytes)
-		if errR != nil {
-			return errR
-		}
+	rotation, err := newKeyRotation(ctx, certsStorage, domain)
+	if err != nil {
+		return failedResult(ctx, &certificateResult{Domain: domain}, err)
 	}
 
 	request := certificate.ObtainRequest{
 		Domains:    merge(certDomains, domains),
 		Bundle:     bundle,
-		PrivateKey: privateKey,
+		PrivateKey: rotation.privateKey,
 		MustStaple: ctx.Bool("must-staple"),
 	}
-	certRes, err := client.Certificate.Obtain(request)
+
+	certRes, err := obtainWithHooks(ctx, newRequestHookMeta(request.Domains), func() (*certificate.Resource, error) {
+		return client.Certificate.Obtain(request)
+	})
 	if err != nil {
-		log.Fatal(err)
+		notifyExpiry(ctx, newStoredCertificateResult(certsStorage, domain, cert, statusFailed))
+		return failedResult(ctx, &certificateResult{Domain: domain, Domains: request.Domains}, err)
 	}
 
-	certsStorage.SaveResource(certRes)
+	if !isDryRun(ctx) {
+		if err = rotation.commit(certRes.PrivateKey); err != nil {
+			log.Warnf("[%s] Unable to save the state of the private key: %v", domain, err)
+		}
+	}
 
-	return renewHook(ctx)
+	return saveCertificate(ctx, certsStorage, certRes, renewStatus(ctx, due))
 }
 
 func renewForCSR(ctx *cli.Context, client *lego.Client, certsStorage *CertificatesStorage, bundle bool) error {
 	csr, err := readCSRFile(ctx.GlobalString("csr"))
 	if err != nil {
-		log.Fatal(err)
+		return failedResult(ctx, &certificateResult{}, err)
 	}
 
 	domain := csr.Subject.CommonName
@@ -147,32 +216,116 @@ func renewForCSR(ctx *cli.Context, client *lego.Client, certsStorage *Certificat
 	// as web servers would not be able to work with a combined file.
 	certificates, err := certsStorage.ReadCertificate(domain, ".crt")
 	if err != nil {
-		log.Fatalf("Error while loading the certificate for domain %s\n\t%v", domain, err)
+		return failedResult(ctx, &certificateResult{Domain: domain}, fmt.Errorf("error while loading the certificate for domain %s\n\t%v", domain, err))
 	}
 
 	cert := certificates[0]
 
-	if !needRenewal(cert, domain, ctx.Int("days")) {
-		return nil
+	due, err := needRenewal(cert, domain, ctx.Int("days"))
+	if err != nil {
+		return failedResult(ctx, &certificateResult{Domain: domain}, err)
+	}
+
+	due = due || ariRenewalTime(ctx, client, cert, domain, 0) != nil
+	if !due {
+		if !isDryRun(ctx) {
+			printSkippedResult(ctx, certsStorage, domain, cert)
+			return errNotDue
+		}
+
+		log.Printf("[%s] dry-run: the certificate doesn't need to be renewed, the renewal is simulated to test the configuration.", domain)
 	}
 
 	// This is just meant to be informal for the user.
 	timeLeft := cert.NotAfter.Sub(time.Now().UTC())
 	log.Infof("[%s] acme: Trying renewal with %d hours remaining", domain, int(timeLeft.Hours()))
 
-	certRes, err := client.Certificate.ObtainForCSR(*csr, bundle)
+	certRes, err := obtainWithHooks(ctx, newRequestHookMeta(certcrypto.ExtractDomainsCSR(csr)), func() (*certificate.Resource, error) {
+		return client.Certificate.ObtainForCSR(*csr, bundle)
+	})
 	if err != nil {
-		log.Fatal(err)
+		notifyExpiry(ctx, newStoredCertificateResult(certsStorage, domain, cert, statusFailed))
+		return failedResult(ctx, &certificateResult{Domain: domain, Domains: certcrypto.ExtractDomainsCSR(csr)}, err)
+	}
+
+	return saveCertificate(ctx, certsStorage, certRes, renewStatus(ctx, due))
+}
+
+// renewStatus returns the status of a renewed certificate,
+// with --dry-run the status of a certificate which doesn't need to be renewed is skipped.
+func renewStatus(ctx *cli.Context, due bool) string {
+	if isDryRun(ctx) && !due {
+		return statusSkipped
+	}
+
+	return statusRenewed
+}
+
+// renewExitCode converts errNotDue to the exit code of a certificate which doesn't need to be renewed (--distinct-exit-codes).
+func renewExitCode(ctx *cli.Context, err error) error {
+	if err != errNotDue {
+		return err
 	}
 
-	certsStorage.SaveResource(certRes)
+	if ctx.Bool("distinct-exit-codes") {
+		return cli.NewExitError("", exitCodeNotDue)
+	}
 
-	return renewHook(ctx)
+	return nil
 }
 
-func needRenewal(x509Cert *x509.Certificate, domain string, days int) bool {
+// printSkippedResult writes the result of a certificate which doesn't need to be renewed with the JSON output,
+// and sends the expiry notification if the certificate expires soon.
+func printSkippedResult(ctx *cli.Context, certsStorage *CertificatesStorage, domain string, cert *x509.Certificate) {
+	result := newStoredCertificateResult(certsStorage, domain, cert, statusSkipped)
+
+	printResult(ctx, result)
+
+	notifyExpiry(ctx, result)
+}
+
+// newStoredCertificateResult creates the result of a certificate read from the storage.
+func newStoredCertificateResult(certsStorage *CertificatesStorage, domain string, cert *x509.Certificate, status string) *certificateResult {
+	result := &certificateResult{
+		Domain:   domain,
+		Status:   status,
+		CertPath: certsStorage.GetFileName(domain, ".crt"),
+	}
+
+	result.setCertificate(cert)
+
+	return result
+}
+
+// ariRenewalTime returns the date of the renewal suggested by the CA (ARI) if it's before now+willingToSleep, nil otherwise.
+// Returns nil if the CA doesn't support ARI or if ARI is disabled.
+func ariRenewalTime(ctx *cli.Context, client *lego.Client, cert *x509.Certificate, domain string, willingToSleep time.Duration) *time.Time {
+	if ctx.Bool("ari-disable") {
+		return nil
+	}
+
+	info, err := client.Certificate.GetRenewalInfo(certificate.RenewalInfoRequest{Cert: cert})
+	if err != nil {
+		if err != api.ErrNoARI {
+			log.Warnf("[%s] acme: Unable to get the renewal information (ARI): %v", domain, err)
+		}
+		return nil
+	}
+
+	renewAt := info.ShouldRenewAt(time.Now(), willingToSleep)
+	if renewAt == nil {
+		log.Infof("[%s] acme: The renewal window suggested by the CA (ARI) starts at %s.", domain, info.SuggestedWindow.Start.Format(time.RFC3339))
+		return nil
+	}
+
+	log.Infof("[%s] acme: The renewal suggested by the CA (ARI) is at %s.", domain, renewAt.Format(time.RFC3339))
+
+	return renewAt
+}
+
+func needRenewal(x509Cert *x509.Certificate, domain string, days int) (bool, error) {
 	if x509Cert.IsCA {
-		log.Fatalf("[%s] Certificate bundle starts with a CA certificate", domain)
+		return false, fmt.Errorf("[%s] certificate bundle starts with a CA certificate", domain)
 	}
 
 	if days >= 0 {
@@ -180,11 +333,11 @@ func needRenewal(x509Cert *x509.Certificate, domain string, days int) bool {
 		if notAfter > days {
 			log.Printf("[%s] The certificate expires in %d days, the number of days defined to perform the renewal is %d: no renewal.",
 				domain, notAfter, days)
-			return false
+			return false, nil
 		}
 	}
 
-	return true
+	return true, nil
 }
 
 func merge(prevDomains []string, nextDomains []string) []string {
@@ -202,25 +355,3 @@ func merge(prevDomains []string, nextDomains []string) []string {
 	}
 	return prevDomains
 }
-
-func renewHook(ctx *cli.Context) error {
-	hook := ctx.String("renew-hook")
-	if hook == "" {
-		return nil
-	}
-
-	ctxCmd, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	parts := strings.Fields(hook)
-	output, err := exec.CommandContext(ctxCmd, parts[0], parts[1:]...).CombinedOutput()
-	if len(output) > 0 {
-		fmt.Println(string(output))
-	}
-
-	if ctxCmd.Err() == context.DeadlineExceeded {
-		return errors.New("hook timed out")
-	}
-
-	return err
-}
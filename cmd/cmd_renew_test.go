@@ -2,10 +2,15 @@ package cmd
 
 import (
 	"crypto/x509"
+	"errors"
+	"flag"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
 )
 
 func Test_merge(t *testing.T) {
@@ -110,10 +115,59 @@ func Test_needRenewal(t *testing.T) {
 	for _, test := range testCases {
 		test := test
 		t.Run(test.desc, func(t *testing.T) {
-
-			actual := needRenewal(test.x509Cert, "foo.com", test.days)
+			actual, err := needRenewal(test.x509Cert, "foo.com", test.days)
+			require.NoError(t, err)
 
 			assert.Equal(t, test.expected, actual)
 		})
 	}
 }
+
+func Test_needRenewal_CA(t *testing.T) {
+	_, err := needRenewal(&x509.Certificate{IsCA: true}, "foo.com", 30)
+	require.EqualError(t, err, "[foo.com] certificate bundle starts with a CA certificate")
+}
+
+func Test_renewExitCode(t *testing.T) {
+	testCases := []struct {
+		desc              string
+		distinctExitCodes bool
+		err               error
+		expectedCode      int
+		expectedErr       error
+	}{
+		{desc: "renewed", distinctExitCodes: true},
+		{desc: "not due", distinctExitCodes: true, err: errNotDue, expectedCode: exitCodeNotDue},
+		{desc: "not due without distinct exit codes", err: errNotDue},
+		{desc: "error", distinctExitCodes: true, err: errors.New("boom"), expectedErr: errors.New("boom")},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			command := createRenew()
+
+			set := flag.NewFlagSet(command.Name, flag.ContinueOnError)
+			for _, f := range command.Flags {
+				f.Apply(set)
+			}
+
+			require.NoError(t, set.Set("distinct-exit-codes", strconv.FormatBool(test.distinctExitCodes)))
+
+			err := renewExitCode(cli.NewContext(cli.NewApp(), set, nil), test.err)
+
+			switch {
+			case test.expectedCode != 0:
+				exitErr, ok := err.(cli.ExitCoder)
+				require.True(t, ok, "expected an exit error: %v", err)
+				assert.Equal(t, test.expectedCode, exitErr.ExitCode())
+			case test.expectedErr != nil:
+				assert.Equal(t, test.expectedErr, err)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -1,62 +1,295 @@
 package cmd
 
 import (
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/go-acme/lego/v3/acme"
+	"github.com/go-acme/lego/v3/certcrypto"
 	"github.com/go-acme/lego/v3/log"
+	"github.com/go-acme/lego/v3/storage"
 	"github.com/urfave/cli"
 )
 
+// revocationReasons the names of the revocation reasons (RFC5280).
+var revocationReasons = map[string]uint{
+	"unspecified":          acme.CRLReasonUnspecified,
+	"keyCompromise":        acme.CRLReasonKeyCompromise,
+	"cACompromise":         acme.CRLReasonCACompromise,
+	"affiliationChanged":   acme.CRLReasonAffiliationChanged,
+	"superseded":           acme.CRLReasonSuperseded,
+	"cessationOfOperation": acme.CRLReasonCessationOfOperation,
+	"certificateHold":      acme.CRLReasonCertificateHold,
+	"removeFromCRL":        acme.CRLReasonRemoveFromCRL,
+	"privilegeWithdrawn":   acme.CRLReasonPrivilegeWithdrawn,
+	"aACompromise":         acme.CRLReasonAACompromise,
+}
+
+// revocationTarget a certificate to revoke.
+type revocationTarget struct {
+	// domain the name of the certificate in the storage, empty if the certificate must not be archived.
+	domain string
+	// name the name of the certificate file in the storage, empty for a file outside of the storage (--cert-file).
+	name     string
+	certPath string
+}
+
 func createRevoke() cli.Command {
 	return cli.Command{
 		Name:   "revoke",
 		Usage:  "Revoke a certificate",
 		Action: revoke,
+		Before: func(ctx *cli.Context) error {
+			if len(ctx.GlobalStringSlice("domains")) == 0 && len(ctx.StringSlice("cert-file")) == 0 && len(ctx.StringSlice("serial")) == 0 {
+				log.Fatal("Please specify --domains/-d, --cert-file or --serial")
+			}
+			return nil
+		},
 		Flags: []cli.Flag{
 			cli.BoolFlag{
 				Name:  "keep, k",
 				Usage: "Keep the certificates after the revocation instead of archiving them.",
 			},
+			cli.StringSliceFlag{
+				Name:  "cert-file",
+				Usage: "Revoke a certificate (PEM) from a file, i.e. a certificate issued elsewhere under the same account. Can be specified multiple times.",
+			},
+			cli.StringSliceFlag{
+				Name:  "serial",
+				Usage: "Revoke the certificate of the storage (certificates and archives) with this serial number (hexadecimal). Can be specified multiple times.",
+			},
+			cli.StringFlag{
+				Name: "reason",
+				Usage: "The reason of the revocation. Supported: unspecified, keyCompromise, cACompromise, affiliationChanged, superseded, " +
+					"cessationOfOperation, certificateHold, removeFromCRL, privilegeWithdrawn, aACompromise (or the reason code).",
+			},
 		},
 	}
 }
 
 func revoke(ctx *cli.Context) error {
-	acc, client := setup(ctx, NewAccountsStorage(ctx))
+	accountsStorage, err := NewAccountsStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	acc, client, err := setup(ctx, accountsStorage)
+	if err != nil {
+		return err
+	}
 
 	if acc.Registration == nil {
-		log.Fatalf("Account %s is not registered. Use 'run' to register a new account.\n", acc.Email)
+		return fmt.Errorf("account %s is not registered, use 'run' to register a new account", acc.Email)
 	}
 
-	certsStorage := NewCertificatesStorage(ctx)
-	certsStorage.CreateRootFolder()
+	reason, err := parseRevocationReason(ctx.String("reason"))
+	if err != nil {
+		return err
+	}
 
-	for _, domain := range ctx.GlobalStringSlice("domains") {
-		log.Printf("Trying to revoke certificate for domain %s", domain)
+	certsStorage, err := NewCertificatesStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	targets, err := revocationTargets(ctx, certsStorage)
+	if err != nil {
+		return failedResult(ctx, &certificateResult{}, err)
+	}
 
-		certBytes, err := certsStorage.ReadFile(domain, ".crt")
+	var results []*certificateResult
+
+	for _, target := range targets {
+		log.Printf("Trying to revoke certificate %s", target.certPath)
+
+		result := &certificateResult{Domain: target.domain, CertPath: target.certPath}
+
+		certBytes, err := readRevocationTarget(certsStorage, target)
 		if err != nil {
-			log.Fatalf("Error while revoking the certificate for domain %s\n\t%v", domain, err)
+			return failedResult(ctx, result, fmt.Errorf("error while revoking the certificate %s\n\t%v", target.certPath, err))
 		}
 
-		err = client.Certificate.Revoke(certBytes)
+		err = client.Certificate.RevokeWithReason(certBytes, reason)
 		if err != nil {
-			log.Fatalf("Error while revoking the certificate for domain %s\n\t%v", domain, err)
+			return failedResult(ctx, result, fmt.Errorf("error while revoking the certificate %s\n\t%v", target.certPath, err))
 		}
 
 		log.Println("Certificate was revoked.")
 
-		if ctx.Bool("keep") {
-			return nil
-		}
+		result.Status = statusRevoked
+		results = append(results, result)
 
-		certsStorage.CreateArchiveFolder()
+		if ctx.Bool("keep") || target.domain == "" {
+			continue
+		}
 
-		err = certsStorage.MoveToArchive(domain)
+		err = certsStorage.MoveToArchive(target.domain)
 		if err != nil {
 			return err
 		}
 
-		log.Println("Certificate was archived for domain:", domain)
+		result.Archived = true
+
+		log.Println("Certificate was archived for domain:", target.domain)
+	}
+
+	if isJSONOutput(ctx) {
+		return printJSON(results)
 	}
 
 	return nil
 }
+
+// revocationTargets returns the certificates to revoke: the certificates of the domains, the files, and the certificates found by serial number.
+func revocationTargets(ctx *cli.Context, certsStorage *CertificatesStorage) ([]revocationTarget, error) {
+	var targets []revocationTarget
+
+	for _, domain := range ctx.GlobalStringSlice("domains") {
+		name, err := certsStorage.getStoredName(domain, ".crt")
+		if err != nil {
+			return nil, err
+		}
+
+		targets = append(targets, revocationTarget{
+			domain:   domain,
+			name:     name,
+			certPath: certsStorage.backend.Location(name),
+		})
+	}
+
+	for _, certFile := range ctx.StringSlice("cert-file") {
+		targets = append(targets, revocationTarget{certPath: certFile})
+	}
+
+	for _, serial := range ctx.StringSlice("serial") {
+		target, err := findCertificateBySerial(certsStorage, serial)
+		if err != nil {
+			return nil, err
+		}
+
+		targets = append(targets, target)
+	}
+
+	return targets, nil
+}
+
+// findCertificateBySerial finds a certificate by serial number in the certificates, then in the archives.
+func findCertificateBySerial(certsStorage *CertificatesStorage, serial string) (revocationTarget, error) {
+	expected, ok := new(big.Int).SetString(strings.Replace(serial, ":", "", -1), 16)
+	if !ok {
+		return revocationTarget{}, fmt.Errorf("invalid serial number: %s", serial)
+	}
+
+	domains, err := certsStorage.listDomains()
+	if err != nil {
+		return revocationTarget{}, err
+	}
+
+	for _, domain := range domains {
+		name, err := certsStorage.getStoredName(domain, ".crt")
+		if err != nil {
+			return revocationTarget{}, err
+		}
+
+		found, err := hasSerialNumber(certsStorage, name, expected)
+		if err != nil {
+			return revocationTarget{}, err
+		}
+
+		if found {
+			return revocationTarget{name: name, certPath: certsStorage.backend.Location(name), domain: domain}, nil
+		}
+	}
+
+	names, err := certsStorage.listCertificates(baseArchivesFolderName)
+	if err != nil {
+		return revocationTarget{}, err
+	}
+
+	for _, name := range names {
+		found, err := hasSerialNumber(certsStorage, name, expected)
+		if err != nil {
+			return revocationTarget{}, err
+		}
+
+		// the archived certificates must not be archived again.
+		if found {
+			return revocationTarget{name: name, certPath: certsStorage.backend.Location(name)}, nil
+		}
+	}
+
+	return revocationTarget{}, fmt.Errorf("no certificate found with the serial number %s", serial)
+}
+
+// hasSerialNumber checks if a certificate of the storage has a serial number, a missing certificate doesn't have it.
+func hasSerialNumber(certsStorage *CertificatesStorage, name string, serial *big.Int) (bool, error) {
+	data, err := certsStorage.backend.Load(name)
+	if err == storage.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	cert, err := certcrypto.ParsePEMCertificate(data)
+	if err != nil {
+		return false, err
+	}
+
+	return cert.SerialNumber.Cmp(serial) == 0, nil
+}
+
+// readRevocationTarget reads a certificate to revoke, from the storage or from a file.
+func readRevocationTarget(certsStorage *CertificatesStorage, target revocationTarget) ([]byte, error) {
+	if target.name == "" {
+		return ioutil.ReadFile(target.certPath)
+	}
+
+	data, err := certsStorage.backend.Load(target.name)
+	if err == storage.ErrNotFound {
+		return nil, fmt.Errorf("open %s: %v", target.certPath, err)
+	}
+
+	return data, err
+}
+
+// parseRevocationReason parses the name (case insensitive) or the code of a revocation reason, an empty value is not a reason.
+func parseRevocationReason(value string) (*uint, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	for name, reason := range revocationReasons {
+		if strings.EqualFold(name, value) {
+			return &reason, nil
+		}
+	}
+
+	code, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported revocation reason: %s", value)
+	}
+
+	reason := uint(code)
+	for _, known := range revocationReasons {
+		if reason == known {
+			return &reason, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unsupported revocation reason: %s", value)
+}
+
+// revocationReasonName returns the name of a revocation reason code.
+func revocationReasonName(code uint) string {
+	for name, reason := range revocationReasons {
+		if reason == code {
+			return name
+		}
+	}
+
+	return strconv.FormatUint(uint64(code), 10)
+}
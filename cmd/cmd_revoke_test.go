@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-acme/lego/v3/acme"
+	"github.com/go-acme/lego/v3/certcrypto"
+	"github.com/go-acme/lego/v3/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseRevocationReason(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		value    string
+		expected uint
+		err      string
+	}{
+		{desc: "name", value: "keyCompromise", expected: acme.CRLReasonKeyCompromise},
+		{desc: "name lower case", value: "superseded", expected: acme.CRLReasonSuperseded},
+		{desc: "code", value: "5", expected: acme.CRLReasonCessationOfOperation},
+		{desc: "unknown name", value: "foo", err: "unsupported revocation reason: foo"},
+		{desc: "unknown code", value: "7", err: "unsupported revocation reason: 7"},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			reason, err := parseRevocationReason(test.value)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, reason)
+			assert.Equal(t, test.expected, *reason)
+		})
+	}
+
+	reason, err := parseRevocationReason("")
+	require.NoError(t, err)
+	assert.Nil(t, reason)
+}
+
+func Test_findCertificateBySerial(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-revoke")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	certsStorage := &CertificatesStorage{backend: storage.NewFileSystem(dir)}
+
+	current := writeTestCertificate(t, certsStorage, "certificates/example.com.crt")
+	archived := writeTestCertificate(t, certsStorage, "archives/1500000000.example.org.crt")
+
+	target, err := findCertificateBySerial(certsStorage, current)
+	require.NoError(t, err)
+
+	expected := revocationTarget{
+		domain:   "example.com",
+		name:     "certificates/example.com.crt",
+		certPath: filepath.Join(dir, baseCertificatesFolderName, "example.com.crt"),
+	}
+	assert.Equal(t, expected, target)
+
+	target, err = findCertificateBySerial(certsStorage, archived)
+	require.NoError(t, err)
+
+	expected = revocationTarget{
+		name:     "archives/1500000000.example.org.crt",
+		certPath: filepath.Join(dir, baseArchivesFolderName, "1500000000.example.org.crt"),
+	}
+	assert.Equal(t, expected, target)
+
+	_, err = findCertificateBySerial(certsStorage, "01")
+	require.EqualError(t, err, "no certificate found with the serial number 01")
+}
+
+// writeTestCertificate saves a self-signed certificate in the storage and returns its serial number.
+func writeTestCertificate(t *testing.T, certsStorage *CertificatesStorage, name string) string {
+	t.Helper()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	pemCert, err := certcrypto.GeneratePemCert(privateKey, "example.com", nil)
+	require.NoError(t, err)
+
+	err = certsStorage.backend.Save(name, pemCert)
+	require.NoError(t, err)
+
+	cert, err := certcrypto.ParsePEMCertificate(pemCert)
+	require.NoError(t, err)
+
+	return cert.SerialNumber.Text(16)
+}
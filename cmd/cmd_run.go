@@ -2,10 +2,12 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 
+	"github.com/go-acme/lego/v3/certcrypto"
 	"github.com/go-acme/lego/v3/certificate"
 	"github.com/go-acme/lego/v3/lego"
 	"github.com/go-acme/lego/v3/log"
@@ -18,6 +20,10 @@ func createRun() cli.Command {
 		Name:  "run",
 		Usage: "Register an account, then create and install a certificate",
 		Before: func(ctx *cli.Context) error {
+			if ctx.GlobalIsSet("manifest") {
+				return nil
+			}
+
 			// we require either domains or csr, but not both
 			hasDomains := len(ctx.GlobalStringSlice("domains")) > 0
 			hasCsr := len(ctx.GlobalString("csr")) > 0
@@ -30,7 +36,7 @@ func createRun() cli.Command {
 			return nil
 		},
 		Action: run,
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			cli.BoolFlag{
 				Name:  "no-bundle",
 				Usage: "Do not create a certificate bundle by adding the issuers certificate to the new certificate.",
@@ -39,93 +45,175 @@ func createRun() cli.Command {
 				Name:  "must-staple",
 				Usage: "Include the OCSP must staple TLS extension in the CSR and generated certificate. Only works if the CSR is generated by lego.",
 			},
-		},
+		}, createHookFlags()...),
 	}
 }
 
 func run(ctx *cli.Context) error {
-	accountsStorage := NewAccountsStorage(ctx)
+	if ctx.GlobalIsSet("manifest") {
+		return runManifest(ctx, run)
+	}
 
-	account, client := setup(ctx, accountsStorage)
-	setupChallenges(ctx, client)
+	accountsStorage, err := NewAccountsStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	account, client, err := setup(ctx, accountsStorage)
+	if err != nil {
+		return err
+	}
+
+	err = setupChallenges(ctx, client)
+	if err != nil {
+		return err
+	}
 
 	if account.Registration == nil {
-		reg, err := register(ctx, client)
+		err = registerAccount(ctx, client, account, accountsStorage)
 		if err != nil {
-			log.Fatalf("Could not complete registration\n\t%v", err)
+			return err
 		}
+	}
+
+	certsStorage, err := NewCertificatesStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	cert, err := obtainCertificate(ctx, client)
+	if err != nil {
+		// Make sure to return a non-zero exit code if ObtainSANCertificate returned at least one error.
+		// Due to us not returning partial certificate we can just return here instead of at the end.
+		return failedResult(ctx, &certificateResult{Domains: ctx.GlobalStringSlice("domains")}, fmt.Errorf("could not obtain certificates:\n\t%v", err))
+	}
 
-		account.Registration = reg
+	return saveCertificate(ctx, certsStorage, cert, statusObtained)
+}
 
-		if err = accountsStorage.Save(account); err != nil {
-			log.Fatal(err)
+// saveCertificate saves the certificate, writes the result with the JSON output, sends the success notification,
+// then executes the post-hook and the renew-hook.
+// With --dry-run, the files which would be written are only reported.
+func saveCertificate(ctx *cli.Context, certsStorage *CertificatesStorage, certRes *certificate.Resource, status string) error {
+	if isDryRun(ctx) {
+		return reportDryRun(ctx, certsStorage, certRes, status)
+	}
+
+	result, err := storeCertificate(ctx, certsStorage, certRes, status)
+	if err != nil {
+		return failedResult(ctx, result, err)
+	}
+
+	printResult(ctx, result)
+
+	notify(ctx, notifyEventSuccess, result)
+
+	return launchPostHooks(ctx, result)
+}
+
+// storeCertificate saves the certificate (and the backup of the previous files, --backups),
+// then writes it in the Kubernetes TLS secret (--kubernetes.secret).
+func storeCertificate(ctx *cli.Context, certsStorage *CertificatesStorage, certRes *certificate.Resource, status string) (*certificateResult, error) {
+	// --backups is an option of renew and daemon, not of run.
+	if backups := ctx.Int("backups"); backups > 0 {
+		err := certsStorage.backupResource(certRes.Domain, backups)
+		if err != nil {
+			return &certificateResult{Domain: certRes.Domain}, fmt.Errorf("unable to save the backup of the certificate for domain %s\n\t%v", certRes.Domain, err)
 		}
+	}
 
-		fmt.Println("!!!! HEADS UP !!!!")
-		fmt.Printf(`
-		Your account credentials have been saved in your Let's Encrypt
-		configuration directory at "%s".
-		You should make a secure backup	of this folder now. This
-		configuration directory will also contain certificates and
-		private keys obtained from Let's Encrypt so making regular
-		backups of this folder is ideal.`, accountsStorage.GetRootPath())
+	err := certsStorage.SaveResource(certRes)
+	if err != nil {
+		return &certificateResult{Domain: certRes.Domain}, err
 	}
 
-	certsStorage := NewCertificatesStorage(ctx)
-	certsStorage.CreateRootFolder()
+	result := newCertificateResult(certsStorage, certRes, status)
 
-	cert, err := obtainCertificate(ctx, client)
+	if isKubernetesOutput(ctx) {
+		secret, err := writeKubernetesSecret(ctx, certRes)
+		if err != nil {
+			return result, fmt.Errorf("unable to write the Kubernetes TLS secret for domain %s\n\t%v", certRes.Domain, err)
+		}
+
+		result.KubernetesSecret = secret
+
+		log.Printf("[%s] The certificate has been written in the Kubernetes TLS secret %s", certRes.Domain, secret)
+	}
+
+	return result, nil
+}
+
+// registerAccount registers the account and saves it.
+func registerAccount(ctx *cli.Context, client *lego.Client, account *Account, accountsStorage *AccountsStorage) error {
+	reg, err := register(ctx, client)
 	if err != nil {
-		// Make sure to return a non-zero exit code if ObtainSANCertificate returned at least one error.
-		// Due to us not returning partial certificate we can just exit here instead of at the end.
-		log.Fatalf("Could not obtain certificates:\n\t%v", err)
+		return fmt.Errorf("could not complete registration: %v", err)
+	}
+
+	account.Registration = reg
+
+	if err = accountsStorage.Save(account); err != nil {
+		return err
 	}
 
-	certsStorage.SaveResource(cert)
+	out := messageOutput(ctx)
+
+	fmt.Fprintln(out, "!!!! HEADS UP !!!!")
+	fmt.Fprintf(out, `
+		Your account credentials have been saved in your Let's Encrypt
+		configuration directory at "%s".
+		You should make a secure backup	of this folder now. This
+		configuration directory will also contain certificates and
+		private keys obtained from Let's Encrypt so making regular
+		backups of this folder is ideal.`, accountsStorage.GetRootPath())
 
 	return nil
 }
 
-func handleTOS(ctx *cli.Context, client *lego.Client) bool {
+func handleTOS(ctx *cli.Context, client *lego.Client) (bool, error) {
 	// Check for a global accept override
 	if ctx.GlobalBool("accept-tos") {
-		return true
+		return true, nil
 	}
 
 	reader := bufio.NewReader(os.Stdin)
 	log.Printf("Please review the TOS at %s", client.GetToSURL())
 
+	out := messageOutput(ctx)
+
 	for {
-		fmt.Println("Do you accept the TOS? Y/n")
+		fmt.Fprintln(out, "Do you accept the TOS? Y/n")
 		text, err := reader.ReadString('\n')
 		if err != nil {
-			log.Fatalf("Could not read from console: %v", err)
+			return false, fmt.Errorf("could not read from console: %v", err)
 		}
 
 		text = strings.Trim(text, "\r\n")
 		switch text {
 		case "", "y", "Y":
-			return true
+			return true, nil
 		case "n", "N":
-			return false
+			return false, nil
 		default:
-			fmt.Println("Your input was invalid. Please answer with one of Y/y, n/N or by pressing enter.")
+			fmt.Fprintln(out, "Your input was invalid. Please answer with one of Y/y, n/N or by pressing enter.")
 		}
 	}
 }
 
 func register(ctx *cli.Context, client *lego.Client) (*registration.Resource, error) {
-	accepted := handleTOS(ctx, client)
-	if !accepted {
-		log.Fatal("You did not accept the TOS. Unable to proceed.")
+	accepted, err := handleTOS(ctx, client)
+	if err != nil {
+		return nil, err
 	}
 
-	if ctx.GlobalBool("eab") {
-		kid := ctx.GlobalString("kid")
-		hmacEncoded := ctx.GlobalString("hmac")
+	if !accepted {
+		return nil, errors.New("you did not accept the TOS: unable to proceed")
+	}
 
-		if kid == "" || hmacEncoded == "" {
-			log.Fatalf("Requires arguments --kid and --hmac.")
+	if requiresEAB(ctx) {
+		kid, hmacEncoded, err := getEABCredentials(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("could not get the External Account Binding credentials: %v", err)
 		}
 
 		return client.Registration.RegisterWithExternalAccountBinding(registration.RegisterEABOptions{
@@ -149,7 +237,9 @@ func obtainCertificate(ctx *cli.Context, client *lego.Client) (*certificate.Reso
 			Bundle:     bundle,
 			MustStaple: ctx.Bool("must-staple"),
 		}
-		return client.Certificate.Obtain(request)
+		return obtainWithHooks(ctx, newRequestHookMeta(domains), func() (*certificate.Resource, error) {
+			return client.Certificate.Obtain(request)
+		})
 	}
 
 	// read the CSR
@@ -159,5 +249,7 @@ func obtainCertificate(ctx *cli.Context, client *lego.Client) (*certificate.Reso
 	}
 
 	// obtain a certificate for this CSR
-	return client.Certificate.ObtainForCSR(*csr, bundle)
+	return obtainWithHooks(ctx, newRequestHookMeta(certcrypto.ExtractDomainsCSR(csr)), func() (*certificate.Resource, error) {
+		return client.Certificate.ObtainForCSR(*csr, bundle)
+	})
 }
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli"
+)
+
+// stdinFileName the name of the files read from stdin.
+const stdinFileName = "-"
+
+// loadDomainsFile adds the domains of the file defined by --domains-file to --domains.
+func loadDomainsFile(ctx *cli.Context) error {
+	filename := ctx.GlobalString("domains-file")
+	if filename == "" {
+		return nil
+	}
+
+	domains, err := readDomainsFile(filename)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool)
+	for _, domain := range ctx.GlobalStringSlice("domains") {
+		existing[domain] = true
+	}
+
+	for _, domain := range domains {
+		if existing[domain] {
+			continue
+		}
+		existing[domain] = true
+
+		if err = ctx.GlobalSet("domains", domain); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readDomainsFile reads the domains of a file, or of stdin if the name of the file is "-".
+func readDomainsFile(filename string) ([]string, error) {
+	if filename == stdinFileName {
+		data, err := readStdin()
+		if err != nil {
+			return nil, err
+		}
+
+		return parseDomains(bytes.NewReader(data))
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the domains file: %v", err)
+	}
+	defer file.Close()
+
+	domains, err := parseDomains(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the domains file %s: %v", filename, err)
+	}
+
+	return domains, nil
+}
+
+// parseDomains reads a list of domains: the domains are separated by new lines, spaces or commas,
+// the blank lines are ignored and the comments start with '#'.
+func parseDomains(r io.Reader) ([]string, error) {
+	var domains []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t' || r == '\r'
+		})
+
+		domains = append(domains, fields...)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return domains, nil
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+func Test_parseDomains(t *testing.T) {
+	content := `# the domains of example.com
+example.com
+www.example.com # the comments are ignored
+
+	example.org, www.example.org
+example.net	www.example.net` + "\r\n"
+
+	domains, err := parseDomains(strings.NewReader(content))
+	require.NoError(t, err)
+
+	expected := []string{"example.com", "www.example.com", "example.org", "www.example.org", "example.net", "www.example.net"}
+	assert.Equal(t, expected, domains)
+}
+
+func Test_loadDomainsFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-domains")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	filename := filepath.Join(dir, "domains.txt")
+
+	err = ioutil.WriteFile(filename, []byte("example.com\nwww.example.com\n\nexample.org\n"), filePerm)
+	require.NoError(t, err)
+
+	set, err := newFlagSet("lego", CreateFlags(""), []string{"--domains=example.com", "--domains-file=" + filename})
+	require.NoError(t, err)
+
+	commandSet, err := newFlagSet("run", nil, nil)
+	require.NoError(t, err)
+
+	app := cli.NewApp()
+	app.Flags = CreateFlags("")
+
+	ctx := cli.NewContext(app, commandSet, cli.NewContext(app, set, nil))
+
+	err = loadDomainsFile(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"example.com", "www.example.com", "example.org"}, ctx.GlobalStringSlice("domains"))
+
+	// the domains of the file are not added again to the certificates of a manifest.
+	assert.Equal(t, []string{"--domains=example.com", "--domains=www.example.com", "--domains=example.org"},
+		contextArgs(ctx.GlobalFlagNames(), ctx.GlobalIsSet, ctx.GlobalGeneric))
+}
+
+func Test_loadDomainsFile_notFound(t *testing.T) {
+	set, err := newFlagSet("lego", CreateFlags(""), []string{"--domains-file=/does/not/exist.txt"})
+	require.NoError(t, err)
+
+	err = loadDomainsFile(cli.NewContext(cli.NewApp(), set, nil))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to read the domains file")
+}
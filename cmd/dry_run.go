@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"github.com/go-acme/lego/v3/certificate"
+	"github.com/go-acme/lego/v3/log"
+	"github.com/go-acme/lego/v3/storage"
+	"github.com/urfave/cli"
+)
+
+// Actions of the files changed by a certificate.
+const (
+	fileCreate  = "create"
+	fileReplace = "replace"
+)
+
+// fileChange a file which would be written by a certificate (--dry-run).
+type fileChange struct {
+	Action string `json:"action"`
+	Path   string `json:"path"`
+}
+
+// isDryRun returns true if the certificate is only obtained to test the configuration (--dry-run).
+func isDryRun(ctx *cli.Context) bool {
+	return ctx.GlobalBool("dry-run")
+}
+
+// reportDryRun reports the files which would be written by the certificate instead of saving it,
+// the changes are not reported if the certificate doesn't need to be renewed.
+func reportDryRun(ctx *cli.Context, certsStorage *CertificatesStorage, certRes *certificate.Resource, status string) error {
+	result := newCertificateResult(certsStorage, certRes, status)
+	result.DryRun = true
+
+	if status == statusSkipped {
+		log.Printf("[%s] dry-run: the configuration is valid, no files would be changed.", certRes.Domain)
+	} else {
+		changes, err := certsStorage.resourceChanges(certRes)
+		if err != nil {
+			return failedResult(ctx, result, err)
+		}
+
+		result.Changes = changes
+
+		log.Printf("[%s] dry-run: the configuration is valid, the following files would be changed:", certRes.Domain)
+		for _, change := range result.Changes {
+			log.Printf("[%s] dry-run: %s %s", certRes.Domain, change.Action, change.Path)
+		}
+	}
+
+	printResult(ctx, result)
+
+	return nil
+}
+
+// resourceChanges returns the files which would be written by SaveResource.
+func (s *CertificatesStorage) resourceChanges(certRes *certificate.Resource) ([]fileChange, error) {
+	extensions := []string{".crt"}
+
+	if certRes.IssuerCertificate != nil {
+		extensions = append(extensions, ".issuer.crt")
+	}
+
+	if certRes.PrivateKey != nil {
+		extensions = append(extensions, ".key")
+
+		if s.pem {
+			extensions = append(extensions, ".pem")
+		}
+
+		if s.haproxy {
+			extensions = append(extensions, ".haproxy.pem")
+		}
+
+		if s.pfx {
+			extensions = append(extensions, ".pfx")
+		}
+
+		if extension := s.keyStoreFileExtension(); extension != "" {
+			extensions = append(extensions, extension)
+		}
+	}
+
+	extensions = append(extensions, ".json")
+
+	layoutNames, err := s.newLayoutNames(certRes)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []fileChange
+	for _, extension := range extensions {
+		name, ok := layoutNames[extension]
+		if !ok {
+			name, err = s.getName(certRes.Domain, extension)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		action := fileCreate
+		if exists, err := storage.Exists(s.backend, name); err == nil && exists {
+			action = fileReplace
+		}
+
+		changes = append(changes, fileChange{Action: action, Path: s.backend.Location(name)})
+	}
+
+	return changes, nil
+}
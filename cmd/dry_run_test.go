@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-acme/lego/v3/certcrypto"
+	"github.com/go-acme/lego/v3/certificate"
+	"github.com/go-acme/lego/v3/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+func TestCertificatesStorage_resourceChanges(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-dry-run")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	certsStorage := &CertificatesStorage{backend: storage.NewFileSystem(dir), pem: true}
+
+	err = certsStorage.WriteFile("example.com", ".crt", []byte("cert"))
+	require.NoError(t, err)
+
+	root := filepath.Join(dir, baseCertificatesFolderName)
+
+	certRes := &certificate.Resource{
+		Domain:      "example.com",
+		Certificate: []byte("cert"),
+		PrivateKey:  []byte("key"),
+	}
+
+	expected := []fileChange{
+		{Action: fileReplace, Path: filepath.Join(root, "example.com.crt")},
+		{Action: fileCreate, Path: filepath.Join(root, "example.com.key")},
+		{Action: fileCreate, Path: filepath.Join(root, "example.com.pem")},
+		{Action: fileCreate, Path: filepath.Join(root, "example.com.json")},
+	}
+
+	changes, err := certsStorage.resourceChanges(certRes)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, changes)
+
+	// CSR: the private key is unknown.
+	certRes.PrivateKey = nil
+	certRes.IssuerCertificate = []byte("issuer")
+
+	expected = []fileChange{
+		{Action: fileReplace, Path: filepath.Join(root, "example.com.crt")},
+		{Action: fileCreate, Path: filepath.Join(root, "example.com.issuer.crt")},
+		{Action: fileCreate, Path: filepath.Join(root, "example.com.json")},
+	}
+
+	changes, err = certsStorage.resourceChanges(certRes)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, changes)
+}
+
+func Test_reportDryRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-dry-run")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	cert, err := certcrypto.GeneratePemCert(privateKey, "example.com", nil)
+	require.NoError(t, err)
+
+	certRes := &certificate.Resource{
+		Domain:      "example.com",
+		Certificate: cert,
+		PrivateKey:  certcrypto.PEMEncode(privateKey),
+	}
+
+	set, err := newFlagSet("lego", CreateFlags(""), []string{"--dry-run", "--output=json"})
+	require.NoError(t, err)
+
+	ctx := cli.NewContext(cli.NewApp(), set, nil)
+
+	previous := jsonOutput
+	defer func() { jsonOutput = previous }()
+
+	testCases := []struct {
+		desc    string
+		status  string
+		changes int
+	}{
+		{desc: "obtained", status: statusObtained, changes: 3},
+		{desc: "not due", status: statusSkipped},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			jsonOutput = out
+
+			err = reportDryRun(ctx, &CertificatesStorage{backend: storage.NewFileSystem(dir)}, certRes, test.status)
+			require.NoError(t, err)
+
+			result := &certificateResult{}
+			err = json.Unmarshal(out.Bytes(), result)
+			require.NoError(t, err)
+
+			assert.True(t, result.DryRun)
+			assert.Equal(t, test.status, result.Status)
+			assert.Len(t, result.Changes, test.changes)
+
+			files, err := ioutil.ReadDir(dir)
+			require.NoError(t, err)
+			assert.Empty(t, files)
+		})
+	}
+}
@@ -0,0 +1,353 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/go-acme/lego/v3/certcrypto"
+	"github.com/go-acme/lego/v3/certificate"
+	"github.com/go-acme/lego/v3/storage"
+)
+
+// layoutRecordExtension the extension of the record of the names of the files written with the templates (certificates/<domain>.layout.json):
+// the names depending on the certificate (Serial, Date) are only known by this record.
+const layoutRecordExtension = ".layout.json"
+
+// fileLayoutKinds the files of a certificate which can be named by a template (--file-layout), and their extensions.
+var fileLayoutKinds = map[string]string{
+	"certificate": ".crt",
+	"issuer":      ".issuer.crt",
+	"key":         ".key",
+	"pem":         ".pem",
+	"haproxy":     ".haproxy.pem",
+	"pfx":         ".pfx",
+	"jks":         ".jks",
+	"p12":         ".p12",
+}
+
+// fileLayoutData the data of the templates of the file names.
+type fileLayoutData struct {
+	// Domain the main domain, as in the default file names (i.e. _.example.com for *.example.com).
+	Domain string
+	// Serial the serial number of the certificate in hexadecimal, as displayed by the list command.
+	Serial string
+	// Date the date of issuance of the certificate (YYYY-MM-DD, UTC).
+	Date string
+}
+
+// The samples used to check the templates, and to find the templates depending on the certificate.
+var (
+	sampleLayoutData      = fileLayoutData{Domain: "example.com", Serial: "3a5c0c3e64c3a9b2", Date: "2020-01-01"}
+	otherSampleLayoutData = fileLayoutData{Domain: "example.com", Serial: "4b6d1d4f75d4bac3", Date: "2020-01-02"}
+)
+
+// parseFileLayout parses the templates of the file names (kind=template), the keys of the result are the extensions of the files.
+func parseFileLayout(values []string) (map[string]*template.Template, error) {
+	layout := map[string]*template.Template{}
+	sampleNames := map[string]string{}
+
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, fmt.Errorf("invalid file layout: %s (the format is kind=template)", value)
+		}
+
+		kind := strings.ToLower(strings.TrimSpace(parts[0]))
+
+		extension, ok := fileLayoutKinds[kind]
+		if !ok {
+			return nil, fmt.Errorf("unsupported kind of file: %s (supported: %s)", kind, strings.Join(supportedFileLayoutKinds(), ", "))
+		}
+
+		if _, dup := layout[extension]; dup {
+			return nil, fmt.Errorf("the file %s is defined twice", kind)
+		}
+
+		tmpl, err := template.New(kind).Parse(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid template of the file %s: %v", kind, err)
+		}
+
+		sample, err := executeFileLayout(tmpl, sampleLayoutData)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template of the file %s: %v", kind, err)
+		}
+
+		if other, dup := sampleNames[sample]; dup {
+			return nil, fmt.Errorf("the files %s and %s have the same name: %s", other, kind, sample)
+		}
+
+		sampleNames[sample] = kind
+		layout[extension] = tmpl
+	}
+
+	return layout, nil
+}
+
+// executeFileLayout returns the name of a file: a name relative to the root of the storage,
+// or an absolute path of the local file system (outside the storage).
+func executeFileLayout(tmpl *template.Template, data fileLayoutData) (string, error) {
+	buf := &bytes.Buffer{}
+
+	err := tmpl.Execute(buf, data)
+	if err != nil {
+		return "", err
+	}
+
+	raw := strings.TrimSpace(buf.String())
+	if strings.HasSuffix(raw, "/") || strings.HasSuffix(raw, string(filepath.Separator)) {
+		return "", fmt.Errorf("the name is not a file: %q", buf.String())
+	}
+
+	if filepath.IsAbs(raw) {
+		return filepath.ToSlash(filepath.Clean(raw)), nil
+	}
+
+	name := path.Clean(raw)
+
+	switch {
+	case name == ".":
+		return "", fmt.Errorf("the name is not a file: %q", buf.String())
+	case path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../"):
+		return "", fmt.Errorf("the name must be an absolute path or relative to the root of the storage: %s", name)
+	case name == baseAccountsRootFolderName || strings.HasPrefix(name, baseAccountsRootFolderName+"/"):
+		return "", fmt.Errorf("the name must not be in the accounts directory: %s", name)
+	}
+
+	return name, nil
+}
+
+// dependsOnCertificate returns true if the name defined by a template depends on the certificate (Serial, Date).
+func dependsOnCertificate(tmpl *template.Template) bool {
+	sample, err := executeFileLayout(tmpl, sampleLayoutData)
+	if err != nil {
+		return false
+	}
+
+	other, err := executeFileLayout(tmpl, otherSampleLayoutData)
+	if err != nil {
+		return false
+	}
+
+	return sample != other
+}
+
+// newLayoutNames returns the names of the files of a certificate defined by the templates (--file-layout).
+func (s *CertificatesStorage) newLayoutNames(certRes *certificate.Resource) (map[string]string, error) {
+	if len(s.layout) == 0 {
+		return nil, nil
+	}
+
+	safe, err := sanitizedDomain(certRes.Domain)
+	if err != nil {
+		return nil, err
+	}
+
+	data := fileLayoutData{Domain: safe}
+
+	for _, tmpl := range s.layout {
+		if !dependsOnCertificate(tmpl) {
+			continue
+		}
+
+		cert, err := certcrypto.ParsePEMCertificate(certRes.Certificate)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse the certificate: %v", err)
+		}
+
+		data.Serial = fmt.Sprintf("%x", cert.SerialNumber)
+		data.Date = cert.NotBefore.UTC().Format("2006-01-02")
+
+		break
+	}
+
+	names := map[string]string{}
+	for extension, tmpl := range s.layout {
+		name, err := executeFileLayout(tmpl, data)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get the name of the file %s for domain %s: %v", extension, certRes.Domain, err)
+		}
+
+		names[extension] = name
+	}
+
+	return names, nil
+}
+
+// loadLayoutNames returns the names of the files written with the templates for a domain:
+// the names of the certificate being saved, or the names of the record of the last saved certificate.
+func (s *CertificatesStorage) loadLayoutNames(domain string) (map[string]string, error) {
+	if names, ok := s.layoutNames[domain]; ok {
+		return names, nil
+	}
+
+	recordName, err := s.getStoredName(domain, layoutRecordExtension)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.backend.Load(recordName)
+	if err == storage.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names map[string]string
+	err = json.Unmarshal(data, &names)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the names of the files of the domain %s: %v", domain, err)
+	}
+
+	return names, nil
+}
+
+// saveLayoutRecord writes the names of the files written with the templates for a domain.
+func (s *CertificatesStorage) saveLayoutRecord(domain string, names map[string]string) error {
+	data, err := json.MarshalIndent(names, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	recordName, err := s.getStoredName(domain, layoutRecordExtension)
+	if err != nil {
+		return err
+	}
+
+	return s.backend.Save(recordName, data)
+}
+
+// layoutName returns the name of a file of a certificate defined by a template (--file-layout),
+// false if the file is not defined by a template.
+func (s *CertificatesStorage) layoutName(domain, extension string) (string, bool, error) {
+	tmpl, ok := s.layout[extension]
+	if !ok {
+		return "", false, nil
+	}
+
+	names, err := s.loadLayoutNames(domain)
+	if err != nil {
+		return "", true, err
+	}
+
+	if name, ok := names[extension]; ok {
+		return name, true, nil
+	}
+
+	if dependsOnCertificate(tmpl) {
+		// no certificate has been saved with the template: the file is at its default location.
+		return "", false, nil
+	}
+
+	safe, err := sanitizedDomain(domain)
+	if err != nil {
+		return "", true, err
+	}
+
+	name, err := executeFileLayout(tmpl, fileLayoutData{Domain: safe})
+	if err != nil {
+		return "", true, fmt.Errorf("unable to get the name of the file %s for domain %s: %v", extension, domain, err)
+	}
+
+	return name, true, nil
+}
+
+// archiveBaseName returns the base name of the file of a certificate in the archives and the backups.
+func (s *CertificatesStorage) archiveBaseName(domain, extension string) (string, error) {
+	if _, ok := s.layout[extension]; ok {
+		// the names of the templates are not unique (i.e. example.com/fullchain.pem).
+		safe, err := sanitizedDomain(domain)
+		if err != nil {
+			return "", err
+		}
+
+		return safe + extension, nil
+	}
+
+	name, err := s.getName(domain, extension)
+	if err != nil {
+		return "", err
+	}
+
+	return path.Base(name), nil
+}
+
+func supportedFileLayoutKinds() []string {
+	var kinds []string
+	for kind := range fileLayoutKinds {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	return kinds
+}
+
+// isAbsoluteName returns true if a name defined by a template is an absolute path of the local file system.
+func isAbsoluteName(name string) bool {
+	return filepath.IsAbs(filepath.FromSlash(name))
+}
+
+// layoutBackend the backend of a certificates storage with templates (--file-layout):
+// the absolute names are files of the local file system, the other names are files of the storage.
+type layoutBackend struct {
+	storage.Backend
+	local *storage.FileSystem
+}
+
+func newLayoutBackend(backend storage.Backend) *layoutBackend {
+	return &layoutBackend{Backend: backend, local: storage.NewFileSystem("")}
+}
+
+// Load implements storage.Backend.
+func (b *layoutBackend) Load(name string) ([]byte, error) {
+	return b.target(name).Load(name)
+}
+
+// Save implements storage.Backend.
+func (b *layoutBackend) Save(name string, data []byte) error {
+	return b.target(name).Save(name, data)
+}
+
+// Delete implements storage.Backend.
+func (b *layoutBackend) Delete(name string) error {
+	return b.target(name).Delete(name)
+}
+
+// Location implements storage.Backend.
+func (b *layoutBackend) Location(name string) string {
+	return b.target(name).Location(name)
+}
+
+// Rename implements storage.Renamer, the file is copied when it is moved between the storage and the local file system.
+func (b *layoutBackend) Rename(oldName, newName string) error {
+	if isAbsoluteName(oldName) == isAbsoluteName(newName) {
+		return storage.Move(b.target(oldName), oldName, newName)
+	}
+
+	data, err := b.Load(oldName)
+	if err != nil {
+		return err
+	}
+
+	err = b.Save(newName, data)
+	if err != nil {
+		return err
+	}
+
+	return b.Delete(oldName)
+}
+
+func (b *layoutBackend) target(name string) storage.Backend {
+	if isAbsoluteName(name) {
+		return b.local
+	}
+
+	return b.Backend
+}
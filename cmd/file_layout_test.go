@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"text/template"
+
+	"github.com/go-acme/lego/v3/certcrypto"
+	"github.com/go-acme/lego/v3/certificate"
+	"github.com/go-acme/lego/v3/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseFileLayout(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		values    []string
+		expected  map[string]string
+		expectErr string
+	}{
+		{
+			desc:     "no layout",
+			expected: map[string]string{},
+		},
+		{
+			desc:   "layout",
+			values: []string{"certificate=live/{{ .Domain }}/fullchain.pem", "Key=live/{{ .Domain }}/privkey.pem", "pfx={{ .Domain }}.p12"},
+			expected: map[string]string{
+				".crt": "live/_.example.com/fullchain.pem",
+				".key": "live/_.example.com/privkey.pem",
+				".pfx": "_.example.com.p12",
+			},
+		},
+		{
+			desc:   "certificate fields",
+			values: []string{"certificate=live/{{ .Domain }}/{{ .Date }}-{{ .Serial }}.crt"},
+			expected: map[string]string{
+				".crt": "live/_.example.com/2020-01-01-ab12.crt",
+			},
+		},
+		{
+			desc:   "absolute",
+			values: []string{"key=/etc/ssl/{{ .Domain }}.key"},
+			expected: map[string]string{
+				".key": "/etc/ssl/_.example.com.key",
+			},
+		},
+		{
+			desc:      "invalid format",
+			values:    []string{"certificate"},
+			expectErr: "invalid file layout: certificate (the format is kind=template)",
+		},
+		{
+			desc:      "unsupported kind",
+			values:    []string{"json={{ .Domain }}.json"},
+			expectErr: "unsupported kind of file: json (supported: certificate, haproxy, issuer, jks, key, p12, pem, pfx)",
+		},
+		{
+			desc:      "defined twice",
+			values:    []string{"key=a/{{ .Domain }}.key", "key=b/{{ .Domain }}.key"},
+			expectErr: "the file key is defined twice",
+		},
+		{
+			desc:      "same name",
+			values:    []string{"certificate=live/{{ .Domain }}.pem", "pem=live/{{ .Domain }}.pem"},
+			expectErr: "the files certificate and pem have the same name: live/example.com.pem",
+		},
+		{
+			desc:      "invalid template",
+			values:    []string{"key={{ .Domain }"},
+			expectErr: `invalid template of the file key: template: key:1: unexpected "}" in operand`,
+		},
+		{
+			desc:      "unknown field",
+			values:    []string{"key={{ .Name }}.key"},
+			expectErr: "invalid template of the file key: template: key:1:3: executing \"key\" at <.Name>: can't evaluate field Name in type cmd.fileLayoutData",
+		},
+		{
+			desc:      "directory",
+			values:    []string{"key=keys/{{ .Domain }}/"},
+			expectErr: `invalid template of the file key: the name is not a file: "keys/example.com/"`,
+		},
+		{
+			desc:      "outside the storage",
+			values:    []string{"key=../{{ .Domain }}.key"},
+			expectErr: "invalid template of the file key: the name must be an absolute path or relative to the root of the storage: ../example.com.key",
+		},
+		{
+			desc:      "accounts",
+			values:    []string{"key=accounts/{{ .Domain }}.key"},
+			expectErr: "invalid template of the file key: the name must not be in the accounts directory: accounts/example.com.key",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			layout, err := parseFileLayout(test.values)
+			if test.expectErr != "" {
+				require.EqualError(t, err, test.expectErr)
+				return
+			}
+
+			require.NoError(t, err)
+
+			names := map[string]string{}
+			for extension, tmpl := range layout {
+				names[extension], err = executeFileLayout(tmpl, fileLayoutData{Domain: "_.example.com", Serial: "ab12", Date: "2020-01-01"})
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, test.expected, names)
+		})
+	}
+}
+
+func TestCertificatesStorage_fileLayout(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-layout")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	layout, err := parseFileLayout([]string{"certificate=live/{{ .Domain }}/fullchain.pem", "key=live/{{ .Domain }}/privkey.pem"})
+	require.NoError(t, err)
+
+	backend := storage.NewFileSystem(dir)
+	certsStorage := &CertificatesStorage{backend: backend, layout: layout}
+
+	require.NoError(t, certsStorage.WriteFile("*.example.com", ".crt", []byte("cert")))
+	require.NoError(t, certsStorage.WriteFile("*.example.com", ".key", []byte("key")))
+	require.NoError(t, certsStorage.WriteFile("*.example.com", ".json", []byte("{}")))
+
+	names, err := backend.List("")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"live/_.example.com/fullchain.pem", "live/_.example.com/privkey.pem", "certificates/_.example.com.json"}, names)
+
+	data, err := certsStorage.ReadFile("*.example.com", ".crt")
+	require.NoError(t, err)
+	assert.Equal(t, "cert", string(data))
+
+	domains, err := certsStorage.listDomains()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"_.example.com"}, domains)
+
+	require.NoError(t, certsStorage.MoveToArchive("*.example.com"))
+
+	names, err = backend.List("")
+	require.NoError(t, err)
+	require.Len(t, names, 3)
+
+	for _, name := range names {
+		assert.Regexp(t, `^archives/\d+\._\.example\.com\.(crt|key|json)$`, name)
+	}
+}
+
+func TestCertificatesStorage_fileLayout_certificateFields(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-layout")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	targetDir, err := ioutil.TempDir("", "lego-layout-target")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(targetDir) }()
+
+	layout, err := parseFileLayout([]string{
+		"certificate=live/{{ .Domain }}/{{ .Date }}-{{ .Serial }}.crt",
+		"key=" + filepath.ToSlash(targetDir) + "/{{ .Domain }}.key",
+	})
+	require.NoError(t, err)
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	certPEM, err := certcrypto.GeneratePemCert(privateKey, "example.com", nil)
+	require.NoError(t, err)
+
+	cert, err := certcrypto.ParsePEMCertificate(certPEM)
+	require.NoError(t, err)
+
+	certRes := &certificate.Resource{
+		Domain:      "example.com",
+		Certificate: certPEM,
+		PrivateKey:  certcrypto.PEMEncode(privateKey),
+	}
+
+	backend := storage.NewFileSystem(dir)
+
+	certsStorage := &CertificatesStorage{backend: newLayoutBackend(backend), layout: layout}
+
+	// no certificate has been saved with the template.
+	exists, err := certsStorage.ExistsFile("example.com", ".crt")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, certsStorage.SaveResource(certRes))
+
+	certName := fmt.Sprintf("live/example.com/%s-%x.crt", cert.NotBefore.UTC().Format("2006-01-02"), cert.SerialNumber)
+
+	names, err := backend.List("")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{certName, "certificates/example.com.layout.json", "certificates/example.com.json"}, names)
+
+	keyData, err := ioutil.ReadFile(filepath.Join(targetDir, "example.com.key"))
+	require.NoError(t, err)
+	assert.Equal(t, certRes.PrivateKey, keyData)
+
+	assert.Equal(t, filepath.Join(dir, filepath.FromSlash(certName)), certsStorage.GetFileName("example.com", ".crt"))
+
+	// the names are read from the record by another storage (i.e. the list and the renew commands).
+	otherStorage := &CertificatesStorage{backend: newLayoutBackend(backend), layout: layout}
+
+	domains, err := otherStorage.listDomains()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example.com"}, domains)
+
+	data, err := otherStorage.ReadFile("example.com", ".crt")
+	require.NoError(t, err)
+	assert.Equal(t, certPEM, data)
+
+	data, err = otherStorage.ReadFile("example.com", ".key")
+	require.NoError(t, err)
+	assert.Equal(t, certRes.PrivateKey, data)
+
+	require.NoError(t, otherStorage.MoveToArchive("example.com"))
+
+	names, err = backend.List("")
+	require.NoError(t, err)
+	require.Len(t, names, 4)
+
+	for _, name := range names {
+		assert.Regexp(t, `^archives/\d+\.example\.com\.(crt|key|json|layout\.json)$`, name)
+	}
+
+	_, err = os.Stat(filepath.Join(targetDir, "example.com.key"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestCertificatesStorage_fileLayout_invalidName(t *testing.T) {
+	// a template producing a name outside of the storage (parseFileLayout only checks the names of example.com).
+	layout := map[string]*template.Template{
+		".crt": template.Must(template.New("certificate").Parse("../{{ .Domain }}.crt")),
+	}
+
+	certsStorage := &CertificatesStorage{backend: storage.NewFileSystem("/tmp/lego"), layout: layout}
+
+	_, err := certsStorage.ExistsFile("example.com", ".crt")
+	require.EqualError(t, err, "unable to get the name of the file .crt for domain example.com: the name must be an absolute path or relative to the root of the storage: ../example.com.crt")
+
+	err = certsStorage.WriteFile("example.com", ".crt", []byte("cert"))
+	require.Error(t, err)
+
+	assert.Empty(t, certsStorage.GetFileName("example.com", ".crt"))
+}
@@ -2,20 +2,37 @@ package cmd
 
 import (
 	"github.com/go-acme/lego/v3/lego"
+	"github.com/go-acme/lego/v3/log"
 	"github.com/urfave/cli"
 )
 
 func CreateFlags(defaultPath string) []cli.Flag {
-	return []cli.Flag{
+	return append([]cli.Flag{
 		cli.StringSliceFlag{
 			Name:  "domains, d",
 			Usage: "Add a domain to the process. Can be specified multiple times.",
 		},
+		cli.StringFlag{
+			Name:  "domains-file",
+			Usage: "Add the domains of a file to the process (- for stdin). The domains are separated by new lines, spaces or commas, the lines starting with '#' are comments.",
+		},
 		cli.StringFlag{
 			Name:  "server, s",
 			Usage: "CA hostname (and optionally :port). The server certificate must be trusted in order to avoid further modifications to the client.",
 			Value: lego.LEDirectoryProduction,
 		},
+		cli.StringFlag{
+			Name:  "ca",
+			Usage: "The CA, instead of --server. Supported: letsencrypt, letsencrypt-staging, zerossl (External Account Binding, the credentials are requested for --email if --kid and --hmac are not defined), buypass.",
+		},
+		cli.BoolFlag{
+			Name:  "staging",
+			Usage: "Use the staging environment of the CA (--ca, Let's Encrypt by default) to test the configuration.",
+		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Test the configuration: the certificate is obtained from the staging environment of the CA (or from --server), the challenges are solved, but the certificate is not saved and the post-hook, the renew-hook and the notifications are not executed. The files which would be written are reported. Used by 'run' and 'renew'.",
+		},
 		cli.BoolFlag{
 			Name:  "accept-tos, a",
 			Usage: "By setting this flag to true you indicate that you accept the current Let's Encrypt terms of service.",
@@ -24,9 +41,33 @@ func CreateFlags(defaultPath string) []cli.Flag {
 			Name:  "email, m",
 			Usage: "Email used for registration and recovery contact.",
 		},
+		cli.StringFlag{
+			Name:  "output",
+			Usage: "The output format of the commands 'run', 'renew', 'revoke', 'list', 'check', 'account show' and 'dns check'. Supported: text, json. With json, the results are written on stdout and the logs on stderr.",
+			Value: outputText,
+		},
+		cli.StringFlag{
+			Name:  "log-format",
+			Usage: "The format of the logs. Supported: text, json (one JSON document per line with the time, the level, the domain and the message).",
+			Value: log.FormatText,
+		},
+		cli.StringFlag{
+			Name:  "log-level",
+			Usage: "The minimum level of the logs. Supported: debug, info, warn, error. The default level is debug if the logging of the DNS provider API calls is enabled (LEGO_DEBUG_DNS_API_HTTP_CLIENT).",
+			Value: "info",
+		},
+		cli.StringFlag{
+			Name:  "manifest",
+			Usage: "Manifest file (TOML, or YAML with the .yaml/.yml extension) describing several certificates, the options of each certificate are the options of the CLI. Used by 'run', 'renew' and 'daemon'.",
+		},
+		cli.IntFlag{
+			Name:  "manifest.workers",
+			Usage: "The number of certificates of the manifest processed concurrently, by separate lego processes. The account must be registered, and the HTTP and TLS challenges require distinct ports.",
+			Value: 1,
+		},
 		cli.StringFlag{
 			Name:  "csr, c",
-			Usage: "Certificate signing request filename, if an external CSR is to be used.",
+			Usage: "Certificate signing request filename (PEM or DER, - for stdin), if an external CSR is to be used.",
 		},
 		cli.BoolFlag{
 			Name:  "eab",
@@ -37,14 +78,25 @@ func CreateFlags(defaultPath string) []cli.Flag {
 			Usage: "Key identifier from External CA. Used for External Account Binding.",
 		},
 		cli.StringFlag{
-			Name:  "hmac",
-			Usage: "MAC key from External CA. Should be in Base64 URL Encoding without padding format. Used for External Account Binding.",
+			Name:   "hmac",
+			Usage:  "MAC key from External CA. Should be in Base64 URL Encoding without padding format. Used for External Account Binding.",
+			EnvVar: "LEGO_EAB_HMAC",
 		},
 		cli.StringFlag{
 			Name:  "key-type, k",
 			Value: "ec384",
 			Usage: "Key type to use for private keys. Supported: rsa2048, rsa4096, rsa8192, ec256, ec384.",
 		},
+		cli.StringFlag{
+			Name:   "account-key.passphrase",
+			Usage:  "Encrypt the private key of the account with this passphrase (encrypted PKCS#8), the private key is decrypted when it's loaded. An existing plaintext private key is encrypted.",
+			EnvVar: "LEGO_ACCOUNT_KEY_PASSPHRASE",
+		},
+		cli.StringFlag{
+			Name:   "account-key.passphrase-file",
+			Usage:  "The file containing the passphrase of the private key of the account, instead of --account-key.passphrase.",
+			EnvVar: "LEGO_ACCOUNT_KEY_PASSPHRASE_FILE",
+		},
 		cli.StringFlag{
 			Name:  "filename",
 			Usage: "(deprecated) Filename of the generated certificate.",
@@ -54,6 +106,11 @@ func CreateFlags(defaultPath string) []cli.Flag {
 			Usage: "Directory to use for storing the data.",
 			Value: defaultPath,
 		},
+		cli.StringFlag{
+			Name:  "storage",
+			Usage: "The storage of the accounts and the certificates. Supported: filesystem (the --path directory), s3 (an Amazon S3 or S3 compatible bucket), gcs (a Google Cloud Storage bucket), vault (HashiCorp Vault KV), consul (HashiCorp Consul KV), etcd (etcd v3), or a storage registered by a third-party package.",
+			Value: defaultStorage,
+		},
 		cli.BoolFlag{
 			Name:  "http",
 			Usage: "Use the HTTP challenge to solve challenges. Can be mixed with other types of challenges.",
@@ -80,6 +137,10 @@ func CreateFlags(defaultPath string) []cli.Flag {
 			Usage: "Set the port and interface to use for TLS based challenges to listen on. Supported: interface:port or :port.",
 			Value: ":443",
 		},
+		cli.StringSliceFlag{
+			Name:  "challenge",
+			Usage: "Select the challenge of a domain and its subdomains, instead of the challenges defined by --http, --tls and --dns. Supported: domain:http, domain:tls, domain:dns, domain:dns:provider (a DNS provider specific to the domain). Can be specified multiple times.",
+		},
 		cli.StringFlag{
 			Name:  "dns",
 			Usage: "Solve a DNS challenge using the specified provider. Can be mixed with other types of challenges. Run 'lego dnshelp' for help on usage.",
@@ -92,6 +153,10 @@ func CreateFlags(defaultPath string) []cli.Flag {
 			Name:  "dns.resolvers",
 			Usage: "Set the resolvers to use for performing recursive DNS queries. Supported: host:port. The default is to use the system resolvers, or Google's DNS resolvers if the system's cannot be determined.",
 		},
+		cli.StringSliceFlag{
+			Name:  "dns.credentials",
+			Usage: "Use a dedicated credential set of the DNS provider for a zone. Supported: zone:set, the set 'foo' is defined by the provider environment variables suffixed by '_FOO'. Can be specified multiple times.",
+		},
 		cli.IntFlag{
 			Name:  "http-timeout",
 			Usage: "Set the HTTP timeout value to a specific value in seconds.",
@@ -105,10 +170,60 @@ func CreateFlags(defaultPath string) []cli.Flag {
 			Name:  "pem",
 			Usage: "Generate a .pem file by concatenating the .key and .crt files together.",
 		},
+		cli.BoolFlag{
+			Name:  "haproxy",
+			Usage: "Generate a .haproxy.pem file with the private key, the certificate and the issuer certificates, in the order expected by HAProxy (crt). The file is written again on each renewal.",
+		},
+		cli.BoolFlag{
+			Name:  "pfx",
+			Usage: "Generate a .pfx (PKCS#12) file with the certificate, the issuer certificates and the private key.",
+		},
+		cli.StringFlag{
+			Name:   "pfx-pass",
+			Usage:  "The password used to encrypt the .pfx (PKCS#12) file.",
+			Value:  "changeit",
+			EnvVar: "LEGO_PFX_PASSWORD",
+		},
+		cli.StringFlag{
+			Name:  "pfx-format",
+			Usage: "The encryption format of the .pfx (PKCS#12) file. Supported: SHA256 (PBES2 with AES-256-CBC and a SHA-256 MAC), DES (3DES with a SHA-1 MAC, for older systems), RC2 (legacy RC2-40 format, requires -legacy with OpenSSL 3).",
+			Value: "SHA256",
+		},
+		cli.StringFlag{
+			Name:  "keystore",
+			Usage: "Generate a Java keystore with the private key, the certificate and the issuer certificates (i.e. for Tomcat, Kafka or Elasticsearch). Supported: jks (.jks file), pkcs12 (.p12 file).",
+		},
+		cli.StringFlag{
+			Name:  "keystore.alias",
+			Usage: "The alias of the private key in the Java keystore (--keystore). The default alias is the main domain.",
+		},
+		cli.StringFlag{
+			Name:   "keystore.password",
+			Usage:  "The password of the Java keystore (--keystore) and of its private key.",
+			Value:  "changeit",
+			EnvVar: "LEGO_KEYSTORE_PASSWORD",
+		},
+		cli.StringSliceFlag{
+			Name:  "file-layout",
+			Usage: "The name of a file of the certificates (kind=template), relative to the root of the storage (--path) or an absolute path, instead of certificates/<domain>.<extension>. Kinds: certificate, issuer, key, pem, haproxy, pfx, jks, p12. The name is a template: {{ .Domain }} the main domain (i.e. _.example.com for *.example.com), {{ .Serial }} the serial number of the certificate (hexadecimal), {{ .Date }} the date of issuance of the certificate (YYYY-MM-DD). Can be specified multiple times.",
+		},
+		cli.StringFlag{
+			Name:  "kubernetes.secret",
+			Usage: "Write the certificate in a Kubernetes TLS secret (kubernetes.io/tls) with this name, in addition to the files. The name is a template: {{ .Domain }} the main domain, {{ .Name }} the main domain as a name of Kubernetes resource (i.e. wildcard.example.com for *.example.com).",
+		},
+		cli.StringFlag{
+			Name:  "kubernetes.namespace",
+			Usage: "The namespace of the Kubernetes TLS secret, a template as --kubernetes.secret. The default namespace is the namespace of the pod (in a cluster) or of the current context of the kubeconfig file.",
+		},
+		cli.StringFlag{
+			Name:   "kubernetes.kubeconfig",
+			Usage:  "The kubeconfig file used to connect to the cluster when lego doesn't run in the cluster (default: $HOME/.kube/config).",
+			EnvVar: "KUBECONFIG",
+		},
 		cli.IntFlag{
 			Name:  "cert.timeout",
 			Usage: "Set the certificate timeout value to a specific value in seconds. Only used when obtaining certificates.",
 			Value: 30,
 		},
-	}
+	}, createNotifyFlags()...)
 }
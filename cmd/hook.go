@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v3/certificate"
+	"github.com/go-acme/lego/v3/log"
+	"github.com/urfave/cli"
+)
+
+// Environment variables exported to the hooks.
+const (
+	hookEnvHook         = "LEGO_HOOK"
+	hookEnvDomain       = "LEGO_CERT_DOMAIN"
+	hookEnvDomains      = "LEGO_CERT_DOMAINS"
+	hookEnvCertPath     = "LEGO_CERT_PATH"
+	hookEnvKeyPath      = "LEGO_CERT_KEY_PATH"
+	hookEnvIssuerPath   = "LEGO_CERT_ISSUER_PATH"
+	hookEnvPEMPath      = "LEGO_CERT_PEM_PATH"
+	hookEnvHAProxyPath  = "LEGO_CERT_HAPROXY_PATH"
+	hookEnvPFXPath      = "LEGO_CERT_PFX_PATH"
+	hookEnvKeyStorePath = "LEGO_CERT_KEYSTORE_PATH"
+	hookEnvSerial       = "LEGO_CERT_SERIAL"
+	hookEnvNotAfter     = "LEGO_CERT_NOT_AFTER"
+	hookEnvError        = "LEGO_ERROR"
+)
+
+const defaultHookTimeout = 2 * time.Minute
+
+// createHookFlags creates the flags of the hooks shared by the commands obtaining certificates.
+func createHookFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "pre-hook",
+			Usage: "Define a hook executed before the validation of the domains, the issuance is aborted if the hook fails.",
+		},
+		cli.DurationFlag{
+			Name:  "pre-hook.timeout",
+			Usage: "The maximum execution time of the pre-hook.",
+			Value: defaultHookTimeout,
+		},
+		cli.StringFlag{
+			Name:  "post-hook",
+			Usage: "Define a hook executed after the issuance of a certificate.",
+		},
+		cli.DurationFlag{
+			Name:  "post-hook.timeout",
+			Usage: "The maximum execution time of the post-hook.",
+			Value: defaultHookTimeout,
+		},
+		cli.StringFlag{
+			Name:  "failure-hook",
+			Usage: "Define a hook executed when a certificate cannot be obtained.",
+		},
+		cli.DurationFlag{
+			Name:  "failure-hook.timeout",
+			Usage: "The maximum execution time of the failure-hook.",
+			Value: defaultHookTimeout,
+		},
+	}
+}
+
+// hookMeta the metadata of a certificate exported to the hooks as environment variables.
+type hookMeta map[string]string
+
+// newRequestHookMeta creates the metadata of a certificate request.
+func newRequestHookMeta(domains []string) hookMeta {
+	meta := hookMeta{hookEnvDomains: strings.Join(domains, ",")}
+
+	if len(domains) > 0 {
+		meta[hookEnvDomain] = domains[0]
+	}
+
+	return meta
+}
+
+// newCertificateHookMeta creates the metadata of an issued certificate.
+func newCertificateHookMeta(result *certificateResult) hookMeta {
+	meta := hookMeta{}
+
+	values := map[string]string{
+		hookEnvDomain:       result.Domain,
+		hookEnvDomains:      strings.Join(result.Domains, ","),
+		hookEnvCertPath:     result.CertPath,
+		hookEnvKeyPath:      result.KeyPath,
+		hookEnvIssuerPath:   result.IssuerPath,
+		hookEnvPEMPath:      result.PEMPath,
+		hookEnvHAProxyPath:  result.HAProxyPath,
+		hookEnvPFXPath:      result.PFXPath,
+		hookEnvKeyStorePath: result.KeyStorePath,
+		hookEnvSerial:       result.Serial,
+	}
+
+	if result.NotAfter != nil {
+		values[hookEnvNotAfter] = result.NotAfter.Format(time.RFC3339)
+	}
+
+	for k, v := range values {
+		if v != "" {
+			meta[k] = v
+		}
+	}
+
+	return meta
+}
+
+func (m hookMeta) withError(err error) hookMeta {
+	meta := hookMeta{hookEnvError: err.Error()}
+	for k, v := range m {
+		meta[k] = v
+	}
+
+	return meta
+}
+
+// environ returns the environment variables of the metadata, sorted by name.
+func (m hookMeta) environ() []string {
+	var env []string
+	for k, v := range m {
+		env = append(env, k+"="+v)
+	}
+
+	sort.Strings(env)
+
+	return env
+}
+
+// launchHook executes the hook defined by the option of the same name, with its timeout (option "<name>.timeout").
+func launchHook(ctx *cli.Context, name string, meta hookMeta) error {
+	hook := ctx.String(name)
+	if hook == "" {
+		return nil
+	}
+
+	timeout := ctx.Duration(name + ".timeout")
+	if timeout <= 0 {
+		timeout = defaultHookTimeout
+	}
+
+	ctxCmd, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	parts := strings.Fields(hook)
+
+	cmd := exec.CommandContext(ctxCmd, parts[0], parts[1:]...)
+	cmd.Env = append(os.Environ(), hookEnvHook+"="+name)
+	cmd.Env = append(cmd.Env, meta.environ()...)
+
+	output, err := cmd.CombinedOutput()
+	if len(output) > 0 {
+		fmt.Fprintln(messageOutput(ctx), string(output))
+	}
+
+	if ctxCmd.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("%s: hook timed out", name)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: %v", name, err)
+	}
+
+	return nil
+}
+
+// launchFailureHook executes the failure hook, the error of the hook is only logged.
+func launchFailureHook(ctx *cli.Context, meta hookMeta, cause error) {
+	err := launchHook(ctx, "failure-hook", meta.withError(cause))
+	if err != nil {
+		log.Printf("[%s] %v", meta[hookEnvDomain], err)
+	}
+}
+
+// launchPostHooks executes the post-hook, then the renew-hook (only defined by the commands renewing a certificate).
+func launchPostHooks(ctx *cli.Context, result *certificateResult) error {
+	meta := newCertificateHookMeta(result)
+
+	err := launchHook(ctx, "post-hook", meta)
+	if err != nil {
+		return err
+	}
+
+	return launchHook(ctx, "renew-hook", meta)
+}
+
+// obtainWithHooks executes the pre-hook, then obtains the certificate.
+// The failure hook is executed if the pre-hook fails or if the certificate cannot be obtained.
+func obtainWithHooks(ctx *cli.Context, meta hookMeta, obtain func() (*certificate.Resource, error)) (*certificate.Resource, error) {
+	err := launchHook(ctx, "pre-hook", meta)
+	if err != nil {
+		err = fmt.Errorf("the issuance has been aborted: %v", err)
+		launchFailureHook(ctx, meta, err)
+		return nil, err
+	}
+
+	certRes, err := obtain()
+	if err != nil {
+		launchFailureHook(ctx, meta, err)
+		return nil, err
+	}
+
+	return certRes, nil
+}
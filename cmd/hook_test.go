@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v3/certcrypto"
+	"github.com/go-acme/lego/v3/certificate"
+	"github.com/go-acme/lego/v3/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+func newHookContext(t *testing.T, args ...string) *cli.Context {
+	t.Helper()
+
+	command := createRenew()
+
+	set, err := newFlagSet(command.Name, command.Flags, args)
+	require.NoError(t, err)
+
+	return cli.NewContext(cli.NewApp(), set, nil)
+}
+
+func Test_newCertificateHookMeta(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	cert, err := certcrypto.GeneratePemCert(privateKey, "example.com", nil)
+	require.NoError(t, err)
+
+	parsed, err := certcrypto.ParsePEMCertificate(cert)
+	require.NoError(t, err)
+
+	certsStorage := &CertificatesStorage{backend: storage.NewFileSystem("/tmp/lego"), pem: true}
+
+	certRes := &certificate.Resource{
+		Domain:      "*.example.com",
+		Certificate: cert,
+		PrivateKey:  certcrypto.PEMEncode(privateKey),
+	}
+
+	meta := newCertificateHookMeta(newCertificateResult(certsStorage, certRes, statusObtained))
+
+	expected := hookMeta{
+		hookEnvDomain:   "*.example.com",
+		hookEnvDomains:  "ACME Challenge TEMP,example.com",
+		hookEnvCertPath: filepath.FromSlash("/tmp/lego/certificates/_.example.com.crt"),
+		hookEnvKeyPath:  filepath.FromSlash("/tmp/lego/certificates/_.example.com.key"),
+		hookEnvPEMPath:  filepath.FromSlash("/tmp/lego/certificates/_.example.com.pem"),
+		hookEnvSerial:   parsed.SerialNumber.Text(16),
+		hookEnvNotAfter: parsed.NotAfter.UTC().Format(time.RFC3339),
+	}
+	assert.Equal(t, expected, meta)
+}
+
+func Test_hookMeta_environ(t *testing.T) {
+	meta := newRequestHookMeta([]string{"example.com", "www.example.com"}).withError(errors.New("boom"))
+
+	expected := []string{
+		"LEGO_CERT_DOMAIN=example.com",
+		"LEGO_CERT_DOMAINS=example.com,www.example.com",
+		"LEGO_ERROR=boom",
+	}
+	assert.Equal(t, expected, meta.environ())
+}
+
+func Test_launchHook(t *testing.T) {
+	if _, err := os.Stat("/bin/sh"); err != nil {
+		t.Skip("requires /bin/sh")
+	}
+
+	dir, err := ioutil.TempDir("", "lego-hook")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	output := filepath.Join(dir, "env.txt")
+
+	script := filepath.Join(dir, "hook.sh")
+	err = ioutil.WriteFile(script, []byte("#!/bin/sh\nenv | grep '^LEGO_' | sort > "+output+"\n"), 0700)
+	require.NoError(t, err)
+
+	ctx := newHookContext(t, "--post-hook="+script)
+
+	err = launchHook(ctx, "post-hook", newRequestHookMeta([]string{"example.com"}))
+	require.NoError(t, err)
+
+	content, err := ioutil.ReadFile(output)
+	require.NoError(t, err)
+
+	expected := []string{
+		"LEGO_CERT_DOMAIN=example.com",
+		"LEGO_CERT_DOMAINS=example.com",
+		"LEGO_HOOK=post-hook",
+	}
+	assert.Equal(t, expected, strings.Split(strings.TrimSpace(string(content)), "\n"))
+}
+
+func Test_launchHook_timeout(t *testing.T) {
+	if _, err := os.Stat("/bin/sleep"); err != nil {
+		t.Skip("requires /bin/sleep")
+	}
+
+	ctx := newHookContext(t, "--pre-hook=/bin/sleep 5", "--pre-hook.timeout=100ms")
+
+	err := launchHook(ctx, "pre-hook", hookMeta{})
+	require.EqualError(t, err, "pre-hook: hook timed out")
+}
+
+func Test_obtainWithHooks_preHookFailure(t *testing.T) {
+	if _, err := os.Stat("/bin/false"); err != nil {
+		t.Skip("requires /bin/false")
+	}
+
+	ctx := newHookContext(t, "--pre-hook=/bin/false")
+
+	var called bool
+	_, err := obtainWithHooks(ctx, hookMeta{}, func() (*certificate.Resource, error) {
+		called = true
+		return &certificate.Resource{}, nil
+	})
+	require.EqualError(t, err, "the issuance has been aborted: pre-hook: exit status 1")
+
+	assert.False(t, called)
+}
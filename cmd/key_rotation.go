@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v3/certcrypto"
+	"github.com/go-acme/lego/v3/log"
+	"github.com/urfave/cli"
+)
+
+// Key rotation policies.
+const (
+	keyRotationAlways = "always"
+	keyRotationNever  = "never"
+)
+
+// keyRotationState the state of the private key of a certificate (<domain>.key-rotation.json).
+type keyRotationState struct {
+	// KeyHash the hash of the public key of the current private key.
+	KeyHash string `json:"keyHash"`
+	// Certificates the number of certificates issued with the current private key.
+	Certificates int `json:"certificates"`
+	// RetainedKeys the previous private keys kept during the grace period.
+	RetainedKeys []retainedKey `json:"retainedKeys,omitempty"`
+}
+
+// retainedKey a previous private key kept in the archives.
+type retainedKey struct {
+	// Name the name of the archived key in the storage.
+	Name     string    `json:"name"`
+	RemoveAt time.Time `json:"removeAt"`
+}
+
+// keyRotation the private key used to renew a certificate, the state is saved by commit once the certificate is renewed.
+type keyRotation struct {
+	certsStorage *CertificatesStorage
+	domain       string
+	grace        time.Duration
+
+	state      *keyRotationState
+	privateKey crypto.PrivateKey
+	rotated    bool
+}
+
+func createKeyRotationFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "key-rotation",
+			Usage: "When to generate a new private key on renewal. Supported: always, never (the private key is reused, as --reuse-key), or a number N (the private key is used by N certificates).",
+			Value: keyRotationAlways,
+		},
+		cli.DurationFlag{
+			Name:  "key-rotation.grace",
+			Usage: "Keep the previous private key in the archives for this duration after a new private key is generated (0 to not keep it).",
+		},
+	}
+}
+
+// getKeyRotation returns the number of certificates issued with a private key: 1 to always generate a new key, 0 to never generate a new key.
+func getKeyRotation(ctx *cli.Context) (int, error) {
+	if ctx.Bool("reuse-key") {
+		if ctx.IsSet("key-rotation") {
+			return 0, errors.New("--reuse-key cannot be used with --key-rotation")
+		}
+
+		return 0, nil
+	}
+
+	value := strings.ToLower(ctx.String("key-rotation"))
+
+	switch value {
+	case keyRotationAlways, "":
+		return 1, nil
+	case keyRotationNever:
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("unsupported key rotation: %s (supported: always, never, or a number greater than 0)", value)
+	}
+
+	return n, nil
+}
+
+// newKeyRotation selects the private key used to renew a certificate:
+// the current private key if it has been used by less certificates than the policy (--key-rotation), otherwise a new key (nil).
+func newKeyRotation(ctx *cli.Context, certsStorage *CertificatesStorage, domain string) (*keyRotation, error) {
+	policy, err := getKeyRotation(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rotation := &keyRotation{
+		certsStorage: certsStorage,
+		domain:       domain,
+		grace:        ctx.Duration("key-rotation.grace"),
+	}
+
+	hasState, err := certsStorage.ExistsFile(domain, ".key-rotation.json")
+	if err != nil {
+		return nil, err
+	}
+
+	if policy == 1 && rotation.grace == 0 && !hasState {
+		// a new key on every renewal, nothing to keep.
+		rotation.rotated = true
+		return rotation, nil
+	}
+
+	keyBytes, err := certsStorage.ReadFile(domain, ".key")
+	if err != nil {
+		return nil, fmt.Errorf("error while loading the private key for domain %s\n\t%v", domain, err)
+	}
+
+	privateKey, err := certcrypto.ParsePEMPrivateKey(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if policy == 0 {
+		// the key is always reused, nothing to count.
+		rotation.privateKey = privateKey
+		return rotation, nil
+	}
+
+	keyHash, err := publicKeyHash(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	rotation.state = certsStorage.readKeyRotationState(domain)
+
+	// the state is reset if the private key has been changed by something else (i.e. run).
+	if rotation.state.KeyHash != keyHash {
+		rotation.state.KeyHash = keyHash
+		rotation.state.Certificates = 1
+	}
+
+	if rotation.state.Certificates < policy {
+		rotation.privateKey = privateKey
+		return rotation, nil
+	}
+
+	log.Infof("[%s] The private key has been used by %d certificates: a new private key is generated.", domain, rotation.state.Certificates)
+
+	rotation.rotated = true
+
+	return rotation, nil
+}
+
+// commit keeps the previous private key (--key-rotation.grace), removes the expired previous keys, and saves the state of the new private key.
+// Must be called before the new certificate is saved.
+func (r *keyRotation) commit(newKeyPEM []byte) error {
+	if r.state == nil {
+		return nil
+	}
+
+	var retained []retainedKey
+	for _, key := range r.state.RetainedKeys {
+		if time.Now().Before(key.RemoveAt) {
+			retained = append(retained, key)
+			continue
+		}
+
+		if err := r.certsStorage.backend.Delete(key.Name); err != nil {
+			log.Warnf("[%s] Unable to remove the previous private key %s: %v", r.domain, r.certsStorage.backend.Location(key.Name), err)
+			retained = append(retained, key)
+		}
+	}
+
+	r.state.RetainedKeys = retained
+
+	if !r.rotated {
+		r.state.Certificates++
+		return r.certsStorage.writeKeyRotationState(r.domain, r.state)
+	}
+
+	if r.grace > 0 {
+		key, err := r.certsStorage.archiveKey(r.domain, time.Now().Add(r.grace))
+		if err != nil {
+			return err
+		}
+
+		r.state.RetainedKeys = append(r.state.RetainedKeys, *key)
+	}
+
+	newKey, err := certcrypto.ParsePEMPrivateKey(newKeyPEM)
+	if err != nil {
+		return err
+	}
+
+	r.state.KeyHash, err = publicKeyHash(newKey)
+	if err != nil {
+		return err
+	}
+
+	r.state.Certificates = 1
+
+	return r.certsStorage.writeKeyRotationState(r.domain, r.state)
+}
+
+// readKeyRotationState reads the state of the private key, a new state is returned if the state cannot be read.
+func (s *CertificatesStorage) readKeyRotationState(domain string) *keyRotationState {
+	state := &keyRotationState{}
+
+	exists, err := s.ExistsFile(domain, ".key-rotation.json")
+	if err != nil {
+		log.Warnf("[%s] Unable to read the state of the private key: %v", domain, err)
+		return state
+	}
+
+	if !exists {
+		return state
+	}
+
+	data, err := s.ReadFile(domain, ".key-rotation.json")
+	if err == nil {
+		err = json.Unmarshal(data, state)
+	}
+
+	if err != nil {
+		log.Warnf("[%s] Unable to read the state of the private key: %v", domain, err)
+		return &keyRotationState{}
+	}
+
+	return state
+}
+
+func (s *CertificatesStorage) writeKeyRotationState(domain string, state *keyRotationState) error {
+	data, err := json.MarshalIndent(state, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return s.WriteFile(domain, ".key-rotation.json", data)
+}
+
+// archiveKey copies the current private key in the archives.
+func (s *CertificatesStorage) archiveKey(domain string, removeAt time.Time) (*retainedKey, error) {
+	keyBytes, err := s.ReadFile(domain, ".key")
+	if err != nil {
+		return nil, err
+	}
+
+	baseName, err := s.archiveBaseName(domain, ".key")
+	if err != nil {
+		return nil, err
+	}
+
+	date := strconv.FormatInt(time.Now().Unix(), 10)
+	name := path.Join(baseArchivesFolderName, date+"."+baseName)
+
+	err = s.backend.Save(name, keyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("[%s] The previous private key is kept until %s: %s", domain, removeAt.Format(time.RFC3339), s.backend.Location(name))
+
+	return &retainedKey{Name: name, RemoveAt: removeAt.UTC()}, nil
+}
+
+// publicKeyHash returns the SHA-256 hash of the public key of a private key.
+func publicKeyHash(privateKey crypto.PrivateKey) (string, error) {
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return "", errors.New("unsupported private key type")
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(der)
+
+	return hex.EncodeToString(hash[:]), nil
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v3/certcrypto"
+	"github.com/go-acme/lego/v3/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+func newKeyRotationContext(t *testing.T, args ...string) *cli.Context {
+	t.Helper()
+
+	command := createRenew()
+
+	set, err := newFlagSet(command.Name, command.Flags, args)
+	require.NoError(t, err)
+
+	return cli.NewContext(cli.NewApp(), set, nil)
+}
+
+func Test_getKeyRotation(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		args      []string
+		expected  int
+		expectErr string
+	}{
+		{desc: "default", expected: 1},
+		{desc: "always", args: []string{"--key-rotation=always"}, expected: 1},
+		{desc: "never", args: []string{"--key-rotation=Never"}, expected: 0},
+		{desc: "reuse-key", args: []string{"--reuse-key"}, expected: 0},
+		{desc: "number", args: []string{"--key-rotation=3"}, expected: 3},
+		{
+			desc:      "zero",
+			args:      []string{"--key-rotation=0"},
+			expectErr: "unsupported key rotation: 0 (supported: always, never, or a number greater than 0)",
+		},
+		{
+			desc:      "reuse-key and key-rotation",
+			args:      []string{"--reuse-key", "--key-rotation=3"},
+			expectErr: "--reuse-key cannot be used with --key-rotation",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			policy, err := getKeyRotation(newKeyRotationContext(t, test.args...))
+			if test.expectErr != "" {
+				require.EqualError(t, err, test.expectErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, policy)
+		})
+	}
+}
+
+func Test_keyRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-key-rotation")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	certsStorage := &CertificatesStorage{backend: storage.NewFileSystem(dir)}
+
+	writeKey := func() []byte {
+		privateKey, errK := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, errK)
+
+		keyPEM := certcrypto.PEMEncode(privateKey)
+		require.NoError(t, certsStorage.WriteFile("example.com", ".key", keyPEM))
+
+		return keyPEM
+	}
+
+	ctx := newKeyRotationContext(t, "--key-rotation=3", "--key-rotation.grace=1h")
+
+	// the initial key is used by 3 certificates: the initial certificate and 2 renewals.
+	currentKey := writeKey()
+
+	for i := 0; i < 2; i++ {
+		rotation, errR := newKeyRotation(ctx, certsStorage, "example.com")
+		require.NoError(t, errR)
+
+		require.NotNil(t, rotation.privateKey)
+		assert.False(t, rotation.rotated)
+
+		require.NoError(t, rotation.commit(currentKey))
+	}
+
+	assert.Equal(t, 3, certsStorage.readKeyRotationState("example.com").Certificates)
+
+	// the third renewal generates a new key, the previous key is kept in the archives.
+	rotation, err := newKeyRotation(ctx, certsStorage, "example.com")
+	require.NoError(t, err)
+
+	assert.Nil(t, rotation.privateKey)
+	assert.True(t, rotation.rotated)
+
+	newKey := func() []byte {
+		privateKey, errK := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, errK)
+		return certcrypto.PEMEncode(privateKey)
+	}()
+
+	require.NoError(t, rotation.commit(newKey))
+	require.NoError(t, certsStorage.WriteFile("example.com", ".key", newKey))
+
+	state := certsStorage.readKeyRotationState("example.com")
+	assert.Equal(t, 1, state.Certificates)
+	require.Len(t, state.RetainedKeys, 1)
+
+	archivedPath := filepath.Join(dir, filepath.FromSlash(state.RetainedKeys[0].Name))
+
+	archived, err := ioutil.ReadFile(archivedPath)
+	require.NoError(t, err)
+	assert.Equal(t, currentKey, archived)
+
+	// the previous key is removed once the grace period is over.
+	state.RetainedKeys[0].RemoveAt = time.Now().Add(-time.Minute)
+	require.NoError(t, certsStorage.writeKeyRotationState("example.com", state))
+
+	rotation, err = newKeyRotation(ctx, certsStorage, "example.com")
+	require.NoError(t, err)
+	require.NoError(t, rotation.commit(newKey))
+
+	state = certsStorage.readKeyRotationState("example.com")
+	assert.Equal(t, 2, state.Certificates)
+	assert.Empty(t, state.RetainedKeys)
+
+	_, err = os.Stat(archivedPath)
+	assert.True(t, os.IsNotExist(err))
+}
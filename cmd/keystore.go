@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"crypto"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v3/certificate"
+	"github.com/go-acme/lego/v3/internal/jks"
+	"github.com/go-acme/lego/v3/internal/pkcs12"
+)
+
+// Formats of the Java keystore (--keystore).
+const (
+	keyStoreJKS    = "jks"
+	keyStorePKCS12 = "pkcs12"
+)
+
+// keyStoreExtension returns the extension of the file of a format of Java keystore.
+func keyStoreExtension(format string) (string, error) {
+	switch strings.ToLower(format) {
+	case keyStoreJKS:
+		return ".jks", nil
+	case keyStorePKCS12:
+		return ".p12", nil
+	default:
+		return "", fmt.Errorf("unsupported keystore format: %s (supported: %s, %s)", format, keyStoreJKS, keyStorePKCS12)
+	}
+}
+
+// WriteKeyStoreFile writes the private key, the certificate and the issuer certificates in a Java keystore (.jks or .p12).
+func (s *CertificatesStorage) WriteKeyStoreFile(domain string, certRes *certificate.Resource) error {
+	extension, err := keyStoreExtension(s.keyStore)
+	if err != nil {
+		return err
+	}
+
+	privateKey, certificates, err := parseResourceChain(certRes)
+	if err != nil {
+		return err
+	}
+
+	alias := s.keyStoreAlias
+	if alias == "" {
+		alias = domain
+	}
+
+	var data []byte
+	if extension == ".jks" {
+		data, err = jks.Encode(privateKey, certificates, alias, s.keyStorePassword, time.Now())
+	} else {
+		data, err = encodePKCS12KeyStore(privateKey, certificates, alias, s.keyStorePassword)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to encode the keystore: %v", err)
+	}
+
+	return s.WriteFile(domain, extension, data)
+}
+
+// keyStoreFileExtension returns the extension of the keystore file, or an empty string if the keystore is not written.
+func (s *CertificatesStorage) keyStoreFileExtension() string {
+	extension, err := keyStoreExtension(s.keyStore)
+	if err != nil {
+		return ""
+	}
+
+	return extension
+}
+
+// encodePKCS12KeyStore encodes a PKCS#12 keystore readable by all the versions of Java (3DES and SHA-1 MAC),
+// the alias of the private key is the friendly name of the key bag.
+func encodePKCS12KeyStore(privateKey crypto.PrivateKey, chain []*x509.Certificate, alias, password string) ([]byte, error) {
+	encoder, err := pkcs12.NewEncoder(pkcs12.FormatDES)
+	if err != nil {
+		return nil, err
+	}
+
+	return encoder.Encode(privateKey, chain, alias, password)
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-acme/lego/v3/certcrypto"
+	"github.com/go-acme/lego/v3/certificate"
+	"github.com/go-acme/lego/v3/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertificatesStorage_WriteKeyStoreFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-keystore")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	cert, err := certcrypto.GeneratePemCert(privateKey, "example.com", nil)
+	require.NoError(t, err)
+
+	issuerKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	issuer, err := certcrypto.GeneratePemCert(issuerKey, "issuer.example.com", nil)
+	require.NoError(t, err)
+
+	certRes := &certificate.Resource{
+		Domain:            "example.com",
+		Certificate:       cert,
+		IssuerCertificate: issuer,
+		PrivateKey:        certcrypto.PEMEncode(privateKey),
+	}
+
+	testCases := []struct {
+		desc     string
+		alias    string
+		expected string
+	}{
+		{desc: "default alias", expected: "example.com"},
+		{desc: "alias", alias: "tomcat", expected: "tomcat"},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			certsStorage := &CertificatesStorage{backend: storage.NewFileSystem(dir), keyStore: "PKCS12", keyStoreAlias: test.alias, keyStorePassword: "changeit"}
+
+			err := certsStorage.WriteKeyStoreFile(certRes.Domain, certRes)
+			require.NoError(t, err)
+
+			data, err := ioutil.ReadFile(filepath.Join(dir, baseCertificatesFolderName, "example.com.p12"))
+			require.NoError(t, err)
+
+			// the MAC is verified.
+			key, certs, friendlyName := decodePFX(t, data, "changeit")
+
+			assert.Equal(t, privateKey, key)
+			require.Len(t, certs, 2)
+			assert.Equal(t, []string{"example.com"}, certs[0].DNSNames)
+			assert.Equal(t, []string{"issuer.example.com"}, certs[1].DNSNames)
+			assert.Equal(t, test.expected, friendlyName)
+		})
+	}
+}
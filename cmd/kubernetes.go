@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/go-acme/lego/v3/certificate"
+	"github.com/go-acme/lego/v3/storage/kubernetes"
+	"github.com/urfave/cli"
+)
+
+// kubernetesNameRegexp the names of the Kubernetes resources (DNS subdomain, RFC 1123).
+var kubernetesNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+
+// kubernetesSecretData the data of the templates of the name and the namespace of the Kubernetes TLS secret.
+type kubernetesSecretData struct {
+	// Domain the main domain of the certificate.
+	Domain string
+	// Name the main domain as a name of Kubernetes resource (i.e. wildcard.example.com for *.example.com).
+	Name string
+}
+
+// isKubernetesOutput returns true if the certificates are written in Kubernetes TLS secrets.
+func isKubernetesOutput(ctx *cli.Context) bool {
+	return ctx.GlobalString("kubernetes.secret") != ""
+}
+
+// checkKubernetesTemplates checks the templates of the name and the namespace of the Kubernetes TLS secret.
+func checkKubernetesTemplates(ctx *cli.Context) error {
+	for _, name := range []string{"kubernetes.secret", "kubernetes.namespace"} {
+		_, err := template.New(name).Parse(ctx.GlobalString(name))
+		if err != nil {
+			return fmt.Errorf("invalid --%s: %v", name, err)
+		}
+	}
+
+	return nil
+}
+
+// writeKubernetesSecret writes a certificate in a Kubernetes TLS secret, returns the namespace and the name of the secret (namespace/name).
+func writeKubernetesSecret(ctx *cli.Context, certRes *certificate.Resource) (string, error) {
+	if certRes.PrivateKey == nil {
+		return "", errors.New("unable to write the Kubernetes TLS secret without private key; are you using a CSR?")
+	}
+
+	client, err := kubernetes.NewClient(ctx.GlobalString("kubernetes.kubeconfig"))
+	if err != nil {
+		return "", err
+	}
+
+	data := kubernetesSecretData{
+		Domain: certRes.Domain,
+		Name:   kubernetesName(certRes.Domain),
+	}
+
+	name, err := executeKubernetesTemplate(ctx, "kubernetes.secret", data)
+	if err != nil {
+		return "", err
+	}
+
+	namespace := client.Namespace()
+	if ctx.GlobalString("kubernetes.namespace") != "" {
+		namespace, err = executeKubernetesTemplate(ctx, "kubernetes.namespace", data)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = client.ApplyTLSSecret(namespace, name, &kubernetes.TLSSecret{
+		Certificate:       certRes.Certificate,
+		PrivateKey:        certRes.PrivateKey,
+		IssuerCertificate: certRes.IssuerCertificate,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return namespace + "/" + name, nil
+}
+
+// kubernetesName returns a domain as a name of Kubernetes resource (i.e. wildcard.example.com for *.example.com).
+func kubernetesName(domain string) string {
+	return strings.Replace(strings.ToLower(domain), "*", "wildcard", -1)
+}
+
+// executeKubernetesTemplate executes the template of an option, the result must be a valid name of Kubernetes resource.
+func executeKubernetesTemplate(ctx *cli.Context, option string, data kubernetesSecretData) (string, error) {
+	tmpl, err := template.New(option).Option("missingkey=error").Parse(ctx.GlobalString(option))
+	if err != nil {
+		return "", fmt.Errorf("invalid --%s: %v", option, err)
+	}
+
+	buf := &bytes.Buffer{}
+
+	err = tmpl.Execute(buf, data)
+	if err != nil {
+		return "", fmt.Errorf("invalid --%s: %v", option, err)
+	}
+
+	value := buf.String()
+	if len(value) > 253 || !kubernetesNameRegexp.MatchString(value) {
+		return "", fmt.Errorf("invalid --%s: %q is not a valid name of Kubernetes resource (lowercase alphanumeric characters, '-' or '.')", option, value)
+	}
+
+	return value, nil
+}
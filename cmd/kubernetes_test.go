@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+func Test_executeKubernetesTemplate(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		template  string
+		domain    string
+		expected  string
+		expectErr string
+	}{
+		{
+			desc:     "name",
+			template: "{{ .Name }}-tls",
+			domain:   "Example.com",
+			expected: "example.com-tls",
+		},
+		{
+			desc:     "wildcard",
+			template: "{{ .Name }}-tls",
+			domain:   "*.example.com",
+			expected: "wildcard.example.com-tls",
+		},
+		{
+			desc:     "static",
+			template: "ingress-tls",
+			domain:   "*.example.com",
+			expected: "ingress-tls",
+		},
+		{
+			desc:      "invalid name",
+			template:  "{{ .Domain }}",
+			domain:    "*.example.com",
+			expectErr: `invalid --kubernetes.secret: "*.example.com" is not a valid name of Kubernetes resource (lowercase alphanumeric characters, '-' or '.')`,
+		},
+		{
+			desc:      "unknown field",
+			template:  "{{ .Foo }}",
+			domain:    "example.com",
+			expectErr: `invalid --kubernetes.secret: template: kubernetes.secret:1:3: executing "kubernetes.secret" at <.Foo>: can't evaluate field Foo in type cmd.kubernetesSecretData`,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			set, err := newFlagSet("lego", CreateFlags(""), []string{"--kubernetes.secret=" + test.template})
+			require.NoError(t, err)
+
+			ctx := cli.NewContext(cli.NewApp(), set, nil)
+
+			data := kubernetesSecretData{
+				Domain: test.domain,
+				Name:   kubernetesName(test.domain),
+			}
+
+			name, err := executeKubernetesTemplate(ctx, "kubernetes.secret", data)
+			if test.expectErr != "" {
+				require.EqualError(t, err, test.expectErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, name)
+		})
+	}
+}
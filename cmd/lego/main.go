@@ -10,6 +10,13 @@ import (
 
 	"github.com/go-acme/lego/v3/cmd"
 	"github.com/go-acme/lego/v3/log"
+	_ "github.com/go-acme/lego/v3/platform/config/secrets/awssm"
+	_ "github.com/go-acme/lego/v3/platform/config/secrets/vault"
+	_ "github.com/go-acme/lego/v3/storage/consul"
+	_ "github.com/go-acme/lego/v3/storage/etcd"
+	_ "github.com/go-acme/lego/v3/storage/gcs"
+	_ "github.com/go-acme/lego/v3/storage/s3"
+	_ "github.com/go-acme/lego/v3/storage/vault"
 	"github.com/urfave/cli"
 )
 
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"io"
+	stdlog "log"
+
+	"github.com/go-acme/lego/v3/log"
+	"github.com/go-acme/lego/v3/platform/apidebug"
+	"github.com/urfave/cli"
+)
+
+// setupLogging replaces the logger by the logger defined by --log-format and --log-level.
+// The logs are written on stderr with the JSON output.
+func setupLogging(ctx *cli.Context) error {
+	logger, err := newLogger(ctx, ctx.GlobalString("log-format"), messageOutput(ctx), stdlog.LstdFlags)
+	if err != nil {
+		return err
+	}
+
+	log.Logger = logger
+
+	return nil
+}
+
+func newLogger(ctx *cli.Context, format string, out io.Writer, flag int) (*log.LevelLogger, error) {
+	level, err := getLogLevel(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return log.NewLevelLogger(out, format, level, flag)
+}
+
+// getLogLevel returns the level defined by --log-level,
+// DEBUG by default if the logging of the DNS provider API calls is enabled (they are DEBUG entries).
+func getLogLevel(ctx *cli.Context) (log.Level, error) {
+	if !ctx.GlobalIsSet("log-level") && apidebug.Enabled() {
+		return log.LevelDebug, nil
+	}
+
+	return log.ParseLevel(ctx.GlobalString("log-level"))
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-acme/lego/v3/log"
+	"github.com/go-acme/lego/v3/platform/apidebug"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+func Test_getLogLevel(t *testing.T) {
+	defer func(value string, ok bool) {
+		if ok {
+			_ = os.Setenv(apidebug.EnvDebug, value)
+		} else {
+			_ = os.Unsetenv(apidebug.EnvDebug)
+		}
+	}(os.LookupEnv(apidebug.EnvDebug))
+
+	testCases := []struct {
+		desc      string
+		args      []string
+		apiDebug  bool
+		expected  log.Level
+		expectErr string
+	}{
+		{desc: "default", expected: log.LevelInfo},
+		{desc: "level", args: []string{"--log-level=error"}, expected: log.LevelError},
+		{desc: "API debug", apiDebug: true, expected: log.LevelDebug},
+		{desc: "API debug and level", args: []string{"--log-level=warn"}, apiDebug: true, expected: log.LevelWarn},
+		{desc: "unsupported level", args: []string{"--log-level=trace"}, expectErr: "unsupported log level: trace (supported: debug, info, warn, error)"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			_ = os.Setenv(apidebug.EnvDebug, "false")
+			if test.apiDebug {
+				_ = os.Setenv(apidebug.EnvDebug, "true")
+			}
+
+			set, err := newFlagSet("lego", CreateFlags(""), test.args)
+			require.NoError(t, err)
+
+			level, err := getLogLevel(cli.NewContext(cli.NewApp(), set, nil))
+			if test.expectErr != "" {
+				require.EqualError(t, err, test.expectErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, level)
+		})
+	}
+}
@@ -0,0 +1,416 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/go-acme/lego/v3/log"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+)
+
+// Manifest describes several certificates.
+//
+// The keys are the names of the options of the CLI (global options and options of the command),
+// "env" defines the environment variables (i.e. the credentials of the DNS provider).
+// The names containing a dot must be quoted (i.e. "http.port").
+//
+//	[global]
+//	  email = "foo@bar.com"
+//	  accept-tos = true
+//
+//	[[certificates]]
+//	  domains = ["example.com", "www.example.com"]
+//	  dns = "cloudflare"
+//	  renew-hook = "./reload.sh"
+//	  "dns.resolvers" = ["1.1.1.1:53"]
+//	  [certificates.env]
+//	    CLOUDFLARE_DNS_API_TOKEN = "xxx"
+//
+// The manifest can also be written in YAML (.yaml or .yml file), with the same structure.
+type Manifest struct {
+	// Global the options applied to all the certificates.
+	Global map[string]interface{} `toml:"global" yaml:"global"`
+	// Certificates the options of each certificate.
+	Certificates []map[string]interface{} `toml:"certificates" yaml:"certificates"`
+}
+
+// manifestEntry the options of a certificate, split between the global options and the options of the command.
+type manifestEntry struct {
+	globalArgs  []string
+	commandArgs []string
+	env         map[string]string
+}
+
+func readManifest(filename string) (*Manifest, error) {
+	manifest := &Manifest{}
+
+	var err error
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		err = decodeYAMLManifest(filename, manifest)
+	default:
+		_, err = toml.DecodeFile(filename, manifest)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %v", filename, err)
+	}
+
+	if len(manifest.Certificates) == 0 {
+		return nil, fmt.Errorf("invalid manifest %s: no certificates", filename)
+	}
+
+	return manifest, nil
+}
+
+// decodeYAMLManifest decodes a YAML manifest,
+// the values are converted to the types decoded from a TOML manifest (tables and integers).
+func decodeYAMLManifest(filename string, manifest *Manifest) error {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	err = yaml.Unmarshal(data, manifest)
+	if err != nil {
+		return err
+	}
+
+	manifest.Global = normalizeYAMLTable(manifest.Global)
+	for i, options := range manifest.Certificates {
+		manifest.Certificates[i] = normalizeYAMLTable(options)
+	}
+
+	return nil
+}
+
+func normalizeYAMLTable(table map[string]interface{}) map[string]interface{} {
+	if table == nil {
+		return nil
+	}
+
+	normalized := make(map[string]interface{}, len(table))
+	for k, v := range table {
+		normalized[k] = normalizeYAMLValue(v)
+	}
+
+	return normalized
+}
+
+func normalizeYAMLValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return int64(v)
+	case map[interface{}]interface{}:
+		table := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			table[fmt.Sprint(k)] = normalizeYAMLValue(item)
+		}
+		return table
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = normalizeYAMLValue(item)
+		}
+		return items
+	default:
+		return value
+	}
+}
+
+// runManifest executes the action of the command for each certificate of the manifest.
+// A failure of a certificate doesn't stop the other certificates: the errors are reported at the end.
+// errNotDue is returned if none of the certificates needs to be renewed.
+func runManifest(ctx *cli.Context, action func(*cli.Context) error) error {
+	manifest, err := readManifest(ctx.GlobalString("manifest"))
+	if err != nil {
+		return err
+	}
+
+	var entries []*manifestEntry
+	for i, options := range manifest.Certificates {
+		entry, err := newManifestEntry(ctx.App.Flags, ctx.Command.Flags, manifest.Global, options)
+		if err != nil {
+			return fmt.Errorf("manifest: certificate #%d: %v", i+1, err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if workers := ctx.GlobalInt("manifest.workers"); workers > 1 {
+		return runManifestParallel(ctx, entries, workers)
+	}
+
+	contexts := make([]*cli.Context, len(entries))
+	names := make([]string, len(entries))
+
+	for i, entry := range entries {
+		certCtx, err := entry.newContext(ctx)
+		if err != nil {
+			return fmt.Errorf("manifest: certificate #%d: %v", i+1, err)
+		}
+
+		if len(certCtx.GlobalStringSlice("domains")) == 0 && certCtx.GlobalString("csr") == "" {
+			return fmt.Errorf("manifest: certificate #%d: domains or csr are required", i+1)
+		}
+
+		// getServer can't report an invalid CA preset.
+		if _, err = getCAPreset(certCtx); err != nil {
+			return fmt.Errorf("manifest: certificate #%d: %v", i+1, err)
+		}
+
+		contexts[i] = certCtx
+		names[i] = strings.Join(certCtx.GlobalStringSlice("domains"), ", ")
+	}
+
+	errs := make([]error, len(entries))
+
+	for i, entry := range entries {
+		log.Infof("manifest: certificate #%d: %s", i+1, names[i])
+
+		restore := entry.setEnv()
+		errs[i] = action(contexts[i])
+		restore()
+	}
+
+	return manifestSummary(names, errs)
+}
+
+// manifestSummary logs the status of each certificate of the manifest, and returns an error if at least one certificate failed.
+// errNotDue is returned if none of the certificates needs to be renewed.
+func manifestSummary(names []string, errs []error) error {
+	var failed, notDue int
+	for i, err := range errs {
+		if err == errNotDue || isNotDueExitCode(err) {
+			notDue++
+			log.Infof("manifest: certificate #%d: %s: no renewal", i+1, names[i])
+			continue
+		}
+
+		if err != nil {
+			failed++
+			log.Printf("manifest: certificate #%d: %s: failed: %v", i+1, names[i], err)
+			continue
+		}
+
+		log.Infof("manifest: certificate #%d: %s: done", i+1, names[i])
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("manifest: %d of %d certificates failed", failed, len(errs))
+	}
+
+	if notDue == len(errs) {
+		return errNotDue
+	}
+
+	return nil
+}
+
+func newManifestEntry(globalFlags, commandFlags []cli.Flag, global, options map[string]interface{}) (*manifestEntry, error) {
+	entry := &manifestEntry{env: make(map[string]string)}
+
+	for _, opts := range []map[string]interface{}{global, options} {
+		var names []string
+		for name := range opts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			value := opts[name]
+
+			if name == "env" {
+				env, ok := value.(map[string]interface{})
+				if !ok {
+					return nil, errors.New("env must be a table")
+				}
+
+				for k, v := range env {
+					entry.env[k] = fmt.Sprint(v)
+				}
+
+				continue
+			}
+
+			if isManifestFlag(name) {
+				return nil, errors.New("a manifest cannot reference a manifest")
+			}
+
+			args, err := toArgs(name, value)
+			if err != nil {
+				return nil, err
+			}
+
+			switch {
+			case hasFlag(globalFlags, name):
+				entry.globalArgs = append(entry.globalArgs, args...)
+			case hasFlag(commandFlags, name):
+				entry.commandArgs = append(entry.commandArgs, args...)
+			default:
+				return nil, fmt.Errorf("unknown option %q", name)
+			}
+		}
+	}
+
+	return entry, nil
+}
+
+// newContext creates the context of the certificate from the options of the command line and the options of the manifest.
+func (e *manifestEntry) newContext(ctx *cli.Context) (*cli.Context, error) {
+	globalArgs, commandArgs := e.arguments(ctx)
+
+	globalSet, err := newFlagSet(ctx.App.Name, ctx.App.Flags, globalArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	commandSet, err := newFlagSet(ctx.Command.Name, ctx.Command.Flags, commandArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	certCtx := cli.NewContext(ctx.App, commandSet, cli.NewContext(ctx.App, globalSet, nil))
+	certCtx.Command = ctx.Command
+
+	err = loadDomainsFile(certCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	return certCtx, nil
+}
+
+// arguments returns the global arguments and the arguments of the command of the certificate:
+// the options of the command line followed by the options of the manifest.
+func (e *manifestEntry) arguments(ctx *cli.Context) ([]string, []string) {
+	globalArgs := append(contextArgs(ctx.GlobalFlagNames(), ctx.GlobalIsSet, ctx.GlobalGeneric), e.globalArgs...)
+	commandArgs := append(contextArgs(ctx.FlagNames(), ctx.IsSet, ctx.Generic), e.commandArgs...)
+
+	return globalArgs, commandArgs
+}
+
+// setEnv defines the environment variables of the certificate and returns a function restoring the previous values.
+func (e *manifestEntry) setEnv() func() {
+	previous := make(map[string]*string)
+
+	for key, value := range e.env {
+		if v, ok := os.LookupEnv(key); ok {
+			previous[key] = &v
+		} else {
+			previous[key] = nil
+		}
+
+		_ = os.Setenv(key, value)
+	}
+
+	return func() {
+		for key, value := range previous {
+			if value == nil {
+				_ = os.Unsetenv(key)
+			} else {
+				_ = os.Setenv(key, *value)
+			}
+		}
+	}
+}
+
+func newFlagSet(name string, flags []cli.Flag, args []string) (*flag.FlagSet, error) {
+	set := flag.NewFlagSet(name, flag.ContinueOnError)
+	set.SetOutput(os.Stderr)
+
+	for _, f := range flags {
+		f.Apply(set)
+	}
+
+	err := set.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return set, nil
+}
+
+// contextArgs returns the arguments corresponding to the options explicitly defined in a context.
+func contextArgs(names []string, isSet func(name string) bool, generic func(name string) interface{}) []string {
+	var args []string
+
+	for _, name := range names {
+		// the domains of --domains-file are already defined by --domains.
+		if isManifestFlag(name) || name == "domains-file" || !isSet(name) {
+			continue
+		}
+
+		switch value := generic(name).(type) {
+		case *cli.StringSlice:
+			for _, v := range value.Value() {
+				args = append(args, fmt.Sprintf("--%s=%s", name, v))
+			}
+		case *cli.IntSlice:
+			for _, v := range value.Value() {
+				args = append(args, fmt.Sprintf("--%s=%d", name, v))
+			}
+		case flag.Value:
+			args = append(args, fmt.Sprintf("--%s=%s", name, value.String()))
+		}
+	}
+
+	return args
+}
+
+// toArgs converts the value of an option of the manifest to arguments.
+func toArgs(name string, value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case bool:
+		return []string{fmt.Sprintf("--%s=%t", name, v)}, nil
+	case string, int64, float64:
+		return []string{fmt.Sprintf("--%s=%v", name, v)}, nil
+	case []string:
+		var args []string
+		for _, item := range v {
+			args = append(args, fmt.Sprintf("--%s=%s", name, item))
+		}
+		return args, nil
+	case []interface{}:
+		var args []string
+		for _, item := range v {
+			itemArgs, err := toArgs(name, item)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, itemArgs...)
+		}
+		return args, nil
+	default:
+		return nil, fmt.Errorf("unsupported value for the option %q: %v", name, value)
+	}
+}
+
+// isManifestFlag returns true for the options of the manifest, they are not applied to the certificates.
+func isManifestFlag(name string) bool {
+	return name == "manifest" || strings.HasPrefix(name, "manifest.")
+}
+
+func hasFlag(flags []cli.Flag, name string) bool {
+	return findFlag(flags, name) != nil
+}
+
+// findFlag returns the flag of an option (by its name or one of its aliases), or nil.
+func findFlag(flags []cli.Flag, name string) cli.Flag {
+	for _, f := range flags {
+		for _, n := range strings.Split(f.GetName(), ",") {
+			if strings.TrimSpace(n) == name {
+				return f
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+func Test_readManifest(t *testing.T) {
+	expected := &Manifest{
+		Global: map[string]interface{}{
+			"email":      "foo@bar.com",
+			"accept-tos": true,
+		},
+		Certificates: []map[string]interface{}{
+			{
+				"domains":    []interface{}{"example.com", "www.example.com"},
+				"dns":        "exec",
+				"renew-hook": "./reload.sh",
+				"days":       int64(45),
+				"env":        map[string]interface{}{"EXEC_PATH": "./update-dns.sh"},
+			},
+			{
+				"domains":   []interface{}{"example.org"},
+				"http":      true,
+				"http.port": ":8080",
+			},
+		},
+	}
+
+	for _, filename := range []string{"./fixtures/manifest.toml", "./fixtures/manifest.yaml"} {
+		filename := filename
+		t.Run(filename, func(t *testing.T) {
+			manifest, err := readManifest(filename)
+			require.NoError(t, err)
+
+			assert.Equal(t, expected, manifest)
+		})
+	}
+}
+
+func Test_newManifestEntry(t *testing.T) {
+	manifest, err := readManifest("./fixtures/manifest.toml")
+	require.NoError(t, err)
+
+	globalFlags := CreateFlags("")
+	commandFlags := createRenew().Flags
+
+	entry, err := newManifestEntry(globalFlags, commandFlags, manifest.Global, manifest.Certificates[0])
+	require.NoError(t, err)
+
+	expected := &manifestEntry{
+		globalArgs:  []string{"--accept-tos=true", "--email=foo@bar.com", "--dns=exec", "--domains=example.com", "--domains=www.example.com"},
+		commandArgs: []string{"--days=45", "--renew-hook=./reload.sh"},
+		env:         map[string]string{"EXEC_PATH": "./update-dns.sh"},
+	}
+	assert.Equal(t, expected, entry)
+
+	_, err = newManifestEntry(globalFlags, commandFlags, manifest.Global, map[string]interface{}{"foo": "bar"})
+	require.EqualError(t, err, `unknown option "foo"`)
+
+	_, err = newManifestEntry(globalFlags, commandFlags, manifest.Global, map[string]interface{}{"manifest": "foo.toml"})
+	require.EqualError(t, err, "a manifest cannot reference a manifest")
+}
+
+func Test_manifestEntry_newContext(t *testing.T) {
+	app := cli.NewApp()
+	app.Flags = CreateFlags("")
+
+	command := createRenew()
+
+	globalSet, err := newFlagSet(app.Name, app.Flags, []string{"--path=/tmp/lego", "--manifest=manifest.toml", "--email=cli@bar.com"})
+	require.NoError(t, err)
+
+	commandSet, err := newFlagSet(command.Name, command.Flags, []string{"--reuse-key"})
+	require.NoError(t, err)
+
+	ctx := cli.NewContext(app, commandSet, cli.NewContext(app, globalSet, nil))
+	ctx.Command = command
+
+	entry := &manifestEntry{
+		globalArgs:  []string{"--email=foo@bar.com", "--domains=example.com", "--http"},
+		commandArgs: []string{"--days=45"},
+	}
+
+	certCtx, err := entry.newContext(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, "/tmp/lego", certCtx.GlobalString("path"))
+	assert.Equal(t, "foo@bar.com", certCtx.GlobalString("email"))
+	assert.Equal(t, []string{"example.com"}, certCtx.GlobalStringSlice("domains"))
+	assert.True(t, certCtx.GlobalBool("http"))
+	assert.False(t, certCtx.GlobalIsSet("manifest"))
+	assert.Equal(t, 45, certCtx.Int("days"))
+	assert.True(t, certCtx.Bool("reuse-key"))
+	assert.False(t, certCtx.Bool("no-bundle"))
+}
+
+func Test_manifestEntry_setEnv(t *testing.T) {
+	defer os.Unsetenv("LEGO_TEST_MANIFEST_A")
+
+	_ = os.Setenv("LEGO_TEST_MANIFEST_A", "a")
+
+	entry := &manifestEntry{env: map[string]string{"LEGO_TEST_MANIFEST_A": "b", "LEGO_TEST_MANIFEST_B": "c"}}
+
+	restore := entry.setEnv()
+	assert.Equal(t, "b", os.Getenv("LEGO_TEST_MANIFEST_A"))
+	assert.Equal(t, "c", os.Getenv("LEGO_TEST_MANIFEST_B"))
+
+	restore()
+	assert.Equal(t, "a", os.Getenv("LEGO_TEST_MANIFEST_A"))
+	_, ok := os.LookupEnv("LEGO_TEST_MANIFEST_B")
+	assert.False(t, ok)
+}
+
+func Test_runManifest_failures(t *testing.T) {
+	app := cli.NewApp()
+	app.Flags = CreateFlags("")
+
+	command := createRenew()
+
+	globalSet, err := newFlagSet(app.Name, app.Flags, []string{"--path=/tmp/lego", "--manifest=./fixtures/manifest.toml"})
+	require.NoError(t, err)
+
+	commandSet, err := newFlagSet(command.Name, command.Flags, nil)
+	require.NoError(t, err)
+
+	ctx := cli.NewContext(app, commandSet, cli.NewContext(app, globalSet, nil))
+	ctx.Command = command
+
+	var domains []string
+
+	err = runManifest(ctx, func(certCtx *cli.Context) error {
+		domains = append(domains, certCtx.GlobalStringSlice("domains")[0])
+
+		if len(domains) == 1 {
+			return errors.New("failure")
+		}
+		return nil
+	})
+	require.EqualError(t, err, "manifest: 1 of 2 certificates failed")
+
+	// the failure of the first certificate doesn't stop the second certificate.
+	assert.Equal(t, []string{"example.com", "example.org"}, domains)
+}
+
+func Test_manifestSummary(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		errs     []error
+		expected string
+	}{
+		{
+			desc: "success",
+			errs: []error{nil, errNotDue},
+		},
+		{
+			desc:     "not due",
+			errs:     []error{errNotDue, errNotDue},
+			expected: errNotDue.Error(),
+		},
+		{
+			desc:     "failures",
+			errs:     []error{errors.New("a"), errNotDue, errors.New("b")},
+			expected: "manifest: 2 of 3 certificates failed",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			names := make([]string, len(test.errs))
+
+			err := manifestSummary(names, test.errs)
+			if test.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func Test_runWorkers(t *testing.T) {
+	var mu sync.Mutex
+	var running, maxRunning int
+
+	errs := runWorkers(10, 3, func(i int) error {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+
+		if i%4 == 0 {
+			return fmt.Errorf("task %d", i)
+		}
+		return nil
+	})
+
+	require.Len(t, errs, 10)
+
+	for i, err := range errs {
+		if i%4 == 0 {
+			assert.EqualError(t, err, fmt.Sprintf("task %d", i))
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+
+	assert.True(t, maxRunning <= 3, "max running tasks: %d", maxRunning)
+}
+
+func Test_extractEnvArgs(t *testing.T) {
+	flags := []cli.Flag{
+		cli.StringFlag{Name: "email, m"},
+		cli.StringFlag{Name: "pfx-pass", EnvVar: "LEGO_PFX_PASSWORD"},
+		cli.StringFlag{Name: "hmac", EnvVar: "LEGO_EAB_HMAC, EAB_HMAC"},
+		cli.BoolFlag{Name: "secret-bool", EnvVar: "SECRET_BOOL"},
+		cli.StringSliceFlag{Name: "secrets", EnvVar: "SECRETS"},
+	}
+
+	args := []string{
+		"--email=foo@bar.com",
+		"--pfx-pass=cli",
+		"--hmac=abc",
+		"--secret-bool",
+		"--secrets=a",
+		"--secrets=b",
+		"--pfx-pass=manifest",
+		"--unknown=value",
+	}
+
+	remaining, env := extractEnvArgs(flags, args)
+
+	assert.Equal(t, []string{"--email=foo@bar.com", "--unknown=value"}, remaining)
+	assert.Equal(t, []string{"LEGO_PFX_PASSWORD=manifest", "LEGO_EAB_HMAC=abc", "SECRET_BOOL=true", "SECRETS=a,b"}, env)
+}
+
+func Test_manifestEntry_arguments(t *testing.T) {
+	app := cli.NewApp()
+	app.Flags = CreateFlags("")
+
+	command := createRun()
+
+	globalSet, err := newFlagSet(app.Name, app.Flags, []string{"--manifest=manifest.toml", "--manifest.workers=4", "--email=cli@bar.com"})
+	require.NoError(t, err)
+
+	commandSet, err := newFlagSet(command.Name, command.Flags, []string{"--no-bundle"})
+	require.NoError(t, err)
+
+	ctx := cli.NewContext(app, commandSet, cli.NewContext(app, globalSet, nil))
+	ctx.Command = command
+
+	entry := &manifestEntry{
+		globalArgs:  []string{"--domains=example.com", "--http"},
+		commandArgs: []string{"--must-staple=true"},
+		env:         map[string]string{"B": "2", "A": "1"},
+	}
+
+	globalArgs, commandArgs := entry.arguments(ctx)
+
+	assert.Equal(t, []string{"--email=cli@bar.com", "--domains=example.com", "--http"}, globalArgs)
+	assert.Equal(t, []string{"--no-bundle=true", "--must-staple=true"}, commandArgs)
+	assert.Equal(t, []string{"A=1", "B=2"}, entry.environ())
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-acme/lego/v3/log"
+	"github.com/urfave/cli"
+)
+
+// runManifestParallel processes the certificates of the manifest concurrently.
+// Each certificate is processed by a separate lego process (the environment variables and the failures are specific to a certificate),
+// the output of a process is written when the process ends.
+// The options backed by an environment variable (i.e. the passwords) are passed to the processes by their environment variables,
+// so they are not visible in the list of the processes.
+func runManifestParallel(ctx *cli.Context, entries []*manifestEntry, workers int) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("manifest: %v", err)
+	}
+
+	commands := make([]*exec.Cmd, len(entries))
+	names := make([]string, len(entries))
+
+	for i, entry := range entries {
+		certCtx, err := entry.newContext(ctx)
+		if err != nil {
+			return fmt.Errorf("manifest: certificate #%d: %v", i+1, err)
+		}
+
+		if len(certCtx.GlobalStringSlice("domains")) == 0 && certCtx.GlobalString("csr") == "" {
+			return fmt.Errorf("manifest: certificate #%d: domains or csr are required", i+1)
+		}
+
+		names[i] = strings.Join(certCtx.GlobalStringSlice("domains"), ", ")
+
+		globalArgs, commandArgs := entry.arguments(ctx)
+
+		globalArgs, globalEnv := extractEnvArgs(ctx.App.Flags, globalArgs)
+		commandArgs, commandEnv := extractEnvArgs(ctx.Command.Flags, commandArgs)
+
+		commands[i] = exec.Command(executable, append(append(globalArgs, ctx.Command.Name), commandArgs...)...)
+		commands[i].Env = append(append(append(os.Environ(), entry.environ()...), globalEnv...), commandEnv...)
+		commands[i].Stderr = os.Stderr
+	}
+
+	var output io.Writer = os.Stdout
+	if isJSONOutput(ctx) {
+		output = jsonOutput
+	}
+
+	var outputMu sync.Mutex
+
+	errs := runWorkers(len(commands), workers, func(i int) error {
+		log.Infof("manifest: certificate #%d: %s", i+1, names[i])
+
+		stdout := &bytes.Buffer{}
+		commands[i].Stdout = stdout
+
+		err := commands[i].Run()
+
+		outputMu.Lock()
+		_, _ = io.Copy(output, stdout)
+		outputMu.Unlock()
+
+		return err
+	})
+
+	return manifestSummary(names, errs)
+}
+
+// isNotDueExitCode returns true if a lego process exited because the certificate doesn't need to be renewed (--distinct-exit-codes).
+func isNotDueExitCode(err error) bool {
+	exitErr, ok := err.(*exec.ExitError)
+	return ok && exitErr.ExitCode() == exitCodeNotDue
+}
+
+// runWorkers executes the tasks [0, count) with a limited number of workers, and returns the errors of the tasks.
+func runWorkers(count, workers int, task func(i int) error) []error {
+	errs := make([]error, count)
+
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < count; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				errs[i] = task(i)
+			}
+		}()
+	}
+
+	for i := 0; i < count; i++ {
+		indexes <- i
+	}
+	close(indexes)
+
+	wg.Wait()
+
+	return errs
+}
+
+// environ returns the environment variables of the certificate, sorted by name.
+func (e *manifestEntry) environ() []string {
+	var env []string
+	for key, value := range e.env {
+		env = append(env, key+"="+value)
+	}
+
+	sort.Strings(env)
+
+	return env
+}
+
+// extractEnvArgs removes the arguments of the options backed by an environment variable,
+// and returns the remaining arguments and the environment variables defining these options.
+func extractEnvArgs(flags []cli.Flag, args []string) ([]string, []string) {
+	var remaining []string
+
+	var names []string
+	values := make(map[string][]string)
+
+	for _, arg := range args {
+		name, value := strings.TrimLeft(arg, "-"), "true"
+		if i := strings.Index(name, "="); i >= 0 {
+			name, value = name[:i], name[i+1:]
+		}
+
+		f := findFlag(flags, name)
+
+		envVar := flagEnvVar(f)
+		if envVar == "" {
+			remaining = append(remaining, arg)
+			continue
+		}
+
+		if _, ok := values[envVar]; !ok {
+			names = append(names, envVar)
+		}
+
+		// the last value of an option wins, except for the lists.
+		switch f.(type) {
+		case cli.StringSliceFlag, cli.IntSliceFlag, cli.Int64SliceFlag:
+			values[envVar] = append(values[envVar], value)
+		default:
+			values[envVar] = []string{value}
+		}
+	}
+
+	var env []string
+	for _, envVar := range names {
+		env = append(env, envVar+"="+strings.Join(values[envVar], ","))
+	}
+
+	return remaining, env
+}
+
+// flagEnvVar returns the first environment variable of an option (the EnvVar field of the flags), or an empty string.
+func flagEnvVar(f cli.Flag) string {
+	if f == nil {
+		return ""
+	}
+
+	field := reflect.Indirect(reflect.ValueOf(f)).FieldByName("EnvVar")
+	if !field.IsValid() || field.Kind() != reflect.String {
+		return ""
+	}
+
+	return strings.TrimSpace(strings.Split(field.String(), ",")[0])
+}
@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v3/log"
+	"github.com/urfave/cli"
+)
+
+// Notification events.
+const (
+	notifyEventSuccess = "success"
+	notifyEventFailure = "failure"
+	notifyEventExpiry  = "expiry"
+)
+
+// smtpSendMail sends the emails (replaced by the tests).
+var smtpSendMail = smtp.SendMail
+
+// notification the document sent to the webhook.
+type notification struct {
+	Event       string             `json:"event"`
+	Message     string             `json:"message"`
+	Certificate *certificateResult `json:"certificate"`
+}
+
+// smtpConfig the configuration of the email notifications.
+type smtpConfig struct {
+	server   string
+	from     string
+	to       []string
+	username string
+	password string
+}
+
+// notifier sends the notifications of the certificates to a webhook, a Slack webhook, and by email.
+type notifier struct {
+	webhookURL string
+	slackURL   string
+	smtp       smtpConfig
+	events     map[string]bool
+	httpClient *http.Client
+}
+
+func createNotifyFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:   "notify.webhook",
+			Usage:  "Send the notifications as JSON documents (POST) to this URL.",
+			EnvVar: "LEGO_NOTIFY_WEBHOOK",
+		},
+		cli.StringFlag{
+			Name:   "notify.slack",
+			Usage:  "Send the notifications to this Slack incoming webhook URL.",
+			EnvVar: "LEGO_NOTIFY_SLACK_WEBHOOK",
+		},
+		cli.StringFlag{
+			Name:   "notify.smtp",
+			Usage:  "Send the notifications by email with this SMTP server. Supported: host:port. Requires --notify.smtp.from and --notify.smtp.to.",
+			EnvVar: "LEGO_NOTIFY_SMTP_SERVER",
+		},
+		cli.StringFlag{
+			Name:   "notify.smtp.from",
+			Usage:  "The sender of the email notifications.",
+			EnvVar: "LEGO_NOTIFY_SMTP_FROM",
+		},
+		cli.StringSliceFlag{
+			Name:  "notify.smtp.to",
+			Usage: "A recipient of the email notifications. Can be specified multiple times.",
+		},
+		cli.StringFlag{
+			Name:   "notify.smtp.username",
+			Usage:  "The username of the SMTP server (PLAIN authentication).",
+			EnvVar: "LEGO_NOTIFY_SMTP_USERNAME",
+		},
+		cli.StringFlag{
+			Name:   "notify.smtp.password",
+			Usage:  "The password of the SMTP server (PLAIN authentication).",
+			EnvVar: "LEGO_NOTIFY_SMTP_PASSWORD",
+		},
+		cli.StringFlag{
+			Name:  "notify.events",
+			Usage: "The events sent as notifications, comma separated. Supported: success (a certificate is obtained or renewed), failure (a certificate cannot be obtained, renewed or revoked), expiry (a certificate not renewed expires soon).",
+			Value: strings.Join([]string{notifyEventSuccess, notifyEventFailure, notifyEventExpiry}, ","),
+		},
+		cli.IntFlag{
+			Name:  "notify.expiry-days",
+			Usage: "The number of days left on a certificate which is not renewed to send the expiry notification.",
+			Value: 14,
+		},
+		cli.DurationFlag{
+			Name:  "notify.timeout",
+			Usage: "The timeout of the sending of a notification.",
+			Value: 30 * time.Second,
+		},
+	}
+}
+
+// newNotifier creates a notifier from the options.
+func newNotifier(ctx *cli.Context) *notifier {
+	n := &notifier{
+		webhookURL: ctx.GlobalString("notify.webhook"),
+		slackURL:   ctx.GlobalString("notify.slack"),
+		smtp: smtpConfig{
+			server:   ctx.GlobalString("notify.smtp"),
+			from:     ctx.GlobalString("notify.smtp.from"),
+			to:       ctx.GlobalStringSlice("notify.smtp.to"),
+			username: ctx.GlobalString("notify.smtp.username"),
+			password: ctx.GlobalString("notify.smtp.password"),
+		},
+		events:     map[string]bool{},
+		httpClient: &http.Client{Timeout: ctx.GlobalDuration("notify.timeout")},
+	}
+
+	for _, event := range strings.Split(ctx.GlobalString("notify.events"), ",") {
+		n.events[strings.ToLower(strings.TrimSpace(event))] = true
+	}
+
+	return n
+}
+
+// enabled returns true if at least one notification channel is defined.
+func (n *notifier) enabled() bool {
+	return n.webhookURL != "" || n.slackURL != "" || n.smtp.server != ""
+}
+
+// send sends the notification of an event to all the channels, the errors are only logged.
+func (n *notifier) send(event string, result *certificateResult) {
+	if !n.enabled() || !n.events[event] {
+		return
+	}
+
+	msg := notification{
+		Event:       event,
+		Message:     notificationMessage(event, result),
+		Certificate: result,
+	}
+
+	if n.webhookURL != "" {
+		if err := n.postJSON(n.webhookURL, msg); err != nil {
+			log.Printf("[%s] notify: webhook: %v", result.Domain, err)
+		}
+	}
+
+	if n.slackURL != "" {
+		if err := n.postJSON(n.slackURL, map[string]string{"text": msg.Message}); err != nil {
+			log.Printf("[%s] notify: slack: %v", result.Domain, err)
+		}
+	}
+
+	if n.smtp.server != "" {
+		if err := n.sendMail(msg); err != nil {
+			log.Printf("[%s] notify: smtp: %v", result.Domain, err)
+		}
+	}
+}
+
+func (n *notifier) postJSON(url string, body interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := n.httpClient.Post(url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (n *notifier) sendMail(msg notification) error {
+	if n.smtp.from == "" || len(n.smtp.to) == 0 {
+		return errors.New("the sender and the recipients are required")
+	}
+
+	var auth smtp.Auth
+	if n.smtp.username != "" {
+		host, _, err := net.SplitHostPort(n.smtp.server)
+		if err != nil {
+			return err
+		}
+
+		auth = smtp.PlainAuth("", n.smtp.username, n.smtp.password, host)
+	}
+
+	return smtpSendMail(n.smtp.server, auth, n.smtp.from, n.smtp.to, mailContent(n.smtp.from, n.smtp.to, msg))
+}
+
+// mailContent creates the content (headers and body) of an email notification.
+func mailContent(from string, to []string, msg notification) []byte {
+	buf := &bytes.Buffer{}
+
+	fmt.Fprintf(buf, "From: %s\r\n", from)
+	fmt.Fprintf(buf, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(buf, "Subject: %s\r\n", msg.Message)
+	fmt.Fprintf(buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+
+	buf.WriteString(msg.Message + "\r\n\r\n")
+
+	result := msg.Certificate
+	if len(result.Domains) > 0 {
+		fmt.Fprintf(buf, "Domains: %s\r\n", strings.Join(result.Domains, ", "))
+	}
+	if result.Serial != "" {
+		fmt.Fprintf(buf, "Serial: %s\r\n", result.Serial)
+	}
+	if result.NotAfter != nil {
+		fmt.Fprintf(buf, "Expires: %s\r\n", result.NotAfter.Format(time.RFC3339))
+	}
+	if result.CertPath != "" {
+		fmt.Fprintf(buf, "Certificate: %s\r\n", result.CertPath)
+	}
+	if result.Error != "" {
+		fmt.Fprintf(buf, "Error: %s\r\n", result.Error)
+	}
+
+	return buf.Bytes()
+}
+
+// notificationMessage creates the message (one line) of a notification.
+func notificationMessage(event string, result *certificateResult) string {
+	name := result.Domain
+	if name == "" {
+		name = strings.Join(result.Domains, ", ")
+	}
+
+	switch event {
+	case notifyEventSuccess:
+		msg := fmt.Sprintf("[lego] The certificate for %s has been %s", name, result.Status)
+		if result.NotAfter != nil {
+			msg += fmt.Sprintf(", it expires on %s", result.NotAfter.Format(time.RFC3339))
+		}
+		return msg + "."
+	case notifyEventFailure:
+		return fmt.Sprintf("[lego] The processing of the certificate for %s has failed: %s", name, strings.Join(strings.Fields(result.Error), " "))
+	case notifyEventExpiry:
+		return fmt.Sprintf("[lego] The certificate for %s expires in %d days (%s).",
+			name, daysRemaining(*result.NotAfter), result.NotAfter.Format(time.RFC3339))
+	default:
+		return fmt.Sprintf("[lego] %s: %s", event, name)
+	}
+}
+
+func daysRemaining(notAfter time.Time) int {
+	return int(time.Until(notAfter).Hours() / 24)
+}
+
+// notify sends the notification of an event, the notifications are disabled by --dry-run.
+func notify(ctx *cli.Context, event string, result *certificateResult) {
+	if isDryRun(ctx) {
+		return
+	}
+
+	newNotifier(ctx).send(event, result)
+}
+
+// notifyExpiry sends the expiry notification if the certificate expires in less than the number of days defined by notify.expiry-days.
+func notifyExpiry(ctx *cli.Context, result *certificateResult) {
+	if result.NotAfter == nil || daysRemaining(*result.NotAfter) > ctx.GlobalInt("notify.expiry-days") {
+		return
+	}
+
+	notify(ctx, notifyEventExpiry, result)
+}
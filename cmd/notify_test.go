@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_notifier_send(t *testing.T) {
+	var webhook notification
+	var slack map[string]string
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/webhook", func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&webhook))
+	})
+	mux.HandleFunc("/slack", func(rw http.ResponseWriter, req *http.Request) {
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&slack))
+	})
+
+	var mail struct {
+		addr string
+		from string
+		to   []string
+		msg  string
+	}
+
+	defer func(sendMail func(string, smtp.Auth, string, []string, []byte) error) { smtpSendMail = sendMail }(smtpSendMail)
+	smtpSendMail = func(addr string, _ smtp.Auth, from string, to []string, msg []byte) error {
+		mail.addr, mail.from, mail.to, mail.msg = addr, from, to, string(msg)
+		return nil
+	}
+
+	n := &notifier{
+		webhookURL: server.URL + "/webhook",
+		slackURL:   server.URL + "/slack",
+		smtp: smtpConfig{
+			server: "smtp.example.com:587",
+			from:   "lego@example.com",
+			to:     []string{"admin@example.com"},
+		},
+		events:     map[string]bool{notifyEventFailure: true},
+		httpClient: server.Client(),
+	}
+
+	result := &certificateResult{Domain: "example.com", Status: statusFailed, Error: "urn:ietf:params:acme:error:rateLimited"}
+
+	n.send(notifyEventFailure, result)
+
+	expectedMessage := "[lego] The processing of the certificate for example.com has failed: urn:ietf:params:acme:error:rateLimited"
+
+	assert.Equal(t, notifyEventFailure, webhook.Event)
+	assert.Equal(t, expectedMessage, webhook.Message)
+	assert.Equal(t, result, webhook.Certificate)
+
+	assert.Equal(t, map[string]string{"text": expectedMessage}, slack)
+
+	assert.Equal(t, "smtp.example.com:587", mail.addr)
+	assert.Equal(t, "lego@example.com", mail.from)
+	assert.Equal(t, []string{"admin@example.com"}, mail.to)
+	assert.Contains(t, mail.msg, "Subject: "+expectedMessage+"\r\n")
+	assert.Contains(t, mail.msg, "Error: urn:ietf:params:acme:error:rateLimited\r\n")
+}
+
+func Test_notifier_send_disabledEvent(t *testing.T) {
+	var called bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	n := &notifier{
+		webhookURL: server.URL,
+		events:     map[string]bool{notifyEventFailure: true},
+		httpClient: server.Client(),
+	}
+
+	n.send(notifyEventSuccess, &certificateResult{Domain: "example.com", Status: statusObtained})
+
+	assert.False(t, called)
+}
+
+func Test_notifier_postJSON_error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, "nope", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	n := &notifier{httpClient: server.Client()}
+
+	err := n.postJSON(server.URL, map[string]string{"text": "hello"})
+	require.EqualError(t, err, "unexpected status code: 403")
+}
+
+func Test_notificationMessage(t *testing.T) {
+	notAfter := time.Now().Add(10*24*time.Hour + time.Hour).UTC().Truncate(time.Second)
+
+	testCases := []struct {
+		desc     string
+		event    string
+		result   *certificateResult
+		expected string
+	}{
+		{
+			desc:     "success",
+			event:    notifyEventSuccess,
+			result:   &certificateResult{Domain: "example.com", Status: statusRenewed, NotAfter: &notAfter},
+			expected: "[lego] The certificate for example.com has been renewed, it expires on " + notAfter.Format(time.RFC3339) + ".",
+		},
+		{
+			desc:     "failure without domain",
+			event:    notifyEventFailure,
+			result:   &certificateResult{Domains: []string{"example.com", "example.org"}, Error: "could not obtain certificates:\n\tboom"},
+			expected: "[lego] The processing of the certificate for example.com, example.org has failed: could not obtain certificates: boom",
+		},
+		{
+			desc:     "expiry",
+			event:    notifyEventExpiry,
+			result:   &certificateResult{Domain: "example.com", NotAfter: &notAfter},
+			expected: "[lego] The certificate for example.com expires in 10 days (" + notAfter.Format(time.RFC3339) + ").",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			msg := notificationMessage(test.event, test.result)
+			assert.Equal(t, test.expected, msg)
+			assert.False(t, strings.Contains(msg, "\n"))
+		})
+	}
+}
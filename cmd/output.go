@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/go-acme/lego/v3/certcrypto"
+	"github.com/go-acme/lego/v3/certificate"
+	"github.com/go-acme/lego/v3/log"
+	"github.com/urfave/cli"
+)
+
+// Output formats.
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+// Status of a certificate in the results of the commands.
+const (
+	statusObtained = "obtained"
+	statusRenewed  = "renewed"
+	statusSkipped  = "skipped"
+	statusRevoked  = "revoked"
+	statusFailed   = "failed"
+)
+
+// jsonOutput the writer of the JSON documents.
+var jsonOutput io.Writer = os.Stdout
+
+// certificateResult the result of a command for a certificate (JSON output).
+type certificateResult struct {
+	Domain           string       `json:"domain"`
+	Domains          []string     `json:"domains,omitempty"`
+	Status           string       `json:"status"`
+	CertPath         string       `json:"certPath,omitempty"`
+	KeyPath          string       `json:"keyPath,omitempty"`
+	IssuerPath       string       `json:"issuerPath,omitempty"`
+	PEMPath          string       `json:"pemPath,omitempty"`
+	HAProxyPath      string       `json:"haproxyPath,omitempty"`
+	PFXPath          string       `json:"pfxPath,omitempty"`
+	KeyStorePath     string       `json:"keyStorePath,omitempty"`
+	KubernetesSecret string       `json:"kubernetesSecret,omitempty"`
+	Serial           string       `json:"serial,omitempty"`
+	NotAfter         *time.Time   `json:"notAfter,omitempty"`
+	Archived         bool         `json:"archived,omitempty"`
+	DryRun           bool         `json:"dryRun,omitempty"`
+	Changes          []fileChange `json:"changes,omitempty"`
+	Error            string       `json:"error,omitempty"`
+}
+
+// newCertificateResult creates the result of an issued certificate.
+func newCertificateResult(certsStorage *CertificatesStorage, certRes *certificate.Resource, status string) *certificateResult {
+	result := &certificateResult{
+		Domain:   certRes.Domain,
+		Status:   status,
+		CertPath: certsStorage.GetFileName(certRes.Domain, ".crt"),
+	}
+
+	if certRes.PrivateKey != nil {
+		result.KeyPath = certsStorage.GetFileName(certRes.Domain, ".key")
+
+		if certsStorage.pem {
+			result.PEMPath = certsStorage.GetFileName(certRes.Domain, ".pem")
+		}
+
+		if certsStorage.haproxy {
+			result.HAProxyPath = certsStorage.GetFileName(certRes.Domain, ".haproxy.pem")
+		}
+
+		if certsStorage.pfx {
+			result.PFXPath = certsStorage.GetFileName(certRes.Domain, ".pfx")
+		}
+
+		if extension := certsStorage.keyStoreFileExtension(); extension != "" {
+			result.KeyStorePath = certsStorage.GetFileName(certRes.Domain, extension)
+		}
+	}
+
+	if certRes.IssuerCertificate != nil {
+		result.IssuerPath = certsStorage.GetFileName(certRes.Domain, ".issuer.crt")
+	}
+
+	cert, err := certcrypto.ParsePEMCertificate(certRes.Certificate)
+	if err != nil {
+		log.Printf("[%s] Unable to read the certificate: %v", certRes.Domain, err)
+		return result
+	}
+
+	result.setCertificate(cert)
+
+	return result
+}
+
+// setCertificate sets the information extracted from the certificate.
+func (r *certificateResult) setCertificate(cert *x509.Certificate) {
+	notAfter := cert.NotAfter.UTC()
+
+	r.Domains = certcrypto.ExtractDomains(cert)
+	r.Serial = fmt.Sprintf("%x", cert.SerialNumber)
+	r.NotAfter = &notAfter
+}
+
+// setupOutput checks the output format.
+// With the JSON output, stdout is only used by the JSON documents (jsonOutput): the logs and the other messages are written on stderr (see messageOutput).
+func setupOutput(ctx *cli.Context) error {
+	switch ctx.GlobalString("output") {
+	case outputText, outputJSON:
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format: %s", ctx.GlobalString("output"))
+	}
+}
+
+// messageOutput returns the writer of the logs and the messages for the user: stderr with the JSON output, stdout otherwise.
+func messageOutput(ctx *cli.Context) io.Writer {
+	if isJSONOutput(ctx) {
+		return os.Stderr
+	}
+
+	return os.Stdout
+}
+
+func isJSONOutput(ctx *cli.Context) bool {
+	return ctx.GlobalString("output") == outputJSON
+}
+
+// printJSON writes a JSON document on the output.
+func printJSON(v interface{}) error {
+	encoder := json.NewEncoder(jsonOutput)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(v)
+}
+
+// printResult writes the result of a certificate with the JSON output.
+func printResult(ctx *cli.Context, result *certificateResult) {
+	if !isJSONOutput(ctx) {
+		return
+	}
+
+	err := printJSON(result)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// failedResult writes the result of a failed certificate with the JSON output, sends the failure notification, then returns the error.
+func failedResult(ctx *cli.Context, result *certificateResult, err error) error {
+	result.Status = statusFailed
+	result.Error = err.Error()
+
+	printResult(ctx, result)
+
+	notify(ctx, notifyEventFailure, result)
+
+	return err
+}
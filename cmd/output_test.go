@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+func Test_printJSON(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	previous := jsonOutput
+	jsonOutput = buf
+	defer func() { jsonOutput = previous }()
+
+	notAfter := time.Date(2020, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	results := []*certificateResult{
+		{
+			Domain:   "example.com",
+			Domains:  []string{"example.com", "www.example.com"},
+			Status:   statusRenewed,
+			CertPath: "/tmp/lego/certificates/example.com.crt",
+			KeyPath:  "/tmp/lego/certificates/example.com.key",
+			Serial:   "3a1b",
+			NotAfter: &notAfter,
+		},
+		{
+			Domain: "example.org",
+			Status: statusFailed,
+			Error:  "boom",
+		},
+	}
+
+	err := printJSON(results)
+	require.NoError(t, err)
+
+	expected := `[
+  {
+    "domain": "example.com",
+    "domains": [
+      "example.com",
+      "www.example.com"
+    ],
+    "status": "renewed",
+    "certPath": "/tmp/lego/certificates/example.com.crt",
+    "keyPath": "/tmp/lego/certificates/example.com.key",
+    "serial": "3a1b",
+    "notAfter": "2020-03-01T12:00:00Z"
+  },
+  {
+    "domain": "example.org",
+    "status": "failed",
+    "error": "boom"
+  }
+]
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func Test_setupOutput(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		args      []string
+		expected  io.Writer
+		expectErr string
+	}{
+		{desc: "default", expected: os.Stdout},
+		{desc: "text", args: []string{"--output=text"}, expected: os.Stdout},
+		{desc: "JSON", args: []string{"--output=json"}, expected: os.Stderr},
+		{desc: "unsupported format", args: []string{"--output=xml"}, expectErr: "unsupported output format: xml"},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			stdout := os.Stdout
+
+			set, err := newFlagSet("lego", CreateFlags(""), test.args)
+			require.NoError(t, err)
+
+			ctx := cli.NewContext(cli.NewApp(), set, nil)
+
+			err = setupOutput(ctx)
+			if test.expectErr != "" {
+				require.EqualError(t, err, test.expectErr)
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Same(t, stdout, os.Stdout)
+			assert.Same(t, os.Stdout, jsonOutput)
+			assert.Equal(t, test.expected, messageOutput(ctx))
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/urfave/cli"
+)
+
+// Actions of the option --service of the daemon (Windows).
+const (
+	serviceInstall   = "install"
+	serviceUninstall = "uninstall"
+	serviceStart     = "start"
+	serviceStop      = "stop"
+	serviceRun       = "run"
+)
+
+const defaultServiceName = "lego"
+
+// isServiceControl returns true if the daemon only controls the service (no certificate is processed).
+func isServiceControl(ctx *cli.Context) bool {
+	switch ctx.String("service") {
+	case serviceUninstall, serviceStart, serviceStop:
+		return true
+	default:
+		return false
+	}
+}
+
+// serviceArgs returns the arguments of the service from the arguments of the installation:
+// the action "install" of the option --service is replaced by the action "run",
+// and the absolute path of the data is added if the option --path is not defined (the working directory of a service is not the current directory).
+func serviceArgs(args []string, path string) []string {
+	var result []string
+
+	var pathDefined bool
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		switch {
+		case arg == "--service" || arg == "-service":
+			result = append(result, "--service="+serviceRun)
+			i++ // skip the value.
+			continue
+		case strings.HasPrefix(arg, "--service=") || strings.HasPrefix(arg, "-service="):
+			result = append(result, "--service="+serviceRun)
+			continue
+		case arg == "--path" || arg == "-path" || strings.HasPrefix(arg, "--path=") || strings.HasPrefix(arg, "-path="):
+			pathDefined = true
+		}
+
+		result = append(result, arg)
+	}
+
+	if !pathDefined {
+		result = append([]string{"--path=" + path}, result...)
+	}
+
+	return result
+}
@@ -0,0 +1,16 @@
+//go:build !windows
+// +build !windows
+
+package cmd
+
+import "github.com/urfave/cli"
+
+// createServiceFlags creates the flags of the Windows service, the service mode is only available on Windows.
+func createServiceFlags() []cli.Flag {
+	return nil
+}
+
+// handleService handles the option --service of the daemon, the service mode is only available on Windows.
+func handleService(_ *cli.Context) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_serviceArgs(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		args     []string
+		expected []string
+	}{
+		{
+			desc:     "separated value",
+			args:     []string{"--email=foo@bar.com", "--domains=example.com", "--http", "daemon", "--service", "install", "--days=45"},
+			expected: []string{"--path=C:\\lego", "--email=foo@bar.com", "--domains=example.com", "--http", "daemon", "--service=run", "--days=45"},
+		},
+		{
+			desc:     "inline value and path",
+			args:     []string{"--path", "D:\\certs", "--domains=example.com", "daemon", "--service=install", "--service.name=lego-example"},
+			expected: []string{"--path", "D:\\certs", "--domains=example.com", "daemon", "--service=run", "--service.name=lego-example"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, serviceArgs(test.args, "C:\\lego"))
+		})
+	}
+}
@@ -0,0 +1,268 @@
+//go:build windows
+// +build windows
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v3/log"
+	"github.com/urfave/cli"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/eventlog"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+// eventID the identifier of the events written by lego in the event log.
+const eventID = 1
+
+// createServiceFlags creates the flags of the Windows service.
+func createServiceFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name: "service",
+			Usage: "Manage the daemon as a Windows service. Supported: install (the options of the command line are the options of the service), " +
+				"uninstall, start, stop, run (used by the service manager).",
+		},
+		cli.StringFlag{
+			Name:  "service.name",
+			Usage: "The name of the Windows service.",
+			Value: defaultServiceName,
+		},
+	}
+}
+
+// handleService handles the option --service of the daemon, returns false if the daemon must run in the console.
+func handleService(ctx *cli.Context) (bool, error) {
+	name := ctx.String("service.name")
+
+	var err error
+
+	switch ctx.String("service") {
+	case "":
+		return false, nil
+	case serviceInstall:
+		err = installService(ctx, name)
+	case serviceUninstall:
+		err = uninstallService(name)
+	case serviceStart:
+		err = controlService(name, func(s *mgr.Service) error { return s.Start() })
+	case serviceStop:
+		err = controlService(name, func(s *mgr.Service) error {
+			_, errC := s.Control(svc.Stop)
+			return errC
+		})
+	case serviceRun:
+		err = runService(ctx, name)
+	default:
+		err = fmt.Errorf("unsupported service action: %s", ctx.String("service"))
+	}
+
+	if err != nil {
+		return true, fmt.Errorf("service %s: %v", name, err)
+	}
+
+	return true, nil
+}
+
+func installService(ctx *cli.Context, name string) error {
+	if ctx.GlobalIsSet("path") && !filepath.IsAbs(ctx.GlobalString("path")) {
+		return errors.New("the option --path must be an absolute path")
+	}
+
+	path, err := filepath.Abs(ctx.GlobalString("path"))
+	if err != nil {
+		return err
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	m, err := mgr.Connect()
+	if err != nil {
+		return err
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(name)
+	if err == nil {
+		s.Close()
+		return errors.New("the service already exists")
+	}
+
+	subject := strings.Join(ctx.GlobalStringSlice("domains"), ", ")
+	description := "Obtains and renews the certificate of " + subject + "."
+
+	if ctx.GlobalIsSet("manifest") {
+		subject = filepath.Base(ctx.GlobalString("manifest"))
+		description = "Obtains and renews the certificates of the manifest " + subject + "."
+	}
+
+	config := mgr.Config{
+		DisplayName: "lego (" + subject + ")",
+		Description: description,
+		StartType:   mgr.StartAutomatic,
+	}
+
+	s, err = m.CreateService(name, executable, config, serviceArgs(os.Args[1:], path)...)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	err = eventlog.InstallAsEventCreate(name, eventlog.Error|eventlog.Warning|eventlog.Info)
+	if err != nil {
+		_ = s.Delete()
+		return fmt.Errorf("unable to install the event log source: %v", err)
+	}
+
+	log.Printf("The service %s has been installed, the data are stored in %s.", name, path)
+
+	return nil
+}
+
+func uninstallService(name string) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return err
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(name)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	err = s.Delete()
+	if err != nil {
+		return err
+	}
+
+	err = eventlog.Remove(name)
+	if err != nil {
+		return fmt.Errorf("unable to remove the event log source: %v", err)
+	}
+
+	log.Printf("The service %s has been uninstalled.", name)
+
+	return nil
+}
+
+func controlService(name string, control func(s *mgr.Service) error) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return err
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(name)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	return control(s)
+}
+
+// runService runs the daemon under the control of the service manager, the logs are written in the event log.
+func runService(ctx *cli.Context, name string) error {
+	elog, err := eventlog.Open(name)
+	if err != nil {
+		return err
+	}
+	defer elog.Close()
+
+	// the event log defines the time and the type of the entries: the logs are written as text.
+	logger, err := newLogger(ctx, log.FormatText, &eventLogWriter{elog: elog}, 0)
+	if err != nil {
+		return err
+	}
+
+	log.Logger = logger
+
+	return svc.Run(name, &daemonService{ctx: ctx})
+}
+
+// daemonService the handler of the requests of the service manager.
+type daemonService struct {
+	ctx *cli.Context
+}
+
+func (d *daemonService) Execute(_ []string, requests <-chan svc.ChangeRequest, status chan<- svc.Status) (bool, uint32) {
+	status <- svc.Status{State: svc.StartPending}
+
+	stop := make(chan struct{})
+	done := make(chan error, 1)
+
+	go func() {
+		done <- runDaemon(d.ctx, stop)
+	}()
+
+	status <- svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}
+
+	for {
+		select {
+		case err := <-done:
+			if err != nil {
+				log.Printf("The daemon has failed: %v", err)
+				return false, 1
+			}
+			return false, 0
+
+		case req := <-requests:
+			switch req.Cmd {
+			case svc.Interrogate:
+				status <- req.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				log.Printf("Received %s request, stopping.", serviceCommandName(req.Cmd))
+				status <- svc.Status{State: svc.StopPending}
+				close(stop)
+
+				select {
+				case <-done:
+				case <-time.After(30 * time.Second):
+				}
+
+				return false, 0
+			}
+		}
+	}
+}
+
+func serviceCommandName(cmd svc.Cmd) string {
+	if cmd == svc.Shutdown {
+		return "shutdown"
+	}
+
+	return "stop"
+}
+
+// eventLogWriter writes the logs in the event log: the warnings as warnings, the other logs as information.
+type eventLogWriter struct {
+	elog *eventlog.Log
+}
+
+func (w *eventLogWriter) Write(p []byte) (int, error) {
+	msg := strings.TrimSpace(string(p))
+
+	var err error
+	if strings.HasPrefix(msg, "[WARN]") {
+		err = w.elog.Warning(eventID, msg)
+	} else {
+		err = w.elog.Info(eventID, msg)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
@@ -3,40 +3,60 @@ package cmd
 import (
 	"crypto/x509"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v3/certcrypto"
 	"github.com/go-acme/lego/v3/lego"
-	"github.com/go-acme/lego/v3/log"
 	"github.com/go-acme/lego/v3/registration"
 	"github.com/urfave/cli"
 )
 
 const filePerm os.FileMode = 0600
 
-func setup(ctx *cli.Context, accountsStorage *AccountsStorage) (*Account, *lego.Client) {
-	keyType := getKeyType(ctx)
-	privateKey := accountsStorage.GetPrivateKey(keyType)
+// setup loads (or creates) the account and its private key, then creates the ACME client.
+func setup(ctx *cli.Context, accountsStorage *AccountsStorage) (*Account, *lego.Client, error) {
+	keyType, err := getKeyType(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	privateKey, err := accountsStorage.GetPrivateKey(keyType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	exists, err := accountsStorage.ExistsAccountFilePath()
+	if err != nil {
+		return nil, nil, err
+	}
 
 	var account *Account
-	if accountsStorage.ExistsAccountFilePath() {
-		account = accountsStorage.LoadAccount(privateKey)
+	if exists {
+		account, err = accountsStorage.LoadAccount(privateKey)
+		if err != nil {
+			return nil, nil, err
+		}
 	} else {
 		account = &Account{Email: accountsStorage.GetUserID(), key: privateKey}
 	}
 
-	client := newClient(ctx, account, keyType)
+	client, err := newClient(ctx, account, keyType)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	return account, client
+	return account, client, nil
 }
 
-func newClient(ctx *cli.Context, acc registration.User, keyType certcrypto.KeyType) *lego.Client {
+func newClient(ctx *cli.Context, acc registration.User, keyType certcrypto.KeyType) (*lego.Client, error) {
 	config := lego.NewConfig(acc)
-	config.CADirURL = ctx.GlobalString("server")
+	config.CADirURL = getServer(ctx)
 
 	config.Certificate = lego.CertificateConfig{
 		KeyType: keyType,
@@ -50,42 +70,41 @@ func newClient(ctx *cli.Context, acc registration.User, keyType certcrypto.KeyTy
 
 	client, err := lego.NewClient(config)
 	if err != nil {
-		log.Fatalf("Could not create client: %v", err)
+		return nil, fmt.Errorf("could not create client: %v", err)
 	}
 
-	if client.GetExternalAccountRequired() && !ctx.GlobalIsSet("eab") {
-		log.Fatal("Server requires External Account Binding. Use --eab with --kid and --hmac.")
+	if client.GetExternalAccountRequired() && !requiresEAB(ctx) {
+		return nil, errors.New("server requires External Account Binding, use --eab with --kid and --hmac")
 	}
 
-	return client
+	return client, nil
 }
 
 // getKeyType the type from which private keys should be generated
-func getKeyType(ctx *cli.Context) certcrypto.KeyType {
+func getKeyType(ctx *cli.Context) (certcrypto.KeyType, error) {
 	keyType := ctx.GlobalString("key-type")
 	switch strings.ToUpper(keyType) {
 	case "RSA2048":
-		return certcrypto.RSA2048
+		return certcrypto.RSA2048, nil
 	case "RSA4096":
-		return certcrypto.RSA4096
+		return certcrypto.RSA4096, nil
 	case "RSA8192":
-		return certcrypto.RSA8192
+		return certcrypto.RSA8192, nil
 	case "EC256":
-		return certcrypto.EC256
+		return certcrypto.EC256, nil
 	case "EC384":
-		return certcrypto.EC384
+		return certcrypto.EC384, nil
 	}
 
-	log.Fatalf("Unsupported KeyType: %s", keyType)
-	return ""
+	return "", fmt.Errorf("unsupported KeyType: %s", keyType)
 }
 
-func getEmail(ctx *cli.Context) string {
+func getEmail(ctx *cli.Context) (string, error) {
 	email := ctx.GlobalString("email")
 	if len(email) == 0 {
-		log.Fatal("You have to pass an account (email address) to the program using --email or -m")
+		return "", errors.New("you have to pass an account (email address) to the program using --email or -m")
 	}
-	return email
+	return email, nil
 }
 
 func createNonExistingFolder(path string) error {
@@ -97,11 +116,25 @@ func createNonExistingFolder(path string) error {
 	return nil
 }
 
+// readCSRFile reads a CSR (PEM or DER) from a file, or from stdin if the name of the file is "-".
 func readCSRFile(filename string) (*x509.CertificateRequest, error) {
-	bytes, err := ioutil.ReadFile(filename)
+	var bytes []byte
+	var err error
+
+	if filename == stdinFileName {
+		bytes, err = readStdin()
+	} else {
+		bytes, err = ioutil.ReadFile(filename)
+	}
 	if err != nil {
 		return nil, err
 	}
+
+	return parseCSR(bytes)
+}
+
+// parseCSR parses a PEM-encoded or a DER-encoded CSR.
+func parseCSR(bytes []byte) (*x509.CertificateRequest, error) {
 	raw := bytes
 
 	// see if we can find a PEM-encoded CSR
@@ -117,7 +150,7 @@ func readCSRFile(filename string) (*x509.CertificateRequest, error) {
 		}
 
 		// did we get a CSR?
-		if p.Type == "CERTIFICATE REQUEST" {
+		if p.Type == "CERTIFICATE REQUEST" || p.Type == "NEW CERTIFICATE REQUEST" {
 			raw = p.Bytes
 		}
 	}
@@ -125,5 +158,29 @@ func readCSRFile(filename string) (*x509.CertificateRequest, error) {
 	// no PEM-encoded CSR
 	// assume we were given a DER-encoded ASN.1 CSR
 	// (if this assumption is wrong, parsing these bytes will fail)
-	return x509.ParseCertificateRequest(raw)
+	csr, err := x509.ParseCertificateRequest(raw)
+	if err != nil {
+		return nil, fmt.Errorf("the CSR is neither a PEM-encoded nor a DER-encoded CSR: %v", err)
+	}
+
+	return csr, nil
+}
+
+// stdin the content of stdin, stdin can only be read once.
+var stdin struct {
+	once sync.Once
+	data []byte
+	err  error
+}
+
+// readStdin reads stdin, the content is kept to be read several times (i.e. by the certificates of a manifest).
+func readStdin() ([]byte, error) {
+	stdin.once.Do(func() {
+		stdin.data, stdin.err = ioutil.ReadAll(os.Stdin)
+		if stdin.err != nil {
+			stdin.err = fmt.Errorf("unable to read stdin: %v", stdin.err)
+		}
+	})
+
+	return stdin.data, stdin.err
 }
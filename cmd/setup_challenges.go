@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
 	"net"
 	"strings"
 	"time"
@@ -10,101 +12,174 @@ import (
 	"github.com/go-acme/lego/v3/challenge/http01"
 	"github.com/go-acme/lego/v3/challenge/tlsalpn01"
 	"github.com/go-acme/lego/v3/lego"
-	"github.com/go-acme/lego/v3/log"
+	"github.com/go-acme/lego/v3/platform/apidebug"
 	"github.com/go-acme/lego/v3/providers/dns"
 	"github.com/go-acme/lego/v3/providers/http/memcached"
 	"github.com/go-acme/lego/v3/providers/http/webroot"
 	"github.com/urfave/cli"
 )
 
-func setupChallenges(ctx *cli.Context, client *lego.Client) {
-	if !ctx.GlobalBool("http") && !ctx.GlobalBool("tls") && !ctx.GlobalIsSet("dns") {
-		log.Fatal("No challenge selected. You must specify at least one challenge: `--http`, `--tls`, `--dns`.")
+// challengeRule the challenge selected for a domain and its subdomains (--challenge).
+type challengeRule struct {
+	domain    string
+	challenge challenge.Type
+	// provider the DNS provider of the domain, the provider defined by --dns if empty.
+	provider string
+}
+
+// challengeNames the names of the challenges used by --challenge.
+var challengeNames = map[string]challenge.Type{
+	"http": challenge.HTTP01,
+	"tls":  challenge.TLSALPN01,
+	"dns":  challenge.DNS01,
+}
+
+func setupChallenges(ctx *cli.Context, client *lego.Client) error {
+	rules, err := parseChallengeRules(ctx.GlobalStringSlice("challenge"))
+	if err != nil {
+		return err
 	}
 
-	if ctx.GlobalBool("http") {
-		err := client.Challenge.SetHTTP01Provider(setupHTTPProvider(ctx))
+	if !ctx.GlobalBool("http") && !ctx.GlobalBool("tls") && !ctx.GlobalIsSet("dns") && len(rules) == 0 {
+		return errors.New("no challenge selected: you must specify at least one challenge: `--http`, `--tls`, `--dns`, `--challenge`")
+	}
+
+	if ctx.GlobalBool("http") || hasChallengeRule(rules, challenge.HTTP01) {
+		provider, err := setupHTTPProvider(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = client.Challenge.SetHTTP01Provider(provider)
+		if err != nil {
+			return err
+		}
+	}
+
+	if ctx.GlobalBool("tls") || hasChallengeRule(rules, challenge.TLSALPN01) {
+		provider, err := setupTLSProvider(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = client.Challenge.SetTLSALPN01Provider(provider)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
 
-	if ctx.GlobalBool("tls") {
-		err := client.Challenge.SetTLSALPN01Provider(setupTLSProvider(ctx))
+	if ctx.GlobalIsSet("dns") || hasChallengeRule(rules, challenge.DNS01) {
+		err = setupDNS(ctx, client, rules)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 	}
 
-	if ctx.GlobalIsSet("dns") {
-		setupDNS(ctx, client)
+	for _, rule := range rules {
+		client.Challenge.SetDomainChallenges(rule.domain, rule.challenge)
+	}
+
+	return nil
+}
+
+// parseChallengeRules parses the challenges selected for the domains: domain:http, domain:tls, domain:dns or domain:dns:provider.
+func parseChallengeRules(values []string) ([]challengeRule, error) {
+	var rules []challengeRule
+	domains := make(map[string]bool)
+
+	for _, value := range values {
+		parts := strings.SplitN(value, ":", 3)
+		if len(parts) < 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid challenge definition: %q (expected domain:challenge)", value)
+		}
+
+		rule := challengeRule{domain: strings.ToLower(strings.TrimSpace(parts[0]))}
+
+		chlgType, ok := challengeNames[strings.ToLower(strings.TrimSpace(parts[1]))]
+		if !ok {
+			return nil, fmt.Errorf("invalid challenge definition: %q (supported challenges: http, tls, dns)", value)
+		}
+		rule.challenge = chlgType
+
+		if len(parts) == 3 {
+			if chlgType != challenge.DNS01 || strings.TrimSpace(parts[2]) == "" {
+				return nil, fmt.Errorf("invalid challenge definition: %q (a provider can only be defined for dns)", value)
+			}
+
+			rule.provider = strings.TrimSpace(parts[2])
+		}
+
+		if domains[rule.domain] {
+			return nil, fmt.Errorf("the challenge of the domain %s is defined several times", rule.domain)
+		}
+		domains[rule.domain] = true
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func hasChallengeRule(rules []challengeRule, chlgType challenge.Type) bool {
+	for _, rule := range rules {
+		if rule.challenge == chlgType {
+			return true
+		}
 	}
+
+	return false
 }
 
-func setupHTTPProvider(ctx *cli.Context) challenge.Provider {
+func setupHTTPProvider(ctx *cli.Context) (challenge.Provider, error) {
 	switch {
 	case ctx.GlobalIsSet("http.webroot"):
-		ps, err := webroot.NewHTTPProvider(ctx.GlobalString("http.webroot"))
-		if err != nil {
-			log.Fatal(err)
-		}
-		return ps
+		return webroot.NewHTTPProvider(ctx.GlobalString("http.webroot"))
 	case ctx.GlobalIsSet("http.memcached-host"):
-		ps, err := memcached.NewMemcachedProvider(ctx.GlobalStringSlice("http.memcached-host"))
-		if err != nil {
-			log.Fatal(err)
-		}
-		return ps
+		return memcached.NewMemcachedProvider(ctx.GlobalStringSlice("http.memcached-host"))
 	case ctx.GlobalIsSet("http.port"):
 		iface := ctx.GlobalString("http.port")
 		if !strings.Contains(iface, ":") {
-			log.Fatalf("The --http switch only accepts interface:port or :port for its argument.")
+			return nil, errors.New("the --http switch only accepts interface:port or :port for its argument")
 		}
 
 		host, port, err := net.SplitHostPort(iface)
 		if err != nil {
-			log.Fatal(err)
+			return nil, err
 		}
 
-		return http01.NewProviderServer(host, port)
-	case ctx.GlobalBool("http"):
-		return http01.NewProviderServer("", "")
+		return http01.NewProviderServer(host, port), nil
 	default:
-		log.Fatal("Invalid HTTP challenge options.")
-		return nil
+		return http01.NewProviderServer("", ""), nil
 	}
 }
 
-func setupTLSProvider(ctx *cli.Context) challenge.Provider {
+func setupTLSProvider(ctx *cli.Context) (challenge.Provider, error) {
 	switch {
 	case ctx.GlobalIsSet("tls.port"):
 		iface := ctx.GlobalString("tls.port")
 		if !strings.Contains(iface, ":") {
-			log.Fatalf("The --tls switch only accepts interface:port or :port for its argument.")
+			return nil, errors.New("the --tls switch only accepts interface:port or :port for its argument")
 		}
 
 		host, port, err := net.SplitHostPort(iface)
 		if err != nil {
-			log.Fatal(err)
+			return nil, err
 		}
 
-		return tlsalpn01.NewProviderServer(host, port)
-	case ctx.GlobalBool("tls"):
-		return tlsalpn01.NewProviderServer("", "")
+		return tlsalpn01.NewProviderServer(host, port), nil
 	default:
-		log.Fatal("Invalid HTTP challenge options.")
-		return nil
+		return tlsalpn01.NewProviderServer("", ""), nil
 	}
 }
 
-func setupDNS(ctx *cli.Context, client *lego.Client) {
-	provider, err := dns.NewDNSChallengeProviderByName(ctx.GlobalString("dns"))
+func setupDNS(ctx *cli.Context, client *lego.Client, rules []challengeRule) error {
+	provider, err := setupDNSProviders(ctx, rules)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 
 	servers := ctx.GlobalStringSlice("dns.resolvers")
-	err = client.Challenge.SetDNS01Provider(provider,
+	return client.Challenge.SetDNS01Provider(provider,
 		dns01.CondOption(len(servers) > 0,
 			dns01.AddRecursiveNameservers(dns01.ParseNameservers(ctx.GlobalStringSlice("dns.resolvers")))),
 		dns01.CondOption(ctx.GlobalBool("dns.disable-cp"),
@@ -112,7 +187,70 @@ func setupDNS(ctx *cli.Context, client *lego.Client) {
 		dns01.CondOption(ctx.GlobalIsSet("dns-timeout"),
 			dns01.AddDNSTimeout(time.Duration(ctx.GlobalInt("dns-timeout"))*time.Second)),
 	)
+}
+
+// setupDNSProviders creates the DNS provider defined by --dns,
+// and the providers of the domains defined by --challenge (domain:dns:provider).
+func setupDNSProviders(ctx *cli.Context, rules []challengeRule) (challenge.Provider, error) {
+	apidebug.Install()
+
+	zones := make(map[string]challenge.Provider)
+	providers := make(map[string]challenge.Provider)
+
+	for _, rule := range rules {
+		if rule.challenge != challenge.DNS01 || rule.provider == "" {
+			continue
+		}
+
+		provider, ok := providers[rule.provider]
+		if !ok {
+			var err error
+			provider, err = dns.NewDNSChallengeProviderByName(rule.provider)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", rule.domain, err)
+			}
+			providers[rule.provider] = provider
+		}
+
+		zones[rule.domain] = provider
+	}
+
+	if !ctx.GlobalIsSet("dns") {
+		for _, rule := range rules {
+			if rule.challenge == challenge.DNS01 && rule.provider == "" {
+				return nil, fmt.Errorf("the DNS provider of the domain %s is not defined: use --dns or --challenge=%s:dns:provider", rule.domain, rule.domain)
+			}
+		}
+
+		return dns.NewZoneProvider(zones)
+	}
+
+	provider, err := setupDNSProvider(ctx)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
+
+	if len(zones) == 0 {
+		return provider, nil
+	}
+
+	// the provider defined by --dns is used for the other domains.
+	zones["."] = provider
+
+	return dns.NewZoneProvider(zones)
+}
+
+func setupDNSProvider(ctx *cli.Context) (challenge.Provider, error) {
+	apidebug.Install()
+
+	if !ctx.GlobalIsSet("dns.credentials") {
+		return dns.NewDNSChallengeProviderByName(ctx.GlobalString("dns"))
+	}
+
+	mapping, err := dns.ParseZoneCredentials(ctx.GlobalStringSlice("dns.credentials"))
+	if err != nil {
+		return nil, err
+	}
+
+	return dns.NewZoneCredentialsProvider(ctx.GlobalString("dns"), mapping)
 }
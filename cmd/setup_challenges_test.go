@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/go-acme/lego/v3/challenge"
+	"github.com/go-acme/lego/v3/providers/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+func Test_parseChallengeRules(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		values    []string
+		expected  []challengeRule
+		expectErr string
+	}{
+		{
+			desc: "rules",
+			values: []string{
+				"Internal.Example.com:dns:route53",
+				"example.com:http",
+				"*.example.org:DNS",
+				"example.net:tls",
+			},
+			expected: []challengeRule{
+				{domain: "internal.example.com", challenge: challenge.DNS01, provider: "route53"},
+				{domain: "example.com", challenge: challenge.HTTP01},
+				{domain: "*.example.org", challenge: challenge.DNS01},
+				{domain: "example.net", challenge: challenge.TLSALPN01},
+			},
+		},
+		{
+			desc:      "missing challenge",
+			values:    []string{"example.com"},
+			expectErr: `invalid challenge definition: "example.com" (expected domain:challenge)`,
+		},
+		{
+			desc:      "unknown challenge",
+			values:    []string{"example.com:foo"},
+			expectErr: `invalid challenge definition: "example.com:foo" (supported challenges: http, tls, dns)`,
+		},
+		{
+			desc:      "provider of http",
+			values:    []string{"example.com:http:route53"},
+			expectErr: `invalid challenge definition: "example.com:http:route53" (a provider can only be defined for dns)`,
+		},
+		{
+			desc:      "duplicate",
+			values:    []string{"example.com:http", "Example.com:dns"},
+			expectErr: "the challenge of the domain example.com is defined several times",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			rules, err := parseChallengeRules(test.values)
+			if test.expectErr != "" {
+				require.EqualError(t, err, test.expectErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, rules)
+		})
+	}
+}
+
+func Test_setupDNSProviders(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		args      []string
+		zone      bool
+		expectErr string
+	}{
+		{
+			desc: "global provider",
+			args: []string{"--dns=manual", "--challenge=example.com:dns"},
+		},
+		{
+			desc: "provider of a domain",
+			args: []string{"--challenge=example.com:dns:manual", "--challenge=example.org:http"},
+			zone: true,
+		},
+		{
+			desc: "global provider and provider of a domain",
+			args: []string{"--dns=manual", "--challenge=internal.example.com:dns:manual"},
+			zone: true,
+		},
+		{
+			desc:      "undefined provider",
+			args:      []string{"--challenge=example.com:dns"},
+			expectErr: "the DNS provider of the domain example.com is not defined: use --dns or --challenge=example.com:dns:provider",
+		},
+		{
+			desc:      "unknown provider",
+			args:      []string{"--challenge=example.com:dns:foo"},
+			expectErr: "example.com: unrecognized DNS provider: foo",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			set, err := newFlagSet("lego", CreateFlags(""), test.args)
+			require.NoError(t, err)
+
+			ctx := cli.NewContext(cli.NewApp(), set, nil)
+
+			rules, err := parseChallengeRules(ctx.GlobalStringSlice("challenge"))
+			require.NoError(t, err)
+
+			provider, err := setupDNSProviders(ctx, rules)
+			if test.expectErr != "" {
+				require.EqualError(t, err, test.expectErr)
+				return
+			}
+
+			require.NoError(t, err)
+
+			var isZone bool
+			switch provider.(type) {
+			case *dns.ZoneProvider, *dns.SequentialZoneProvider:
+				isZone = true
+			}
+			assert.Equal(t, test.zone, isZone)
+		})
+	}
+}
+
+func Test_setupChallenges_errors(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		args      []string
+		expectErr string
+	}{
+		{
+			desc:      "no challenge",
+			args:      []string{"--domains=example.com"},
+			expectErr: "no challenge selected: you must specify at least one challenge: `--http`, `--tls`, `--dns`, `--challenge`",
+		},
+		{
+			desc:      "invalid challenge",
+			args:      []string{"--challenge=example.com:foo"},
+			expectErr: `invalid challenge definition: "example.com:foo" (supported challenges: http, tls, dns)`,
+		},
+		{
+			desc:      "invalid HTTP port",
+			args:      []string{"--http", "--http.port=8080"},
+			expectErr: "the --http switch only accepts interface:port or :port for its argument",
+		},
+		{
+			desc:      "invalid TLS port",
+			args:      []string{"--tls", "--tls.port=8443"},
+			expectErr: "the --tls switch only accepts interface:port or :port for its argument",
+		},
+		{
+			desc:      "unknown DNS provider",
+			args:      []string{"--challenge=example.com:dns:foo"},
+			expectErr: "example.com: unrecognized DNS provider: foo",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			set, err := newFlagSet("lego", CreateFlags(""), test.args)
+			require.NoError(t, err)
+
+			ctx := cli.NewContext(cli.NewApp(), set, nil)
+
+			// the errors are returned before the client is used.
+			err = setupChallenges(ctx, nil)
+			require.EqualError(t, err, test.expectErr)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseCSR(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: "example.com"},
+		DNSNames: []string{"example.com", "www.example.com"},
+	}, privateKey)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc string
+		data []byte
+	}{
+		{
+			desc: "DER",
+			data: der,
+		},
+		{
+			desc: "PEM",
+			data: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}),
+		},
+		{
+			desc: "PEM (NEW CERTIFICATE REQUEST)",
+			data: pem.EncodeToMemory(&pem.Block{Type: "NEW CERTIFICATE REQUEST", Bytes: der}),
+		},
+		{
+			desc: "PEM with the private key",
+			data: append(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: []byte("key")}),
+				pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})...),
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			csr, err := parseCSR(test.data)
+			require.NoError(t, err)
+
+			assert.Equal(t, "example.com", csr.Subject.CommonName)
+			assert.Equal(t, []string{"example.com", "www.example.com"}, csr.DNSNames)
+		})
+	}
+}
+
+func Test_parseCSR_invalid(t *testing.T) {
+	_, err := parseCSR([]byte("foo"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the CSR is neither a PEM-encoded nor a DER-encoded CSR")
+}
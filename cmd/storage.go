@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-acme/lego/v3/storage"
+	"github.com/urfave/cli"
+)
+
+// defaultStorage the name of the default storage: the files are stored in the "path" folder.
+const defaultStorage = "filesystem"
+
+// newStorageBackend creates the backend storing the accounts and the certificates ("storage" option).
+func newStorageBackend(ctx *cli.Context) (storage.Backend, error) {
+	if isFileSystemStorage(ctx) {
+		return storage.NewFileSystem(ctx.GlobalString("path")), nil
+	}
+
+	name := ctx.GlobalString("storage")
+
+	factory, ok := storage.LookupBackend(name)
+	if !ok {
+		return nil, fmt.Errorf("unsupported storage: %s (supported: %s)", name, supportedStorages())
+	}
+
+	backend, err := factory()
+	if err != nil {
+		return nil, fmt.Errorf("could not create the storage %s: %v", name, err)
+	}
+
+	return backend, nil
+}
+
+// isFileSystemStorage returns true if the files are stored in the "path" folder.
+func isFileSystemStorage(ctx *cli.Context) bool {
+	name := ctx.GlobalString("storage")
+	return name == "" || name == defaultStorage
+}
+
+func supportedStorages() string {
+	names := append([]string{defaultStorage}, storage.RegisteredBackends()...)
+	return strings.Join(names, ", ")
+}
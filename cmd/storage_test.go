@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/go-acme/lego/v3/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+type testBackend struct {
+	*storage.FileSystem
+}
+
+func Test_newStorageBackend(t *testing.T) {
+	storage.RegisterBackend("cmd-test", func() (storage.Backend, error) {
+		return testBackend{FileSystem: storage.NewFileSystem("/tmp/lego-test")}, nil
+	})
+
+	testCases := []struct {
+		desc     string
+		args     []string
+		expected storage.Backend
+	}{
+		{
+			desc:     "default",
+			args:     []string{"--path=/tmp/lego"},
+			expected: storage.NewFileSystem("/tmp/lego"),
+		},
+		{
+			desc:     "filesystem",
+			args:     []string{"--path=/tmp/lego", "--storage=filesystem"},
+			expected: storage.NewFileSystem("/tmp/lego"),
+		},
+		{
+			desc:     "registered",
+			args:     []string{"--path=/tmp/lego", "--storage=cmd-test"},
+			expected: testBackend{FileSystem: storage.NewFileSystem("/tmp/lego-test")},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			set, err := newFlagSet("lego", CreateFlags(""), test.args)
+			require.NoError(t, err)
+
+			ctx := cli.NewContext(cli.NewApp(), set, nil)
+
+			backend, err := newStorageBackend(ctx)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, backend)
+		})
+	}
+}
@@ -16,14 +16,20 @@ func allDNSCodes() string {
 		"manual",
 		"acme-dns",
 		"alidns",
+		"arvancloud",
 		"auroradns",
 		"azure",
 		"bindman",
 		"bluecat",
+		"bunny",
+		"civo",
 		"cloudflare",
 		"cloudns",
 		"cloudxns",
+		"combell",
 		"conoha",
+		"constellix",
+		"desec",
 		"designate",
 		"digitalocean",
 		"dnsimple",
@@ -33,52 +39,553 @@ func allDNSCodes() string {
 		"dreamhost",
 		"duckdns",
 		"dyn",
+		"dynu",
 		"easydns",
+		"epik",
 		"exec",
 		"exoscale",
 		"fastdns",
+		"freemyip",
 		"gandi",
 		"gandiv5",
 		"gcloud",
 		"glesys",
 		"godaddy",
+		"googledomains",
+		"hetzner",
 		"hostingde",
+		"hosttech",
 		"httpreq",
+		"hurricane",
 		"iij",
+		"infoblox",
+		"infomaniak",
 		"inwx",
+		"ionos",
 		"joker",
 		"lightsail",
 		"linode",
 		"linodev4",
 		"liquidweb",
+		"loopia",
 		"mydnsjp",
+		"mythicbeasts",
 		"namecheap",
 		"namedotcom",
 		"namesilo",
 		"netcup",
 		"nifcloud",
+		"njalla",
 		"ns1",
 		"oraclecloud",
 		"otc",
 		"ovh",
 		"pdns",
+		"plesk",
+		"porkbun",
 		"rackspace",
+		"rcodezero",
 		"rfc2136",
 		"route53",
+		"safedns",
 		"sakuracloud",
+		"scaleway",
 		"selectel",
 		"stackpath",
+		"tencentcloud",
 		"transip",
+		"ultradns",
+		"variomedia",
 		"vegadns",
+		"vercel",
 		"versio",
 		"vscale",
 		"vultr",
+		"websupport",
+		"world4you",
+		"yandexcloud",
 		"zoneee",
 	}
 	sort.Strings(providers)
 	return strings.Join(providers, ", ")
 }
 
+// dnsCredentials returns the environment variables of the credentials of a DNS provider.
+func dnsCredentials(name string) []string {
+	switch name {
+	case "acme-dns":
+		return []string{
+			"ACME_DNS_API_BASE",
+			"ACME_DNS_STORAGE_PATH",
+		}
+	case "alidns":
+		return []string{
+			"ALICLOUD_ACCESS_KEY",
+			"ALICLOUD_SECRET_KEY",
+		}
+	case "arvancloud":
+		return []string{
+			"ARVANCLOUD_API_KEY",
+		}
+	case "auroradns":
+		return []string{
+			"AURORA_ENDPOINT",
+			"AURORA_KEY",
+			"AURORA_USER_ID",
+		}
+	case "azure":
+		return []string{
+			"AZURE_CERTIFICATE_PASSWORD",
+			"AZURE_CERTIFICATE_PATH",
+			"AZURE_CLIENT_ID",
+			"AZURE_CLIENT_SECRET",
+			"AZURE_RESOURCE_GROUP",
+			"AZURE_SUBSCRIPTION_ID",
+			"AZURE_TENANT_ID",
+		}
+	case "bindman":
+		return []string{
+			"BINDMAN_MANAGER_ADDRESS",
+		}
+	case "bluecat":
+		return []string{
+			"BLUECAT_CONFIG_NAME",
+			"BLUECAT_DNS_VIEW",
+			"BLUECAT_PASSWORD",
+			"BLUECAT_SERVER_URL",
+			"BLUECAT_USER_NAME",
+		}
+	case "bunny":
+		return []string{
+			"BUNNY_API_KEY",
+		}
+	case "civo":
+		return []string{
+			"CIVO_TOKEN",
+		}
+	case "cloudflare":
+		return []string{
+			"CF_API_EMAIL",
+			"CF_API_KEY",
+			"CF_DNS_API_TOKEN",
+			"CF_DNS_API_TOKENS",
+			"CF_ZONE_API_TOKEN",
+			"CLOUDFLARE_API_KEY",
+			"CLOUDFLARE_DNS_API_TOKEN",
+			"CLOUDFLARE_DNS_API_TOKENS",
+			"CLOUDFLARE_EMAIL",
+			"CLOUDFLARE_ZONE_API_TOKEN",
+		}
+	case "cloudns":
+		return []string{
+			"CLOUDNS_AUTH_ID",
+			"CLOUDNS_AUTH_PASSWORD",
+		}
+	case "cloudxns":
+		return []string{
+			"CLOUDXNS_API_KEY",
+			"CLOUDXNS_SECRET_KEY",
+		}
+	case "combell":
+		return []string{
+			"COMBELL_API_KEY",
+			"COMBELL_API_SECRET",
+		}
+	case "conoha":
+		return []string{
+			"CONOHA_API_PASSWORD",
+			"CONOHA_API_USERNAME",
+			"CONOHA_TENANT_ID",
+		}
+	case "constellix":
+		return []string{
+			"CONSTELLIX_API_KEY",
+			"CONSTELLIX_SECRET_KEY",
+		}
+	case "desec":
+		return []string{
+			"DESEC_TOKEN",
+		}
+	case "designate":
+		return []string{
+			"OS_AUTH_URL",
+			"OS_PASSWORD",
+			"OS_PROJECT_NAME",
+			"OS_REGION_NAME",
+			"OS_TENANT_NAME",
+			"OS_USERNAME",
+		}
+	case "digitalocean":
+		return []string{
+			"DO_AUTH_TOKEN",
+		}
+	case "dnsimple":
+		return []string{
+			"DNSIMPLE_BASE_URL",
+			"DNSIMPLE_OAUTH_TOKEN",
+		}
+	case "dnsmadeeasy":
+		return []string{
+			"DNSMADEEASY_API_KEY",
+			"DNSMADEEASY_API_SECRET",
+		}
+	case "dnspod":
+		return []string{
+			"DNSPOD_API_KEY",
+		}
+	case "dode":
+		return []string{
+			"DODE_TOKEN",
+		}
+	case "dreamhost":
+		return []string{
+			"DREAMHOST_API_KEY",
+		}
+	case "duckdns":
+		return []string{
+			"DUCKDNS_TOKEN",
+		}
+	case "dyn":
+		return []string{
+			"DYN_CUSTOMER_NAME",
+			"DYN_PASSWORD",
+			"DYN_USER_NAME",
+		}
+	case "dynu":
+		return []string{
+			"DYNU_API_KEY",
+		}
+	case "easydns":
+		return []string{
+			"EASYDNS_KEY",
+			"EASYDNS_TOKEN",
+		}
+	case "epik":
+		return []string{
+			"EPIK_SIGNATURE",
+		}
+	case "exoscale":
+		return []string{
+			"EXOSCALE_API_KEY",
+			"EXOSCALE_API_SECRET",
+		}
+	case "fastdns":
+		return []string{
+			"AKAMAI_ACCESS_TOKEN",
+			"AKAMAI_CLIENT_SECRET",
+			"AKAMAI_CLIENT_TOKEN",
+			"AKAMAI_HOST",
+		}
+	case "freemyip":
+		return []string{
+			"FREEMYIP_TOKEN",
+		}
+	case "gandi":
+		return []string{
+			"GANDI_API_KEY",
+		}
+	case "gandiv5":
+		return []string{
+			"GANDIV5_API_KEY",
+		}
+	case "gcloud":
+		return []string{
+			"GCE_PROJECT",
+			"GCE_SERVICE_ACCOUNT",
+			"GCE_SERVICE_ACCOUNT_FILE",
+		}
+	case "glesys":
+		return []string{
+			"GLESYS_API_KEY",
+			"GLESYS_API_USER",
+		}
+	case "godaddy":
+		return []string{
+			"GODADDY_API_KEY",
+			"GODADDY_API_SECRET",
+		}
+	case "googledomains":
+		return []string{
+			"GOOGLE_DOMAINS_ACCESS_TOKEN",
+		}
+	case "hetzner":
+		return []string{
+			"HETZNER_API_KEY",
+		}
+	case "hostingde":
+		return []string{
+			"HOSTINGDE_API_KEY",
+			"HOSTINGDE_ZONE_NAME",
+		}
+	case "hosttech":
+		return []string{
+			"HOSTTECH_API_KEY",
+		}
+	case "httpreq":
+		return []string{
+			"HTTPREQ_ENDPOINT",
+			"HTTPREQ_MODE",
+		}
+	case "hurricane":
+		return []string{
+			"HURRICANE_TOKENS",
+		}
+	case "iij":
+		return []string{
+			"IIJ_API_ACCESS_KEY",
+			"IIJ_API_SECRET_KEY",
+			"IIJ_DO_SERVICE_CODE",
+		}
+	case "infoblox":
+		return []string{
+			"INFOBLOX_HOST",
+			"INFOBLOX_PASSWORD",
+			"INFOBLOX_USERNAME",
+		}
+	case "infomaniak":
+		return []string{
+			"INFOMANIAK_ACCESS_TOKEN",
+		}
+	case "inwx":
+		return []string{
+			"INWX_PASSWORD",
+			"INWX_USERNAME",
+		}
+	case "ionos":
+		return []string{
+			"IONOS_API_KEY",
+		}
+	case "joker":
+		return []string{
+			"JOKER_API_KEY",
+			"JOKER_PASSWORD",
+			"JOKER_USERNAME",
+		}
+	case "lightsail":
+		return []string{
+			"AWS_ACCESS_KEY_ID",
+			"AWS_PROFILE",
+			"AWS_SECRET_ACCESS_KEY",
+		}
+	case "linode":
+		return []string{
+			"LINODE_API_KEY",
+		}
+	case "linodev4":
+		return []string{
+			"LINODE_TOKEN",
+		}
+	case "liquidweb":
+		return []string{
+			"LIQUID_WEB_PASSWORD",
+			"LIQUID_WEB_USERNAME",
+		}
+	case "loopia":
+		return []string{
+			"LOOPIA_API_PASSWORD",
+			"LOOPIA_API_USER",
+		}
+	case "mydnsjp":
+		return []string{
+			"MYDNSJP_MASTER_ID",
+			"MYDNSJP_PASSWORD",
+		}
+	case "mythicbeasts":
+		return []string{
+			"MYTHICBEASTS_PASSWORD",
+			"MYTHICBEASTS_USERNAME",
+		}
+	case "namecheap":
+		return []string{
+			"NAMECHEAP_API_KEY",
+			"NAMECHEAP_API_USER",
+		}
+	case "namedotcom":
+		return []string{
+			"NAMECOM_API_TOKEN",
+			"NAMECOM_USERNAME",
+		}
+	case "namesilo":
+		return []string{
+			"NAMESILO_API_KEY",
+		}
+	case "netcup":
+		return []string{
+			"NETCUP_API_KEY",
+			"NETCUP_API_PASSWORD",
+			"NETCUP_CUSTOMER_NUMBER",
+		}
+	case "nifcloud":
+		return []string{
+			"NIFCLOUD_ACCESS_KEY_ID",
+			"NIFCLOUD_SECRET_ACCESS_KEY",
+		}
+	case "njalla":
+		return []string{
+			"NJALLA_TOKEN",
+		}
+	case "ns1":
+		return []string{
+			"NS1_API_KEY",
+		}
+	case "oraclecloud":
+		return []string{
+			"OCI_COMPARTMENT_OCID",
+			"OCI_PRIVKEY_FILE",
+			"OCI_PRIVKEY_PASS",
+			"OCI_PUBKEY_FINGERPRINT",
+			"OCI_REGION",
+			"OCI_TENANCY_OCID",
+			"OCI_USER_OCID",
+		}
+	case "otc":
+		return []string{
+			"OTC_DOMAIN_NAME",
+			"OTC_IDENTITY_ENDPOINT",
+			"OTC_PASSWORD",
+			"OTC_PROJECT_NAME",
+			"OTC_USER_NAME",
+		}
+	case "ovh":
+		return []string{
+			"OVH_APPLICATION_KEY",
+			"OVH_APPLICATION_SECRET",
+			"OVH_CONSUMER_KEY",
+			"OVH_ENDPOINT",
+		}
+	case "pdns":
+		return []string{
+			"PDNS_API_KEY",
+			"PDNS_API_URL",
+		}
+	case "plesk":
+		return []string{
+			"PLESK_PASSWORD",
+			"PLESK_SERVER_BASE_URL",
+			"PLESK_USERNAME",
+		}
+	case "porkbun":
+		return []string{
+			"PORKBUN_API_KEY",
+			"PORKBUN_SECRET_API_KEY",
+		}
+	case "rackspace":
+		return []string{
+			"RACKSPACE_API_KEY",
+			"RACKSPACE_USER",
+		}
+	case "rcodezero":
+		return []string{
+			"RCODEZERO_API_TOKEN",
+		}
+	case "rfc2136":
+		return []string{
+			"RFC2136_NAMESERVER",
+			"RFC2136_TSIG_ALGORITHM",
+			"RFC2136_TSIG_KEY",
+			"RFC2136_TSIG_SECRET",
+		}
+	case "route53":
+		return []string{
+			"AWS_ACCESS_KEY_ID",
+			"AWS_ASSUME_ROLE_ARN",
+			"AWS_EXTERNAL_ID",
+			"AWS_HOSTED_ZONE_ID",
+			"AWS_PROFILE",
+			"AWS_REGION",
+			"AWS_SECRET_ACCESS_KEY",
+		}
+	case "safedns":
+		return []string{
+			"SAFEDNS_AUTH_TOKEN",
+		}
+	case "sakuracloud":
+		return []string{
+			"SAKURACLOUD_ACCESS_TOKEN",
+			"SAKURACLOUD_ACCESS_TOKEN_SECRET",
+		}
+	case "scaleway":
+		return []string{
+			"SCALEWAY_API_TOKEN",
+		}
+	case "selectel":
+		return []string{
+			"SELECTEL_API_TOKEN",
+		}
+	case "stackpath":
+		return []string{
+			"STACKPATH_CLIENT_ID",
+			"STACKPATH_CLIENT_SECRET",
+			"STACKPATH_STACK_ID",
+		}
+	case "tencentcloud":
+		return []string{
+			"TENCENTCLOUD_SECRET_ID",
+			"TENCENTCLOUD_SECRET_KEY",
+		}
+	case "transip":
+		return []string{
+			"TRANSIP_ACCOUNT_NAME",
+			"TRANSIP_PRIVATE_KEY_PATH",
+		}
+	case "ultradns":
+		return []string{
+			"ULTRADNS_PASSWORD",
+			"ULTRADNS_USERNAME",
+		}
+	case "variomedia":
+		return []string{
+			"VARIOMEDIA_API_TOKEN",
+		}
+	case "vegadns":
+		return []string{
+			"SECRET_VEGADNS_KEY",
+			"SECRET_VEGADNS_SECRET",
+			"VEGADNS_URL",
+		}
+	case "vercel":
+		return []string{
+			"VERCEL_API_TOKEN",
+		}
+	case "versio":
+		return []string{
+			"VERSIO_PASSWORD",
+			"VERSIO_USERNAME",
+		}
+	case "vscale":
+		return []string{
+			"VSCALE_API_TOKEN",
+		}
+	case "vultr":
+		return []string{
+			"VULTR_API_KEY",
+		}
+	case "websupport":
+		return []string{
+			"WEBSUPPORT_API_KEY",
+			"WEBSUPPORT_SECRET",
+		}
+	case "world4you":
+		return []string{
+			"WORLD4YOU_PASSWORD",
+			"WORLD4YOU_USERNAME",
+		}
+	case "yandexcloud":
+		return []string{
+			"YANDEX_CLOUD_FOLDER_ID",
+			"YANDEX_CLOUD_IAM_TOKEN",
+			"YANDEX_CLOUD_SERVICE_ACCOUNT_KEY",
+		}
+	case "zoneee":
+		return []string{
+			"ZONEEE_API_KEY",
+			"ZONEEE_API_USER",
+		}
+	default:
+		return nil
+	}
+}
+
 func displayDNSHelp(name string) error {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 	ew := &errWriter{w: w}
@@ -97,6 +604,9 @@ func displayDNSHelp(name string) error {
 		ew.writeln(`	- "ACME_DNS_STORAGE_PATH":	The ACME-DNS JSON account data file. A per-domain account will be registered/persisted to this file and used for TXT updates.`)
 		ew.writeln()
 
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "ACME_DNS_ALLOW_FROM":	Comma separated list of CIDR ranges allowed to update the TXT records of the registered accounts`)
+
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/acme-dns`)
 
@@ -121,6 +631,27 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/alidns`)
 
+	case "arvancloud":
+		// generated from: providers/dns/arvancloud/arvancloud.toml
+		ew.writeln(`Configuration for ArvanCloud.`)
+		ew.writeln(`Code:	'arvancloud'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "ARVANCLOUD_API_KEY":	API key (i.e. 'Apikey xxxx')`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "ARVANCLOUD_ENDPOINT":	The endpoint URL of the API Server`)
+		ew.writeln(`	- "ARVANCLOUD_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "ARVANCLOUD_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "ARVANCLOUD_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "ARVANCLOUD_TTL":	The TTL of the TXT record used for the DNS challenge (minimum: 120)`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/arvancloud`)
+
 	case "auroradns":
 		// generated from: providers/dns/auroradns/auroradns.toml
 		ew.writeln(`Configuration for Aurora DNS.`)
@@ -150,6 +681,8 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 
 		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "AZURE_CERTIFICATE_PASSWORD":	Password of the client certificate`)
+		ew.writeln(`	- "AZURE_CERTIFICATE_PATH":	Path of the client certificate (PKCS#12)`)
 		ew.writeln(`	- "AZURE_CLIENT_ID":	Client ID`)
 		ew.writeln(`	- "AZURE_CLIENT_SECRET":	Client secret`)
 		ew.writeln(`	- "AZURE_RESOURCE_GROUP":	Resource group`)
@@ -159,8 +692,10 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 
 		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "AZURE_AUTH_METHOD":	Authentication method: 'env', 'cert' or 'msi' (by default, chosen according to the credentials)`)
 		ew.writeln(`	- "AZURE_METADATA_ENDPOINT":	Metadata Service endpoint URL`)
 		ew.writeln(`	- "AZURE_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "AZURE_PRIVATE_ZONE":	Set to true to use Azure Private DNS Zones and not public`)
 		ew.writeln(`	- "AZURE_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
 		ew.writeln(`	- "AZURE_TTL":	The TTL of the TXT record used for the DNS challenge`)
 
@@ -210,6 +745,48 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/bluecat`)
 
+	case "bunny":
+		// generated from: providers/dns/bunny/bunny.toml
+		ew.writeln(`Configuration for Bunny.`)
+		ew.writeln(`Code:	'bunny'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "BUNNY_API_KEY":	API key`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "BUNNY_ENDPOINT":	The endpoint URL of the API Server`)
+		ew.writeln(`	- "BUNNY_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "BUNNY_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "BUNNY_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "BUNNY_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/bunny`)
+
+	case "civo":
+		// generated from: providers/dns/civo/civo.toml
+		ew.writeln(`Configuration for Civo.`)
+		ew.writeln(`Code:	'civo'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "CIVO_TOKEN":	Authentication token`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "CIVO_ENDPOINT":	The endpoint URL of the API Server`)
+		ew.writeln(`	- "CIVO_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "CIVO_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "CIVO_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "CIVO_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/civo`)
+
 	case "cloudflare":
 		// generated from: providers/dns/cloudflare/cloudflare.toml
 		ew.writeln(`Configuration for Cloudflare.`)
@@ -220,8 +797,14 @@ func displayDNSHelp(name string) error {
 		ew.writeln(`Credentials:`)
 		ew.writeln(`	- "CF_API_EMAIL":	Account email`)
 		ew.writeln(`	- "CF_API_KEY":	API key`)
-		ew.writeln(`	- "CLOUDFLARE_API_KEY":	Alias to CLOUDFLARE_API_KEY`)
+		ew.writeln(`	- "CF_DNS_API_TOKEN":	API token with DNS:Edit permission (since v3.1.0)`)
+		ew.writeln(`	- "CF_DNS_API_TOKENS":	API tokens scoped to a single zone: 'zone:token[,zone:token]'`)
+		ew.writeln(`	- "CF_ZONE_API_TOKEN":	API token with Zone:Read permission (since v3.1.0)`)
+		ew.writeln(`	- "CLOUDFLARE_API_KEY":	Alias to CF_API_KEY`)
+		ew.writeln(`	- "CLOUDFLARE_DNS_API_TOKEN":	Alias to CF_DNS_API_TOKEN`)
+		ew.writeln(`	- "CLOUDFLARE_DNS_API_TOKENS":	Alias to CF_DNS_API_TOKENS`)
 		ew.writeln(`	- "CLOUDFLARE_EMAIL":	Alias to CF_API_EMAIL`)
+		ew.writeln(`	- "CLOUDFLARE_ZONE_API_TOKEN":	Alias to CF_ZONE_API_TOKEN`)
 		ew.writeln()
 
 		ew.writeln(`Additional Configuration:`)
@@ -275,6 +858,28 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/cloudxns`)
 
+	case "combell":
+		// generated from: providers/dns/combell/combell.toml
+		ew.writeln(`Configuration for Combell.`)
+		ew.writeln(`Code:	'combell'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "COMBELL_API_KEY":	API key`)
+		ew.writeln(`	- "COMBELL_API_SECRET":	API secret`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "COMBELL_ENDPOINT":	The endpoint URL of the API Server`)
+		ew.writeln(`	- "COMBELL_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "COMBELL_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "COMBELL_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "COMBELL_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/combell`)
+
 	case "conoha":
 		// generated from: providers/dns/conoha/conoha.toml
 		ew.writeln(`Configuration for ConoHa.`)
@@ -298,6 +903,50 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/conoha`)
 
+	case "constellix":
+		// generated from: providers/dns/constellix/constellix.toml
+		ew.writeln(`Configuration for Constellix.`)
+		ew.writeln(`Code:	'constellix'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "CONSTELLIX_API_KEY":	User API key`)
+		ew.writeln(`	- "CONSTELLIX_SECRET_KEY":	User secret key`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "CONSTELLIX_ENDPOINT":	The endpoint URL of the API Server`)
+		ew.writeln(`	- "CONSTELLIX_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "CONSTELLIX_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "CONSTELLIX_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "CONSTELLIX_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/constellix`)
+
+	case "desec":
+		// generated from: providers/dns/desec/desec.toml
+		ew.writeln(`Configuration for deSEC.io.`)
+		ew.writeln(`Code:	'desec'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "DESEC_TOKEN":	Domain token`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "DESEC_ENDPOINT":	The endpoint URL of the API Server`)
+		ew.writeln(`	- "DESEC_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "DESEC_MAX_RETRIES":	The maximum number of retries of a throttled request`)
+		ew.writeln(`	- "DESEC_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "DESEC_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "DESEC_TTL":	The TTL of the TXT record used for the DNS challenge (minimum: 3600)`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/desec`)
+
 	case "designate":
 		// generated from: providers/dns/designate/designate.toml
 		ew.writeln(`Configuration for Designate DNSaaS for Openstack.`)
@@ -338,7 +987,7 @@ func displayDNSHelp(name string) error {
 		ew.writeln(`	- "DO_HTTP_TIMEOUT":	API request timeout`)
 		ew.writeln(`	- "DO_POLLING_INTERVAL":	Time between DNS propagation check`)
 		ew.writeln(`	- "DO_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
-		ew.writeln(`	- "DO_TTL":	The TTL of the TXT record used for the DNS challenge`)
+		ew.writeln(`	- "DO_TTL":	The TTL of the TXT record used for the DNS challenge (minimum: 30)`)
 
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/digitalocean`)
@@ -356,8 +1005,10 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 
 		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "DNSIMPLE_ACCOUNT_ID":	ID of the account managing the zones (by default, the account of the token)`)
 		ew.writeln(`	- "DNSIMPLE_POLLING_INTERVAL":	Time between DNS propagation check`)
 		ew.writeln(`	- "DNSIMPLE_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "DNSIMPLE_SANDBOX":	Set to true to use the sandbox environment`)
 		ew.writeln(`	- "DNSIMPLE_TTL":	The TTL of the TXT record used for the DNS challenge`)
 
 		ew.writeln()
@@ -376,6 +1027,7 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 
 		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "DNSMADEEASY_ENDPOINT":	API endpoint URL (ignored when the sandbox is activated)`)
 		ew.writeln(`	- "DNSMADEEASY_HTTP_TIMEOUT":	API request timeout`)
 		ew.writeln(`	- "DNSMADEEASY_POLLING_INTERVAL":	Time between DNS propagation check`)
 		ew.writeln(`	- "DNSMADEEASY_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
@@ -462,7 +1114,6 @@ func displayDNSHelp(name string) error {
 		ew.writeln(`	- "DUCKDNS_POLLING_INTERVAL":	Time between DNS propagation check`)
 		ew.writeln(`	- "DUCKDNS_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
 		ew.writeln(`	- "DUCKDNS_SEQUENCE_INTERVAL":	Interval between iteration`)
-		ew.writeln(`	- "DUCKDNS_TTL":	The TTL of the TXT record used for the DNS challenge`)
 
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/duckdns`)
@@ -489,6 +1140,27 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/dyn`)
 
+	case "dynu":
+		// generated from: providers/dns/dynu/dynu.toml
+		ew.writeln(`Configuration for Dynu.`)
+		ew.writeln(`Code:	'dynu'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "DYNU_API_KEY":	API key`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "DYNU_ENDPOINT":	The endpoint URL of the API Server`)
+		ew.writeln(`	- "DYNU_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "DYNU_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "DYNU_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "DYNU_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/dynu`)
+
 	case "easydns":
 		// generated from: providers/dns/easydns/easydns.toml
 		ew.writeln(`Configuration for EasyDNS.`)
@@ -512,6 +1184,27 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/easydns`)
 
+	case "epik":
+		// generated from: providers/dns/epik/epik.toml
+		ew.writeln(`Configuration for Epik.`)
+		ew.writeln(`Code:	'epik'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "EPIK_SIGNATURE":	Epik API signature`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "EPIK_ENDPOINT":	The endpoint URL of the API Server`)
+		ew.writeln(`	- "EPIK_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "EPIK_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "EPIK_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "EPIK_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/epik`)
+
 	case "exec":
 		// generated from: providers/dns/exec/exec.toml
 		ew.writeln(`Configuration for External program.`)
@@ -530,12 +1223,13 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 
 		ew.writeln(`Credentials:`)
-		ew.writeln(`	- "EXOSCALE_API_KEY":	API key`)
-		ew.writeln(`	- "EXOSCALE_API_SECRET":	API secret`)
-		ew.writeln(`	- "EXOSCALE_ENDPOINT":	API endpoint URL`)
+		ew.writeln(`	- "EXOSCALE_API_KEY":	IAM access key`)
+		ew.writeln(`	- "EXOSCALE_API_SECRET":	IAM access key secret`)
 		ew.writeln()
 
 		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "EXOSCALE_API_ZONE":	Zone of the API endpoint (Default: ch-gva-2)`)
+		ew.writeln(`	- "EXOSCALE_ENDPOINT":	API endpoint URL (Default: https://api-<zone>.exoscale.com/v2)`)
 		ew.writeln(`	- "EXOSCALE_HTTP_TIMEOUT":	API request timeout`)
 		ew.writeln(`	- "EXOSCALE_POLLING_INTERVAL":	Time between DNS propagation check`)
 		ew.writeln(`	- "EXOSCALE_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
@@ -566,6 +1260,26 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/fastdns`)
 
+	case "freemyip":
+		// generated from: providers/dns/freemyip/freemyip.toml
+		ew.writeln(`Configuration for freemyip.com.`)
+		ew.writeln(`Code:	'freemyip'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "FREEMYIP_TOKEN":	Account token`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "FREEMYIP_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "FREEMYIP_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "FREEMYIP_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "FREEMYIP_SEQUENCE_INTERVAL":	Interval between iteration`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/freemyip`)
+
 	case "gandi":
 		// generated from: providers/dns/gandi/gandi.toml
 		ew.writeln(`Configuration for Gandi.`)
@@ -598,9 +1312,11 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 
 		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "GANDIV5_API_KEYS":	API keys by domain: 'domain:key[,domain:key]'`)
 		ew.writeln(`	- "GANDIV5_HTTP_TIMEOUT":	API request timeout`)
 		ew.writeln(`	- "GANDIV5_POLLING_INTERVAL":	Time between DNS propagation check`)
 		ew.writeln(`	- "GANDIV5_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "GANDIV5_SHARING_ID":	ID of the organization owning the domains`)
 		ew.writeln(`	- "GANDIV5_TTL":	The TTL of the TXT record used for the DNS challenge`)
 
 		ew.writeln()
@@ -615,7 +1331,7 @@ func displayDNSHelp(name string) error {
 
 		ew.writeln(`Credentials:`)
 		ew.writeln(`	- "Application Default Credentials":	[Documentation](https://cloud.google.com/docs/authentication/production#providing_credentials_to_your_application)`)
-		ew.writeln(`	- "GCE_PROJECT":	Project name`)
+		ew.writeln(`	- "GCE_PROJECT":	Project name (by default, the project of the credentials or of the metadata server)`)
 		ew.writeln(`	- "GCE_SERVICE_ACCOUNT":	Account`)
 		ew.writeln(`	- "GCE_SERVICE_ACCOUNT_FILE":	Account file path`)
 		ew.writeln()
@@ -665,12 +1381,52 @@ func displayDNSHelp(name string) error {
 		ew.writeln(`	- "GODADDY_HTTP_TIMEOUT":	API request timeout`)
 		ew.writeln(`	- "GODADDY_POLLING_INTERVAL":	Time between DNS propagation check`)
 		ew.writeln(`	- "GODADDY_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
-		ew.writeln(`	- "GODADDY_SEQUENCE_INTERVAL":	Interval between iteration`)
 		ew.writeln(`	- "GODADDY_TTL":	The TTL of the TXT record used for the DNS challenge`)
 
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/godaddy`)
 
+	case "googledomains":
+		// generated from: providers/dns/googledomains/googledomains.toml
+		ew.writeln(`Configuration for Google Domains.`)
+		ew.writeln(`Code:	'googledomains'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "GOOGLE_DOMAINS_ACCESS_TOKEN":	Access token of the ACME DNS API`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "GOOGLE_DOMAINS_ENDPOINT":	The endpoint URL of the API Server`)
+		ew.writeln(`	- "GOOGLE_DOMAINS_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "GOOGLE_DOMAINS_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "GOOGLE_DOMAINS_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/googledomains`)
+
+	case "hetzner":
+		// generated from: providers/dns/hetzner/hetzner.toml
+		ew.writeln(`Configuration for Hetzner.`)
+		ew.writeln(`Code:	'hetzner'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "HETZNER_API_KEY":	API key`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "HETZNER_ENDPOINT":	The endpoint URL of the API Server`)
+		ew.writeln(`	- "HETZNER_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "HETZNER_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "HETZNER_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "HETZNER_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/hetzner`)
+
 	case "hostingde":
 		// generated from: providers/dns/hostingde/hostingde.toml
 		ew.writeln(`Configuration for Hosting.de.`)
@@ -692,6 +1448,27 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/hostingde`)
 
+	case "hosttech":
+		// generated from: providers/dns/hosttech/hosttech.toml
+		ew.writeln(`Configuration for Hosttech.`)
+		ew.writeln(`Code:	'hosttech'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "HOSTTECH_API_KEY":	API token`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "HOSTTECH_ENDPOINT":	The endpoint URL of the API Server`)
+		ew.writeln(`	- "HOSTTECH_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "HOSTTECH_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "HOSTTECH_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "HOSTTECH_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/hosttech`)
+
 	case "httpreq":
 		// generated from: providers/dns/httpreq/httpreq.toml
 		ew.writeln(`Configuration for HTTP request.`)
@@ -705,15 +1482,48 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 
 		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "HTTPREQ_HEADERS":	Static headers added to the requests: 'Name:value[,Name:value]'`)
+		ew.writeln(`	- "HTTPREQ_HMAC_KEY":	HMAC secret key used to sign the requests`)
+		ew.writeln(`	- "HTTPREQ_HMAC_KEY_ID":	HMAC key ID`)
+		ew.writeln(`	- "HTTPREQ_HMAC_KEY_ID_HEADER":	Name of the key ID header (Default: X-Key-Id)`)
+		ew.writeln(`	- "HTTPREQ_HMAC_SIGNATURE_HEADER":	Name of the signature header (Default: X-Signature)`)
+		ew.writeln(`	- "HTTPREQ_HMAC_TIMESTAMP_HEADER":	Name of the timestamp header (Default: X-Timestamp)`)
 		ew.writeln(`	- "HTTPREQ_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "HTTPREQ_OAUTH2_CLIENT_ID":	OAuth2 client ID`)
+		ew.writeln(`	- "HTTPREQ_OAUTH2_CLIENT_SECRET":	OAuth2 client secret`)
+		ew.writeln(`	- "HTTPREQ_OAUTH2_SCOPES":	OAuth2 scopes (comma separated)`)
+		ew.writeln(`	- "HTTPREQ_OAUTH2_TOKEN_URL":	OAuth2 token URL (client credentials flow)`)
 		ew.writeln(`	- "HTTPREQ_PASSWORD":	Basic authentication password`)
 		ew.writeln(`	- "HTTPREQ_POLLING_INTERVAL":	Time between DNS propagation check`)
 		ew.writeln(`	- "HTTPREQ_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "HTTPREQ_RESPONSE_ERROR_FIELD":	Path of the field of the response containing the error message`)
+		ew.writeln(`	- "HTTPREQ_RESPONSE_SUCCESS_FIELD":	Path of the field of the response indicating the success`)
+		ew.writeln(`	- "HTTPREQ_RESPONSE_SUCCESS_VALUE":	Value of the success field when the request succeeded (Default: true)`)
 		ew.writeln(`	- "HTTPREQ_USERNAME":	Basic authentication username`)
 
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/httpreq`)
 
+	case "hurricane":
+		// generated from: providers/dns/hurricane/hurricane.toml
+		ew.writeln(`Configuration for Hurricane Electric DNS.`)
+		ew.writeln(`Code:	'hurricane'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "HURRICANE_TOKENS":	TXT record names and tokens ('domain:token[,domain:token]')`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "HURRICANE_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "HURRICANE_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "HURRICANE_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "HURRICANE_SEQUENCE_INTERVAL":	Time between sequential requests`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/hurricane`)
+
 	case "iij":
 		// generated from: providers/dns/iij/iij.toml
 		ew.writeln(`Configuration for Internet Initiative Japan.`)
@@ -735,6 +1545,52 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/iij`)
 
+	case "infoblox":
+		// generated from: providers/dns/infoblox/infoblox.toml
+		ew.writeln(`Configuration for Infoblox.`)
+		ew.writeln(`Code:	'infoblox'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "INFOBLOX_HOST":	Host URI (i.e. 'infoblox.example.org' or 'https://infoblox.example.org:8443')`)
+		ew.writeln(`	- "INFOBLOX_PASSWORD":	Account Password`)
+		ew.writeln(`	- "INFOBLOX_USERNAME":	Account Username`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "INFOBLOX_DNS_VIEW":	The view for the TXT records, default: 'default'`)
+		ew.writeln(`	- "INFOBLOX_HTTP_TIMEOUT":	HTTP request timeout`)
+		ew.writeln(`	- "INFOBLOX_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "INFOBLOX_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "INFOBLOX_SSL_VERIFY":	Whether or not to verify the TLS certificate, default: true`)
+		ew.writeln(`	- "INFOBLOX_TTL":	The TTL of the TXT record used for the DNS challenge`)
+		ew.writeln(`	- "INFOBLOX_WAPI_VERSION":	The version of WAPI being used, default: '2.11'`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/infoblox`)
+
+	case "infomaniak":
+		// generated from: providers/dns/infomaniak/infomaniak.toml
+		ew.writeln(`Configuration for Infomaniak.`)
+		ew.writeln(`Code:	'infomaniak'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "INFOMANIAK_ACCESS_TOKEN":	Access token`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "INFOMANIAK_ENDPOINT":	The endpoint URL of the API Server`)
+		ew.writeln(`	- "INFOMANIAK_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "INFOMANIAK_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "INFOMANIAK_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "INFOMANIAK_TTL":	The TTL of the TXT record used for the DNS challenge in seconds (minimum: 300)`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/infomaniak`)
+
 	case "inwx":
 		// generated from: providers/dns/inwx/inwx.toml
 		ew.writeln(`Configuration for INWX.`)
@@ -756,6 +1612,27 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/inwx`)
 
+	case "ionos":
+		// generated from: providers/dns/ionos/ionos.toml
+		ew.writeln(`Configuration for IONOS.`)
+		ew.writeln(`Code:	'ionos'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "IONOS_API_KEY":	API key '<prefix>.<secret>' https://developer.hosting.ionos.com/docs/getstarted`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "IONOS_ENDPOINT":	The endpoint URL of the API Server`)
+		ew.writeln(`	- "IONOS_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "IONOS_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "IONOS_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "IONOS_TTL":	The TTL of the TXT record used for the DNS challenge (minimum: 300)`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/ionos`)
+
 	case "joker":
 		// generated from: providers/dns/joker/joker.toml
 		ew.writeln(`Configuration for Joker.`)
@@ -787,13 +1664,15 @@ func displayDNSHelp(name string) error {
 
 		ew.writeln(`Credentials:`)
 		ew.writeln(`	- "AWS_ACCESS_KEY_ID":	Access key ID`)
+		ew.writeln(`	- "AWS_PROFILE":	Managed by the AWS client (the profile of the shared configuration)`)
 		ew.writeln(`	- "AWS_SECRET_ACCESS_KEY":	Secret access key`)
-		ew.writeln(`	- "DNS_ZONE":	DNS zone`)
 		ew.writeln()
 
 		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "DNS_ZONE":	DNS zone (by default, the DNS zone of the account matching the domain)`)
 		ew.writeln(`	- "LIGHTSAIL_POLLING_INTERVAL":	Time between DNS propagation check`)
 		ew.writeln(`	- "LIGHTSAIL_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "LIGHTSAIL_REGION":	Region of the Lightsail API (default: us-east-1)`)
 
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/lightsail`)
@@ -846,7 +1725,6 @@ func displayDNSHelp(name string) error {
 		ew.writeln(`Credentials:`)
 		ew.writeln(`	- "LIQUID_WEB_PASSWORD":	Storm API Password`)
 		ew.writeln(`	- "LIQUID_WEB_USERNAME":	Storm API Username`)
-		ew.writeln(`	- "LIQUID_WEB_ZONE":	DNS Zone`)
 		ew.writeln()
 
 		ew.writeln(`Additional Configuration:`)
@@ -855,10 +1733,33 @@ func displayDNSHelp(name string) error {
 		ew.writeln(`	- "LIQUID_WEB_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
 		ew.writeln(`	- "LIQUID_WEB_TTL":	The TTL of the TXT record used for the DNS challenge`)
 		ew.writeln(`	- "LIQUID_WEB_URL":	Storm API endpoint`)
+		ew.writeln(`	- "LIQUID_WEB_ZONE":	DNS Zone used when the zone can't be found in the zones of the account`)
 
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/liquidweb`)
 
+	case "loopia":
+		// generated from: providers/dns/loopia/loopia.toml
+		ew.writeln(`Configuration for Loopia.`)
+		ew.writeln(`Code:	'loopia'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "LOOPIA_API_PASSWORD":	API password`)
+		ew.writeln(`	- "LOOPIA_API_USER":	API username`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "LOOPIA_API_URL":	API endpoint. Ex: https://api.loopia.se/RPCSERV or https://api.loopia.rs/RPCSERV`)
+		ew.writeln(`	- "LOOPIA_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "LOOPIA_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "LOOPIA_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "LOOPIA_TTL":	The TTL of the TXT record used for the DNS challenge (minimum: 300)`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/loopia`)
+
 	case "mydnsjp":
 		// generated from: providers/dns/mydnsjp/mydnsjp.toml
 		ew.writeln(`Configuration for MyDNS.jp.`)
@@ -880,6 +1781,29 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/mydnsjp`)
 
+	case "mythicbeasts":
+		// generated from: providers/dns/mythicbeasts/mythicbeasts.toml
+		ew.writeln(`Configuration for MythicBeasts.`)
+		ew.writeln(`Code:	'mythicbeasts'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "MYTHICBEASTS_PASSWORD":	API key secret`)
+		ew.writeln(`	- "MYTHICBEASTS_USERNAME":	API key ID`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "MYTHICBEASTS_API_ENDPOINT":	The endpoint for the API (must implement v2)`)
+		ew.writeln(`	- "MYTHICBEASTS_AUTH_API_ENDPOINT":	The endpoint for the token exchange`)
+		ew.writeln(`	- "MYTHICBEASTS_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "MYTHICBEASTS_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "MYTHICBEASTS_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "MYTHICBEASTS_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/mythicbeasts`)
+
 	case "namecheap":
 		// generated from: providers/dns/namecheap/namecheap.toml
 		ew.writeln(`Configuration for Namecheap.`)
@@ -893,9 +1817,11 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 
 		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "NAMECHEAP_DEBUG":	Log the host records and the client IP (true or false)`)
 		ew.writeln(`	- "NAMECHEAP_HTTP_TIMEOUT":	API request timeout`)
 		ew.writeln(`	- "NAMECHEAP_POLLING_INTERVAL":	Time between DNS propagation check`)
 		ew.writeln(`	- "NAMECHEAP_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "NAMECHEAP_SANDBOX":	Use the sandbox API (true or false)`)
 		ew.writeln(`	- "NAMECHEAP_TTL":	The TTL of the TXT record used for the DNS challenge`)
 
 		ew.writeln()
@@ -984,6 +1910,27 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/nifcloud`)
 
+	case "njalla":
+		// generated from: providers/dns/njalla/njalla.toml
+		ew.writeln(`Configuration for Njalla.`)
+		ew.writeln(`Code:	'njalla'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "NJALLA_TOKEN":	API token`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "NJALLA_ENDPOINT":	The endpoint URL of the API Server`)
+		ew.writeln(`	- "NJALLA_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "NJALLA_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "NJALLA_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "NJALLA_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/njalla`)
+
 	case "ns1":
 		// generated from: providers/dns/ns1/ns1.toml
 		ew.writeln(`Configuration for NS1.`)
@@ -1064,7 +2011,7 @@ func displayDNSHelp(name string) error {
 		ew.writeln(`	- "OVH_APPLICATION_KEY":	Application key`)
 		ew.writeln(`	- "OVH_APPLICATION_SECRET":	Application secret`)
 		ew.writeln(`	- "OVH_CONSUMER_KEY":	Consumer key`)
-		ew.writeln(`	- "OVH_ENDPOINT":	Endpoint URL (ovh-eu or ovh-ca)`)
+		ew.writeln(`	- "OVH_ENDPOINT":	Endpoint name (ovh-eu, ovh-ca, ovh-us, kimsufi-eu, kimsufi-ca, soyoustart-eu, soyoustart-ca) or URL`)
 		ew.writeln()
 
 		ew.writeln(`Additional Configuration:`)
@@ -1097,6 +2044,49 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/pdns`)
 
+	case "plesk":
+		// generated from: providers/dns/plesk/plesk.toml
+		ew.writeln(`Configuration for Plesk.`)
+		ew.writeln(`Code:	'plesk'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "PLESK_PASSWORD":	API password`)
+		ew.writeln(`	- "PLESK_SERVER_BASE_URL":	Base URL of the server (ex: https://plesk.myserver.com:8443)`)
+		ew.writeln(`	- "PLESK_USERNAME":	API username`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "PLESK_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "PLESK_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "PLESK_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/plesk`)
+
+	case "porkbun":
+		// generated from: providers/dns/porkbun/porkbun.toml
+		ew.writeln(`Configuration for Porkbun.`)
+		ew.writeln(`Code:	'porkbun'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "PORKBUN_API_KEY":	API key`)
+		ew.writeln(`	- "PORKBUN_SECRET_API_KEY":	secret API key`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "PORKBUN_ENDPOINT":	The endpoint URL of the API Server`)
+		ew.writeln(`	- "PORKBUN_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "PORKBUN_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "PORKBUN_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "PORKBUN_TTL":	The TTL of the TXT record used for the DNS challenge (minimum: 600)`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/porkbun`)
+
 	case "rackspace":
 		// generated from: providers/dns/rackspace/rackspace.toml
 		ew.writeln(`Configuration for Rackspace.`)
@@ -1118,6 +2108,27 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/rackspace`)
 
+	case "rcodezero":
+		// generated from: providers/dns/rcodezero/rcodezero.toml
+		ew.writeln(`Configuration for RcodeZero.`)
+		ew.writeln(`Code:	'rcodezero'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "RCODEZERO_API_TOKEN":	API token`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "RCODEZERO_ENDPOINT":	The endpoint URL of the API Server`)
+		ew.writeln(`	- "RCODEZERO_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "RCODEZERO_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "RCODEZERO_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "RCODEZERO_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/rcodezero`)
+
 	case "rfc2136":
 		// generated from: providers/dns/rfc2136/rfc2136.toml
 		ew.writeln(`Configuration for RFC2136.`)
@@ -1151,20 +2162,48 @@ func displayDNSHelp(name string) error {
 
 		ew.writeln(`Credentials:`)
 		ew.writeln(`	- "AWS_ACCESS_KEY_ID":	Managed by the AWS client`)
+		ew.writeln(`	- "AWS_ASSUME_ROLE_ARN":	The ARN of the role to assume`)
+		ew.writeln(`	- "AWS_EXTERNAL_ID":	The external ID of the role to assume`)
 		ew.writeln(`	- "AWS_HOSTED_ZONE_ID":	Override the hosted zone ID`)
+		ew.writeln(`	- "AWS_PROFILE":	Managed by the AWS client (the profile of the shared configuration)`)
 		ew.writeln(`	- "AWS_REGION":	Managed by the AWS client`)
 		ew.writeln(`	- "AWS_SECRET_ACCESS_KEY":	Managed by the AWS client`)
 		ew.writeln()
 
 		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "AWS_ASSUME_ROLE_SESSION_NAME":	The name of the session of the assumed role`)
 		ew.writeln(`	- "AWS_MAX_RETRIES":	The number of maximum returns the service will use to make an individual API request`)
 		ew.writeln(`	- "AWS_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "AWS_PRIVATE_ZONE":	Set to true to target the private hosted zone instead of the public hosted zone`)
 		ew.writeln(`	- "AWS_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "AWS_ROUTE53_ENDPOINT":	A custom endpoint of the Route 53 API`)
+		ew.writeln(`	- "AWS_STS_ENDPOINT":	A custom endpoint of the STS API (used to assume the role)`)
 		ew.writeln(`	- "AWS_TTL":	The TTL of the TXT record used for the DNS challenge`)
 
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/route53`)
 
+	case "safedns":
+		// generated from: providers/dns/safedns/safedns.toml
+		ew.writeln(`Configuration for UKFast SafeDNS.`)
+		ew.writeln(`Code:	'safedns'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "SAFEDNS_AUTH_TOKEN":	Authentication token (API application key)`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "SAFEDNS_ENDPOINT":	The endpoint URL of the API Server`)
+		ew.writeln(`	- "SAFEDNS_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "SAFEDNS_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "SAFEDNS_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "SAFEDNS_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/safedns`)
+
 	case "sakuracloud":
 		// generated from: providers/dns/sakuracloud/sakuracloud.toml
 		ew.writeln(`Configuration for Sakura Cloud.`)
@@ -1186,6 +2225,27 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/sakuracloud`)
 
+	case "scaleway":
+		// generated from: providers/dns/scaleway/scaleway.toml
+		ew.writeln(`Configuration for Scaleway.`)
+		ew.writeln(`Code:	'scaleway'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "SCALEWAY_API_TOKEN":	API token (secret key)`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "SCALEWAY_ENDPOINT":	The endpoint URL of the API Server`)
+		ew.writeln(`	- "SCALEWAY_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "SCALEWAY_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "SCALEWAY_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "SCALEWAY_TTL":	The TTL of the TXT record used for the DNS challenge (minimum: 60)`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/scaleway`)
+
 	case "selectel":
 		// generated from: providers/dns/selectel/selectel.toml
 		ew.writeln(`Configuration for Selectel.`)
@@ -1228,6 +2288,30 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/stackpath`)
 
+	case "tencentcloud":
+		// generated from: providers/dns/tencentcloud/tencentcloud.toml
+		ew.writeln(`Configuration for Tencent Cloud DNS.`)
+		ew.writeln(`Code:	'tencentcloud'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "TENCENTCLOUD_SECRET_ID":	Access key ID`)
+		ew.writeln(`	- "TENCENTCLOUD_SECRET_KEY":	Access Key secret`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "TENCENTCLOUD_ENDPOINT":	The endpoint URL of the API Server (Default: https://dnspod.tencentcloudapi.com)`)
+		ew.writeln(`	- "TENCENTCLOUD_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "TENCENTCLOUD_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "TENCENTCLOUD_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "TENCENTCLOUD_REGION":	Region`)
+		ew.writeln(`	- "TENCENTCLOUD_SESSION_TOKEN":	Access Key token (only with temporary credentials)`)
+		ew.writeln(`	- "TENCENTCLOUD_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/tencentcloud`)
+
 	case "transip":
 		// generated from: providers/dns/transip/transip.toml
 		ew.writeln(`Configuration for TransIP.`)
@@ -1248,6 +2332,50 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/transip`)
 
+	case "ultradns":
+		// generated from: providers/dns/ultradns/ultradns.toml
+		ew.writeln(`Configuration for UltraDNS.`)
+		ew.writeln(`Code:	'ultradns'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "ULTRADNS_PASSWORD":	API Password`)
+		ew.writeln(`	- "ULTRADNS_USERNAME":	API Username`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "ULTRADNS_ACCOUNT_NAME":	The name of the account of the zones`)
+		ew.writeln(`	- "ULTRADNS_ENDPOINT":	The endpoint URL of the API Server (Default: https://api.ultradns.com)`)
+		ew.writeln(`	- "ULTRADNS_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "ULTRADNS_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "ULTRADNS_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "ULTRADNS_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/ultradns`)
+
+	case "variomedia":
+		// generated from: providers/dns/variomedia/variomedia.toml
+		ew.writeln(`Configuration for Variomedia.`)
+		ew.writeln(`Code:	'variomedia'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "VARIOMEDIA_API_TOKEN":	API token`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "VARIOMEDIA_ENDPOINT":	The endpoint URL of the API Server`)
+		ew.writeln(`	- "VARIOMEDIA_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "VARIOMEDIA_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "VARIOMEDIA_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "VARIOMEDIA_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/variomedia`)
+
 	case "vegadns":
 		// generated from: providers/dns/vegadns/vegadns.toml
 		ew.writeln(`Configuration for VegaDNS.`)
@@ -1269,6 +2397,28 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/vegadns`)
 
+	case "vercel":
+		// generated from: providers/dns/vercel/vercel.toml
+		ew.writeln(`Configuration for Vercel.`)
+		ew.writeln(`Code:	'vercel'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "VERCEL_API_TOKEN":	Authentication token`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "VERCEL_ENDPOINT":	The endpoint URL of the API Server`)
+		ew.writeln(`	- "VERCEL_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "VERCEL_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "VERCEL_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "VERCEL_TEAM_ID":	Team ID (i.e. team_xxxxxx), required to manage the domains of a team`)
+		ew.writeln(`	- "VERCEL_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/vercel`)
+
 	case "versio":
 		// generated from: providers/dns/versio/versio.toml
 		ew.writeln(`Configuration for Versio.[nl|eu|uk].`)
@@ -1325,6 +2475,7 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 
 		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "VULTR_ENDPOINT":	The endpoint URL of the API (default: https://api.vultr.com/v2)`)
 		ew.writeln(`	- "VULTR_HTTP_TIMEOUT":	API request timeout`)
 		ew.writeln(`	- "VULTR_POLLING_INTERVAL":	Time between DNS propagation check`)
 		ew.writeln(`	- "VULTR_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
@@ -1333,6 +2484,72 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/vultr`)
 
+	case "websupport":
+		// generated from: providers/dns/websupport/websupport.toml
+		ew.writeln(`Configuration for Websupport.`)
+		ew.writeln(`Code:	'websupport'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "WEBSUPPORT_API_KEY":	API key`)
+		ew.writeln(`	- "WEBSUPPORT_SECRET":	API secret`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "WEBSUPPORT_ENDPOINT":	The endpoint URL of the API Server`)
+		ew.writeln(`	- "WEBSUPPORT_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "WEBSUPPORT_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "WEBSUPPORT_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "WEBSUPPORT_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/websupport`)
+
+	case "world4you":
+		// generated from: providers/dns/world4you/world4you.toml
+		ew.writeln(`Configuration for World4You.`)
+		ew.writeln(`Code:	'world4you'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "WORLD4YOU_PASSWORD":	Password of the customer panel`)
+		ew.writeln(`	- "WORLD4YOU_USERNAME":	Username of the customer panel`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "WORLD4YOU_ENDPOINT":	The URL of the customer panel (Default: https://my.world4you.com/en)`)
+		ew.writeln(`	- "WORLD4YOU_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "WORLD4YOU_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "WORLD4YOU_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/world4you`)
+
+	case "yandexcloud":
+		// generated from: providers/dns/yandexcloud/yandexcloud.toml
+		ew.writeln(`Configuration for Yandex Cloud.`)
+		ew.writeln(`Code:	'yandexcloud'`)
+		ew.writeln(`Since:	'v3.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "YANDEX_CLOUD_FOLDER_ID":	The ID of the folder containing the DNS zones`)
+		ew.writeln(`	- "YANDEX_CLOUD_IAM_TOKEN":	An IAM token, alternative to the service account key`)
+		ew.writeln(`	- "YANDEX_CLOUD_SERVICE_ACCOUNT_KEY":	The authorized key of a service account (JSON, raw or base64 encoded)`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "YANDEX_CLOUD_ENDPOINT":	The endpoint URL of the API Server`)
+		ew.writeln(`	- "YANDEX_CLOUD_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "YANDEX_CLOUD_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "YANDEX_CLOUD_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "YANDEX_CLOUD_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/yandexcloud`)
+
 	case "zoneee":
 		// generated from: providers/dns/zoneee/zoneee.toml
 		ew.writeln(`Configuration for Zone.ee.`)
@@ -3,6 +3,7 @@ module github.com/go-acme/lego/v3
 go 1.12
 
 require (
+	cloud.google.com/go v0.38.0
 	github.com/Azure/azure-sdk-for-go v32.4.0+incompatible
 	github.com/Azure/go-autorest/autorest v0.5.0
 	github.com/Azure/go-autorest/autorest/adal v0.2.0
@@ -19,7 +20,6 @@ require (
 	github.com/cpu/goacmedns v0.0.1
 	github.com/decker502/dnspod-go v0.2.0
 	github.com/dnsimple/dnsimple-go v0.30.0
-	github.com/exoscale/egoscale v0.18.1
 	github.com/go-errors/errors v1.0.1 // indirect
 	github.com/gophercloud/gophercloud v0.3.0
 	github.com/hashicorp/golang-lru v0.5.3 // indirect
@@ -42,11 +42,13 @@ require (
 	github.com/timewasted/linode v0.0.0-20160829202747-37e84520dcf7
 	github.com/transip/gotransip v0.0.0-20190812104329-6d8d9179b66f
 	github.com/urfave/cli v1.21.0
-	github.com/vultr/govultr v0.1.4
 	golang.org/x/crypto v0.0.0-20190701094942-4def268fd1a4
 	golang.org/x/net v0.0.0-20190503192946-f4e77d36d62c
 	golang.org/x/oauth2 v0.0.0-20190604053449-0f29369cfe45
+	golang.org/x/sys v0.0.0-20190507160741-ecd444e8653b
 	google.golang.org/api v0.8.0
+	gopkg.in/ini.v1 v1.44.0
 	gopkg.in/ns1/ns1-go.v2 v2.0.0-20190730140822-b51389932cbc
 	gopkg.in/square/go-jose.v2 v2.3.1
+	gopkg.in/yaml.v2 v2.2.2
 )
@@ -8,6 +8,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"text/template"
 
@@ -23,6 +24,8 @@ const (
 	docOutput   = root + "docs/content/dns"
 )
 
+var envVarPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
 type Model struct {
 	Name          string         // Real name of the DNS provider
 	Code          string         // DNS code
@@ -117,6 +120,10 @@ func generateCLIHelp(models *Providers) error {
 		"safe": func(src string) string {
 			return strings.ReplaceAll(src, "`", "'")
 		},
+		// the credentials can also be described by a text (i.e. "instance metadata service").
+		"isEnvVar": func(src string) bool {
+			return envVarPattern.MatchString(src)
+		},
 	})
 
 	b := &bytes.Buffer{}
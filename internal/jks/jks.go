@@ -0,0 +1,139 @@
+// Package jks encodes the Java keystores of the proprietary format of the Sun provider (JKS).
+package jks
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"fmt"
+	"time"
+	"unicode/utf16"
+
+	"github.com/go-acme/lego/v3/internal/pkcs12"
+	"github.com/go-acme/lego/v3/internal/pkcs8"
+)
+
+// JKS (the proprietary format of the Sun provider).
+const (
+	magic         = 0xfeedfeed
+	version       = 2
+	privateKeyTag = 1
+	whitener      = "Mighty Aphrodite"
+)
+
+// oidKeyProtector the algorithm of the private keys of the JKS files (sun.security.provider.KeyProtector).
+var oidKeyProtector = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 42, 2, 17, 1, 1}
+
+// Encode encodes a JKS keystore with a private key entry, the private key is protected by the password of the keystore.
+func Encode(privateKey crypto.PrivateKey, chain []*x509.Certificate, alias, password string, date time.Time) ([]byte, error) {
+	protectedKey, err := protectPrivateKey(privateKey, password)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+
+	// the writes in a bytes.Buffer never fail.
+	_ = binary.Write(buf, binary.BigEndian, []uint32{magic, version, 1, privateKeyTag})
+
+	err = writeJavaUTF(buf, alias)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = binary.Write(buf, binary.BigEndian, date.UnixNano()/int64(time.Millisecond))
+
+	_ = binary.Write(buf, binary.BigEndian, uint32(len(protectedKey)))
+	buf.Write(protectedKey)
+
+	_ = binary.Write(buf, binary.BigEndian, uint32(len(chain)))
+	for _, cert := range chain {
+		_ = writeJavaUTF(buf, "X.509")
+		_ = binary.Write(buf, binary.BigEndian, uint32(len(cert.Raw)))
+		buf.Write(cert.Raw)
+	}
+
+	digest := integrityDigest(password, buf.Bytes())
+	buf.Write(digest[:])
+
+	return buf.Bytes(), nil
+}
+
+// protectPrivateKey encrypts a private key as the Sun key protector:
+// the PKCS#8 private key is XORed with a SHA-1 key stream derived from the password and a salt, followed by a SHA-1 checksum.
+func protectPrivateKey(privateKey crypto.PrivateKey, password string) ([]byte, error) {
+	plainKey, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, sha1.Size)
+	if _, err = rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	passwordBytes := pkcs12.BMPString(password)
+
+	encryptedKey := make([]byte, len(plainKey))
+
+	digest := salt
+	for i := 0; i < len(plainKey); i += sha1.Size {
+		sum := sha1.Sum(concat(passwordBytes, digest))
+		digest = sum[:]
+
+		for j := 0; j < sha1.Size && i+j < len(plainKey); j++ {
+			encryptedKey[i+j] = plainKey[i+j] ^ digest[j]
+		}
+	}
+
+	checksum := sha1.Sum(concat(passwordBytes, plainKey))
+
+	return asn1.Marshal(pkcs8.EncryptedPrivateKeyInfo{
+		Algorithm:     pkix.AlgorithmIdentifier{Algorithm: oidKeyProtector, Parameters: asn1.NullRawValue},
+		EncryptedData: concat(salt, encryptedKey, checksum[:]),
+	})
+}
+
+// integrityDigest returns the digest of the content of a JKS keystore (the integrity check).
+func integrityDigest(password string, data []byte) [sha1.Size]byte {
+	return sha1.Sum(concat(pkcs12.BMPString(password), []byte(whitener), data))
+}
+
+// writeJavaUTF writes a string as java.io.DataOutput.writeUTF (the length, then the modified UTF-8 bytes).
+func writeJavaUTF(buf *bytes.Buffer, value string) error {
+	var data []byte
+	for _, c := range utf16.Encode([]rune(value)) {
+		switch {
+		case c != 0 && c < 0x80:
+			data = append(data, byte(c))
+		case c < 0x800:
+			data = append(data, byte(0xc0|c>>6), byte(0x80|c&0x3f))
+		default:
+			data = append(data, byte(0xe0|c>>12), byte(0x80|(c>>6)&0x3f), byte(0x80|c&0x3f))
+		}
+	}
+
+	if len(data) > 0xffff {
+		return fmt.Errorf("the string is too long: %d bytes", len(data))
+	}
+
+	_ = binary.Write(buf, binary.BigEndian, uint16(len(data)))
+	buf.Write(data)
+
+	return nil
+}
+
+// concat concatenates byte slices in a new slice.
+func concat(values ...[]byte) []byte {
+	var data []byte
+	for _, value := range values {
+		data = append(data, value...)
+	}
+
+	return data
+}
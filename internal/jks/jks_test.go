@@ -0,0 +1,123 @@
+package jks
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v3/certcrypto"
+	"github.com/go-acme/lego/v3/internal/pkcs12"
+	"github.com/go-acme/lego/v3/internal/pkcs8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	certPEM, err := certcrypto.GeneratePemCert(privateKey, "example.com", nil)
+	require.NoError(t, err)
+
+	cert, err := certcrypto.ParsePEMCertificate(certPEM)
+	require.NoError(t, err)
+
+	date := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	data, err := Encode(privateKey, []*x509.Certificate{cert, cert}, "tomcat", "changeit", date)
+	require.NoError(t, err)
+
+	// the integrity check.
+	content, digest := data[:len(data)-sha1.Size], data[len(data)-sha1.Size:]
+	expectedDigest := integrityDigest("changeit", content)
+	assert.Equal(t, expectedDigest[:], digest)
+
+	reader := bytes.NewReader(content)
+
+	var header [4]uint32
+	require.NoError(t, binary.Read(reader, binary.BigEndian, &header))
+	assert.Equal(t, [4]uint32{magic, version, 1, privateKeyTag}, header)
+
+	assert.Equal(t, "tomcat", readJavaUTF(t, reader))
+
+	var timestamp int64
+	require.NoError(t, binary.Read(reader, binary.BigEndian, &timestamp))
+	assert.Equal(t, date.Unix()*1000, timestamp)
+
+	var info pkcs8.EncryptedPrivateKeyInfo
+	_, err = asn1.Unmarshal(readJavaBytes(t, reader), &info)
+	require.NoError(t, err)
+	assert.Equal(t, oidKeyProtector, info.Algorithm.Algorithm)
+
+	// recovers the private key (sun.security.provider.KeyProtector).
+	salt := info.EncryptedData[:sha1.Size]
+	encryptedKey := info.EncryptedData[sha1.Size : len(info.EncryptedData)-sha1.Size]
+	checksum := info.EncryptedData[len(info.EncryptedData)-sha1.Size:]
+
+	plainKey := make([]byte, len(encryptedKey))
+	digest = salt
+	for i := range encryptedKey {
+		if i%sha1.Size == 0 {
+			sum := sha1.Sum(append(pkcs12.BMPString("changeit"), digest...))
+			digest = sum[:]
+		}
+		plainKey[i] = encryptedKey[i] ^ digest[i%sha1.Size]
+	}
+
+	expectedChecksum := sha1.Sum(append(pkcs12.BMPString("changeit"), plainKey...))
+	assert.Equal(t, expectedChecksum[:], checksum)
+
+	recovered, err := x509.ParsePKCS8PrivateKey(plainKey)
+	require.NoError(t, err)
+	assert.Equal(t, privateKey, recovered)
+
+	var count uint32
+	require.NoError(t, binary.Read(reader, binary.BigEndian, &count))
+	require.EqualValues(t, 2, count)
+
+	for i := 0; i < int(count); i++ {
+		assert.Equal(t, "X.509", readJavaUTF(t, reader))
+		assert.Equal(t, cert.Raw, readJavaBytes(t, reader))
+	}
+
+	assert.Zero(t, reader.Len())
+}
+
+func Test_writeJavaUTF(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	require.NoError(t, writeJavaUTF(buf, "é\x00€"))
+	assert.Equal(t, []byte{0, 7, 0xc3, 0xa9, 0xc0, 0x80, 0xe2, 0x82, 0xac}, buf.Bytes())
+}
+
+func readJavaUTF(t *testing.T, reader *bytes.Reader) string {
+	t.Helper()
+
+	var size uint16
+	require.NoError(t, binary.Read(reader, binary.BigEndian, &size))
+
+	data := make([]byte, size)
+	_, err := reader.Read(data)
+	require.NoError(t, err)
+
+	return string(data)
+}
+
+func readJavaBytes(t *testing.T, reader *bytes.Reader) []byte {
+	t.Helper()
+
+	var size uint32
+	require.NoError(t, binary.Read(reader, binary.BigEndian, &size))
+
+	data := make([]byte, size)
+	_, err := reader.Read(data)
+	require.NoError(t, err)
+
+	return data
+}
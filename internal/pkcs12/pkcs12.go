@@ -0,0 +1,462 @@
+// Package pkcs12 encodes the PKCS#12 files (RFC 7292) with the private key and the certificates of a certificate resource.
+package pkcs12
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/go-acme/lego/v3/internal/pkcs8"
+)
+
+// Formats of the PKCS#12 files (the encryption algorithms).
+const (
+	// FormatSHA256 the certificates and the private key are encrypted with PBES2 (AES-256-CBC), SHA-256 MAC (the default of OpenSSL 3).
+	FormatSHA256 = "SHA256"
+	// FormatDES the certificates and the private key are encrypted with 3DES, SHA-1 MAC (readable by all the versions of Java).
+	FormatDES = "DES"
+	// FormatRC2 the certificates are encrypted with RC2-40, the private key with 3DES, SHA-1 MAC (legacy format for the old systems,
+	// OpenSSL 3 requires -legacy to read it).
+	FormatRC2 = "RC2"
+)
+
+// Parameters of the encryption and of the MAC of the PKCS#12 files (the defaults of OpenSSL).
+const (
+	saltLength = 8
+	iterations = 2048
+)
+
+// IDs of the key derivation of the PKCS#12 files (RFC 7292, appendix B.3).
+const (
+	keyID byte = 1
+	ivID  byte = 2
+	macID byte = 3
+)
+
+var (
+	oidPKCS7Data                     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidPKCS7EncryptedData            = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 6}
+	oidPBEWithSHAAnd3KeyTripleDESCBC = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 1, 3}
+	oidPBEWithSHAAnd40BitRC2CBC      = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 1, 6}
+	oidPKCS8ShroudedKeyBag           = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 10, 1, 2}
+	oidCertBag                       = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 10, 1, 3}
+	oidPKCS9FriendlyName             = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 20}
+	oidPKCS9LocalKeyID               = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 21}
+	oidCertTypeX509                  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 22, 1}
+	oidSHA1                          = asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}
+	oidSHA256                        = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+)
+
+// pfx PFX (RFC 7292).
+type pfx struct {
+	Version  int
+	AuthSafe contentInfo
+	MacData  macData `asn1:"optional"`
+}
+
+// contentInfo ContentInfo (RFC 2315).
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"tag:0,explicit,optional"`
+}
+
+// encryptedData EncryptedData (RFC 2315).
+type encryptedData struct {
+	Version              int
+	EncryptedContentInfo struct {
+		ContentType                asn1.ObjectIdentifier
+		ContentEncryptionAlgorithm pkix.AlgorithmIdentifier
+		EncryptedContent           []byte `asn1:"tag:0,optional"`
+	}
+}
+
+// macData MacData (RFC 7292).
+type macData struct {
+	Mac struct {
+		Algorithm pkix.AlgorithmIdentifier
+		Digest    []byte
+	}
+	MacSalt    []byte
+	Iterations int `asn1:"optional,default:1"`
+}
+
+// safeBag SafeBag (RFC 7292).
+type safeBag struct {
+	ID         asn1.ObjectIdentifier
+	Value      asn1.RawValue `asn1:"tag:0,explicit"`
+	Attributes []attribute   `asn1:"set,optional"`
+}
+
+// certBag CertBag (RFC 7292).
+type certBag struct {
+	ID   asn1.ObjectIdentifier
+	Data asn1.RawValue `asn1:"tag:0,explicit"`
+}
+
+// attribute PKCS12Attribute (RFC 7292).
+type attribute struct {
+	ID    asn1.ObjectIdentifier
+	Value asn1.RawValue `asn1:"set"`
+}
+
+// pbeParams pkcs-12PbeParams (RFC 7292).
+type pbeParams struct {
+	Salt       []byte
+	Iterations int
+}
+
+// Encoder the algorithms of a PKCS#12 file:
+// the encryption of the certificates, the encryption of the private key, and the MAC.
+type Encoder struct {
+	certAlgorithm asn1.ObjectIdentifier
+	keyAlgorithm  asn1.ObjectIdentifier
+	macAlgorithm  asn1.ObjectIdentifier
+}
+
+// NewEncoder returns the PKCS#12 encoder of a format (FormatSHA256, FormatDES or FormatRC2), FormatSHA256 if the format is empty.
+func NewEncoder(format string) (*Encoder, error) {
+	switch strings.ToUpper(format) {
+	case FormatSHA256, "":
+		return &Encoder{
+			certAlgorithm: pkcs8.OIDPBES2,
+			keyAlgorithm:  pkcs8.OIDPBES2,
+			macAlgorithm:  oidSHA256,
+		}, nil
+	case FormatDES:
+		return &Encoder{
+			certAlgorithm: oidPBEWithSHAAnd3KeyTripleDESCBC,
+			keyAlgorithm:  oidPBEWithSHAAnd3KeyTripleDESCBC,
+			macAlgorithm:  oidSHA1,
+		}, nil
+	case FormatRC2:
+		return &Encoder{
+			certAlgorithm: oidPBEWithSHAAnd40BitRC2CBC,
+			keyAlgorithm:  oidPBEWithSHAAnd3KeyTripleDESCBC,
+			macAlgorithm:  oidSHA1,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported PFX format: %s", format)
+	}
+}
+
+// Encode encodes a PKCS#12 file with the private key, the certificate and the issuer certificates (the chain).
+// The friendly name (the alias of the Java keystores) is optional.
+func (e *Encoder) Encode(privateKey crypto.PrivateKey, chain []*x509.Certificate, friendlyName, password string) ([]byte, error) {
+	// the private key and the certificate are linked by the local key ID.
+	localKeyID := sha1.Sum(chain[0].Raw)
+
+	attributes, err := newAttributes(localKeyID[:], friendlyName)
+	if err != nil {
+		return nil, err
+	}
+
+	var certBags []safeBag
+	for i, cert := range chain {
+		var bag safeBag
+		bag, err = newCertBag(cert)
+		if err != nil {
+			return nil, err
+		}
+
+		if i == 0 {
+			bag.Attributes = attributes
+		}
+
+		certBags = append(certBags, bag)
+	}
+
+	certsData, err := asn1.Marshal(certBags)
+	if err != nil {
+		return nil, err
+	}
+
+	certsInfo, err := e.encryptContent(certsData, password)
+	if err != nil {
+		return nil, err
+	}
+
+	keyBag, err := e.newShroudedKeyBag(privateKey, password)
+	if err != nil {
+		return nil, err
+	}
+
+	keyBag.Attributes = attributes
+
+	keysData, err := asn1.Marshal([]safeBag{keyBag})
+	if err != nil {
+		return nil, err
+	}
+
+	keysContent, err := explicitOctetString(keysData)
+	if err != nil {
+		return nil, err
+	}
+
+	authSafeData, err := asn1.Marshal([]contentInfo{certsInfo, {ContentType: oidPKCS7Data, Content: keysContent}})
+	if err != nil {
+		return nil, err
+	}
+
+	authSafeContent, err := explicitOctetString(authSafeData)
+	if err != nil {
+		return nil, err
+	}
+
+	mac, err := e.computeMAC(authSafeData, password)
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(pfx{
+		Version:  3,
+		AuthSafe: contentInfo{ContentType: oidPKCS7Data, Content: authSafeContent},
+		MacData:  mac,
+	})
+}
+
+// encryptContent encrypts the safe contents of the certificates, as an encrypted data content info.
+func (e *Encoder) encryptContent(plaintext []byte, password string) (contentInfo, error) {
+	algorithm, ciphertext, err := encrypt(e.certAlgorithm, plaintext, password)
+	if err != nil {
+		return contentInfo{}, err
+	}
+
+	var data encryptedData
+	data.EncryptedContentInfo.ContentType = oidPKCS7Data
+	data.EncryptedContentInfo.ContentEncryptionAlgorithm = algorithm
+	data.EncryptedContentInfo.EncryptedContent = ciphertext
+
+	content, err := asn1.Marshal(data)
+	if err != nil {
+		return contentInfo{}, err
+	}
+
+	return contentInfo{ContentType: oidPKCS7EncryptedData, Content: explicitContent(content)}, nil
+}
+
+// newShroudedKeyBag creates a bag with the encrypted PKCS#8 private key.
+func (e *Encoder) newShroudedKeyBag(privateKey crypto.PrivateKey, password string) (safeBag, error) {
+	plainKey, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return safeBag{}, err
+	}
+
+	algorithm, encryptedKey, err := encrypt(e.keyAlgorithm, plainKey, password)
+	if err != nil {
+		return safeBag{}, err
+	}
+
+	keyInfo, err := asn1.Marshal(pkcs8.EncryptedPrivateKeyInfo{Algorithm: algorithm, EncryptedData: encryptedKey})
+	if err != nil {
+		return safeBag{}, err
+	}
+
+	return safeBag{ID: oidPKCS8ShroudedKeyBag, Value: explicitContent(keyInfo)}, nil
+}
+
+// computeMAC computes the MAC of the authenticated safe.
+func (e *Encoder) computeMAC(data []byte, password string) (macData, error) {
+	var result macData
+
+	hashFunc := sha1.New
+	if e.macAlgorithm.Equal(oidSHA256) {
+		hashFunc = sha256.New
+	}
+
+	result.MacSalt = make([]byte, saltLength)
+	if _, err := io.ReadFull(rand.Reader, result.MacSalt); err != nil {
+		return result, err
+	}
+
+	result.Iterations = iterations
+
+	key := deriveKey(hashFunc, macID, result.MacSalt, password, result.Iterations, hashFunc().Size())
+
+	mac := hmac.New(hashFunc, key)
+	mac.Write(data)
+
+	result.Mac.Algorithm = pkix.AlgorithmIdentifier{Algorithm: e.macAlgorithm, Parameters: asn1.NullRawValue}
+	result.Mac.Digest = mac.Sum(nil)
+
+	return result, nil
+}
+
+// newCertBag creates a bag with a X.509 certificate.
+func newCertBag(cert *x509.Certificate) (safeBag, error) {
+	data, err := explicitOctetString(cert.Raw)
+	if err != nil {
+		return safeBag{}, err
+	}
+
+	value, err := asn1.Marshal(certBag{ID: oidCertTypeX509, Data: data})
+	if err != nil {
+		return safeBag{}, err
+	}
+
+	return safeBag{ID: oidCertBag, Value: explicitContent(value)}, nil
+}
+
+// newAttributes creates the attributes of the private key and of the certificate:
+// the local key ID, and the friendly name (a BMPString) if defined.
+func newAttributes(localKeyID []byte, friendlyName string) ([]attribute, error) {
+	value, err := asn1.Marshal(localKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := []attribute{{
+		ID:    oidPKCS9LocalKeyID,
+		Value: asn1.RawValue{Tag: asn1.TagSet, IsCompound: true, Bytes: value},
+	}}
+
+	if friendlyName == "" {
+		return attributes, nil
+	}
+
+	value, err = asn1.Marshal(asn1.RawValue{Tag: 30, Bytes: BMPString(friendlyName)})
+	if err != nil {
+		return nil, err
+	}
+
+	return append(attributes, attribute{
+		ID:    oidPKCS9FriendlyName,
+		Value: asn1.RawValue{Tag: asn1.TagSet, IsCompound: true, Bytes: value},
+	}), nil
+}
+
+// encrypt encrypts data with a password based encryption algorithm, returns the algorithm (with its parameters) and the encrypted data.
+// The PKCS#12 algorithms derive the key and the IV from the BMPString of the password, PBES2 from the UTF-8 password.
+func encrypt(algorithm asn1.ObjectIdentifier, data []byte, password string) (pkix.AlgorithmIdentifier, []byte, error) {
+	if algorithm.Equal(pkcs8.OIDPBES2) {
+		return pkcs8.EncryptPBES2(data, []byte(password), iterations)
+	}
+
+	salt := make([]byte, saltLength)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return pkix.AlgorithmIdentifier{}, nil, err
+	}
+
+	var block cipher.Block
+	switch {
+	case algorithm.Equal(oidPBEWithSHAAnd3KeyTripleDESCBC):
+		var err error
+		block, err = des.NewTripleDESCipher(deriveKey(sha1.New, keyID, salt, password, iterations, 24))
+		if err != nil {
+			return pkix.AlgorithmIdentifier{}, nil, err
+		}
+	case algorithm.Equal(oidPBEWithSHAAnd40BitRC2CBC):
+		block = newRC2Cipher(deriveKey(sha1.New, keyID, salt, password, iterations, 5), 40)
+	default:
+		return pkix.AlgorithmIdentifier{}, nil, fmt.Errorf("unsupported encryption algorithm: %s", algorithm)
+	}
+
+	iv := deriveKey(sha1.New, ivID, salt, password, iterations, block.BlockSize())
+
+	params, err := asn1.Marshal(pbeParams{Salt: salt, Iterations: iterations})
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, nil, err
+	}
+
+	return pkix.AlgorithmIdentifier{Algorithm: algorithm, Parameters: asn1.RawValue{FullBytes: params}}, pkcs8.EncryptCBC(block, iv, data), nil
+}
+
+// deriveKey derives a key, an IV or a MAC key from a password (RFC 7292, appendix B.2).
+func deriveKey(hashFunc func() hash.Hash, id byte, salt []byte, password string, iterations, size int) []byte {
+	h := hashFunc()
+	v := h.BlockSize()
+
+	// the BMPString of the password, with the null terminator.
+	passwordBytes := append(BMPString(password), 0, 0)
+
+	d := bytes.Repeat([]byte{id}, v)
+	input := concat(fillWithRepeats(salt, v), fillWithRepeats(passwordBytes, v))
+
+	var key []byte
+	for {
+		h.Reset()
+		h.Write(d)
+		h.Write(input)
+		a := h.Sum(nil)
+
+		for i := 1; i < iterations; i++ {
+			h.Reset()
+			h.Write(a)
+			a = h.Sum(nil)
+		}
+
+		key = append(key, a...)
+		if len(key) >= size {
+			return key[:size]
+		}
+
+		// each block of the input is incremented by B+1, B is the hash repeated to the size of a block.
+		b := fillWithRepeats(a, v)
+		for j := 0; j < len(input); j += v {
+			carry := uint16(1)
+			for k := v - 1; k >= 0; k-- {
+				sum := uint16(input[j+k]) + uint16(b[k]) + carry
+				input[j+k] = byte(sum)
+				carry = sum >> 8
+			}
+		}
+	}
+}
+
+// explicitOctetString encodes data in an octet string, as the explicit content of a content info or of a bag.
+func explicitOctetString(data []byte) (asn1.RawValue, error) {
+	content, err := asn1.Marshal(data)
+	if err != nil {
+		return asn1.RawValue{}, err
+	}
+
+	return explicitContent(content), nil
+}
+
+// explicitContent the explicit content ([0]) of a content info or of a bag, encoding/asn1 ignores the tags of the raw values.
+func explicitContent(der []byte) asn1.RawValue {
+	return asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: der}
+}
+
+// BMPString returns the UTF-16 big endian bytes of a string, without terminator (the passwords and the friendly names).
+func BMPString(value string) []byte {
+	var data []byte
+	for _, c := range utf16.Encode([]rune(value)) {
+		data = append(data, byte(c>>8), byte(c))
+	}
+
+	return data
+}
+
+// concat concatenates byte slices in a new slice.
+func concat(values ...[]byte) []byte {
+	var data []byte
+	for _, value := range values {
+		data = append(data, value...)
+	}
+
+	return data
+}
+
+// fillWithRepeats repeats a pattern to the next multiple of the block size.
+func fillWithRepeats(pattern []byte, blockSize int) []byte {
+	if len(pattern) == 0 {
+		return nil
+	}
+
+	size := blockSize * ((len(pattern) + blockSize - 1) / blockSize)
+
+	return bytes.Repeat(pattern, (size+len(pattern)-1)/len(pattern))[:size]
+}
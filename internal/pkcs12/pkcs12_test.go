@@ -0,0 +1,247 @@
+package pkcs12
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	"testing"
+
+	"github.com/go-acme/lego/v3/certcrypto"
+	"github.com/go-acme/lego/v3/internal/pkcs8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gopkcs12 "golang.org/x/crypto/pkcs12"
+)
+
+func TestEncoder_Encode(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	certPEM, err := certcrypto.GeneratePemCert(privateKey, "example.com", nil)
+	require.NoError(t, err)
+
+	cert, err := certcrypto.ParsePEMCertificate(certPEM)
+	require.NoError(t, err)
+
+	issuerKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	issuerPEM, err := certcrypto.GeneratePemCert(issuerKey, "issuer.example.com", nil)
+	require.NoError(t, err)
+
+	issuer, err := certcrypto.ParsePEMCertificate(issuerPEM)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		format       string
+		friendlyName string
+	}{
+		{format: FormatRC2},
+		{format: FormatDES, friendlyName: "tomcat"},
+		{format: FormatSHA256, friendlyName: "tomcat"},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.format, func(t *testing.T) {
+			encoder, err := NewEncoder(test.format)
+			require.NoError(t, err)
+
+			data, err := encoder.Encode(privateKey, []*x509.Certificate{cert, issuer}, test.friendlyName, "secret")
+			require.NoError(t, err)
+
+			key, certs, friendlyName := decodePKCS12(t, data, "secret")
+
+			assert.Equal(t, privateKey, key)
+			require.Len(t, certs, 2)
+			assert.Equal(t, cert.Raw, certs[0].Raw)
+			assert.Equal(t, issuer.Raw, certs[1].Raw)
+			assert.Equal(t, test.friendlyName, friendlyName)
+		})
+	}
+}
+
+func TestEncoder_Encode_localKeyID(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	certPEM, err := certcrypto.GeneratePemCert(privateKey, "example.com", nil)
+	require.NoError(t, err)
+
+	cert, err := certcrypto.ParsePEMCertificate(certPEM)
+	require.NoError(t, err)
+
+	encoder, err := NewEncoder(FormatRC2)
+	require.NoError(t, err)
+
+	data, err := encoder.Encode(privateKey, []*x509.Certificate{cert}, "", "secret")
+	require.NoError(t, err)
+
+	blocks, err := gopkcs12.ToPEM(data, "secret")
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+
+	localKeyID := sha1.Sum(cert.Raw)
+
+	for _, block := range blocks {
+		assert.Equal(t, hex.EncodeToString(localKeyID[:]), block.Headers["localKeyId"], block.Type)
+	}
+}
+
+func TestNewEncoder_unsupported(t *testing.T) {
+	_, err := NewEncoder("foo")
+	require.EqualError(t, err, "unsupported PFX format: foo")
+}
+
+func Test_deriveKey(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		salt     string
+		password string
+		expected string
+	}{
+		{
+			desc:     "several blocks",
+			salt:     "ffffffffffffffff",
+			password: "sesame",
+			expected: "7cd9fd3e2b3be7691a44e3bef0f9ea0fb9b897d4e325d9d1",
+		},
+		{
+			desc:     "leading zeros",
+			salt:     "f37e05b518324b4b",
+			expected: "00f759ff47d14dd03665d5943cb3c4a39a2555c02aed66e1",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			salt, err := hex.DecodeString(test.salt)
+			require.NoError(t, err)
+
+			key := deriveKey(sha1.New, keyID, salt, test.password, 2048, 24)
+			assert.Equal(t, test.expected, hex.EncodeToString(key))
+		})
+	}
+}
+
+// decodePKCS12 decodes a PKCS#12 file with a RSA private key, returns the private key, the certificates and the friendly name of the private key.
+// The SHA-1 MAC formats are decoded by golang.org/x/crypto/pkcs12, the SHA256 format (not supported by golang.org/x/crypto/pkcs12) is decoded here.
+func decodePKCS12(t *testing.T, data []byte, password string) (crypto.PrivateKey, []*x509.Certificate, string) {
+	t.Helper()
+
+	var file pfx
+	_, err := asn1.Unmarshal(data, &file)
+	require.NoError(t, err)
+
+	var privateKey crypto.PrivateKey
+	var certs []*x509.Certificate
+	var friendlyName string
+
+	if file.MacData.Mac.Algorithm.Algorithm.Equal(oidSHA1) {
+		blocks, err := gopkcs12.ToPEM(data, password)
+		require.NoError(t, err)
+
+		for _, block := range blocks {
+			switch block.Type {
+			case "PRIVATE KEY":
+				privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+				require.NoError(t, err)
+
+				friendlyName = block.Headers["friendlyName"]
+			case "CERTIFICATE":
+				cert, err := x509.ParseCertificate(block.Bytes)
+				require.NoError(t, err)
+
+				certs = append(certs, cert)
+			}
+		}
+
+		return privateKey, certs, friendlyName
+	}
+
+	require.Equal(t, oidSHA256, file.MacData.Mac.Algorithm.Algorithm)
+
+	var authSafeData []byte
+	_, err = asn1.Unmarshal(file.AuthSafe.Content.Bytes, &authSafeData)
+	require.NoError(t, err)
+
+	mac := hmac.New(sha256.New, deriveKey(sha256.New, macID, file.MacData.MacSalt, password, file.MacData.Iterations, sha256.Size))
+	mac.Write(authSafeData)
+	require.Equal(t, mac.Sum(nil), file.MacData.Mac.Digest, "MAC")
+
+	var authSafe []contentInfo
+	_, err = asn1.Unmarshal(authSafeData, &authSafe)
+	require.NoError(t, err)
+
+	for _, info := range authSafe {
+		var bagsData []byte
+
+		if info.ContentType.Equal(oidPKCS7EncryptedData) {
+			var content encryptedData
+			_, err = asn1.Unmarshal(info.Content.Bytes, &content)
+			require.NoError(t, err)
+
+			encryptedInfo := content.EncryptedContentInfo
+			bagsData, err = pkcs8.DecryptPBES2(encryptedInfo.ContentEncryptionAlgorithm, encryptedInfo.EncryptedContent, []byte(password))
+			require.NoError(t, err)
+		} else {
+			_, err = asn1.Unmarshal(info.Content.Bytes, &bagsData)
+			require.NoError(t, err)
+		}
+
+		var bags []safeBag
+		_, err = asn1.Unmarshal(bagsData, &bags)
+		require.NoError(t, err)
+
+		for _, bag := range bags {
+			switch {
+			case bag.ID.Equal(oidCertBag):
+				var cb certBag
+				_, err = asn1.Unmarshal(bag.Value.Bytes, &cb)
+				require.NoError(t, err)
+
+				var der []byte
+				_, err = asn1.Unmarshal(cb.Data.Bytes, &der)
+				require.NoError(t, err)
+
+				cert, err := x509.ParseCertificate(der)
+				require.NoError(t, err)
+
+				certs = append(certs, cert)
+			case bag.ID.Equal(oidPKCS8ShroudedKeyBag):
+				privateKey, err = pkcs8.DecryptPrivateKey(bag.Value.Bytes, []byte(password))
+				require.NoError(t, err)
+
+				for _, attr := range bag.Attributes {
+					if !attr.ID.Equal(oidPKCS9FriendlyName) {
+						continue
+					}
+
+					var value asn1.RawValue
+					_, err = asn1.Unmarshal(attr.Value.Bytes, &value)
+					require.NoError(t, err)
+
+					friendlyName = decodeBMPString(value.Bytes)
+				}
+			}
+		}
+	}
+
+	return privateKey, certs, friendlyName
+}
+
+func decodeBMPString(data []byte) string {
+	var runes []rune
+	for i := 0; i+1 < len(data); i += 2 {
+		runes = append(runes, rune(data[i])<<8|rune(data[i+1]))
+	}
+
+	return string(runes)
+}
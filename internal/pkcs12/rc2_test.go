@@ -0,0 +1,49 @@
+package pkcs12
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_rc2Cipher(t *testing.T) {
+	// the test vectors of RFC 2268.
+	testCases := []struct {
+		key           string
+		effectiveBits int
+		plaintext     string
+		ciphertext    string
+	}{
+		{key: "0000000000000000", effectiveBits: 63, plaintext: "0000000000000000", ciphertext: "ebb773f993278eff"},
+		{key: "ffffffffffffffff", effectiveBits: 64, plaintext: "ffffffffffffffff", ciphertext: "278b27e42e2f0d49"},
+		{key: "3000000000000000", effectiveBits: 64, plaintext: "1000000000000001", ciphertext: "30649edf9be7d2c2"},
+		{key: "88", effectiveBits: 64, plaintext: "0000000000000000", ciphertext: "61a8a244adacccf0"},
+		{key: "88bca90e90875a", effectiveBits: 64, plaintext: "0000000000000000", ciphertext: "6ccf4308974c267f"},
+		{key: "88bca90e90875a7f0f79c384627bafb2", effectiveBits: 64, plaintext: "0000000000000000", ciphertext: "1a807d272bbe5db1"},
+		{key: "88bca90e90875a7f0f79c384627bafb2", effectiveBits: 128, plaintext: "0000000000000000", ciphertext: "2269552ab0f85ca6"},
+		{key: "88bca90e90875a7f0f79c384627bafb216f80a6f85920584c42fceb0be255daf1e", effectiveBits: 129, plaintext: "0000000000000000", ciphertext: "5b78d3a43dfff1f1"},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.key, func(t *testing.T) {
+			key, err := hex.DecodeString(test.key)
+			require.NoError(t, err)
+
+			plaintext, err := hex.DecodeString(test.plaintext)
+			require.NoError(t, err)
+
+			c := newRC2Cipher(key, test.effectiveBits)
+
+			ciphertext := make([]byte, rc2BlockSize)
+			c.Encrypt(ciphertext, plaintext)
+			assert.Equal(t, test.ciphertext, hex.EncodeToString(ciphertext))
+
+			decrypted := make([]byte, rc2BlockSize)
+			c.Decrypt(decrypted, ciphertext)
+			assert.Equal(t, plaintext, decrypted)
+		})
+	}
+}
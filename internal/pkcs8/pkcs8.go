@@ -0,0 +1,234 @@
+// Package pkcs8 encrypts and decrypts the PKCS#8 private keys with PBES2 (RFC 5208, RFC 8018).
+package pkcs8
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// Parameters of the encryption of the private keys (PBES2 with PBKDF2-HMAC-SHA256 and AES-256-CBC, RFC 8018).
+const (
+	saltLength = 16
+	iterations = 100000
+)
+
+// PEMType the type of the PEM block of an encrypted PKCS#8 private key.
+const PEMType = "ENCRYPTED PRIVATE KEY"
+
+// ErrWrongPassphrase the private key cannot be decrypted with the passphrase.
+var ErrWrongPassphrase = errors.New("wrong passphrase or corrupted private key")
+
+// OIDPBES2 the identifier of the PBES2 encryption scheme.
+var OIDPBES2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+
+var (
+	oidPBKDF2         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidAES128CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+// EncryptedPrivateKeyInfo EncryptedPrivateKeyInfo (RFC 5208).
+type EncryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+// pbes2Params PBES2-params (RFC 8018).
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+// pbkdf2Params PBKDF2-params (RFC 8018), the PRF is HMAC-SHA1 if not defined.
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// EncryptPrivateKey encrypts a private key with a passphrase, as an encrypted PKCS#8 PEM block ("ENCRYPTED PRIVATE KEY").
+// The private key can be decrypted by OpenSSL (openssl pkey -in account.key).
+func EncryptPrivateKey(privateKey crypto.PrivateKey, passphrase []byte) (*pem.Block, error) {
+	plaintext, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	algorithm, ciphertext, err := EncryptPBES2(plaintext, passphrase, iterations)
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := asn1.Marshal(EncryptedPrivateKeyInfo{
+		Algorithm:     algorithm,
+		EncryptedData: ciphertext,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pem.Block{Type: PEMType, Bytes: der}, nil
+}
+
+// DecryptPrivateKey decrypts an encrypted PKCS#8 private key (DER) with a passphrase.
+// Only PBES2 with PBKDF2 (HMAC-SHA1 or HMAC-SHA256) and AES-CBC is supported (the default of OpenSSL).
+func DecryptPrivateKey(der, passphrase []byte) (crypto.PrivateKey, error) {
+	var info EncryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, fmt.Errorf("invalid encrypted private key: %v", err)
+	}
+
+	plaintext, err := DecryptPBES2(info.Algorithm, info.EncryptedData, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := x509.ParsePKCS8PrivateKey(plaintext)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+
+	return privateKey, nil
+}
+
+// EncryptPBES2 encrypts data with PBES2 (PBKDF2-HMAC-SHA256 and AES-256-CBC), returns the algorithm (with its parameters) and the encrypted data.
+func EncryptPBES2(plaintext, passphrase []byte, iterations int) (pkix.AlgorithmIdentifier, []byte, error) {
+	salt := make([]byte, saltLength)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return pkix.AlgorithmIdentifier{}, nil, err
+	}
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return pkix.AlgorithmIdentifier{}, nil, err
+	}
+
+	block, err := aes.NewCipher(pbkdf2.Key(passphrase, salt, iterations, 32, sha256.New))
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, nil, err
+	}
+
+	ciphertext := EncryptCBC(block, iv, plaintext)
+
+	kdfParams, err := asn1.Marshal(pbkdf2Params{
+		Salt:           salt,
+		IterationCount: iterations,
+		PRF:            pkix.AlgorithmIdentifier{Algorithm: oidHMACWithSHA256, Parameters: asn1.NullRawValue},
+	})
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, nil, err
+	}
+
+	encryptionParams, err := asn1.Marshal(iv)
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, nil, err
+	}
+
+	params, err := asn1.Marshal(pbes2Params{
+		KeyDerivationFunc: pkix.AlgorithmIdentifier{Algorithm: oidPBKDF2, Parameters: asn1.RawValue{FullBytes: kdfParams}},
+		EncryptionScheme:  pkix.AlgorithmIdentifier{Algorithm: oidAES256CBC, Parameters: asn1.RawValue{FullBytes: encryptionParams}},
+	})
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, nil, err
+	}
+
+	return pkix.AlgorithmIdentifier{Algorithm: OIDPBES2, Parameters: asn1.RawValue{FullBytes: params}}, ciphertext, nil
+}
+
+// DecryptPBES2 decrypts data encrypted with PBES2.
+// Only PBKDF2 (HMAC-SHA1 or HMAC-SHA256) and AES-CBC are supported (the default of OpenSSL).
+func DecryptPBES2(algorithm pkix.AlgorithmIdentifier, ciphertext, passphrase []byte) ([]byte, error) {
+	if !algorithm.Algorithm.Equal(OIDPBES2) {
+		return nil, fmt.Errorf("unsupported encryption algorithm of the private key: %s (supported: PBES2)", algorithm.Algorithm)
+	}
+
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(algorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("invalid PBES2 parameters: %v", err)
+	}
+
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, fmt.Errorf("unsupported key derivation function: %s (supported: PBKDF2)", params.KeyDerivationFunc.Algorithm)
+	}
+
+	var kdfParams pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdfParams); err != nil {
+		return nil, fmt.Errorf("invalid PBKDF2 parameters: %v", err)
+	}
+
+	var prf func() hash.Hash
+	switch {
+	case len(kdfParams.PRF.Algorithm) == 0, kdfParams.PRF.Algorithm.Equal(oidHMACWithSHA1):
+		prf = sha1.New
+	case kdfParams.PRF.Algorithm.Equal(oidHMACWithSHA256):
+		prf = sha256.New
+	default:
+		return nil, fmt.Errorf("unsupported PBKDF2 function: %s (supported: HMAC-SHA1, HMAC-SHA256)", kdfParams.PRF.Algorithm)
+	}
+
+	var keyLength int
+	switch {
+	case params.EncryptionScheme.Algorithm.Equal(oidAES128CBC):
+		keyLength = 16
+	case params.EncryptionScheme.Algorithm.Equal(oidAES192CBC):
+		keyLength = 24
+	case params.EncryptionScheme.Algorithm.Equal(oidAES256CBC):
+		keyLength = 32
+	default:
+		return nil, fmt.Errorf("unsupported encryption scheme: %s (supported: AES-CBC)", params.EncryptionScheme.Algorithm)
+	}
+
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil || len(iv) != aes.BlockSize {
+		return nil, errors.New("invalid AES-CBC parameters")
+	}
+
+	if len(ciphertext) == 0 || len(ciphertext)%aes.BlockSize != 0 {
+		return nil, errors.New("invalid encrypted private key: the length is not a multiple of the block size")
+	}
+
+	block, err := aes.NewCipher(pbkdf2.Key(passphrase, kdfParams.Salt, kdfParams.IterationCount, keyLength, prf))
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, ciphertext)
+
+	padding := int(plaintext[len(plaintext)-1])
+	if padding == 0 || padding > aes.BlockSize || !bytes.Equal(plaintext[len(plaintext)-padding:], bytes.Repeat([]byte{byte(padding)}, padding)) {
+		return nil, ErrWrongPassphrase
+	}
+
+	return plaintext[:len(plaintext)-padding], nil
+}
+
+// EncryptCBC pads the data (PKCS#7 padding) and encrypts it with a block cipher in CBC mode.
+func EncryptCBC(block cipher.Block, iv, plaintext []byte) []byte {
+	padding := block.BlockSize() - len(plaintext)%block.BlockSize()
+	plaintext = append(plaintext[:len(plaintext):len(plaintext)], bytes.Repeat([]byte{byte(padding)}, padding)...)
+
+	ciphertext := make([]byte, len(plaintext))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, plaintext)
+
+	return ciphertext
+}
@@ -0,0 +1,198 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level the severity of a log entry.
+type Level int
+
+// Levels of the log entries.
+// The level of an entry is defined by the prefix of its message ([DEBUG], [INFO], [WARN], [ERROR]),
+// the entries without prefix are INFO entries.
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+	LevelFatal
+)
+
+var levelNames = map[Level]string{
+	LevelDebug: "debug",
+	LevelInfo:  "info",
+	LevelWarn:  "warn",
+	LevelError: "error",
+	LevelFatal: "fatal",
+}
+
+// levelPrefixes the prefixes of the messages defining the level of the entries.
+var levelPrefixes = []struct {
+	prefix string
+	level  Level
+}{
+	{prefix: "[DEBUG] ", level: LevelDebug},
+	{prefix: "[INFO] ", level: LevelInfo},
+	{prefix: "[WARN] ", level: LevelWarn},
+	{prefix: "[ERROR] ", level: LevelError},
+}
+
+// Formats of the log entries.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+func (l Level) String() string {
+	return levelNames[l]
+}
+
+// ParseLevel returns the level corresponding to a name (debug, info, warn, error).
+func ParseLevel(name string) (Level, error) {
+	for level, n := range levelNames {
+		if level != LevelFatal && strings.EqualFold(n, name) {
+			return level, nil
+		}
+	}
+
+	return LevelInfo, fmt.Errorf("unsupported log level: %s (supported: debug, info, warn, error)", name)
+}
+
+// LevelLogger is a StdLogger writing only the entries of a level greater or equal to a minimum level,
+// as text (the format of the standard logger) or as JSON documents (one per line).
+//
+// The JSON documents contain the time (if flag is not 0), the level, the domain (the "[domain]" prefix of the message) and the message:
+//
+//	{"time":"2020-01-20T10:30:00.123Z","level":"info","domain":"example.com","msg":"acme: Obtaining bundled SAN certificate"}
+type LevelLogger struct {
+	format string
+	level  Level
+	flag   int
+
+	mu     sync.Mutex
+	out    io.Writer
+	logger *log.Logger
+}
+
+// NewLevelLogger creates a LevelLogger.
+// The flag defines the properties of the text entries (see log.New), the JSON entries contain the time if flag is not 0.
+func NewLevelLogger(out io.Writer, format string, level Level, flag int) (*LevelLogger, error) {
+	if format != FormatText && format != FormatJSON {
+		return nil, fmt.Errorf("unsupported log format: %s (supported: text, json)", format)
+	}
+
+	return &LevelLogger{
+		format: format,
+		level:  level,
+		flag:   flag,
+		out:    out,
+		logger: log.New(out, "", flag),
+	}, nil
+}
+
+// Fatal writes a FATAL entry, then calls os.Exit(1).
+func (l *LevelLogger) Fatal(args ...interface{}) {
+	l.write(LevelFatal, fmt.Sprint(args...))
+	os.Exit(1)
+}
+
+// Fatalln writes a FATAL entry, then calls os.Exit(1).
+func (l *LevelLogger) Fatalln(args ...interface{}) {
+	l.write(LevelFatal, fmt.Sprintln(args...))
+	os.Exit(1)
+}
+
+// Fatalf writes a FATAL entry, then calls os.Exit(1).
+func (l *LevelLogger) Fatalf(format string, args ...interface{}) {
+	l.write(LevelFatal, fmt.Sprintf(format, args...))
+	os.Exit(1)
+}
+
+// Print writes an entry, the level is defined by the prefix of the message.
+func (l *LevelLogger) Print(args ...interface{}) {
+	l.print(fmt.Sprint(args...))
+}
+
+// Println writes an entry, the level is defined by the prefix of the message.
+func (l *LevelLogger) Println(args ...interface{}) {
+	l.print(fmt.Sprintln(args...))
+}
+
+// Printf writes an entry, the level is defined by the prefix of the message.
+func (l *LevelLogger) Printf(format string, args ...interface{}) {
+	l.print(fmt.Sprintf(format, args...))
+}
+
+func (l *LevelLogger) print(msg string) {
+	for _, p := range levelPrefixes {
+		if strings.HasPrefix(msg, p.prefix) {
+			l.write(p.level, msg)
+			return
+		}
+	}
+
+	l.write(LevelInfo, msg)
+}
+
+func (l *LevelLogger) write(level Level, msg string) {
+	if level < l.level {
+		return
+	}
+
+	if l.format == FormatText {
+		_ = l.logger.Output(4, msg)
+		return
+	}
+
+	entry := jsonEntry{Level: level.String()}
+
+	if l.flag != 0 {
+		entry.Time = time.Now().UTC().Format(time.RFC3339Nano)
+	}
+
+	msg = strings.TrimSuffix(msg, "\n")
+	if level != LevelFatal {
+		msg = strings.TrimPrefix(msg, "["+strings.ToUpper(level.String())+"] ")
+	}
+
+	entry.Domain, entry.Msg = splitDomain(msg)
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, _ = l.out.Write(append(data, '\n'))
+}
+
+// jsonEntry a log entry in the JSON format.
+type jsonEntry struct {
+	Time   string `json:"time,omitempty"`
+	Level  string `json:"level"`
+	Domain string `json:"domain,omitempty"`
+	Msg    string `json:"msg"`
+}
+
+// splitDomain extracts the domain of a message prefixed by "[domain] ".
+func splitDomain(msg string) (string, string) {
+	if !strings.HasPrefix(msg, "[") {
+		return "", msg
+	}
+
+	end := strings.Index(msg, "] ")
+	if end < 0 || strings.ContainsAny(msg[1:end], " \t[") {
+		return "", msg
+	}
+
+	return msg[1:end], msg[end+2:]
+}
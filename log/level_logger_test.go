@@ -0,0 +1,91 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("WARN")
+	require.NoError(t, err)
+	assert.Equal(t, LevelWarn, level)
+
+	_, err = ParseLevel("fatal")
+	require.EqualError(t, err, "unsupported log level: fatal (supported: debug, info, warn, error)")
+}
+
+func TestNewLevelLogger_unsupportedFormat(t *testing.T) {
+	_, err := NewLevelLogger(&bytes.Buffer{}, "xml", LevelInfo, 0)
+	require.EqualError(t, err, "unsupported log format: xml (supported: text, json)")
+}
+
+func TestLevelLogger_text(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	logger, err := NewLevelLogger(buf, FormatText, LevelInfo, 0)
+	require.NoError(t, err)
+
+	logger.Printf("[DEBUG] apidebug: %s", "GET /")
+	logger.Printf("[INFO] [%s] acme: Obtaining bundled SAN certificate", "example.com")
+	logger.Println("[WARN] foo")
+	logger.Print("bar")
+
+	expected := "[INFO] [example.com] acme: Obtaining bundled SAN certificate\n[WARN] foo\nbar\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestLevelLogger_json(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	logger, err := NewLevelLogger(buf, FormatJSON, LevelDebug, 0)
+	require.NoError(t, err)
+
+	logger.Printf("[DEBUG] apidebug: request:\n%s", "GET / HTTP/1.1")
+	logger.Printf("[INFO] [%s] acme: Obtaining bundled SAN certificate", "*.example.com")
+	logger.Println("[WARN] [example.com] acme: error cleaning up: timeout")
+	logger.Print("[example.com] dry-run: the certificate doesn't need to be renewed")
+	logger.Print("[ERROR] foo bar")
+
+	expected := `{"level":"debug","msg":"apidebug: request:\nGET / HTTP/1.1"}
+{"level":"info","domain":"*.example.com","msg":"acme: Obtaining bundled SAN certificate"}
+{"level":"warn","domain":"example.com","msg":"acme: error cleaning up: timeout"}
+{"level":"info","domain":"example.com","msg":"dry-run: the certificate doesn't need to be renewed"}
+{"level":"error","msg":"foo bar"}
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestLevelLogger_jsonTime(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	logger, err := NewLevelLogger(buf, FormatJSON, LevelWarn, 1)
+	require.NoError(t, err)
+
+	logger.Print("[INFO] foo")
+	assert.Empty(t, buf.String())
+
+	logger.Print("[WARN] foo")
+	assert.Regexp(t, `^\{"time":"[0-9T:.Z-]+","level":"warn","msg":"foo"\}\n$`, buf.String())
+}
+
+func Test_splitDomain(t *testing.T) {
+	testCases := []struct {
+		msg            string
+		expectedDomain string
+		expectedMsg    string
+	}{
+		{msg: "[example.com] foo", expectedDomain: "example.com", expectedMsg: "foo"},
+		{msg: "foo [example.com] bar", expectedMsg: "foo [example.com] bar"},
+		{msg: "[foo bar] baz", expectedMsg: "[foo bar] baz"},
+		{msg: "[example.com]", expectedMsg: "[example.com]"},
+	}
+
+	for _, test := range testCases {
+		domain, msg := splitDomain(test.msg)
+		assert.Equal(t, test.expectedDomain, domain, test.msg)
+		assert.Equal(t, test.expectedMsg, msg, test.msg)
+	}
+}
@@ -0,0 +1,178 @@
+// Package apidebug logs the HTTP requests and responses exchanged with the DNS provider APIs.
+//
+// The logging is enabled by setting LEGO_DEBUG_DNS_API_HTTP_CLIENT to true.
+// The credentials (headers, query parameters, body fields, values of the credential environment variables,
+// and secrets read from files or resolvers) are redacted from the logs.
+package apidebug
+
+import (
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-acme/lego/v3/log"
+	"github.com/go-acme/lego/v3/platform/config/env"
+)
+
+// EnvDebug enables the logging of the DNS provider API calls.
+const EnvDebug = "LEGO_DEBUG_DNS_API_HTTP_CLIENT"
+
+const redacted = "***"
+
+// sensitive matches the names (headers, query parameters, fields, environment variables) holding credentials.
+const sensitive = `(?i:auth|token|key|secret|passw|pwd|signature|session|cookie|tsig|credential)`
+
+var (
+	headerPattern = regexp.MustCompile(`^` + `[\w-]*` + sensitive)
+	paramPattern  = regexp.MustCompile(`([?&;][^=&;#\s]*` + sensitive + `[^=&;#\s]*=)[^&;#\s]*`)
+	jsonPattern   = regexp.MustCompile(`("[^"]*` + sensitive + `[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	formPattern   = regexp.MustCompile(`(^|&)([^=&]*` + sensitive + `[^=&]*=)[^&]*`)
+	xmlPattern    = regexp.MustCompile(`(<[\w:.-]*` + sensitive + `[\w:.-]*>)[^<]*(</)`)
+)
+
+var installOnce sync.Once
+
+// Enabled returns true if the logging of the DNS provider API calls is enabled.
+func Enabled() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv(EnvDebug))
+	return enabled
+}
+
+// Install wraps http.DefaultTransport with a Transport when the logging is enabled.
+// It covers the HTTP clients of the providers which do not define their own transport.
+func Install() {
+	if !Enabled() {
+		return
+	}
+
+	installOnce.Do(func() {
+		http.DefaultTransport = NewTransport(http.DefaultTransport)
+	})
+}
+
+// Transport an http.RoundTripper logging the redacted requests and responses.
+type Transport struct {
+	base    http.RoundTripper
+	secrets []string
+}
+
+// NewTransport creates a Transport, http.DefaultTransport is used when base is nil.
+// The values of the environment variables with a credential name (i.e. CLOUDFLARE_API_KEY, RFC2136_TSIG_SECRET),
+// and the values read from the `_FILE` files or fetched by the secret resolvers (i.e. vault:, awssm:), are redacted wherever they appear.
+func NewTransport(base http.RoundTripper) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &Transport{base: base, secrets: secretsFromEnv()}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	dump, err := httputil.DumpRequestOut(req, true)
+	if err != nil {
+		log.Printf("[DEBUG] apidebug: unable to dump the request: %v", err)
+	} else {
+		log.Printf("[DEBUG] apidebug: request:\n%s", t.Redact(string(dump)))
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		log.Printf("[DEBUG] apidebug: %s %s: %s", req.Method, t.Redact(req.URL.String()), t.Redact(err.Error()))
+		return nil, err
+	}
+
+	dump, err = httputil.DumpResponse(resp, true)
+	if err != nil {
+		log.Printf("[DEBUG] apidebug: unable to dump the response: %v", err)
+	} else {
+		log.Printf("[DEBUG] apidebug: response:\n%s", t.Redact(string(dump)))
+	}
+
+	return resp, nil
+}
+
+// Redact removes the credentials from a dumped request or response.
+func (t *Transport) Redact(dump string) string {
+	for _, secret := range t.allSecrets() {
+		dump = strings.Replace(dump, secret, redacted, -1)
+	}
+
+	head, body := dump, ""
+	if i := strings.Index(dump, "\r\n\r\n"); i >= 0 {
+		head, body = dump[:i], dump[i:]
+	}
+
+	lines := strings.Split(head, "\r\n")
+	for i, line := range lines {
+		if i == 0 {
+			// request or status line
+			lines[i] = paramPattern.ReplaceAllString(line, "${1}"+redacted)
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) == 2 && headerPattern.MatchString(parts[0]) {
+			lines[i] = parts[0] + ": " + redacted
+		}
+	}
+
+	return strings.Join(lines, "\r\n") + redactBody(body)
+}
+
+func redactBody(body string) string {
+	body = jsonPattern.ReplaceAllString(body, `${1}"`+redacted+`"`)
+	body = xmlPattern.ReplaceAllString(body, "${1}"+redacted+"${2}")
+
+	content := strings.TrimSpace(body)
+	if !strings.ContainsAny(content, " \n{}<>") {
+		// form-urlencoded
+		body = strings.Replace(body, content, formPattern.ReplaceAllString(content, "${1}${2}"+redacted), 1)
+	}
+
+	return body
+}
+
+// allSecrets returns the values of the environment variables and the secrets resolved by the env package,
+// the providers resolve their secrets after the creation of the transport.
+func (t *Transport) allSecrets() []string {
+	secrets := append([]string{}, t.secrets...)
+
+	for _, secret := range env.ResolvedSecrets() {
+		// the short values are ignored to avoid redacting everything.
+		if len(secret) >= 6 {
+			secrets = append(secrets, secret)
+		}
+	}
+
+	// the longest values first: a secret can contain another one.
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+
+	return secrets
+}
+
+func secretsFromEnv() []string {
+	namePattern := regexp.MustCompile(sensitive)
+
+	var secrets []string
+	for _, kv := range os.Environ() {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 || parts[0] == EnvDebug || !namePattern.MatchString(parts[0]) {
+			continue
+		}
+
+		// the short values are ignored to avoid redacting everything.
+		if len(parts[1]) < 6 {
+			continue
+		}
+
+		secrets = append(secrets, parts[1])
+	}
+
+	return secrets
+}
@@ -0,0 +1,122 @@
+package apidebug
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	stdlog "log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-acme/lego/v3/log"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport_Redact(t *testing.T) {
+	transport := &Transport{secrets: []string{"tsig-secret-value"}}
+
+	testCases := []struct {
+		desc     string
+		dump     string
+		expected string
+	}{
+		{
+			desc:     "headers",
+			dump:     "GET /zones HTTP/1.1\r\nHost: example.com\r\nAuthorization: Bearer abc\r\nX-Auth-Key: abc\r\nAccept: application/json\r\n\r\n",
+			expected: "GET /zones HTTP/1.1\r\nHost: example.com\r\nAuthorization: ***\r\nX-Auth-Key: ***\r\nAccept: application/json\r\n\r\n",
+		},
+		{
+			desc:     "query parameters",
+			dump:     "GET /update?domains=example&token=abc&txt=value HTTP/1.1\r\nHost: example.com\r\n\r\n",
+			expected: "GET /update?domains=example&token=***&txt=value HTTP/1.1\r\nHost: example.com\r\n\r\n",
+		},
+		{
+			desc:     "JSON body",
+			dump:     "POST /login HTTP/1.1\r\nHost: example.com\r\n\r\n{\"user\":\"foo\",\"password\":\"b\\\"ar\",\"apiKey\": \"abc\"}",
+			expected: "POST /login HTTP/1.1\r\nHost: example.com\r\n\r\n{\"user\":\"foo\",\"password\":\"***\",\"apiKey\": \"***\"}",
+		},
+		{
+			desc:     "form body",
+			dump:     "POST /login HTTP/1.1\r\nHost: example.com\r\n\r\nuser=foo&api_token=abc&ttl=120",
+			expected: "POST /login HTTP/1.1\r\nHost: example.com\r\n\r\nuser=foo&api_token=***&ttl=120",
+		},
+		{
+			desc:     "XML body",
+			dump:     "POST /rpc HTTP/1.1\r\nHost: example.com\r\n\r\n<auth><user>foo</user><Password>bar</Password></auth>",
+			expected: "POST /rpc HTTP/1.1\r\nHost: example.com\r\n\r\n<auth><user>foo</user><Password>***</Password></auth>",
+		},
+		{
+			desc:     "environment values",
+			dump:     "POST /rpc HTTP/1.1\r\nHost: example.com\r\n\r\n{\"data\":\"tsig-secret-value\"}",
+			expected: "POST /rpc HTTP/1.1\r\nHost: example.com\r\n\r\n{\"data\":\"***\"}",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, transport.Redact(test.dump))
+		})
+	}
+}
+
+func TestTransport_RoundTrip(t *testing.T) {
+	defer func(logger log.StdLogger) { log.Logger = logger }(log.Logger)
+
+	buf := &bytes.Buffer{}
+	log.Logger = stdlog.New(buf, "", 0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(rw, `{"id":1,"token":"response-token"}`)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewTransport(nil)}
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"secret":"request-secret"}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer request-token")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	body := &bytes.Buffer{}
+	_, err = body.ReadFrom(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"id":1,"token":"response-token"}`, body.String())
+
+	logs := buf.String()
+	assert.Contains(t, logs, `"id":1`)
+	assert.NotContains(t, logs, "response-token")
+	assert.NotContains(t, logs, "request-secret")
+	assert.NotContains(t, logs, "request-token")
+}
+
+func TestTransport_Redact_resolvedSecrets(t *testing.T) {
+	file, err := ioutil.TempFile("", "lego-apidebug")
+	require.NoError(t, err)
+	defer func() { _ = os.Remove(file.Name()) }()
+
+	_, err = file.WriteString("file-secret-value\n")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	transport := NewTransport(nil)
+
+	defer func(value string) { _ = os.Setenv("TEST_APIDEBUG_TOKEN_FILE", value) }(os.Getenv("TEST_APIDEBUG_TOKEN_FILE"))
+	require.NoError(t, os.Setenv("TEST_APIDEBUG_TOKEN_FILE", file.Name()))
+
+	// the provider reads its credentials after the creation of the transport.
+	assert.Equal(t, "file-secret-value", env.GetOrFile("TEST_APIDEBUG_TOKEN"))
+
+	dump := "POST /rpc HTTP/1.1\r\nHost: example.com\r\n\r\n{\"data\":\"file-secret-value\"}"
+	expected := "POST /rpc HTTP/1.1\r\nHost: example.com\r\n\r\n{\"data\":\"***\"}"
+
+	assert.Equal(t, expected, transport.Redact(dump))
+}
@@ -18,7 +18,11 @@ func Get(names ...string) (map[string]string, error) {
 
 	var missingEnvVars []string
 	for _, envVar := range names {
-		value := GetOrFile(envVar)
+		value, err := getOrFile(envVar)
+		if err != nil {
+			return nil, err
+		}
+
 		if value == "" {
 			missingEnvVars = append(missingEnvVars, envVar)
 		}
@@ -63,7 +67,11 @@ func GetWithFallback(groups ...[]string) (map[string]string, error) {
 			return nil, errors.New("undefined environment variable names")
 		}
 
-		value, envVar := getOneWithFallback(names[0], names[1:]...)
+		value, envVar, err := getOneWithFallback(names[0], names[1:]...)
+		if err != nil {
+			return nil, err
+		}
+
 		if len(value) == 0 {
 			missingEnvVars = append(missingEnvVars, envVar)
 			continue
@@ -78,20 +86,19 @@ func GetWithFallback(groups ...[]string) (map[string]string, error) {
 	return values, nil
 }
 
-func getOneWithFallback(main string, names ...string) (string, string) {
-	value := GetOrFile(main)
-	if len(value) > 0 {
-		return value, main
-	}
+func getOneWithFallback(main string, names ...string) (string, string, error) {
+	for _, name := range append([]string{main}, names...) {
+		value, err := getOrFile(name)
+		if err != nil {
+			return "", main, err
+		}
 
-	for _, name := range names {
-		value := GetOrFile(name)
 		if len(value) > 0 {
-			return value, main
+			return value, main, nil
 		}
 	}
 
-	return "", main
+	return "", main, nil
 }
 
 // GetOrDefaultInt returns the given environment variable value as an integer.
@@ -141,23 +148,40 @@ func GetOrDefaultBool(envVar string, defaultValue bool) bool {
 // GetOrFile Attempts to resolve 'key' as an environment variable.
 // Failing that, it will check to see if '<key>_FILE' exists.
 // If so, it will attempt to read from the referenced file to populate a value.
+// The trailing line breaks of the file are removed.
+//
+// All the environment variables of the DNS providers must be read with this function (directly or through the other functions of this package),
+// to support the `_FILE` suffix (i.e. Docker and Kubernetes secrets).
+//
+// The value can be a reference to a secret managed by a registered resolver (see RegisterResolver).
+// The errors (unreadable file, unresolvable secret) are logged and an empty value is returned:
+// Get and GetWithFallback return these errors.
 func GetOrFile(envVar string) string {
+	value, err := getOrFile(envVar)
+	if err != nil {
+		log.Print(err)
+		return ""
+	}
+
+	return value
+}
+
+func getOrFile(envVar string) (string, error) {
 	envVarValue := os.Getenv(envVar)
 	if envVarValue != "" {
-		return envVarValue
+		return resolve(envVar, envVarValue)
 	}
 
 	fileVar := envVar + "_FILE"
 	fileVarValue := os.Getenv(fileVar)
 	if fileVarValue == "" {
-		return envVarValue
+		return envVarValue, nil
 	}
 
 	fileContents, err := ioutil.ReadFile(fileVarValue)
 	if err != nil {
-		log.Printf("Failed to read the file %s (defined by env var %s): %s", fileVarValue, fileVar, err)
-		return ""
+		return "", fmt.Errorf("failed to read the file %s (defined by env var %s): %v", fileVarValue, fileVar, err)
 	}
 
-	return strings.TrimSuffix(string(fileContents), "\n")
+	return resolve(fileVar, strings.TrimRight(string(fileContents), "\r\n"))
 }
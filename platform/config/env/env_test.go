@@ -1,6 +1,7 @@
 package env
 
 import (
+	"errors"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -300,6 +301,14 @@ func TestGetOrFile_ReadsFiles(t *testing.T) {
 			desc:        "with an empty last line",
 			fileContent: []byte("lego_file\n"),
 		},
+		{
+			desc:        "with a Windows line break",
+			fileContent: []byte("lego_file\r\n"),
+		},
+		{
+			desc:        "with several empty last lines",
+			fileContent: []byte("lego_file\n\n"),
+		},
 	}
 
 	for _, test := range testCases {
@@ -314,7 +323,7 @@ func TestGetOrFile_ReadsFiles(t *testing.T) {
 			require.NoError(t, err)
 			defer os.Remove(file.Name())
 
-			err = ioutil.WriteFile(file.Name(), []byte("lego_file\n"), 0644)
+			err = ioutil.WriteFile(file.Name(), test.fileContent, 0644)
 			require.NoError(t, err)
 
 			err = os.Setenv(varEnvFileName, file.Name())
@@ -324,6 +333,7 @@ func TestGetOrFile_ReadsFiles(t *testing.T) {
 			value := GetOrFile(varEnvName)
 
 			assert.Equal(t, "lego_file", value)
+			assert.Contains(t, ResolvedSecrets(), "lego_file")
 		})
 	}
 }
@@ -356,3 +366,107 @@ func TestGetOrFile_PrefersEnvVars(t *testing.T) {
 
 	assert.Equal(t, "lego_env", value)
 }
+
+func TestGetOrDefaultString_ReadsFiles(t *testing.T) {
+	err := os.Unsetenv("TEST_LEGO_ENV_VAR")
+	require.NoError(t, err)
+
+	file, err := ioutil.TempFile("", "lego")
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+
+	err = ioutil.WriteFile(file.Name(), []byte("lego_file\n"), 0644)
+	require.NoError(t, err)
+
+	err = os.Setenv("TEST_LEGO_ENV_VAR_FILE", file.Name())
+	require.NoError(t, err)
+	defer os.Unsetenv("TEST_LEGO_ENV_VAR_FILE")
+
+	value := GetOrDefaultString("TEST_LEGO_ENV_VAR", "default")
+
+	assert.Equal(t, "lego_file", value)
+}
+
+func TestGetOrFile_resolver(t *testing.T) {
+	RegisterResolver("test", func(reference string) (string, error) {
+		if reference == "error" {
+			return "", errors.New("boom")
+		}
+		return "resolved:" + reference, nil
+	})
+	defer func() {
+		resolversMu.Lock()
+		delete(resolvers, "test")
+		resolversMu.Unlock()
+	}()
+
+	testCases := []struct {
+		desc      string
+		value     string
+		expected  string
+		expectErr string
+	}{
+		{
+			desc:     "reference",
+			value:    "test:secret/data/dns#token",
+			expected: "resolved:secret/data/dns#token",
+		},
+		{
+			desc:     "unknown scheme",
+			value:    "other:value",
+			expected: "other:value",
+		},
+		{
+			desc:     "plain value",
+			value:    "value",
+			expected: "value",
+		},
+		{
+			desc:      "resolver error",
+			value:     "test:error",
+			expectErr: "failed to resolve the secret defined by env var TEST_LEGO_ENV_VAR: test: boom",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			err := os.Setenv("TEST_LEGO_ENV_VAR", test.value)
+			require.NoError(t, err)
+			defer os.Unsetenv("TEST_LEGO_ENV_VAR")
+
+			value := GetOrFile("TEST_LEGO_ENV_VAR")
+
+			assert.Equal(t, test.expected, value)
+
+			values, err := Get("TEST_LEGO_ENV_VAR")
+			if test.expectErr != "" {
+				require.EqualError(t, err, test.expectErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, values["TEST_LEGO_ENV_VAR"])
+
+			values, err = GetWithFallback([]string{"TEST_LEGO_ENV_VAR_MISSING", "TEST_LEGO_ENV_VAR"})
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, values["TEST_LEGO_ENV_VAR_MISSING"])
+		})
+	}
+
+	assert.Contains(t, ResolvedSecrets(), "resolved:secret/data/dns#token")
+	assert.NotContains(t, ResolvedSecrets(), "other:value")
+	assert.NotContains(t, ResolvedSecrets(), "value")
+}
+
+func TestGet_unreadableFile(t *testing.T) {
+	err := os.Setenv("TEST_LEGO_ENV_VAR_FILE", "/does/not/exist")
+	require.NoError(t, err)
+	defer os.Unsetenv("TEST_LEGO_ENV_VAR_FILE")
+
+	_, err = Get("TEST_LEGO_ENV_VAR")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read the file /does/not/exist (defined by env var TEST_LEGO_ENV_VAR_FILE)")
+
+	_, err = GetWithFallback([]string{"TEST_LEGO_ENV_VAR"})
+	require.Error(t, err)
+}
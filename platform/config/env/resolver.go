@@ -0,0 +1,96 @@
+package env
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Resolver fetches the value of a secret from its reference (the value without the scheme).
+type Resolver func(reference string) (string, error)
+
+var (
+	resolversMu sync.RWMutex
+	resolvers   = make(map[string]Resolver)
+)
+
+var (
+	secretsMu sync.RWMutex
+	secrets   = make(map[string]struct{})
+)
+
+// RegisterResolver registers a resolver for the values prefixed by `<scheme>:`.
+//
+//	// FOO_API_KEY=vault:secret/data/dns#token
+//	env.RegisterResolver("vault", func(reference string) (string, error) {
+//		// reference: secret/data/dns#token
+//	})
+//
+// It panics if a resolver is already registered for the scheme.
+func RegisterResolver(scheme string, resolver Resolver) {
+	resolversMu.Lock()
+	defer resolversMu.Unlock()
+
+	if scheme == "" || resolver == nil {
+		panic("env: RegisterResolver with an empty scheme or a nil resolver")
+	}
+
+	if _, dup := resolvers[scheme]; dup {
+		panic(fmt.Sprintf("env: RegisterResolver called twice for scheme %s", scheme))
+	}
+
+	resolvers[scheme] = resolver
+}
+
+// Resolve returns the value of the secret referenced by the value,
+// or the value itself if it's not a reference handled by a registered resolver.
+func Resolve(value string) (string, error) {
+	parts := strings.SplitN(value, ":", 2)
+	if len(parts) != 2 {
+		return value, nil
+	}
+
+	resolversMu.RLock()
+	resolver, ok := resolvers[parts[0]]
+	resolversMu.RUnlock()
+
+	if !ok {
+		return value, nil
+	}
+
+	secret, err := resolver(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("%s: %v", parts[0], err)
+	}
+
+	return secret, nil
+}
+
+// ResolvedSecrets returns the values read from the `_FILE` files and the secrets fetched by the registered resolvers,
+// these values are not in the environment (i.e. to redact them from the logs).
+func ResolvedSecrets() []string {
+	secretsMu.RLock()
+	defer secretsMu.RUnlock()
+
+	var values []string
+	for value := range secrets {
+		values = append(values, value)
+	}
+
+	return values
+}
+
+func resolve(envVar, value string) (string, error) {
+	secret, err := Resolve(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the secret defined by env var %s: %v", envVar, err)
+	}
+
+	if secret != "" && (secret != value || strings.HasSuffix(envVar, "_FILE")) {
+		secretsMu.Lock()
+		secrets[secret] = struct{}{}
+		secretsMu.Unlock()
+	}
+
+	return secret, nil
+}
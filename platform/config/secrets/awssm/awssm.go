@@ -0,0 +1,89 @@
+// Package awssm implements a resolver of environment variable values referencing secrets stored in AWS Secrets Manager.
+//
+//	import _ "github.com/go-acme/lego/v3/platform/config/secrets/awssm"
+//
+//	// CLOUDFLARE_API_KEY=awssm:dns/cloudflare#api_key
+//
+// The AWS credentials and region are resolved with the default credential chain of the AWS SDK.
+package awssm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/go-acme/lego/v3/platform/config/env"
+)
+
+// Scheme the prefix of the values resolved by this package.
+const Scheme = "awssm"
+
+func init() {
+	env.RegisterResolver(Scheme, Resolve)
+}
+
+// Resolve resolves a reference with the form `<secret ID>` or `<secret ID>#<field>` (i.e. `dns/cloudflare#api_key`).
+// When the field is defined, the secret must be a JSON object.
+func Resolve(reference string) (string, error) {
+	sess, err := session.NewSession()
+	if err != nil {
+		return "", err
+	}
+
+	return resolve(secretsmanager.New(sess), reference)
+}
+
+func resolve(client secretsmanageriface.SecretsManagerAPI, reference string) (string, error) {
+	parts := strings.SplitN(reference, "#", 2)
+	if parts[0] == "" {
+		return "", fmt.Errorf("invalid reference %q: the expected format is <secret ID>[#<field>]", reference)
+	}
+
+	output, err := client.GetSecretValue(&secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(parts[0]),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var value string
+	switch {
+	case output.SecretString != nil:
+		value = aws.StringValue(output.SecretString)
+	case output.SecretBinary != nil:
+		value = string(output.SecretBinary)
+	default:
+		return "", errors.New("empty secret " + parts[0])
+	}
+
+	if len(parts) == 1 {
+		return value, nil
+	}
+
+	var data map[string]interface{}
+	err = json.Unmarshal([]byte(value), &data)
+	if err != nil {
+		return "", fmt.Errorf("the secret %s is not a JSON object: %v", parts[0], err)
+	}
+
+	field, ok := data[parts[1]]
+	if !ok {
+		return "", fmt.Errorf("field %q not found in %s", parts[1], parts[0])
+	}
+
+	if s, ok := field.(string); ok {
+		return s, nil
+	}
+
+	raw, err := json.Marshal(field)
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
@@ -0,0 +1,84 @@
+package awssm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockClient struct {
+	secretsmanageriface.SecretsManagerAPI
+	secrets map[string]string
+}
+
+func (m mockClient) GetSecretValue(input *secretsmanager.GetSecretValueInput) (*secretsmanager.GetSecretValueOutput, error) {
+	value, ok := m.secrets[aws.StringValue(input.SecretId)]
+	if !ok {
+		return nil, errors.New("secret not found")
+	}
+
+	return &secretsmanager.GetSecretValueOutput{SecretString: aws.String(value)}, nil
+}
+
+func Test_resolve(t *testing.T) {
+	client := mockClient{secrets: map[string]string{
+		"dns/raw":  "s3cr3t",
+		"dns/json": `{"api_key":"key","ttl":60}`,
+	}}
+
+	testCases := []struct {
+		desc      string
+		reference string
+		expected  string
+		expectErr string
+	}{
+		{
+			desc:      "raw secret",
+			reference: "dns/raw",
+			expected:  "s3cr3t",
+		},
+		{
+			desc:      "JSON field",
+			reference: "dns/json#api_key",
+			expected:  "key",
+		},
+		{
+			desc:      "JSON field not a string",
+			reference: "dns/json#ttl",
+			expected:  "60",
+		},
+		{
+			desc:      "missing field",
+			reference: "dns/json#foo",
+			expectErr: `field "foo" not found in dns/json`,
+		},
+		{
+			desc:      "not a JSON object",
+			reference: "dns/raw#foo",
+			expectErr: "the secret dns/raw is not a JSON object: invalid character 's' looking for beginning of value",
+		},
+		{
+			desc:      "unknown secret",
+			reference: "dns/unknown",
+			expectErr: "secret not found",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			value, err := resolve(client, test.reference)
+			if test.expectErr != "" {
+				require.EqualError(t, err, test.expectErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, value)
+		})
+	}
+}
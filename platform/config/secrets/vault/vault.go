@@ -0,0 +1,148 @@
+// Package vault implements a resolver of environment variable values referencing secrets stored in HashiCorp Vault (KV secrets engine).
+//
+//	import _ "github.com/go-acme/lego/v3/platform/config/secrets/vault"
+//
+//	// CLOUDFLARE_API_KEY=vault:secret/data/dns#cloudflare_key
+//
+// The address of the Vault server and the token are defined by VAULT_ADDR and VAULT_TOKEN.
+package vault
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v3/platform/config/env"
+)
+
+// Scheme the prefix of the values resolved by this package.
+const Scheme = "vault"
+
+func init() {
+	env.RegisterResolver(Scheme, Resolve)
+}
+
+// Client a minimal client for the Vault KV secrets engine.
+type Client struct {
+	Address    string
+	Token      string
+	Namespace  string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a client configured from VAULT_ADDR, VAULT_TOKEN, and VAULT_NAMESPACE.
+func NewClient() (*Client, error) {
+	values, err := env.Get("VAULT_ADDR", "VAULT_TOKEN")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		Address:   values["VAULT_ADDR"],
+		Token:     values["VAULT_TOKEN"],
+		Namespace: env.GetOrFile("VAULT_NAMESPACE"),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("VAULT_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}, nil
+}
+
+// Resolve resolves a reference with the form `<path>#<field>` (i.e. `secret/data/dns#token`).
+func Resolve(reference string) (string, error) {
+	client, err := NewClient()
+	if err != nil {
+		return "", err
+	}
+
+	return client.Resolve(reference)
+}
+
+// Resolve resolves a reference with the form `<path>#<field>` (i.e. `secret/data/dns#token`).
+func (c *Client) Resolve(reference string) (string, error) {
+	parts := strings.SplitN(reference, "#", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("invalid reference %q: the expected format is <path>#<field>", reference)
+	}
+
+	data, err := c.read(parts[0])
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := data[parts[1]]
+	if !ok {
+		return "", fmt.Errorf("field %q not found in %s", parts[1], parts[0])
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	default:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(raw), nil
+	}
+}
+
+// read reads a secret, it supports the KV secrets engine version 1 and 2.
+func (c *Client) read(path string) (map[string]interface{}, error) {
+	endpoint := strings.TrimSuffix(c.Address, "/") + "/v1/" + strings.TrimPrefix(path, "/")
+
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-Vault-Token", c.Token)
+	if c.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.Namespace)
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to read the secret %s: %d: %s", path, resp.StatusCode, string(body))
+	}
+
+	var secret struct {
+		Data map[string]interface{} `json:"data"`
+	}
+
+	err = json.Unmarshal(body, &secret)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the secret %s: %v", path, err)
+	}
+
+	if secret.Data == nil {
+		return nil, errors.New("empty secret " + path)
+	}
+
+	// KV version 2: the data of the secret are in data.data, and the metadata in data.metadata.
+	if data, ok := secret.Data["data"].(map[string]interface{}); ok {
+		if _, ok := secret.Data["metadata"]; ok {
+			return data, nil
+		}
+	}
+
+	return secret.Data, nil
+}
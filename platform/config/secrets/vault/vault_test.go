@@ -0,0 +1,86 @@
+package vault
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Resolve(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/v1/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("X-Vault-Token") != "root" {
+			http.Error(rw, `{"errors":["permission denied"]}`, http.StatusForbidden)
+			return
+		}
+
+		switch req.URL.Path {
+		case "/v1/secret/data/dns":
+			fmt.Fprint(rw, `{"data":{"data":{"token":"kv2"},"metadata":{"version":1}}}`)
+		case "/v1/kv/dns":
+			fmt.Fprint(rw, `{"data":{"token":"kv1","ttl":60}}`)
+		default:
+			http.Error(rw, `{"errors":[]}`, http.StatusNotFound)
+		}
+	})
+
+	client := &Client{Address: server.URL, Token: "root"}
+
+	testCases := []struct {
+		desc      string
+		reference string
+		expected  string
+		expectErr string
+	}{
+		{
+			desc:      "KV version 2",
+			reference: "secret/data/dns#token",
+			expected:  "kv2",
+		},
+		{
+			desc:      "KV version 1",
+			reference: "kv/dns#token",
+			expected:  "kv1",
+		},
+		{
+			desc:      "not a string",
+			reference: "kv/dns#ttl",
+			expected:  "60",
+		},
+		{
+			desc:      "missing field",
+			reference: "kv/dns#foo",
+			expectErr: `field "foo" not found in kv/dns`,
+		},
+		{
+			desc:      "invalid reference",
+			reference: "kv/dns",
+			expectErr: `invalid reference "kv/dns": the expected format is <path>#<field>`,
+		},
+		{
+			desc:      "unknown secret",
+			reference: "kv/unknown#token",
+			expectErr: "unable to read the secret kv/unknown: 404: {\"errors\":[]}\n",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			value, err := client.Resolve(test.reference)
+			if test.expectErr != "" {
+				require.EqualError(t, err, test.expectErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, value)
+		})
+	}
+}
@@ -25,6 +25,7 @@ func SetupFakeAPI() (*http.ServeMux, string, func()) {
 			NewOrderURL:   ts.URL + "/newOrder",
 			RevokeCertURL: ts.URL + "/revokeCert",
 			KeyChangeURL:  ts.URL + "/keyChange",
+			RenewalInfo:   ts.URL + "/renewalInfo",
 		})
 
 		mux.HandleFunc("/nonce", func(w http.ResponseWriter, r *http.Request) {
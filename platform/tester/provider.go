@@ -0,0 +1,190 @@
+package tester
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/go-acme/lego/v3/challenge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const defaultConcurrency = 5
+
+// ProviderSuite Conformance test suite for challenge providers.
+//
+//	var envTest = tester.NewEnvTest("FOO_API_KEY", "FOO_TTL")
+//
+//	func TestConformance(t *testing.T) {
+//		// the provider must target a fake API (httptest.Server).
+//		suite := &tester.ProviderSuite{
+//			NewProvider: func() (challenge.Provider, error) { return NewDNSProvider() },
+//			EnvTest:     envTest,
+//			EnvVars:     map[string]string{"FOO_API_KEY": "secret"},
+//			RequiredEnvVars: []string{"FOO_API_KEY"},
+//		}
+//		suite.Run(t)
+//	}
+type ProviderSuite struct {
+	// NewProvider creates the provider to test.
+	NewProvider func() (challenge.Provider, error)
+
+	// Domain used for the challenges (default: example.com).
+	Domain string
+
+	// Concurrency number of concurrent Present/CleanUp calls (default: 5).
+	Concurrency int
+
+	// IdempotentCleanUp checks that a second CleanUp of the same record succeeds (optional):
+	// most of the providers return an error when the record is already removed.
+	IdempotentCleanUp bool
+
+	// EnvTest manages the environment variables used by NewProvider (optional).
+	EnvTest *EnvTest
+	// EnvVars a valid set of environment variables for NewProvider.
+	EnvVars map[string]string
+	// RequiredEnvVars environment variables without which NewProvider must fail.
+	RequiredEnvVars []string
+}
+
+// Run Runs the conformance tests.
+func (s *ProviderSuite) Run(t *testing.T) {
+	require.NotNil(t, s.NewProvider, "NewProvider is required")
+
+	if s.EnvTest != nil {
+		t.Run("environment", s.testEnvironment)
+	}
+
+	t.Run("timeout", s.testTimeout)
+	t.Run("present and cleanup", s.testPresentCleanUp)
+	t.Run("idempotency", s.testIdempotency)
+	t.Run("concurrency", s.testConcurrency)
+}
+
+func (s *ProviderSuite) testEnvironment(t *testing.T) {
+	defer s.EnvTest.RestoreEnv()
+
+	s.EnvTest.ClearEnv()
+	s.EnvTest.Apply(s.EnvVars)
+
+	provider, err := s.NewProvider()
+	require.NoError(t, err)
+	require.NotNil(t, provider)
+
+	for _, key := range s.RequiredEnvVars {
+		key := key
+		t.Run("missing "+key, func(t *testing.T) {
+			s.EnvTest.ClearEnv()
+			s.EnvTest.Apply(s.EnvVars)
+			s.EnvTest.Apply(map[string]string{key: ""})
+
+			_, err := s.NewProvider()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func (s *ProviderSuite) testTimeout(t *testing.T) {
+	provider := s.newProvider(t)
+
+	p, ok := provider.(challenge.ProviderTimeout)
+	if !ok {
+		t.Skip("the provider doesn't define timeouts")
+	}
+
+	timeout, interval := p.Timeout()
+	assert.True(t, timeout > 0, "the timeout must be positive: %v", timeout)
+	assert.True(t, interval > 0, "the interval must be positive: %v", interval)
+	assert.True(t, interval <= timeout, "the interval (%v) must be lower than the timeout (%v)", interval, timeout)
+}
+
+func (s *ProviderSuite) testPresentCleanUp(t *testing.T) {
+	provider := s.newProvider(t)
+
+	domain := s.domain()
+
+	err := provider.Present(domain, "token", "keyAuth")
+	require.NoError(t, err)
+
+	err = provider.CleanUp(domain, "token", "keyAuth")
+	require.NoError(t, err)
+}
+
+func (s *ProviderSuite) testIdempotency(t *testing.T) {
+	provider := s.newProvider(t)
+
+	domain := s.domain()
+
+	for i := 0; i < 2; i++ {
+		err := provider.Present(domain, "token", "keyAuth")
+		require.NoError(t, err, "Present call %d", i+1)
+	}
+
+	err := provider.CleanUp(domain, "token", "keyAuth")
+	require.NoError(t, err, "CleanUp call 1")
+
+	if !s.IdempotentCleanUp {
+		return
+	}
+
+	err = provider.CleanUp(domain, "token", "keyAuth")
+	require.NoError(t, err, "CleanUp call 2")
+}
+
+func (s *ProviderSuite) testConcurrency(t *testing.T) {
+	provider := s.newProvider(t)
+
+	concurrency := s.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	domain := s.domain()
+
+	call := func(fn func(domain, token, keyAuth string) error) []error {
+		errs := make([]error, concurrency)
+
+		var wg sync.WaitGroup
+		for i := 0; i < concurrency; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = fn(domain, fmt.Sprintf("token%d", i), fmt.Sprintf("keyAuth%d", i))
+			}(i)
+		}
+		wg.Wait()
+
+		return errs
+	}
+
+	for i, err := range call(provider.Present) {
+		assert.NoError(t, err, "concurrent Present call %d", i)
+	}
+
+	for i, err := range call(provider.CleanUp) {
+		assert.NoError(t, err, "concurrent CleanUp call %d", i)
+	}
+}
+
+func (s *ProviderSuite) newProvider(t *testing.T) challenge.Provider {
+	if s.EnvTest != nil {
+		defer s.EnvTest.RestoreEnv()
+
+		s.EnvTest.ClearEnv()
+		s.EnvTest.Apply(s.EnvVars)
+	}
+
+	provider, err := s.NewProvider()
+	require.NoError(t, err)
+	require.NotNil(t, provider)
+
+	return provider
+}
+
+func (s *ProviderSuite) domain() string {
+	if s.Domain == "" {
+		return "example.com"
+	}
+	return s.Domain
+}
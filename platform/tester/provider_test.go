@@ -0,0 +1,106 @@
+package tester_test
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge"
+	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryProvider struct {
+	mu      sync.Mutex
+	records map[string]bool
+}
+
+func newMemoryProvider() (*memoryProvider, error) {
+	if os.Getenv(envVar01) == "" {
+		return nil, errors.New("missing " + envVar01)
+	}
+
+	return &memoryProvider{records: make(map[string]bool)}, nil
+}
+
+func (p *memoryProvider) Present(domain, token, keyAuth string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.records[domain+keyAuth] = true
+	return nil
+}
+
+func (p *memoryProvider) CleanUp(domain, token, keyAuth string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.records, domain+keyAuth)
+	return nil
+}
+
+func (p *memoryProvider) Timeout() (timeout, interval time.Duration) {
+	return time.Minute, time.Second
+}
+
+func TestProviderSuite_Run(t *testing.T) {
+	defer clearEnv()
+
+	var provider *memoryProvider
+
+	suite := &tester.ProviderSuite{
+		NewProvider: func() (challenge.Provider, error) {
+			var err error
+			provider, err = newMemoryProvider()
+			return provider, err
+		},
+		EnvTest:           tester.NewEnvTest(envVar01, envVar02),
+		EnvVars:           map[string]string{envVar01: "A"},
+		RequiredEnvVars:   []string{envVar01},
+		Concurrency:       10,
+		IdempotentCleanUp: true,
+	}
+
+	suite.Run(t)
+
+	assert.Empty(t, provider.records)
+}
+
+// strictProvider returns an error on the clean up of a missing record.
+type strictProvider struct {
+	*memoryProvider
+}
+
+func (p *strictProvider) CleanUp(domain, token, keyAuth string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.records[domain+keyAuth] {
+		return errors.New("record not found")
+	}
+
+	delete(p.records, domain+keyAuth)
+	return nil
+}
+
+func TestProviderSuite_Run_cleanUpNotIdempotent(t *testing.T) {
+	defer clearEnv()
+
+	var provider *strictProvider
+
+	suite := &tester.ProviderSuite{
+		NewProvider: func() (challenge.Provider, error) {
+			p, err := newMemoryProvider()
+			provider = &strictProvider{memoryProvider: p}
+			return provider, err
+		},
+		EnvTest: tester.NewEnvTest(envVar01, envVar02),
+		EnvVars: map[string]string{envVar01: "A"},
+	}
+
+	suite.Run(t)
+
+	assert.Empty(t, provider.records)
+}
@@ -0,0 +1,361 @@
+package tester
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// RecorderModeEnvVar Name of the environment variable used to define the mode of the recorders.
+const RecorderModeEnvVar = "LEGO_RECORDER_MODE"
+
+// Recorder modes.
+const (
+	// ModeReplay replays the recorded interactions, the real API is never called.
+	ModeReplay = "replay"
+	// ModeRecord calls the real API and records the interactions.
+	ModeRecord = "record"
+	// ModeDisabled calls the real API without recording.
+	ModeDisabled = "disabled"
+)
+
+const redacted = "[REDACTED]"
+
+var defaultScrubbedHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Auth-Token",
+	"X-Api-Key",
+	"X-Auth-Key",
+	"X-Auth-Email",
+}
+
+// Interaction A recorded HTTP request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest A recorded HTTP request.
+type RecordedRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// RecordedResponse A recorded HTTP response.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// RecorderOption Recorder option.
+type RecorderOption func(*Recorder)
+
+// WithMode Defines the mode of the recorder (replaces the mode defined by RecorderModeEnvVar).
+func WithMode(mode string) RecorderOption {
+	return func(r *Recorder) {
+		r.mode = mode
+	}
+}
+
+// WithTransport Defines the transport used to call the real API.
+func WithTransport(transport http.RoundTripper) RecorderOption {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithScrubbedHeaders Adds the headers to redact from the recorded interactions.
+func WithScrubbedHeaders(names ...string) RecorderOption {
+	return func(r *Recorder) {
+		r.scrubbedHeaders = append(r.scrubbedHeaders, names...)
+	}
+}
+
+// WithScrubbedQueryParams Adds the query parameters to redact from the recorded interactions.
+func WithScrubbedQueryParams(names ...string) RecorderOption {
+	return func(r *Recorder) {
+		r.scrubbedParams = append(r.scrubbedParams, names...)
+	}
+}
+
+// WithScrubbedValues Adds secret values (API keys, passwords, ...) to redact everywhere in the recorded interactions.
+// Empty values are ignored.
+func WithScrubbedValues(values ...string) RecorderOption {
+	return func(r *Recorder) {
+		for _, value := range values {
+			if value != "" {
+				r.scrubbedValues = append(r.scrubbedValues, value)
+			}
+		}
+	}
+}
+
+// Recorder A http.RoundTripper that records the HTTP interactions with an API (with secrets scrubbed) into a cassette file,
+// and replays them later without calling the API.
+//
+//	recorder, err := tester.NewRecorder("fixtures/present.json", tester.WithScrubbedValues(envTest.GetValue("FOO_API_KEY")))
+//	require.NoError(t, err)
+//	defer func() { require.NoError(t, recorder.Stop()) }()
+//
+//	config.HTTPClient = recorder.Client()
+//
+// The mode is defined by the environment variable LEGO_RECORDER_MODE (replay by default).
+type Recorder struct {
+	path      string
+	mode      string
+	transport http.RoundTripper
+
+	scrubbedHeaders []string
+	scrubbedParams  []string
+	scrubbedValues  []string
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	used         []bool
+}
+
+// NewRecorder Creates a recorder using the cassette file path.
+func NewRecorder(path string, opts ...RecorderOption) (*Recorder, error) {
+	r := &Recorder{
+		path:            path,
+		mode:            os.Getenv(RecorderModeEnvVar),
+		transport:       http.DefaultTransport,
+		scrubbedHeaders: defaultScrubbedHeaders,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.mode == "" {
+		r.mode = ModeReplay
+	}
+
+	switch r.mode {
+	case ModeReplay:
+		err := r.load()
+		if err != nil {
+			return nil, err
+		}
+	case ModeRecord, ModeDisabled:
+	default:
+		return nil, fmt.Errorf("recorder: unknown mode: %s", r.mode)
+	}
+
+	return r, nil
+}
+
+// Mode Gets the mode of the recorder.
+func (r *Recorder) Mode() string {
+	return r.mode
+}
+
+// Client Creates a HTTP client using the recorder.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip Implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch r.mode {
+	case ModeReplay:
+		return r.replay(req, body)
+	case ModeRecord:
+		return r.record(req, body)
+	default:
+		return r.transport.RoundTrip(req)
+	}
+}
+
+// Stop Saves the recorded interactions into the cassette file (record mode only).
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(r.path), 0755)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(r.path, data, 0644)
+}
+
+func (r *Recorder) load() error {
+	data, err := ioutil.ReadFile(r.path)
+	if err != nil {
+		return fmt.Errorf("recorder: unable to read the cassette: %v", err)
+	}
+
+	err = json.Unmarshal(data, &r.interactions)
+	if err != nil {
+		return fmt.Errorf("recorder: unable to parse the cassette %s: %v", r.path, err)
+	}
+
+	r.used = make([]bool, len(r.interactions))
+
+	return nil
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	recorded := r.newRecordedRequest(req, body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.interactions {
+		if r.used[i] || !matchRequest(interaction.Request, recorded) {
+			continue
+		}
+
+		r.used[i] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        cloneHeader(interaction.Response.Headers),
+			Body:          ioutil.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("recorder: no recorded interaction for %s %s", recorded.Method, recorded.URL)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	interaction := &Interaction{
+		Request: r.newRecordedRequest(req, body),
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    r.scrubHeaders(resp.Header),
+			Body:       r.scrub(string(respBody)),
+		},
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, interaction)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+func (r *Recorder) newRecordedRequest(req *http.Request, body []byte) RecordedRequest {
+	return RecordedRequest{
+		Method:  req.Method,
+		URL:     r.scrubURL(req.URL),
+		Headers: r.scrubHeaders(req.Header),
+		Body:    r.scrub(string(body)),
+	}
+}
+
+func (r *Recorder) scrubURL(uri *url.URL) string {
+	u := *uri
+	u.User = nil
+
+	query := u.Query()
+	for _, param := range r.scrubbedParams {
+		if _, ok := query[param]; ok {
+			query.Set(param, redacted)
+		}
+	}
+	u.RawQuery = query.Encode()
+
+	return r.scrub(u.String())
+}
+
+func (r *Recorder) scrubHeaders(headers http.Header) http.Header {
+	scrubbed := make(http.Header)
+
+	for key, values := range headers {
+		for _, value := range values {
+			scrubbed.Add(key, r.scrub(value))
+		}
+	}
+
+	for _, name := range r.scrubbedHeaders {
+		if _, ok := scrubbed[http.CanonicalHeaderKey(name)]; ok {
+			scrubbed.Set(name, redacted)
+		}
+	}
+
+	return scrubbed
+}
+
+func (r *Recorder) scrub(value string) string {
+	for _, secret := range r.scrubbedValues {
+		value = strings.Replace(value, secret, redacted, -1)
+		value = strings.Replace(value, url.QueryEscape(secret), redacted, -1)
+	}
+	return value
+}
+
+func matchRequest(recorded, req RecordedRequest) bool {
+	return recorded.Method == req.Method && recorded.URL == req.URL && recorded.Body == req.Body
+}
+
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, errors.New("recorder: unable to read the request body")
+	}
+
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+func cloneHeader(headers http.Header) http.Header {
+	clone := make(http.Header, len(headers))
+	for key, values := range headers {
+		clone[key] = append([]string(nil), values...)
+	}
+	return clone
+}
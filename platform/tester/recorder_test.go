@@ -0,0 +1,111 @@
+package tester_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder_recordAndReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-recorder")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	cassette := filepath.Join(dir, "fixtures", "cassette.json")
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("X-Api-Key") != "s3cr3t" {
+			http.Error(rw, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		body, _ := ioutil.ReadAll(req.Body)
+		rw.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(rw, `{"method":%q,"body":%q,"token":"s3cr3t"}`, req.Method, string(body))
+	}))
+
+	// record
+	recorder, err := tester.NewRecorder(cassette, tester.WithMode(tester.ModeRecord), tester.WithScrubbedValues("s3cr3t"))
+	require.NoError(t, err)
+
+	recorded := doRequests(t, recorder.Client(), server.URL)
+	require.NoError(t, recorder.Stop())
+
+	server.Close()
+
+	data, err := ioutil.ReadFile(cassette)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "s3cr3t")
+	assert.Contains(t, string(data), "[REDACTED]")
+
+	// replay
+	recorder, err = tester.NewRecorder(cassette, tester.WithMode(tester.ModeReplay))
+	require.NoError(t, err)
+
+	replayed := doRequests(t, recorder.Client(), server.URL)
+
+	expected := []string{
+		`{"method":"GET","body":"","token":"[REDACTED]"}`,
+		`{"method":"POST","body":"value=1","token":"[REDACTED]"}`,
+	}
+	assert.Equal(t, expected, replayed)
+	assert.Equal(t, len(recorded), len(replayed))
+
+	// all the interactions have been used.
+	_, err = recorder.Client().Get(server.URL + "/records")
+	assert.Error(t, err)
+}
+
+func TestNewRecorder_replayMissingCassette(t *testing.T) {
+	_, err := tester.NewRecorder(filepath.Join("fixtures", "missing.json"), tester.WithMode(tester.ModeReplay))
+	assert.Error(t, err)
+}
+
+func TestNewRecorder_unknownMode(t *testing.T) {
+	_, err := tester.NewRecorder("cassette.json", tester.WithMode("foo"))
+	assert.EqualError(t, err, "recorder: unknown mode: foo")
+}
+
+func doRequests(t *testing.T, client *http.Client, baseURL string) []string {
+	t.Helper()
+
+	var bodies []string
+
+	req, err := http.NewRequest(http.MethodGet, baseURL+"/records", nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Api-Key", "s3cr3t")
+
+	bodies = append(bodies, do(t, client, req))
+
+	req, err = http.NewRequest(http.MethodPost, baseURL+"/records", strings.NewReader("value=1"))
+	require.NoError(t, err)
+	req.Header.Set("X-Api-Key", "s3cr3t")
+
+	bodies = append(bodies, do(t, client, req))
+
+	return bodies
+}
+
+func do(t *testing.T, client *http.Client, req *http.Request) string {
+	t.Helper()
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return string(body)
+}
@@ -5,6 +5,7 @@ package acmedns
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/cpu/goacmedns"
 	"github.com/go-acme/lego/v3/challenge/dns01"
@@ -20,6 +21,9 @@ const (
 	// storagePathEnvVar is the environment variable name for the ACME-DNS JSON account data file.
 	// A per-domain account will be registered/persisted to this file and used for TXT updates.
 	storagePathEnvVar = envNamespace + "STORAGE_PATH"
+	// allowFromEnvVar is the environment variable name for the comma separated list of CIDR ranges
+	// allowed to update the TXT records of the accounts registered by the provider.
+	allowFromEnvVar = envNamespace + "ALLOW_FROM"
 )
 
 // acmeDNSClient is an interface describing the goacmedns.Client functions the DNSProvider uses.
@@ -38,6 +42,9 @@ type acmeDNSClient interface {
 type DNSProvider struct {
 	client  acmeDNSClient
 	storage goacmedns.Storage
+
+	// AllowFrom restricts the updates of the registered accounts to these CIDR ranges.
+	AllowFrom []string
 }
 
 // NewDNSProvider creates an ACME-DNS provider using file based account storage.
@@ -50,7 +57,19 @@ func NewDNSProvider() (*DNSProvider, error) {
 
 	client := goacmedns.NewClient(values[apiBaseEnvVar])
 	storage := goacmedns.NewFileStorage(values[storagePathEnvVar], 0600)
-	return NewDNSProviderClient(client, storage)
+
+	provider, err := NewDNSProviderClient(client, storage)
+	if err != nil {
+		return nil, err
+	}
+
+	if allowFrom := env.GetOrFile(allowFromEnvVar); allowFrom != "" {
+		for _, cidr := range strings.Split(allowFrom, ",") {
+			provider.AllowFrom = append(provider.AllowFrom, strings.TrimSpace(cidr))
+		}
+	}
+
+	return provider, nil
 }
 
 // NewDNSProviderClient creates an ACME-DNS DNSProvider with the given acmeDNSClient and goacmedns.Storage.
@@ -135,8 +154,7 @@ func (d *DNSProvider) CleanUp(_, _, _ string) error {
 // the one-time manual CNAME setup required to complete setup of the ACME-DNS hook for the domain.
 // If any other error occurs it is returned as-is.
 func (d *DNSProvider) register(domain, fqdn string) error {
-	// TODO(@cpu): Read CIDR whitelists from the environment
-	newAcct, err := d.client.RegisterAccount(nil)
+	newAcct, err := d.client.RegisterAccount(d.AllowFrom)
 	if err != nil {
 		return err
 	}
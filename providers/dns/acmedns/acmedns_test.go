@@ -46,6 +46,19 @@ func (c mockClient) RegisterAccount(_ []string) (goacmedns.Account, error) {
 	return c.mockAccount, nil
 }
 
+// mockAllowFromClient is a mock implementing the acmeDNSClient interface that
+// tracks the allowFrom restriction used to register accounts.
+type mockAllowFromClient struct {
+	mockClient
+	allowFrom *[]string
+}
+
+// RegisterAccount saves the allowFrom restriction and returns c.mockAccount.
+func (c mockAllowFromClient) RegisterAccount(allowFrom []string) (goacmedns.Account, error) {
+	*c.allowFrom = allowFrom
+	return c.mockAccount, nil
+}
+
 // mockUpdateClient is a mock implementing the acmeDNSClient interface that
 // tracks the calls to UpdateTXTRecord in the records map.
 type mockUpdateClient struct {
@@ -276,3 +289,19 @@ func TestRegister(t *testing.T) {
 		})
 	}
 }
+
+// TestRegister_allowFrom tests that the allowFrom restriction is used to register accounts.
+func TestRegister_allowFrom(t *testing.T) {
+	var allowFrom []string
+	client := mockAllowFromClient{mockClient: mockClient{egTestAccount}, allowFrom: &allowFrom}
+
+	dp, err := NewDNSProviderClient(client, mockStorage{make(map[string]goacmedns.Account)})
+	require.NoError(t, err)
+
+	dp.AllowFrom = []string{"192.168.0.0/24", "10.0.0.1/32"}
+
+	err = dp.register(egDomain, egFQDN)
+	require.IsType(t, ErrCNAMERequired{}, err)
+
+	assert.Equal(t, []string{"192.168.0.0/24", "10.0.0.1/32"}, allowFrom)
+}
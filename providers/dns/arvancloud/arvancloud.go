@@ -0,0 +1,191 @@
+// Package arvancloud implements a DNS provider for solving the DNS-01 challenge using ArvanCloud DNS.
+package arvancloud
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/arvancloud/internal"
+)
+
+// minTTL the minimal TTL allowed by ArvanCloud.
+const minTTL = 120
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL            string
+	APIKey             string
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString("ARVANCLOUD_ENDPOINT", internal.DefaultBaseURL),
+		TTL:                env.GetOrDefaultInt("ARVANCLOUD_TTL", minTTL),
+		PropagationTimeout: env.GetOrDefaultSecond("ARVANCLOUD_PROPAGATION_TIMEOUT", 120*time.Second),
+		PollingInterval:    env.GetOrDefaultSecond("ARVANCLOUD_POLLING_INTERVAL", 2*time.Second),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("ARVANCLOUD_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses the ArvanCloud CDN API to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	recordIDs   map[string]string
+	recordIDsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for ArvanCloud.
+// Credentials must be passed in the environment variable: ARVANCLOUD_API_KEY.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("ARVANCLOUD_API_KEY")
+	if err != nil {
+		return nil, fmt.Errorf("arvancloud: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIKey = values["ARVANCLOUD_API_KEY"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for ArvanCloud.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("arvancloud: the configuration of the DNS provider is nil")
+	}
+
+	if config.APIKey == "" {
+		return nil, errors.New("arvancloud: credentials missing")
+	}
+
+	if config.TTL < minTTL {
+		return nil, fmt.Errorf("arvancloud: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	}
+
+	client := internal.NewClient(config.APIKey)
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{
+		config:    config,
+		client:    client,
+		recordIDs: make(map[string]string),
+	}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("arvancloud: %v", err)
+	}
+
+	record := internal.DNSRecord{
+		Type:  "txt",
+		Name:  extractRecordName(fqdn, zone),
+		Value: internal.TXTValue{Text: value},
+		TTL:   d.config.TTL,
+	}
+
+	newRecord, err := d.client.CreateRecord(zone, record)
+	if err != nil {
+		return fmt.Errorf("arvancloud: %v", err)
+	}
+
+	d.recordIDsMu.Lock()
+	d.recordIDs[token] = newRecord.ID
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	// gets the record's unique ID from when we created it
+	d.recordIDsMu.Lock()
+	recordID, ok := d.recordIDs[token]
+	d.recordIDsMu.Unlock()
+	if !ok {
+		return fmt.Errorf("arvancloud: unknown record ID for '%s'", fqdn)
+	}
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("arvancloud: %v", err)
+	}
+
+	err = d.client.DeleteRecord(zone, recordID)
+	if err != nil {
+		return fmt.Errorf("arvancloud: %v", err)
+	}
+
+	// deletes record ID from map
+	d.recordIDsMu.Lock()
+	delete(d.recordIDs, token)
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// findZone returns the longest domain of the account matching the FQDN.
+func (d *DNSProvider) findZone(fqdn string) (string, error) {
+	domains, err := d.client.GetDomains()
+	if err != nil {
+		return "", err
+	}
+
+	name := dns01.UnFqdn(fqdn)
+
+	var zone string
+	for _, domain := range domains {
+		if (name == domain.Domain || strings.HasSuffix(name, "."+domain.Domain)) && len(domain.Domain) > len(zone) {
+			zone = domain.Domain
+		}
+	}
+
+	if zone == "" {
+		return "", fmt.Errorf("no domain found for %s", fqdn)
+	}
+
+	return zone, nil
+}
+
+func extractRecordName(fqdn, zone string) string {
+	name := dns01.UnFqdn(fqdn)
+	if idx := strings.LastIndex(name, "."+zone); idx != -1 {
+		return name[:idx]
+	}
+	return name
+}
@@ -0,0 +1,169 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// DefaultBaseURL the default base URL of the ArvanCloud CDN API.
+const DefaultBaseURL = "https://napi.arvancloud.com/cdn/4.0"
+
+// defaultPerPage the number of domains by page.
+const defaultPerPage = 100
+
+// Domain a domain (DNS zone).
+type Domain struct {
+	ID     string `json:"id"`
+	Domain string `json:"domain"`
+	Name   string `json:"name"`
+}
+
+// Meta the pagination information of a listing.
+type Meta struct {
+	CurrentPage int `json:"current_page"`
+	LastPage    int `json:"last_page"`
+	PerPage     int `json:"per_page"`
+	Total       int `json:"total"`
+}
+
+// DomainsResponse a page of domains.
+type DomainsResponse struct {
+	Data []Domain `json:"data"`
+	Meta Meta     `json:"meta"`
+}
+
+// TXTValue the value of a TXT record.
+type TXTValue struct {
+	Text string `json:"text"`
+}
+
+// DNSRecord a DNS record.
+type DNSRecord struct {
+	ID    string      `json:"id,omitempty"`
+	Type  string      `json:"type"`
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+	TTL   int         `json:"ttl,omitempty"`
+	Cloud bool        `json:"cloud"`
+}
+
+type recordResponse struct {
+	Data DNSRecord `json:"data"`
+}
+
+type apiError struct {
+	Message string `json:"message"`
+}
+
+// Client the ArvanCloud CDN API client.
+type Client struct {
+	apiKey     string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates an ArvanCloud CDN API client.
+// The API key is sent as is in the Authorization header (i.e. "Apikey xxxx").
+func NewClient(apiKey string) *Client {
+	return &Client{
+		apiKey:     apiKey,
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// GetDomains returns all the domains of the account, the domains are listed page by page.
+func (c *Client) GetDomains() ([]Domain, error) {
+	var domains []Domain
+
+	for page := 1; ; page++ {
+		query := url.Values{}
+		query.Set("page", strconv.Itoa(page))
+		query.Set("per_page", strconv.Itoa(defaultPerPage))
+
+		resp := &DomainsResponse{}
+		err := c.do(http.MethodGet, "/domains?"+query.Encode(), nil, resp)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get domains: %v", err)
+		}
+
+		domains = append(domains, resp.Data...)
+
+		if len(resp.Data) == 0 || page >= resp.Meta.LastPage {
+			return domains, nil
+		}
+	}
+}
+
+// CreateRecord creates a DNS record in a domain.
+func (c *Client) CreateRecord(domain string, record DNSRecord) (*DNSRecord, error) {
+	resp := &recordResponse{}
+	err := c.do(http.MethodPost, fmt.Sprintf("/domains/%s/dns-records", domain), record, resp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create record %s in %s: %v", record.Name, domain, err)
+	}
+
+	return &resp.Data, nil
+}
+
+// DeleteRecord deletes a DNS record of a domain.
+func (c *Client) DeleteRecord(domain, recordID string) error {
+	err := c.do(http.MethodDelete, fmt.Sprintf("/domains/%s/dns-records/%s", domain, recordID), nil, nil)
+	if err != nil {
+		return fmt.Errorf("unable to delete record %s in %s: %v", recordID, domain, err)
+	}
+
+	return nil
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body bytes.Buffer
+	if payload != nil {
+		err := json.NewEncoder(&body).Encode(payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+uri, &body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", c.apiKey)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &apiError{}
+		if json.Unmarshal(raw, apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("%d: %s", resp.StatusCode, apiErr.Message)
+		}
+
+		return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest() (*Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client := NewClient("Apikey secret")
+	client.BaseURL = server.URL
+
+	return client, mux, server.Close
+}
+
+func checkAuth(rw http.ResponseWriter, req *http.Request) bool {
+	if req.Header.Get("Authorization") != "Apikey secret" {
+		rw.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(rw, `{"message":"Unauthenticated."}`)
+		return false
+	}
+	return true
+}
+
+func TestClient_GetDomains(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/domains", func(rw http.ResponseWriter, req *http.Request) {
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		switch req.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(rw, `{"data":[{"id":"a","domain":"example.com"}],"meta":{"current_page":1,"last_page":2}}`)
+		case "2":
+			fmt.Fprint(rw, `{"data":[{"id":"b","domain":"example.org"}],"meta":{"current_page":2,"last_page":2}}`)
+		default:
+			http.Error(rw, "unexpected page", http.StatusBadRequest)
+		}
+	})
+
+	domains, err := client.GetDomains()
+	require.NoError(t, err)
+
+	expected := []Domain{{ID: "a", Domain: "example.com"}, {ID: "b", Domain: "example.org"}}
+	assert.Equal(t, expected, domains)
+}
+
+func TestClient_GetDomains_error(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	client.apiKey = "Apikey invalid"
+
+	mux.HandleFunc("/domains", func(rw http.ResponseWriter, req *http.Request) {
+		checkAuth(rw, req)
+	})
+
+	_, err := client.GetDomains()
+	require.EqualError(t, err, "unable to get domains: 401: Unauthenticated.")
+}
+
+func TestClient_CreateRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/domains/example.com/dns-records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := `{"type":"txt","name":"_acme-challenge","value":{"text":"abc"},"ttl":120,"cloud":false}` + "\n"
+		if string(body) != expected {
+			http.Error(rw, fmt.Sprintf("unexpected body: %s", string(body)), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		fmt.Fprint(rw, `{"data":{"id":"42","type":"txt","name":"_acme-challenge","value":{"text":"abc"},"ttl":120}}`)
+	})
+
+	record := DNSRecord{Type: "txt", Name: "_acme-challenge", Value: TXTValue{Text: "abc"}, TTL: 120}
+
+	newRecord, err := client.CreateRecord("example.com", record)
+	require.NoError(t, err)
+
+	assert.Equal(t, "42", newRecord.ID)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/domains/example.com/dns-records/42", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		fmt.Fprint(rw, `{"message":"DNS record deleted"}`)
+	})
+
+	err := client.DeleteRecord("example.com", "42")
+	require.NoError(t, err)
+}
@@ -3,7 +3,8 @@
 package azure
 
 import (
-	"context"
+	"crypto/rsa"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -11,14 +12,14 @@ import (
 	"strings"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2017-09-01/dns"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/adal"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
-	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/go-acme/lego/v3/challenge"
 	"github.com/go-acme/lego/v3/challenge/dns01"
 	"github.com/go-acme/lego/v3/platform/config/env"
+	"golang.org/x/crypto/pkcs12"
 )
 
 const defaultMetadataEndpoint = "http://169.254.169.254"
@@ -30,11 +31,22 @@ type Config struct {
 	ClientSecret string
 	TenantID     string
 
+	// AuthMethod forces the authentication method: "env" (client secret or environment), "msi" or "cert".
+	// By default, the method is chosen according to the defined credentials.
+	AuthMethod string
+
+	// ClientCertificatePath is the path of the PKCS#12 file (.pfx) containing the client certificate and its RSA private key.
+	ClientCertificatePath     string
+	ClientCertificatePassword string
+
 	SubscriptionID string
 	ResourceGroup  string
 
 	MetadataEndpoint string
 
+	// PrivateZone targets the Azure Private DNS zones instead of the public DNS zones.
+	PrivateZone bool
+
 	PropagationTimeout time.Duration
 	PollingInterval    time.Duration
 	TTL                int
@@ -48,23 +60,32 @@ func NewDefaultConfig() *Config {
 		PropagationTimeout: env.GetOrDefaultSecond("AZURE_PROPAGATION_TIMEOUT", 2*time.Minute),
 		PollingInterval:    env.GetOrDefaultSecond("AZURE_POLLING_INTERVAL", 2*time.Second),
 		MetadataEndpoint:   env.GetOrFile("AZURE_METADATA_ENDPOINT"),
+		AuthMethod:         env.GetOrFile("AZURE_AUTH_METHOD"),
+		PrivateZone:        env.GetOrDefaultBool("AZURE_PRIVATE_ZONE", false),
 	}
 }
 
 // DNSProvider is an implementation of the acme.ChallengeProvider interface
 type DNSProvider struct {
-	config     *Config
-	authorizer autorest.Authorizer
+	config   *Config
+	provider challenge.ProviderTimeout
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for azure.
 // Credentials can be passed in the environment variables:
 // AZURE_CLIENT_ID, AZURE_CLIENT_SECRET, AZURE_SUBSCRIPTION_ID, AZURE_TENANT_ID, AZURE_RESOURCE_GROUP
+// or AZURE_CLIENT_ID, AZURE_TENANT_ID, AZURE_CERTIFICATE_PATH, AZURE_CERTIFICATE_PASSWORD for a client certificate.
 // If the credentials are _not_ set via the environment,
 // then it will attempt to get a bearer token via the instance metadata service.
 // see: https://github.com/Azure/go-autorest/blob/v10.14.0/autorest/azure/auth/auth.go#L38-L42
+// AZURE_AUTH_METHOD=msi forces the use of a managed identity (user-assigned if AZURE_CLIENT_ID is defined).
 func NewDNSProvider() (*DNSProvider, error) {
 	config := NewDefaultConfig()
+	config.ClientID = env.GetOrFile("AZURE_CLIENT_ID")
+	config.ClientSecret = env.GetOrFile("AZURE_CLIENT_SECRET")
+	config.TenantID = env.GetOrFile("AZURE_TENANT_ID")
+	config.ClientCertificatePath = env.GetOrFile("AZURE_CERTIFICATE_PATH")
+	config.ClientCertificatePassword = env.GetOrFile("AZURE_CERTIFICATE_PASSWORD")
 	config.SubscriptionID = env.GetOrFile("AZURE_SUBSCRIPTION_ID")
 	config.ResourceGroup = env.GetOrFile("AZURE_RESOURCE_GROUP")
 
@@ -110,132 +131,139 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		config.ResourceGroup = resGroup
 	}
 
-	return &DNSProvider{config: config, authorizer: authorizer}, nil
+	if config.PrivateZone {
+		return &DNSProvider{config: config, provider: &dnsProviderPrivate{config: config, authorizer: authorizer}}, nil
+	}
+
+	return &DNSProvider{config: config, provider: &dnsProviderPublic{config: config, authorizer: authorizer}}, nil
 }
 
 // Timeout returns the timeout and interval to use when checking for DNS propagation.
 // Adjusting here to cope with spikes in propagation times.
 func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
-	return d.config.PropagationTimeout, d.config.PollingInterval
+	return d.provider.Timeout()
 }
 
 // Present creates a TXT record to fulfill the dns-01 challenge
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
-	ctx := context.Background()
-	fqdn, value := dns01.GetRecord(domain, keyAuth)
+	return d.provider.Present(domain, token, keyAuth)
+}
 
-	zone, err := d.getHostedZoneID(ctx, fqdn)
-	if err != nil {
-		return fmt.Errorf("azure: %v", err)
-	}
+// CleanUp removes the TXT record matching the specified parameters
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	return d.provider.CleanUp(domain, token, keyAuth)
+}
 
-	rsc := dns.NewRecordSetsClient(d.config.SubscriptionID)
-	rsc.Authorizer = d.authorizer
+// Returns the relative record to the domain
+func toRelativeRecord(domain, zone string) string {
+	return dns01.UnFqdn(strings.TrimSuffix(domain, zone))
+}
 
-	relative := toRelativeRecord(fqdn, dns01.ToFqdn(zone))
+func getAuthorizer(config *Config) (autorest.Authorizer, error) {
+	switch strings.ToLower(config.AuthMethod) {
+	case "msi":
+		return getMSIAuthorizer(config)
+	case "cert":
+		return getCertificateAuthorizer(config)
+	case "", "env":
+	default:
+		return nil, fmt.Errorf("azure: unsupported authentication method: %s", config.AuthMethod)
+	}
 
-	// Get existing record set
-	rset, err := rsc.Get(ctx, d.config.ResourceGroup, zone, relative, dns.TXT)
-	if err != nil {
-		detailedError, ok := err.(autorest.DetailedError)
-		if !ok || detailedError.StatusCode != http.StatusNotFound {
-			return fmt.Errorf("azure: %v", err)
+	if config.ClientID != "" && config.ClientSecret != "" && config.TenantID != "" {
+		oauthConfig, err := adal.NewOAuthConfig(azure.PublicCloud.ActiveDirectoryEndpoint, config.TenantID)
+		if err != nil {
+			return nil, err
 		}
-	}
 
-	// Construct unique TXT records using map
-	uniqRecords := map[string]struct{}{value: {}}
-	if rset.RecordSetProperties != nil && rset.TxtRecords != nil {
-		for _, txtRecord := range *rset.TxtRecords {
-			// Assume Value doesn't contain multiple strings
-			if txtRecord.Value != nil && len(*txtRecord.Value) > 0 {
-				uniqRecords[(*txtRecord.Value)[0]] = struct{}{}
-			}
+		spt, err := adal.NewServicePrincipalToken(*oauthConfig, config.ClientID, config.ClientSecret, azure.PublicCloud.ResourceManagerEndpoint)
+		if err != nil {
+			return nil, err
 		}
-	}
 
-	var txtRecords []dns.TxtRecord
-	for txt := range uniqRecords {
-		txtRecords = append(txtRecords, dns.TxtRecord{Value: &[]string{txt}})
+		spt.SetSender(config.HTTPClient)
+		return autorest.NewBearerAuthorizer(spt), nil
 	}
 
-	rec := dns.RecordSet{
-		Name: &relative,
-		RecordSetProperties: &dns.RecordSetProperties{
-			TTL:        to.Int64Ptr(int64(d.config.TTL)),
-			TxtRecords: &txtRecords,
-		},
+	if config.ClientCertificatePath != "" && config.AuthMethod == "" {
+		return getCertificateAuthorizer(config)
 	}
 
-	_, err = rsc.CreateOrUpdate(ctx, d.config.ResourceGroup, zone, relative, dns.TXT, rec, "", "")
-	if err != nil {
-		return fmt.Errorf("azure: %v", err)
-	}
-	return nil
+	return auth.NewAuthorizerFromEnvironment()
 }
 
-// CleanUp removes the TXT record matching the specified parameters
-func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
-	ctx := context.Background()
-	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+// getMSIAuthorizer uses the system-assigned managed identity,
+// or the user-assigned managed identity if the client ID is defined.
+func getMSIAuthorizer(config *Config) (autorest.Authorizer, error) {
+	msiEndpoint, err := getMSIEndpoint(config)
+	if err != nil {
+		return nil, fmt.Errorf("azure: failed to get the MSI endpoint: %v", err)
+	}
 
-	zone, err := d.getHostedZoneID(ctx, fqdn)
+	var spt *adal.ServicePrincipalToken
+	if config.ClientID == "" {
+		spt, err = adal.NewServicePrincipalTokenFromMSI(msiEndpoint, azure.PublicCloud.ResourceManagerEndpoint)
+	} else {
+		spt, err = adal.NewServicePrincipalTokenFromMSIWithUserAssignedID(msiEndpoint, azure.PublicCloud.ResourceManagerEndpoint, config.ClientID)
+	}
 	if err != nil {
-		return fmt.Errorf("azure: %v", err)
+		return nil, fmt.Errorf("azure: failed to get oauth token from MSI: %v", err)
 	}
 
-	relative := toRelativeRecord(fqdn, dns01.ToFqdn(zone))
-	rsc := dns.NewRecordSetsClient(d.config.SubscriptionID)
-	rsc.Authorizer = d.authorizer
+	spt.SetSender(config.HTTPClient)
+	return autorest.NewBearerAuthorizer(spt), nil
+}
 
-	_, err = rsc.Delete(ctx, d.config.ResourceGroup, zone, relative, dns.TXT, "")
-	if err != nil {
-		return fmt.Errorf("azure: %v", err)
+func getMSIEndpoint(config *Config) (string, error) {
+	if config.MetadataEndpoint == "" {
+		return adal.GetMSIVMEndpoint()
 	}
-	return nil
+
+	return strings.TrimSuffix(config.MetadataEndpoint, "/") + "/metadata/identity/oauth2/token", nil
 }
 
-// Checks that azure has a zone for this domain name.
-func (d *DNSProvider) getHostedZoneID(ctx context.Context, fqdn string) (string, error) {
-	authZone, err := dns01.FindZoneByFqdn(fqdn)
+// getCertificateAuthorizer uses a client certificate of a service principal.
+func getCertificateAuthorizer(config *Config) (autorest.Authorizer, error) {
+	if config.ClientID == "" || config.TenantID == "" || config.ClientCertificatePath == "" {
+		return nil, errors.New("azure: the client ID, the tenant ID and the certificate path are required to use a client certificate")
+	}
+
+	data, err := ioutil.ReadFile(config.ClientCertificatePath)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("azure: failed to read the client certificate: %v", err)
 	}
 
-	dc := dns.NewZonesClient(d.config.SubscriptionID)
-	dc.Authorizer = d.authorizer
+	certificate, privateKey, err := decodePkcs12(data, config.ClientCertificatePassword)
+	if err != nil {
+		return nil, fmt.Errorf("azure: failed to decode the client certificate: %v", err)
+	}
 
-	zone, err := dc.Get(ctx, d.config.ResourceGroup, dns01.UnFqdn(authZone))
+	oauthConfig, err := adal.NewOAuthConfig(azure.PublicCloud.ActiveDirectoryEndpoint, config.TenantID)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("azure: %v", err)
 	}
 
-	// zone.Name shouldn't have a trailing dot(.)
-	return to.String(zone.Name), nil
-}
+	spt, err := adal.NewServicePrincipalTokenFromCertificate(*oauthConfig, config.ClientID, certificate, privateKey, azure.PublicCloud.ResourceManagerEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("azure: %v", err)
+	}
 
-// Returns the relative record to the domain
-func toRelativeRecord(domain, zone string) string {
-	return dns01.UnFqdn(strings.TrimSuffix(domain, zone))
+	spt.SetSender(config.HTTPClient)
+	return autorest.NewBearerAuthorizer(spt), nil
 }
 
-func getAuthorizer(config *Config) (autorest.Authorizer, error) {
-	if config.ClientID != "" && config.ClientSecret != "" && config.TenantID != "" {
-		oauthConfig, err := adal.NewOAuthConfig(azure.PublicCloud.ActiveDirectoryEndpoint, config.TenantID)
-		if err != nil {
-			return nil, err
-		}
-
-		spt, err := adal.NewServicePrincipalToken(*oauthConfig, config.ClientID, config.ClientSecret, azure.PublicCloud.ResourceManagerEndpoint)
-		if err != nil {
-			return nil, err
-		}
+func decodePkcs12(data []byte, password string) (*x509.Certificate, *rsa.PrivateKey, error) {
+	privateKey, certificate, err := pkcs12.Decode(data, password)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		spt.SetSender(config.HTTPClient)
-		return autorest.NewBearerAuthorizer(spt), nil
+	rsaPrivateKey, ok := privateKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, nil, errors.New("the private key is not a RSA private key")
 	}
 
-	return auth.NewAuthorizerFromEnvironment()
+	return certificate, rsaPrivateKey, nil
 }
 
 // Fetches metadata from environment or he instance metadata service
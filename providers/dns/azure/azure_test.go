@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -15,7 +16,10 @@ var envTest = tester.NewEnvTest(
 	"AZURE_CLIENT_SECRET",
 	"AZURE_SUBSCRIPTION_ID",
 	"AZURE_TENANT_ID",
-	"AZURE_RESOURCE_GROUP").
+	"AZURE_RESOURCE_GROUP",
+	"AZURE_AUTH_METHOD",
+	"AZURE_CERTIFICATE_PATH",
+	"AZURE_CERTIFICATE_PASSWORD").
 	WithDomain("AZURE_DOMAIN")
 
 func TestNewDNSProvider(t *testing.T) {
@@ -34,6 +38,25 @@ func TestNewDNSProvider(t *testing.T) {
 				"AZURE_RESOURCE_GROUP":  "E",
 			},
 		},
+		{
+			desc: "success client certificate",
+			envVars: map[string]string{
+				"AZURE_CLIENT_ID":            "A",
+				"AZURE_TENANT_ID":            "C",
+				"AZURE_SUBSCRIPTION_ID":      "D",
+				"AZURE_RESOURCE_GROUP":       "E",
+				"AZURE_CERTIFICATE_PATH":     "fixtures/client.pfx",
+				"AZURE_CERTIFICATE_PASSWORD": "secret",
+			},
+		},
+		{
+			desc: "success MSI",
+			envVars: map[string]string{
+				"AZURE_AUTH_METHOD":     "msi",
+				"AZURE_SUBSCRIPTION_ID": "D",
+				"AZURE_RESOURCE_GROUP":  "E",
+			},
+		},
 		{
 			desc: "missing client ID",
 			envVars: map[string]string{
@@ -69,14 +92,18 @@ func TestNewDNSProvider(t *testing.T) {
 
 func TestNewDNSProviderConfig(t *testing.T) {
 	testCases := []struct {
-		desc           string
-		clientID       string
-		clientSecret   string
-		subscriptionID string
-		tenantID       string
-		resourceGroup  string
-		handler        func(w http.ResponseWriter, r *http.Request)
-		expected       string
+		desc                string
+		clientID            string
+		clientSecret        string
+		subscriptionID      string
+		tenantID            string
+		resourceGroup       string
+		privateZone         bool
+		authMethod          string
+		certificatePath     string
+		certificatePassword string
+		handler             func(w http.ResponseWriter, r *http.Request)
+		expected            string
 	}{
 		{
 			desc:           "success",
@@ -86,6 +113,64 @@ func TestNewDNSProviderConfig(t *testing.T) {
 			subscriptionID: "D",
 			resourceGroup:  "E",
 		},
+		{
+			desc:           "success (private zone)",
+			clientID:       "A",
+			clientSecret:   "B",
+			tenantID:       "C",
+			subscriptionID: "D",
+			resourceGroup:  "E",
+			privateZone:    true,
+		},
+		{
+			desc:           "success (system-assigned MSI)",
+			subscriptionID: "D",
+			resourceGroup:  "E",
+			authMethod:     "msi",
+		},
+		{
+			desc:           "success (user-assigned MSI)",
+			clientID:       "A",
+			subscriptionID: "D",
+			resourceGroup:  "E",
+			authMethod:     "msi",
+		},
+		{
+			desc:                "success (client certificate)",
+			clientID:            "A",
+			tenantID:            "C",
+			subscriptionID:      "D",
+			resourceGroup:       "E",
+			authMethod:          "cert",
+			certificatePath:     "fixtures/client.pfx",
+			certificatePassword: "secret",
+		},
+		{
+			desc:                "invalid client certificate password",
+			clientID:            "A",
+			tenantID:            "C",
+			subscriptionID:      "D",
+			resourceGroup:       "E",
+			certificatePath:     "fixtures/client.pfx",
+			certificatePassword: "foo",
+			expected:            "azure: failed to decode the client certificate: pkcs12: decryption password incorrect",
+		},
+		{
+			desc:           "client certificate missing",
+			clientID:       "A",
+			tenantID:       "C",
+			subscriptionID: "D",
+			resourceGroup:  "E",
+			authMethod:     "cert",
+			expected:       "azure: the client ID, the tenant ID and the certificate path are required to use a client certificate",
+		},
+		{
+			desc:           "unsupported authentication method",
+			subscriptionID: "D",
+			resourceGroup:  "E",
+			authMethod:     "foo",
+			expected:       "azure: unsupported authentication method: foo",
+		},
 		{
 			desc:           "SubscriptionID missing",
 			clientID:       "A",
@@ -128,6 +213,10 @@ func TestNewDNSProviderConfig(t *testing.T) {
 			config.SubscriptionID = test.subscriptionID
 			config.TenantID = test.tenantID
 			config.ResourceGroup = test.resourceGroup
+			config.PrivateZone = test.privateZone
+			config.AuthMethod = test.authMethod
+			config.ClientCertificatePath = test.certificatePath
+			config.ClientCertificatePassword = test.certificatePassword
 
 			handler := http.NewServeMux()
 			server := httptest.NewServer(handler)
@@ -145,6 +234,11 @@ func TestNewDNSProviderConfig(t *testing.T) {
 				require.NoError(t, err)
 				require.NotNil(t, p)
 				require.NotNil(t, p.config)
+				if test.privateZone {
+					assert.IsType(t, &dnsProviderPrivate{}, p.provider)
+				} else {
+					assert.IsType(t, &dnsProviderPublic{}, p.provider)
+				}
 			} else {
 				require.EqualError(t, err, test.expected)
 			}
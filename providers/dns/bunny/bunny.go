@@ -0,0 +1,191 @@
+// Package bunny implements a DNS provider for solving the DNS-01 challenge using Bunny DNS.
+package bunny
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/bunny/internal"
+	"github.com/miekg/dns"
+)
+
+const minTTL = 60
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL            string
+	APIKey             string
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString("BUNNY_ENDPOINT", internal.DefaultBaseURL),
+		TTL:                env.GetOrDefaultInt("BUNNY_TTL", dns01.DefaultTTL),
+		PropagationTimeout: env.GetOrDefaultSecond("BUNNY_PROPAGATION_TIMEOUT", 120*time.Second),
+		PollingInterval:    env.GetOrDefaultSecond("BUNNY_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("BUNNY_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+type recordRef struct {
+	zoneID   int64
+	recordID int64
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses the Bunny API to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	records   map[string]recordRef
+	recordsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Bunny DNS.
+// Credentials must be passed in the environment variable: BUNNY_API_KEY.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("BUNNY_API_KEY")
+	if err != nil {
+		return nil, fmt.Errorf("bunny: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIKey = values["BUNNY_API_KEY"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Bunny DNS.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("bunny: the configuration of the DNS provider is nil")
+	}
+
+	if config.APIKey == "" {
+		return nil, errors.New("bunny: credentials missing")
+	}
+
+	if config.TTL < minTTL {
+		return nil, fmt.Errorf("bunny: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	}
+
+	client := internal.NewClient(config.APIKey)
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{
+		config:  config,
+		client:  client,
+		records: make(map[string]recordRef),
+	}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("bunny: %v", err)
+	}
+
+	record := internal.Record{
+		Type:  internal.RecordTypeTXT,
+		TTL:   d.config.TTL,
+		Name:  extractRecordName(fqdn, zone.Domain),
+		Value: value,
+	}
+
+	newRecord, err := d.client.AddRecord(zone.ID, record)
+	if err != nil {
+		return fmt.Errorf("bunny: %v", err)
+	}
+
+	d.recordsMu.Lock()
+	d.records[token] = recordRef{zoneID: zone.ID, recordID: newRecord.ID}
+	d.recordsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	// gets the record's unique ID from when we created it
+	d.recordsMu.Lock()
+	ref, ok := d.records[token]
+	d.recordsMu.Unlock()
+	if !ok {
+		return fmt.Errorf("bunny: unknown record ID for '%s'", fqdn)
+	}
+
+	err := d.client.DeleteRecord(ref.zoneID, ref.recordID)
+	if err != nil {
+		return fmt.Errorf("bunny: %v", err)
+	}
+
+	// deletes record ID from map
+	d.recordsMu.Lock()
+	delete(d.records, token)
+	d.recordsMu.Unlock()
+
+	return nil
+}
+
+// findZone returns the zone of the account matching the longest part of the FQDN.
+// The search of the API matches the domains containing the term, so the exact domain is checked.
+func (d *DNSProvider) findZone(fqdn string) (*internal.Zone, error) {
+	name := dns01.UnFqdn(fqdn)
+
+	for _, index := range dns.Split(name) {
+		candidate := name[index:]
+
+		zones, err := d.client.SearchZones(candidate)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, zone := range zones {
+			if strings.EqualFold(zone.Domain, candidate) {
+				return &zones[i], nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no zone found for %s", fqdn)
+}
+
+func extractRecordName(fqdn, zone string) string {
+	name := dns01.UnFqdn(fqdn)
+	if idx := strings.LastIndex(name, "."+zone); idx != -1 {
+		return name[:idx]
+	}
+	return name
+}
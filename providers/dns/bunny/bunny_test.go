@@ -0,0 +1,207 @@
+package bunny
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest("BUNNY_API_KEY").
+	WithDomain("BUNNY_DOMAIN")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"BUNNY_API_KEY": "123",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				"BUNNY_API_KEY": "",
+			},
+			expected: "bunny: some credentials information are missing: BUNNY_API_KEY",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		token    string
+		ttl      int
+		expected string
+	}{
+		{
+			desc:  "success",
+			token: "123",
+			ttl:   minTTL,
+		},
+		{
+			desc:     "missing credentials",
+			ttl:      minTTL,
+			expected: "bunny: credentials missing",
+		},
+		{
+			desc:     "invalid TTL",
+			token:    "123",
+			ttl:      30,
+			expected: "bunny: invalid TTL, TTL (30) must be greater than 60",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.APIKey = test.token
+			config.TTL = test.ttl
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func setupTest() (*DNSProvider, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	config := NewDefaultConfig()
+	config.APIKey = "secret"
+	config.BaseURL = server.URL
+
+	p, err := NewDNSProviderConfig(config)
+	if err != nil {
+		panic(err)
+	}
+
+	mux.HandleFunc("/dnszone", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Query().Get("search") {
+		case "sub.example.com":
+			fmt.Fprint(rw, `{"Items":[{"Id":2,"Domain":"sub.example.com"},{"Id":4,"Domain":"other.sub.example.com"}],"CurrentPage":1,"TotalItems":2,"HasMoreItems":false}`)
+		case "example.com":
+			fmt.Fprint(rw, `{"Items":[{"Id":1,"Domain":"example.com"},{"Id":2,"Domain":"sub.example.com"}],"CurrentPage":1,"TotalItems":2,"HasMoreItems":false}`)
+		default:
+			fmt.Fprint(rw, `{"Items":[],"CurrentPage":1,"TotalItems":0,"HasMoreItems":false}`)
+		}
+	})
+
+	return p, mux, server.Close
+}
+
+func TestDNSProvider_Present_CleanUp(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/dnszone/2/records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPut {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		fmt.Fprint(rw, `{"Id":2001,"Type":3,"Ttl":120,"Name":"_acme-challenge.www","Value":"value"}`)
+	})
+
+	var deleted bool
+	mux.HandleFunc("/dnszone/2/records/2001", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		deleted = true
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	err := provider.Present("www.sub.example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Equal(t, recordRef{zoneID: 2, recordID: 2001}, provider.records["token"])
+
+	err = provider.CleanUp("www.sub.example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.True(t, deleted)
+	assert.Empty(t, provider.records)
+}
+
+func TestDNSProvider_Present_unknownDomain(t *testing.T) {
+	provider, _, tearDown := setupTest()
+	defer tearDown()
+
+	err := provider.Present("example.net", "token", "keyAuth")
+	require.EqualError(t, err, "bunny: no zone found for _acme-challenge.example.net.")
+}
+
+func Test_extractRecordName(t *testing.T) {
+	assert.Equal(t, "_acme-challenge", extractRecordName("_acme-challenge.example.com.", "example.com"))
+	assert.Equal(t, "_acme-challenge.sub", extractRecordName("_acme-challenge.sub.example.com.", "example.com"))
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
@@ -0,0 +1,154 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// DefaultBaseURL the default base URL of the Bunny API.
+const DefaultBaseURL = "https://api.bunny.net"
+
+// RecordTypeTXT the type of the TXT records.
+const RecordTypeTXT = 3
+
+// Zone a DNS zone.
+type Zone struct {
+	ID      int64    `json:"Id"`
+	Domain  string   `json:"Domain"`
+	Records []Record `json:"Records,omitempty"`
+}
+
+// Record a DNS record, the name is relative to the zone (i.e. "_acme-challenge.sub").
+type Record struct {
+	ID    int64  `json:"Id,omitempty"`
+	Type  int    `json:"Type"`
+	TTL   int    `json:"Ttl,omitempty"`
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
+}
+
+type zonesResponse struct {
+	Items        []Zone `json:"Items"`
+	CurrentPage  int    `json:"CurrentPage"`
+	TotalItems   int    `json:"TotalItems"`
+	HasMoreItems bool   `json:"HasMoreItems"`
+}
+
+type apiError struct {
+	ErrorKey string `json:"ErrorKey"`
+	Field    string `json:"Field"`
+	Message  string `json:"Message"`
+}
+
+// Client the Bunny API client.
+type Client struct {
+	apiKey     string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a Bunny API client.
+func NewClient(apiKey string) *Client {
+	return &Client{
+		apiKey:     apiKey,
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// SearchZones returns the DNS zones with a domain containing the search term.
+func (c *Client) SearchZones(search string) ([]Zone, error) {
+	var zones []Zone
+
+	for page := 1; ; page++ {
+		query := url.Values{}
+		query.Set("search", search)
+		query.Set("page", strconv.Itoa(page))
+		query.Set("perPage", "1000")
+
+		resp := &zonesResponse{}
+		err := c.do(http.MethodGet, "/dnszone?"+query.Encode(), nil, resp)
+		if err != nil {
+			return nil, fmt.Errorf("unable to search the zones %s: %v", search, err)
+		}
+
+		zones = append(zones, resp.Items...)
+
+		if !resp.HasMoreItems {
+			return zones, nil
+		}
+	}
+}
+
+// AddRecord adds a record to a zone.
+func (c *Client) AddRecord(zoneID int64, record Record) (*Record, error) {
+	newRecord := &Record{}
+	err := c.do(http.MethodPut, fmt.Sprintf("/dnszone/%d/records", zoneID), record, newRecord)
+	if err != nil {
+		return nil, fmt.Errorf("unable to add the record %s to the zone %d: %v", record.Name, zoneID, err)
+	}
+
+	return newRecord, nil
+}
+
+// DeleteRecord deletes a record of a zone.
+func (c *Client) DeleteRecord(zoneID, recordID int64) error {
+	err := c.do(http.MethodDelete, fmt.Sprintf("/dnszone/%d/records/%d", zoneID, recordID), nil, nil)
+	if err != nil {
+		return fmt.Errorf("unable to delete the record %d of the zone %d: %v", recordID, zoneID, err)
+	}
+
+	return nil
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body bytes.Buffer
+	if payload != nil {
+		err := json.NewEncoder(&body).Encode(payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+uri, &body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("AccessKey", c.apiKey)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &apiError{}
+		if json.Unmarshal(raw, apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("%d: %s: %s", resp.StatusCode, apiErr.ErrorKey, apiErr.Message)
+		}
+
+		return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	if result == nil || len(raw) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
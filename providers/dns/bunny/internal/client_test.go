@@ -0,0 +1,140 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest() (*Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client := NewClient("secret")
+	client.BaseURL = server.URL
+
+	return client, mux, server.Close
+}
+
+func checkAuth(rw http.ResponseWriter, req *http.Request) bool {
+	if req.Header.Get("AccessKey") != "secret" {
+		rw.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(rw, `{"ErrorKey":"unauthorized","Field":"AccessKey","Message":"The request authorization failed"}`)
+		return false
+	}
+	return true
+}
+
+func TestClient_SearchZones(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/dnszone", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		if req.URL.Query().Get("search") != "example.com" {
+			http.Error(rw, fmt.Sprintf("unexpected query: %s", req.URL.RawQuery), http.StatusBadRequest)
+			return
+		}
+
+		switch req.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(rw, `{"Items":[{"Id":1,"Domain":"example.com"}],"CurrentPage":1,"TotalItems":2,"HasMoreItems":true}`)
+		case "2":
+			fmt.Fprint(rw, `{"Items":[{"Id":2,"Domain":"sub.example.com"}],"CurrentPage":2,"TotalItems":2,"HasMoreItems":false}`)
+		default:
+			http.Error(rw, fmt.Sprintf("unexpected query: %s", req.URL.RawQuery), http.StatusBadRequest)
+		}
+	})
+
+	zones, err := client.SearchZones("example.com")
+	require.NoError(t, err)
+
+	expected := []Zone{{ID: 1, Domain: "example.com"}, {ID: 2, Domain: "sub.example.com"}}
+	assert.Equal(t, expected, zones)
+}
+
+func TestClient_SearchZones_error(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	client.apiKey = "invalid"
+
+	mux.HandleFunc("/dnszone", func(rw http.ResponseWriter, req *http.Request) {
+		checkAuth(rw, req)
+	})
+
+	_, err := client.SearchZones("example.com")
+	require.EqualError(t, err, "unable to search the zones example.com: 401: unauthorized: The request authorization failed")
+}
+
+func TestClient_AddRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/dnszone/1/records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPut {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		record := Record{}
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := Record{Type: RecordTypeTXT, TTL: 120, Name: "_acme-challenge", Value: "value"}
+		if record != expected {
+			http.Error(rw, fmt.Sprintf("unexpected record: %+v", record), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		fmt.Fprint(rw, `{"Id":2001,"Type":3,"Ttl":120,"Name":"_acme-challenge","Value":"value"}`)
+	})
+
+	record, err := client.AddRecord(1, Record{Type: RecordTypeTXT, TTL: 120, Name: "_acme-challenge", Value: "value"})
+	require.NoError(t, err)
+
+	expected := &Record{ID: 2001, Type: RecordTypeTXT, TTL: 120, Name: "_acme-challenge", Value: "value"}
+	assert.Equal(t, expected, record)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/dnszone/1/records/2001", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	err := client.DeleteRecord(1, 2001)
+	require.NoError(t, err)
+}
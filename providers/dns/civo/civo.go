@@ -0,0 +1,190 @@
+// Package civo implements a DNS provider for solving the DNS-01 challenge using Civo.
+package civo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/civo/internal"
+)
+
+const minTTL = 600
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL            string
+	Token              string
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString("CIVO_ENDPOINT", internal.DefaultBaseURL),
+		TTL:                env.GetOrDefaultInt("CIVO_TTL", minTTL),
+		PropagationTimeout: env.GetOrDefaultSecond("CIVO_PROPAGATION_TIMEOUT", 300*time.Second),
+		PollingInterval:    env.GetOrDefaultSecond("CIVO_POLLING_INTERVAL", 30*time.Second),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("CIVO_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+type recordRef struct {
+	domainID string
+	recordID string
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses the Civo API to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	records   map[string]recordRef
+	recordsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Civo.
+// Credentials must be passed in the environment variable: CIVO_TOKEN.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("CIVO_TOKEN")
+	if err != nil {
+		return nil, fmt.Errorf("civo: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.Token = values["CIVO_TOKEN"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Civo.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("civo: the configuration of the DNS provider is nil")
+	}
+
+	if config.Token == "" {
+		return nil, errors.New("civo: credentials missing")
+	}
+
+	if config.TTL < minTTL {
+		return nil, fmt.Errorf("civo: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	}
+
+	client := internal.NewClient(config.Token)
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{
+		config:  config,
+		client:  client,
+		records: make(map[string]recordRef),
+	}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("civo: %v", err)
+	}
+
+	record := internal.Record{
+		Type:  "TXT",
+		Name:  extractRecordName(fqdn, zone.Name),
+		Value: value,
+		TTL:   d.config.TTL,
+	}
+
+	newRecord, err := d.client.CreateRecord(zone.ID, record)
+	if err != nil {
+		return fmt.Errorf("civo: %v", err)
+	}
+
+	d.recordsMu.Lock()
+	d.records[token] = recordRef{domainID: zone.ID, recordID: newRecord.ID}
+	d.recordsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	// gets the record's unique ID from when we created it
+	d.recordsMu.Lock()
+	ref, ok := d.records[token]
+	d.recordsMu.Unlock()
+	if !ok {
+		return fmt.Errorf("civo: unknown record ID for '%s'", fqdn)
+	}
+
+	err := d.client.DeleteRecord(ref.domainID, ref.recordID)
+	if err != nil {
+		return fmt.Errorf("civo: %v", err)
+	}
+
+	// deletes record ID from map
+	d.recordsMu.Lock()
+	delete(d.records, token)
+	d.recordsMu.Unlock()
+
+	return nil
+}
+
+// findDomain returns the longest domain of the account matching the FQDN.
+func (d *DNSProvider) findDomain(fqdn string) (*internal.Domain, error) {
+	domains, err := d.client.ListDomains()
+	if err != nil {
+		return nil, err
+	}
+
+	name := dns01.UnFqdn(fqdn)
+
+	var zone *internal.Domain
+	for i, domain := range domains {
+		if (name == domain.Name || strings.HasSuffix(name, "."+domain.Name)) && (zone == nil || len(domain.Name) > len(zone.Name)) {
+			zone = &domains[i]
+		}
+	}
+
+	if zone == nil {
+		return nil, fmt.Errorf("no domain found for %s", fqdn)
+	}
+
+	return zone, nil
+}
+
+func extractRecordName(fqdn, zone string) string {
+	name := dns01.UnFqdn(fqdn)
+	if idx := strings.LastIndex(name, "."+zone); idx != -1 {
+		return name[:idx]
+	}
+	return name
+}
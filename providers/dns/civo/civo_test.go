@@ -0,0 +1,200 @@
+package civo
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest("CIVO_TOKEN").
+	WithDomain("CIVO_DOMAIN")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"CIVO_TOKEN": "123",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				"CIVO_TOKEN": "",
+			},
+			expected: "civo: some credentials information are missing: CIVO_TOKEN",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		token    string
+		ttl      int
+		expected string
+	}{
+		{
+			desc:  "success",
+			token: "123",
+			ttl:   minTTL,
+		},
+		{
+			desc:     "missing credentials",
+			ttl:      minTTL,
+			expected: "civo: credentials missing",
+		},
+		{
+			desc:     "invalid TTL",
+			token:    "123",
+			ttl:      100,
+			expected: "civo: invalid TTL, TTL (100) must be greater than 600",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.Token = test.token
+			config.TTL = test.ttl
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func setupTest() (*DNSProvider, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	config := NewDefaultConfig()
+	config.Token = "secret"
+	config.BaseURL = server.URL
+
+	p, err := NewDNSProviderConfig(config)
+	if err != nil {
+		panic(err)
+	}
+
+	mux.HandleFunc("/dns", func(rw http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(rw, `[{"id":"1","name":"example.com"},{"id":"2","name":"sub.example.com"},{"id":"3","name":"example.org"}]`)
+	})
+
+	return p, mux, server.Close
+}
+
+func TestDNSProvider_Present_CleanUp(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/dns/2/records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		fmt.Fprint(rw, `{"id":"abc","domain_id":"2","type":"TXT","name":"_acme-challenge.www","value":"value","ttl":600}`)
+	})
+
+	var deleted bool
+	mux.HandleFunc("/dns/2/records/abc", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		deleted = true
+		fmt.Fprint(rw, `{"result":"success"}`)
+	})
+
+	err := provider.Present("www.sub.example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Equal(t, recordRef{domainID: "2", recordID: "abc"}, provider.records["token"])
+
+	err = provider.CleanUp("www.sub.example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.True(t, deleted)
+	assert.Empty(t, provider.records)
+}
+
+func TestDNSProvider_Present_unknownDomain(t *testing.T) {
+	provider, _, tearDown := setupTest()
+	defer tearDown()
+
+	err := provider.Present("example.net", "token", "keyAuth")
+	require.EqualError(t, err, "civo: no domain found for _acme-challenge.example.net.")
+}
+
+func Test_extractRecordName(t *testing.T) {
+	assert.Equal(t, "_acme-challenge", extractRecordName("_acme-challenge.example.com.", "example.com"))
+	assert.Equal(t, "_acme-challenge.sub", extractRecordName("_acme-challenge.sub.example.com.", "example.com"))
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
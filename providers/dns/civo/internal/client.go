@@ -0,0 +1,129 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// DefaultBaseURL the default base URL of the Civo API.
+const DefaultBaseURL = "https://api.civo.com/v2"
+
+// Domain a DNS domain.
+type Domain struct {
+	ID        string `json:"id"`
+	AccountID string `json:"account_id,omitempty"`
+	Name      string `json:"name"`
+}
+
+// Record a DNS record, the name is relative to the domain (i.e. "_acme-challenge.sub").
+type Record struct {
+	ID       string `json:"id,omitempty"`
+	DomainID string `json:"domain_id,omitempty"`
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	TTL      int    `json:"ttl,omitempty"`
+}
+
+type apiError struct {
+	Code   string `json:"code"`
+	Reason string `json:"reason"`
+}
+
+// Client the Civo API client.
+type Client struct {
+	token      string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a Civo API client.
+func NewClient(token string) *Client {
+	return &Client{
+		token:      token,
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// ListDomains returns the DNS domains of the account.
+func (c *Client) ListDomains() ([]Domain, error) {
+	var domains []Domain
+	err := c.do(http.MethodGet, "/dns", nil, &domains)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the domains: %v", err)
+	}
+
+	return domains, nil
+}
+
+// CreateRecord creates a record in a domain.
+func (c *Client) CreateRecord(domainID string, record Record) (*Record, error) {
+	newRecord := &Record{}
+	err := c.do(http.MethodPost, fmt.Sprintf("/dns/%s/records", domainID), record, newRecord)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the record %s in the domain %s: %v", record.Name, domainID, err)
+	}
+
+	return newRecord, nil
+}
+
+// DeleteRecord deletes a record of a domain.
+func (c *Client) DeleteRecord(domainID, recordID string) error {
+	err := c.do(http.MethodDelete, fmt.Sprintf("/dns/%s/records/%s", domainID, recordID), nil, nil)
+	if err != nil {
+		return fmt.Errorf("unable to delete the record %s of the domain %s: %v", recordID, domainID, err)
+	}
+
+	return nil
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body bytes.Buffer
+	if payload != nil {
+		err := json.NewEncoder(&body).Encode(payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+uri, &body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &apiError{}
+		if json.Unmarshal(raw, apiErr) == nil && apiErr.Code != "" {
+			return fmt.Errorf("%d: %s: %s", resp.StatusCode, apiErr.Code, apiErr.Reason)
+		}
+
+		return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
@@ -0,0 +1,126 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest() (*Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client := NewClient("secret")
+	client.BaseURL = server.URL
+
+	return client, mux, server.Close
+}
+
+func checkAuth(rw http.ResponseWriter, req *http.Request) bool {
+	if req.Header.Get("Authorization") != "Bearer secret" {
+		rw.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(rw, `{"code":"authentication_invalid_key","reason":"The API key provided is invalid"}`)
+		return false
+	}
+	return true
+}
+
+func TestClient_ListDomains(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/dns", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		fmt.Fprint(rw, `[{"id":"1","account_id":"a","name":"example.com"}]`)
+	})
+
+	domains, err := client.ListDomains()
+	require.NoError(t, err)
+
+	assert.Equal(t, []Domain{{ID: "1", AccountID: "a", Name: "example.com"}}, domains)
+}
+
+func TestClient_ListDomains_error(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	client.token = "invalid"
+
+	mux.HandleFunc("/dns", func(rw http.ResponseWriter, req *http.Request) {
+		checkAuth(rw, req)
+	})
+
+	_, err := client.ListDomains()
+	require.EqualError(t, err, "unable to list the domains: 401: authentication_invalid_key: The API key provided is invalid")
+}
+
+func TestClient_CreateRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/dns/1/records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		record := Record{}
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := Record{Type: "TXT", Name: "_acme-challenge", Value: "value", TTL: 600}
+		if record != expected {
+			http.Error(rw, fmt.Sprintf("unexpected record: %+v", record), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `{"id":"abc","domain_id":"1","type":"TXT","name":"_acme-challenge","value":"value","ttl":600}`)
+	})
+
+	record, err := client.CreateRecord("1", Record{Type: "TXT", Name: "_acme-challenge", Value: "value", TTL: 600})
+	require.NoError(t, err)
+
+	expected := &Record{ID: "abc", DomainID: "1", Type: "TXT", Name: "_acme-challenge", Value: "value", TTL: 600}
+	assert.Equal(t, expected, record)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/dns/1/records/abc", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		fmt.Fprint(rw, `{"result":"success"}`)
+	})
+
+	err := client.DeleteRecord("1", "abc")
+	require.NoError(t, err)
+}
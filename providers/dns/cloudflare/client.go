@@ -0,0 +1,92 @@
+package cloudflare
+
+import (
+	"sync"
+
+	cloudflare "github.com/cloudflare/cloudflare-go"
+	"github.com/go-acme/lego/v3/challenge/dns01"
+)
+
+// metaClient uses a client to read the zones and a client to edit the DNS records:
+// the scoped API tokens can be restricted to one of these permissions.
+type metaClient struct {
+	clientEdit *cloudflare.API // needs Zone/DNS/Edit
+	clientRead *cloudflare.API // needs Zone/Zone/Read
+
+	zones   map[string]string // caches calls to ZoneIDByName
+	zonesMu sync.RWMutex
+}
+
+func newClient(config *Config) (*metaClient, error) {
+	// with AuthKey/AuthEmail we can access all available APIs
+	if config.AuthToken == "" {
+		client, err := cloudflare.New(config.AuthKey, config.AuthEmail, cloudflare.HTTPClient(config.HTTPClient))
+		if err != nil {
+			return nil, err
+		}
+
+		return &metaClient{
+			clientEdit: client,
+			clientRead: client,
+			zones:      make(map[string]string),
+		}, nil
+	}
+
+	dns, err := cloudflare.NewWithAPIToken(config.AuthToken, cloudflare.HTTPClient(config.HTTPClient))
+	if err != nil {
+		return nil, err
+	}
+
+	if config.ZoneToken == "" || config.ZoneToken == config.AuthToken {
+		return &metaClient{
+			clientEdit: dns,
+			clientRead: dns,
+			zones:      make(map[string]string),
+		}, nil
+	}
+
+	zone, err := cloudflare.NewWithAPIToken(config.ZoneToken, cloudflare.HTTPClient(config.HTTPClient))
+	if err != nil {
+		return nil, err
+	}
+
+	return &metaClient{
+		clientEdit: dns,
+		clientRead: zone,
+		zones:      make(map[string]string),
+	}, nil
+}
+
+func (m *metaClient) CreateDNSRecord(zoneID string, rr cloudflare.DNSRecord) (*cloudflare.DNSRecordResponse, error) {
+	return m.clientEdit.CreateDNSRecord(zoneID, rr)
+}
+
+func (m *metaClient) DNSRecords(zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+	return m.clientEdit.DNSRecords(zoneID, rr)
+}
+
+func (m *metaClient) DeleteDNSRecord(zoneID, recordID string) error {
+	return m.clientEdit.DeleteDNSRecord(zoneID, recordID)
+}
+
+// ZoneIDByName returns the ID of the zone, the name of the zone can be a FQDN.
+func (m *metaClient) ZoneIDByName(fqdn string) (string, error) {
+	m.zonesMu.RLock()
+	id := m.zones[fqdn]
+	m.zonesMu.RUnlock()
+
+	if id != "" {
+		return id, nil
+	}
+
+	id, err := m.clientRead.ZoneIDByName(dns01.UnFqdn(fqdn))
+	if err != nil {
+		return "", err
+	}
+
+	m.zonesMu.Lock()
+	m.zones[fqdn] = id
+	m.zonesMu.Unlock()
+
+	return id, nil
+}
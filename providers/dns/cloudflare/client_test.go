@@ -0,0 +1,101 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cloudflare "github.com/cloudflare/cloudflare-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newClient(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		config     *Config
+		sameClient bool
+		expected   string
+	}{
+		{
+			desc:       "global API key",
+			config:     &Config{AuthEmail: "test@example.com", AuthKey: "123"},
+			sameClient: true,
+		},
+		{
+			desc:       "DNS API token",
+			config:     &Config{AuthToken: "dns"},
+			sameClient: true,
+		},
+		{
+			desc:       "same DNS and zone API tokens",
+			config:     &Config{AuthToken: "dns", ZoneToken: "dns"},
+			sameClient: true,
+		},
+		{
+			desc:   "different DNS and zone API tokens",
+			config: &Config{AuthToken: "dns", ZoneToken: "zone"},
+		},
+		{
+			desc:     "missing credentials",
+			config:   &Config{},
+			expected: "invalid credentials: key & email must not be empty",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			client, err := newClient(test.config)
+
+			if test.expected != "" {
+				require.EqualError(t, err, test.expected)
+				return
+			}
+
+			require.NoError(t, err)
+
+			if test.sameClient {
+				assert.Same(t, client.clientEdit, client.clientRead)
+			} else {
+				assert.NotSame(t, client.clientEdit, client.clientRead)
+			}
+		})
+	}
+}
+
+func TestMetaClient_ZoneIDByName(t *testing.T) {
+	var calls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer zone" {
+			rw.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(rw, `{"success":false,"errors":[{"code":9109,"message":"Unauthorized to access requested resource"}],"messages":[],"result":null}`)
+			return
+		}
+
+		calls++
+
+		fmt.Fprint(rw, `{"success":true,"errors":[],"messages":[],"result":[{"id":"023e105f4ecef8ad9ca31a8372d0c353","name":"example.com"}],"result_info":{"page":1,"per_page":20,"count":1,"total_count":1,"total_pages":1}}`)
+	}))
+	defer server.Close()
+
+	client, err := newClient(&Config{AuthToken: "dns", ZoneToken: "zone"})
+	require.NoError(t, err)
+
+	client.clientRead.BaseURL = server.URL
+	client.clientEdit.BaseURL = server.URL
+
+	for i := 0; i < 2; i++ {
+		zoneID, err := client.ZoneIDByName("example.com.")
+		require.NoError(t, err)
+
+		assert.Equal(t, "023e105f4ecef8ad9ca31a8372d0c353", zoneID)
+	}
+
+	// the zone IDs are cached.
+	assert.Equal(t, 1, calls)
+
+	_, err = client.DNSRecords("023e105f4ecef8ad9ca31a8372d0c353", cloudflare.DNSRecord{Type: "TXT"})
+	require.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	cloudflare "github.com/cloudflare/cloudflare-go"
@@ -19,8 +20,16 @@ const (
 
 // Config is used to configure the creation of the DNSProvider
 type Config struct {
-	AuthEmail          string
-	AuthKey            string
+	AuthEmail string
+	AuthKey   string
+
+	AuthToken string
+	ZoneToken string
+
+	// ZoneTokens are API tokens scoped to a single zone (Zone:Read and DNS:Edit), by zone name.
+	// They take precedence over the other credentials for their zone.
+	ZoneTokens map[string]string
+
 	TTL                int
 	PropagationTimeout time.Duration
 	PollingInterval    time.Duration
@@ -41,24 +50,35 @@ func NewDefaultConfig() *Config {
 
 // DNSProvider is an implementation of the acme.ChallengeProvider interface
 type DNSProvider struct {
-	client *cloudflare.API
-	config *Config
+	client      *metaClient
+	zoneClients map[string]*metaClient
+	config      *Config
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for Cloudflare.
 // Credentials must be passed in the environment variables:
-// CLOUDFLARE_EMAIL and CLOUDFLARE_API_KEY.
+// CLOUDFLARE_EMAIL and CLOUDFLARE_API_KEY (Global API Key),
+// or CLOUDFLARE_DNS_API_TOKEN and optionally CLOUDFLARE_ZONE_API_TOKEN (scoped API tokens).
+// API tokens scoped to a single zone can be passed in CLOUDFLARE_DNS_API_TOKENS: `zone:token[,zone:token]`.
 func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.GetWithFallback(
-		[]string{"CLOUDFLARE_EMAIL", "CF_API_EMAIL"},
-		[]string{"CLOUDFLARE_API_KEY", "CF_API_KEY"})
-	if err != nil {
+	config := NewDefaultConfig()
+
+	if tokens, err := env.GetWithFallback([]string{"CLOUDFLARE_DNS_API_TOKENS", "CF_DNS_API_TOKENS"}); err == nil {
+		config.ZoneTokens, err = parseZoneTokens(tokens["CLOUDFLARE_DNS_API_TOKENS"])
+		if err != nil {
+			return nil, fmt.Errorf("cloudflare: %v", err)
+		}
+	}
+
+	values, err := getCredentials()
+	if err != nil && len(config.ZoneTokens) == 0 {
 		return nil, fmt.Errorf("cloudflare: %v", err)
 	}
 
-	config := NewDefaultConfig()
 	config.AuthEmail = values["CLOUDFLARE_EMAIL"]
 	config.AuthKey = values["CLOUDFLARE_API_KEY"]
+	config.AuthToken = values["CLOUDFLARE_DNS_API_TOKEN"]
+	config.ZoneToken = values["CLOUDFLARE_ZONE_API_TOKEN"]
 
 	return NewDNSProviderConfig(config)
 }
@@ -73,12 +93,27 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, fmt.Errorf("cloudflare: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
 	}
 
-	client, err := cloudflare.New(config.AuthKey, config.AuthEmail, cloudflare.HTTPClient(config.HTTPClient))
+	zoneClients := make(map[string]*metaClient)
+	for zone, token := range config.ZoneTokens {
+		client, err := newClient(&Config{AuthToken: token, HTTPClient: config.HTTPClient})
+		if err != nil {
+			return nil, fmt.Errorf("cloudflare: zone %s: %v", zone, err)
+		}
+
+		zoneClients[dns01.UnFqdn(strings.ToLower(zone))] = client
+	}
+
+	// the other credentials are optional when all the zones have their own API token.
+	if len(zoneClients) > 0 && config.AuthEmail == "" && config.AuthKey == "" && config.AuthToken == "" {
+		return &DNSProvider{zoneClients: zoneClients, config: config}, nil
+	}
+
+	client, err := newClient(config)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("cloudflare: %v", err)
 	}
 
-	return &DNSProvider{client: client, config: config}, nil
+	return &DNSProvider{client: client, zoneClients: zoneClients, config: config}, nil
 }
 
 // Timeout returns the timeout and interval to use when checking for DNS propagation.
@@ -96,7 +131,12 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 		return fmt.Errorf("cloudflare: %v", err)
 	}
 
-	zoneID, err := d.client.ZoneIDByName(dns01.UnFqdn(authZone))
+	client, err := d.clientFor(authZone)
+	if err != nil {
+		return fmt.Errorf("cloudflare: %v", err)
+	}
+
+	zoneID, err := client.ZoneIDByName(authZone)
 	if err != nil {
 		return fmt.Errorf("cloudflare: failed to find zone %s: %v", authZone, err)
 	}
@@ -108,7 +148,7 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 		TTL:     d.config.TTL,
 	}
 
-	response, err := d.client.CreateDNSRecord(zoneID, dnsRecord)
+	response, err := client.CreateDNSRecord(zoneID, dnsRecord)
 	if err != nil {
 		return fmt.Errorf("cloudflare: failed to create TXT record: %v", err)
 	}
@@ -131,7 +171,12 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 		return fmt.Errorf("cloudflare: %v", err)
 	}
 
-	zoneID, err := d.client.ZoneIDByName(dns01.UnFqdn(authZone))
+	client, err := d.clientFor(authZone)
+	if err != nil {
+		return fmt.Errorf("cloudflare: %v", err)
+	}
+
+	zoneID, err := client.ZoneIDByName(authZone)
 	if err != nil {
 		return fmt.Errorf("cloudflare: failed to find zone %s: %v", authZone, err)
 	}
@@ -141,13 +186,13 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 		Name: dns01.UnFqdn(fqdn),
 	}
 
-	records, err := d.client.DNSRecords(zoneID, dnsRecord)
+	records, err := client.DNSRecords(zoneID, dnsRecord)
 	if err != nil {
 		return fmt.Errorf("cloudflare: failed to find TXT records: %v", err)
 	}
 
 	for _, record := range records {
-		err = d.client.DeleteDNSRecord(zoneID, record.ID)
+		err = client.DeleteDNSRecord(zoneID, record.ID)
 		if err != nil {
 			log.Printf("cloudflare: failed to delete TXT record: %v", err)
 		}
@@ -155,3 +200,58 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 
 	return nil
 }
+
+// clientFor returns the client of the API token of the zone, or the default client.
+func (d *DNSProvider) clientFor(authZone string) (*metaClient, error) {
+	if client, ok := d.zoneClients[dns01.UnFqdn(strings.ToLower(authZone))]; ok {
+		return client, nil
+	}
+
+	if d.client == nil {
+		return nil, fmt.Errorf("no API token defined for the zone %s", authZone)
+	}
+
+	return d.client, nil
+}
+
+func getCredentials() (map[string]string, error) {
+	values, err := env.GetWithFallback(
+		[]string{"CLOUDFLARE_EMAIL", "CF_API_EMAIL"},
+		[]string{"CLOUDFLARE_API_KEY", "CF_API_KEY"})
+	if err == nil {
+		return values, nil
+	}
+
+	values, errT := env.GetWithFallback(
+		[]string{"CLOUDFLARE_DNS_API_TOKEN", "CF_DNS_API_TOKEN"},
+		[]string{"CLOUDFLARE_ZONE_API_TOKEN", "CF_ZONE_API_TOKEN", "CLOUDFLARE_DNS_API_TOKEN", "CF_DNS_API_TOKEN"})
+	if errT != nil {
+		return nil, fmt.Errorf("%v or %v", err, errT)
+	}
+
+	return values, nil
+}
+
+func parseZoneTokens(raw string) (map[string]string, error) {
+	tokens := make(map[string]string)
+
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		parts := strings.SplitN(item, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("incorrect zone token pair: %s", item)
+		}
+
+		tokens[dns01.UnFqdn(strings.TrimSpace(parts[0]))] = strings.TrimSpace(parts[1])
+	}
+
+	if len(tokens) == 0 {
+		return nil, errors.New("no zone tokens found")
+	}
+
+	return tokens, nil
+}
@@ -11,7 +11,10 @@ import (
 
 var envTest = tester.NewEnvTest(
 	"CLOUDFLARE_EMAIL",
-	"CLOUDFLARE_API_KEY").
+	"CLOUDFLARE_API_KEY",
+	"CLOUDFLARE_DNS_API_TOKEN",
+	"CLOUDFLARE_ZONE_API_TOKEN",
+	"CLOUDFLARE_DNS_API_TOKENS").
 	WithDomain("CLOUDFLARE_DOMAIN")
 
 func TestNewDNSProvider(t *testing.T) {
@@ -27,13 +30,46 @@ func TestNewDNSProvider(t *testing.T) {
 				"CLOUDFLARE_API_KEY": "123",
 			},
 		},
+		{
+			desc: "success API token",
+			envVars: map[string]string{
+				"CLOUDFLARE_DNS_API_TOKEN": "012345abcdef",
+			},
+		},
+		{
+			desc: "success separate API tokens",
+			envVars: map[string]string{
+				"CLOUDFLARE_DNS_API_TOKEN":  "012345abcdef",
+				"CLOUDFLARE_ZONE_API_TOKEN": "abcdef012345",
+			},
+		},
+		{
+			desc: "success zone tokens",
+			envVars: map[string]string{
+				"CLOUDFLARE_DNS_API_TOKENS": "example.com:012345abcdef,example.org:abcdef012345",
+			},
+		},
+		{
+			desc: "success zone tokens and API token",
+			envVars: map[string]string{
+				"CLOUDFLARE_DNS_API_TOKENS": "example.com:012345abcdef",
+				"CLOUDFLARE_DNS_API_TOKEN":  "abcdef012345",
+			},
+		},
+		{
+			desc: "invalid zone tokens",
+			envVars: map[string]string{
+				"CLOUDFLARE_DNS_API_TOKENS": "example.com",
+			},
+			expected: "cloudflare: incorrect zone token pair: example.com",
+		},
 		{
 			desc: "missing credentials",
 			envVars: map[string]string{
 				"CLOUDFLARE_EMAIL":   "",
 				"CLOUDFLARE_API_KEY": "",
 			},
-			expected: "cloudflare: some credentials information are missing: CLOUDFLARE_EMAIL,CLOUDFLARE_API_KEY",
+			expected: "cloudflare: some credentials information are missing: CLOUDFLARE_EMAIL,CLOUDFLARE_API_KEY or some credentials information are missing: CLOUDFLARE_DNS_API_TOKEN,CLOUDFLARE_ZONE_API_TOKEN",
 		},
 		{
 			desc: "missing email",
@@ -41,7 +77,7 @@ func TestNewDNSProvider(t *testing.T) {
 				"CLOUDFLARE_EMAIL":   "",
 				"CLOUDFLARE_API_KEY": "key",
 			},
-			expected: "cloudflare: some credentials information are missing: CLOUDFLARE_EMAIL",
+			expected: "cloudflare: some credentials information are missing: CLOUDFLARE_EMAIL or some credentials information are missing: CLOUDFLARE_DNS_API_TOKEN,CLOUDFLARE_ZONE_API_TOKEN",
 		},
 		{
 			desc: "missing api key",
@@ -49,7 +85,14 @@ func TestNewDNSProvider(t *testing.T) {
 				"CLOUDFLARE_EMAIL":   "awesome@possum.com",
 				"CLOUDFLARE_API_KEY": "",
 			},
-			expected: "cloudflare: some credentials information are missing: CLOUDFLARE_API_KEY",
+			expected: "cloudflare: some credentials information are missing: CLOUDFLARE_API_KEY or some credentials information are missing: CLOUDFLARE_DNS_API_TOKEN,CLOUDFLARE_ZONE_API_TOKEN",
+		},
+		{
+			desc: "missing DNS API token",
+			envVars: map[string]string{
+				"CLOUDFLARE_ZONE_API_TOKEN": "abcdef012345",
+			},
+			expected: "cloudflare: some credentials information are missing: CLOUDFLARE_EMAIL,CLOUDFLARE_API_KEY or some credentials information are missing: CLOUDFLARE_DNS_API_TOKEN",
 		},
 	}
 
@@ -66,7 +109,7 @@ func TestNewDNSProvider(t *testing.T) {
 				require.NoError(t, err)
 				require.NotNil(t, p)
 				assert.NotNil(t, p.config)
-				assert.NotNil(t, p.client)
+				assert.True(t, p.client != nil || len(p.zoneClients) > 0)
 			} else {
 				require.EqualError(t, err, test.expected)
 			}
@@ -76,29 +119,45 @@ func TestNewDNSProvider(t *testing.T) {
 
 func TestNewDNSProviderConfig(t *testing.T) {
 	testCases := []struct {
-		desc      string
-		authEmail string
-		authKey   string
-		expected  string
+		desc       string
+		authEmail  string
+		authKey    string
+		authToken  string
+		zoneToken  string
+		zoneTokens map[string]string
+		expected   string
 	}{
 		{
 			desc:      "success",
 			authEmail: "test@example.com",
 			authKey:   "123",
 		},
+		{
+			desc:      "success with API token",
+			authToken: "012345abcdef",
+		},
+		{
+			desc:      "success with separate API tokens",
+			authToken: "012345abcdef",
+			zoneToken: "abcdef012345",
+		},
+		{
+			desc:       "success with zone tokens",
+			zoneTokens: map[string]string{"example.com": "012345abcdef"},
+		},
 		{
 			desc:     "missing credentials",
-			expected: "invalid credentials: key & email must not be empty",
+			expected: "cloudflare: invalid credentials: key & email must not be empty",
 		},
 		{
 			desc:     "missing email",
 			authKey:  "123",
-			expected: "invalid credentials: key & email must not be empty",
+			expected: "cloudflare: invalid credentials: key & email must not be empty",
 		},
 		{
 			desc:      "missing api key",
 			authEmail: "test@example.com",
-			expected:  "invalid credentials: key & email must not be empty",
+			expected:  "cloudflare: invalid credentials: key & email must not be empty",
 		},
 	}
 
@@ -107,6 +166,9 @@ func TestNewDNSProviderConfig(t *testing.T) {
 			config := NewDefaultConfig()
 			config.AuthEmail = test.authEmail
 			config.AuthKey = test.authKey
+			config.AuthToken = test.authToken
+			config.ZoneToken = test.zoneToken
+			config.ZoneTokens = test.zoneTokens
 
 			p, err := NewDNSProviderConfig(config)
 
@@ -114,7 +176,7 @@ func TestNewDNSProviderConfig(t *testing.T) {
 				require.NoError(t, err)
 				require.NotNil(t, p)
 				assert.NotNil(t, p.config)
-				assert.NotNil(t, p.client)
+				assert.True(t, p.client != nil || len(p.zoneClients) > 0)
 			} else {
 				require.EqualError(t, err, test.expected)
 			}
@@ -122,6 +184,31 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func TestDNSProvider_clientFor(t *testing.T) {
+	config := NewDefaultConfig()
+	config.AuthToken = "012345abcdef"
+	config.ZoneTokens = map[string]string{"Example.com.": "abcdef012345"}
+
+	p, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	client, err := p.clientFor("example.com.")
+	require.NoError(t, err)
+	assert.Same(t, p.zoneClients["example.com"], client)
+
+	client, err = p.clientFor("example.org.")
+	require.NoError(t, err)
+	assert.Same(t, p.client, client)
+
+	config.AuthToken = ""
+
+	p, err = NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	_, err = p.clientFor("example.org.")
+	require.EqualError(t, err, "no API token defined for the zone example.org.")
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
@@ -0,0 +1,210 @@
+// Package combell implements a DNS provider for solving the DNS-01 challenge using Combell.
+package combell
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/combell/internal"
+)
+
+const minTTL = 60
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL            string
+	APIKey             string
+	APISecret          string
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString("COMBELL_ENDPOINT", internal.DefaultBaseURL),
+		TTL:                env.GetOrDefaultInt("COMBELL_TTL", minTTL),
+		PropagationTimeout: env.GetOrDefaultSecond("COMBELL_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("COMBELL_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("COMBELL_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses the Combell API to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	recordIDs   map[string]string
+	recordIDsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Combell.
+// Credentials must be passed in the environment variables: COMBELL_API_KEY and COMBELL_API_SECRET.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("COMBELL_API_KEY", "COMBELL_API_SECRET")
+	if err != nil {
+		return nil, fmt.Errorf("combell: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIKey = values["COMBELL_API_KEY"]
+	config.APISecret = values["COMBELL_API_SECRET"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Combell.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("combell: the configuration of the DNS provider is nil")
+	}
+
+	if config.APIKey == "" || config.APISecret == "" {
+		return nil, errors.New("combell: credentials missing")
+	}
+
+	if config.TTL < minTTL {
+		return nil, fmt.Errorf("combell: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	}
+
+	client := internal.NewClient(config.APIKey, config.APISecret)
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{
+		config:    config,
+		client:    client,
+		recordIDs: make(map[string]string),
+	}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("combell: %v", err)
+	}
+
+	record := internal.Record{
+		Type:       "TXT",
+		RecordName: extractRecordName(fqdn, zone),
+		Content:    value,
+		TTL:        d.config.TTL,
+	}
+
+	recordID, err := d.client.CreateRecord(zone, record)
+	if err != nil {
+		return fmt.Errorf("combell: %v", err)
+	}
+
+	d.recordIDsMu.Lock()
+	d.recordIDs[token] = recordID
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("combell: %v", err)
+	}
+
+	d.recordIDsMu.Lock()
+	recordID, ok := d.recordIDs[token]
+	d.recordIDsMu.Unlock()
+
+	if !ok {
+		recordID, err = d.findRecordID(zone, extractRecordName(fqdn, zone), value)
+		if err != nil {
+			return fmt.Errorf("combell: %v", err)
+		}
+	}
+
+	err = d.client.DeleteRecord(zone, recordID)
+	if err != nil {
+		return fmt.Errorf("combell: %v", err)
+	}
+
+	d.recordIDsMu.Lock()
+	delete(d.recordIDs, token)
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// findZone returns the longest domain linked to the account matching the FQDN.
+func (d *DNSProvider) findZone(fqdn string) (string, error) {
+	domains, err := d.client.GetDomains()
+	if err != nil {
+		return "", err
+	}
+
+	name := dns01.UnFqdn(fqdn)
+
+	var zone string
+	for _, domain := range domains {
+		if (name == domain.DomainName || strings.HasSuffix(name, "."+domain.DomainName)) && len(domain.DomainName) > len(zone) {
+			zone = domain.DomainName
+		}
+	}
+
+	if zone == "" {
+		return "", fmt.Errorf("no domain found for %s", fqdn)
+	}
+
+	return zone, nil
+}
+
+// findRecordID returns the ID of the TXT record matching the name and the value.
+func (d *DNSProvider) findRecordID(zone, recordName, value string) (string, error) {
+	records, err := d.client.GetRecords(zone, "TXT", recordName)
+	if err != nil {
+		return "", err
+	}
+
+	for _, record := range records {
+		if record.RecordName == recordName && record.Content == value {
+			return record.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("no TXT record found for %s.%s", recordName, zone)
+}
+
+func extractRecordName(fqdn, zone string) string {
+	name := dns01.UnFqdn(fqdn)
+	if idx := strings.LastIndex(name, "."+zone); idx != -1 {
+		return name[:idx]
+	}
+	return name
+}
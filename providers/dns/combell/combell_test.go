@@ -0,0 +1,249 @@
+package combell
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest(
+	"COMBELL_API_KEY",
+	"COMBELL_API_SECRET").
+	WithDomain("COMBELL_DOMAIN")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"COMBELL_API_KEY":    "123",
+				"COMBELL_API_SECRET": "456",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				"COMBELL_API_KEY":    "",
+				"COMBELL_API_SECRET": "",
+			},
+			expected: "combell: some credentials information are missing: COMBELL_API_KEY,COMBELL_API_SECRET",
+		},
+		{
+			desc: "missing API key",
+			envVars: map[string]string{
+				"COMBELL_API_KEY":    "",
+				"COMBELL_API_SECRET": "456",
+			},
+			expected: "combell: some credentials information are missing: COMBELL_API_KEY",
+		},
+		{
+			desc: "missing API secret",
+			envVars: map[string]string{
+				"COMBELL_API_KEY":    "123",
+				"COMBELL_API_SECRET": "",
+			},
+			expected: "combell: some credentials information are missing: COMBELL_API_SECRET",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		apiKey    string
+		apiSecret string
+		ttl       int
+		expected  string
+	}{
+		{
+			desc:      "success",
+			apiKey:    "123",
+			apiSecret: "456",
+			ttl:       minTTL,
+		},
+		{
+			desc:     "missing credentials",
+			ttl:      minTTL,
+			expected: "combell: credentials missing",
+		},
+		{
+			desc:     "missing API secret",
+			apiKey:   "123",
+			ttl:      minTTL,
+			expected: "combell: credentials missing",
+		},
+		{
+			desc:      "invalid TTL",
+			apiKey:    "123",
+			apiSecret: "456",
+			ttl:       30,
+			expected:  "combell: invalid TTL, TTL (30) must be greater than 60",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.APIKey = test.apiKey
+			config.APISecret = test.apiSecret
+			config.TTL = test.ttl
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func setupTest() (*DNSProvider, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	config := NewDefaultConfig()
+	config.APIKey = "key"
+	config.APISecret = "secret"
+	config.BaseURL = server.URL + "/v2"
+
+	p, err := NewDNSProviderConfig(config)
+	if err != nil {
+		panic(err)
+	}
+
+	mux.HandleFunc("/v2/domains", func(rw http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(rw, `[{"domain_name":"example.com"},{"domain_name":"sub.example.com"},{"domain_name":"example.org"}]`)
+	})
+
+	return p, mux, server.Close
+}
+
+func TestDNSProvider_Present_CleanUp(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/v2/dns/sub.example.com/records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		rw.Header().Set("Location", "/v2/dns/sub.example.com/records/123")
+		rw.WriteHeader(http.StatusCreated)
+	})
+
+	var deleted bool
+	mux.HandleFunc("/v2/dns/sub.example.com/records/123", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		deleted = true
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	err := provider.Present("www.sub.example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Equal(t, "123", provider.recordIDs["token"])
+
+	err = provider.CleanUp("www.sub.example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.True(t, deleted)
+	assert.Empty(t, provider.recordIDs)
+}
+
+func TestDNSProvider_CleanUp_lookup(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	_, value := dns01.GetRecord("www.example.com", "keyAuth")
+
+	mux.HandleFunc("/v2/dns/example.com/records", func(rw http.ResponseWriter, req *http.Request) {
+		fmt.Fprintf(rw, `[
+{"id":"1","type":"TXT","record_name":"_acme-challenge.www","content":"other"},
+{"id":"123","type":"TXT","record_name":"_acme-challenge.www","content":%q}]`, value)
+	})
+
+	var deleted bool
+	mux.HandleFunc("/v2/dns/example.com/records/123", func(rw http.ResponseWriter, req *http.Request) {
+		deleted = true
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	err := provider.CleanUp("www.example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.True(t, deleted)
+}
+
+func Test_extractRecordName(t *testing.T) {
+	assert.Equal(t, "_acme-challenge", extractRecordName("_acme-challenge.example.com.", "example.com"))
+	assert.Equal(t, "_acme-challenge.sub", extractRecordName("_acme-challenge.sub.example.com.", "example.com"))
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
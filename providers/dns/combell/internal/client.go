@@ -0,0 +1,222 @@
+package internal
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL the default base URL of the Combell API.
+const DefaultBaseURL = "https://api.combell.com/v2"
+
+const pageSize = 100
+
+// Domain a domain linked to the account.
+type Domain struct {
+	DomainName string `json:"domain_name"`
+}
+
+// Record a DNS record, the name is relative to the domain (i.e. "_acme-challenge.sub").
+type Record struct {
+	ID         string `json:"id,omitempty"`
+	Type       string `json:"type"`
+	RecordName string `json:"record_name"`
+	Content    string `json:"content"`
+	TTL        int    `json:"ttl,omitempty"`
+}
+
+type apiError struct {
+	ErrorCode string `json:"error_code"`
+	ErrorText string `json:"error_text"`
+}
+
+// Client the Combell API client.
+type Client struct {
+	apiKey     string
+	apiSecret  string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a Combell API client.
+func NewClient(apiKey, apiSecret string) *Client {
+	return &Client{
+		apiKey:     apiKey,
+		apiSecret:  apiSecret,
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// GetDomains returns all the domains linked to the account.
+func (c *Client) GetDomains() ([]Domain, error) {
+	var domains []Domain
+
+	for skip := 0; ; skip += pageSize {
+		query := url.Values{}
+		query.Set("skip", strconv.Itoa(skip))
+		query.Set("take", strconv.Itoa(pageSize))
+
+		var page []Domain
+		_, err := c.do(http.MethodGet, "/domains?"+query.Encode(), nil, &page)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get the domains: %v", err)
+		}
+
+		domains = append(domains, page...)
+
+		if len(page) < pageSize {
+			return domains, nil
+		}
+	}
+}
+
+// GetRecords returns the records of a domain matching the type and the name.
+func (c *Client) GetRecords(domain, recordType, recordName string) ([]Record, error) {
+	query := url.Values{}
+	query.Set("type", recordType)
+	query.Set("record_name", recordName)
+
+	var records []Record
+	_, err := c.do(http.MethodGet, fmt.Sprintf("/dns/%s/records?%s", domain, query.Encode()), nil, &records)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the records of the domain %s: %v", domain, err)
+	}
+
+	return records, nil
+}
+
+// CreateRecord creates a record and returns its ID.
+func (c *Client) CreateRecord(domain string, record Record) (string, error) {
+	resp, err := c.do(http.MethodPost, fmt.Sprintf("/dns/%s/records", domain), record, nil)
+	if err != nil {
+		return "", fmt.Errorf("unable to create the record %s of the domain %s: %v", record.RecordName, domain, err)
+	}
+
+	// the ID of the record is the last segment of the location (i.e. "/v2/dns/example.com/records/123").
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return "", fmt.Errorf("unable to create the record %s of the domain %s: missing location", record.RecordName, domain)
+	}
+
+	return path.Base(location), nil
+}
+
+// DeleteRecord deletes a record.
+func (c *Client) DeleteRecord(domain, recordID string) error {
+	_, err := c.do(http.MethodDelete, fmt.Sprintf("/dns/%s/records/%s", domain, recordID), nil, nil)
+	if err != nil {
+		return fmt.Errorf("unable to delete the record %s of the domain %s: %v", recordID, domain, err)
+	}
+
+	return nil
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) (*http.Response, error) {
+	var body []byte
+	if payload != nil {
+		var err error
+		body, err = json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+uri, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	authorization, err := c.sign(req, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", authorization)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &apiError{}
+		if json.Unmarshal(raw, apiErr) == nil && apiErr.ErrorText != "" {
+			return nil, fmt.Errorf("%d: %s: %s", resp.StatusCode, apiErr.ErrorCode, apiErr.ErrorText)
+		}
+
+		return nil, fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	if result == nil {
+		return resp, nil
+	}
+
+	return resp, json.Unmarshal(raw, result)
+}
+
+// sign creates the value of the Authorization header: "hmac <api key>:<signature>:<nonce>:<timestamp>".
+// The signature is the base64 encoded HMAC-SHA256 (with the API secret) of the concatenation of
+// the API key, the lowercased method, the lowercased URL encoded path (with the query), the timestamp, the nonce,
+// and the base64 encoded MD5 of the body (if any).
+func (c *Client) sign(req *http.Request, body []byte) (string, error) {
+	nonce, err := newNonce()
+	if err != nil {
+		return "", err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	signature := signature(c.apiKey, c.apiSecret, req.Method, req.URL.RequestURI(), timestamp, nonce, body)
+
+	return fmt.Sprintf("hmac %s:%s:%s:%s", c.apiKey, signature, nonce, timestamp), nil
+}
+
+// signature computes the signature of a request.
+func signature(apiKey, apiSecret, method, requestURI, timestamp, nonce string, body []byte) string {
+	var content string
+	if len(body) > 0 {
+		sum := md5.Sum(body)
+		content = base64.StdEncoding.EncodeToString(sum[:])
+	}
+
+	message := apiKey + strings.ToLower(method) + strings.ToLower(url.QueryEscape(requestURI)) + timestamp + nonce + content
+
+	mac := hmac.New(sha256.New, []byte(apiSecret))
+	_, _ = mac.Write([]byte(message))
+
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func newNonce() (string, error) {
+	raw := make([]byte, 16)
+	_, err := rand.Read(raw)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(raw), nil
+}
@@ -0,0 +1,170 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest() (*Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client := NewClient("key", "secret")
+	client.BaseURL = server.URL + "/v2"
+
+	return client, mux, server.Close
+}
+
+func checkAuth(rw http.ResponseWriter, req *http.Request, body []byte) bool {
+	authorization := strings.TrimPrefix(req.Header.Get("Authorization"), "hmac ")
+
+	parts := strings.Split(authorization, ":")
+	if len(parts) != 4 || parts[0] != "key" ||
+		parts[1] != signature("key", "secret", req.Method, req.URL.RequestURI(), parts[3], parts[2], body) {
+		rw.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(rw, `{"error_code":"authorization_failed","error_text":"Invalid signature"}`)
+		return false
+	}
+	return true
+}
+
+func Test_signature(t *testing.T) {
+	sig := signature("key", "secret", http.MethodGet, "/v2/domains?skip=0&take=100", "1580000000", "nonce", nil)
+	assert.Equal(t, "7HsL8lsADHWwJicjrY/NOTRoPoZ8numNtVKGOgFtHeE=", sig)
+
+	sig = signature("key", "secret", http.MethodPost, "/v2/dns/example.com/records", "1580000000", "nonce", []byte(`{}`))
+	assert.NotEqual(t, signature("key", "secret", http.MethodPost, "/v2/dns/example.com/records", "1580000000", "nonce", nil), sig)
+}
+
+func TestClient_GetDomains(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/v2/domains", func(rw http.ResponseWriter, req *http.Request) {
+		if !checkAuth(rw, req, nil) {
+			return
+		}
+
+		if req.URL.Query().Get("skip") != "0" || req.URL.Query().Get("take") != "100" {
+			http.Error(rw, fmt.Sprintf("unexpected query: %s", req.URL.RawQuery), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `[{"domain_name":"example.com","expiration_date":"2021-01-01T00:00:00","will_renew":true},{"domain_name":"example.org"}]`)
+	})
+
+	domains, err := client.GetDomains()
+	require.NoError(t, err)
+
+	assert.Equal(t, []Domain{{DomainName: "example.com"}, {DomainName: "example.org"}}, domains)
+}
+
+func TestClient_GetDomains_error(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	client.apiSecret = "invalid"
+
+	mux.HandleFunc("/v2/domains", func(rw http.ResponseWriter, req *http.Request) {
+		checkAuth(rw, req, nil)
+	})
+
+	_, err := client.GetDomains()
+	require.EqualError(t, err, "unable to get the domains: 401: authorization_failed: Invalid signature")
+}
+
+func TestClient_GetRecords(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/v2/dns/example.com/records", func(rw http.ResponseWriter, req *http.Request) {
+		if !checkAuth(rw, req, nil) {
+			return
+		}
+
+		if req.URL.Query().Get("type") != "TXT" || req.URL.Query().Get("record_name") != "_acme-challenge" {
+			http.Error(rw, fmt.Sprintf("unexpected query: %s", req.URL.RawQuery), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `[{"id":"123","type":"TXT","record_name":"_acme-challenge","content":"value","ttl":60}]`)
+	})
+
+	records, err := client.GetRecords("example.com", "TXT", "_acme-challenge")
+	require.NoError(t, err)
+
+	expected := []Record{{ID: "123", Type: "TXT", RecordName: "_acme-challenge", Content: "value", TTL: 60}}
+	assert.Equal(t, expected, records)
+}
+
+func TestClient_CreateRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/v2/dns/example.com/records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !checkAuth(rw, req, body) {
+			return
+		}
+
+		record := Record{}
+		err = json.Unmarshal(body, &record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := Record{Type: "TXT", RecordName: "_acme-challenge", Content: "value", TTL: 60}
+		if record != expected {
+			http.Error(rw, fmt.Sprintf("unexpected record: %+v", record), http.StatusBadRequest)
+			return
+		}
+
+		rw.Header().Set("Location", "/v2/dns/example.com/records/123")
+		rw.WriteHeader(http.StatusCreated)
+	})
+
+	recordID, err := client.CreateRecord("example.com", Record{Type: "TXT", RecordName: "_acme-challenge", Content: "value", TTL: 60})
+	require.NoError(t, err)
+
+	assert.Equal(t, "123", recordID)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/v2/dns/example.com/records/123", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req, nil) {
+			return
+		}
+
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	err := client.DeleteRecord("example.com", "123")
+	require.NoError(t, err)
+}
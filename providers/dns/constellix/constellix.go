@@ -0,0 +1,223 @@
+// Package constellix implements a DNS provider for solving the DNS-01 challenge using Constellix DNS.
+package constellix
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/constellix/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL            string
+	APIKey             string
+	SecretKey          string
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString("CONSTELLIX_ENDPOINT", internal.DefaultBaseURL),
+		TTL:                env.GetOrDefaultInt("CONSTELLIX_TTL", 60),
+		PropagationTimeout: env.GetOrDefaultSecond("CONSTELLIX_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("CONSTELLIX_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("CONSTELLIX_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses the Constellix DNS API to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Constellix.
+// Credentials must be passed in the environment variables:
+// CONSTELLIX_API_KEY and CONSTELLIX_SECRET_KEY.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("CONSTELLIX_API_KEY", "CONSTELLIX_SECRET_KEY")
+	if err != nil {
+		return nil, fmt.Errorf("constellix: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIKey = values["CONSTELLIX_API_KEY"]
+	config.SecretKey = values["CONSTELLIX_SECRET_KEY"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Constellix.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("constellix: the configuration of the DNS provider is nil")
+	}
+
+	if config.APIKey == "" || config.SecretKey == "" {
+		return nil, errors.New("constellix: credentials missing")
+	}
+
+	client := internal.NewClient(config.APIKey, config.SecretKey)
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+// The value is added to the existing TXT record with the same name, if any.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	dom, recordName, err := d.findDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("constellix: %v", err)
+	}
+
+	records, err := d.client.SearchTXTRecords(dom.ID, recordName)
+	if err != nil {
+		return fmt.Errorf("constellix: %v", err)
+	}
+
+	quoted := strconv.Quote(value)
+
+	if len(records) == 0 {
+		request := internal.RecordRequest{
+			Name:       recordName,
+			TTL:        d.config.TTL,
+			RoundRobin: []internal.RecordValue{{Value: quoted}},
+		}
+
+		err = d.client.CreateTXTRecord(dom.ID, request)
+		if err != nil {
+			return fmt.Errorf("constellix: %v", err)
+		}
+
+		return nil
+	}
+
+	record := records[0]
+
+	for _, v := range record.Value {
+		if v.Value == quoted {
+			return nil
+		}
+	}
+
+	request := internal.RecordRequest{
+		Name:       recordName,
+		TTL:        record.TTL,
+		RoundRobin: append(record.Value, internal.RecordValue{Value: quoted}),
+	}
+
+	err = d.client.UpdateTXTRecord(dom.ID, record.ID, request)
+	if err != nil {
+		return fmt.Errorf("constellix: %v", err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record value matching the specified parameters.
+// The record is deleted when no other value remains.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	dom, recordName, err := d.findDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("constellix: %v", err)
+	}
+
+	records, err := d.client.SearchTXTRecords(dom.ID, recordName)
+	if err != nil {
+		return fmt.Errorf("constellix: %v", err)
+	}
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	record := records[0]
+	quoted := strconv.Quote(value)
+
+	var values []internal.RecordValue
+	for _, v := range record.Value {
+		if v.Value != quoted {
+			values = append(values, v)
+		}
+	}
+
+	if len(values) == len(record.Value) {
+		return nil
+	}
+
+	if len(values) == 0 {
+		err = d.client.DeleteTXTRecord(dom.ID, record.ID)
+		if err != nil {
+			return fmt.Errorf("constellix: %v", err)
+		}
+
+		return nil
+	}
+
+	request := internal.RecordRequest{
+		Name:       recordName,
+		TTL:        record.TTL,
+		RoundRobin: values,
+	}
+
+	err = d.client.UpdateTXTRecord(dom.ID, record.ID, request)
+	if err != nil {
+		return fmt.Errorf("constellix: %v", err)
+	}
+
+	return nil
+}
+
+func (d *DNSProvider) findDomain(fqdn string) (*internal.Domain, string, error) {
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not find zone for FQDN %q: %v", fqdn, err)
+	}
+
+	dom, err := d.client.GetDomainByName(dns01.UnFqdn(authZone))
+	if err != nil {
+		return nil, "", err
+	}
+
+	return dom, extractRecordName(fqdn, authZone), nil
+}
+
+func extractRecordName(fqdn, authZone string) string {
+	name := dns01.UnFqdn(fqdn)
+	if idx := strings.LastIndex(name, "."+dns01.UnFqdn(authZone)); idx != -1 {
+		return name[:idx]
+	}
+	return name
+}
@@ -0,0 +1,204 @@
+package internal
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL the default base URL of the Constellix DNS API.
+const DefaultBaseURL = "https://api.dns.constellix.com/v1"
+
+// Domain a DNS domain.
+type Domain struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// RecordValue a value of a record.
+type RecordValue struct {
+	Value       string `json:"value"`
+	DisableFlag bool   `json:"disableFlag"`
+}
+
+// Record a TXT record, as returned by the API.
+type Record struct {
+	ID    int64         `json:"id"`
+	Name  string        `json:"name"`
+	TTL   int           `json:"ttl"`
+	Value []RecordValue `json:"value"`
+}
+
+// RecordRequest the payload used to create or update a TXT record.
+type RecordRequest struct {
+	Name       string        `json:"name"`
+	TTL        int           `json:"ttl"`
+	RoundRobin []RecordValue `json:"roundRobin"`
+}
+
+type apiError struct {
+	Errors []string `json:"errors"`
+}
+
+// StatusError an error returned by the API.
+type StatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%d: %s", e.StatusCode, e.Message)
+}
+
+// Client the Constellix DNS API client.
+type Client struct {
+	apiKey     string
+	secretKey  string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a Constellix DNS API client.
+func NewClient(apiKey, secretKey string) *Client {
+	return &Client{
+		apiKey:     apiKey,
+		secretKey:  secretKey,
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// GetDomainByName returns the domain matching exactly the name.
+func (c *Client) GetDomainByName(name string) (*Domain, error) {
+	query := url.Values{}
+	query.Set("exact", name)
+
+	var domains []Domain
+	err := c.do(http.MethodGet, "/domains/search?"+query.Encode(), nil, &domains)
+	if err != nil {
+		return nil, fmt.Errorf("unable to search the domain %s: %v", name, err)
+	}
+
+	for _, domain := range domains {
+		if domain.Name == name {
+			return &domain, nil
+		}
+	}
+
+	return nil, fmt.Errorf("domain %s not found", name)
+}
+
+// SearchTXTRecords returns the TXT records matching exactly the name.
+func (c *Client) SearchTXTRecords(domainID int64, name string) ([]Record, error) {
+	query := url.Values{}
+	query.Set("exact", name)
+
+	var records []Record
+	err := c.do(http.MethodGet, fmt.Sprintf("/domains/%d/records/txt/search?%s", domainID, query.Encode()), nil, &records)
+	if err != nil {
+		// the search endpoint returns a 404 when no record matches.
+		if e, ok := err.(*StatusError); ok && e.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to search the TXT records %s: %v", name, err)
+	}
+
+	return records, nil
+}
+
+// CreateTXTRecord creates a TXT record.
+func (c *Client) CreateTXTRecord(domainID int64, record RecordRequest) error {
+	err := c.do(http.MethodPost, fmt.Sprintf("/domains/%d/records/txt", domainID), record, nil)
+	if err != nil {
+		return fmt.Errorf("unable to create the TXT record %s: %v", record.Name, err)
+	}
+
+	return nil
+}
+
+// UpdateTXTRecord replaces the values of a TXT record.
+func (c *Client) UpdateTXTRecord(domainID, recordID int64, record RecordRequest) error {
+	err := c.do(http.MethodPut, fmt.Sprintf("/domains/%d/records/txt/%d", domainID, recordID), record, nil)
+	if err != nil {
+		return fmt.Errorf("unable to update the TXT record %d: %v", recordID, err)
+	}
+
+	return nil
+}
+
+// DeleteTXTRecord deletes a TXT record.
+func (c *Client) DeleteTXTRecord(domainID, recordID int64) error {
+	err := c.do(http.MethodDelete, fmt.Sprintf("/domains/%d/records/txt/%d", domainID, recordID), nil, nil)
+	if err != nil {
+		return fmt.Errorf("unable to delete the TXT record %d: %v", recordID, err)
+	}
+
+	return nil
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body bytes.Buffer
+	if payload != nil {
+		err := json.NewEncoder(&body).Encode(payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+uri, &body)
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10)
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("x-cnsdns-apiKey", c.apiKey)
+	req.Header.Set("x-cnsdns-requestDate", timestamp)
+	req.Header.Set("x-cnsdns-hmac", computeHMAC(timestamp, c.secretKey))
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &apiError{}
+		if json.Unmarshal(raw, apiErr) == nil && len(apiErr.Errors) > 0 {
+			return &StatusError{StatusCode: resp.StatusCode, Message: strings.Join(apiErr.Errors, ", ")}
+		}
+
+		return &StatusError{StatusCode: resp.StatusCode, Message: string(raw)}
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
+
+// computeHMAC signs the timestamp (in milliseconds) with the secret key: base64(HMAC-SHA1).
+func computeHMAC(timestamp, secretKey string) string {
+	h := hmac.New(sha1.New, []byte(secretKey))
+	_, _ = h.Write([]byte(timestamp))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,153 @@
+package internal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest() (*Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client := NewClient("key", "secret")
+	client.BaseURL = server.URL
+
+	return client, mux, server.Close
+}
+
+func checkAuth(rw http.ResponseWriter, req *http.Request) bool {
+	timestamp := req.Header.Get("x-cnsdns-requestDate")
+	if req.Header.Get("x-cnsdns-apiKey") != "key" || timestamp == "" || req.Header.Get("x-cnsdns-hmac") != computeHMAC(timestamp, "secret") {
+		rw.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(rw, `{"errors":["Unable to authenticate token"]}`)
+		return false
+	}
+	return true
+}
+
+func TestClient_GetDomainByName(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/domains/search", func(rw http.ResponseWriter, req *http.Request) {
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		if req.URL.Query().Get("exact") != "example.com" {
+			http.Error(rw, `{"errors":["not found"]}`, http.StatusNotFound)
+			return
+		}
+
+		fmt.Fprint(rw, `[{"id":1234,"name":"example.com"}]`)
+	})
+
+	domain, err := client.GetDomainByName("example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, &Domain{ID: 1234, Name: "example.com"}, domain)
+}
+
+func TestClient_GetDomainByName_error(t *testing.T) {
+	client, _, tearDown := setupTest()
+	defer tearDown()
+	client.secretKey = "invalid"
+
+	_, err := client.GetDomainByName("example.com")
+	require.Error(t, err)
+}
+
+func TestClient_SearchTXTRecords(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/domains/1234/records/txt/search", func(rw http.ResponseWriter, req *http.Request) {
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		if req.URL.Query().Get("exact") != "_acme-challenge" {
+			rw.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(rw, `{"errors":["Requested record was not found"]}`)
+			return
+		}
+
+		fmt.Fprint(rw, `[{"id":42,"name":"_acme-challenge","ttl":60,"value":[{"value":"\"abc\"","disableFlag":false}]}]`)
+	})
+
+	records, err := client.SearchTXTRecords(1234, "_acme-challenge")
+	require.NoError(t, err)
+
+	expected := []Record{{ID: 42, Name: "_acme-challenge", TTL: 60, Value: []RecordValue{{Value: `"abc"`}}}}
+	assert.Equal(t, expected, records)
+
+	records, err = client.SearchTXTRecords(1234, "_acme-challenge.sub")
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestClient_CreateTXTRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/domains/1234/records/txt", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := `{"name":"_acme-challenge","ttl":60,"roundRobin":[{"value":"\"abc\"","disableFlag":false}]}` + "\n"
+		if string(body) != expected {
+			http.Error(rw, fmt.Sprintf("unexpected body: %s", string(body)), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		fmt.Fprint(rw, `[{"id":42}]`)
+	})
+
+	record := RecordRequest{Name: "_acme-challenge", TTL: 60, RoundRobin: []RecordValue{{Value: `"abc"`}}}
+
+	err := client.CreateTXTRecord(1234, record)
+	require.NoError(t, err)
+}
+
+func TestClient_DeleteTXTRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/domains/1234/records/txt/42", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		fmt.Fprint(rw, `{"success":"Record deleted successfully"}`)
+	})
+
+	err := client.DeleteTXTRecord(1234, 42)
+	require.NoError(t, err)
+
+	err = client.DeleteTXTRecord(1234, 43)
+	require.Error(t, err)
+}
@@ -0,0 +1,206 @@
+// Package desec implements a DNS provider for solving the DNS-01 challenge using deSEC DNS.
+package desec
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/desec/internal"
+)
+
+// minTTL the minimal TTL allowed by deSEC.
+const minTTL = 3600
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL            string
+	Token              string
+	TTL                int
+	MaxRetries         int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString("DESEC_ENDPOINT", internal.DefaultBaseURL),
+		TTL:                env.GetOrDefaultInt("DESEC_TTL", minTTL),
+		MaxRetries:         env.GetOrDefaultInt("DESEC_MAX_RETRIES", 5),
+		PropagationTimeout: env.GetOrDefaultSecond("DESEC_PROPAGATION_TIMEOUT", 2*time.Minute),
+		PollingInterval:    env.GetOrDefaultSecond("DESEC_POLLING_INTERVAL", 4*time.Second),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("DESEC_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses deSEC's API to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	// the TXT values of a name are in the same RRSet: the read-modify-write of the RRSets are serialized.
+	rrSetsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for deSEC.
+// Credentials must be passed in the environment variable: DESEC_TOKEN.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("DESEC_TOKEN")
+	if err != nil {
+		return nil, fmt.Errorf("desec: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.Token = values["DESEC_TOKEN"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for deSEC.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("desec: the configuration of the DNS provider is nil")
+	}
+
+	if config.Token == "" {
+		return nil, errors.New("desec: incomplete credentials, missing token")
+	}
+
+	if config.TTL < minTTL {
+		return nil, fmt.Errorf("desec: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	}
+
+	client := internal.NewClient(config.Token)
+	client.MaxRetries = config.MaxRetries
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+	quotedValue := strconv.Quote(value)
+
+	domainName, subName, err := d.getNames(fqdn)
+	if err != nil {
+		return fmt.Errorf("desec: %v", err)
+	}
+
+	d.rrSetsMu.Lock()
+	defer d.rrSetsMu.Unlock()
+
+	rrSet, err := d.client.GetTxtRRSet(domainName, subName)
+	if err != nil {
+		if _, ok := err.(*internal.NotFound); !ok {
+			return fmt.Errorf("desec: failed to get RRSet: %v", err)
+		}
+
+		// the RRSet doesn't exist.
+		rrSet := internal.RRSet{
+			Domain:  domainName,
+			SubName: subName,
+			Records: []string{quotedValue},
+			TTL:     d.config.TTL,
+		}
+
+		_, err = d.client.AddTxtRRSet(rrSet)
+		if err != nil {
+			return fmt.Errorf("desec: %v", err)
+		}
+
+		return nil
+	}
+
+	// the RRSet already exists: the value is merged with the existing records.
+	for _, record := range rrSet.Records {
+		if record == quotedValue {
+			return nil
+		}
+	}
+
+	_, err = d.client.UpdateTxtRRSet(domainName, subName, append(rrSet.Records, quotedValue))
+	if err != nil {
+		return fmt.Errorf("desec: %v", err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+	quotedValue := strconv.Quote(value)
+
+	domainName, subName, err := d.getNames(fqdn)
+	if err != nil {
+		return fmt.Errorf("desec: %v", err)
+	}
+
+	d.rrSetsMu.Lock()
+	defer d.rrSetsMu.Unlock()
+
+	rrSet, err := d.client.GetTxtRRSet(domainName, subName)
+	if err != nil {
+		return fmt.Errorf("desec: failed to get RRSet: %v", err)
+	}
+
+	var records []string
+	for _, record := range rrSet.Records {
+		if record != quotedValue {
+			records = append(records, record)
+		}
+	}
+
+	if len(records) == len(rrSet.Records) {
+		return nil
+	}
+
+	if records == nil {
+		// an empty list of records deletes the RRSet.
+		records = []string{}
+	}
+
+	_, err = d.client.UpdateTxtRRSet(domainName, subName, records)
+	if err != nil {
+		return fmt.Errorf("desec: %v", err)
+	}
+
+	return nil
+}
+
+// getNames returns the domain of the account responsible for the FQDN and the subname of the FQDN in this domain.
+func (d *DNSProvider) getNames(fqdn string) (string, string, error) {
+	name := dns01.UnFqdn(fqdn)
+
+	domain, err := d.client.GetResponsibleDomain(name)
+	if err != nil {
+		return "", "", err
+	}
+
+	return domain.Name, strings.TrimSuffix(strings.TrimSuffix(name, domain.Name), "."), nil
+}
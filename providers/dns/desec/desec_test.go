@@ -0,0 +1,227 @@
+package desec
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/go-acme/lego/v3/providers/dns/desec/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest("DESEC_TOKEN").
+	WithDomain("DESEC_DOMAIN")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"DESEC_TOKEN": "123",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				"DESEC_TOKEN": "",
+			},
+			expected: "desec: some credentials information are missing: DESEC_TOKEN",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		token    string
+		ttl      int
+		expected string
+	}{
+		{
+			desc:  "success",
+			token: "123",
+			ttl:   minTTL,
+		},
+		{
+			desc:     "missing credentials",
+			ttl:      minTTL,
+			expected: "desec: incomplete credentials, missing token",
+		},
+		{
+			desc:     "invalid TTL",
+			token:    "123",
+			ttl:      60,
+			expected: "desec: invalid TTL, TTL (60) must be greater than 3600",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.Token = test.token
+			config.TTL = test.ttl
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+// setupRRSetsTest creates a provider using a fake API storing the TXT RRSets of the example.com domain.
+func setupRRSetsTest(t *testing.T) (*DNSProvider, map[string]*internal.RRSet, func()) {
+	t.Helper()
+
+	rrSets := make(map[string]*internal.RRSet)
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc("/domains/", func(rw http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(rw, `[{"name":"example.com"}]`)
+	})
+
+	mux.HandleFunc("/domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		rrSet := &internal.RRSet{}
+		if err := json.NewDecoder(req.Body).Decode(rrSet); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		rrSet.Domain = "example.com"
+		rrSets[rrSet.SubName] = rrSet
+
+		rw.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(rw).Encode(rrSet)
+	})
+
+	mux.HandleFunc("/domains/example.com/rrsets/_acme-challenge/TXT/", func(rw http.ResponseWriter, req *http.Request) {
+		rrSet, ok := rrSets["_acme-challenge"]
+		if !ok {
+			http.Error(rw, `{"detail":"Not found."}`, http.StatusNotFound)
+			return
+		}
+
+		if req.Method == http.MethodPatch {
+			update := &internal.RRSet{}
+			if err := json.NewDecoder(req.Body).Decode(update); err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			if len(update.Records) == 0 {
+				delete(rrSets, "_acme-challenge")
+				rw.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			rrSet.Records = update.Records
+		}
+
+		_ = json.NewEncoder(rw).Encode(rrSet)
+	})
+
+	config := NewDefaultConfig()
+	config.Token = "token"
+	config.BaseURL = server.URL
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	return provider, rrSets, server.Close
+}
+
+func TestDNSProvider_Present_mergeRRSet(t *testing.T) {
+	provider, rrSets, tearDown := setupRRSetsTest(t)
+	defer tearDown()
+
+	err := provider.Present("example.com", "", "foo")
+	require.NoError(t, err)
+
+	require.Contains(t, rrSets, "_acme-challenge")
+	assert.Len(t, rrSets["_acme-challenge"].Records, 1)
+	assert.Equal(t, minTTL, rrSets["_acme-challenge"].TTL)
+
+	// wildcard and domain: same name, two values.
+	err = provider.Present("example.com", "", "bar")
+	require.NoError(t, err)
+
+	assert.Len(t, rrSets["_acme-challenge"].Records, 2)
+
+	err = provider.CleanUp("example.com", "", "foo")
+	require.NoError(t, err)
+
+	require.Contains(t, rrSets, "_acme-challenge")
+	_, value := dns01.GetRecord("example.com", "bar")
+	assert.Equal(t, []string{`"` + value + `"`}, rrSets["_acme-challenge"].Records)
+
+	err = provider.CleanUp("example.com", "", "bar")
+	require.NoError(t, err)
+
+	assert.NotContains(t, rrSets, "_acme-challenge")
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
@@ -0,0 +1,193 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// DefaultBaseURL the default base URL of the deSEC API.
+const DefaultBaseURL = "https://desec.io/api/v1"
+
+// maxRetryAfter the maximum waiting time before retrying a throttled request.
+const maxRetryAfter = 2 * time.Minute
+
+// Domain a domain.
+type Domain struct {
+	Name string `json:"name,omitempty"`
+}
+
+// RRSet a resource record set: all the records with the same name and type.
+type RRSet struct {
+	Domain  string   `json:"domain,omitempty"`
+	SubName string   `json:"subname"`
+	Type    string   `json:"type"`
+	Records []string `json:"records"`
+	TTL     int      `json:"ttl,omitempty"`
+}
+
+// NotFound the error returned when a resource doesn't exist.
+type NotFound struct {
+	Detail string `json:"detail"`
+}
+
+func (n *NotFound) Error() string {
+	return n.Detail
+}
+
+// Client the deSEC API client.
+type Client struct {
+	token      string
+	BaseURL    string
+	HTTPClient *http.Client
+	// MaxRetries the maximum number of retries of a throttled request.
+	MaxRetries int
+}
+
+// NewClient creates a deSEC API client.
+func NewClient(token string) *Client {
+	return &Client{
+		token:      token,
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{},
+		MaxRetries: 5,
+	}
+}
+
+// GetResponsibleDomain returns the domain of the account responsible for a name.
+func (c *Client) GetResponsibleDomain(name string) (*Domain, error) {
+	query := url.Values{}
+	query.Set("owns_qname", name)
+
+	var domains []Domain
+	err := c.do(http.MethodGet, "/domains/?"+query.Encode(), nil, &domains)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the domain of %s: %v", name, err)
+	}
+
+	if len(domains) == 0 {
+		return nil, fmt.Errorf("no domain found for %s", name)
+	}
+
+	return &domains[0], nil
+}
+
+// GetTxtRRSet returns the TXT RRSet of a subname, or a NotFound error.
+func (c *Client) GetTxtRRSet(domainName, subName string) (*RRSet, error) {
+	rrSet := &RRSet{}
+	err := c.do(http.MethodGet, rrSetPath(domainName, subName), nil, rrSet)
+	if err != nil {
+		return nil, err
+	}
+
+	return rrSet, nil
+}
+
+// AddTxtRRSet creates a TXT RRSet.
+func (c *Client) AddTxtRRSet(rrSet RRSet) (*RRSet, error) {
+	rrSet.Type = "TXT"
+
+	created := &RRSet{}
+	err := c.do(http.MethodPost, fmt.Sprintf("/domains/%s/rrsets/", rrSet.Domain), rrSet, created)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create RRSet: %v", err)
+	}
+
+	return created, nil
+}
+
+// UpdateTxtRRSet replaces the records of a TXT RRSet, the RRSet is deleted when there are no more records.
+func (c *Client) UpdateTxtRRSet(domainName, subName string, records []string) (*RRSet, error) {
+	updated := &RRSet{}
+	err := c.do(http.MethodPatch, rrSetPath(domainName, subName), RRSet{SubName: subName, Type: "TXT", Records: records}, updated)
+	if err != nil {
+		return nil, fmt.Errorf("unable to update RRSet: %v", err)
+	}
+
+	return updated, nil
+}
+
+func rrSetPath(domainName, subName string) string {
+	if subName == "" {
+		// the empty subname is represented by @ in the URL.
+		subName = "@"
+	}
+
+	return fmt.Sprintf("/domains/%s/rrsets/%s/TXT/", domainName, subName)
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body []byte
+	if payload != nil {
+		var err error
+		body, err = json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequest(method, c.BaseURL+uri, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Authorization", "Token "+c.token)
+		if payload != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			return err
+		}
+
+		raw, err := ioutil.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusTooManyRequests && attempt < c.MaxRetries:
+			// deSEC throttles the write requests by minute, the waiting time is defined by Retry-After.
+			time.Sleep(retryAfter(resp))
+			continue
+
+		case resp.StatusCode == http.StatusNotFound:
+			notFound := &NotFound{}
+			if json.Unmarshal(raw, notFound) != nil || notFound.Detail == "" {
+				notFound.Detail = string(raw)
+			}
+			return notFound
+
+		case resp.StatusCode/100 != 2:
+			return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+
+		case result == nil || resp.StatusCode == http.StatusNoContent:
+			return nil
+		}
+
+		return json.Unmarshal(raw, result)
+	}
+}
+
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return time.Second
+	}
+
+	wait := time.Duration(seconds) * time.Second
+	if wait > maxRetryAfter {
+		return maxRetryAfter
+	}
+
+	return wait
+}
@@ -0,0 +1,129 @@
+package internal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest() (*http.ServeMux, *Client, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client := NewClient("token")
+	client.BaseURL = server.URL
+
+	return mux, client, server.Close
+}
+
+func TestClient_GetResponsibleDomain(t *testing.T) {
+	mux, client, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/domains/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Token token" {
+			http.Error(rw, `{"detail":"Invalid token."}`, http.StatusUnauthorized)
+			return
+		}
+
+		if req.URL.Query().Get("owns_qname") == "_acme-challenge.www.example.com" {
+			fmt.Fprint(rw, `[{"name":"example.com","minimum_ttl":3600}]`)
+			return
+		}
+
+		fmt.Fprint(rw, `[]`)
+	})
+
+	domain, err := client.GetResponsibleDomain("_acme-challenge.www.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, &Domain{Name: "example.com"}, domain)
+
+	_, err = client.GetResponsibleDomain("_acme-challenge.example.org")
+	require.EqualError(t, err, "no domain found for _acme-challenge.example.org")
+}
+
+func TestClient_GetTxtRRSet(t *testing.T) {
+	mux, client, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/domains/example.com/rrsets/_acme-challenge/TXT/", func(rw http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(rw, `{"domain":"example.com","subname":"_acme-challenge","type":"TXT","records":["\"foo\""],"ttl":3600}`)
+	})
+	mux.HandleFunc("/domains/example.com/rrsets/@/TXT/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(rw, `{"detail":"Not found."}`)
+	})
+
+	rrSet, err := client.GetTxtRRSet("example.com", "_acme-challenge")
+	require.NoError(t, err)
+
+	expected := &RRSet{Domain: "example.com", SubName: "_acme-challenge", Type: "TXT", Records: []string{`"foo"`}, TTL: 3600}
+	assert.Equal(t, expected, rrSet)
+
+	_, err = client.GetTxtRRSet("example.com", "")
+	require.Error(t, err)
+	assert.IsType(t, &NotFound{}, err)
+	assert.EqualError(t, err, "Not found.")
+}
+
+func TestClient_UpdateTxtRRSet_throttled(t *testing.T) {
+	mux, client, tearDown := setupTest()
+	defer tearDown()
+
+	var calls int
+	mux.HandleFunc("/domains/example.com/rrsets/_acme-challenge/TXT/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPatch {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		calls++
+		if calls == 1 {
+			rw.Header().Set("Retry-After", "0")
+			rw.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(rw, `{"detail":"Request was throttled. Expected available in 0 seconds."}`)
+			return
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := `{"subname":"_acme-challenge","type":"TXT","records":["\"foo\"","\"bar\""]}`
+		if string(body) != expected {
+			http.Error(rw, fmt.Sprintf("unexpected body: %s", string(body)), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `{"domain":"example.com","subname":"_acme-challenge","type":"TXT","records":["\"foo\"","\"bar\""],"ttl":3600}`)
+	})
+
+	rrSet, err := client.UpdateTxtRRSet("example.com", "_acme-challenge", []string{`"foo"`, `"bar"`})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, []string{`"foo"`, `"bar"`}, rrSet.Records)
+}
+
+func TestClient_UpdateTxtRRSet_tooManyRetries(t *testing.T) {
+	mux, client, tearDown := setupTest()
+	defer tearDown()
+
+	client.MaxRetries = 1
+
+	mux.HandleFunc("/domains/example.com/rrsets/_acme-challenge/TXT/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Retry-After", "0")
+		rw.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(rw, `{"detail":"Request was throttled."}`)
+	})
+
+	_, err := client.UpdateTxtRRSet("example.com", "_acme-challenge", []string{})
+	require.EqualError(t, err, `unable to update RRSet: 429: {"detail":"Request was throttled."}`)
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	"github.com/go-acme/lego/v3/challenge/dns01"
 )
@@ -39,12 +40,8 @@ func (d *DNSProvider) removeTxtRecord(domain string, recordID int) error {
 	}
 
 	reqURL := fmt.Sprintf("%s/v2/domains/%s/records/%d", d.config.BaseURL, dns01.UnFqdn(authZone), recordID)
-	req, err := d.newRequest(http.MethodDelete, reqURL, nil)
-	if err != nil {
-		return err
-	}
 
-	resp, err := d.config.HTTPClient.Do(req)
+	req, resp, err := d.do(http.MethodDelete, reqURL, nil)
 	if err != nil {
 		return err
 	}
@@ -70,12 +67,8 @@ func (d *DNSProvider) addTxtRecord(fqdn, value string) (*txtRecordResponse, erro
 	}
 
 	reqURL := fmt.Sprintf("%s/v2/domains/%s/records", d.config.BaseURL, dns01.UnFqdn(authZone))
-	req, err := d.newRequest(http.MethodPost, reqURL, bytes.NewReader(body))
-	if err != nil {
-		return nil, err
-	}
 
-	resp, err := d.config.HTTPClient.Do(req)
+	req, resp, err := d.do(http.MethodPost, reqURL, body)
 	if err != nil {
 		return nil, err
 	}
@@ -100,6 +93,55 @@ func (d *DNSProvider) addTxtRecord(fqdn, value string) (*txtRecordResponse, erro
 	return respData, nil
 }
 
+func (d *DNSProvider) getAccount() error {
+	reqURL := fmt.Sprintf("%s/v2/account", d.config.BaseURL)
+
+	req, resp, err := d.do(http.MethodGet, reqURL, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return readError(req, resp)
+	}
+
+	return nil
+}
+
+// do sends a request, waiting for the reset of the rate limit when needed,
+// and retries it when it is rejected by the rate limit (429).
+func (d *DNSProvider) do(method, reqURL string, body []byte) (*http.Request, *http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		time.Sleep(d.rateLimit.wait(time.Now()))
+
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+
+		req, err := d.newRequest(method, reqURL, reader)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		resp, err := d.config.HTTPClient.Do(req)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		d.rateLimit.update(resp.Header)
+
+		if resp.StatusCode != http.StatusTooManyRequests || attempt >= maxRateLimitRetries {
+			return req, resp, nil
+		}
+
+		_ = resp.Body.Close()
+
+		time.Sleep(retryDelay(resp.Header, attempt, time.Now()))
+	}
+}
+
 func (d *DNSProvider) newRequest(method, reqURL string, body io.Reader) (*http.Request, error) {
 	req, err := http.NewRequest(method, reqURL, body)
 	if err != nil {
@@ -12,6 +12,8 @@ import (
 	"github.com/go-acme/lego/v3/platform/config/env"
 )
 
+const minTTL = 30
+
 // Config is used to configure the creation of the DNSProvider
 type Config struct {
 	BaseURL            string
@@ -26,7 +28,7 @@ type Config struct {
 func NewDefaultConfig() *Config {
 	return &Config{
 		BaseURL:            defaultBaseURL,
-		TTL:                env.GetOrDefaultInt("DO_TTL", 30),
+		TTL:                env.GetOrDefaultInt("DO_TTL", minTTL),
 		PropagationTimeout: env.GetOrDefaultSecond("DO_PROPAGATION_TIMEOUT", 60*time.Second),
 		PollingInterval:    env.GetOrDefaultSecond("DO_POLLING_INTERVAL", 5*time.Second),
 		HTTPClient: &http.Client{
@@ -39,6 +41,7 @@ func NewDefaultConfig() *Config {
 // that uses DigitalOcean's REST API to manage TXT records for a domain.
 type DNSProvider struct {
 	config      *Config
+	rateLimit   *rateLimit
 	recordIDs   map[string]int
 	recordIDsMu sync.Mutex
 }
@@ -68,12 +71,17 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, fmt.Errorf("digitalocean: credentials missing")
 	}
 
+	if config.TTL < minTTL {
+		return nil, fmt.Errorf("digitalocean: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	}
+
 	if config.BaseURL == "" {
 		config.BaseURL = defaultBaseURL
 	}
 
 	return &DNSProvider{
 		config:    config,
+		rateLimit: newRateLimit(),
 		recordIDs: make(map[string]int),
 	}, nil
 }
@@ -84,6 +92,16 @@ func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
 	return d.config.PropagationTimeout, d.config.PollingInterval
 }
 
+// CheckCredentials reads the account of the authentication token.
+func (d *DNSProvider) CheckCredentials() error {
+	err := d.getAccount()
+	if err != nil {
+		return fmt.Errorf("digitalocean: %v", err)
+	}
+
+	return nil
+}
+
 // Present creates a TXT record using the specified parameters
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	fqdn, value := dns01.GetRecord(domain, keyAuth)
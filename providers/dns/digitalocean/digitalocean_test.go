@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-acme/lego/v3/platform/tester"
 	"github.com/stretchr/testify/assert"
@@ -76,22 +77,32 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	testCases := []struct {
 		desc      string
 		authToken string
+		ttl       int
 		expected  string
 	}{
 		{
 			desc:      "success",
 			authToken: "123",
+			ttl:       30,
 		},
 		{
 			desc:     "missing credentials",
+			ttl:      30,
 			expected: "digitalocean: credentials missing",
 		},
+		{
+			desc:      "invalid TTL",
+			authToken: "123",
+			ttl:       10,
+			expected:  "digitalocean: invalid TTL, TTL (10) must be greater than 30",
+		},
 	}
 
 	for _, test := range testCases {
 		t.Run(test.desc, func(t *testing.T) {
 			config := NewDefaultConfig()
 			config.AuthToken = test.authToken
+			config.TTL = test.ttl
 
 			p, err := NewDNSProviderConfig(config)
 
@@ -169,3 +180,147 @@ func TestDNSProvider_CleanUp(t *testing.T) {
 	err := provider.CleanUp("example.com", "", "")
 	require.NoError(t, err, "fail to remove TXT record")
 }
+
+func TestDNSProvider_CheckCredentials(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/v2/account", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "method")
+		assert.Equal(t, "Bearer asdf1234", r.Header.Get("Authorization"), "Authorization")
+
+		fmt.Fprintf(w, `{"account":{"email":"foo@example.com","status":"active"}}`)
+	})
+
+	err := provider.CheckCredentials()
+	require.NoError(t, err)
+}
+
+func TestDNSProvider_CheckCredentials_unauthorized(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/v2/account", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprintf(w, `{"id":"unauthorized","message":"Unable to authenticate you."}`)
+	})
+
+	err := provider.CheckCredentials()
+	require.EqualError(t, err, "digitalocean: HTTP 401: unauthorized: Unable to authenticate you.")
+}
+
+func TestDNSProvider_Present_rateLimit(t *testing.T) {
+	defer func(delay time.Duration) { retryBaseDelay = delay }(retryBaseDelay)
+	retryBaseDelay = time.Millisecond
+
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	var calls int
+	mux.HandleFunc("/v2/domains/example.com/records", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		reqBody, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		// the body must be sent again.
+		assert.Contains(t, string(reqBody), `"data":"w6uP8Tcg6K2QR905Rms8iXTlksL6OD1KOWBxTK7wxPI"`)
+
+		if calls < 3 {
+			w.Header().Set("RateLimit-Remaining", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"id":"too_many_requests","message":"API Rate limit exceeded."}`)
+			return
+		}
+
+		w.Header().Set("RateLimit-Remaining", "249")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"domain_record":{"id":1234567,"type":"TXT","name":"_acme-challenge","data":"w6uP8Tcg6K2QR905Rms8iXTlksL6OD1KOWBxTK7wxPI"}}`)
+	})
+
+	err := provider.Present("example.com", "", "foobar")
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, calls)
+}
+
+func TestDNSProvider_Present_rateLimitExceeded(t *testing.T) {
+	defer func(delay time.Duration) { retryBaseDelay = delay }(retryBaseDelay)
+	retryBaseDelay = time.Millisecond
+
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	var calls int
+	mux.HandleFunc("/v2/domains/example.com/records", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"id":"too_many_requests","message":"API Rate limit exceeded."}`)
+	})
+
+	err := provider.Present("example.com", "", "foobar")
+	require.EqualError(t, err, "digitalocean: HTTP 429: too_many_requests: API Rate limit exceeded.")
+
+	assert.Equal(t, maxRateLimitRetries+1, calls)
+}
+
+func TestRateLimit_wait(t *testing.T) {
+	now := time.Unix(1566000000, 0)
+
+	testCases := []struct {
+		desc     string
+		header   http.Header
+		expected time.Duration
+	}{
+		{
+			desc:     "no headers",
+			header:   http.Header{},
+			expected: 0,
+		},
+		{
+			desc: "remaining requests",
+			header: http.Header{
+				"Ratelimit-Remaining": []string{"10"},
+				"Ratelimit-Reset":     []string{"1566000030"},
+			},
+			expected: 0,
+		},
+		{
+			desc: "no remaining request",
+			header: http.Header{
+				"Ratelimit-Remaining": []string{"0"},
+				"Ratelimit-Reset":     []string{"1566000030"},
+			},
+			expected: 30 * time.Second,
+		},
+		{
+			desc: "reset in the past",
+			header: http.Header{
+				"Ratelimit-Remaining": []string{"0"},
+				"Ratelimit-Reset":     []string{"1565999990"},
+			},
+			expected: 0,
+		},
+		{
+			desc: "long reset",
+			header: http.Header{
+				"Ratelimit-Remaining": []string{"0"},
+				"Ratelimit-Reset":     []string{"1566003600"},
+			},
+			expected: maxRateLimitWait,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			limit := newRateLimit()
+			limit.update(test.header)
+
+			assert.Equal(t, test.expected, limit.wait(now))
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package digitalocean
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// https://developers.digitalocean.com/documentation/v2/#rate-limit
+const (
+	headerRateLimitRemaining = "RateLimit-Remaining"
+	headerRateLimitReset     = "RateLimit-Reset"
+)
+
+const (
+	// maxRateLimitRetries is the maximum number of retries of a request rejected by the rate limit (429).
+	maxRateLimitRetries = 5
+	// maxRateLimitWait is the maximum waiting time before a request.
+	maxRateLimitWait = 2 * time.Minute
+)
+
+// retryBaseDelay is the waiting time before the first retry when the reset time of the rate limit is unknown.
+// It is overridden during tests.
+var retryBaseDelay = 1 * time.Second
+
+// rateLimit tracks the rate limit of the API from the headers of the responses.
+type rateLimit struct {
+	mu        sync.Mutex
+	remaining int
+	reset     time.Time
+}
+
+func newRateLimit() *rateLimit {
+	return &rateLimit{remaining: -1}
+}
+
+// update reads the rate limit headers of a response.
+func (r *rateLimit) update(header http.Header) {
+	remaining, err := strconv.Atoi(header.Get(headerRateLimitRemaining))
+	if err != nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.remaining = remaining
+	r.reset = parseReset(header)
+}
+
+// wait returns the waiting time before the next request:
+// the time until the reset of the rate limit if no request remains.
+func (r *rateLimit) wait(now time.Time) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.remaining != 0 || r.reset.IsZero() {
+		return 0
+	}
+
+	return capWait(r.reset.Sub(now))
+}
+
+// retryDelay returns the waiting time before retrying a request rejected by the rate limit.
+func retryDelay(header http.Header, attempt int, now time.Time) time.Duration {
+	if reset := parseReset(header); !reset.IsZero() {
+		return capWait(reset.Sub(now))
+	}
+
+	return capWait(retryBaseDelay * time.Duration(1<<uint(attempt)))
+}
+
+// parseReset parses the reset time of the rate limit (Unix epoch in seconds).
+func parseReset(header http.Header) time.Time {
+	reset, err := strconv.ParseInt(header.Get(headerRateLimitReset), 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return time.Unix(reset, 0)
+}
+
+func capWait(wait time.Duration) time.Duration {
+	if wait < 0 {
+		return 0
+	}
+
+	if wait > maxRateLimitWait {
+		return maxRateLimitWait
+	}
+
+	return wait
+}
@@ -5,16 +5,23 @@ import (
 
 	"github.com/go-acme/lego/v3/challenge"
 	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
 	"github.com/go-acme/lego/v3/providers/dns/acmedns"
 	"github.com/go-acme/lego/v3/providers/dns/alidns"
+	"github.com/go-acme/lego/v3/providers/dns/arvancloud"
 	"github.com/go-acme/lego/v3/providers/dns/auroradns"
 	"github.com/go-acme/lego/v3/providers/dns/azure"
 	"github.com/go-acme/lego/v3/providers/dns/bindman"
 	"github.com/go-acme/lego/v3/providers/dns/bluecat"
+	"github.com/go-acme/lego/v3/providers/dns/bunny"
+	"github.com/go-acme/lego/v3/providers/dns/civo"
 	"github.com/go-acme/lego/v3/providers/dns/cloudflare"
 	"github.com/go-acme/lego/v3/providers/dns/cloudns"
 	"github.com/go-acme/lego/v3/providers/dns/cloudxns"
+	"github.com/go-acme/lego/v3/providers/dns/combell"
 	"github.com/go-acme/lego/v3/providers/dns/conoha"
+	"github.com/go-acme/lego/v3/providers/dns/constellix"
+	"github.com/go-acme/lego/v3/providers/dns/desec"
 	"github.com/go-acme/lego/v3/providers/dns/designate"
 	"github.com/go-acme/lego/v3/providers/dns/digitalocean"
 	"github.com/go-acme/lego/v3/providers/dns/dnsimple"
@@ -24,49 +31,89 @@ import (
 	"github.com/go-acme/lego/v3/providers/dns/dreamhost"
 	"github.com/go-acme/lego/v3/providers/dns/duckdns"
 	"github.com/go-acme/lego/v3/providers/dns/dyn"
+	"github.com/go-acme/lego/v3/providers/dns/dynu"
 	"github.com/go-acme/lego/v3/providers/dns/easydns"
+	"github.com/go-acme/lego/v3/providers/dns/epik"
 	"github.com/go-acme/lego/v3/providers/dns/exec"
 	"github.com/go-acme/lego/v3/providers/dns/exoscale"
 	"github.com/go-acme/lego/v3/providers/dns/fastdns"
+	"github.com/go-acme/lego/v3/providers/dns/freemyip"
 	"github.com/go-acme/lego/v3/providers/dns/gandi"
 	"github.com/go-acme/lego/v3/providers/dns/gandiv5"
 	"github.com/go-acme/lego/v3/providers/dns/gcloud"
 	"github.com/go-acme/lego/v3/providers/dns/glesys"
 	"github.com/go-acme/lego/v3/providers/dns/godaddy"
+	"github.com/go-acme/lego/v3/providers/dns/googledomains"
+	"github.com/go-acme/lego/v3/providers/dns/hetzner"
 	"github.com/go-acme/lego/v3/providers/dns/hostingde"
+	"github.com/go-acme/lego/v3/providers/dns/hosttech"
 	"github.com/go-acme/lego/v3/providers/dns/httpreq"
+	"github.com/go-acme/lego/v3/providers/dns/hurricane"
 	"github.com/go-acme/lego/v3/providers/dns/iij"
+	"github.com/go-acme/lego/v3/providers/dns/infoblox"
+	"github.com/go-acme/lego/v3/providers/dns/infomaniak"
 	"github.com/go-acme/lego/v3/providers/dns/inwx"
+	"github.com/go-acme/lego/v3/providers/dns/ionos"
 	"github.com/go-acme/lego/v3/providers/dns/joker"
 	"github.com/go-acme/lego/v3/providers/dns/lightsail"
 	"github.com/go-acme/lego/v3/providers/dns/linode"
 	"github.com/go-acme/lego/v3/providers/dns/linodev4"
 	"github.com/go-acme/lego/v3/providers/dns/liquidweb"
+	"github.com/go-acme/lego/v3/providers/dns/loopia"
 	"github.com/go-acme/lego/v3/providers/dns/mydnsjp"
+	"github.com/go-acme/lego/v3/providers/dns/mythicbeasts"
 	"github.com/go-acme/lego/v3/providers/dns/namecheap"
 	"github.com/go-acme/lego/v3/providers/dns/namedotcom"
 	"github.com/go-acme/lego/v3/providers/dns/namesilo"
 	"github.com/go-acme/lego/v3/providers/dns/netcup"
 	"github.com/go-acme/lego/v3/providers/dns/nifcloud"
+	"github.com/go-acme/lego/v3/providers/dns/njalla"
 	"github.com/go-acme/lego/v3/providers/dns/ns1"
 	"github.com/go-acme/lego/v3/providers/dns/oraclecloud"
 	"github.com/go-acme/lego/v3/providers/dns/otc"
 	"github.com/go-acme/lego/v3/providers/dns/ovh"
 	"github.com/go-acme/lego/v3/providers/dns/pdns"
+	"github.com/go-acme/lego/v3/providers/dns/plesk"
+	"github.com/go-acme/lego/v3/providers/dns/porkbun"
 	"github.com/go-acme/lego/v3/providers/dns/rackspace"
+	"github.com/go-acme/lego/v3/providers/dns/rcodezero"
 	"github.com/go-acme/lego/v3/providers/dns/rfc2136"
 	"github.com/go-acme/lego/v3/providers/dns/route53"
+	"github.com/go-acme/lego/v3/providers/dns/safedns"
 	"github.com/go-acme/lego/v3/providers/dns/sakuracloud"
+	"github.com/go-acme/lego/v3/providers/dns/scaleway"
 	"github.com/go-acme/lego/v3/providers/dns/selectel"
 	"github.com/go-acme/lego/v3/providers/dns/stackpath"
+	"github.com/go-acme/lego/v3/providers/dns/tencentcloud"
 	"github.com/go-acme/lego/v3/providers/dns/transip"
+	"github.com/go-acme/lego/v3/providers/dns/ultradns"
+	"github.com/go-acme/lego/v3/providers/dns/variomedia"
 	"github.com/go-acme/lego/v3/providers/dns/vegadns"
+	"github.com/go-acme/lego/v3/providers/dns/vercel"
 	"github.com/go-acme/lego/v3/providers/dns/versio"
 	"github.com/go-acme/lego/v3/providers/dns/vscale"
 	"github.com/go-acme/lego/v3/providers/dns/vultr"
+	"github.com/go-acme/lego/v3/providers/dns/websupport"
+	"github.com/go-acme/lego/v3/providers/dns/world4you"
+	"github.com/go-acme/lego/v3/providers/dns/yandexcloud"
 	"github.com/go-acme/lego/v3/providers/dns/zoneee"
 )
 
+// builtinProviders the names of the DNS providers handled by NewDNSChallengeProviderByName,
+// they cannot be used by the third-party providers (see RegisterProvider).
+var builtinProviders = []string{
+	"acme-dns", "alidns", "arvancloud", "auroradns", "azure", "bindman", "bluecat", "bunny", "civo", "cloudflare",
+	"cloudns", "cloudxns", "combell", "conoha", "constellix", "desec", "designate", "digitalocean", "dnsimple",
+	"dnsmadeeasy", "dnspod", "dode", "dreamhost", "duckdns", "dyn", "dynu", "easydns", "epik", "exec", "exoscale",
+	"fastdns", "freemyip", "gandi", "gandiv5", "gcloud", "glesys", "godaddy", "googledomains", "hetzner", "hostingde",
+	"hosttech", "httpreq", "hurricane", "iij", "infoblox", "infomaniak", "inwx", "ionos", "joker", "lightsail", "linode",
+	"linodev4", "liquidweb", "loopia", "manual", "mydnsjp", "mythicbeasts", "namecheap", "namedotcom", "namesilo",
+	"netcup", "nifcloud", "njalla", "ns1", "oraclecloud", "otc", "ovh", "pdns", "plesk", "porkbun", "rackspace",
+	"rcodezero", "rfc2136", "route53", "safedns", "sakuracloud", "scaleway", "selectel", "stackpath", "tencentcloud",
+	"transip", "ultradns", "variomedia", "vegadns", "vercel", "versio", "vscale", "vultr", "websupport", "world4you",
+	"yandexcloud", "zoneee",
+}
+
 // NewDNSChallengeProviderByName Factory for DNS providers
 func NewDNSChallengeProviderByName(name string) (challenge.Provider, error) {
 	switch name {
@@ -74,6 +121,8 @@ func NewDNSChallengeProviderByName(name string) (challenge.Provider, error) {
 		return acmedns.NewDNSProvider()
 	case "alidns":
 		return alidns.NewDNSProvider()
+	case "arvancloud":
+		return arvancloud.NewDNSProvider()
 	case "azure":
 		return azure.NewDNSProvider()
 	case "auroradns":
@@ -82,14 +131,24 @@ func NewDNSChallengeProviderByName(name string) (challenge.Provider, error) {
 		return bindman.NewDNSProvider()
 	case "bluecat":
 		return bluecat.NewDNSProvider()
+	case "bunny":
+		return bunny.NewDNSProvider()
+	case "civo":
+		return civo.NewDNSProvider()
 	case "cloudflare":
 		return cloudflare.NewDNSProvider()
 	case "cloudns":
 		return cloudns.NewDNSProvider()
 	case "cloudxns":
 		return cloudxns.NewDNSProvider()
+	case "combell":
+		return combell.NewDNSProvider()
 	case "conoha":
 		return conoha.NewDNSProvider()
+	case "constellix":
+		return constellix.NewDNSProvider()
+	case "desec":
+		return desec.NewDNSProvider()
 	case "designate":
 		return designate.NewDNSProvider()
 	case "digitalocean":
@@ -108,14 +167,20 @@ func NewDNSChallengeProviderByName(name string) (challenge.Provider, error) {
 		return duckdns.NewDNSProvider()
 	case "dyn":
 		return dyn.NewDNSProvider()
+	case "dynu":
+		return dynu.NewDNSProvider()
 	case "fastdns":
 		return fastdns.NewDNSProvider()
 	case "easydns":
 		return easydns.NewDNSProvider()
+	case "epik":
+		return epik.NewDNSProvider()
 	case "exec":
 		return exec.NewDNSProvider()
 	case "exoscale":
 		return exoscale.NewDNSProvider()
+	case "freemyip":
+		return freemyip.NewDNSProvider()
 	case "gandi":
 		return gandi.NewDNSProvider()
 	case "gandiv5":
@@ -126,28 +191,46 @@ func NewDNSChallengeProviderByName(name string) (challenge.Provider, error) {
 		return gcloud.NewDNSProvider()
 	case "godaddy":
 		return godaddy.NewDNSProvider()
+	case "googledomains":
+		return googledomains.NewDNSProvider()
+	case "hetzner":
+		return hetzner.NewDNSProvider()
 	case "hostingde":
 		return hostingde.NewDNSProvider()
+	case "hosttech":
+		return hosttech.NewDNSProvider()
 	case "httpreq":
 		return httpreq.NewDNSProvider()
+	case "hurricane":
+		return hurricane.NewDNSProvider()
 	case "iij":
 		return iij.NewDNSProvider()
+	case "infoblox":
+		return infoblox.NewDNSProvider()
+	case "infomaniak":
+		return infomaniak.NewDNSProvider()
 	case "inwx":
 		return inwx.NewDNSProvider()
+	case "ionos":
+		return ionos.NewDNSProvider()
 	case "joker":
 		return joker.NewDNSProvider()
 	case "lightsail":
 		return lightsail.NewDNSProvider()
 	case "linode":
-		return linode.NewDNSProvider()
+		return newLinodeProvider()
 	case "linodev4":
 		return linodev4.NewDNSProvider()
 	case "liquidweb":
 		return liquidweb.NewDNSProvider()
+	case "loopia":
+		return loopia.NewDNSProvider()
 	case "manual":
 		return dns01.NewDNSProviderManual()
 	case "mydnsjp":
 		return mydnsjp.NewDNSProvider()
+	case "mythicbeasts":
+		return mythicbeasts.NewDNSProvider()
 	case "namecheap":
 		return namecheap.NewDNSProvider()
 	case "namedotcom":
@@ -158,6 +241,8 @@ func NewDNSChallengeProviderByName(name string) (challenge.Provider, error) {
 		return netcup.NewDNSProvider()
 	case "nifcloud":
 		return nifcloud.NewDNSProvider()
+	case "njalla":
+		return njalla.NewDNSProvider()
 	case "ns1":
 		return ns1.NewDNSProvider()
 	case "oraclecloud":
@@ -168,31 +253,68 @@ func NewDNSChallengeProviderByName(name string) (challenge.Provider, error) {
 		return ovh.NewDNSProvider()
 	case "pdns":
 		return pdns.NewDNSProvider()
+	case "plesk":
+		return plesk.NewDNSProvider()
+	case "porkbun":
+		return porkbun.NewDNSProvider()
 	case "rackspace":
 		return rackspace.NewDNSProvider()
+	case "rcodezero":
+		return rcodezero.NewDNSProvider()
 	case "route53":
 		return route53.NewDNSProvider()
 	case "rfc2136":
 		return rfc2136.NewDNSProvider()
+	case "safedns":
+		return safedns.NewDNSProvider()
 	case "sakuracloud":
 		return sakuracloud.NewDNSProvider()
 	case "stackpath":
 		return stackpath.NewDNSProvider()
+	case "scaleway":
+		return scaleway.NewDNSProvider()
 	case "selectel":
 		return selectel.NewDNSProvider()
+	case "tencentcloud":
+		return tencentcloud.NewDNSProvider()
 	case "transip":
 		return transip.NewDNSProvider()
+	case "ultradns":
+		return ultradns.NewDNSProvider()
+	case "variomedia":
+		return variomedia.NewDNSProvider()
 	case "vegadns":
 		return vegadns.NewDNSProvider()
+	case "vercel":
+		return vercel.NewDNSProvider()
 	case "versio":
 		return versio.NewDNSProvider()
 	case "vultr":
 		return vultr.NewDNSProvider()
 	case "vscale":
 		return vscale.NewDNSProvider()
+	case "websupport":
+		return websupport.NewDNSProvider()
+	case "world4you":
+		return world4you.NewDNSProvider()
+	case "yandexcloud":
+		return yandexcloud.NewDNSProvider()
 	case "zoneee":
 		return zoneee.NewDNSProvider()
 	default:
+		if factory, ok := getRegisteredProvider(name); ok {
+			return factory()
+		}
 		return nil, fmt.Errorf("unrecognized DNS provider: %s", name)
 	}
 }
+
+// newLinodeProvider returns the provider of the Linode APIv4 (LINODE_TOKEN),
+// or the provider of the deprecated APIv3 if only LINODE_API_KEY is defined.
+func newLinodeProvider() (challenge.Provider, error) {
+	if env.GetOrFile("LINODE_TOKEN") == "" && env.GetOrFile("LINODE_API_KEY") != "" {
+		return linode.NewDNSProvider()
+	}
+
+	return linodev4.NewDNSProvider()
+}
@@ -5,11 +5,13 @@ import (
 
 	"github.com/go-acme/lego/v3/platform/tester"
 	"github.com/go-acme/lego/v3/providers/dns/exec"
+	"github.com/go-acme/lego/v3/providers/dns/linode"
+	"github.com/go-acme/lego/v3/providers/dns/linodev4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-var envTest = tester.NewEnvTest("EXEC_PATH")
+var envTest = tester.NewEnvTest("EXEC_PATH", "LINODE_TOKEN", "LINODE_API_KEY")
 
 func TestKnownDNSProviderSuccess(t *testing.T) {
 	defer envTest.RestoreEnv()
@@ -38,3 +40,49 @@ func TestUnknownDNSProvider(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, provider)
 }
+
+func TestLinodeDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected interface{}
+	}{
+		{
+			desc:     "APIv4 token",
+			envVars:  map[string]string{"LINODE_TOKEN": "123"},
+			expected: &linodev4.DNSProvider{},
+		},
+		{
+			desc:     "APIv3 key",
+			envVars:  map[string]string{"LINODE_API_KEY": "123"},
+			expected: &linode.DNSProvider{},
+		},
+		{
+			desc:     "APIv4 token and APIv3 key",
+			envVars:  map[string]string{"LINODE_TOKEN": "123", "LINODE_API_KEY": "456"},
+			expected: &linodev4.DNSProvider{},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			provider, err := NewDNSChallengeProviderByName("linode")
+			require.NoError(t, err)
+
+			assert.IsType(t, test.expected, provider)
+		})
+	}
+}
+
+func TestLinodeDNSProvider_missingCredentials(t *testing.T) {
+	defer envTest.RestoreEnv()
+	envTest.ClearEnv()
+
+	_, err := NewDNSChallengeProviderByName("linode")
+	require.EqualError(t, err, "linodev4: some credentials information are missing: LINODE_TOKEN")
+}
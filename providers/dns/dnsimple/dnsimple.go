@@ -15,10 +15,19 @@ import (
 	"golang.org/x/oauth2"
 )
 
+// sandboxBaseURL the base URL of the DNSimple sandbox environment.
+const sandboxBaseURL = "https://api.sandbox.dnsimple.com"
+
 // Config is used to configure the creation of the DNSProvider
 type Config struct {
-	AccessToken        string
-	BaseURL            string
+	AccessToken string
+	BaseURL     string
+	// Sandbox uses the sandbox environment when BaseURL is not defined.
+	Sandbox bool
+	// AccountID the ID of the account managing the zones.
+	// It is required for a token having access to several accounts (user token),
+	// otherwise the account of the token is used.
+	AccountID          string
 	PropagationTimeout time.Duration
 	PollingInterval    time.Duration
 	TTL                int
@@ -27,6 +36,8 @@ type Config struct {
 // NewDefaultConfig returns a default configuration for the DNSProvider
 func NewDefaultConfig() *Config {
 	return &Config{
+		Sandbox:            env.GetOrDefaultBool("DNSIMPLE_SANDBOX", false),
+		AccountID:          env.GetOrFile("DNSIMPLE_ACCOUNT_ID"),
 		TTL:                env.GetOrDefaultInt("DNSIMPLE_TTL", dns01.DefaultTTL),
 		PropagationTimeout: env.GetOrDefaultSecond("DNSIMPLE_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
 		PollingInterval:    env.GetOrDefaultSecond("DNSIMPLE_POLLING_INTERVAL", dns01.DefaultPollingInterval),
@@ -41,6 +52,7 @@ type DNSProvider struct {
 
 // NewDNSProvider returns a DNSProvider instance configured for dnsimple.
 // Credentials must be passed in the environment variables: DNSIMPLE_OAUTH_TOKEN.
+// DNSIMPLE_ACCOUNT_ID selects the account of a token having access to several accounts.
 //
 // See: https://developer.dnsimple.com/v2/#authentication
 func NewDNSProvider() (*DNSProvider, error) {
@@ -64,8 +76,11 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: config.AccessToken})
 	client := dnsimple.NewClient(oauth2.NewClient(context.Background(), ts))
 
-	if config.BaseURL != "" {
+	switch {
+	case config.BaseURL != "":
 		client.BaseURL = config.BaseURL
+	case config.Sandbox:
+		client.BaseURL = sandboxBaseURL
 	}
 
 	return &DNSProvider{client: client, config: config}, nil
@@ -198,13 +213,17 @@ func extractRecordName(fqdn, domain string) string {
 }
 
 func (d *DNSProvider) getAccountID() (string, error) {
+	if d.config.AccountID != "" {
+		return d.config.AccountID, nil
+	}
+
 	whoamiResponse, err := d.client.Identity.Whoami()
 	if err != nil {
 		return "", err
 	}
 
 	if whoamiResponse.Data.Account == nil {
-		return "", fmt.Errorf("user tokens are not supported, please use an account token")
+		return "", fmt.Errorf("the account ID is required with a user token, please define the account ID or use an account token")
 	}
 
 	return strconv.FormatInt(whoamiResponse.Data.Account.ID, 10), nil
@@ -14,7 +14,9 @@ const sandboxURL = "https://api.sandbox.fake.com"
 
 var envTest = tester.NewEnvTest(
 	"DNSIMPLE_OAUTH_TOKEN",
-	"DNSIMPLE_BASE_URL").
+	"DNSIMPLE_BASE_URL",
+	"DNSIMPLE_SANDBOX",
+	"DNSIMPLE_ACCOUNT_ID").
 	WithDomain("DNSIMPLE_DOMAIN").
 	WithLiveTestRequirements("DNSIMPLE_OAUTH_TOKEN", "DNSIMPLE_DOMAIN")
 
@@ -37,6 +39,13 @@ func TestNewDNSProvider(t *testing.T) {
 				"DNSIMPLE_BASE_URL":    "https://api.dnsimple.test",
 			},
 		},
+		{
+			desc: "success: sandbox",
+			envVars: map[string]string{
+				"DNSIMPLE_OAUTH_TOKEN": "my_token",
+				"DNSIMPLE_SANDBOX":     "true",
+			},
+		},
 		{
 			desc: "missing oauth token",
 			envVars: map[string]string{
@@ -65,6 +74,10 @@ func TestNewDNSProvider(t *testing.T) {
 				if baseURL != "" {
 					assert.Equal(t, baseURL, p.client.BaseURL)
 				}
+
+				if os.Getenv("DNSIMPLE_SANDBOX") == "true" {
+					assert.Equal(t, sandboxBaseURL, p.client.BaseURL)
+				}
 			} else {
 				require.EqualError(t, err, test.expected)
 			}
@@ -120,6 +133,20 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func TestDNSProvider_getAccountID(t *testing.T) {
+	config := NewDefaultConfig()
+	config.AccessToken = "my_token"
+	config.AccountID = "1010"
+
+	p, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	accountID, err := p.getAccountID()
+	require.NoError(t, err)
+
+	assert.Equal(t, "1010", accountID)
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
@@ -14,6 +14,11 @@ import (
 	"github.com/go-acme/lego/v3/providers/dns/dnsmadeeasy/internal"
 )
 
+const (
+	defaultBaseURL = "https://api.dnsmadeeasy.com/V2.0"
+	sandboxBaseURL = "https://api.sandbox.dnsmadeeasy.com/V2.0"
+)
+
 // Config is used to configure the creation of the DNSProvider
 type Config struct {
 	BaseURL            string
@@ -29,6 +34,8 @@ type Config struct {
 // NewDefaultConfig returns a default configuration for the DNSProvider
 func NewDefaultConfig() *Config {
 	return &Config{
+		BaseURL:            env.GetOrFile("DNSMADEEASY_ENDPOINT"),
+		Sandbox:            env.GetOrDefaultBool("DNSMADEEASY_SANDBOX", false),
 		TTL:                env.GetOrDefaultInt("DNSMADEEASY_TTL", dns01.DefaultTTL),
 		PropagationTimeout: env.GetOrDefaultSecond("DNSMADEEASY_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
 		PollingInterval:    env.GetOrDefaultSecond("DNSMADEEASY_POLLING_INTERVAL", dns01.DefaultPollingInterval),
@@ -58,7 +65,6 @@ func NewDNSProvider() (*DNSProvider, error) {
 	}
 
 	config := NewDefaultConfig()
-	config.Sandbox = env.GetOrDefaultBool("DNSMADEEASY_SANDBOX", false)
 	config.APIKey = values["DNSMADEEASY_API_KEY"]
 	config.APISecret = values["DNSMADEEASY_API_SECRET"]
 
@@ -73,12 +79,12 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 
 	var baseURL string
 	if config.Sandbox {
-		baseURL = "https://api.sandbox.dnsmadeeasy.com/V2.0"
+		baseURL = sandboxBaseURL
 	} else {
 		if len(config.BaseURL) > 0 {
 			baseURL = config.BaseURL
 		} else {
-			baseURL = "https://api.dnsmadeeasy.com/V2.0"
+			baseURL = defaultBaseURL
 		}
 	}
 
@@ -10,7 +10,8 @@ import (
 
 var envTest = tester.NewEnvTest(
 	"DNSMADEEASY_API_KEY",
-	"DNSMADEEASY_API_SECRET").
+	"DNSMADEEASY_API_SECRET",
+	"DNSMADEEASY_ENDPOINT").
 	WithDomain("DNSMADEEASY_DOMAIN")
 
 func TestNewDNSProvider(t *testing.T) {
@@ -9,9 +9,14 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 )
 
+// maxClockSyncRetries the number of retries of a request rejected because of the clock skew.
+const maxClockSyncRetries = 1
+
 // Domain holds the DNSMadeEasy API representation of a Domain
 type Domain struct {
 	ID   int    `json:"id"`
@@ -38,6 +43,11 @@ type Client struct {
 	apiSecret  string
 	BaseURL    string
 	HTTPClient *http.Client
+
+	// clockOffset the difference between the clock of the API server and the local clock,
+	// the request date must be close to the date of the server.
+	clockOffset   time.Duration
+	clockOffsetMu sync.Mutex
 }
 
 // NewClient creates a DNSMadeEasy client
@@ -130,7 +140,36 @@ func (c *Client) sendRequest(method, resource string, payload interface{}) (*htt
 		return nil, err
 	}
 
-	timestamp := time.Now().UTC().Format(time.RFC1123)
+	for i := 0; ; i++ {
+		resp, err := c.do(method, url, body)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode > 299 {
+			respBody, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return nil, fmt.Errorf("request failed with HTTP status code %d", resp.StatusCode)
+			}
+
+			if i < maxClockSyncRetries && c.syncClock(resp, respBody) {
+				continue
+			}
+
+			return nil, fmt.Errorf("request failed with HTTP status code %d: %s", resp.StatusCode, string(respBody))
+		}
+
+		return resp, nil
+	}
+}
+
+func (c *Client) do(method, url string, body []byte) (*http.Response, error) {
+	c.clockOffsetMu.Lock()
+	now := time.Now().Add(c.clockOffset)
+	c.clockOffsetMu.Unlock()
+
+	timestamp := now.UTC().Format(time.RFC1123)
 	signature, err := computeHMAC(timestamp, c.apiSecret)
 	if err != nil {
 		return nil, err
@@ -146,20 +185,26 @@ func (c *Client) sendRequest(method, resource string, payload interface{}) (*htt
 	req.Header.Set("accept", "application/json")
 	req.Header.Set("content-type", "application/json")
 
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, err
+	return c.HTTPClient.Do(req)
+}
+
+// syncClock aligns the request dates on the date of the API server
+// if the request has been rejected because the request date is out of sync.
+func (c *Client) syncClock(resp *http.Response, body []byte) bool {
+	if resp.StatusCode != http.StatusBadRequest || !strings.Contains(strings.ToLower(string(body)), "out of sync") {
+		return false
 	}
 
-	if resp.StatusCode > 299 {
-		body, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("request failed with HTTP status code %d", resp.StatusCode)
-		}
-		return nil, fmt.Errorf("request failed with HTTP status code %d: %s", resp.StatusCode, string(body))
+	serverTime, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return false
 	}
 
-	return resp, nil
+	c.clockOffsetMu.Lock()
+	c.clockOffset = time.Until(serverTime)
+	c.clockOffsetMu.Unlock()
+
+	return true
 }
 
 func computeHMAC(message string, secret string) (string, error) {
@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest() (*Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client, _ := NewClient("key", "secret")
+	client.BaseURL = server.URL
+
+	return client, mux, server.Close
+}
+
+func TestClient_GetDomain_clockSkew(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	serverTime := time.Now().Add(2 * time.Hour).UTC()
+
+	var calls int
+	mux.HandleFunc("/dns/managed/name", func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+
+		requestDate, err := time.Parse(time.RFC1123, req.Header.Get("x-dnsme-requestDate"))
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		rw.Header().Set("Date", serverTime.Format(http.TimeFormat))
+
+		if d := serverTime.Sub(requestDate); d > 30*time.Second || d < -30*time.Second {
+			rw.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprint(rw, `{"error": ["Request sent with date header too far out of sync."]}`)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{"id": 1, "name": "example.com"}`)
+	})
+
+	domain, err := client.GetDomain("example.com.")
+	require.NoError(t, err)
+
+	assert.Equal(t, &Domain{ID: 1, Name: "example.com"}, domain)
+	assert.Equal(t, 2, calls)
+
+	_, err = client.GetDomain("example.com.")
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, calls)
+}
+
+func TestClient_GetDomain_error(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	var calls int
+	mux.HandleFunc("/dns/managed/name", func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+
+		rw.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprint(rw, `{"error": ["Request sent with date header too far out of sync."]}`)
+	})
+
+	_, err := client.GetDomain("example.com.")
+	require.EqualError(t, err, `request failed with HTTP status code 400: {"error": ["Request sent with date header too far out of sync."]}`)
+
+	assert.Equal(t, 2, calls)
+}
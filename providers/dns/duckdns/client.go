@@ -42,6 +42,10 @@ func (d *DNSProvider) updateTxtRecord(domain, token, txt string, clear bool) err
 
 	body := string(bodyBytes)
 	if body != "OK" {
+		// the token is removed from the URL: the error can be logged.
+		query.Set("token", "***")
+		u.RawQuery = query.Encode()
+
 		return fmt.Errorf("request to change TXT record for DuckDNS returned the following result (%s) this does not match expectation (OK) used url [%s]", body, u)
 	}
 	return nil
@@ -0,0 +1,178 @@
+// Package dynu implements a DNS provider for solving the DNS-01 challenge using Dynu DNS.
+package dynu
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/dynu/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL            string
+	APIKey             string
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString("DYNU_ENDPOINT", internal.DefaultBaseURL),
+		TTL:                env.GetOrDefaultInt("DYNU_TTL", 300),
+		PropagationTimeout: env.GetOrDefaultSecond("DYNU_PROPAGATION_TIMEOUT", 3*time.Minute),
+		PollingInterval:    env.GetOrDefaultSecond("DYNU_POLLING_INTERVAL", 10*time.Second),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("DYNU_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// recordRef identifies a record: the record IDs are only unique inside a domain.
+type recordRef struct {
+	domainID int64
+	recordID int64
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses the Dynu API to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	records   map[string]recordRef
+	recordsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Dynu.
+// Credentials must be passed in the environment variable: DYNU_API_KEY.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("DYNU_API_KEY")
+	if err != nil {
+		return nil, fmt.Errorf("dynu: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIKey = values["DYNU_API_KEY"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Dynu.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("dynu: the configuration of the DNS provider is nil")
+	}
+
+	if config.APIKey == "" {
+		return nil, errors.New("dynu: credentials missing")
+	}
+
+	client := internal.NewClient(config.APIKey)
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{
+		config:  config,
+		client:  client,
+		records: make(map[string]recordRef),
+	}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	root, err := d.client.GetRootDomain(dns01.UnFqdn(fqdn))
+	if err != nil {
+		return fmt.Errorf("dynu: %v", err)
+	}
+
+	record := internal.DNSRecord{
+		NodeName:   root.Node,
+		RecordType: "TXT",
+		TextData:   value,
+		TTL:        d.config.TTL,
+		State:      true,
+	}
+
+	newRecord, err := d.client.AddRecord(root.ID, record)
+	if err != nil {
+		return fmt.Errorf("dynu: %v", err)
+	}
+
+	d.recordsMu.Lock()
+	d.records[token] = recordRef{domainID: root.ID, recordID: newRecord.ID}
+	d.recordsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	d.recordsMu.Lock()
+	ref, ok := d.records[token]
+	d.recordsMu.Unlock()
+
+	if !ok {
+		var err error
+		ref, err = d.findRecord(fqdn, value)
+		if err != nil {
+			return fmt.Errorf("dynu: %v", err)
+		}
+	}
+
+	err := d.client.DeleteRecord(ref.domainID, ref.recordID)
+	if err != nil {
+		return fmt.Errorf("dynu: %v", err)
+	}
+
+	d.recordsMu.Lock()
+	delete(d.records, token)
+	d.recordsMu.Unlock()
+
+	return nil
+}
+
+// findRecord looks up the TXT record matching the FQDN and the value.
+func (d *DNSProvider) findRecord(fqdn, value string) (recordRef, error) {
+	root, err := d.client.GetRootDomain(dns01.UnFqdn(fqdn))
+	if err != nil {
+		return recordRef{}, err
+	}
+
+	records, err := d.client.GetRecords(root.ID)
+	if err != nil {
+		return recordRef{}, err
+	}
+
+	for _, record := range records {
+		if record.RecordType == "TXT" && record.NodeName == root.Node && record.TextData == value {
+			return recordRef{domainID: root.ID, recordID: record.ID}, nil
+		}
+	}
+
+	return recordRef{}, fmt.Errorf("no TXT record found for %s", fqdn)
+}
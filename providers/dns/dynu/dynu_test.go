@@ -0,0 +1,201 @@
+package dynu
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest("DYNU_API_KEY").
+	WithDomain("DYNU_DOMAIN")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"DYNU_API_KEY": "123",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				"DYNU_API_KEY": "",
+			},
+			expected: "dynu: some credentials information are missing: DYNU_API_KEY",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		apiKey   string
+		expected string
+	}{
+		{
+			desc:   "success",
+			apiKey: "123",
+		},
+		{
+			desc:     "missing credentials",
+			expected: "dynu: credentials missing",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.APIKey = test.apiKey
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func setupTest() (*DNSProvider, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	config := NewDefaultConfig()
+	config.APIKey = "secret"
+	config.BaseURL = server.URL
+
+	p, err := NewDNSProviderConfig(config)
+	if err != nil {
+		panic(err)
+	}
+
+	mux.HandleFunc("/dns/getroot/_acme-challenge.sub.example.com", func(rw http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(rw, `{"statusCode":200,"id":9876,"domainName":"example.com","hostname":"_acme-challenge.sub.example.com","node":"_acme-challenge.sub"}`)
+	})
+
+	return p, mux, server.Close
+}
+
+func TestDNSProvider_Present_CleanUp(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	var deleted bool
+	mux.HandleFunc("/dns/9876/record", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		fmt.Fprint(rw, `{"statusCode":200,"id":1234,"domainId":9876,"nodeName":"_acme-challenge.sub","recordType":"TXT"}`)
+	})
+	mux.HandleFunc("/dns/9876/record/1234", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		deleted = true
+		fmt.Fprint(rw, `{"statusCode":200}`)
+	})
+
+	err := provider.Present("sub.example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Equal(t, recordRef{domainID: 9876, recordID: 1234}, provider.records["token"])
+
+	err = provider.CleanUp("sub.example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.True(t, deleted)
+	assert.Empty(t, provider.records)
+}
+
+func TestDNSProvider_CleanUp_lookup(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	_, value := dns01.GetRecord("sub.example.com", "keyAuth")
+
+	mux.HandleFunc("/dns/9876/record", func(rw http.ResponseWriter, req *http.Request) {
+		fmt.Fprintf(rw, `{"statusCode":200,"dnsRecords":[
+{"id":1,"domainId":9876,"nodeName":"_acme-challenge.sub","recordType":"TXT","textData":"other"},
+{"id":1234,"domainId":9876,"nodeName":"_acme-challenge.sub","recordType":"TXT","textData":%q}]}`, value)
+	})
+
+	var deleted bool
+	mux.HandleFunc("/dns/9876/record/1234", func(rw http.ResponseWriter, req *http.Request) {
+		deleted = true
+		fmt.Fprint(rw, `{"statusCode":200}`)
+	})
+
+	err := provider.CleanUp("sub.example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.True(t, deleted)
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
@@ -0,0 +1,154 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// DefaultBaseURL the default base URL of the Dynu API.
+const DefaultBaseURL = "https://api.dynu.com/v2"
+
+// DNSHostname the root domain of a hostname.
+type DNSHostname struct {
+	ID         int64  `json:"id"`
+	DomainName string `json:"domainName"`
+	Hostname   string `json:"hostname"`
+	Node       string `json:"node"`
+}
+
+// DNSRecord a DNS record.
+// The ID of a record is distinct from the ID of its domain: both are needed to delete the record.
+type DNSRecord struct {
+	ID         int64  `json:"id,omitempty"`
+	DomainID   int64  `json:"domainId,omitempty"`
+	DomainName string `json:"domainName,omitempty"`
+	NodeName   string `json:"nodeName"`
+	Hostname   string `json:"hostname,omitempty"`
+	RecordType string `json:"recordType"`
+	TTL        int    `json:"ttl,omitempty"`
+	State      bool   `json:"state"`
+	TextData   string `json:"textData,omitempty"`
+}
+
+type recordsResponse struct {
+	DNSRecords []DNSRecord `json:"dnsRecords"`
+}
+
+type apiError struct {
+	StatusCode int    `json:"statusCode"`
+	Type       string `json:"type"`
+	Message    string `json:"message"`
+}
+
+type apiException struct {
+	Exception apiError `json:"exception"`
+}
+
+// Client the Dynu API client.
+type Client struct {
+	apiKey     string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a Dynu API client.
+func NewClient(apiKey string) *Client {
+	return &Client{
+		apiKey:     apiKey,
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// GetRootDomain returns the root domain (DNS service) of a hostname and the node name of the hostname inside it.
+func (c *Client) GetRootDomain(hostname string) (*DNSHostname, error) {
+	root := &DNSHostname{}
+	err := c.do(http.MethodGet, "/dns/getroot/"+hostname, nil, root)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the root domain of %s: %v", hostname, err)
+	}
+
+	return root, nil
+}
+
+// GetRecords returns the records of a domain.
+func (c *Client) GetRecords(domainID int64) ([]DNSRecord, error) {
+	resp := &recordsResponse{}
+	err := c.do(http.MethodGet, fmt.Sprintf("/dns/%d/record", domainID), nil, resp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the records of the domain %d: %v", domainID, err)
+	}
+
+	return resp.DNSRecords, nil
+}
+
+// AddRecord adds a record to a domain.
+func (c *Client) AddRecord(domainID int64, record DNSRecord) (*DNSRecord, error) {
+	newRecord := &DNSRecord{}
+	err := c.do(http.MethodPost, fmt.Sprintf("/dns/%d/record", domainID), record, newRecord)
+	if err != nil {
+		return nil, fmt.Errorf("unable to add the record %s to the domain %d: %v", record.NodeName, domainID, err)
+	}
+
+	return newRecord, nil
+}
+
+// DeleteRecord deletes a record of a domain.
+func (c *Client) DeleteRecord(domainID, recordID int64) error {
+	err := c.do(http.MethodDelete, fmt.Sprintf("/dns/%d/record/%d", domainID, recordID), nil, nil)
+	if err != nil {
+		return fmt.Errorf("unable to delete the record %d of the domain %d: %v", recordID, domainID, err)
+	}
+
+	return nil
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body bytes.Buffer
+	if payload != nil {
+		err := json.NewEncoder(&body).Encode(payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+uri, &body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("API-Key", c.apiKey)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &apiException{}
+		if json.Unmarshal(raw, apiErr) == nil && apiErr.Exception.Message != "" {
+			return fmt.Errorf("%d: %s: %s", resp.StatusCode, apiErr.Exception.Type, apiErr.Exception.Message)
+		}
+
+		return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
@@ -0,0 +1,152 @@
+package internal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest() (*Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client := NewClient("secret")
+	client.BaseURL = server.URL
+
+	return client, mux, server.Close
+}
+
+func checkAuth(rw http.ResponseWriter, req *http.Request) bool {
+	if req.Header.Get("API-Key") != "secret" {
+		rw.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(rw, `{"exception":{"statusCode":401,"type":"Authentication Exception","message":"Invalid API key."}}`)
+		return false
+	}
+	return true
+}
+
+func TestClient_GetRootDomain(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/dns/getroot/_acme-challenge.sub.example.com", func(rw http.ResponseWriter, req *http.Request) {
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		fmt.Fprint(rw, `{"statusCode":200,"id":9876,"domainName":"example.com","hostname":"_acme-challenge.sub.example.com","node":"_acme-challenge.sub"}`)
+	})
+
+	root, err := client.GetRootDomain("_acme-challenge.sub.example.com")
+	require.NoError(t, err)
+
+	expected := &DNSHostname{ID: 9876, DomainName: "example.com", Hostname: "_acme-challenge.sub.example.com", Node: "_acme-challenge.sub"}
+	assert.Equal(t, expected, root)
+}
+
+func TestClient_GetRootDomain_error(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	client.apiKey = "invalid"
+
+	mux.HandleFunc("/dns/getroot/example.com", func(rw http.ResponseWriter, req *http.Request) {
+		checkAuth(rw, req)
+	})
+
+	_, err := client.GetRootDomain("example.com")
+	require.EqualError(t, err, "unable to get the root domain of example.com: 401: Authentication Exception: Invalid API key.")
+}
+
+func TestClient_GetRecords(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/dns/9876/record", func(rw http.ResponseWriter, req *http.Request) {
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		fmt.Fprint(rw, `{"statusCode":200,"dnsRecords":[{"id":1234,"domainId":9876,"domainName":"example.com","nodeName":"_acme-challenge","hostname":"_acme-challenge.example.com","recordType":"TXT","ttl":300,"state":true,"textData":"value"}]}`)
+	})
+
+	records, err := client.GetRecords(9876)
+	require.NoError(t, err)
+
+	expected := []DNSRecord{{
+		ID:         1234,
+		DomainID:   9876,
+		DomainName: "example.com",
+		NodeName:   "_acme-challenge",
+		Hostname:   "_acme-challenge.example.com",
+		RecordType: "TXT",
+		TTL:        300,
+		State:      true,
+		TextData:   "value",
+	}}
+	assert.Equal(t, expected, records)
+}
+
+func TestClient_AddRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/dns/9876/record", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := `{"nodeName":"_acme-challenge","recordType":"TXT","ttl":300,"state":true,"textData":"value"}` + "\n"
+		if string(body) != expected {
+			http.Error(rw, fmt.Sprintf("unexpected body: %s", string(body)), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `{"statusCode":200,"id":1234,"domainId":9876,"nodeName":"_acme-challenge","recordType":"TXT","ttl":300,"state":true,"textData":"value"}`)
+	})
+
+	record := DNSRecord{NodeName: "_acme-challenge", RecordType: "TXT", TTL: 300, State: true, TextData: "value"}
+
+	newRecord, err := client.AddRecord(9876, record)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1234), newRecord.ID)
+	assert.Equal(t, int64(9876), newRecord.DomainID)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/dns/9876/record/1234", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		fmt.Fprint(rw, `{"statusCode":200}`)
+	})
+
+	err := client.DeleteRecord(9876, 1234)
+	require.NoError(t, err)
+}
@@ -31,6 +31,15 @@ type addRecordResponse struct {
 	Status int        `json:"status"`
 }
 
+type listRecordsResponse struct {
+	Msg    string       `json:"msg"`
+	Tm     int          `json:"tm"`
+	Data   []zoneRecord `json:"data"`
+	Count  int          `json:"count"`
+	Total  int          `json:"total"`
+	Status int          `json:"status"`
+}
+
 func (d *DNSProvider) addRecord(domain string, record interface{}) (string, error) {
 	pathAdd := path.Join("/zones/records/add", domain, "TXT")
 
@@ -45,6 +54,18 @@ func (d *DNSProvider) addRecord(domain string, record interface{}) (string, erro
 	return recordID, nil
 }
 
+func (d *DNSProvider) listRecords(domain string) ([]zoneRecord, error) {
+	pathList := path.Join("/zones/records/all", domain)
+
+	response := &listRecordsResponse{}
+	err := d.doRequest(http.MethodGet, pathList, nil, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
 func (d *DNSProvider) deleteRecord(domain, recordID string) error {
 	pathDelete := path.Join("/zones/records", domain, recordID)
 
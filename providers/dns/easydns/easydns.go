@@ -115,21 +115,35 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	return nil
 }
 
-// CleanUp removes the TXT record matching the specified parameters
+// CleanUp removes the TXT record matching the specified parameters.
+// When the record ID is unknown (i.e. the record was created by another process), the record is looked up by its host and value.
 func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 	fqdn, challenge := dns01.GetRecord(domain, keyAuth)
 
 	key := getMapKey(fqdn, challenge)
+
+	d.recordIDsMu.Lock()
 	recordID, exists := d.recordIDs[key]
+	d.recordIDsMu.Unlock()
+
+	apiHost, apiDomain := splitFqdn(fqdn)
+
 	if !exists {
-		return nil
+		var err error
+		recordID, err = d.findRecordID(apiDomain, apiHost, challenge)
+		if err != nil {
+			return fmt.Errorf("easydns: error looking up zone record: %v", err)
+		}
+
+		if recordID == "" {
+			return nil
+		}
 	}
 
-	_, apiDomain := splitFqdn(fqdn)
 	err := d.deleteRecord(apiDomain, recordID)
 
 	d.recordIDsMu.Lock()
-	defer delete(d.recordIDs, key)
+	delete(d.recordIDs, key)
 	d.recordIDsMu.Unlock()
 
 	if err != nil {
@@ -160,6 +174,22 @@ func splitFqdn(fqdn string) (host, domain string) {
 	return
 }
 
+// findRecordID returns the ID of the TXT record matching the host and the value, or an empty string if there is no such record.
+func (d *DNSProvider) findRecordID(apiDomain, apiHost, value string) (string, error) {
+	records, err := d.listRecords(apiDomain)
+	if err != nil {
+		return "", err
+	}
+
+	for _, record := range records {
+		if record.Type == "TXT" && record.Host == apiHost && strings.Trim(record.Rdata, `"`) == value {
+			return record.ID, nil
+		}
+	}
+
+	return "", nil
+}
+
 func getMapKey(fqdn, value string) string {
 	return fqdn + "|" + value
 }
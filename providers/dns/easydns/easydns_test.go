@@ -186,11 +186,64 @@ func TestDNSProvider_Present(t *testing.T) {
 }
 
 func TestDNSProvider_Cleanup_WhenRecordIdNotSet_NoOp(t *testing.T) {
-	provider, _, tearDown := setup()
+	provider, mux, tearDown := setup()
 	defer tearDown()
 
+	mux.HandleFunc("/zones/records/all/example.com", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "method")
+		assert.Equal(t, "format=json", r.URL.RawQuery, "query")
+
+		_, err := fmt.Fprintf(w, `{"msg":"OK","data":[],"count":0,"total":0,"status":200}`)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	err := provider.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+}
+
+func TestDNSProvider_Cleanup_WhenRecordIdNotSet_DeletesTxtRecordByLookup(t *testing.T) {
+	provider, mux, tearDown := setup()
+	defer tearDown()
+
+	mux.HandleFunc("/zones/records/all/example.com", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "method")
+		assert.Equal(t, "format=json", r.URL.RawQuery, "query")
+		assert.Equal(t, "Basic VE9LRU46U0VDUkVU", r.Header.Get("Authorization"), "Authorization")
+
+		_, err := fmt.Fprintf(w, `{
+			"msg": "OK",
+			"data": [
+				{"id": "1", "domain": "example.com", "host": "www", "type": "A", "rdata": "192.0.2.1"},
+				{"id": "2", "domain": "example.com", "host": "_acme-challenge", "type": "TXT", "rdata": "other"},
+				{"id": "123456", "domain": "example.com", "host": "_acme-challenge", "type": "TXT", "rdata": "pW9ZKG0xz_PCriK-nCMOjADy9eJcgGWIzkkj2fN4uZM"}
+			],
+			"count": 3,
+			"total": 3,
+			"status": 200
+		}`)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	var deleted bool
+	mux.HandleFunc("/zones/records/example.com/123456", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method, "method")
+		assert.Equal(t, "format=json", r.URL.RawQuery, "query")
+
+		deleted = true
+
+		_, err := fmt.Fprintf(w, `{"msg":"OK","data":{"domain":"example.com","id":"123456"},"status":200}`)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
 	err := provider.CleanUp("example.com", "token", "keyAuth")
 	require.NoError(t, err)
+	assert.True(t, deleted)
 }
 
 func TestDNSProvider_Cleanup_WhenRecordIdSet_DeletesTxtRecord(t *testing.T) {
@@ -0,0 +1,157 @@
+// Package epik implements a DNS provider for solving the DNS-01 challenge using Epik.
+package epik
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/epik/internal"
+)
+
+const minTTL = 300
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL            string
+	Signature          string
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString("EPIK_ENDPOINT", internal.DefaultBaseURL),
+		TTL:                env.GetOrDefaultInt("EPIK_TTL", 3600),
+		PropagationTimeout: env.GetOrDefaultSecond("EPIK_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("EPIK_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("EPIK_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses the Epik API to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Epik.
+// Credentials must be passed in the environment variable: EPIK_SIGNATURE.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("EPIK_SIGNATURE")
+	if err != nil {
+		return nil, fmt.Errorf("epik: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.Signature = values["EPIK_SIGNATURE"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Epik.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("epik: the configuration of the DNS provider is nil")
+	}
+
+	if config.Signature == "" {
+		return nil, errors.New("epik: credentials missing")
+	}
+
+	if config.TTL < minTTL {
+		return nil, fmt.Errorf("epik: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	}
+
+	client := internal.NewClient(config.Signature)
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return fmt.Errorf("epik: could not find zone for FQDN %q: %v", fqdn, err)
+	}
+
+	record := internal.RecordRequest{
+		Host: extractRecordName(fqdn, authZone),
+		Type: "TXT",
+		Data: value,
+		TTL:  d.config.TTL,
+	}
+
+	err = d.client.CreateHostRecord(dns01.UnFqdn(authZone), record)
+	if err != nil {
+		return fmt.Errorf("epik: %v", err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+// The API doesn't return the ID of the created records, so the record is looked up by its name and its value.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return fmt.Errorf("epik: could not find zone for FQDN %q: %v", fqdn, err)
+	}
+
+	zone := dns01.UnFqdn(authZone)
+	recordName := extractRecordName(fqdn, authZone)
+
+	records, err := d.client.GetHostRecords(zone)
+	if err != nil {
+		return fmt.Errorf("epik: %v", err)
+	}
+
+	for _, record := range records {
+		if strings.EqualFold(record.Type, "TXT") && record.Name == recordName && record.Data == value {
+			err = d.client.RemoveHostRecord(zone, record.ID)
+			if err != nil {
+				return fmt.Errorf("epik: %v", err)
+			}
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("epik: no TXT record found for %s", fqdn)
+}
+
+func extractRecordName(fqdn, authZone string) string {
+	name := dns01.UnFqdn(fqdn)
+	if idx := strings.LastIndex(name, "."+dns01.UnFqdn(authZone)); idx != -1 {
+		return name[:idx]
+	}
+	return name
+}
@@ -0,0 +1,158 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// DefaultBaseURL the default base URL of the Epik API.
+const DefaultBaseURL = "https://usersapiv2.epik.com/v2"
+
+// Record a host record, the name is relative to the domain (i.e. "_acme-challenge.sub").
+type Record struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Data string `json:"data"`
+	Aux  int    `json:"aux"`
+	TTL  int    `json:"ttl"`
+}
+
+// RecordRequest the payload of a host record creation.
+type RecordRequest struct {
+	Host string `json:"HOST"`
+	Type string `json:"TYPE"`
+	Data string `json:"DATA"`
+	Aux  int    `json:"AUX"`
+	TTL  int    `json:"TTL"`
+}
+
+type createHostRecords struct {
+	Payload RecordRequest `json:"create_host_records_payload"`
+}
+
+type getDNSRecordResponse struct {
+	Data struct {
+		Name    string   `json:"name"`
+		Records []Record `json:"records"`
+	} `json:"data"`
+}
+
+type apiError struct {
+	Errors []struct {
+		Code        int    `json:"code"`
+		Message     string `json:"message"`
+		Description string `json:"description"`
+	} `json:"errors"`
+}
+
+// Client the Epik API client.
+type Client struct {
+	signature  string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates an Epik API client.
+func NewClient(signature string) *Client {
+	return &Client{
+		signature:  signature,
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// GetHostRecords returns the host records of a domain.
+func (c *Client) GetHostRecords(domain string) ([]Record, error) {
+	resp := &getDNSRecordResponse{}
+	err := c.do(http.MethodGet, fmt.Sprintf("/domains/%s/records", domain), nil, nil, resp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the host records of the domain %s: %v", domain, err)
+	}
+
+	return resp.Data.Records, nil
+}
+
+// CreateHostRecord creates a host record of a domain.
+func (c *Client) CreateHostRecord(domain string, record RecordRequest) error {
+	err := c.do(http.MethodPost, fmt.Sprintf("/domains/%s/records", domain), nil, createHostRecords{Payload: record}, nil)
+	if err != nil {
+		return fmt.Errorf("unable to create the host record %s of the domain %s: %v", record.Host, domain, err)
+	}
+
+	return nil
+}
+
+// RemoveHostRecord removes a host record of a domain.
+func (c *Client) RemoveHostRecord(domain, recordID string) error {
+	query := url.Values{}
+	query.Set("ID", recordID)
+
+	err := c.do(http.MethodDelete, fmt.Sprintf("/domains/%s/records", domain), query, nil, nil)
+	if err != nil {
+		return fmt.Errorf("unable to remove the host record %s of the domain %s: %v", recordID, domain, err)
+	}
+
+	return nil
+}
+
+func (c *Client) do(method, uri string, query url.Values, payload, result interface{}) error {
+	endpoint, err := url.Parse(c.BaseURL + uri)
+	if err != nil {
+		return err
+	}
+
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("SIGNATURE", c.signature)
+	endpoint.RawQuery = query.Encode()
+
+	var body bytes.Buffer
+	if payload != nil {
+		err = json.NewEncoder(&body).Encode(payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, endpoint.String(), &body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &apiError{}
+		if json.Unmarshal(raw, apiErr) == nil && len(apiErr.Errors) > 0 {
+			return fmt.Errorf("%d: %d: %s: %s", resp.StatusCode, apiErr.Errors[0].Code, apiErr.Errors[0].Message, apiErr.Errors[0].Description)
+		}
+
+		return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
@@ -0,0 +1,129 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest() (*Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client := NewClient("secret")
+	client.BaseURL = server.URL
+
+	return client, mux, server.Close
+}
+
+func checkAuth(rw http.ResponseWriter, req *http.Request) bool {
+	if req.URL.Query().Get("SIGNATURE") != "secret" {
+		rw.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(rw, `{"errors":[{"code":401,"message":"Unauthorized","description":"Invalid signature"}]}`)
+		return false
+	}
+	return true
+}
+
+func TestClient_GetHostRecords(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/domains/example.com/records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		fmt.Fprint(rw, `{"data":{"name":"example.com","code":200,"records":[{"id":"abc","name":"_acme-challenge","type":"TXT","data":"value","aux":0,"ttl":300}]}}`)
+	})
+
+	records, err := client.GetHostRecords("example.com")
+	require.NoError(t, err)
+
+	expected := []Record{{ID: "abc", Name: "_acme-challenge", Type: "TXT", Data: "value", TTL: 300}}
+	assert.Equal(t, expected, records)
+}
+
+func TestClient_GetHostRecords_error(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	client.signature = "invalid"
+
+	mux.HandleFunc("/domains/example.com/records", func(rw http.ResponseWriter, req *http.Request) {
+		checkAuth(rw, req)
+	})
+
+	_, err := client.GetHostRecords("example.com")
+	require.EqualError(t, err, "unable to get the host records of the domain example.com: 401: 401: Unauthorized: Invalid signature")
+}
+
+func TestClient_CreateHostRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/domains/example.com/records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		payload := createHostRecords{}
+		err := json.NewDecoder(req.Body).Decode(&payload)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := RecordRequest{Host: "_acme-challenge", Type: "TXT", Data: "value", TTL: 300}
+		if payload.Payload != expected {
+			http.Error(rw, fmt.Sprintf("unexpected record: %+v", payload.Payload), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `{"code":1000,"message":"Command completed successfully.","data":{"_acme-challenge":{"HOST":"_acme-challenge","TYPE":"TXT","DATA":"value","AUX":0,"TTL":300}}}`)
+	})
+
+	err := client.CreateHostRecord("example.com", RecordRequest{Host: "_acme-challenge", Type: "TXT", Data: "value", TTL: 300})
+	require.NoError(t, err)
+}
+
+func TestClient_RemoveHostRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/domains/example.com/records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		if req.URL.Query().Get("ID") != "abc" {
+			http.Error(rw, fmt.Sprintf("unexpected query: %s", req.URL.RawQuery), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `{"code":1000,"message":"Command completed successfully.","data":{"success":true}}`)
+	})
+
+	err := client.RemoveHostRecord("example.com", "abc")
+	require.NoError(t, err)
+}
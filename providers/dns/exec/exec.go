@@ -4,7 +4,6 @@ package exec
 import (
 	"errors"
 	"fmt"
-	"os"
 	"os/exec"
 	"time"
 
@@ -45,7 +44,7 @@ func NewDNSProvider() (*DNSProvider, error) {
 
 	config := NewDefaultConfig()
 	config.Program = values["EXEC_PATH"]
-	config.Mode = os.Getenv("EXEC_MODE")
+	config.Mode = env.GetOrFile("EXEC_MODE")
 
 	return NewDNSProviderConfig(config)
 }
@@ -2,23 +2,23 @@
 package exoscale
 
 import (
-	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
-	"github.com/exoscale/egoscale"
 	"github.com/go-acme/lego/v3/challenge/dns01"
 	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/exoscale/internal"
 )
 
-const defaultBaseURL = "https://api.exoscale.com/dns"
-
 // Config is used to configure the creation of the DNSProvider
 type Config struct {
-	APIKey             string
-	APISecret          string
+	APIKey    string
+	APISecret string
+	// Zone the zone of the API endpoint (i.e. "ch-gva-2"), used when Endpoint is not defined.
+	Zone               string
 	Endpoint           string
 	HTTPClient         *http.Client
 	PropagationTimeout time.Duration
@@ -29,6 +29,7 @@ type Config struct {
 // NewDefaultConfig returns a default configuration for the DNSProvider
 func NewDefaultConfig() *Config {
 	return &Config{
+		Zone:               env.GetOrDefaultString("EXOSCALE_API_ZONE", internal.DefaultZone),
 		TTL:                env.GetOrDefaultInt("EXOSCALE_TTL", dns01.DefaultTTL),
 		PropagationTimeout: env.GetOrDefaultSecond("EXOSCALE_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
 		PollingInterval:    env.GetOrDefaultSecond("EXOSCALE_POLLING_INTERVAL", dns01.DefaultPollingInterval),
@@ -38,14 +39,22 @@ func NewDefaultConfig() *Config {
 	}
 }
 
+type recordRef struct {
+	domainID string
+	recordID string
+}
+
 // DNSProvider is an implementation of the acme.ChallengeProvider interface.
 type DNSProvider struct {
 	config *Config
-	client *egoscale.Client
+	client *internal.Client
+
+	records   map[string]recordRef
+	recordsMu sync.Mutex
 }
 
 // NewDNSProvider Credentials must be passed in the environment variables:
-// EXOSCALE_API_KEY, EXOSCALE_API_SECRET, EXOSCALE_ENDPOINT.
+// EXOSCALE_API_KEY, EXOSCALE_API_SECRET (IAM access key), EXOSCALE_API_ZONE or EXOSCALE_ENDPOINT.
 func NewDNSProvider() (*DNSProvider, error) {
 	values, err := env.Get("EXOSCALE_API_KEY", "EXOSCALE_API_SECRET")
 	if err != nil {
@@ -71,80 +80,98 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	}
 
 	if config.Endpoint == "" {
-		config.Endpoint = defaultBaseURL
+		zone := config.Zone
+		if zone == "" {
+			zone = internal.DefaultZone
+		}
+
+		config.Endpoint = internal.BaseURL(zone)
 	}
 
-	client := egoscale.NewClient(config.Endpoint, config.APIKey, config.APISecret)
-	client.HTTPClient = config.HTTPClient
+	client := internal.NewClient(config.APIKey, config.APISecret)
+	client.BaseURL = config.Endpoint
 
-	return &DNSProvider{client: client, config: config}, nil
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{
+		config:  config,
+		client:  client,
+		records: make(map[string]recordRef),
+	}, nil
 }
 
 // Present creates a TXT record to fulfill the dns-01 challenge.
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
-	ctx := context.Background()
 	fqdn, value := dns01.GetRecord(domain, keyAuth)
 	zone, recordName, err := d.FindZoneAndRecordName(fqdn, domain)
 	if err != nil {
-		return err
+		return fmt.Errorf("exoscale: %v", err)
 	}
 
-	recordID, err := d.FindExistingRecordID(zone, recordName)
+	domainID, err := d.findDomainID(zone)
 	if err != nil {
-		return err
+		return fmt.Errorf("exoscale: %v", err)
 	}
 
-	if recordID == 0 {
-		record := egoscale.DNSRecord{
-			Name:       recordName,
-			TTL:        d.config.TTL,
-			Content:    value,
-			RecordType: "TXT",
-		}
-
-		_, err := d.client.CreateRecord(ctx, zone, record)
-		if err != nil {
-			return errors.New("Error while creating DNS record: " + err.Error())
-		}
-	} else {
-		record := egoscale.UpdateDNSRecord{
-			ID:         recordID,
-			Name:       recordName,
-			TTL:        d.config.TTL,
-			Content:    value,
-			RecordType: "TXT",
-		}
+	record := internal.Record{
+		Name:    recordName,
+		Type:    "TXT",
+		Content: value,
+		TTL:     d.config.TTL,
+	}
 
-		_, err := d.client.UpdateRecord(ctx, zone, record)
-		if err != nil {
-			return errors.New("Error while updating DNS record: " + err.Error())
-		}
+	recordID, err := d.client.CreateRecord(domainID, record)
+	if err != nil {
+		return fmt.Errorf("exoscale: %v", err)
 	}
 
+	d.recordsMu.Lock()
+	d.records[token] = recordRef{domainID: domainID, recordID: recordID}
+	d.recordsMu.Unlock()
+
 	return nil
 }
 
 // CleanUp removes the record matching the specified parameters.
 func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
-	ctx := context.Background()
-	fqdn, _ := dns01.GetRecord(domain, keyAuth)
-	zone, recordName, err := d.FindZoneAndRecordName(fqdn, domain)
-	if err != nil {
-		return err
-	}
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
 
-	recordID, err := d.FindExistingRecordID(zone, recordName)
-	if err != nil {
-		return err
-	}
+	d.recordsMu.Lock()
+	ref, ok := d.records[token]
+	d.recordsMu.Unlock()
+
+	if !ok {
+		zone, recordName, err := d.FindZoneAndRecordName(fqdn, domain)
+		if err != nil {
+			return fmt.Errorf("exoscale: %v", err)
+		}
+
+		ref.domainID, err = d.findDomainID(zone)
+		if err != nil {
+			return fmt.Errorf("exoscale: %v", err)
+		}
 
-	if recordID != 0 {
-		err = d.client.DeleteRecord(ctx, zone, recordID)
+		ref.recordID, err = d.FindExistingRecordID(ref.domainID, recordName, value)
 		if err != nil {
-			return errors.New("Error while deleting DNS record: " + err.Error())
+			return fmt.Errorf("exoscale: %v", err)
+		}
+
+		if ref.recordID == "" {
+			return nil
 		}
 	}
 
+	err := d.client.DeleteRecord(ref.domainID, ref.recordID)
+	if err != nil {
+		return fmt.Errorf("exoscale: %v", err)
+	}
+
+	d.recordsMu.Lock()
+	delete(d.records, token)
+	d.recordsMu.Unlock()
+
 	return nil
 }
 
@@ -154,20 +181,21 @@ func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
 	return d.config.PropagationTimeout, d.config.PollingInterval
 }
 
-// FindExistingRecordID Query Exoscale to find an existing record for this name.
-// Returns nil if no record could be found
-func (d *DNSProvider) FindExistingRecordID(zone, recordName string) (int64, error) {
-	ctx := context.Background()
-	records, err := d.client.GetRecords(ctx, zone)
+// FindExistingRecordID Query Exoscale to find an existing TXT record for this name and this value.
+// Returns an empty ID if no record could be found
+func (d *DNSProvider) FindExistingRecordID(domainID, recordName, value string) (string, error) {
+	records, err := d.client.ListRecords(domainID)
 	if err != nil {
-		return -1, errors.New("Error while retrievening DNS records: " + err.Error())
+		return "", err
 	}
+
 	for _, record := range records {
-		if record.Name == recordName {
+		if record.Type == "TXT" && record.Name == recordName && record.Content == value {
 			return record.ID, nil
 		}
 	}
-	return 0, nil
+
+	return "", nil
 }
 
 // FindZoneAndRecordName Extract DNS zone and DNS entry name
@@ -182,3 +210,19 @@ func (d *DNSProvider) FindZoneAndRecordName(fqdn, domain string) (string, string
 
 	return zone, name, nil
 }
+
+// findDomainID returns the ID of the DNS domain of the zone.
+func (d *DNSProvider) findDomainID(zone string) (string, error) {
+	domains, err := d.client.ListDomains()
+	if err != nil {
+		return "", err
+	}
+
+	for _, dom := range domains {
+		if dom.UnicodeName == zone {
+			return dom.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("domain %s not found", zone)
+}
@@ -11,7 +11,9 @@ import (
 
 var envTest = tester.NewEnvTest(
 	"EXOSCALE_API_SECRET",
-	"EXOSCALE_API_KEY").
+	"EXOSCALE_API_KEY",
+	"EXOSCALE_API_ZONE",
+	"EXOSCALE_ENDPOINT").
 	WithDomain("EXOSCALE_DOMAIN")
 
 func TestNewDNSProvider(t *testing.T) {
@@ -122,6 +124,46 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func TestNewDNSProviderConfig_endpoint(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		zone     string
+		endpoint string
+		expected string
+	}{
+		{
+			desc:     "default zone",
+			expected: "https://api-ch-gva-2.exoscale.com/v2",
+		},
+		{
+			desc:     "zone",
+			zone:     "de-fra-1",
+			expected: "https://api-de-fra-1.exoscale.com/v2",
+		},
+		{
+			desc:     "endpoint",
+			zone:     "de-fra-1",
+			endpoint: "https://api.example.com/v2",
+			expected: "https://api.example.com/v2",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.APIKey = "EXOabc"
+			config.APISecret = "456"
+			config.Zone = test.zone
+			config.Endpoint = test.endpoint
+
+			p, err := NewDNSProviderConfig(config)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, p.client.BaseURL)
+		})
+	}
+}
+
 func TestDNSProvider_FindZoneAndRecordName(t *testing.T) {
 	config := NewDefaultConfig()
 	config.APIKey = "example@example.com"
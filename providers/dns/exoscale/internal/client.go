@@ -0,0 +1,233 @@
+package internal
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultZone the default zone of the API endpoint.
+const DefaultZone = "ch-gva-2"
+
+// signatureValidity the validity of the signature of a request.
+const signatureValidity = 10 * time.Minute
+
+// BaseURL returns the API v2 endpoint of a zone (i.e. "ch-gva-2").
+func BaseURL(zone string) string {
+	return fmt.Sprintf("https://api-%s.exoscale.com/v2", zone)
+}
+
+// Domain a DNS domain.
+type Domain struct {
+	ID          string `json:"id"`
+	UnicodeName string `json:"unicode-name"`
+}
+
+// Record a DNS record, the name is relative to the domain (i.e. "_acme-challenge.sub").
+type Record struct {
+	ID      string `json:"id,omitempty"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Content string `json:"content"`
+	TTL     int    `json:"ttl,omitempty"`
+}
+
+// Operation an asynchronous operation of the API.
+type Operation struct {
+	ID        string `json:"id"`
+	State     string `json:"state"`
+	Reason    string `json:"reason,omitempty"`
+	Reference struct {
+		ID string `json:"id"`
+	} `json:"reference"`
+}
+
+type domainsResponse struct {
+	Domains []Domain `json:"dns-domains"`
+}
+
+type recordsResponse struct {
+	Records []Record `json:"dns-domain-records"`
+}
+
+type apiError struct {
+	Message string `json:"message"`
+}
+
+// Client the Exoscale API v2 client.
+type Client struct {
+	apiKey     string
+	apiSecret  string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates an Exoscale API v2 client, the credentials are the key and the secret of an IAM access key.
+func NewClient(apiKey, apiSecret string) *Client {
+	return &Client{
+		apiKey:     apiKey,
+		apiSecret:  apiSecret,
+		BaseURL:    BaseURL(DefaultZone),
+		HTTPClient: &http.Client{},
+	}
+}
+
+// ListDomains returns all the DNS domains of the organization.
+func (c *Client) ListDomains() ([]Domain, error) {
+	var result domainsResponse
+	err := c.do(http.MethodGet, "/dns-domain", nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the domains: %v", err)
+	}
+
+	return result.Domains, nil
+}
+
+// ListRecords returns all the records of a domain.
+func (c *Client) ListRecords(domainID string) ([]Record, error) {
+	var result recordsResponse
+	err := c.do(http.MethodGet, fmt.Sprintf("/dns-domain/%s/record", domainID), nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the records of the domain %s: %v", domainID, err)
+	}
+
+	return result.Records, nil
+}
+
+// CreateRecord creates a record in a domain and returns the ID of the record.
+func (c *Client) CreateRecord(domainID string, record Record) (string, error) {
+	var op Operation
+	err := c.do(http.MethodPost, fmt.Sprintf("/dns-domain/%s/record", domainID), record, &op)
+	if err != nil {
+		return "", fmt.Errorf("unable to create the record %s in the domain %s: %v", record.Name, domainID, err)
+	}
+
+	if op.State == "failure" {
+		return "", fmt.Errorf("unable to create the record %s in the domain %s: operation %s failed: %s", record.Name, domainID, op.ID, op.Reason)
+	}
+
+	return op.Reference.ID, nil
+}
+
+// DeleteRecord deletes a record of a domain.
+func (c *Client) DeleteRecord(domainID, recordID string) error {
+	var op Operation
+	err := c.do(http.MethodDelete, fmt.Sprintf("/dns-domain/%s/record/%s", domainID, recordID), nil, &op)
+	if err != nil {
+		return fmt.Errorf("unable to delete the record %s of the domain %s: %v", recordID, domainID, err)
+	}
+
+	if op.State == "failure" {
+		return fmt.Errorf("unable to delete the record %s of the domain %s: operation %s failed: %s", recordID, domainID, op.ID, op.Reason)
+	}
+
+	return nil
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body []byte
+	if payload != nil {
+		var err error
+		body, err = json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+uri, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	err = c.sign(req, body, time.Now().Add(signatureValidity))
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &apiError{}
+		if json.Unmarshal(raw, apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("%d: %s", resp.StatusCode, apiErr.Message)
+		}
+
+		return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	if result == nil || len(raw) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
+
+// sign sets the Authorization header of the request (EXO2-HMAC-SHA256).
+// The signed message is composed of the method and the path, the body, the values of the query parameters
+// (sorted by name), the values of the signed headers (none) and the expiration date.
+func (c *Client) sign(req *http.Request, body []byte, expires time.Time) error {
+	query := req.URL.Query()
+
+	var names []string
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var values string
+	for _, name := range names {
+		values += query.Get(name)
+	}
+
+	expiration := strconv.FormatInt(expires.Unix(), 10)
+
+	message := strings.Join([]string{
+		req.Method + " " + req.URL.EscapedPath(),
+		string(body),
+		values,
+		"",
+		expiration,
+	}, "\n")
+
+	mac := hmac.New(sha256.New, []byte(c.apiSecret))
+	_, err := mac.Write([]byte(message))
+	if err != nil {
+		return err
+	}
+
+	header := []string{"EXO2-HMAC-SHA256 credential=" + c.apiKey}
+	if len(names) > 0 {
+		header = append(header, "signed-query-args="+strings.Join(names, ";"))
+	}
+	header = append(header,
+		"expires="+expiration,
+		"signature="+base64.StdEncoding.EncodeToString(mac.Sum(nil)),
+	)
+
+	req.Header.Set("Authorization", strings.Join(header, ","))
+
+	return nil
+}
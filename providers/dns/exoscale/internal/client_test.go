@@ -0,0 +1,192 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest() (*Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client := NewClient("key", "secret")
+	client.BaseURL = server.URL
+
+	return client, mux, server.Close
+}
+
+func checkAuth(rw http.ResponseWriter, req *http.Request) bool {
+	if !strings.HasPrefix(req.Header.Get("Authorization"), "EXO2-HMAC-SHA256 credential=key,expires=") {
+		rw.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(rw, `{"message":"Invalid request signature"}`)
+		return false
+	}
+	return true
+}
+
+func TestClient_sign(t *testing.T) {
+	client := NewClient("key", "secret")
+
+	testCases := []struct {
+		desc     string
+		uri      string
+		expected string
+	}{
+		{
+			desc:     "without query",
+			uri:      "https://api-ch-gva-2.exoscale.com/v2/dns-domain",
+			expected: "EXO2-HMAC-SHA256 credential=key,expires=1600000000,signature=z503B004Yr8FzMeDfLkH86s72IL+uhOHgMsRyaBPvjE=",
+		},
+		{
+			desc:     "with query",
+			uri:      "https://api-ch-gva-2.exoscale.com/v2/dns-domain?b=b&a=a",
+			expected: "EXO2-HMAC-SHA256 credential=key,signed-query-args=a;b,expires=1600000000,signature=063m40+rKrCdCzUYNheoKmclmu5NMMcJDDUyDRkmXDc=",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, test.uri, nil)
+			require.NoError(t, err)
+
+			err = client.sign(req, nil, time.Unix(1600000000, 0))
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, req.Header.Get("Authorization"))
+		})
+	}
+}
+
+func TestClient_ListDomains(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/dns-domain", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		fmt.Fprint(rw, `{"dns-domains":[{"id":"d1","unicode-name":"example.com","created-at":"2021-01-01T00:00:00Z"},{"id":"d2","unicode-name":"example.org"}]}`)
+	})
+
+	domains, err := client.ListDomains()
+	require.NoError(t, err)
+
+	expected := []Domain{
+		{ID: "d1", UnicodeName: "example.com"},
+		{ID: "d2", UnicodeName: "example.org"},
+	}
+	assert.Equal(t, expected, domains)
+}
+
+func TestClient_ListDomains_error(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	client.apiKey = "invalid"
+
+	mux.HandleFunc("/dns-domain", func(rw http.ResponseWriter, req *http.Request) {
+		checkAuth(rw, req)
+	})
+
+	_, err := client.ListDomains()
+	require.EqualError(t, err, "unable to list the domains: 403: Invalid request signature")
+}
+
+func TestClient_ListRecords(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/dns-domain/d1/record", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		fmt.Fprint(rw, `{"dns-domain-records":[{"id":"a","name":"www","type":"A","content":"10.0.0.1","ttl":300},{"id":"b","name":"_acme-challenge","type":"TXT","content":"value","ttl":120}]}`)
+	})
+
+	records, err := client.ListRecords("d1")
+	require.NoError(t, err)
+
+	expected := []Record{
+		{ID: "a", Name: "www", Type: "A", Content: "10.0.0.1", TTL: 300},
+		{ID: "b", Name: "_acme-challenge", Type: "TXT", Content: "value", TTL: 120},
+	}
+	assert.Equal(t, expected, records)
+}
+
+func TestClient_CreateRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/dns-domain/d1/record", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		record := Record{}
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := Record{Name: "_acme-challenge", Type: "TXT", Content: "value", TTL: 120}
+		if record != expected {
+			http.Error(rw, fmt.Sprintf("unexpected record: %+v", record), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `{"id":"op1","state":"pending","reference":{"id":"abc","link":"/v2/dns-domain/d1/record/abc","command":"get-dns-domain-record"}}`)
+	})
+
+	recordID, err := client.CreateRecord("d1", Record{Name: "_acme-challenge", Type: "TXT", Content: "value", TTL: 120})
+	require.NoError(t, err)
+
+	assert.Equal(t, "abc", recordID)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/dns-domain/d1/record/abc", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		fmt.Fprint(rw, `{"id":"op2","state":"failure","reason":"forbidden","reference":{"id":"abc"}}`)
+	})
+
+	err := client.DeleteRecord("d1", "abc")
+	require.EqualError(t, err, "unable to delete the record abc of the domain d1: operation op2 failed: forbidden")
+}
@@ -0,0 +1,138 @@
+// Package freemyip implements a DNS provider for solving the DNS-01 challenge using freemyip.com.
+package freemyip
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/freemyip/internal"
+	"github.com/miekg/dns"
+)
+
+const rootDomain = "freemyip.com"
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	Token              string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	SequenceInterval   time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		PropagationTimeout: env.GetOrDefaultSecond("FREEMYIP_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("FREEMYIP_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		SequenceInterval:   env.GetOrDefaultSecond("FREEMYIP_SEQUENCE_INTERVAL", dns01.DefaultPropagationTimeout),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("FREEMYIP_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider adds and removes the record for the DNS challenge
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+}
+
+// NewDNSProvider returns a new DNS provider using
+// environment variable FREEMYIP_TOKEN for adding and removing the DNS record.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("FREEMYIP_TOKEN")
+	if err != nil {
+		return nil, fmt.Errorf("freemyip: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.Token = values["FREEMYIP_TOKEN"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for freemyip.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("freemyip: the configuration of the DNS provider is nil")
+	}
+
+	if config.Token == "" {
+		return nil, errors.New("freemyip: credentials missing")
+	}
+
+	client := internal.NewClient(config.Token)
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	_, value := dns01.GetRecord(domain, keyAuth)
+
+	mainDomain := getMainDomain(domain)
+	if mainDomain == "" {
+		return fmt.Errorf("freemyip: unable to find the main domain for: %s", domain)
+	}
+
+	err := d.client.AddTXTRecord(mainDomain, value)
+	if err != nil {
+		return fmt.Errorf("freemyip: %v", err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	mainDomain := getMainDomain(domain)
+	if mainDomain == "" {
+		return fmt.Errorf("freemyip: unable to find the main domain for: %s", domain)
+	}
+
+	err := d.client.DeleteTXTRecord(mainDomain)
+	if err != nil {
+		return fmt.Errorf("freemyip: %v", err)
+	}
+
+	return nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Sequential All DNS challenges for this provider will be resolved sequentially.
+// Returns the interval between each iteration.
+func (d *DNSProvider) Sequential() time.Duration {
+	return d.config.SequenceInterval
+}
+
+// freemyip only lets you write the TXT record of your subdomain (i.e. "example.freemyip.com"),
+// so everything that is not in the top 3 levels is stripped off.
+func getMainDomain(domain string) string {
+	domain = dns01.UnFqdn(domain)
+
+	if !strings.HasSuffix(strings.ToLower(domain), "."+rootDomain) {
+		return ""
+	}
+
+	split := dns.Split(domain)
+	if len(split) < 3 {
+		return ""
+	}
+
+	return domain[split[len(split)-3]:]
+}
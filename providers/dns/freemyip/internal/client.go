@@ -0,0 +1,96 @@
+package internal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultBaseURL the default URL of the freemyip update endpoint.
+const DefaultBaseURL = "https://freemyip.com/update"
+
+const successMessage = "OK"
+
+// Client the freemyip client.
+type Client struct {
+	token      string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a freemyip client.
+func NewClient(token string) *Client {
+	return &Client{
+		token:      token,
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// AddTXTRecord sets the TXT record of a domain (i.e. "example.freemyip.com").
+func (c *Client) AddTXTRecord(domain, value string) error {
+	query := url.Values{}
+	query.Set("domain", domain)
+	query.Set("txt", value)
+
+	err := c.update(query)
+	if err != nil {
+		return fmt.Errorf("unable to set the TXT record of %s: %v", domain, err)
+	}
+
+	return nil
+}
+
+// DeleteTXTRecord deletes the TXT record of a domain (i.e. "example.freemyip.com").
+func (c *Client) DeleteTXTRecord(domain string) error {
+	query := url.Values{}
+	query.Set("domain", domain)
+	query.Set("txt", "null")
+	query.Set("delete", "yes")
+
+	err := c.update(query)
+	if err != nil {
+		return fmt.Errorf("unable to delete the TXT record of %s: %v", domain, err)
+	}
+
+	return nil
+}
+
+func (c *Client) update(query url.Values) error {
+	endpoint, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return err
+	}
+
+	query.Set("token", c.token)
+	endpoint.RawQuery = query.Encode()
+
+	resp, err := c.HTTPClient.Get(endpoint.String())
+	if err != nil {
+		// the token must not be logged: the error contains the URL.
+		if urlErr, ok := err.(*url.Error); ok {
+			return urlErr.Err
+		}
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	body := strings.TrimSpace(string(raw))
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%d: %s", resp.StatusCode, body)
+	}
+
+	if !strings.HasPrefix(body, successMessage) {
+		return fmt.Errorf("unexpected response: %s", body)
+	}
+
+	return nil
+}
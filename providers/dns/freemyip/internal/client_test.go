@@ -0,0 +1,68 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest() (*Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client := NewClient("secret")
+	client.BaseURL = server.URL + "/update"
+
+	return client, mux, server.Close
+}
+
+func TestClient_AddTXTRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/update", func(rw http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+		if query.Get("token") != "secret" || query.Get("domain") != "example.freemyip.com" || query.Get("txt") != "value" || query.Get("delete") != "" {
+			fmt.Fprint(rw, "ERROR")
+			return
+		}
+
+		fmt.Fprint(rw, "OK\n")
+	})
+
+	err := client.AddTXTRecord("example.freemyip.com", "value")
+	require.NoError(t, err)
+}
+
+func TestClient_AddTXTRecord_error(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/update", func(rw http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(rw, "ERROR")
+	})
+
+	err := client.AddTXTRecord("example.freemyip.com", "value")
+	require.EqualError(t, err, "unable to set the TXT record of example.freemyip.com: unexpected response: ERROR")
+}
+
+func TestClient_DeleteTXTRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/update", func(rw http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+		if query.Get("token") != "secret" || query.Get("domain") != "example.freemyip.com" || query.Get("delete") != "yes" {
+			fmt.Fprint(rw, "ERROR")
+			return
+		}
+
+		fmt.Fprint(rw, "OK\n")
+	})
+
+	err := client.DeleteTXTRecord("example.freemyip.com")
+	require.NoError(t, err)
+}
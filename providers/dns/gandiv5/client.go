@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 
 	"github.com/go-acme/lego/v3/log"
 )
@@ -42,7 +43,7 @@ func (d *DNSProvider) addTXTRecord(domain string, name string, value string, ttl
 	target := fmt.Sprintf("domains/%s/records/%s/TXT", domain, name)
 
 	newRecord := &Record{RRSetTTL: ttl, RRSetValues: values}
-	req, err := d.newRequest(http.MethodPut, target, newRecord)
+	req, err := d.newRequest(http.MethodPut, domain, target, newRecord)
 	if err != nil {
 		return err
 	}
@@ -65,7 +66,7 @@ func (d *DNSProvider) getTXTRecord(domain, name string) (*Record, error) {
 
 	// Get exiting values for the TXT records
 	// Needed to create challenges for both wildcard and base name domains
-	req, err := d.newRequest(http.MethodGet, target, nil)
+	req, err := d.newRequest(http.MethodGet, domain, target, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +83,7 @@ func (d *DNSProvider) getTXTRecord(domain, name string) (*Record, error) {
 func (d *DNSProvider) deleteTXTRecord(domain string, name string) error {
 	target := fmt.Sprintf("domains/%s/records/%s/TXT", domain, name)
 
-	req, err := d.newRequest(http.MethodDelete, target, nil)
+	req, err := d.newRequest(http.MethodDelete, domain, target, nil)
 	if err != nil {
 		return err
 	}
@@ -100,38 +101,42 @@ func (d *DNSProvider) deleteTXTRecord(domain string, name string) error {
 	return nil
 }
 
-func (d *DNSProvider) newRequest(method, resource string, body interface{}) (*http.Request, error) {
+func (d *DNSProvider) newRequest(method, domain, resource string, body interface{}) (*http.Request, error) {
 	u := fmt.Sprintf("%s/%s", d.config.BaseURL, resource)
 
+	if d.config.SharingID != "" {
+		u += "?" + url.Values{"sharing_id": {d.config.SharingID}}.Encode()
+	}
+
+	var req *http.Request
 	if body == nil {
-		req, err := http.NewRequest(method, u, nil)
+		var err error
+		req, err = http.NewRequest(method, u, nil)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		reqBody, err := json.Marshal(body)
 		if err != nil {
 			return nil, err
 		}
 
-		return req, nil
-	}
+		req, err = http.NewRequest(method, u, bytes.NewBuffer(reqBody))
+		if err != nil {
+			return nil, err
+		}
 
-	reqBody, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
+		req.Header.Set("Content-Type", "application/json")
 	}
 
-	req, err := http.NewRequest(method, u, bytes.NewBuffer(reqBody))
-	if err != nil {
-		return nil, err
+	if apiKey := d.apiKey(domain); len(apiKey) > 0 {
+		req.Header.Set(apiKeyHeader, apiKey)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-
 	return req, nil
 }
 
 func (d *DNSProvider) do(req *http.Request, v interface{}) error {
-	if len(d.config.APIKey) > 0 {
-		req.Header.Set(apiKeyHeader, d.config.APIKey)
-	}
-
 	resp, err := d.config.HTTPClient.Do(req)
 	if err != nil {
 		return err
@@ -29,8 +29,12 @@ type inProgressInfo struct {
 
 // Config is used to configure the creation of the DNSProvider
 type Config struct {
-	BaseURL            string
-	APIKey             string
+	BaseURL string
+	APIKey  string
+	// APIKeys are API keys by domain (i.e. for a reseller), they take precedence over APIKey for their domain.
+	APIKeys map[string]string
+	// SharingID the ID of the organization owning the domains.
+	SharingID          string
 	PropagationTimeout time.Duration
 	PollingInterval    time.Duration
 	TTL                int
@@ -40,6 +44,7 @@ type Config struct {
 // NewDefaultConfig returns a default configuration for the DNSProvider
 func NewDefaultConfig() *Config {
 	return &Config{
+		SharingID:          env.GetOrFile("GANDIV5_SHARING_ID"),
 		TTL:                env.GetOrDefaultInt("GANDIV5_TTL", minTTL),
 		PropagationTimeout: env.GetOrDefaultSecond("GANDIV5_PROPAGATION_TIMEOUT", 20*time.Minute),
 		PollingInterval:    env.GetOrDefaultSecond("GANDIV5_POLLING_INTERVAL", 20*time.Second),
@@ -62,13 +67,23 @@ type DNSProvider struct {
 
 // NewDNSProvider returns a DNSProvider instance configured for Gandi.
 // Credentials must be passed in the environment variable: GANDIV5_API_KEY.
+// API keys by domain can be passed in GANDIV5_API_KEYS: `domain:key[,domain:key]`.
 func NewDNSProvider() (*DNSProvider, error) {
+	config := NewDefaultConfig()
+
+	if keys := env.GetOrFile("GANDIV5_API_KEYS"); keys != "" {
+		var err error
+		config.APIKeys, err = parseAPIKeys(keys)
+		if err != nil {
+			return nil, fmt.Errorf("gandiv5: %v", err)
+		}
+	}
+
 	values, err := env.Get("GANDIV5_API_KEY")
-	if err != nil {
+	if err != nil && len(config.APIKeys) == 0 {
 		return nil, fmt.Errorf("gandi: %v", err)
 	}
 
-	config := NewDefaultConfig()
 	config.APIKey = values["GANDIV5_API_KEY"]
 
 	return NewDNSProviderConfig(config)
@@ -80,7 +95,7 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("gandiv5: the configuration of the DNS provider is nil")
 	}
 
-	if config.APIKey == "" {
+	if config.APIKey == "" && len(config.APIKeys) == 0 {
 		return nil, fmt.Errorf("gandiv5: no API Key given")
 	}
 
@@ -116,6 +131,10 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	}
 	name := fqdn[:len(fqdn)-len("."+authZone)]
 
+	if d.apiKey(authZone) == "" {
+		return fmt.Errorf("gandiv5: no API key for the domain %s", dns01.UnFqdn(authZone))
+	}
+
 	// acquire lock and check there is not a challenge already in
 	// progress for this value of authZone
 	d.inProgressMu.Lock()
@@ -165,3 +184,49 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
 	return d.config.PropagationTimeout, d.config.PollingInterval
 }
+
+// apiKey returns the API key of the most specific domain of APIKeys matching the domain,
+// or APIKey if none matches.
+func (d *DNSProvider) apiKey(domain string) string {
+	domain = strings.ToLower(dns01.UnFqdn(domain))
+
+	apiKey := d.config.APIKey
+	var matched string
+	for name, key := range d.config.APIKeys {
+		name = strings.ToLower(dns01.UnFqdn(name))
+		if domain != name && !strings.HasSuffix(domain, "."+name) {
+			continue
+		}
+
+		if len(name) > len(matched) {
+			apiKey = key
+			matched = name
+		}
+	}
+
+	return apiKey
+}
+
+func parseAPIKeys(raw string) (map[string]string, error) {
+	keys := make(map[string]string)
+
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		parts := strings.SplitN(item, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("incorrect domain API key pair: %s", item)
+		}
+
+		keys[dns01.UnFqdn(strings.TrimSpace(parts[0]))] = strings.TrimSpace(parts[1])
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("no API keys found")
+	}
+
+	return keys, nil
+}
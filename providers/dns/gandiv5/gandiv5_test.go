@@ -10,10 +10,14 @@ import (
 
 	"github.com/go-acme/lego/v3/log"
 	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-var envTest = tester.NewEnvTest("GANDIV5_API_KEY")
+var envTest = tester.NewEnvTest(
+	"GANDIV5_API_KEY",
+	"GANDIV5_API_KEYS",
+	"GANDIV5_SHARING_ID")
 
 func TestNewDNSProvider(t *testing.T) {
 	testCases := []struct {
@@ -34,6 +38,19 @@ func TestNewDNSProvider(t *testing.T) {
 			},
 			expected: "gandi: some credentials information are missing: GANDIV5_API_KEY",
 		},
+		{
+			desc: "success with API keys by domain",
+			envVars: map[string]string{
+				"GANDIV5_API_KEYS": "example.com:123,example.org:456",
+			},
+		},
+		{
+			desc: "invalid API keys by domain",
+			envVars: map[string]string{
+				"GANDIV5_API_KEYS": "example.com",
+			},
+			expected: "gandiv5: incorrect domain API key pair: example.com",
+		},
 	}
 
 	for _, test := range testCases {
@@ -61,12 +78,17 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	testCases := []struct {
 		desc     string
 		apiKey   string
+		apiKeys  map[string]string
 		expected string
 	}{
 		{
 			desc:   "success",
 			apiKey: "123",
 		},
+		{
+			desc:    "success with API keys by domain",
+			apiKeys: map[string]string{"example.com": "123"},
+		},
 		{
 			desc:     "missing credentials",
 			expected: "gandiv5: no API Key given",
@@ -77,6 +99,7 @@ func TestNewDNSProviderConfig(t *testing.T) {
 		t.Run(test.desc, func(t *testing.T) {
 			config := NewDefaultConfig()
 			config.APIKey = test.apiKey
+			config.APIKeys = test.apiKeys
 
 			p, err := NewDNSProviderConfig(config)
 
@@ -185,3 +208,71 @@ func TestDNSProvider(t *testing.T) {
 	err = provider.CleanUp("abc.def.example.com", "", fakeKeyAuth)
 	require.NoError(t, err)
 }
+
+func TestDNSProvider_sharingIDAndAPIKeys(t *testing.T) {
+	handler := http.NewServeMux()
+	handler.HandleFunc("/domains/example.com/records/_acme-challenge.abc.def/TXT", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get(apiKeyHeader) != "key-example" {
+			http.Error(rw, `{"message": "invalid API key"}`, http.StatusUnauthorized)
+			return
+		}
+
+		if req.URL.Query().Get("sharing_id") != "org-123" {
+			http.Error(rw, `{"message": "invalid sharing ID"}`, http.StatusForbidden)
+			return
+		}
+
+		if req.Method == http.MethodGet {
+			_, _ = rw.Write([]byte(`{"rrset_ttl":300,"rrset_values":[]}`))
+		}
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	config := NewDefaultConfig()
+	config.APIKey = "key-default"
+	config.APIKeys = map[string]string{
+		"com":         "key-com",
+		"example.com": "key-example",
+		"example.org": "key-other",
+	}
+	config.SharingID = "org-123"
+	config.BaseURL = server.URL
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	provider.findZoneByFqdn = func(fqdn string) (string, error) {
+		return "example.com.", nil
+	}
+
+	err = provider.Present("abc.def.example.com", "", "XXXX")
+	require.NoError(t, err)
+
+	err = provider.CleanUp("abc.def.example.com", "", "XXXX")
+	require.NoError(t, err)
+}
+
+func TestDNSProvider_apiKey(t *testing.T) {
+	config := NewDefaultConfig()
+	config.APIKeys = map[string]string{
+		"example.com":     "key-example",
+		"sub.example.com": "key-sub",
+	}
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	assert.Equal(t, "key-example", provider.apiKey("example.com."))
+	assert.Equal(t, "key-example", provider.apiKey("foo.example.com"))
+	assert.Equal(t, "key-sub", provider.apiKey("sub.example.com"))
+	assert.Equal(t, "", provider.apiKey("example.org"))
+
+	provider.findZoneByFqdn = func(fqdn string) (string, error) {
+		return "example.org.", nil
+	}
+
+	err = provider.Present("example.org", "", "XXXX")
+	require.EqualError(t, err, "gandiv5: no API key for the domain example.org")
+}
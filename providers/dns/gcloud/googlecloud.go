@@ -10,11 +10,13 @@ import (
 	"strconv"
 	"time"
 
+	"cloud.google.com/go/compute/metadata"
 	"github.com/go-acme/lego/v3/challenge/dns01"
 	"github.com/go-acme/lego/v3/log"
 	"github.com/go-acme/lego/v3/platform/config/env"
 	"github.com/go-acme/lego/v3/platform/wait"
 	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/dns/v1"
 	"google.golang.org/api/googleapi"
@@ -52,9 +54,10 @@ type DNSProvider struct {
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for Google Cloud DNS.
-// Project name must be passed in the environment variable: GCE_PROJECT.
 // A Service Account can be passed in the environment variable: GCE_SERVICE_ACCOUNT
-// or by specifying the keyfile location: GCE_SERVICE_ACCOUNT_FILE
+// or by specifying the keyfile location: GCE_SERVICE_ACCOUNT_FILE.
+// Otherwise the Application Default Credentials are used (GOOGLE_APPLICATION_CREDENTIALS, gcloud SDK, metadata server, workload identity).
+// The project can be overridden with the environment variable: GCE_PROJECT.
 func NewDNSProvider() (*DNSProvider, error) {
 	// Use a service account file if specified via environment variable.
 	if saKey := env.GetOrFile("GCE_SERVICE_ACCOUNT"); len(saKey) > 0 {
@@ -66,21 +69,28 @@ func NewDNSProvider() (*DNSProvider, error) {
 	return NewDNSProviderCredentials(project)
 }
 
-// NewDNSProviderCredentials uses the supplied credentials
+// NewDNSProviderCredentials uses the Application Default Credentials
 // to return a DNSProvider instance configured for Google Cloud DNS.
+// If the project is empty, the project of the credentials or of the metadata server is used.
 func NewDNSProviderCredentials(project string) (*DNSProvider, error) {
-	if project == "" {
-		return nil, fmt.Errorf("googlecloud: project name missing")
-	}
+	ctx := context.Background()
 
-	client, err := google.DefaultClient(context.Background(), dns.NdevClouddnsReadwriteScope)
+	credentials, err := google.FindDefaultCredentials(ctx, dns.NdevClouddnsReadwriteScope)
 	if err != nil {
 		return nil, fmt.Errorf("googlecloud: unable to get Google Cloud client: %v", err)
 	}
 
+	if project == "" {
+		project = autodetectProjectID(credentials)
+	}
+
+	if project == "" {
+		return nil, fmt.Errorf("googlecloud: project name missing")
+	}
+
 	config := NewDefaultConfig()
 	config.Project = project
-	config.HTTPClient = client
+	config.HTTPClient = oauth2.NewClient(ctx, credentials.TokenSource)
 
 	return NewDNSProviderConfig(config)
 }
@@ -328,6 +338,26 @@ func (d *DNSProvider) findTxtRecords(zone, fqdn string) ([]*dns.ResourceRecordSe
 	return recs.Rrsets, nil
 }
 
+// autodetectProjectID returns the project of the credentials file,
+// or the project of the instance when running on Google Compute Engine (GCE, GKE, Cloud Run, ...).
+func autodetectProjectID(credentials *google.Credentials) string {
+	if credentials.ProjectID != "" {
+		return credentials.ProjectID
+	}
+
+	if !metadata.OnGCE() {
+		return ""
+	}
+
+	projectID, err := metadata.ProjectID()
+	if err != nil {
+		log.Printf("googlecloud: unable to get the project ID from the metadata server: %v", err)
+		return ""
+	}
+
+	return projectID
+}
+
 func mustUnquote(raw string) string {
 	clean, err := strconv.Unquote(raw)
 	if err != nil {
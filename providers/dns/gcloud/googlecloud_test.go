@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
 	"golang.org/x/oauth2/google"
@@ -44,12 +45,20 @@ func TestNewDNSProvider(t *testing.T) {
 			expected: "googlecloud: unable to get Google Cloud client: google: error getting credentials using GOOGLE_APPLICATION_CREDENTIALS environment variable: open not-a-secret-file: no such file or directory",
 		},
 		{
-			desc: "missing project",
+			desc: "success application default credentials",
 			envVars: map[string]string{
-				"GCE_PROJECT":              "",
-				"GCE_SERVICE_ACCOUNT_FILE": "",
+				"GCE_PROJECT":                    "",
+				"GCE_SERVICE_ACCOUNT_FILE":       "",
+				"GOOGLE_APPLICATION_CREDENTIALS": "fixtures/gce_account_service_file.json",
+			},
+		},
+		{
+			desc: "success application default credentials with project",
+			envVars: map[string]string{
+				"GCE_PROJECT":                    "B",
+				"GCE_SERVICE_ACCOUNT_FILE":       "",
+				"GOOGLE_APPLICATION_CREDENTIALS": "fixtures/gce_account_service_file.json",
 			},
-			expected: "googlecloud: project name missing",
 		},
 		{
 			desc: "success key file",
@@ -88,6 +97,41 @@ func TestNewDNSProvider(t *testing.T) {
 	}
 }
 
+func TestNewDNSProviderCredentials(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		project  string
+		expected string
+	}{
+		{
+			desc:     "project of the credentials",
+			expected: "A",
+		},
+		{
+			desc:     "project override",
+			project:  "B",
+			expected: "B",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(map[string]string{
+				"GOOGLE_APPLICATION_CREDENTIALS": "fixtures/gce_account_service_file.json",
+			})
+
+			p, err := NewDNSProviderCredentials(test.project)
+			require.NoError(t, err)
+			require.NotNil(t, p)
+
+			assert.Equal(t, test.expected, p.config.Project)
+		})
+	}
+}
+
 func TestNewDNSProviderConfig(t *testing.T) {
 	testCases := []struct {
 		desc     string
@@ -4,11 +4,18 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"io"
 	"io/ioutil"
 	"net/http"
+	"time"
 )
 
+// maxRetries is the maximum number of retries of a throttled request.
+const maxRetries = 3
+
+// retryBaseDelay is the delay before retrying a throttled request when the API doesn't define one.
+// It is overridden during tests.
+var retryBaseDelay = time.Second
+
 // DNSRecord a DNS record
 type DNSRecord struct {
 	Type     string `json:"type"`
@@ -18,14 +25,43 @@ type DNSRecord struct {
 	TTL      int    `json:"ttl,omitempty"`
 }
 
+// apiError an error returned by the API.
+type apiError struct {
+	Code          string `json:"code"`
+	Message       string `json:"message"`
+	RetryAfterSec int    `json:"retryAfterSec,omitempty"`
+}
+
+func (d *DNSProvider) getRecords(domainZone, rType, recordName string) ([]DNSRecord, error) {
+	resp, err := d.makeRequest(http.MethodGet, fmt.Sprintf("/v1/domains/%s/records/%s/%s", domainZone, rType, recordName), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("could not get records: Domain: %s; Record: %s, Status: %v; Body: %s", domainZone, recordName, resp.StatusCode, string(bodyBytes))
+	}
+
+	var records []DNSRecord
+	err = json.NewDecoder(resp.Body).Decode(&records)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode records: %v", err)
+	}
+
+	return records, nil
+}
+
+// updateRecords replaces all the records of a type and a name.
 func (d *DNSProvider) updateRecords(records []DNSRecord, domainZone string, recordName string) error {
 	body, err := json.Marshal(records)
 	if err != nil {
 		return err
 	}
 
-	var resp *http.Response
-	resp, err = d.makeRequest(http.MethodPut, fmt.Sprintf("/v1/domains/%s/records/TXT/%s", domainZone, recordName), bytes.NewReader(body))
+	resp, err := d.makeRequest(http.MethodPut, fmt.Sprintf("/v1/domains/%s/records/TXT/%s", domainZone, recordName), body)
 	if err != nil {
 		return err
 	}
@@ -36,18 +72,46 @@ func (d *DNSProvider) updateRecords(records []DNSRecord, domainZone string, reco
 		bodyBytes, _ := ioutil.ReadAll(resp.Body)
 		return fmt.Errorf("could not create record %v; Status: %v; Body: %s", string(body), resp.StatusCode, string(bodyBytes))
 	}
+
 	return nil
 }
 
-func (d *DNSProvider) makeRequest(method, uri string, body io.Reader) (*http.Response, error) {
-	req, err := http.NewRequest(method, fmt.Sprintf("%s%s", defaultBaseURL, uri), body)
-	if err != nil {
-		return nil, err
+// makeRequest sends a request to the API.
+// A throttled request (429 Too Many Requests) is retried after the delay requested by the API.
+func (d *DNSProvider) makeRequest(method, uri string, body []byte) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequest(method, fmt.Sprintf("%s%s", d.config.BaseURL, uri), bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", fmt.Sprintf("sso-key %s:%s", d.config.APIKey, d.config.APISecret))
+
+		resp, err := d.config.HTTPClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusTooManyRequests || attempt >= maxRetries {
+			return resp, nil
+		}
+
+		wait := retryAfter(resp)
+		resp.Body.Close()
+
+		time.Sleep(wait)
 	}
+}
 
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("sso-key %s:%s", d.config.APIKey, d.config.APISecret))
+// retryAfter reads the delay before retrying a throttled request.
+func retryAfter(resp *http.Response) time.Duration {
+	var apiErr apiError
+	err := json.NewDecoder(resp.Body).Decode(&apiErr)
+	if err != nil || apiErr.RetryAfterSec <= 0 {
+		return retryBaseDelay
+	}
 
-	return d.config.HTTPClient.Do(req)
+	return time.Duration(apiErr.RetryAfterSec) * time.Second
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v3/challenge/dns01"
@@ -18,13 +19,17 @@ const (
 	minTTL         = 600
 )
 
+// nullData is the data of the placeholder record used when all the challenge records of a name are removed,
+// because GoDaddy has no proper DELETE record method.
+const nullData = "null"
+
 // Config is used to configure the creation of the DNSProvider
 type Config struct {
+	BaseURL            string
 	APIKey             string
 	APISecret          string
 	PropagationTimeout time.Duration
 	PollingInterval    time.Duration
-	SequenceInterval   time.Duration
 	TTL                int
 	HTTPClient         *http.Client
 }
@@ -32,10 +37,10 @@ type Config struct {
 // NewDefaultConfig returns a default configuration for the DNSProvider
 func NewDefaultConfig() *Config {
 	return &Config{
+		BaseURL:            defaultBaseURL,
 		TTL:                env.GetOrDefaultInt("GODADDY_TTL", minTTL),
 		PropagationTimeout: env.GetOrDefaultSecond("GODADDY_PROPAGATION_TIMEOUT", 120*time.Second),
 		PollingInterval:    env.GetOrDefaultSecond("GODADDY_POLLING_INTERVAL", 2*time.Second),
-		SequenceInterval:   env.GetOrDefaultSecond("GODADDY_SEQUENCE_INTERVAL", dns01.DefaultPropagationTimeout),
 		HTTPClient: &http.Client{
 			Timeout: env.GetOrDefaultSecond("GODADDY_HTTP_TIMEOUT", 30*time.Second),
 		},
@@ -45,6 +50,10 @@ func NewDefaultConfig() *Config {
 // DNSProvider is an implementation of the acme.ChallengeProvider interface
 type DNSProvider struct {
 	config *Config
+	// the records of a name are read, merged and written back: the updates are serialized.
+	recordsMu sync.Mutex
+	// findZoneByFqdn determines the DNS zone of an fqdn. It is overridden during tests.
+	findZoneByFqdn func(fqdn string) (string, error)
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for godaddy.
@@ -73,11 +82,18 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, fmt.Errorf("godaddy: credentials missing")
 	}
 
+	if config.BaseURL == "" {
+		config.BaseURL = defaultBaseURL
+	}
+
 	if config.TTL < minTTL {
 		return nil, fmt.Errorf("godaddy: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
 	}
 
-	return &DNSProvider{config: config}, nil
+	return &DNSProvider{
+		config:         config,
+		findZoneByFqdn: dns01.FindZoneByFqdn,
+	}, nil
 }
 
 // Timeout returns the timeout and interval to use when checking for DNS
@@ -86,51 +102,101 @@ func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
 	return d.config.PropagationTimeout, d.config.PollingInterval
 }
 
-// Present creates a TXT record to fulfill the dns-01 challenge
+// Present creates a TXT record to fulfill the dns-01 challenge.
+// The API replaces all the records of a name,
+// so the existing TXT records of the name are kept to allow several challenges on the same name (e.g. wildcard and base domain).
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
 	domainZone, err := d.getZone(fqdn)
 	if err != nil {
-		return err
+		return fmt.Errorf("godaddy: failed to get zone: %v", err)
 	}
 
 	recordName := d.extractRecordName(fqdn, domainZone)
-	rec := []DNSRecord{
-		{
-			Type: "TXT",
-			Name: recordName,
-			Data: value,
-			TTL:  d.config.TTL,
-		},
+
+	d.recordsMu.Lock()
+	defer d.recordsMu.Unlock()
+
+	existing, err := d.getRecords(domainZone, "TXT", recordName)
+	if err != nil {
+		return fmt.Errorf("godaddy: failed to get TXT records: %v", err)
+	}
+
+	var records []DNSRecord
+	for _, record := range existing {
+		if record.Data == nullData || record.Data == value {
+			continue
+		}
+		records = append(records, record)
+	}
+
+	records = append(records, DNSRecord{
+		Type: "TXT",
+		Name: recordName,
+		Data: value,
+		TTL:  d.config.TTL,
+	})
+
+	err = d.updateRecords(records, domainZone, recordName)
+	if err != nil {
+		return fmt.Errorf("godaddy: failed to add TXT record: %v", err)
 	}
 
-	return d.updateRecords(rec, domainZone, recordName)
+	return nil
 }
 
-// CleanUp sets null value in the TXT DNS record as GoDaddy has no proper DELETE record method
+// CleanUp removes the TXT record matching the specified parameters, and keeps the other TXT records of the name.
+// The last TXT record of a name is replaced by a placeholder, as GoDaddy has no proper DELETE record method.
 func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
-	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
 	domainZone, err := d.getZone(fqdn)
 	if err != nil {
-		return err
+		return fmt.Errorf("godaddy: failed to get zone: %v", err)
 	}
 
 	recordName := d.extractRecordName(fqdn, domainZone)
-	rec := []DNSRecord{
-		{
+
+	d.recordsMu.Lock()
+	defer d.recordsMu.Unlock()
+
+	existing, err := d.getRecords(domainZone, "TXT", recordName)
+	if err != nil {
+		return fmt.Errorf("godaddy: failed to get TXT records: %v", err)
+	}
+
+	var found bool
+	var records []DNSRecord
+	for _, record := range existing {
+		if record.Data == value {
+			found = true
+			continue
+		}
+		if record.Data == nullData {
+			continue
+		}
+		records = append(records, record)
+	}
+
+	if !found {
+		return nil
+	}
+
+	if len(records) == 0 {
+		records = append(records, DNSRecord{
 			Type: "TXT",
 			Name: recordName,
-			Data: "null",
-		},
+			Data: nullData,
+		})
 	}
 
-	return d.updateRecords(rec, domainZone, recordName)
-}
+	err = d.updateRecords(records, domainZone, recordName)
+	if err != nil {
+		return fmt.Errorf("godaddy: failed to remove TXT record: %v", err)
+	}
 
-// Sequential All DNS challenges for this provider will be resolved sequentially.
-// Returns the interval between each iteration.
-func (d *DNSProvider) Sequential() time.Duration {
-	return d.config.SequenceInterval
+	return nil
 }
 
 func (d *DNSProvider) extractRecordName(fqdn, domain string) string {
@@ -142,7 +208,7 @@ func (d *DNSProvider) extractRecordName(fqdn, domain string) string {
 }
 
 func (d *DNSProvider) getZone(fqdn string) (string, error) {
-	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	authZone, err := d.findZoneByFqdn(fqdn)
 	if err != nil {
 		return "", err
 	}
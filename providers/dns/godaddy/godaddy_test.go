@@ -1,9 +1,16 @@
 package godaddy
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/go-acme/lego/v3/challenge/dns01"
 	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -118,6 +125,172 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func setupTest() (*DNSProvider, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	config := NewDefaultConfig()
+	config.APIKey = "key"
+	config.APISecret = "secret"
+	config.BaseURL = server.URL
+
+	provider, err := NewDNSProviderConfig(config)
+	if err != nil {
+		panic(err)
+	}
+
+	provider.findZoneByFqdn = func(fqdn string) (string, error) {
+		return "example.com.", nil
+	}
+
+	return provider, mux, server.Close
+}
+
+// handleRecords mocks the TXT records of a name.
+func handleRecords(t *testing.T, mux *http.ServeMux, records *[]DNSRecord) {
+	t.Helper()
+
+	mux.HandleFunc("/v1/domains/example.com/records/TXT/_acme-challenge", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "sso-key key:secret" {
+			http.Error(rw, "invalid credentials", http.StatusUnauthorized)
+			return
+		}
+
+		switch req.Method {
+		case http.MethodGet:
+			err := json.NewEncoder(rw).Encode(records)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusInternalServerError)
+			}
+		case http.MethodPut:
+			var newRecords []DNSRecord
+			err := json.NewDecoder(req.Body).Decode(&newRecords)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+			*records = newRecords
+		default:
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func TestDNSProvider_Present(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	records := []DNSRecord{
+		{Type: "TXT", Name: "_acme-challenge", Data: "other", TTL: 600},
+	}
+	handleRecords(t, mux, &records)
+
+	err := provider.Present("example.com", "", "foobar")
+	require.NoError(t, err)
+
+	_, value := dns01.GetRecord("example.com", "foobar")
+
+	expected := []DNSRecord{
+		{Type: "TXT", Name: "_acme-challenge", Data: "other", TTL: 600},
+		{Type: "TXT", Name: "_acme-challenge", Data: value, TTL: 600},
+	}
+	assert.Equal(t, expected, records)
+}
+
+func TestDNSProvider_Present_replacePlaceholder(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	records := []DNSRecord{
+		{Type: "TXT", Name: "_acme-challenge", Data: "null"},
+	}
+	handleRecords(t, mux, &records)
+
+	err := provider.Present("example.com", "", "foobar")
+	require.NoError(t, err)
+
+	_, value := dns01.GetRecord("example.com", "foobar")
+
+	expected := []DNSRecord{
+		{Type: "TXT", Name: "_acme-challenge", Data: value, TTL: 600},
+	}
+	assert.Equal(t, expected, records)
+}
+
+func TestDNSProvider_CleanUp(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	_, value := dns01.GetRecord("example.com", "foobar")
+
+	records := []DNSRecord{
+		{Type: "TXT", Name: "_acme-challenge", Data: "other", TTL: 600},
+		{Type: "TXT", Name: "_acme-challenge", Data: value, TTL: 600},
+	}
+	handleRecords(t, mux, &records)
+
+	err := provider.CleanUp("example.com", "", "foobar")
+	require.NoError(t, err)
+
+	expected := []DNSRecord{
+		{Type: "TXT", Name: "_acme-challenge", Data: "other", TTL: 600},
+	}
+	assert.Equal(t, expected, records)
+}
+
+func TestDNSProvider_CleanUp_lastRecord(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	_, value := dns01.GetRecord("example.com", "foobar")
+
+	records := []DNSRecord{
+		{Type: "TXT", Name: "_acme-challenge", Data: value, TTL: 600},
+	}
+	handleRecords(t, mux, &records)
+
+	err := provider.CleanUp("example.com", "", "foobar")
+	require.NoError(t, err)
+
+	expected := []DNSRecord{
+		{Type: "TXT", Name: "_acme-challenge", Data: "null"},
+	}
+	assert.Equal(t, expected, records)
+}
+
+func TestDNSProvider_Present_throttled(t *testing.T) {
+	defer func(delay time.Duration) { retryBaseDelay = delay }(retryBaseDelay)
+	retryBaseDelay = time.Millisecond
+
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	var records []DNSRecord
+	var calls int
+
+	mux.HandleFunc("/v1/domains/example.com/records/TXT/_acme-challenge", func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+		if calls == 1 {
+			rw.WriteHeader(http.StatusTooManyRequests)
+			_, _ = fmt.Fprint(rw, `{"code":"TOO_MANY_REQUESTS","message":"Too many requests received within interval"}`)
+			return
+		}
+
+		switch req.Method {
+		case http.MethodGet:
+			_, _ = fmt.Fprint(rw, `[]`)
+		case http.MethodPut:
+			_ = json.NewDecoder(req.Body).Decode(&records)
+		}
+	})
+
+	err := provider.Present("example.com", "", "foobar")
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, calls)
+	assert.Len(t, records, 1)
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
@@ -0,0 +1,131 @@
+// Package googledomains implements a DNS provider for solving the DNS-01 challenge using the Google Domains ACME DNS API.
+package googledomains
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/googledomains/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL            string
+	AccessToken        string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString("GOOGLE_DOMAINS_ENDPOINT", internal.DefaultBaseURL),
+		PropagationTimeout: env.GetOrDefaultSecond("GOOGLE_DOMAINS_PROPAGATION_TIMEOUT", 2*time.Minute),
+		PollingInterval:    env.GetOrDefaultSecond("GOOGLE_DOMAINS_POLLING_INTERVAL", 2*time.Second),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("GOOGLE_DOMAINS_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses the Google Domains ACME DNS API to manage the ACME challenge records of a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Google Domains.
+// Credentials must be passed in the environment variable: GOOGLE_DOMAINS_ACCESS_TOKEN.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("GOOGLE_DOMAINS_ACCESS_TOKEN")
+	if err != nil {
+		return nil, fmt.Errorf("googledomains: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.AccessToken = values["GOOGLE_DOMAINS_ACCESS_TOKEN"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Google Domains.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("googledomains: the configuration of the DNS provider is nil")
+	}
+
+	if config.AccessToken == "" {
+		return nil, errors.New("googledomains: credentials missing")
+	}
+
+	client := internal.NewClient(config.AccessToken)
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	rootDomain, err := getRootDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("googledomains: %v", err)
+	}
+
+	toAdd := []internal.ACMETxtRecord{{Fqdn: dns01.UnFqdn(fqdn), Digest: value}}
+
+	_, err = d.client.RotateChallenges(rootDomain, toAdd, nil)
+	if err != nil {
+		return fmt.Errorf("googledomains: %v", err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	rootDomain, err := getRootDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("googledomains: %v", err)
+	}
+
+	toRemove := []internal.ACMETxtRecord{{Fqdn: dns01.UnFqdn(fqdn), Digest: value}}
+
+	_, err = d.client.RotateChallenges(rootDomain, nil, toRemove)
+	if err != nil {
+		return fmt.Errorf("googledomains: %v", err)
+	}
+
+	return nil
+}
+
+func getRootDomain(fqdn string) (string, error) {
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return "", fmt.Errorf("could not find zone for FQDN %q: %v", fqdn, err)
+	}
+
+	return dns01.UnFqdn(authZone), nil
+}
@@ -0,0 +1,108 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// DefaultBaseURL the default base URL of the Google Domains ACME DNS API.
+const DefaultBaseURL = "https://acmedns.googleapis.com/v1"
+
+// ACMETxtRecord an ACME challenge TXT record.
+type ACMETxtRecord struct {
+	Fqdn       string `json:"fqdn"`
+	Digest     string `json:"digest"`
+	UpdateTime string `json:"updateTime,omitempty"`
+}
+
+// RotateChallengesRequest the request to add and remove ACME challenge records of a root domain.
+type RotateChallengesRequest struct {
+	AccessToken        string          `json:"accessToken"`
+	RecordsToAdd       []ACMETxtRecord `json:"recordsToAdd,omitempty"`
+	RecordsToRemove    []ACMETxtRecord `json:"recordsToRemove,omitempty"`
+	KeepExpiredRecords bool            `json:"keepExpiredRecords"`
+}
+
+// ACMEChallengeSet the ACME challenge records of a root domain.
+type ACMEChallengeSet struct {
+	Record []ACMETxtRecord `json:"record"`
+}
+
+type apiError struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+	} `json:"error"`
+}
+
+// Client the Google Domains ACME DNS API client.
+type Client struct {
+	accessToken string
+	BaseURL     string
+	HTTPClient  *http.Client
+}
+
+// NewClient creates a Google Domains ACME DNS API client.
+func NewClient(accessToken string) *Client {
+	return &Client{
+		accessToken: accessToken,
+		BaseURL:     DefaultBaseURL,
+		HTTPClient:  &http.Client{},
+	}
+}
+
+// RotateChallenges adds and removes ACME challenge records of a root domain in a single call.
+func (c *Client) RotateChallenges(rootDomain string, toAdd, toRemove []ACMETxtRecord) (*ACMEChallengeSet, error) {
+	request := RotateChallengesRequest{
+		AccessToken:     c.accessToken,
+		RecordsToAdd:    toAdd,
+		RecordsToRemove: toRemove,
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("%s/acmeChallengeSets/%s:rotateChallenges", c.BaseURL, rootDomain)
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to rotate the challenges of %s: %v", rootDomain, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &apiError{}
+		if json.Unmarshal(raw, apiErr) == nil && apiErr.Error.Message != "" {
+			return nil, fmt.Errorf("unable to rotate the challenges of %s: %d: %s: %s", rootDomain, resp.StatusCode, apiErr.Error.Status, apiErr.Error.Message)
+		}
+
+		return nil, fmt.Errorf("unable to rotate the challenges of %s: %d: %s", rootDomain, resp.StatusCode, string(raw))
+	}
+
+	set := &ACMEChallengeSet{}
+	err = json.Unmarshal(raw, set)
+	if err != nil {
+		return nil, err
+	}
+
+	return set, nil
+}
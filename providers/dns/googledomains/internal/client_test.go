@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_RotateChallenges(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		toAdd    []ACMETxtRecord
+		toRemove []ACMETxtRecord
+		expected string
+	}{
+		{
+			desc:     "add",
+			toAdd:    []ACMETxtRecord{{Fqdn: "_acme-challenge.example.com", Digest: "value"}},
+			expected: `{"accessToken":"secret","recordsToAdd":[{"fqdn":"_acme-challenge.example.com","digest":"value"}],"keepExpiredRecords":false}`,
+		},
+		{
+			desc:     "remove",
+			toRemove: []ACMETxtRecord{{Fqdn: "_acme-challenge.example.com", Digest: "value"}},
+			expected: `{"accessToken":"secret","recordsToRemove":[{"fqdn":"_acme-challenge.example.com","digest":"value"}],"keepExpiredRecords":false}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			mux := http.NewServeMux()
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mux.HandleFunc("/acmeChallengeSets/example.com:rotateChallenges", func(rw http.ResponseWriter, req *http.Request) {
+				if req.Method != http.MethodPost {
+					http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+					return
+				}
+
+				body, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					http.Error(rw, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				if string(body) != test.expected {
+					http.Error(rw, fmt.Sprintf("unexpected body: %s", string(body)), http.StatusBadRequest)
+					return
+				}
+
+				fmt.Fprint(rw, `{"record":[{"fqdn":"_acme-challenge.example.com","digest":"value","updateTime":"2020-04-01T12:00:00Z"}]}`)
+			})
+
+			client := NewClient("secret")
+			client.BaseURL = server.URL
+
+			set, err := client.RotateChallenges("example.com", test.toAdd, test.toRemove)
+			require.NoError(t, err)
+
+			expected := &ACMEChallengeSet{Record: []ACMETxtRecord{{Fqdn: "_acme-challenge.example.com", Digest: "value", UpdateTime: "2020-04-01T12:00:00Z"}}}
+			assert.Equal(t, expected, set)
+		})
+	}
+}
+
+func TestClient_RotateChallenges_error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(rw, `{"error":{"code":403,"message":"The caller does not have permission","status":"PERMISSION_DENIED"}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("invalid")
+	client.BaseURL = server.URL
+
+	_, err := client.RotateChallenges("example.com", nil, nil)
+	require.EqualError(t, err, "unable to rotate the challenges of example.com: 403: PERMISSION_DENIED: The caller does not have permission")
+}
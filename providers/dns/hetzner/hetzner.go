@@ -0,0 +1,162 @@
+// Package hetzner implements a DNS provider for solving the DNS-01 challenge using Hetzner DNS.
+package hetzner
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/hetzner/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL            string
+	APIKey             string
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString("HETZNER_ENDPOINT", internal.DefaultBaseURL),
+		TTL:                env.GetOrDefaultInt("HETZNER_TTL", 120),
+		PropagationTimeout: env.GetOrDefaultSecond("HETZNER_PROPAGATION_TIMEOUT", 120*time.Second),
+		PollingInterval:    env.GetOrDefaultSecond("HETZNER_POLLING_INTERVAL", 2*time.Second),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("HETZNER_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses Hetzner's DNS API to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	recordIDs   map[string]string
+	recordIDsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Hetzner.
+// Credentials must be passed in the environment variable: HETZNER_API_KEY.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("HETZNER_API_KEY")
+	if err != nil {
+		return nil, fmt.Errorf("hetzner: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIKey = values["HETZNER_API_KEY"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Hetzner.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("hetzner: the configuration of the DNS provider is nil")
+	}
+
+	if config.APIKey == "" {
+		return nil, errors.New("hetzner: credentials missing")
+	}
+
+	client := internal.NewClient(config.APIKey)
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{
+		config:    config,
+		client:    client,
+		recordIDs: make(map[string]string),
+	}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return fmt.Errorf("hetzner: could not find zone for domain %q: %v", domain, err)
+	}
+
+	zoneID, err := d.client.GetZoneID(dns01.UnFqdn(authZone))
+	if err != nil {
+		return fmt.Errorf("hetzner: %v", err)
+	}
+
+	record := internal.DNSRecord{
+		Type:   "TXT",
+		Name:   extractRecordName(fqdn, authZone),
+		Value:  value,
+		TTL:    d.config.TTL,
+		ZoneID: zoneID,
+	}
+
+	newRecord, err := d.client.CreateRecord(record)
+	if err != nil {
+		return fmt.Errorf("hetzner: %v", err)
+	}
+
+	d.recordIDsMu.Lock()
+	d.recordIDs[token] = newRecord.ID
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	// gets the record's unique ID from when we created it
+	d.recordIDsMu.Lock()
+	recordID, ok := d.recordIDs[token]
+	d.recordIDsMu.Unlock()
+	if !ok {
+		return fmt.Errorf("hetzner: unknown record ID for '%s'", fqdn)
+	}
+
+	err := d.client.DeleteRecord(recordID)
+	if err != nil {
+		return fmt.Errorf("hetzner: %v", err)
+	}
+
+	// deletes record ID from map
+	d.recordIDsMu.Lock()
+	delete(d.recordIDs, token)
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+func extractRecordName(fqdn, authZone string) string {
+	name := dns01.UnFqdn(fqdn)
+	if idx := strings.LastIndex(name, "."+dns01.UnFqdn(authZone)); idx != -1 {
+		return name[:idx]
+	}
+	return name
+}
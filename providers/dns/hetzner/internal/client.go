@@ -0,0 +1,189 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// DefaultBaseURL the default base URL of the Hetzner DNS API.
+const DefaultBaseURL = "https://dns.hetzner.com/api/v1"
+
+const authenticationHeader = "Auth-API-Token"
+
+// defaultPerPage the maximum number of zones by page allowed by the API.
+const defaultPerPage = 100
+
+// Zone a DNS zone.
+type Zone struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Pagination the pagination information of a listing.
+type Pagination struct {
+	Page         int `json:"page"`
+	PerPage      int `json:"per_page"`
+	LastPage     int `json:"last_page"`
+	TotalEntries int `json:"total_entries"`
+}
+
+// Meta the meta information of a listing.
+type Meta struct {
+	Pagination Pagination `json:"pagination"`
+}
+
+// Zones a page of zones.
+type Zones struct {
+	Zones []Zone `json:"zones"`
+	Meta  Meta   `json:"meta"`
+}
+
+// DNSRecord a DNS record.
+type DNSRecord struct {
+	ID     string `json:"id,omitempty"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Value  string `json:"value"`
+	TTL    int    `json:"ttl,omitempty"`
+	ZoneID string `json:"zone_id"`
+}
+
+type recordResponse struct {
+	Record DNSRecord `json:"record"`
+}
+
+type apiError struct {
+	Error struct {
+		Message string `json:"message"`
+		Code    int    `json:"code"`
+	} `json:"error"`
+	Message string `json:"message"`
+}
+
+// Client the Hetzner DNS API client.
+type Client struct {
+	apiKey     string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a Hetzner DNS API client.
+func NewClient(apiKey string) *Client {
+	return &Client{
+		apiKey:     apiKey,
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// GetZoneID returns the ID of a zone, the zones are listed page by page until the zone is found.
+func (c *Client) GetZoneID(name string) (string, error) {
+	for page := 1; ; page++ {
+		zones, err := c.getZones(page)
+		if err != nil {
+			return "", err
+		}
+
+		for _, zone := range zones.Zones {
+			if zone.Name == name {
+				return zone.ID, nil
+			}
+		}
+
+		if len(zones.Zones) == 0 || page >= zones.Meta.Pagination.LastPage {
+			return "", fmt.Errorf("zone %s not found", name)
+		}
+	}
+}
+
+func (c *Client) getZones(page int) (*Zones, error) {
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("per_page", strconv.Itoa(defaultPerPage))
+
+	zones := &Zones{}
+	err := c.do(http.MethodGet, "/zones?"+query.Encode(), nil, zones)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get zones: %v", err)
+	}
+
+	return zones, nil
+}
+
+// CreateRecord creates a DNS record.
+func (c *Client) CreateRecord(record DNSRecord) (*DNSRecord, error) {
+	resp := &recordResponse{}
+	err := c.do(http.MethodPost, "/records", record, resp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create record %s: %v", record.Name, err)
+	}
+
+	return &resp.Record, nil
+}
+
+// DeleteRecord deletes a DNS record.
+func (c *Client) DeleteRecord(recordID string) error {
+	err := c.do(http.MethodDelete, "/records/"+recordID, nil, nil)
+	if err != nil {
+		return fmt.Errorf("unable to delete record %s: %v", recordID, err)
+	}
+
+	return nil
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body bytes.Buffer
+	if payload != nil {
+		err := json.NewEncoder(&body).Encode(payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+uri, &body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set(authenticationHeader, c.apiKey)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &apiError{}
+		if json.Unmarshal(raw, apiErr) == nil {
+			if apiErr.Error.Message != "" {
+				return fmt.Errorf("%d: %s", resp.StatusCode, apiErr.Error.Message)
+			}
+			if apiErr.Message != "" {
+				return fmt.Errorf("%d: %s", resp.StatusCode, apiErr.Message)
+			}
+		}
+
+		return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
@@ -0,0 +1,121 @@
+package internal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest() (*http.ServeMux, *Client, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client := NewClient("secret")
+	client.BaseURL = server.URL
+
+	return mux, client, server.Close
+}
+
+func TestClient_GetZoneID(t *testing.T) {
+	mux, client, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/zones", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get(authenticationHeader) != "secret" {
+			http.Error(rw, `{"message":"Invalid API key"}`, http.StatusUnauthorized)
+			return
+		}
+
+		switch req.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(rw, `{"zones":[{"id":"aaa","name":"example.org"}],"meta":{"pagination":{"page":1,"per_page":1,"last_page":2,"total_entries":2}}}`)
+		case "2":
+			fmt.Fprint(rw, `{"zones":[{"id":"bbb","name":"example.com"}],"meta":{"pagination":{"page":2,"per_page":1,"last_page":2,"total_entries":2}}}`)
+		default:
+			http.Error(rw, `{"message":"page not found"}`, http.StatusNotFound)
+		}
+	})
+
+	zoneID, err := client.GetZoneID("example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "bbb", zoneID)
+
+	_, err = client.GetZoneID("example.net")
+	require.EqualError(t, err, "zone example.net not found")
+}
+
+func TestClient_GetZoneID_error(t *testing.T) {
+	mux, client, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/zones", func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, `{"message":"Invalid API key"}`, http.StatusUnauthorized)
+	})
+
+	_, err := client.GetZoneID("example.com")
+	require.EqualError(t, err, "unable to get zones: 401: Invalid API key")
+}
+
+func TestClient_CreateRecord(t *testing.T) {
+	mux, client, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := `{"name":"_acme-challenge","type":"TXT","value":"txtvalue","ttl":120,"zone_id":"bbb"}`
+		if string(body) != expected+"\n" {
+			http.Error(rw, fmt.Sprintf("unexpected body: %s", string(body)), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `{"record":{"id":"ccc","name":"_acme-challenge","type":"TXT","value":"txtvalue","ttl":120,"zone_id":"bbb"}}`)
+	})
+
+	record, err := client.CreateRecord(DNSRecord{
+		Name:   "_acme-challenge",
+		Type:   "TXT",
+		Value:  "txtvalue",
+		TTL:    120,
+		ZoneID: "bbb",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ccc", record.ID)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	mux, client, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/records/ccc", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+	})
+
+	err := client.DeleteRecord("ccc")
+	require.NoError(t, err)
+
+	err = client.DeleteRecord("ddd")
+	require.EqualError(t, err, "unable to delete record ddd: 404: 404 page not found\n")
+}
@@ -0,0 +1,172 @@
+// Package hosttech implements a DNS provider for solving the DNS-01 challenge using hosttech.ch.
+package hosttech
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/hosttech/internal"
+)
+
+const minTTL = 600
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL            string
+	APIKey             string
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString("HOSTTECH_ENDPOINT", internal.DefaultBaseURL),
+		TTL:                env.GetOrDefaultInt("HOSTTECH_TTL", 3600),
+		PropagationTimeout: env.GetOrDefaultSecond("HOSTTECH_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("HOSTTECH_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("HOSTTECH_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+type recordRef struct {
+	zoneID   int
+	recordID int
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses the hosttech API to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	records   map[string]recordRef
+	recordsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for hosttech.
+// Credentials must be passed in the environment variable: HOSTTECH_API_KEY.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("HOSTTECH_API_KEY")
+	if err != nil {
+		return nil, fmt.Errorf("hosttech: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIKey = values["HOSTTECH_API_KEY"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for hosttech.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("hosttech: the configuration of the DNS provider is nil")
+	}
+
+	if config.APIKey == "" {
+		return nil, errors.New("hosttech: credentials missing")
+	}
+
+	if config.TTL < minTTL {
+		return nil, fmt.Errorf("hosttech: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	}
+
+	client := internal.NewClient(config.APIKey)
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{
+		config:  config,
+		client:  client,
+		records: make(map[string]recordRef),
+	}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return fmt.Errorf("hosttech: could not find zone for FQDN %q: %v", fqdn, err)
+	}
+
+	zone, err := d.client.GetZone(dns01.UnFqdn(authZone))
+	if err != nil {
+		return fmt.Errorf("hosttech: %v", err)
+	}
+
+	record := internal.Record{
+		Type: "TXT",
+		Name: extractRecordName(fqdn, zone.Name),
+		Text: value,
+		TTL:  d.config.TTL,
+	}
+
+	newRecord, err := d.client.AddRecord(zone.ID, record)
+	if err != nil {
+		return fmt.Errorf("hosttech: %v", err)
+	}
+
+	d.recordsMu.Lock()
+	d.records[token] = recordRef{zoneID: zone.ID, recordID: newRecord.ID}
+	d.recordsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	// gets the record's unique ID from when we created it
+	d.recordsMu.Lock()
+	ref, ok := d.records[token]
+	d.recordsMu.Unlock()
+	if !ok {
+		return fmt.Errorf("hosttech: unknown record ID for '%s'", fqdn)
+	}
+
+	err := d.client.DeleteRecord(ref.zoneID, ref.recordID)
+	if err != nil {
+		return fmt.Errorf("hosttech: %v", err)
+	}
+
+	// deletes record ID from map
+	d.recordsMu.Lock()
+	delete(d.records, token)
+	d.recordsMu.Unlock()
+
+	return nil
+}
+
+func extractRecordName(fqdn, zone string) string {
+	name := dns01.UnFqdn(fqdn)
+	if idx := strings.LastIndex(name, "."+zone); idx != -1 {
+		return name[:idx]
+	}
+	return name
+}
@@ -0,0 +1,149 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// DefaultBaseURL the default base URL of the hosttech API.
+const DefaultBaseURL = "https://api.ns1.hosttech.eu/api/user/v1"
+
+// Zone a DNS zone.
+type Zone struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+	TTL   int    `json:"ttl,omitempty"`
+}
+
+// Record a DNS record, the name is relative to the zone (i.e. "_acme-challenge.sub").
+type Record struct {
+	ID      int    `json:"id,omitempty"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Text    string `json:"text"`
+	TTL     int    `json:"ttl"`
+	Comment string `json:"comment,omitempty"`
+}
+
+type zonesResponse struct {
+	Data []Zone `json:"data"`
+}
+
+type recordResponse struct {
+	Data Record `json:"data"`
+}
+
+type apiError struct {
+	Message string              `json:"message"`
+	Errors  map[string][]string `json:"errors"`
+}
+
+// Client the hosttech API client.
+type Client struct {
+	token      string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a hosttech API client.
+func NewClient(token string) *Client {
+	return &Client{
+		token:      token,
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// GetZone returns the zone matching the domain.
+func (c *Client) GetZone(domain string) (*Zone, error) {
+	query := url.Values{}
+	query.Set("query", domain)
+
+	resp := &zonesResponse{}
+	err := c.do(http.MethodGet, "/zones?"+query.Encode(), nil, resp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the zone %s: %v", domain, err)
+	}
+
+	// the query matches the zones containing the domain.
+	for _, zone := range resp.Data {
+		if zone.Name == domain {
+			return &zone, nil
+		}
+	}
+
+	return nil, fmt.Errorf("zone %s not found", domain)
+}
+
+// AddRecord adds a record to a zone.
+func (c *Client) AddRecord(zoneID int, record Record) (*Record, error) {
+	resp := &recordResponse{}
+	err := c.do(http.MethodPost, fmt.Sprintf("/zones/%d/records", zoneID), record, resp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to add the record %s to the zone %d: %v", record.Name, zoneID, err)
+	}
+
+	return &resp.Data, nil
+}
+
+// DeleteRecord deletes a record of a zone.
+func (c *Client) DeleteRecord(zoneID, recordID int) error {
+	err := c.do(http.MethodDelete, fmt.Sprintf("/zones/%d/records/%d", zoneID, recordID), nil, nil)
+	if err != nil {
+		return fmt.Errorf("unable to delete the record %d of the zone %d: %v", recordID, zoneID, err)
+	}
+
+	return nil
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body bytes.Buffer
+	if payload != nil {
+		err := json.NewEncoder(&body).Encode(payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+uri, &body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &apiError{}
+		if json.Unmarshal(raw, apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("%d: %s", resp.StatusCode, apiErr.Message)
+		}
+
+		return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
@@ -0,0 +1,143 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest() (*Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client := NewClient("secret")
+	client.BaseURL = server.URL
+
+	return client, mux, server.Close
+}
+
+func checkAuth(rw http.ResponseWriter, req *http.Request) bool {
+	if req.Header.Get("Authorization") != "Bearer secret" {
+		rw.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(rw, `{"message":"Unauthenticated."}`)
+		return false
+	}
+	return true
+}
+
+func TestClient_GetZone(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/zones", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		if req.URL.Query().Get("query") != "example.com" {
+			http.Error(rw, fmt.Sprintf("unexpected query: %s", req.URL.RawQuery), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `{"data":[{"id":11,"name":"sub.example.com","email":"test@example.com","ttl":10800},{"id":10,"name":"example.com","email":"test@example.com","ttl":10800}]}`)
+	})
+
+	zone, err := client.GetZone("example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, &Zone{ID: 10, Name: "example.com", Email: "test@example.com", TTL: 10800}, zone)
+}
+
+func TestClient_GetZone_notFound(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/zones", func(rw http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(rw, `{"data":[{"id":11,"name":"sub.example.com"}]}`)
+	})
+
+	_, err := client.GetZone("example.com")
+	require.EqualError(t, err, "zone example.com not found")
+}
+
+func TestClient_GetZone_error(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	client.token = "invalid"
+
+	mux.HandleFunc("/zones", func(rw http.ResponseWriter, req *http.Request) {
+		checkAuth(rw, req)
+	})
+
+	_, err := client.GetZone("example.com")
+	require.EqualError(t, err, "unable to get the zone example.com: 401: Unauthenticated.")
+}
+
+func TestClient_AddRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/zones/10/records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		record := Record{}
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := Record{Type: "TXT", Name: "_acme-challenge", Text: "value", TTL: 3600}
+		if record != expected {
+			http.Error(rw, fmt.Sprintf("unexpected record: %+v", record), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		fmt.Fprint(rw, `{"data":{"id":12,"type":"TXT","name":"_acme-challenge","text":"value","ttl":3600}}`)
+	})
+
+	record, err := client.AddRecord(10, Record{Type: "TXT", Name: "_acme-challenge", Text: "value", TTL: 3600})
+	require.NoError(t, err)
+
+	assert.Equal(t, &Record{ID: 12, Type: "TXT", Name: "_acme-challenge", Text: "value", TTL: 3600}, record)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/zones/10/records/12", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	err := client.DeleteRecord(10, 12)
+	require.NoError(t, err)
+}
@@ -0,0 +1,48 @@
+package httpreq
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultSignatureHeader = "X-Signature"
+	defaultTimestampHeader = "X-Timestamp"
+	defaultKeyIDHeader     = "X-Key-Id"
+)
+
+// signRequest adds the HMAC-SHA256 signature headers to the request.
+//
+// The signed message is composed of the method, the path, the timestamp (Unix time in seconds) and the body,
+// separated by a new line:
+//
+//	POST\n/present\n1566000000\n{"fqdn":"_acme-challenge.example.com.","value":"..."}
+//
+// The signature is hex encoded.
+func signRequest(req *http.Request, config *HMACConfig, body []byte, now time.Time) {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	message := strings.Join([]string{req.Method, req.URL.EscapedPath(), timestamp, string(body)}, "\n")
+
+	mac := hmac.New(sha256.New, []byte(config.Key))
+	_, _ = mac.Write([]byte(message))
+
+	req.Header.Set(headerName(config.TimestampHeader, defaultTimestampHeader), timestamp)
+	req.Header.Set(headerName(config.SignatureHeader, defaultSignatureHeader), hex.EncodeToString(mac.Sum(nil)))
+
+	if config.KeyID != "" {
+		req.Header.Set(headerName(config.KeyIDHeader, defaultKeyIDHeader), config.KeyID)
+	}
+}
+
+func headerName(name, defaultName string) string {
+	if name == "" {
+		return defaultName
+	}
+	return name
+}
@@ -3,18 +3,21 @@ package httpreq
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"os"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/go-acme/lego/v3/challenge/dns01"
 	"github.com/go-acme/lego/v3/platform/config/env"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
 )
 
 type message struct {
@@ -28,12 +31,33 @@ type messageRaw struct {
 	KeyAuth string `json:"keyAuth"`
 }
 
+// OAuth2Config is used to configure the OAuth2 client credentials flow.
+type OAuth2Config struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+}
+
+// HMACConfig is used to configure the HMAC signature of the requests.
+type HMACConfig struct {
+	Key             string
+	KeyID           string
+	SignatureHeader string
+	TimestampHeader string
+	KeyIDHeader     string
+}
+
 // Config is used to configure the creation of the DNSProvider
 type Config struct {
 	Endpoint           *url.URL
 	Mode               string
 	Username           string
 	Password           string
+	OAuth2             *OAuth2Config
+	HMAC               *HMACConfig
+	Headers            map[string]string
+	Response           *ResponseConfig
 	PropagationTimeout time.Duration
 	PollingInterval    time.Duration
 	HTTPClient         *http.Client
@@ -53,6 +77,7 @@ func NewDefaultConfig() *Config {
 // DNSProvider describes a provider for acme-proxy
 type DNSProvider struct {
 	config *Config
+	client *http.Client
 }
 
 // NewDNSProvider returns a DNSProvider instance.
@@ -68,10 +93,55 @@ func NewDNSProvider() (*DNSProvider, error) {
 	}
 
 	config := NewDefaultConfig()
-	config.Mode = os.Getenv("HTTPREQ_MODE")
-	config.Username = os.Getenv("HTTPREQ_USERNAME")
-	config.Password = os.Getenv("HTTPREQ_PASSWORD")
+	config.Mode = env.GetOrFile("HTTPREQ_MODE")
+	config.Username = env.GetOrFile("HTTPREQ_USERNAME")
+	config.Password = env.GetOrFile("HTTPREQ_PASSWORD")
 	config.Endpoint = endpoint
+
+	if tokenURL := env.GetOrFile("HTTPREQ_OAUTH2_TOKEN_URL"); tokenURL != "" {
+		values, err = env.Get("HTTPREQ_OAUTH2_CLIENT_ID", "HTTPREQ_OAUTH2_CLIENT_SECRET")
+		if err != nil {
+			return nil, fmt.Errorf("httpreq: %v", err)
+		}
+
+		config.OAuth2 = &OAuth2Config{
+			TokenURL:     tokenURL,
+			ClientID:     values["HTTPREQ_OAUTH2_CLIENT_ID"],
+			ClientSecret: values["HTTPREQ_OAUTH2_CLIENT_SECRET"],
+		}
+
+		if scopes := env.GetOrFile("HTTPREQ_OAUTH2_SCOPES"); scopes != "" {
+			config.OAuth2.Scopes = strings.Split(scopes, ",")
+		}
+	}
+
+	if key := env.GetOrFile("HTTPREQ_HMAC_KEY"); key != "" {
+		config.HMAC = &HMACConfig{
+			Key:             key,
+			KeyID:           env.GetOrFile("HTTPREQ_HMAC_KEY_ID"),
+			SignatureHeader: env.GetOrDefaultString("HTTPREQ_HMAC_SIGNATURE_HEADER", defaultSignatureHeader),
+			TimestampHeader: env.GetOrDefaultString("HTTPREQ_HMAC_TIMESTAMP_HEADER", defaultTimestampHeader),
+			KeyIDHeader:     env.GetOrDefaultString("HTTPREQ_HMAC_KEY_ID_HEADER", defaultKeyIDHeader),
+		}
+	}
+
+	if headers := env.GetOrFile("HTTPREQ_HEADERS"); headers != "" {
+		config.Headers, err = parseHeaders(headers)
+		if err != nil {
+			return nil, fmt.Errorf("httpreq: %v", err)
+		}
+	}
+
+	successField := env.GetOrFile("HTTPREQ_RESPONSE_SUCCESS_FIELD")
+	errorField := env.GetOrFile("HTTPREQ_RESPONSE_ERROR_FIELD")
+	if successField != "" || errorField != "" {
+		config.Response = &ResponseConfig{
+			SuccessField: successField,
+			SuccessValue: env.GetOrDefaultString("HTTPREQ_RESPONSE_SUCCESS_VALUE", defaultSuccessValue),
+			ErrorField:   errorField,
+		}
+	}
+
 	return NewDNSProviderConfig(config)
 }
 
@@ -85,7 +155,24 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("httpreq: the endpoint is missing")
 	}
 
-	return &DNSProvider{config: config}, nil
+	if config.HMAC != nil && len(config.HMAC.Key) == 0 {
+		return nil, errors.New("httpreq: the HMAC key is missing")
+	}
+
+	client := config.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	if config.OAuth2 != nil {
+		if config.OAuth2.TokenURL == "" || config.OAuth2.ClientID == "" || config.OAuth2.ClientSecret == "" {
+			return nil, errors.New("httpreq: the OAuth2 token URL, client ID and client secret are required")
+		}
+
+		client = newOAuth2Client(config.OAuth2, client)
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
 }
 
 // Timeout returns the timeout and interval to use when checking for DNS propagation.
@@ -172,11 +259,19 @@ func (d *DNSProvider) doPost(uri string, msg interface{}) error {
 
 	req.Header.Set("Content-Type", "application/json")
 
+	for name, value := range d.config.Headers {
+		req.Header.Set(name, value)
+	}
+
 	if len(d.config.Username) > 0 && len(d.config.Password) > 0 {
 		req.SetBasicAuth(d.config.Username, d.config.Password)
 	}
 
-	resp, err := d.config.HTTPClient.Do(req)
+	if d.config.HMAC != nil {
+		signRequest(req, d.config.HMAC, reqBody.Bytes(), time.Now())
+	}
+
+	resp, err := d.client.Do(req)
 	if err != nil {
 		return err
 	}
@@ -188,8 +283,62 @@ func (d *DNSProvider) doPost(uri string, msg interface{}) error {
 			return fmt.Errorf("%d: failed to read response body: %v", resp.StatusCode, err)
 		}
 
+		if d.config.Response != nil {
+			var data interface{}
+			if json.Unmarshal(body, &data) == nil {
+				return fmt.Errorf("%d: %s", resp.StatusCode, responseError(d.config.Response, data, body))
+			}
+		}
+
 		return fmt.Errorf("%d: request failed: %v", resp.StatusCode, string(body))
 	}
 
-	return nil
+	if d.config.Response == nil {
+		return nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%d: failed to read response body: %v", resp.StatusCode, err)
+	}
+
+	return checkResponse(d.config.Response, body)
+}
+
+// parseHeaders parses a list of `name:value` headers.
+func parseHeaders(raw string) (map[string]string, error) {
+	headers := make(map[string]string)
+
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		parts := strings.SplitN(item, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("incorrect header: %s", item)
+		}
+
+		headers[http.CanonicalHeaderKey(strings.TrimSpace(parts[0]))] = strings.TrimSpace(parts[1])
+	}
+
+	return headers, nil
+}
+
+func newOAuth2Client(config *OAuth2Config, client *http.Client) *http.Client {
+	oauthConfig := &clientcredentials.Config{
+		TokenURL:     config.TokenURL,
+		ClientID:     config.ClientID,
+		ClientSecret: config.ClientSecret,
+		Scopes:       config.Scopes,
+	}
+
+	// the token source fetches a new token when the current one is expired.
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, client)
+
+	oauthClient := oauthConfig.Client(ctx)
+	oauthClient.Timeout = client.Timeout
+
+	return oauthClient
 }
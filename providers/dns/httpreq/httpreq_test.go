@@ -1,19 +1,37 @@
 package httpreq
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"path"
 	"testing"
 
+	"github.com/go-acme/lego/v3/challenge"
 	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-var envTest = tester.NewEnvTest("HTTPREQ_ENDPOINT", "HTTPREQ_MODE", "HTTPREQ_USERNAME", "HTTPREQ_PASSWORD")
+var envTest = tester.NewEnvTest(
+	"HTTPREQ_ENDPOINT",
+	"HTTPREQ_MODE",
+	"HTTPREQ_USERNAME",
+	"HTTPREQ_PASSWORD",
+	"HTTPREQ_OAUTH2_TOKEN_URL",
+	"HTTPREQ_OAUTH2_CLIENT_ID",
+	"HTTPREQ_OAUTH2_CLIENT_SECRET",
+	"HTTPREQ_HMAC_KEY",
+	"HTTPREQ_HEADERS",
+	"HTTPREQ_RESPONSE_SUCCESS_FIELD",
+	"HTTPREQ_RESPONSE_SUCCESS_VALUE",
+	"HTTPREQ_RESPONSE_ERROR_FIELD")
 
 func TestNewDNSProvider(t *testing.T) {
 	testCases := []struct {
@@ -41,6 +59,54 @@ func TestNewDNSProvider(t *testing.T) {
 			},
 			expected: "httpreq: some credentials information are missing: HTTPREQ_ENDPOINT",
 		},
+		{
+			desc: "success OAuth2",
+			envVars: map[string]string{
+				"HTTPREQ_ENDPOINT":             "http://localhost:8090",
+				"HTTPREQ_OAUTH2_TOKEN_URL":     "http://localhost:8090/token",
+				"HTTPREQ_OAUTH2_CLIENT_ID":     "id",
+				"HTTPREQ_OAUTH2_CLIENT_SECRET": "secret",
+			},
+		},
+		{
+			desc: "missing OAuth2 client credentials",
+			envVars: map[string]string{
+				"HTTPREQ_ENDPOINT":         "http://localhost:8090",
+				"HTTPREQ_OAUTH2_TOKEN_URL": "http://localhost:8090/token",
+			},
+			expected: "httpreq: some credentials information are missing: HTTPREQ_OAUTH2_CLIENT_ID,HTTPREQ_OAUTH2_CLIENT_SECRET",
+		},
+		{
+			desc: "success HMAC",
+			envVars: map[string]string{
+				"HTTPREQ_ENDPOINT": "http://localhost:8090",
+				"HTTPREQ_HMAC_KEY": "secret",
+			},
+		},
+		{
+			desc: "success headers",
+			envVars: map[string]string{
+				"HTTPREQ_ENDPOINT": "http://localhost:8090",
+				"HTTPREQ_HEADERS":  "X-Api-Key:secret, X-Client:lego",
+			},
+		},
+		{
+			desc: "invalid headers",
+			envVars: map[string]string{
+				"HTTPREQ_ENDPOINT": "http://localhost:8090",
+				"HTTPREQ_HEADERS":  "X-Api-Key",
+			},
+			expected: "httpreq: incorrect header: X-Api-Key",
+		},
+		{
+			desc: "success response schema",
+			envVars: map[string]string{
+				"HTTPREQ_ENDPOINT":               "http://localhost:8090",
+				"HTTPREQ_RESPONSE_SUCCESS_FIELD": "status",
+				"HTTPREQ_RESPONSE_SUCCESS_VALUE": "ok",
+				"HTTPREQ_RESPONSE_ERROR_FIELD":   "error.message",
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -254,6 +320,238 @@ func TestNewDNSProvider_Cleanup(t *testing.T) {
 	}
 }
 
+func TestNewDNSProvider_Present_oauth2(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	var tokenCalls int
+	mux.HandleFunc("/token", func(rw http.ResponseWriter, req *http.Request) {
+		tokenCalls++
+
+		username, password, ok := req.BasicAuth()
+		if !ok || username != "id" || password != "secret" {
+			http.Error(rw, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(rw, `{"access_token":"abc","token_type":"bearer","expires_in":3600}`)
+	})
+
+	mux.HandleFunc("/present", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer abc" {
+			http.Error(rw, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		successHandler(rw, req)
+	})
+
+	config := NewDefaultConfig()
+	config.Endpoint = mustParse(server.URL)
+	config.OAuth2 = &OAuth2Config{
+		TokenURL:     server.URL + "/token",
+		ClientID:     "id",
+		ClientSecret: "secret",
+	}
+
+	p, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	err = p.Present("domain", "token", "key")
+	require.NoError(t, err)
+
+	err = p.Present("domain", "token", "key")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, tokenCalls)
+}
+
+func TestNewDNSProvider_Present_hmac(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/present", func(rw http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if req.Header.Get("X-Client") != "lego" {
+			http.Error(rw, "invalid key ID", http.StatusUnauthorized)
+			return
+		}
+
+		mac := hmac.New(sha256.New, []byte("secret"))
+		_, _ = mac.Write([]byte("POST\n/present\n" + req.Header.Get("X-Timestamp") + "\n" + string(body)))
+
+		if req.Header.Get("X-Sig") != hex.EncodeToString(mac.Sum(nil)) {
+			http.Error(rw, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		fmt.Fprint(rw, "lego")
+	})
+
+	config := NewDefaultConfig()
+	config.Endpoint = mustParse(server.URL)
+	config.HMAC = &HMACConfig{
+		Key:             "secret",
+		KeyID:           "lego",
+		SignatureHeader: "X-Sig",
+		KeyIDHeader:     "X-Client",
+	}
+
+	p, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	err = p.Present("domain", "token", "key")
+	require.NoError(t, err)
+}
+
+func TestNewDNSProvider_Present_headers(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/present", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("X-Api-Key") != "secret" || req.Header.Get("X-Client") != "lego" {
+			http.Error(rw, "invalid headers", http.StatusUnauthorized)
+			return
+		}
+
+		fmt.Fprint(rw, "lego")
+	})
+
+	config := NewDefaultConfig()
+	config.Endpoint = mustParse(server.URL)
+	config.Headers = map[string]string{
+		"X-Api-Key": "secret",
+		"X-Client":  "lego",
+	}
+
+	p, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	err = p.Present("domain", "token", "key")
+	require.NoError(t, err)
+}
+
+func TestNewDNSProvider_Present_response(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		response   *ResponseConfig
+		statusCode int
+		body       string
+		expected   string
+	}{
+		{
+			desc:       "success value",
+			response:   &ResponseConfig{SuccessField: "result.status", SuccessValue: "ok"},
+			statusCode: http.StatusOK,
+			body:       `{"result":{"status":"ok"}}`,
+		},
+		{
+			desc:       "success default value",
+			response:   &ResponseConfig{SuccessField: "success"},
+			statusCode: http.StatusOK,
+			body:       `{"success":true}`,
+		},
+		{
+			desc:       "unexpected value with error field",
+			response:   &ResponseConfig{SuccessField: "result.status", SuccessValue: "ok", ErrorField: "errors.0.message"},
+			statusCode: http.StatusOK,
+			body:       `{"result":{"status":"ko"},"errors":[{"message":"zone not found"}]}`,
+			expected:   "httpreq: request failed: zone not found",
+		},
+		{
+			desc:       "missing success field",
+			response:   &ResponseConfig{SuccessField: "success"},
+			statusCode: http.StatusOK,
+			body:       `{"error":"zone not found"}`,
+			expected:   `httpreq: request failed: {"error":"zone not found"}`,
+		},
+		{
+			desc:       "error field only",
+			response:   &ResponseConfig{ErrorField: "error"},
+			statusCode: http.StatusOK,
+			body:       `{"error":"zone not found"}`,
+			expected:   "httpreq: request failed: zone not found",
+		},
+		{
+			desc:       "empty error field",
+			response:   &ResponseConfig{ErrorField: "error"},
+			statusCode: http.StatusOK,
+			body:       `{"error":null}`,
+		},
+		{
+			desc:       "error status with error field",
+			response:   &ResponseConfig{ErrorField: "error.message"},
+			statusCode: http.StatusBadRequest,
+			body:       `{"error":{"message":"invalid FQDN"}}`,
+			expected:   "httpreq: 400: request failed: invalid FQDN",
+		},
+		{
+			desc:       "invalid JSON",
+			response:   &ResponseConfig{SuccessField: "success"},
+			statusCode: http.StatusOK,
+			body:       `lego`,
+			expected:   "httpreq: failed to unmarshal response: invalid character 'l' looking for beginning of value: lego",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			mux := http.NewServeMux()
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mux.HandleFunc("/present", func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(test.statusCode)
+				fmt.Fprint(rw, test.body)
+			})
+
+			config := NewDefaultConfig()
+			config.Endpoint = mustParse(server.URL)
+			config.Response = test.response
+
+			p, err := NewDNSProviderConfig(config)
+			require.NoError(t, err)
+
+			err = p.Present("domain", "token", "key")
+			if test.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestDNSProvider_conformance(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/present", successHandler)
+	mux.HandleFunc("/cleanup", successHandler)
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	suite := &tester.ProviderSuite{
+		NewProvider: func() (challenge.Provider, error) {
+			return NewDNSProvider()
+		},
+		EnvTest:           envTest,
+		EnvVars:           map[string]string{"HTTPREQ_ENDPOINT": server.URL},
+		RequiredEnvVars:   []string{"HTTPREQ_ENDPOINT"},
+		IdempotentCleanUp: true,
+	}
+
+	suite.Run(t)
+}
+
 func successHandler(rw http.ResponseWriter, req *http.Request) {
 	if req.Method != http.MethodPost {
 		http.Error(rw, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
@@ -0,0 +1,109 @@
+package httpreq
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const defaultSuccessValue = "true"
+
+// ResponseConfig is used to configure the schema of the responses.
+// The fields are paths in the JSON body of the response, the segments are separated by dots (i.e. `result.status`, `errors.0.message`).
+type ResponseConfig struct {
+	// SuccessField is the field that indicates if the request succeeded.
+	SuccessField string
+	// SuccessValue is the value of the SuccessField when the request succeeded (Default: true).
+	SuccessValue string
+	// ErrorField is the field containing the error message.
+	ErrorField string
+}
+
+// checkResponse checks the body of a response with a successful status code.
+func checkResponse(config *ResponseConfig, body []byte) error {
+	if config.SuccessField == "" && config.ErrorField == "" {
+		return nil
+	}
+
+	var data interface{}
+	err := json.Unmarshal(body, &data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal response: %v: %s", err, string(body))
+	}
+
+	if config.SuccessField != "" {
+		value, ok := lookupField(data, config.SuccessField)
+
+		expected := config.SuccessValue
+		if expected == "" {
+			expected = defaultSuccessValue
+		}
+
+		if !ok || fieldString(value) != expected {
+			return errors.New(responseError(config, data, body))
+		}
+
+		return nil
+	}
+
+	if value, ok := lookupField(data, config.ErrorField); ok && value != nil && fieldString(value) != "" {
+		return fmt.Errorf("request failed: %s", fieldString(value))
+	}
+
+	return nil
+}
+
+// responseError returns the error message of the response, or the raw body.
+func responseError(config *ResponseConfig, data interface{}, body []byte) string {
+	if config != nil && config.ErrorField != "" {
+		if value, ok := lookupField(data, config.ErrorField); ok && value != nil {
+			return fmt.Sprintf("request failed: %s", fieldString(value))
+		}
+	}
+
+	return fmt.Sprintf("request failed: %s", string(body))
+}
+
+// lookupField returns the value at the path in the JSON data.
+func lookupField(data interface{}, path string) (interface{}, bool) {
+	current := data
+
+	for _, segment := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[segment]
+			if !ok {
+				return nil, false
+			}
+			current = value
+
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+
+		default:
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+func fieldString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	case map[string]interface{}, []interface{}:
+		raw, _ := json.Marshal(v)
+		return string(raw)
+	default:
+		return fmt.Sprint(v)
+	}
+}
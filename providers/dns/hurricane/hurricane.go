@@ -0,0 +1,139 @@
+// Package hurricane implements a DNS provider for solving the DNS-01 challenge using Hurricane Electric.
+package hurricane
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/hurricane/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	Credentials        map[string]string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	SequenceInterval   time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		PropagationTimeout: env.GetOrDefaultSecond("HURRICANE_PROPAGATION_TIMEOUT", 300*time.Second),
+		PollingInterval:    env.GetOrDefaultSecond("HURRICANE_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		SequenceInterval:   env.GetOrDefaultSecond("HURRICANE_SEQUENCE_INTERVAL", dns01.DefaultPropagationTimeout),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("HURRICANE_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses the dynamic DNS interface of Hurricane Electric to update the pre-created TXT records.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Hurricane Electric.
+// The credentials must be passed in the environment variable HURRICANE_TOKENS: `domain:token[,domain:token]`.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("HURRICANE_TOKENS")
+	if err != nil {
+		return nil, fmt.Errorf("hurricane: %v", err)
+	}
+
+	credentials, err := parseCredentials(values["HURRICANE_TOKENS"])
+	if err != nil {
+		return nil, fmt.Errorf("hurricane: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.Credentials = credentials
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Hurricane Electric.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("hurricane: the configuration of the DNS provider is nil")
+	}
+
+	if len(config.Credentials) == 0 {
+		return nil, errors.New("hurricane: credentials missing")
+	}
+
+	client := internal.NewClient(config.Credentials)
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present updates the TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	_, value := dns01.GetRecord(domain, keyAuth)
+
+	err := d.client.UpdateTxtRecord(dns01.UnFqdn(domain), value)
+	if err != nil {
+		return fmt.Errorf("hurricane: %v", err)
+	}
+
+	return nil
+}
+
+// CleanUp replaces the value of the TXT record by a placeholder:
+// the records are pre-created and can't be deleted through the dynamic DNS interface.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	err := d.client.UpdateTxtRecord(dns01.UnFqdn(domain), ".")
+	if err != nil {
+		return fmt.Errorf("hurricane: %v", err)
+	}
+
+	return nil
+}
+
+// Sequential All DNS challenges for this provider will be resolved sequentially.
+// Returns the interval between each iteration.
+func (d *DNSProvider) Sequential() time.Duration {
+	return d.config.SequenceInterval
+}
+
+func parseCredentials(raw string) (map[string]string, error) {
+	credentials := make(map[string]string)
+
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		parts := strings.SplitN(item, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("incorrect credential pair: %s", item)
+		}
+
+		credentials[dns01.UnFqdn(strings.TrimSpace(parts[0]))] = strings.TrimSpace(parts[1])
+	}
+
+	if len(credentials) == 0 {
+		return nil, errors.New("no credentials found")
+	}
+
+	return credentials, nil
+}
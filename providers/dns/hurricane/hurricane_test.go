@@ -0,0 +1,145 @@
+package hurricane
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest("HURRICANE_TOKENS").
+	WithDomain("HURRICANE_DOMAIN")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"HURRICANE_TOKENS": "example.org:123",
+			},
+		},
+		{
+			desc: "success multiple domains",
+			envVars: map[string]string{
+				"HURRICANE_TOKENS": "example.org:123,example.com:456,example.net:789",
+			},
+		},
+		{
+			desc: "invalid credentials",
+			envVars: map[string]string{
+				"HURRICANE_TOKENS": "123",
+			},
+			expected: "hurricane: incorrect credential pair: 123",
+		},
+		{
+			desc: "invalid credentials, partial",
+			envVars: map[string]string{
+				"HURRICANE_TOKENS": "example.org:123,example.net",
+			},
+			expected: "hurricane: incorrect credential pair: example.net",
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				"HURRICANE_TOKENS": "",
+			},
+			expected: "hurricane: some credentials information are missing: HURRICANE_TOKENS",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		creds    map[string]string
+		expected string
+	}{
+		{
+			desc:  "success",
+			creds: map[string]string{"example.org": "123"},
+		},
+		{
+			desc:     "missing credentials",
+			expected: "hurricane: credentials missing",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.Credentials = test.creds
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func Test_parseCredentials(t *testing.T) {
+	credentials, err := parseCredentials(" example.org:123 , sub.example.com.:456,")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"example.org": "123", "sub.example.com": "456"}, credentials)
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// DefaultBaseURL the default URL of the dynamic DNS interface of Hurricane Electric.
+const DefaultBaseURL = "https://dyn.dns.he.net/nic/update"
+
+const (
+	codeGood     = "good"
+	codeNoChg    = "nochg"
+	codeAbuse    = "abuse"
+	codeBadAgent = "badagent"
+	codeBadAuth  = "badauth"
+	codeInterval = "interval"
+	codeNoHost   = "nohost"
+	codeNotFqdn  = "notfqdn"
+)
+
+// Client the client of the dynamic DNS interface of Hurricane Electric.
+type Client struct {
+	credentials map[string]string
+	// the updates are sent one at a time to limit the rate-limiting errors.
+	updateMu sync.Mutex
+
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a client: the credentials map the domains to the keys of their `_acme-challenge` records.
+func NewClient(credentials map[string]string) *Client {
+	return &Client{
+		credentials: credentials,
+		BaseURL:     DefaultBaseURL,
+		HTTPClient:  &http.Client{},
+	}
+}
+
+// UpdateTxtRecord updates the value of the pre-created TXT record of the domain.
+func (c *Client) UpdateTxtRecord(domain, txt string) error {
+	hostname := "_acme-challenge." + domain
+
+	token, ok := c.credentials[domain]
+	if !ok {
+		return fmt.Errorf("domain %s not found in credentials, check your credentials map", domain)
+	}
+
+	data := url.Values{}
+	data.Set("password", token)
+	data.Set("hostname", hostname)
+	data.Set("txt", txt)
+
+	c.updateMu.Lock()
+	defer c.updateMu.Unlock()
+
+	resp, err := c.HTTPClient.PostForm(c.BaseURL, data)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	body := strings.TrimSpace(string(raw))
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: unexpected status code: %d: %s", hostname, resp.StatusCode, body)
+	}
+
+	return evaluateBody(body, hostname)
+}
+
+func evaluateBody(body, hostname string) error {
+	code := strings.SplitN(body, " ", 2)[0]
+
+	switch code {
+	case codeGood, codeNoChg:
+		return nil
+	case codeAbuse:
+		return fmt.Errorf("%s: blocked hostname for abuse: %s", hostname, body)
+	case codeBadAgent:
+		return fmt.Errorf("%s: user agent not sent or HTTP method not recognized; open an issue on go-acme/lego on Github: %s", hostname, body)
+	case codeBadAuth:
+		return fmt.Errorf("%s: wrong authentication: %s", hostname, body)
+	case codeInterval:
+		return fmt.Errorf("%s: TXT records update exceeded API rate limit: %s", hostname, body)
+	case codeNoHost:
+		return fmt.Errorf("%s: the record provided does not exist in this account: %s", hostname, body)
+	case codeNotFqdn:
+		return fmt.Errorf("%s: the record provided isn't an FQDN: %s", hostname, body)
+	default:
+		return fmt.Errorf("%s: unexpected response: %s", hostname, body)
+	}
+}
@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_UpdateTxtRecord(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		code     string
+		expected string
+	}{
+		{
+			desc: "good",
+			code: codeGood,
+		},
+		{
+			desc: "nochg",
+			code: codeNoChg,
+		},
+		{
+			desc:     "abuse",
+			code:     codeAbuse,
+			expected: "_acme-challenge.example.com: blocked hostname for abuse: abuse",
+		},
+		{
+			desc:     "badauth",
+			code:     codeBadAuth,
+			expected: "_acme-challenge.example.com: wrong authentication: badauth",
+		},
+		{
+			desc:     "interval",
+			code:     codeInterval,
+			expected: "_acme-challenge.example.com: TXT records update exceeded API rate limit: interval",
+		},
+		{
+			desc:     "nohost",
+			code:     codeNoHost,
+			expected: "_acme-challenge.example.com: the record provided does not exist in this account: nohost",
+		},
+		{
+			desc:     "unknown",
+			code:     "foo",
+			expected: "_acme-challenge.example.com: unexpected response: foo",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				if req.Method != http.MethodPost {
+					http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+					return
+				}
+
+				if err := req.ParseForm(); err != nil {
+					http.Error(rw, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				if req.PostForm.Get("hostname") != "_acme-challenge.example.com" ||
+					req.PostForm.Get("password") != "secret" ||
+					req.PostForm.Get("txt") != "foo" {
+					http.Error(rw, fmt.Sprintf("unexpected form: %v", req.PostForm), http.StatusBadRequest)
+					return
+				}
+
+				fmt.Fprint(rw, test.code)
+			}))
+			defer server.Close()
+
+			client := NewClient(map[string]string{"example.com": "secret"})
+			client.BaseURL = server.URL
+
+			err := client.UpdateTxtRecord("example.com", "foo")
+			if test.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestClient_UpdateTxtRecord_unknownDomain(t *testing.T) {
+	client := NewClient(map[string]string{"example.com": "secret"})
+
+	err := client.UpdateTxtRecord("example.org", "foo")
+	require.EqualError(t, err, "domain example.org not found in credentials, check your credentials map")
+}
@@ -0,0 +1,177 @@
+// Package infoblox implements a DNS provider for solving the DNS-01 challenge using Infoblox NIOS (WAPI).
+package infoblox
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/infoblox/internal"
+)
+
+const defaultDNSView = "default"
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	// Host is the URL of the grid manager (i.e. "infoblox.example.com" or "https://infoblox.example.com:8443").
+	Host string
+	// WapiVersion is the version of the web API used.
+	WapiVersion string
+	// DNSView is the DNS view in which the records are created.
+	DNSView string
+
+	Username string
+	Password string
+
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	config := &Config{
+		WapiVersion:        env.GetOrDefaultString("INFOBLOX_WAPI_VERSION", internal.DefaultWAPIVersion),
+		DNSView:            env.GetOrDefaultString("INFOBLOX_DNS_VIEW", defaultDNSView),
+		TTL:                env.GetOrDefaultInt("INFOBLOX_TTL", dns01.DefaultTTL),
+		PropagationTimeout: env.GetOrDefaultSecond("INFOBLOX_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("INFOBLOX_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("INFOBLOX_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+
+	if !env.GetOrDefaultBool("INFOBLOX_SSL_VERIFY", true) {
+		config.HTTPClient.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}
+	}
+
+	return config
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses the Infoblox NIOS WAPI to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	recordRefs   map[string]string
+	recordRefsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Infoblox.
+// Credentials must be passed in the environment variables:
+// INFOBLOX_HOST, INFOBLOX_USERNAME, and INFOBLOX_PASSWORD.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("INFOBLOX_HOST", "INFOBLOX_USERNAME", "INFOBLOX_PASSWORD")
+	if err != nil {
+		return nil, fmt.Errorf("infoblox: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.Host = values["INFOBLOX_HOST"]
+	config.Username = values["INFOBLOX_USERNAME"]
+	config.Password = values["INFOBLOX_PASSWORD"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Infoblox.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("infoblox: the configuration of the DNS provider is nil")
+	}
+
+	if config.Host == "" {
+		return nil, errors.New("infoblox: missing host")
+	}
+
+	if config.Username == "" || config.Password == "" {
+		return nil, errors.New("infoblox: credentials missing")
+	}
+
+	client, err := internal.NewClient(config.Host, config.WapiVersion, config.Username, config.Password)
+	if err != nil {
+		return nil, fmt.Errorf("infoblox: %v", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{
+		config:     config,
+		client:     client,
+		recordRefs: make(map[string]string),
+	}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	record := internal.RecordTXT{
+		Name:   dns01.UnFqdn(fqdn),
+		Text:   value,
+		View:   d.config.DNSView,
+		TTL:    uint32(d.config.TTL),
+		UseTTL: true,
+	}
+
+	ref, err := d.client.CreateTXTRecord(record)
+	if err != nil {
+		return fmt.Errorf("infoblox: %v", err)
+	}
+
+	d.recordRefsMu.Lock()
+	d.recordRefs[token] = ref
+	d.recordRefsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	d.recordRefsMu.Lock()
+	ref, ok := d.recordRefs[token]
+	d.recordRefsMu.Unlock()
+
+	if !ok {
+		records, err := d.client.GetTXTRecords(dns01.UnFqdn(fqdn), value, d.config.DNSView)
+		if err != nil {
+			return fmt.Errorf("infoblox: %v", err)
+		}
+
+		if len(records) == 0 {
+			return fmt.Errorf("infoblox: no TXT record found for %s", fqdn)
+		}
+
+		ref = records[0].Ref
+	}
+
+	err := d.client.DeleteObject(ref)
+	if err != nil {
+		return fmt.Errorf("infoblox: %v", err)
+	}
+
+	d.recordRefsMu.Lock()
+	delete(d.recordRefs, token)
+	d.recordRefsMu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,278 @@
+package infoblox
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest(
+	"INFOBLOX_HOST",
+	"INFOBLOX_USERNAME",
+	"INFOBLOX_PASSWORD",
+	"INFOBLOX_WAPI_VERSION",
+	"INFOBLOX_DNS_VIEW",
+	"INFOBLOX_SSL_VERIFY").
+	WithDomain("INFOBLOX_DOMAIN")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		insecure bool
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"INFOBLOX_HOST":     "infoblox.example.com",
+				"INFOBLOX_USERNAME": "user",
+				"INFOBLOX_PASSWORD": "secret",
+			},
+		},
+		{
+			desc: "success without SSL verification",
+			envVars: map[string]string{
+				"INFOBLOX_HOST":       "infoblox.example.com",
+				"INFOBLOX_USERNAME":   "user",
+				"INFOBLOX_PASSWORD":   "secret",
+				"INFOBLOX_SSL_VERIFY": "false",
+			},
+			insecure: true,
+		},
+		{
+			desc: "missing host",
+			envVars: map[string]string{
+				"INFOBLOX_HOST":     "",
+				"INFOBLOX_USERNAME": "user",
+				"INFOBLOX_PASSWORD": "secret",
+			},
+			expected: "infoblox: some credentials information are missing: INFOBLOX_HOST",
+		},
+		{
+			desc: "missing username",
+			envVars: map[string]string{
+				"INFOBLOX_HOST":     "infoblox.example.com",
+				"INFOBLOX_USERNAME": "",
+				"INFOBLOX_PASSWORD": "secret",
+			},
+			expected: "infoblox: some credentials information are missing: INFOBLOX_USERNAME",
+		},
+		{
+			desc: "missing password",
+			envVars: map[string]string{
+				"INFOBLOX_HOST":     "infoblox.example.com",
+				"INFOBLOX_USERNAME": "user",
+				"INFOBLOX_PASSWORD": "",
+			},
+			expected: "infoblox: some credentials information are missing: INFOBLOX_PASSWORD",
+		},
+		{
+			desc:     "missing credentials",
+			envVars:  map[string]string{},
+			expected: "infoblox: some credentials information are missing: INFOBLOX_HOST,INFOBLOX_USERNAME,INFOBLOX_PASSWORD",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+
+				if test.insecure {
+					transport, ok := p.config.HTTPClient.Transport.(*http.Transport)
+					require.True(t, ok)
+					assert.True(t, transport.TLSClientConfig.InsecureSkipVerify)
+				} else {
+					assert.Nil(t, p.config.HTTPClient.Transport)
+				}
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		host     string
+		username string
+		password string
+		expected string
+	}{
+		{
+			desc:     "success",
+			host:     "infoblox.example.com",
+			username: "user",
+			password: "secret",
+		},
+		{
+			desc:     "missing host",
+			username: "user",
+			password: "secret",
+			expected: "infoblox: missing host",
+		},
+		{
+			desc:     "missing username",
+			host:     "infoblox.example.com",
+			password: "secret",
+			expected: "infoblox: credentials missing",
+		},
+		{
+			desc:     "missing password",
+			host:     "infoblox.example.com",
+			username: "user",
+			expected: "infoblox: credentials missing",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.Host = test.host
+			config.Username = test.username
+			config.Password = test.password
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func setupTest() (*DNSProvider, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	config := NewDefaultConfig()
+	config.Host = server.URL
+	config.Username = "user"
+	config.Password = "secret"
+
+	p, err := NewDNSProviderConfig(config)
+	if err != nil {
+		panic(err)
+	}
+
+	return p, mux, server.Close
+}
+
+func TestDNSProvider_Present_CleanUp(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	ref := "record:txt/ZG5zLmJpbmRfdHh0:_acme-challenge.example.com/default"
+
+	mux.HandleFunc("/wapi/v2.11/record:txt", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(rw, "%q", ref)
+	})
+
+	var deleted bool
+	mux.HandleFunc("/wapi/v2.11/"+ref, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		deleted = true
+		fmt.Fprintf(rw, "%q", ref)
+	})
+
+	err := provider.Present("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Equal(t, ref, provider.recordRefs["token"])
+
+	err = provider.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.True(t, deleted)
+	assert.Empty(t, provider.recordRefs)
+}
+
+func TestDNSProvider_CleanUp_lookup(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	_, value := dns01.GetRecord("example.com", "keyAuth")
+	ref := "record:txt/ZG5zLmJpbmRfdHh0:_acme-challenge.example.com/default"
+
+	mux.HandleFunc("/wapi/v2.11/record:txt", func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("text") != value {
+			fmt.Fprint(rw, `[]`)
+			return
+		}
+
+		fmt.Fprintf(rw, `[{"_ref":%q,"name":"_acme-challenge.example.com","text":%q,"view":"default"}]`, ref, value)
+	})
+
+	var deleted bool
+	mux.HandleFunc("/wapi/v2.11/"+ref, func(rw http.ResponseWriter, req *http.Request) {
+		deleted = true
+		fmt.Fprintf(rw, "%q", ref)
+	})
+
+	err := provider.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.True(t, deleted)
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
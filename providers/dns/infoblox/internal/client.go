@@ -0,0 +1,163 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultWAPIVersion the default version of the WAPI.
+const DefaultWAPIVersion = "2.11"
+
+// RecordTXT a TXT record object (record:txt).
+type RecordTXT struct {
+	Ref    string `json:"_ref,omitempty"`
+	Name   string `json:"name"`
+	Text   string `json:"text"`
+	View   string `json:"view,omitempty"`
+	TTL    uint32 `json:"ttl,omitempty"`
+	UseTTL bool   `json:"use_ttl,omitempty"`
+}
+
+type apiError struct {
+	Error string `json:"Error"`
+	Code  string `json:"code"`
+	Text  string `json:"text"`
+}
+
+// Client the Infoblox NIOS WAPI client.
+type Client struct {
+	username   string
+	password   string
+	baseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates an Infoblox NIOS WAPI client.
+// The base URL is built from the host (and port) of the grid master and the WAPI version: https://<host>/wapi/v<version>
+func NewClient(host, version, username, password string) (*Client, error) {
+	if version == "" {
+		version = DefaultWAPIVersion
+	}
+
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+
+	baseURL := fmt.Sprintf("%s/wapi/v%s", strings.TrimSuffix(host, "/"), version)
+
+	_, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		username:   username,
+		password:   password,
+		baseURL:    baseURL,
+		HTTPClient: &http.Client{},
+	}, nil
+}
+
+// CreateTXTRecord creates a TXT record and returns its reference.
+func (c *Client) CreateTXTRecord(record RecordTXT) (string, error) {
+	var ref string
+	err := c.do(http.MethodPost, "record:txt", nil, record, &ref)
+	if err != nil {
+		return "", fmt.Errorf("unable to create the TXT record %s: %v", record.Name, err)
+	}
+
+	return ref, nil
+}
+
+// GetTXTRecords returns the TXT records matching the name, the text, and the DNS view.
+func (c *Client) GetTXTRecords(name, text, view string) ([]RecordTXT, error) {
+	query := url.Values{}
+	query.Set("name", name)
+	if text != "" {
+		query.Set("text", text)
+	}
+	if view != "" {
+		query.Set("view", view)
+	}
+
+	var records []RecordTXT
+	err := c.do(http.MethodGet, "record:txt", query, nil, &records)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the TXT records %s: %v", name, err)
+	}
+
+	return records, nil
+}
+
+// DeleteObject deletes an object by its reference.
+func (c *Client) DeleteObject(ref string) error {
+	err := c.do(http.MethodDelete, ref, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("unable to delete the object %s: %v", ref, err)
+	}
+
+	return nil
+}
+
+func (c *Client) do(method, ref string, query url.Values, payload, result interface{}) error {
+	// the references contain colons (i.e. "record:txt/ZG5z...:_acme-challenge.example.com/default"),
+	// so they cannot be resolved as relative URLs.
+	endpoint, err := url.Parse(c.baseURL + "/" + ref)
+	if err != nil {
+		return err
+	}
+
+	if len(query) > 0 {
+		endpoint.RawQuery = query.Encode()
+	}
+
+	var body bytes.Buffer
+	if payload != nil {
+		err = json.NewEncoder(&body).Encode(payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, endpoint.String(), &body)
+	if err != nil {
+		return err
+	}
+
+	req.SetBasicAuth(c.username, c.password)
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &apiError{}
+		if json.Unmarshal(raw, apiErr) == nil && apiErr.Text != "" {
+			return fmt.Errorf("%d: %s: %s", resp.StatusCode, apiErr.Code, apiErr.Text)
+		}
+
+		return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
@@ -0,0 +1,192 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest() (*Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client, err := NewClient(server.URL, "2.11", "user", "secret")
+	if err != nil {
+		panic(err)
+	}
+
+	return client, mux, server.Close
+}
+
+func checkAuth(rw http.ResponseWriter, req *http.Request) bool {
+	username, password, ok := req.BasicAuth()
+	if !ok || username != "user" || password != "secret" {
+		rw.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(rw, `{"Error":"AdmConProtoError: Authentication failed","code":"Client.Ibap.Proto","text":"Authentication failed"}`)
+		return false
+	}
+	return true
+}
+
+func TestNewClient(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		host     string
+		version  string
+		expected string
+	}{
+		{
+			desc:     "host",
+			host:     "infoblox.example.com",
+			version:  "2.11",
+			expected: "https://infoblox.example.com/wapi/v2.11",
+		},
+		{
+			desc:     "URL with port",
+			host:     "https://infoblox.example.com:8443/",
+			version:  "2.5",
+			expected: "https://infoblox.example.com:8443/wapi/v2.5",
+		},
+		{
+			desc:     "default version",
+			host:     "infoblox.example.com",
+			expected: "https://infoblox.example.com/wapi/v2.11",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			client, err := NewClient(test.host, test.version, "user", "secret")
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, client.baseURL)
+		})
+	}
+}
+
+func TestClient_CreateTXTRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/wapi/v2.11/record:txt", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		record := RecordTXT{}
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := RecordTXT{Name: "_acme-challenge.example.com", Text: "value", View: "default", TTL: 120, UseTTL: true}
+		if record != expected {
+			http.Error(rw, fmt.Sprintf("unexpected record: %+v", record), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		fmt.Fprint(rw, `"record:txt/ZG5zLmJpbmRfdHh0:_acme-challenge.example.com/default"`)
+	})
+
+	record := RecordTXT{Name: "_acme-challenge.example.com", Text: "value", View: "default", TTL: 120, UseTTL: true}
+
+	ref, err := client.CreateTXTRecord(record)
+	require.NoError(t, err)
+
+	assert.Equal(t, "record:txt/ZG5zLmJpbmRfdHh0:_acme-challenge.example.com/default", ref)
+}
+
+func TestClient_CreateTXTRecord_error(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	client.password = "invalid"
+
+	mux.HandleFunc("/wapi/v2.11/record:txt", func(rw http.ResponseWriter, req *http.Request) {
+		checkAuth(rw, req)
+	})
+
+	_, err := client.CreateTXTRecord(RecordTXT{Name: "_acme-challenge.example.com", Text: "value"})
+	require.EqualError(t, err, "unable to create the TXT record _acme-challenge.example.com: 401: Client.Ibap.Proto: Authentication failed")
+}
+
+func TestClient_GetTXTRecords(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/wapi/v2.11/record:txt", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		query := req.URL.Query()
+		if query.Get("name") != "_acme-challenge.example.com" || query.Get("text") != "value" || query.Get("view") != "default" {
+			http.Error(rw, fmt.Sprintf("unexpected query: %s", req.URL.RawQuery), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `[{"_ref":"record:txt/ZG5zLmJpbmRfdHh0:_acme-challenge.example.com/default","name":"_acme-challenge.example.com","text":"value","view":"default"}]`)
+	})
+
+	records, err := client.GetTXTRecords("_acme-challenge.example.com", "value", "default")
+	require.NoError(t, err)
+
+	expected := []RecordTXT{{
+		Ref:  "record:txt/ZG5zLmJpbmRfdHh0:_acme-challenge.example.com/default",
+		Name: "_acme-challenge.example.com",
+		Text: "value",
+		View: "default",
+	}}
+	assert.Equal(t, expected, records)
+}
+
+func TestClient_DeleteObject(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/wapi/v2.11/record:txt/ZG5zLmJpbmRfdHh0:_acme-challenge.example.com/default", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		fmt.Fprint(rw, `"record:txt/ZG5zLmJpbmRfdHh0:_acme-challenge.example.com/default"`)
+	})
+
+	err := client.DeleteObject("record:txt/ZG5zLmJpbmRfdHh0:_acme-challenge.example.com/default")
+	require.NoError(t, err)
+}
+
+func TestClient_DeleteObject_error(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/wapi/v2.11/record:txt/unknown", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(rw, `{"Error":"AdmConDataNotFoundError: Reference record:txt/unknown not found","code":"Client.Ibap.Data.NotFound","text":"Reference record:txt/unknown not found"}`)
+	})
+
+	err := client.DeleteObject("record:txt/unknown")
+	require.EqualError(t, err, "unable to delete the object record:txt/unknown: 404: Client.Ibap.Data.NotFound: Reference record:txt/unknown not found")
+}
@@ -0,0 +1,180 @@
+// Package infomaniak implements a DNS provider for solving the DNS-01 challenge using Infomaniak DNS.
+package infomaniak
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/infomaniak/internal"
+)
+
+// minTTL the minimal TTL allowed by Infomaniak.
+const minTTL = 300
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	APIEndpoint        string
+	AccessToken        string
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		APIEndpoint:        env.GetOrDefaultString("INFOMANIAK_ENDPOINT", internal.DefaultBaseURL),
+		TTL:                env.GetOrDefaultInt("INFOMANIAK_TTL", minTTL),
+		PropagationTimeout: env.GetOrDefaultSecond("INFOMANIAK_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("INFOMANIAK_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("INFOMANIAK_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses Infomaniak's API to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	recordIDs   map[string]string
+	recordIDsMu sync.Mutex
+
+	domainIDs   map[string]uint64
+	domainIDsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Infomaniak.
+// Credentials must be passed in the environment variable: INFOMANIAK_ACCESS_TOKEN.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("INFOMANIAK_ACCESS_TOKEN")
+	if err != nil {
+		return nil, fmt.Errorf("infomaniak: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.AccessToken = values["INFOMANIAK_ACCESS_TOKEN"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Infomaniak.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("infomaniak: the configuration of the DNS provider is nil")
+	}
+
+	if config.AccessToken == "" {
+		return nil, errors.New("infomaniak: credentials missing")
+	}
+
+	if config.TTL < minTTL {
+		return nil, fmt.Errorf("infomaniak: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	}
+
+	client := internal.NewClient(config.AccessToken)
+
+	if config.APIEndpoint != "" {
+		client.BaseURL = config.APIEndpoint
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{
+		config:    config,
+		client:    client,
+		recordIDs: make(map[string]string),
+		domainIDs: make(map[string]uint64),
+	}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	ikDomain, err := d.client.GetDomainByName(domain)
+	if err != nil {
+		return fmt.Errorf("infomaniak: %v", err)
+	}
+
+	record := internal.Record{
+		Source: extractRecordName(fqdn, ikDomain.CustomerName),
+		Target: value,
+		Type:   "TXT",
+		TTL:    d.config.TTL,
+	}
+
+	recordID, err := d.client.CreateDNSRecord(ikDomain, record)
+	if err != nil {
+		return fmt.Errorf("infomaniak: %v", err)
+	}
+
+	d.recordIDsMu.Lock()
+	d.recordIDs[token] = recordID
+	d.recordIDsMu.Unlock()
+
+	d.domainIDsMu.Lock()
+	d.domainIDs[token] = ikDomain.ID
+	d.domainIDsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	d.recordIDsMu.Lock()
+	recordID, ok := d.recordIDs[token]
+	d.recordIDsMu.Unlock()
+	if !ok {
+		return fmt.Errorf("infomaniak: unknown record ID for '%s'", fqdn)
+	}
+
+	d.domainIDsMu.Lock()
+	domainID, ok := d.domainIDs[token]
+	d.domainIDsMu.Unlock()
+	if !ok {
+		return fmt.Errorf("infomaniak: unknown domain ID for '%s'", fqdn)
+	}
+
+	err := d.client.DeleteDNSRecord(domainID, recordID)
+	if err != nil {
+		return fmt.Errorf("infomaniak: %v", err)
+	}
+
+	d.recordIDsMu.Lock()
+	delete(d.recordIDs, token)
+	d.recordIDsMu.Unlock()
+
+	d.domainIDsMu.Lock()
+	delete(d.domainIDs, token)
+	d.domainIDsMu.Unlock()
+
+	return nil
+}
+
+func extractRecordName(fqdn, domain string) string {
+	name := dns01.UnFqdn(fqdn)
+	if idx := strings.LastIndex(name, "."+domain); idx != -1 {
+		return name[:idx]
+	}
+	return name
+}
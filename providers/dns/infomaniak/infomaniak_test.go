@@ -0,0 +1,175 @@
+package infomaniak
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest("INFOMANIAK_ACCESS_TOKEN").
+	WithDomain("INFOMANIAK_DOMAIN")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"INFOMANIAK_ACCESS_TOKEN": "123",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				"INFOMANIAK_ACCESS_TOKEN": "",
+			},
+			expected: "infomaniak: some credentials information are missing: INFOMANIAK_ACCESS_TOKEN",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		accessToken string
+		ttl         int
+		expected    string
+	}{
+		{
+			desc:        "success",
+			accessToken: "123",
+			ttl:         minTTL,
+		},
+		{
+			desc:     "missing credentials",
+			ttl:      minTTL,
+			expected: "infomaniak: credentials missing",
+		},
+		{
+			desc:        "invalid TTL",
+			accessToken: "123",
+			ttl:         60,
+			expected:    "infomaniak: invalid TTL, TTL (60) must be greater than 300",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.AccessToken = test.accessToken
+			config.TTL = test.ttl
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestDNSProvider_PresentAndCleanUp(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/1/product", func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("customer_name") == "example.com" {
+			fmt.Fprint(rw, `{"result":"success","data":[{"id":123,"customer_name":"example.com"}]}`)
+			return
+		}
+		fmt.Fprint(rw, `{"result":"success","data":[]}`)
+	})
+
+	mux.HandleFunc("/1/domain/123/dns/record", func(rw http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(rw, `{"result":"success","data":"456"}`)
+	})
+
+	var deleted bool
+	mux.HandleFunc("/1/domain/123/dns/record/456", func(rw http.ResponseWriter, req *http.Request) {
+		deleted = req.Method == http.MethodDelete
+		fmt.Fprint(rw, `{"result":"success","data":true}`)
+	})
+
+	config := NewDefaultConfig()
+	config.AccessToken = "token"
+	config.APIEndpoint = server.URL
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	err = provider.Present("sub.example.com", "abc", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Equal(t, "456", provider.recordIDs["abc"])
+	assert.Equal(t, uint64(123), provider.domainIDs["abc"])
+
+	err = provider.CleanUp("sub.example.com", "abc", "keyAuth")
+	require.NoError(t, err)
+
+	assert.True(t, deleted)
+	assert.Empty(t, provider.recordIDs)
+	assert.Empty(t, provider.domainIDs)
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
@@ -0,0 +1,167 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+)
+
+// DefaultBaseURL the default base URL of the Infomaniak API.
+const DefaultBaseURL = "https://api.infomaniak.com"
+
+// DNSDomain a domain product of the account.
+type DNSDomain struct {
+	ID           uint64 `json:"id,omitempty"`
+	CustomerName string `json:"customer_name,omitempty"`
+}
+
+// Record a DNS record.
+type Record struct {
+	ID     string `json:"id,omitempty"`
+	Source string `json:"source,omitempty"`
+	Type   string `json:"type,omitempty"`
+	TTL    int    `json:"ttl,omitempty"`
+	Target string `json:"target,omitempty"`
+}
+
+// APIResponse the envelope of the responses of the API.
+type APIResponse struct {
+	Result      string          `json:"result"`
+	Data        json.RawMessage `json:"data,omitempty"`
+	ErrResponse *APIErrorDetail `json:"error,omitempty"`
+}
+
+// APIErrorDetail the error details of a response.
+type APIErrorDetail struct {
+	Code        string `json:"code"`
+	Description string `json:"description,omitempty"`
+}
+
+func (a APIErrorDetail) Error() string {
+	return fmt.Sprintf("%s: %s", a.Code, a.Description)
+}
+
+// Client the Infomaniak API client.
+type Client struct {
+	accessToken string
+	BaseURL     string
+	HTTPClient  *http.Client
+}
+
+// NewClient creates an Infomaniak API client.
+func NewClient(accessToken string) *Client {
+	return &Client{
+		accessToken: accessToken,
+		BaseURL:     DefaultBaseURL,
+		HTTPClient:  &http.Client{},
+	}
+}
+
+// GetDomainByName returns the domain product managing the DNS zone of a name.
+// The domains are product-scoped: the name and its parents are looked up until a domain product is found.
+func (c *Client) GetDomainByName(name string) (*DNSDomain, error) {
+	name = dns01.UnFqdn(name)
+
+	for {
+		query := url.Values{}
+		query.Set("service_name", "domain")
+		query.Set("customer_name", name)
+
+		var domains []DNSDomain
+		err := c.do(http.MethodGet, "/1/product?"+query.Encode(), nil, &domains)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get domain %s: %v", name, err)
+		}
+
+		for _, domain := range domains {
+			if domain.CustomerName == name {
+				return &domain, nil
+			}
+		}
+
+		idx := strings.Index(name, ".")
+		if idx == -1 || !strings.Contains(name[idx+1:], ".") {
+			return nil, fmt.Errorf("domain not found for %s", name)
+		}
+
+		name = name[idx+1:]
+	}
+}
+
+// CreateDNSRecord creates a DNS record in a domain and returns its ID.
+func (c *Client) CreateDNSRecord(domain *DNSDomain, record Record) (string, error) {
+	var recordID string
+	err := c.do(http.MethodPost, fmt.Sprintf("/1/domain/%d/dns/record", domain.ID), record, &recordID)
+	if err != nil {
+		return "", fmt.Errorf("unable to create record %s: %v", record.Source, err)
+	}
+
+	return recordID, nil
+}
+
+// DeleteDNSRecord deletes a DNS record of a domain.
+func (c *Client) DeleteDNSRecord(domainID uint64, recordID string) error {
+	err := c.do(http.MethodDelete, fmt.Sprintf("/1/domain/%d/dns/record/%s", domainID, recordID), nil, nil)
+	if err != nil {
+		return fmt.Errorf("unable to delete record %s: %v", recordID, err)
+	}
+
+	return nil
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body bytes.Buffer
+	if payload != nil {
+		err := json.NewEncoder(&body).Encode(payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+uri, &body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	apiResp := &APIResponse{}
+	err = json.Unmarshal(raw, apiResp)
+	if err != nil {
+		return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	if apiResp.Result != "success" {
+		if apiResp.ErrResponse != nil {
+			return fmt.Errorf("%d: %v", resp.StatusCode, apiResp.ErrResponse)
+		}
+		return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	if result == nil || len(apiResp.Data) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(apiResp.Data, result)
+}
@@ -0,0 +1,109 @@
+package internal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest() (*http.ServeMux, *Client, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client := NewClient("token")
+	client.BaseURL = server.URL
+
+	return mux, client, server.Close
+}
+
+func TestClient_GetDomainByName(t *testing.T) {
+	mux, client, tearDown := setupTest()
+	defer tearDown()
+
+	var lookups []string
+	mux.HandleFunc("/1/product", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer token" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(rw, `{"result":"error","error":{"code":"not_authorized","description":"Authorization required"}}`)
+			return
+		}
+
+		name := req.URL.Query().Get("customer_name")
+		lookups = append(lookups, name)
+
+		if name == "example.com" {
+			fmt.Fprint(rw, `{"result":"success","data":[{"id":123,"customer_name":"example.com"}]}`)
+			return
+		}
+
+		fmt.Fprint(rw, `{"result":"success","data":[]}`)
+	})
+
+	domain, err := client.GetDomainByName("_acme-challenge.sub.example.com.")
+	require.NoError(t, err)
+
+	assert.Equal(t, &DNSDomain{ID: 123, CustomerName: "example.com"}, domain)
+	assert.Equal(t, []string{"_acme-challenge.sub.example.com", "sub.example.com", "example.com"}, lookups)
+
+	_, err = client.GetDomainByName("example.org")
+	require.EqualError(t, err, "domain not found for example.org")
+
+	client.accessToken = "invalid"
+
+	_, err = client.GetDomainByName("example.com")
+	require.EqualError(t, err, "unable to get domain example.com: 401: not_authorized: Authorization required")
+}
+
+func TestClient_CreateDNSRecord(t *testing.T) {
+	mux, client, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/1/domain/123/dns/record", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := `{"source":"_acme-challenge","type":"TXT","ttl":300,"target":"txtvalue"}`
+		if string(body) != expected+"\n" {
+			http.Error(rw, fmt.Sprintf("unexpected body: %s", string(body)), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `{"result":"success","data":"456"}`)
+	})
+
+	record := Record{Source: "_acme-challenge", Type: "TXT", TTL: 300, Target: "txtvalue"}
+
+	recordID, err := client.CreateDNSRecord(&DNSDomain{ID: 123}, record)
+	require.NoError(t, err)
+	assert.Equal(t, "456", recordID)
+}
+
+func TestClient_DeleteDNSRecord(t *testing.T) {
+	mux, client, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/1/domain/123/dns/record/456", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		fmt.Fprint(rw, `{"result":"success","data":true}`)
+	})
+
+	err := client.DeleteDNSRecord(123, "456")
+	require.NoError(t, err)
+}
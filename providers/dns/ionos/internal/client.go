@@ -0,0 +1,167 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// DefaultBaseURL the default base URL of the IONOS DNS API.
+const DefaultBaseURL = "https://api.hosting.ionos.com/dns/v1"
+
+// Zone a DNS zone.
+type Zone struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Records []Record `json:"records,omitempty"`
+}
+
+// Record a DNS record, the name is the full name of the record (i.e. "_acme-challenge.example.com").
+type Record struct {
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Content  string `json:"content"`
+	TTL      int    `json:"ttl,omitempty"`
+	Prio     int    `json:"prio,omitempty"`
+	Disabled bool   `json:"disabled"`
+}
+
+// RecordsFilter filters the records of a zone.
+type RecordsFilter struct {
+	Suffix     string
+	RecordName string
+	RecordType string
+}
+
+type apiError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Client the IONOS DNS API client.
+type Client struct {
+	apiKey     string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates an IONOS DNS API client.
+// The API key is composed of the public prefix and the secret: "prefix.secret".
+func NewClient(apiKey string) *Client {
+	return &Client{
+		apiKey:     apiKey,
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// ListZones returns the zones of the account.
+func (c *Client) ListZones() ([]Zone, error) {
+	var zones []Zone
+	err := c.do(http.MethodGet, "/zones", nil, &zones)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list zones: %v", err)
+	}
+
+	return zones, nil
+}
+
+// GetRecords returns the records of a zone matching the filter.
+func (c *Client) GetRecords(zoneID string, filter RecordsFilter) ([]Record, error) {
+	query := url.Values{}
+	if filter.Suffix != "" {
+		query.Set("suffix", filter.Suffix)
+	}
+	if filter.RecordName != "" {
+		query.Set("recordName", filter.RecordName)
+	}
+	if filter.RecordType != "" {
+		query.Set("recordType", filter.RecordType)
+	}
+
+	uri := "/zones/" + zoneID
+	if len(query) > 0 {
+		uri += "?" + query.Encode()
+	}
+
+	zone := &Zone{}
+	err := c.do(http.MethodGet, uri, nil, zone)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the records of the zone %s: %v", zoneID, err)
+	}
+
+	return zone.Records, nil
+}
+
+// AddRecords adds records to a zone: the PATCH method keeps the existing records.
+func (c *Client) AddRecords(zoneID string, records []Record) error {
+	err := c.do(http.MethodPatch, "/zones/"+zoneID, records, nil)
+	if err != nil {
+		return fmt.Errorf("unable to add records to the zone %s: %v", zoneID, err)
+	}
+
+	return nil
+}
+
+// DeleteRecord deletes a record of a zone.
+func (c *Client) DeleteRecord(zoneID, recordID string) error {
+	err := c.do(http.MethodDelete, fmt.Sprintf("/zones/%s/records/%s", zoneID, recordID), nil, nil)
+	if err != nil {
+		return fmt.Errorf("unable to delete the record %s of the zone %s: %v", recordID, zoneID, err)
+	}
+
+	return nil
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body bytes.Buffer
+	if payload != nil {
+		err := json.NewEncoder(&body).Encode(payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+uri, &body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-API-Key", c.apiKey)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		// the errors are returned as a list.
+		var apiErrs []apiError
+		if json.Unmarshal(raw, &apiErrs) == nil && len(apiErrs) > 0 {
+			return fmt.Errorf("%d: %s: %s", resp.StatusCode, apiErrs[0].Code, apiErrs[0].Message)
+		}
+
+		return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
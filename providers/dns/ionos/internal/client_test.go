@@ -0,0 +1,139 @@
+package internal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest() (*Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client := NewClient("prefix.secret")
+	client.BaseURL = server.URL
+
+	return client, mux, server.Close
+}
+
+func checkAuth(rw http.ResponseWriter, req *http.Request) bool {
+	if req.Header.Get("X-API-Key") != "prefix.secret" {
+		rw.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(rw, `[{"code":"UNAUTHORIZED","message":"The customer is not authorized to do this operation."}]`)
+		return false
+	}
+	return true
+}
+
+func TestClient_ListZones(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/zones", func(rw http.ResponseWriter, req *http.Request) {
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		fmt.Fprint(rw, `[{"id":"11af3414-ebba-11e9-8df5-66fbe8a334b4","name":"example.com","type":"NATIVE"}]`)
+	})
+
+	zones, err := client.ListZones()
+	require.NoError(t, err)
+
+	expected := []Zone{{ID: "11af3414-ebba-11e9-8df5-66fbe8a334b4", Name: "example.com", Type: "NATIVE"}}
+	assert.Equal(t, expected, zones)
+}
+
+func TestClient_ListZones_error(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	client.apiKey = "invalid"
+
+	mux.HandleFunc("/zones", func(rw http.ResponseWriter, req *http.Request) {
+		checkAuth(rw, req)
+	})
+
+	_, err := client.ListZones()
+	require.EqualError(t, err, "unable to list zones: 401: UNAUTHORIZED: The customer is not authorized to do this operation.")
+}
+
+func TestClient_GetRecords(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/zones/abc", func(rw http.ResponseWriter, req *http.Request) {
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		query := req.URL.Query()
+		if query.Get("recordName") != "_acme-challenge.example.com" || query.Get("recordType") != "TXT" {
+			http.Error(rw, fmt.Sprintf("unexpected query: %s", req.URL.RawQuery), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `{"id":"abc","name":"example.com","type":"NATIVE","records":[{"id":"42","name":"_acme-challenge.example.com","type":"TXT","content":"\"value\"","ttl":300,"disabled":false}]}`)
+	})
+
+	records, err := client.GetRecords("abc", RecordsFilter{RecordName: "_acme-challenge.example.com", RecordType: "TXT"})
+	require.NoError(t, err)
+
+	expected := []Record{{ID: "42", Name: "_acme-challenge.example.com", Type: "TXT", Content: `"value"`, TTL: 300}}
+	assert.Equal(t, expected, records)
+}
+
+func TestClient_AddRecords(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/zones/abc", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPatch {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := `[{"name":"_acme-challenge.example.com","type":"TXT","content":"value","ttl":300,"disabled":false}]` + "\n"
+		if string(body) != expected {
+			http.Error(rw, fmt.Sprintf("unexpected body: %s", string(body)), http.StatusBadRequest)
+			return
+		}
+	})
+
+	records := []Record{{Name: "_acme-challenge.example.com", Type: "TXT", Content: "value", TTL: 300}}
+
+	err := client.AddRecords("abc", records)
+	require.NoError(t, err)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/zones/abc/records/42", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		checkAuth(rw, req)
+	})
+
+	err := client.DeleteRecord("abc", "42")
+	require.NoError(t, err)
+}
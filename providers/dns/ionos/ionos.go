@@ -0,0 +1,179 @@
+// Package ionos implements a DNS provider for solving the DNS-01 challenge using IONOS DNS.
+package ionos
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/ionos/internal"
+)
+
+// minTTL the minimal TTL allowed by IONOS.
+const minTTL = 300
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL            string
+	APIKey             string
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString("IONOS_ENDPOINT", internal.DefaultBaseURL),
+		TTL:                env.GetOrDefaultInt("IONOS_TTL", minTTL),
+		PropagationTimeout: env.GetOrDefaultSecond("IONOS_PROPAGATION_TIMEOUT", 5*time.Minute),
+		PollingInterval:    env.GetOrDefaultSecond("IONOS_POLLING_INTERVAL", 10*time.Second),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("IONOS_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses the IONOS DNS API to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for IONOS.
+// Credentials must be passed in the environment variable: IONOS_API_KEY.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("IONOS_API_KEY")
+	if err != nil {
+		return nil, fmt.Errorf("ionos: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIKey = values["IONOS_API_KEY"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for IONOS.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("ionos: the configuration of the DNS provider is nil")
+	}
+
+	if config.APIKey == "" {
+		return nil, errors.New("ionos: credentials missing")
+	}
+
+	parts := strings.SplitN(config.APIKey, ".", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, errors.New("ionos: the API key must use the format 'prefix.secret'")
+	}
+
+	if config.TTL < minTTL {
+		return nil, fmt.Errorf("ionos: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	}
+
+	client := internal.NewClient(config.APIKey)
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("ionos: %v", err)
+	}
+
+	records := []internal.Record{{
+		Name:    dns01.UnFqdn(fqdn),
+		Type:    "TXT",
+		Content: value,
+		TTL:     d.config.TTL,
+	}}
+
+	err = d.client.AddRecords(zone.ID, records)
+	if err != nil {
+		return fmt.Errorf("ionos: %v", err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("ionos: %v", err)
+	}
+
+	filter := internal.RecordsFilter{
+		RecordName: dns01.UnFqdn(fqdn),
+		RecordType: "TXT",
+	}
+
+	records, err := d.client.GetRecords(zone.ID, filter)
+	if err != nil {
+		return fmt.Errorf("ionos: %v", err)
+	}
+
+	for _, record := range records {
+		if record.Name != dns01.UnFqdn(fqdn) || strings.Trim(record.Content, `"`) != value {
+			continue
+		}
+
+		err = d.client.DeleteRecord(zone.ID, record.ID)
+		if err != nil {
+			return fmt.Errorf("ionos: %v", err)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("ionos: no TXT record found for %s", fqdn)
+}
+
+// findZone returns the zone of the account matching the authoritative zone of the FQDN.
+func (d *DNSProvider) findZone(fqdn string) (*internal.Zone, error) {
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return nil, fmt.Errorf("could not find zone for FQDN %q: %v", fqdn, err)
+	}
+
+	zones, err := d.client.ListZones()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, zone := range zones {
+		if zone.Name == dns01.UnFqdn(authZone) {
+			return &zone, nil
+		}
+	}
+
+	return nil, fmt.Errorf("zone %s not found", dns01.UnFqdn(authZone))
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -18,6 +19,8 @@ import (
 
 const (
 	maxRetries = 5
+	// defaultRegion the region of the Lightsail DNS zones: the domain operations are only available in us-east-1.
+	defaultRegion = "us-east-1"
 )
 
 // customRetryer implements the client.Retryer interface by composing the DefaultRetryer.
@@ -43,10 +46,15 @@ func (c customRetryer) RetryRules(r *request.Request) time.Duration {
 
 // Config is used to configure the creation of the DNSProvider
 type Config struct {
-	DNSZone            string
+	// DNSZone the DNS zone of the domains, if empty the zone is found in the DNS zones of the account.
+	DNSZone string
+	// Region the region of the Lightsail API, the DNS zones are managed in us-east-1.
 	Region             string
 	PropagationTimeout time.Duration
 	PollingInterval    time.Duration
+
+	// Profile the name of the profile of the shared configuration (~/.aws/config and ~/.aws/credentials).
+	Profile string
 }
 
 // NewDefaultConfig returns a default configuration for the DNSProvider
@@ -55,7 +63,8 @@ func NewDefaultConfig() *Config {
 		DNSZone:            env.GetOrFile("DNS_ZONE"),
 		PropagationTimeout: env.GetOrDefaultSecond("LIGHTSAIL_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
 		PollingInterval:    env.GetOrDefaultSecond("LIGHTSAIL_POLLING_INTERVAL", dns01.DefaultPollingInterval),
-		Region:             env.GetOrDefaultString("LIGHTSAIL_REGION", "us-east-1"),
+		Region:             env.GetOrDefaultString("LIGHTSAIL_REGION", defaultRegion),
+		Profile:            env.GetOrFile("AWS_PROFILE"),
 	}
 }
 
@@ -71,10 +80,11 @@ type DNSProvider struct {
 // and prioritized in the following order:
 // 1. Environment variables: AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY,
 //     [AWS_SESSION_TOKEN], [DNS_ZONE], [LIGHTSAIL_REGION]
-// 2. Shared credentials file (defaults to ~/.aws/credentials)
+// 2. Shared credentials file (defaults to ~/.aws/credentials), and shared configuration file (defaults to ~/.aws/config)
+//    with the profile AWS_PROFILE
 // 3. Amazon EC2 IAM role
 //
-// public hosted zone via the FQDN.
+// If DNS_ZONE is not set, Lego tries to determine the correct DNS zone of the account via the FQDN.
 //
 // See also: https://github.com/aws/aws-sdk-go/wiki/configuring-sdk
 func NewDNSProvider() (*DNSProvider, error) {
@@ -87,13 +97,22 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("lightsail: the configuration of the DNS provider is nil")
 	}
 
+	region := config.Region
+	if region == "" {
+		region = defaultRegion
+	}
+
 	retryer := customRetryer{}
 	retryer.NumMaxRetries = maxRetries
 
-	conf := aws.NewConfig().WithRegion(config.Region)
-	sess, err := session.NewSession(request.WithRetryer(conf, retryer))
+	conf := aws.NewConfig().WithRegion(region)
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *request.WithRetryer(conf, retryer),
+		Profile:           config.Profile,
+		SharedConfigState: session.SharedConfigEnable,
+	})
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("lightsail: %v", err)
 	}
 
 	return &DNSProvider{
@@ -106,7 +125,12 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	fqdn, value := dns01.GetRecord(domain, keyAuth)
 
-	err := d.newTxtRecord(fqdn, `"`+value+`"`)
+	zone, err := d.getZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("lightsail: %v", err)
+	}
+
+	err = d.newTxtRecord(zone, fqdn, `"`+value+`"`)
 	if err != nil {
 		return fmt.Errorf("lightsail: %v", err)
 	}
@@ -117,8 +141,13 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 	fqdn, value := dns01.GetRecord(domain, keyAuth)
 
+	zone, err := d.getZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("lightsail: %v", err)
+	}
+
 	params := &lightsail.DeleteDomainEntryInput{
-		DomainName: aws.String(d.config.DNSZone),
+		DomainName: aws.String(zone),
 		DomainEntry: &lightsail.DomainEntry{
 			Name:   aws.String(fqdn),
 			Type:   aws.String("TXT"),
@@ -126,7 +155,7 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 		},
 	}
 
-	_, err := d.client.DeleteDomainEntry(params)
+	_, err = d.client.DeleteDomainEntry(params)
 	if err != nil {
 		return fmt.Errorf("lightsail: %v", err)
 	}
@@ -139,9 +168,9 @@ func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
 	return d.config.PropagationTimeout, d.config.PollingInterval
 }
 
-func (d *DNSProvider) newTxtRecord(fqdn string, value string) error {
+func (d *DNSProvider) newTxtRecord(zone, fqdn, value string) error {
 	params := &lightsail.CreateDomainEntryInput{
-		DomainName: aws.String(d.config.DNSZone),
+		DomainName: aws.String(zone),
 		DomainEntry: &lightsail.DomainEntry{
 			Name:   aws.String(fqdn),
 			Target: aws.String(value),
@@ -151,3 +180,55 @@ func (d *DNSProvider) newTxtRecord(fqdn string, value string) error {
 	_, err := d.client.CreateDomainEntry(params)
 	return err
 }
+
+// getZone returns the DNS zone of a FQDN:
+// the DNS zone of the configuration, or the most specific DNS zone of the account matching the FQDN.
+func (d *DNSProvider) getZone(fqdn string) (string, error) {
+	if d.config.DNSZone != "" {
+		return d.config.DNSZone, nil
+	}
+
+	domains, err := d.listDomains()
+	if err != nil {
+		return "", fmt.Errorf("failed to list the DNS zones: %v", err)
+	}
+
+	name := strings.ToLower(dns01.UnFqdn(fqdn))
+
+	var zone string
+	for _, domain := range domains {
+		candidate := strings.ToLower(dns01.UnFqdn(domain))
+		if (name == candidate || strings.HasSuffix(name, "."+candidate)) && len(candidate) > len(zone) {
+			zone = candidate
+		}
+	}
+
+	if zone == "" {
+		return "", fmt.Errorf("no DNS zone found for %s", fqdn)
+	}
+
+	return zone, nil
+}
+
+// listDomains returns the names of all the DNS zones of the account.
+func (d *DNSProvider) listDomains() ([]string, error) {
+	var names []string
+
+	input := &lightsail.GetDomainsInput{}
+	for {
+		output, err := d.client.GetDomains(input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, domain := range output.Domains {
+			names = append(names, aws.StringValue(domain.Name))
+		}
+
+		if aws.StringValue(output.NextPageToken) == "" {
+			return names, nil
+		}
+
+		input.PageToken = output.NextPageToken
+	}
+}
@@ -1,6 +1,9 @@
 package lightsail
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
@@ -10,6 +13,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/lightsail"
 	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -17,7 +21,11 @@ var envTest = tester.NewEnvTest(
 	"AWS_ACCESS_KEY_ID",
 	"AWS_SECRET_ACCESS_KEY",
 	"AWS_REGION",
-	"AWS_HOSTED_ZONE_ID").
+	"AWS_HOSTED_ZONE_ID",
+	"AWS_PROFILE",
+	"AWS_CONFIG_FILE",
+	"AWS_SHARED_CREDENTIALS_FILE",
+	"LIGHTSAIL_REGION").
 	WithDomain("DNS_ZONE").
 	WithLiveTestRequirements("AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "DNS_ZONE")
 
@@ -35,6 +43,7 @@ func makeProvider(ts *httptest.Server) (*DNSProvider, error) {
 	}
 
 	conf := NewDefaultConfig()
+	conf.DNSZone = "example.com"
 
 	client := lightsail.New(sess)
 	return &DNSProvider{client: client, config: conf}, nil
@@ -76,3 +85,81 @@ func TestDNSProvider_Present(t *testing.T) {
 	err = provider.Present(domain, "", keyAuth)
 	require.NoError(t, err, "Expected Present to return no error")
 }
+
+func TestDNSProvider_Present_zoneDiscovery(t *testing.T) {
+	var domainName string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var body map[string]interface{}
+		err := json.NewDecoder(req.Body).Decode(&body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		rw.Header().Set("Content-Type", "application/x-amz-json-1.1")
+
+		switch req.Header.Get("X-Amz-Target") {
+		case "Lightsail_20161128.GetDomains":
+			if body["pageToken"] == nil {
+				_, _ = fmt.Fprint(rw, `{"domains":[{"name":"example.com"},{"name":"example.org"}],"nextPageToken":"next"}`)
+				return
+			}
+			_, _ = fmt.Fprint(rw, `{"domains":[{"name":"sub.example.com"}]}`)
+
+		case "Lightsail_20161128.CreateDomainEntry":
+			domainName, _ = body["domainName"].(string)
+			_, _ = fmt.Fprint(rw, `{"operation":{}}`)
+
+		default:
+			http.Error(rw, fmt.Sprintf("unexpected target: %s", req.Header.Get("X-Amz-Target")), http.StatusBadRequest)
+		}
+	}))
+	defer ts.Close()
+
+	provider, err := makeProvider(ts)
+	require.NoError(t, err)
+
+	provider.config.DNSZone = ""
+
+	err = provider.Present("www.sub.example.com", "", "123456d==")
+	require.NoError(t, err)
+
+	assert.Equal(t, "sub.example.com", domainName)
+}
+
+func TestDNSProvider_getZone_notFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		_, _ = fmt.Fprint(rw, `{"domains":[{"name":"example.org"}]}`)
+	}))
+	defer ts.Close()
+
+	provider, err := makeProvider(ts)
+	require.NoError(t, err)
+
+	provider.config.DNSZone = ""
+
+	_, err = provider.getZone("_acme-challenge.example.com.")
+	require.EqualError(t, err, "no DNS zone found for _acme-challenge.example.com.")
+}
+
+func TestNewDNSProviderConfig_profile(t *testing.T) {
+	defer envTest.RestoreEnv()
+	envTest.ClearEnv()
+
+	os.Setenv("AWS_CONFIG_FILE", "fixtures/config")
+	os.Setenv("AWS_SHARED_CREDENTIALS_FILE", "fixtures/credentials")
+
+	config := NewDefaultConfig()
+	config.Profile = "dns"
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	creds, err := provider.client.Config.Credentials.Get()
+	require.NoError(t, err)
+
+	assert.Equal(t, "AKIDPROFILE", creds.AccessKeyID)
+	assert.Equal(t, "us-east-1", aws.StringValue(provider.client.Config.Region))
+}
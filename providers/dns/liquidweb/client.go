@@ -0,0 +1,216 @@
+package liquidweb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v3"
+)
+
+// the Liquid Web client library doesn't expose the list methods of the DNS API.
+
+const defaultPageSize = 100
+
+// retryInterval is the initial interval between two attempts of a request.
+// It is overridden during tests.
+var retryInterval = 500 * time.Millisecond
+
+type apiRequest struct {
+	Params interface{} `json:"params"`
+}
+
+type listParams struct {
+	Zone     string `json:"zone,omitempty"`
+	PageNum  int    `json:"page_num"`
+	PageSize int    `json:"page_size"`
+}
+
+type deleteParams struct {
+	ID int `json:"id"`
+}
+
+type listResponse struct {
+	ItemTotal int             `json:"item_total"`
+	PageNum   int             `json:"page_num"`
+	PageTotal int             `json:"page_total"`
+	Items     json.RawMessage `json:"items"`
+}
+
+type apiError struct {
+	ErrorClass  string `json:"error_class"`
+	FullMessage string `json:"full_message"`
+}
+
+func (a apiError) Error() string {
+	return fmt.Sprintf("%s: %s", a.ErrorClass, a.FullMessage)
+}
+
+type statusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (s statusError) Error() string {
+	return fmt.Sprintf("%d: %s", s.StatusCode, s.Body)
+}
+
+type dnsZone struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type dnsRecord struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	RData string `json:"rdata"`
+	TTL   int    `json:"ttl"`
+}
+
+// listZones returns all the zones of the account.
+func (d *DNSProvider) listZones() ([]dnsZone, error) {
+	var zones []dnsZone
+
+	err := d.list("Network/DNS/Zone/list", listParams{}, func(items json.RawMessage) error {
+		var page []dnsZone
+		err := json.Unmarshal(items, &page)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal zones: %v", err)
+		}
+
+		zones = append(zones, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return zones, nil
+}
+
+// listRecords returns all the records of the zone.
+func (d *DNSProvider) listRecords(zone string) ([]dnsRecord, error) {
+	var records []dnsRecord
+
+	err := d.list("Network/DNS/Record/list", listParams{Zone: zone}, func(items json.RawMessage) error {
+		var page []dnsRecord
+		err := json.Unmarshal(items, &page)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal records: %v", err)
+		}
+
+		records = append(records, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (d *DNSProvider) deleteRecord(id int) error {
+	return withRetry(func() error {
+		var result map[string]interface{}
+		return d.call("Network/DNS/Record/delete", deleteParams{ID: id}, &result)
+	})
+}
+
+// list calls a paginated method and reads all the pages.
+func (d *DNSProvider) list(method string, params listParams, onPage func(items json.RawMessage) error) error {
+	params.PageSize = defaultPageSize
+
+	for page := 1; ; page++ {
+		params.PageNum = page
+
+		var response listResponse
+		err := withRetry(func() error {
+			return d.call(method, params, &response)
+		})
+		if err != nil {
+			return err
+		}
+
+		err = onPage(response.Items)
+		if err != nil {
+			return err
+		}
+
+		if page >= response.PageTotal {
+			return nil
+		}
+	}
+}
+
+func (d *DNSProvider) call(method string, params interface{}, result interface{}) error {
+	body, err := json.Marshal(apiRequest{Params: params})
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(d.config.BaseURL, "/"), method)
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(d.config.Username, d.config.Password)
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return statusError{StatusCode: resp.StatusCode, Body: string(raw)}
+	}
+
+	var apiErr apiError
+	if err = json.Unmarshal(raw, &apiErr); err == nil && apiErr.ErrorClass != "" {
+		return apiErr
+	}
+
+	return json.Unmarshal(raw, result)
+}
+
+// withRetry retries the operation on transient errors: network errors, 429 and 5xx responses.
+func withRetry(operation func() error) error {
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = retryInterval
+	bo.MaxInterval = 10 * bo.InitialInterval
+	bo.MaxElapsedTime = 60 * bo.InitialInterval
+
+	return backoff.Retry(func() error {
+		err := operation()
+		if err != nil && !isTransient(err) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}, bo)
+}
+
+func isTransient(err error) bool {
+	switch e := err.(type) {
+	case statusError:
+		return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+	case *url.Error:
+		return true
+	default:
+		return false
+	}
+}
@@ -4,7 +4,9 @@ package liquidweb
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -46,13 +48,19 @@ func NewDefaultConfig() *Config {
 type DNSProvider struct {
 	config      *Config
 	client      *lw.API
+	httpClient  *http.Client
 	recordIDs   map[string]int
 	recordIDsMu sync.Mutex
+
+	zones   []string // caches the zones of the account
+	zonesMu sync.Mutex
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for Liquid Web.
+// The zone of the domain is found in the zones of the account,
+// LIQUID_WEB_ZONE is used when it can't be found.
 func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get("LIQUID_WEB_USERNAME", "LIQUID_WEB_PASSWORD", "LIQUID_WEB_ZONE")
+	values, err := env.Get("LIQUID_WEB_USERNAME", "LIQUID_WEB_PASSWORD")
 	if err != nil {
 		return nil, fmt.Errorf("liquidweb: %v", err)
 	}
@@ -61,7 +69,7 @@ func NewDNSProvider() (*DNSProvider, error) {
 	config.BaseURL = env.GetOrFile("LIQUID_WEB_URL")
 	config.Username = values["LIQUID_WEB_USERNAME"]
 	config.Password = values["LIQUID_WEB_PASSWORD"]
-	config.Zone = values["LIQUID_WEB_ZONE"]
+	config.Zone = env.GetOrFile("LIQUID_WEB_ZONE")
 
 	return NewDNSProviderConfig(config)
 }
@@ -76,10 +84,6 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		config.BaseURL = defaultBaseURL
 	}
 
-	if config.Zone == "" {
-		return nil, fmt.Errorf("liquidweb: zone is missing")
-	}
-
 	if config.Username == "" {
 		return nil, fmt.Errorf("liquidweb: username is missing")
 	}
@@ -95,9 +99,10 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	}
 
 	return &DNSProvider{
-		config:    config,
-		recordIDs: make(map[string]int),
-		client:    client,
+		config:     config,
+		recordIDs:  make(map[string]int),
+		client:     client,
+		httpClient: &http.Client{Timeout: config.HTTPTimeout},
 	}, nil
 }
 
@@ -111,11 +116,16 @@ func (d *DNSProvider) Timeout() (time.Duration, time.Duration) {
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	fqdn, value := dns01.GetRecord(domain, keyAuth)
 
+	zone, err := d.findZone(dns01.UnFqdn(fqdn))
+	if err != nil {
+		return fmt.Errorf("liquidweb: %v", err)
+	}
+
 	params := &network.DNSRecordParams{
 		Name:  dns01.UnFqdn(fqdn),
 		RData: strconv.Quote(value),
 		Type:  "TXT",
-		Zone:  d.config.Zone,
+		Zone:  zone,
 		TTL:   d.config.TTL,
 	}
 
@@ -138,7 +148,8 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 	d.recordIDsMu.Unlock()
 
 	if !ok {
-		return fmt.Errorf("liquidweb: unknown record ID for '%s'", domain)
+		// the record ID is unknown if the record was created by another process.
+		return d.cleanUpByLookup(domain, keyAuth)
 	}
 
 	params := &network.DNSRecordParams{ID: recordID}
@@ -153,3 +164,93 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 
 	return nil
 }
+
+// cleanUpByLookup removes the TXT records matching the name and the value of the challenge.
+func (d *DNSProvider) cleanUpByLookup(domain, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+	name := dns01.UnFqdn(fqdn)
+
+	zone, err := d.findZone(name)
+	if err != nil {
+		return fmt.Errorf("liquidweb: %v", err)
+	}
+
+	records, err := d.listRecords(zone)
+	if err != nil {
+		return fmt.Errorf("liquidweb: could not list TXT records: %v", err)
+	}
+
+	for _, record := range records {
+		if record.Type != "TXT" || dns01.UnFqdn(record.Name) != name || mustUnquote(record.RData) != value {
+			continue
+		}
+
+		err = d.deleteRecord(record.ID)
+		if err != nil {
+			return fmt.Errorf("liquidweb: could not remove TXT record: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// findZone returns the most specific zone of the account matching the name,
+// or the configured zone.
+func (d *DNSProvider) findZone(name string) (string, error) {
+	zones, err := d.getZones()
+	if err != nil {
+		if d.config.Zone == "" {
+			return "", fmt.Errorf("failed to retrieve the zones of the account: %v", err)
+		}
+
+		return d.config.Zone, nil
+	}
+
+	var zone string
+	for _, z := range zones {
+		if (name == z || strings.HasSuffix(name, "."+z)) && len(z) > len(zone) {
+			zone = z
+		}
+	}
+
+	if zone != "" {
+		return zone, nil
+	}
+
+	if d.config.Zone == "" {
+		return "", fmt.Errorf("no zone found in the account for %s", name)
+	}
+
+	return d.config.Zone, nil
+}
+
+func (d *DNSProvider) getZones() ([]string, error) {
+	d.zonesMu.Lock()
+	defer d.zonesMu.Unlock()
+
+	if d.zones != nil {
+		return d.zones, nil
+	}
+
+	items, err := d.listZones()
+	if err != nil {
+		return nil, err
+	}
+
+	zones := make([]string, 0, len(items))
+	for _, item := range items {
+		zones = append(zones, dns01.UnFqdn(item.Name))
+	}
+
+	d.zones = zones
+
+	return zones, nil
+}
+
+func mustUnquote(raw string) string {
+	clean, err := strconv.Unquote(raw)
+	if err != nil {
+		return raw
+	}
+	return clean
+}
@@ -1,6 +1,7 @@
 package liquidweb
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -56,7 +57,7 @@ func TestNewDNSProvider(t *testing.T) {
 		{
 			desc:     "missing credentials",
 			envVars:  map[string]string{},
-			expected: "liquidweb: some credentials information are missing: LIQUID_WEB_USERNAME,LIQUID_WEB_PASSWORD,LIQUID_WEB_ZONE",
+			expected: "liquidweb: some credentials information are missing: LIQUID_WEB_USERNAME,LIQUID_WEB_PASSWORD",
 		},
 		{
 			desc: "missing username",
@@ -74,11 +75,11 @@ func TestNewDNSProvider(t *testing.T) {
 			}, expected: "liquidweb: some credentials information are missing: LIQUID_WEB_PASSWORD",
 		},
 		{
-			desc: "missing zone",
+			desc: "success without zone",
 			envVars: map[string]string{
 				"LIQUID_WEB_USERNAME": "blars",
 				"LIQUID_WEB_PASSWORD": "tacoman",
-			}, expected: "liquidweb: some credentials information are missing: LIQUID_WEB_ZONE",
+			},
 		},
 	}
 
@@ -123,7 +124,7 @@ func TestNewDNSProviderConfig(t *testing.T) {
 			username: "",
 			password: "",
 			zone:     "",
-			expected: "liquidweb: zone is missing",
+			expected: "liquidweb: username is missing",
 		},
 		{
 			desc:     "missing username",
@@ -140,11 +141,10 @@ func TestNewDNSProviderConfig(t *testing.T) {
 			expected: "liquidweb: password is missing",
 		},
 		{
-			desc:     "missing zone",
+			desc:     "success without zone",
 			username: "acme",
 			password: "secret",
 			zone:     "",
-			expected: "liquidweb: zone is missing",
 		},
 	}
 
@@ -218,6 +218,116 @@ func TestDNSProvider_Present(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestDNSProvider_Present_zoneDetection(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	var zoneCalls int
+	mux.HandleFunc("/v1/Network/DNS/Zone/list", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		var req struct {
+			Params listParams `json:"params"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		zoneCalls++
+
+		switch req.Params.PageNum {
+		case 1:
+			fmt.Fprint(w, `{"item_count":1,"item_total":2,"page_num":1,"page_size":1,"page_total":2,"items":[{"id":1,"name":"tacoman.com"}]}`)
+		case 2:
+			fmt.Fprint(w, `{"item_count":1,"item_total":2,"page_num":2,"page_size":1,"page_total":2,"items":[{"id":2,"name":"sub.tacoman.com"}]}`)
+		default:
+			http.Error(w, fmt.Sprintf("unexpected page: %d", req.Params.PageNum), http.StatusBadRequest)
+		}
+	})
+
+	var zones []string
+	mux.HandleFunc("/v1/Network/DNS/Record/create", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params struct {
+				Zone string `json:"zone"`
+			} `json:"params"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		zones = append(zones, req.Params.Zone)
+
+		fmt.Fprint(w, `{"type":"TXT","name":"_acme-challenge.tacoman.com","rdata":"\"value\"","ttl":300,"id":1234567,"prio":null}`)
+	})
+
+	err := provider.Present("foo.sub.tacoman.com", "", "")
+	require.NoError(t, err)
+
+	err = provider.Present("foo.tacoman.com", "", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"sub.tacoman.com", "tacoman.com"}, zones)
+
+	// the zones are cached.
+	assert.Equal(t, 2, zoneCalls)
+}
+
+func TestDNSProvider_findZone(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		zone        string
+		domain      string
+		expected    string
+		expectedErr string
+	}{
+		{
+			desc:     "zone of the account",
+			zone:     "example.org",
+			domain:   "_acme-challenge.foo.example.com",
+			expected: "example.com",
+		},
+		{
+			desc:     "configured zone",
+			zone:     "example.org",
+			domain:   "_acme-challenge.foo.example.net",
+			expected: "example.org",
+		},
+		{
+			desc:        "no zone",
+			domain:      "_acme-challenge.foo.example.net",
+			expectedErr: "no zone found in the account for _acme-challenge.foo.example.net",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			provider, mux, tearDown := setupTest()
+			defer tearDown()
+
+			provider.config.Zone = test.zone
+
+			mux.HandleFunc("/v1/Network/DNS/Zone/list", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"item_count":1,"item_total":1,"page_num":1,"page_size":100,"page_total":1,"items":[{"id":1,"name":"example.com"}]}`)
+			})
+
+			zone, err := provider.findZone(test.domain)
+
+			if test.expectedErr != "" {
+				require.EqualError(t, err, test.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, zone)
+		})
+	}
+}
+
 func TestDNSProvider_CleanUp(t *testing.T) {
 	provider, mux, tearDown := setupTest()
 	defer tearDown()
@@ -243,6 +353,96 @@ func TestDNSProvider_CleanUp(t *testing.T) {
 	require.NoError(t, err, "fail to remove TXT record")
 }
 
+func TestDNSProvider_CleanUp_lookup(t *testing.T) {
+	defer func(interval time.Duration) { retryInterval = interval }(retryInterval)
+	retryInterval = time.Millisecond
+
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/v1/Network/DNS/Zone/list", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"item_count":1,"item_total":1,"page_num":1,"page_size":100,"page_total":1,"items":[{"id":1,"name":"tacoman.com"}]}`)
+	})
+
+	var failures int
+	mux.HandleFunc("/v1/Network/DNS/Record/list", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params listParams `json:"params"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		assert.Equal(t, "tacoman.com", req.Params.Zone)
+
+		// transient error
+		if failures == 0 {
+			failures++
+			http.Error(w, "service unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		switch req.Params.PageNum {
+		case 1:
+			fmt.Fprint(w, `{"item_count":2,"item_total":4,"page_num":1,"page_size":2,"page_total":2,"items":[
+				{"id":1,"name":"_acme-challenge.tacoman.com","type":"TXT","rdata":"\"47DEQpj8HBSa-_TImW-5JCeuQeRkm5NMpJWZG3hSuFU\"","ttl":300},
+				{"id":2,"name":"_acme-challenge.foo.tacoman.com","type":"TXT","rdata":"\"47DEQpj8HBSa-_TImW-5JCeuQeRkm5NMpJWZG3hSuFU\"","ttl":300}
+			]}`)
+		case 2:
+			fmt.Fprint(w, `{"item_count":2,"item_total":4,"page_num":2,"page_size":2,"page_total":2,"items":[
+				{"id":3,"name":"_acme-challenge.tacoman.com","type":"TXT","rdata":"\"other\"","ttl":300},
+				{"id":4,"name":"_acme-challenge.tacoman.com","type":"TXT","rdata":"47DEQpj8HBSa-_TImW-5JCeuQeRkm5NMpJWZG3hSuFU","ttl":300}
+			]}`)
+		default:
+			http.Error(w, fmt.Sprintf("unexpected page: %d", req.Params.PageNum), http.StatusBadRequest)
+		}
+	})
+
+	var deleted []int
+	mux.HandleFunc("/v1/Network/DNS/Record/delete", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params deleteParams `json:"params"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		deleted = append(deleted, req.Params.ID)
+
+		fmt.Fprintf(w, `{"deleted": "%d"}`, req.Params.ID)
+	})
+
+	err := provider.CleanUp("tacoman.com", "unknown", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{1, 4}, deleted)
+}
+
+func TestDNSProvider_CleanUp_lookupError(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/v1/Network/DNS/Zone/list", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"item_count":1,"item_total":1,"page_num":1,"page_size":100,"page_total":1,"items":[{"id":1,"name":"tacoman.com"}]}`)
+	})
+
+	var calls int
+	mux.HandleFunc("/v1/Network/DNS/Record/list", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"error_class":"LW::Exception::RecordNotFound","full_message":"Record 'zone: tacoman.com' not found"}`)
+	})
+
+	err := provider.CleanUp("tacoman.com", "unknown", "")
+	require.EqualError(t, err, "liquidweb: could not list TXT records: LW::Exception::RecordNotFound: Record 'zone: tacoman.com' not found")
+
+	// the API errors are not retried.
+	assert.Equal(t, 1, calls)
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
@@ -0,0 +1,231 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// DefaultBaseURL the default URL of the Loopia XML-RPC API.
+const DefaultBaseURL = "https://api.loopia.se/RPCSERV"
+
+// returnOK the value returned by the API when the call succeeds.
+const returnOK = "OK"
+
+// RecordObj a zone record.
+type RecordObj struct {
+	Type     string
+	TTL      int
+	Priority int
+	Rdata    string
+	RecordID int
+}
+
+// RPCError an XML-RPC fault.
+type RPCError struct {
+	FaultCode   int
+	FaultString string
+}
+
+func (e RPCError) Error() string {
+	return fmt.Sprintf("RPC Error: (%d) %s", e.FaultCode, e.FaultString)
+}
+
+// Client the Loopia XML-RPC API client.
+type Client struct {
+	apiUser     string
+	apiPassword string
+	BaseURL     string
+	HTTPClient  *http.Client
+}
+
+// NewClient creates a Loopia XML-RPC API client.
+func NewClient(apiUser, apiPassword string) *Client {
+	return &Client{
+		apiUser:     apiUser,
+		apiPassword: apiPassword,
+		BaseURL:     DefaultBaseURL,
+		HTTPClient:  &http.Client{},
+	}
+}
+
+// AddSubdomain creates the subdomain object of a domain.
+// The subdomain must exist before records can be added to it.
+func (c *Client) AddSubdomain(domain, subdomain string) error {
+	call := &methodCall{
+		MethodName: "addSubdomain",
+		Params: []param{
+			paramString{Value: c.apiUser},
+			paramString{Value: c.apiPassword},
+			paramString{Value: domain},
+			paramString{Value: subdomain},
+		},
+	}
+
+	return c.rpcCallOK(call)
+}
+
+// RemoveSubdomain removes the subdomain object of a domain and all its records.
+func (c *Client) RemoveSubdomain(domain, subdomain string) error {
+	call := &methodCall{
+		MethodName: "removeSubdomain",
+		Params: []param{
+			paramString{Value: c.apiUser},
+			paramString{Value: c.apiPassword},
+			paramString{Value: domain},
+			paramString{Value: subdomain},
+		},
+	}
+
+	return c.rpcCallOK(call)
+}
+
+// AddTXTRecord adds a TXT record to a subdomain.
+func (c *Client) AddTXTRecord(domain, subdomain string, ttl int, value string) error {
+	call := &methodCall{
+		MethodName: "addZoneRecord",
+		Params: []param{
+			paramString{Value: c.apiUser},
+			paramString{Value: c.apiPassword},
+			paramString{Value: domain},
+			paramString{Value: subdomain},
+			paramStruct{
+				StructMembers: []structMember{
+					structMemberString{Name: "type", Value: "TXT"},
+					structMemberInt{Name: "ttl", Value: ttl},
+					structMemberInt{Name: "priority", Value: 0},
+					structMemberString{Name: "rdata", Value: value},
+					structMemberInt{Name: "record_id", Value: 0},
+				},
+			},
+		},
+	}
+
+	return c.rpcCallOK(call)
+}
+
+// GetZoneRecords returns the records of a subdomain.
+func (c *Client) GetZoneRecords(domain, subdomain string) ([]RecordObj, error) {
+	call := &methodCall{
+		MethodName: "getZoneRecords",
+		Params: []param{
+			paramString{Value: c.apiUser},
+			paramString{Value: c.apiPassword},
+			paramString{Value: domain},
+			paramString{Value: subdomain},
+		},
+	}
+
+	resp := &responseRecords{}
+	err := c.rpcCall(call, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []RecordObj
+	for _, object := range resp.Values {
+		if len(object.Members) == 0 {
+			// the errors are returned as a string inside the array.
+			return nil, fmt.Errorf("unexpected %s response: %s", call.MethodName, strings.TrimSpace(object.string()))
+		}
+
+		record := RecordObj{}
+		for _, member := range object.Members {
+			switch member.Name {
+			case "type":
+				record.Type = member.Value.string()
+			case "ttl":
+				record.TTL = member.Value.Int
+			case "priority":
+				record.Priority = member.Value.Int
+			case "rdata":
+				record.Rdata = member.Value.string()
+			case "record_id":
+				record.RecordID = member.Value.Int
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// RemoveZoneRecord removes a record of a subdomain.
+func (c *Client) RemoveZoneRecord(domain, subdomain string, recordID int) error {
+	call := &methodCall{
+		MethodName: "removeZoneRecord",
+		Params: []param{
+			paramString{Value: c.apiUser},
+			paramString{Value: c.apiPassword},
+			paramString{Value: domain},
+			paramString{Value: subdomain},
+			paramInt{Value: recordID},
+		},
+	}
+
+	return c.rpcCallOK(call)
+}
+
+// rpcCallOK makes an XML-RPC call returning a status string.
+func (c *Client) rpcCallOK(call *methodCall) error {
+	resp := &responseString{}
+	err := c.rpcCall(call, resp)
+	if err != nil {
+		return err
+	}
+
+	status := strings.TrimSpace(resp.Value.string())
+	if status != returnOK {
+		return fmt.Errorf("unexpected %s response: %s", call.MethodName, status)
+	}
+
+	return nil
+}
+
+// rpcCall makes an XML-RPC call to the Loopia endpoint by marshaling the call to XML and sending it via HTTP POST.
+// The response is then unmarshalled into the resp argument.
+func (c *Client) rpcCall(call *methodCall, resp response) error {
+	body, err := xml.MarshalIndent(call, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal error: %v", err)
+	}
+
+	body = append([]byte(xml.Header), body...)
+
+	req, err := http.NewRequest(http.MethodPost, c.BaseURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "text/xml")
+
+	httpResp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return err
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%d: %s", httpResp.StatusCode, string(raw))
+	}
+
+	err = xml.Unmarshal(raw, resp)
+	if err != nil {
+		return fmt.Errorf("unmarshal error: %v", err)
+	}
+
+	if resp.faultCode() != 0 {
+		return RPCError{FaultCode: resp.faultCode(), FaultString: resp.faultString()}
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package internal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, method string, params []string, response string) (*Client, func()) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		content := string(body)
+		if !strings.Contains(content, "<methodName>"+method+"</methodName>") {
+			http.Error(rw, fmt.Sprintf("unexpected method: %s", content), http.StatusBadRequest)
+			return
+		}
+
+		for _, p := range params {
+			if !strings.Contains(content, p) {
+				http.Error(rw, fmt.Sprintf("missing parameter %s: %s", p, content), http.StatusBadRequest)
+				return
+			}
+		}
+
+		fmt.Fprint(rw, response)
+	}))
+
+	client := NewClient("user@loopiaapi", "secret")
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+const responseOK = `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse><params><param><value><string>OK</string></value></param></params></methodResponse>`
+
+func TestClient_AddSubdomain(t *testing.T) {
+	client, tearDown := setupTest(t, "addSubdomain", []string{"<string>user@loopiaapi</string>", "<string>example.com</string>", "<string>_acme-challenge</string>"}, responseOK)
+	defer tearDown()
+
+	err := client.AddSubdomain("example.com", "_acme-challenge")
+	require.NoError(t, err)
+}
+
+func TestClient_AddTXTRecord(t *testing.T) {
+	params := []string{"<name>type</name>", "<string>TXT</string>", "<int>300</int>", "<string>value</string>"}
+
+	client, tearDown := setupTest(t, "addZoneRecord", params, responseOK)
+	defer tearDown()
+
+	err := client.AddTXTRecord("example.com", "_acme-challenge", 300, "value")
+	require.NoError(t, err)
+}
+
+func TestClient_AddTXTRecord_error(t *testing.T) {
+	response := `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse><params><param><value><string>AUTH_ERROR</string></value></param></params></methodResponse>`
+
+	client, tearDown := setupTest(t, "addZoneRecord", nil, response)
+	defer tearDown()
+
+	err := client.AddTXTRecord("example.com", "_acme-challenge", 300, "value")
+	require.EqualError(t, err, "unexpected addZoneRecord response: AUTH_ERROR")
+}
+
+func TestClient_GetZoneRecords(t *testing.T) {
+	response := `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse>
+  <params>
+    <param>
+      <value>
+        <array>
+          <data>
+            <value>
+              <struct>
+                <member><name>type</name><value><string>TXT</string></value></member>
+                <member><name>ttl</name><value><int>300</int></value></member>
+                <member><name>priority</name><value><int>0</int></value></member>
+                <member><name>rdata</name><value><string>value</string></value></member>
+                <member><name>record_id</name><value><int>12345678</int></value></member>
+              </struct>
+            </value>
+          </data>
+        </array>
+      </value>
+    </param>
+  </params>
+</methodResponse>`
+
+	client, tearDown := setupTest(t, "getZoneRecords", []string{"<string>_acme-challenge</string>"}, response)
+	defer tearDown()
+
+	records, err := client.GetZoneRecords("example.com", "_acme-challenge")
+	require.NoError(t, err)
+
+	expected := []RecordObj{{Type: "TXT", TTL: 300, Rdata: "value", RecordID: 12345678}}
+	assert.Equal(t, expected, records)
+}
+
+func TestClient_GetZoneRecords_error(t *testing.T) {
+	response := `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse><params><param><value><array><data><value><string>AUTH_ERROR</string></value></data></array></value></param></params></methodResponse>`
+
+	client, tearDown := setupTest(t, "getZoneRecords", nil, response)
+	defer tearDown()
+
+	_, err := client.GetZoneRecords("example.com", "_acme-challenge")
+	require.EqualError(t, err, "unexpected getZoneRecords response: AUTH_ERROR")
+}
+
+func TestClient_RemoveZoneRecord(t *testing.T) {
+	client, tearDown := setupTest(t, "removeZoneRecord", []string{"<int>12345678</int>"}, responseOK)
+	defer tearDown()
+
+	err := client.RemoveZoneRecord("example.com", "_acme-challenge", 12345678)
+	require.NoError(t, err)
+}
+
+func TestClient_RemoveSubdomain(t *testing.T) {
+	client, tearDown := setupTest(t, "removeSubdomain", []string{"<string>_acme-challenge</string>"}, responseOK)
+	defer tearDown()
+
+	err := client.RemoveSubdomain("example.com", "_acme-challenge")
+	require.NoError(t, err)
+}
+
+func TestClient_fault(t *testing.T) {
+	response := `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse><fault><value><struct>
+<member><name>faultCode</name><value><int>623</int></value></member>
+<member><name>faultString</name><value><string>Method not allowed</string></value></member>
+</struct></value></fault></methodResponse>`
+
+	client, tearDown := setupTest(t, "removeSubdomain", nil, response)
+	defer tearDown()
+
+	err := client.RemoveSubdomain("example.com", "_acme-challenge")
+	require.EqualError(t, err, "RPC Error: (623) Method not allowed")
+}
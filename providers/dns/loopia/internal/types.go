@@ -0,0 +1,100 @@
+package internal
+
+import "encoding/xml"
+
+// types for XML-RPC method calls and parameters
+
+type param interface {
+	param()
+}
+
+type paramString struct {
+	XMLName xml.Name `xml:"param"`
+	Value   string   `xml:"value>string"`
+}
+
+type paramInt struct {
+	XMLName xml.Name `xml:"param"`
+	Value   int      `xml:"value>int"`
+}
+
+type paramStruct struct {
+	XMLName       xml.Name       `xml:"param"`
+	StructMembers []structMember `xml:"value>struct>member"`
+}
+
+type structMember interface {
+	structMember()
+}
+
+type structMemberString struct {
+	Name  string `xml:"name"`
+	Value string `xml:"value>string"`
+}
+
+type structMemberInt struct {
+	Name  string `xml:"name"`
+	Value int    `xml:"value>int"`
+}
+
+func (p paramString) param()               {}
+func (p paramInt) param()                  {}
+func (p paramStruct) param()               {}
+func (m structMemberString) structMember() {}
+func (m structMemberInt) structMember()    {}
+
+type methodCall struct {
+	XMLName    xml.Name `xml:"methodCall"`
+	MethodName string   `xml:"methodName"`
+	Params     []param  `xml:"params"`
+}
+
+// types for XML-RPC responses
+
+type response interface {
+	faultCode() int
+	faultString() string
+}
+
+type responseFault struct {
+	FaultCode   int    `xml:"fault>value>struct>member>value>int"`
+	FaultString string `xml:"fault>value>struct>member>value>string"`
+}
+
+func (r responseFault) faultCode() int      { return r.FaultCode }
+func (r responseFault) faultString() string { return r.FaultString }
+
+// value an XML-RPC value: a value without type is a string.
+type value struct {
+	Text   string `xml:",chardata"`
+	String string `xml:"string"`
+	Int    int    `xml:"int"`
+}
+
+func (v value) string() string {
+	if v.String != "" {
+		return v.String
+	}
+	return v.Text
+}
+
+type responseString struct {
+	responseFault
+	Value value `xml:"params>param>value"`
+}
+
+type recordMember struct {
+	Name  string `xml:"name"`
+	Value value  `xml:"value"`
+}
+
+// recordObject a value of the records array: a struct, or a string when an error occurs.
+type recordObject struct {
+	value
+	Members []recordMember `xml:"struct>member"`
+}
+
+type responseRecords struct {
+	responseFault
+	Values []recordObject `xml:"params>param>value>array>data>value"`
+}
@@ -0,0 +1,179 @@
+// Package loopia implements a DNS provider for solving the DNS-01 challenge using Loopia DNS.
+package loopia
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/loopia/internal"
+)
+
+// minTTL the minimal TTL allowed by Loopia.
+const minTTL = 300
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL            string
+	APIUser            string
+	APIPassword        string
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString("LOOPIA_API_URL", internal.DefaultBaseURL),
+		TTL:                env.GetOrDefaultInt("LOOPIA_TTL", minTTL),
+		PropagationTimeout: env.GetOrDefaultSecond("LOOPIA_PROPAGATION_TIMEOUT", 40*time.Minute),
+		PollingInterval:    env.GetOrDefaultSecond("LOOPIA_POLLING_INTERVAL", 60*time.Second),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("LOOPIA_HTTP_TIMEOUT", 60*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses the Loopia XML-RPC API to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Loopia.
+// Credentials must be passed in the environment variables:
+// LOOPIA_API_USER and LOOPIA_API_PASSWORD.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("LOOPIA_API_USER", "LOOPIA_API_PASSWORD")
+	if err != nil {
+		return nil, fmt.Errorf("loopia: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIUser = values["LOOPIA_API_USER"]
+	config.APIPassword = values["LOOPIA_API_PASSWORD"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Loopia.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("loopia: the configuration of the DNS provider is nil")
+	}
+
+	if config.APIUser == "" || config.APIPassword == "" {
+		return nil, errors.New("loopia: credentials missing")
+	}
+
+	if config.TTL < minTTL {
+		return nil, fmt.Errorf("loopia: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	}
+
+	client := internal.NewClient(config.APIUser, config.APIPassword)
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, subdomain, err := splitDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("loopia: %v", err)
+	}
+
+	// the records can only be added to an existing subdomain.
+	err = d.client.AddSubdomain(zone, subdomain)
+	if err != nil {
+		return fmt.Errorf("loopia: %v", err)
+	}
+
+	err = d.client.AddTXTRecord(zone, subdomain, d.config.TTL, value)
+	if err != nil {
+		return fmt.Errorf("loopia: %v", err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+// The subdomain is removed when it no longer contains records.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, subdomain, err := splitDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("loopia: %v", err)
+	}
+
+	records, err := d.client.GetZoneRecords(zone, subdomain)
+	if err != nil {
+		return fmt.Errorf("loopia: %v", err)
+	}
+
+	remaining := len(records)
+	for _, record := range records {
+		if record.Type != "TXT" || record.Rdata != value {
+			continue
+		}
+
+		err = d.client.RemoveZoneRecord(zone, subdomain, record.RecordID)
+		if err != nil {
+			return fmt.Errorf("loopia: %v", err)
+		}
+
+		remaining--
+	}
+
+	if remaining > 0 {
+		return nil
+	}
+
+	err = d.client.RemoveSubdomain(zone, subdomain)
+	if err != nil {
+		return fmt.Errorf("loopia: %v", err)
+	}
+
+	return nil
+}
+
+// splitDomain returns the zone and the subdomain of the FQDN.
+func splitDomain(fqdn string) (string, string, error) {
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return "", "", fmt.Errorf("could not find zone for FQDN %q: %v", fqdn, err)
+	}
+
+	return dns01.UnFqdn(authZone), extractRecordName(fqdn, authZone), nil
+}
+
+func extractRecordName(fqdn, authZone string) string {
+	name := dns01.UnFqdn(fqdn)
+	if idx := strings.LastIndex(name, "."+dns01.UnFqdn(authZone)); idx != -1 {
+		return name[:idx]
+	}
+	return name
+}
@@ -0,0 +1,168 @@
+package loopia
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest(
+	"LOOPIA_API_USER",
+	"LOOPIA_API_PASSWORD").
+	WithDomain("LOOPIA_DOMAIN")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"LOOPIA_API_USER":     "123",
+				"LOOPIA_API_PASSWORD": "456",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				"LOOPIA_API_USER":     "",
+				"LOOPIA_API_PASSWORD": "",
+			},
+			expected: "loopia: some credentials information are missing: LOOPIA_API_USER,LOOPIA_API_PASSWORD",
+		},
+		{
+			desc: "missing user",
+			envVars: map[string]string{
+				"LOOPIA_API_USER":     "",
+				"LOOPIA_API_PASSWORD": "456",
+			},
+			expected: "loopia: some credentials information are missing: LOOPIA_API_USER",
+		},
+		{
+			desc: "missing password",
+			envVars: map[string]string{
+				"LOOPIA_API_USER":     "123",
+				"LOOPIA_API_PASSWORD": "",
+			},
+			expected: "loopia: some credentials information are missing: LOOPIA_API_PASSWORD",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		apiUser     string
+		apiPassword string
+		ttl         int
+		expected    string
+	}{
+		{
+			desc:        "success",
+			apiUser:     "123",
+			apiPassword: "456",
+			ttl:         minTTL,
+		},
+		{
+			desc:        "invalid TTL",
+			apiUser:     "123",
+			apiPassword: "456",
+			ttl:         100,
+			expected:    "loopia: invalid TTL, TTL (100) must be greater than 300",
+		},
+		{
+			desc:     "missing credentials",
+			expected: "loopia: credentials missing",
+		},
+		{
+			desc:        "missing user",
+			apiPassword: "456",
+			expected:    "loopia: credentials missing",
+		},
+		{
+			desc:     "missing password",
+			apiUser:  "123",
+			expected: "loopia: credentials missing",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.APIUser = test.apiUser
+			config.APIPassword = test.apiPassword
+			if test.ttl != 0 {
+				config.TTL = test.ttl
+			}
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func Test_extractRecordName(t *testing.T) {
+	assert.Equal(t, "_acme-challenge", extractRecordName("_acme-challenge.example.com.", "example.com."))
+	assert.Equal(t, "_acme-challenge.sub", extractRecordName("_acme-challenge.sub.example.com.", "example.com."))
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
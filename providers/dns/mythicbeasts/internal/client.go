@@ -0,0 +1,157 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultBaseURL the default base URL of the Mythic Beasts DNS API v2.
+const DefaultBaseURL = "https://api.mythic-beasts.com/dns/v2"
+
+// DefaultAuthURL the default URL of the token endpoint (OAuth2 client credentials).
+const DefaultAuthURL = "https://auth.mythic-beasts.com/login"
+
+// Record a DNS record.
+type Record struct {
+	Host string `json:"host"`
+	TTL  int    `json:"ttl"`
+	Type string `json:"type"`
+	Data string `json:"data"`
+}
+
+type recordsRequest struct {
+	Records []Record `json:"records"`
+}
+
+type recordsAdded struct {
+	RecordsAdded int `json:"records_added"`
+}
+
+type recordsRemoved struct {
+	RecordsRemoved int `json:"records_removed"`
+}
+
+type apiError struct {
+	Error  string   `json:"error"`
+	Errors []string `json:"errors"`
+}
+
+// Client the Mythic Beasts DNS API v2 client.
+// The HTTP client must handle the authentication (i.e. an OAuth2 client using the client credentials flow).
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a Mythic Beasts DNS API v2 client.
+func NewClient(httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+
+	return &Client{
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: httpClient,
+	}
+}
+
+// AddTXTRecord adds a TXT record to the host, the existing records are kept.
+func (c *Client) AddTXTRecord(zone, host, value string, ttl int) error {
+	request := recordsRequest{
+		Records: []Record{{Host: host, TTL: ttl, Type: "TXT", Data: value}},
+	}
+
+	result := &recordsAdded{}
+	err := c.do(http.MethodPost, recordsURI(zone, host, nil), request, result)
+	if err != nil {
+		return fmt.Errorf("unable to add the TXT record %s to %s: %v", host, zone, err)
+	}
+
+	if result.RecordsAdded != 1 {
+		return fmt.Errorf("unable to add the TXT record %s to %s: %d records added", host, zone, result.RecordsAdded)
+	}
+
+	return nil
+}
+
+// DeleteTXTRecord deletes the TXT record of the host matching the value.
+func (c *Client) DeleteTXTRecord(zone, host, value string) error {
+	query := url.Values{}
+	query.Set("data", value)
+
+	result := &recordsRemoved{}
+	err := c.do(http.MethodDelete, recordsURI(zone, host, query), nil, result)
+	if err != nil {
+		return fmt.Errorf("unable to delete the TXT record %s of %s: %v", host, zone, err)
+	}
+
+	if result.RecordsRemoved != 1 {
+		return fmt.Errorf("unable to delete the TXT record %s of %s: %d records removed", host, zone, result.RecordsRemoved)
+	}
+
+	return nil
+}
+
+func recordsURI(zone, host string, query url.Values) string {
+	uri := fmt.Sprintf("/zones/%s/records/%s/TXT", url.PathEscape(zone), url.PathEscape(host))
+	if len(query) > 0 {
+		uri += "?" + query.Encode()
+	}
+	return uri
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body bytes.Buffer
+	if payload != nil {
+		err := json.NewEncoder(&body).Encode(payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+uri, &body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &apiError{}
+		if json.Unmarshal(raw, apiErr) == nil {
+			if apiErr.Error != "" {
+				return fmt.Errorf("%d: %s", resp.StatusCode, apiErr.Error)
+			}
+			if len(apiErr.Errors) > 0 {
+				return fmt.Errorf("%d: %s", resp.StatusCode, strings.Join(apiErr.Errors, ", "))
+			}
+		}
+
+		return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
@@ -0,0 +1,88 @@
+package internal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest() (*Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client := NewClient(nil)
+	client.BaseURL = server.URL
+
+	return client, mux, server.Close
+}
+
+func TestClient_AddTXTRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/zones/example.com/records/_acme-challenge/TXT", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := `{"records":[{"host":"_acme-challenge","ttl":120,"type":"TXT","data":"value"}]}` + "\n"
+		if string(body) != expected {
+			http.Error(rw, fmt.Sprintf("unexpected body: %s", string(body)), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `{"records_added":1}`)
+	})
+
+	err := client.AddTXTRecord("example.com", "_acme-challenge", "value", 120)
+	require.NoError(t, err)
+}
+
+func TestClient_AddTXTRecord_error(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/zones/example.com/records/_acme-challenge/TXT", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(rw, `{"error":"Access denied to zone example.com"}`)
+	})
+
+	err := client.AddTXTRecord("example.com", "_acme-challenge", "value", 120)
+	require.EqualError(t, err, "unable to add the TXT record _acme-challenge to example.com: 403: Access denied to zone example.com")
+}
+
+func TestClient_DeleteTXTRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/zones/example.com/records/_acme-challenge/TXT", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.URL.Query().Get("data") != "value" {
+			fmt.Fprint(rw, `{"records_removed":0}`)
+			return
+		}
+
+		fmt.Fprint(rw, `{"records_removed":1}`)
+	})
+
+	err := client.DeleteTXTRecord("example.com", "_acme-challenge", "value")
+	require.NoError(t, err)
+
+	err = client.DeleteTXTRecord("example.com", "_acme-challenge", "other")
+	require.EqualError(t, err, "unable to delete the TXT record _acme-challenge of example.com: 0 records removed")
+}
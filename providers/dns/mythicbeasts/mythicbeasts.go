@@ -0,0 +1,156 @@
+// Package mythicbeasts implements a DNS provider for solving the DNS-01 challenge using Mythic Beasts DNS.
+package mythicbeasts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/mythicbeasts/internal"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL            string
+	AuthURL            string
+	UserName           string
+	Password           string
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString("MYTHICBEASTS_API_ENDPOINT", internal.DefaultBaseURL),
+		AuthURL:            env.GetOrDefaultString("MYTHICBEASTS_AUTH_API_ENDPOINT", internal.DefaultAuthURL),
+		TTL:                env.GetOrDefaultInt("MYTHICBEASTS_TTL", dns01.DefaultTTL),
+		PropagationTimeout: env.GetOrDefaultSecond("MYTHICBEASTS_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("MYTHICBEASTS_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("MYTHICBEASTS_HTTP_TIMEOUT", 10*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses the Mythic Beasts DNS API v2 to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Mythic Beasts.
+// Credentials must be passed in the environment variables:
+// MYTHICBEASTS_USERNAME and MYTHICBEASTS_PASSWORD (API key ID and secret).
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("MYTHICBEASTS_USERNAME", "MYTHICBEASTS_PASSWORD")
+	if err != nil {
+		return nil, fmt.Errorf("mythicbeasts: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.UserName = values["MYTHICBEASTS_USERNAME"]
+	config.Password = values["MYTHICBEASTS_PASSWORD"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Mythic Beasts.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("mythicbeasts: the configuration of the DNS provider is nil")
+	}
+
+	if config.UserName == "" || config.Password == "" {
+		return nil, errors.New("mythicbeasts: credentials missing")
+	}
+
+	authURL := config.AuthURL
+	if authURL == "" {
+		authURL = internal.DefaultAuthURL
+	}
+
+	client := internal.NewClient(getOauthClient(config, authURL))
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// getOauthClient creates an HTTP client using the OAuth2 client credentials flow:
+// the token is requested with the API key ID and secret, then refreshed when it expires.
+func getOauthClient(config *Config, authURL string) *http.Client {
+	oauthConfig := &clientcredentials.Config{
+		TokenURL:     authURL,
+		ClientID:     config.UserName,
+		ClientSecret: config.Password,
+		AuthStyle:    oauth2.AuthStyleInHeader,
+	}
+
+	ctx := context.Background()
+	if config.HTTPClient != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, config.HTTPClient)
+	}
+
+	return oauthConfig.Client(ctx)
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return fmt.Errorf("mythicbeasts: could not find zone for domain %q: %v", domain, err)
+	}
+
+	err = d.client.AddTXTRecord(dns01.UnFqdn(authZone), extractRecordName(fqdn, authZone), value, d.config.TTL)
+	if err != nil {
+		return fmt.Errorf("mythicbeasts: %v", err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return fmt.Errorf("mythicbeasts: could not find zone for domain %q: %v", domain, err)
+	}
+
+	err = d.client.DeleteTXTRecord(dns01.UnFqdn(authZone), extractRecordName(fqdn, authZone), value)
+	if err != nil {
+		return fmt.Errorf("mythicbeasts: %v", err)
+	}
+
+	return nil
+}
+
+func extractRecordName(fqdn, authZone string) string {
+	name := dns01.UnFqdn(fqdn)
+	if idx := strings.LastIndex(name, "."+dns01.UnFqdn(authZone)); idx != -1 {
+		return name[:idx]
+	}
+	return name
+}
@@ -0,0 +1,197 @@
+package mythicbeasts
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest(
+	"MYTHICBEASTS_USERNAME",
+	"MYTHICBEASTS_PASSWORD").
+	WithDomain("MYTHICBEASTS_DOMAIN")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"MYTHICBEASTS_USERNAME": "123",
+				"MYTHICBEASTS_PASSWORD": "456",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				"MYTHICBEASTS_USERNAME": "",
+				"MYTHICBEASTS_PASSWORD": "",
+			},
+			expected: "mythicbeasts: some credentials information are missing: MYTHICBEASTS_USERNAME,MYTHICBEASTS_PASSWORD",
+		},
+		{
+			desc: "missing user",
+			envVars: map[string]string{
+				"MYTHICBEASTS_USERNAME": "",
+				"MYTHICBEASTS_PASSWORD": "456",
+			},
+			expected: "mythicbeasts: some credentials information are missing: MYTHICBEASTS_USERNAME",
+		},
+		{
+			desc: "missing password",
+			envVars: map[string]string{
+				"MYTHICBEASTS_USERNAME": "123",
+				"MYTHICBEASTS_PASSWORD": "",
+			},
+			expected: "mythicbeasts: some credentials information are missing: MYTHICBEASTS_PASSWORD",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		userName string
+		password string
+		expected string
+	}{
+		{
+			desc:     "success",
+			userName: "123",
+			password: "456",
+		},
+		{
+			desc:     "missing credentials",
+			expected: "mythicbeasts: credentials missing",
+		},
+		{
+			desc:     "missing user",
+			password: "456",
+			expected: "mythicbeasts: credentials missing",
+		},
+		{
+			desc:     "missing password",
+			userName: "123",
+			expected: "mythicbeasts: credentials missing",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.UserName = test.userName
+			config.Password = test.password
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func Test_extractRecordName(t *testing.T) {
+	assert.Equal(t, "_acme-challenge", extractRecordName("_acme-challenge.example.com.", "example.com."))
+	assert.Equal(t, "_acme-challenge.sub", extractRecordName("_acme-challenge.sub.example.com.", "example.com."))
+}
+
+func TestDNSProvider_oauth(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/login", func(rw http.ResponseWriter, req *http.Request) {
+		user, password, ok := req.BasicAuth()
+		if !ok || user != "user" || password != "secret" || req.FormValue("grant_type") != "client_credentials" {
+			http.Error(rw, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+			return
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(rw, `{"access_token":"token","token_type":"bearer","expires_in":300}`)
+	})
+
+	mux.HandleFunc("/zones/example.com/records/_acme-challenge/TXT", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer token" {
+			http.Error(rw, `{"error":"Unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+
+		fmt.Fprint(rw, `{"records_added":1}`)
+	})
+
+	config := NewDefaultConfig()
+	config.UserName = "user"
+	config.Password = "secret"
+	config.BaseURL = server.URL
+	config.AuthURL = server.URL + "/login"
+
+	p, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	err = p.client.AddTXTRecord("example.com", "_acme-challenge", "value", 120)
+	require.NoError(t, err)
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
@@ -39,7 +39,15 @@ type getHostsResponse struct {
 	XMLName xml.Name   `xml:"ApiResponse"`
 	Status  string     `xml:"Status,attr"`
 	Errors  []apiError `xml:"Errors>Error"`
-	Hosts   []Record   `xml:"CommandResponse>DomainDNSGetHostsResult>host"`
+	Result  dnsHosts   `xml:"CommandResponse>DomainDNSGetHostsResult"`
+}
+
+// dnsHosts describes the DNS settings of a domain returned by the Namecheap DNS gethosts API.
+type dnsHosts struct {
+	// EmailType is the mail settings of the domain, it is reset by setHosts if it is not sent.
+	EmailType     string   `xml:",attr"`
+	IsUsingOurDNS bool     `xml:",attr"`
+	Hosts         []Record `xml:"host"`
 }
 
 type getTldsResponse struct {
@@ -77,7 +85,7 @@ func (d *DNSProvider) getTLDs() (map[string]string, error) {
 
 // getHosts reads the full list of DNS host records.
 // https://www.namecheap.com/support/api/methods/domains-dns/get-hosts.aspx
-func (d *DNSProvider) getHosts(sld, tld string) ([]Record, error) {
+func (d *DNSProvider) getHosts(sld, tld string) (*dnsHosts, error) {
 	request, err := d.newRequestGet("namecheap.domains.dns.getHosts",
 		addParam("SLD", sld),
 		addParam("TLD", tld),
@@ -96,17 +104,21 @@ func (d *DNSProvider) getHosts(sld, tld string) ([]Record, error) {
 		return nil, fmt.Errorf("%s [%d]", ghr.Errors[0].Description, ghr.Errors[0].Number)
 	}
 
-	return ghr.Hosts, nil
+	return &ghr.Result, nil
 }
 
-// setHosts writes the full list of DNS host records .
+// setHosts writes the full list of DNS host records, the mail settings of the domain are kept.
 // https://www.namecheap.com/support/api/methods/domains-dns/set-hosts.aspx
-func (d *DNSProvider) setHosts(sld, tld string, hosts []Record) error {
+func (d *DNSProvider) setHosts(sld, tld string, hosts *dnsHosts) error {
 	req, err := d.newRequestPost("namecheap.domains.dns.setHosts",
 		addParam("SLD", sld),
 		addParam("TLD", tld),
 		func(values url.Values) {
-			for i, h := range hosts {
+			if hosts.EmailType != "" {
+				values.Set("EmailType", hosts.EmailType)
+			}
+
+			for i, h := range hosts.Hosts {
 				ind := fmt.Sprintf("%d", i+1)
 				values.Add("HostName"+ind, h.Name)
 				values.Add("RecordType"+ind, h.Type)
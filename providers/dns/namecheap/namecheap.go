@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v3/challenge/dns01"
@@ -30,6 +32,7 @@ import (
 
 const (
 	defaultBaseURL = "https://api.namecheap.com/xml.response"
+	sandboxBaseURL = "https://api.sandbox.namecheap.com/xml.response"
 	getIPURL       = "https://dynamicdns.park-your-domain.com/getip"
 )
 
@@ -60,8 +63,13 @@ type Config struct {
 
 // NewDefaultConfig returns a default configuration for the DNSProvider
 func NewDefaultConfig() *Config {
+	baseURL := defaultBaseURL
+	if env.GetOrDefaultBool("NAMECHEAP_SANDBOX", false) {
+		baseURL = sandboxBaseURL
+	}
+
 	return &Config{
-		BaseURL:            defaultBaseURL,
+		BaseURL:            baseURL,
 		Debug:              env.GetOrDefaultBool("NAMECHEAP_DEBUG", false),
 		TTL:                env.GetOrDefaultInt("NAMECHEAP_TTL", dns01.DefaultTTL),
 		PropagationTimeout: env.GetOrDefaultSecond("NAMECHEAP_PROPAGATION_TIMEOUT", 60*time.Minute),
@@ -76,6 +84,8 @@ func NewDefaultConfig() *Config {
 // that uses Namecheap's tool API to manage TXT records for a domain.
 type DNSProvider struct {
 	config *Config
+	// the host records of a domain are read, modified and written back: the updates are serialized.
+	hostsMu sync.Mutex
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for namecheap.
@@ -133,11 +143,6 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 		return fmt.Errorf("namecheap: %v", err)
 	}
 
-	records, err := d.getHosts(ch.sld, ch.tld)
-	if err != nil {
-		return fmt.Errorf("namecheap: %v", err)
-	}
-
 	record := Record{
 		Name:    ch.key,
 		Type:    "TXT",
@@ -146,15 +151,15 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 		TTL:     strconv.Itoa(d.config.TTL),
 	}
 
-	records = append(records, record)
-
-	if d.config.Debug {
+	err = d.updateHosts(ch, func(records []Record) ([]Record, bool) {
 		for _, h := range records {
-			log.Printf("%-5.5s %-30.30s %-6s %-70.70s", h.Type, h.Name, h.TTL, h.Address)
+			if isChallengeRecord(h, ch) {
+				return records, false
+			}
 		}
-	}
 
-	err = d.setHosts(ch.sld, ch.tld, records)
+		return append(records, record), true
+	})
 	if err != nil {
 		return fmt.Errorf("namecheap: %v", err)
 	}
@@ -173,33 +178,105 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 		return fmt.Errorf("namecheap: %v", err)
 	}
 
-	records, err := d.getHosts(ch.sld, ch.tld)
+	err = d.updateHosts(ch, func(records []Record) ([]Record, bool) {
+		// Find the challenge TXT record and remove it if found.
+		var found bool
+		var newRecords []Record
+		for _, h := range records {
+			if isChallengeRecord(h, ch) {
+				found = true
+			} else {
+				newRecords = append(newRecords, h)
+			}
+		}
+
+		return newRecords, found
+	})
 	if err != nil {
 		return fmt.Errorf("namecheap: %v", err)
 	}
+	return nil
+}
 
-	// Find the challenge TXT record and remove it if found.
-	var found bool
-	var newRecords []Record
-	for _, h := range records {
-		if h.Name == ch.key && h.Type == "TXT" {
-			found = true
-		} else {
-			newRecords = append(newRecords, h)
-		}
+// updateHosts reads the host records of the domain of a challenge, modifies them, writes them back,
+// and verifies that the host records of the domain are the expected ones.
+// Namecheap has no API to modify a single record: all the host records are replaced by setHosts,
+// the modify function returns false when no change is needed.
+func (d *DNSProvider) updateHosts(ch *challenge, modify func(records []Record) ([]Record, bool)) error {
+	d.hostsMu.Lock()
+	defer d.hostsMu.Unlock()
+
+	hosts, err := d.getHosts(ch.sld, ch.tld)
+	if err != nil {
+		return err
 	}
 
-	if !found {
+	if !hosts.IsUsingOurDNS {
+		return fmt.Errorf("the domain %s.%s doesn't use the Namecheap DNS servers", ch.sld, ch.tld)
+	}
+
+	records, changed := modify(hosts.Hosts)
+	if !changed {
 		return nil
 	}
 
-	err = d.setHosts(ch.sld, ch.tld, newRecords)
+	if d.config.Debug {
+		for _, h := range records {
+			log.Printf("%-5.5s %-30.30s %-6s %-70.70s", h.Type, h.Name, h.TTL, h.Address)
+		}
+	}
+
+	err = d.setHosts(ch.sld, ch.tld, &dnsHosts{EmailType: hosts.EmailType, Hosts: records})
 	if err != nil {
-		return fmt.Errorf("namecheap: %v", err)
+		return err
+	}
+
+	updated, err := d.getHosts(ch.sld, ch.tld)
+	if err != nil {
+		return fmt.Errorf("failed to verify the host records: %v", err)
+	}
+
+	return verifyHosts(records, updated.Hosts)
+}
+
+// verifyHosts checks that the host records read after a setHosts are the records that were sent.
+func verifyHosts(expected, actual []Record) error {
+	counts := make(map[string]int)
+	for _, h := range expected {
+		counts[recordKey(h)]++
 	}
+
+	for _, h := range actual {
+		counts[recordKey(h)]--
+	}
+
+	var diff []string
+	for key, count := range counts {
+		switch {
+		case count > 0:
+			diff = append(diff, "missing "+key)
+		case count < 0:
+			diff = append(diff, "unexpected "+key)
+		}
+	}
+
+	if len(diff) > 0 {
+		sort.Strings(diff)
+		return fmt.Errorf("the host records don't match the expected records after the update: %s", strings.Join(diff, ", "))
+	}
+
 	return nil
 }
 
+// recordKey identifies a host record, ignoring the normalization of the values done by Namecheap.
+func recordKey(h Record) string {
+	return fmt.Sprintf("[%s:%s:%s]", strings.ToUpper(h.Type), strings.ToLower(dns01.UnFqdn(h.Name)), dns01.UnFqdn(h.Address))
+}
+
+func isChallengeRecord(h Record, ch *challenge) bool {
+	return h.Type == "TXT" && h.Name == ch.key && h.Address == ch.keyValue
+}
+
 // getClientIP returns the client's public IP address.
 // It uses namecheap's IP discovery service to perform the lookup.
 func getClientIP(client *http.Client, debug bool) (addr string, err error) {
@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -42,12 +44,12 @@ func TestDNSProvider_getHosts(t *testing.T) {
 			hosts, err := provider.getHosts(ch.sld, ch.tld)
 			if test.errString != "" {
 				assert.EqualError(t, err, test.errString)
-			} else {
-				assert.NoError(t, err)
+				return
 			}
+			require.NoError(t, err)
 
 		next1:
-			for _, h := range hosts {
+			for _, h := range hosts.Hosts {
 				for _, th := range test.hosts {
 					if h == th {
 						continue next1
@@ -58,7 +60,7 @@ func TestDNSProvider_getHosts(t *testing.T) {
 
 		next2:
 			for _, th := range test.hosts {
-				for _, h := range hosts {
+				for _, h := range hosts.Hosts {
 					if h == th {
 						continue next2
 					}
@@ -130,6 +132,79 @@ func TestDNSProvider_CleanUp(t *testing.T) {
 	}
 }
 
+func TestDNSProvider_Present_keepRecords(t *testing.T) {
+	test := testCases[0]
+
+	mock := httptest.NewServer(mockServer(&test, t))
+	defer mock.Close()
+
+	prov := mockDNSProvider(mock.URL)
+
+	err := prov.Present(test.domain, "", "dummyKey")
+	require.NoError(t, err)
+
+	ch, err := newChallenge(test.domain, "dummyKey", tldsMock)
+	require.NoError(t, err)
+
+	hosts, err := prov.getHosts(ch.sld, ch.tld)
+	require.NoError(t, err)
+
+	expected := append([]Record{}, test.hosts...)
+	expected = append(expected, Record{Type: "TXT", Name: "_acme-challenge.test", Address: ch.keyValue, MXPref: "10", TTL: "120"})
+	assert.ElementsMatch(t, expected, hosts.Hosts)
+
+	err = prov.CleanUp(test.domain, "", "dummyKey")
+	require.NoError(t, err)
+
+	hosts, err = prov.getHosts(ch.sld, ch.tld)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, test.hosts, hosts.Hosts)
+}
+
+func TestDNSProvider_Present_verificationFailure(t *testing.T) {
+	test := testCases[1]
+	test.ignoreSetHosts = true
+
+	mock := httptest.NewServer(mockServer(&test, t))
+	defer mock.Close()
+
+	prov := mockDNSProvider(mock.URL)
+
+	err := prov.Present(test.domain, "", "dummyKey")
+
+	ch, _ := newChallenge(test.domain, "dummyKey", tldsMock)
+	assert.EqualError(t, err, "namecheap: the host records don't match the expected records after the update: missing [TXT:_acme-challenge:"+ch.keyValue+"]")
+}
+
+func TestDNSProvider_Present_notUsingOurDNS(t *testing.T) {
+	test := testCase{
+		name:             "Test:Error:NotUsingOurDNS",
+		domain:           "example.com",
+		getHostsResponse: responseGetHostsNotUsingOurDNS,
+	}
+
+	mock := httptest.NewServer(mockServer(&test, t))
+	defer mock.Close()
+
+	prov := mockDNSProvider(mock.URL)
+
+	err := prov.Present(test.domain, "", "dummyKey")
+	assert.EqualError(t, err, "namecheap: the domain example.com doesn't use the Namecheap DNS servers")
+}
+
+func TestNewDefaultConfig_sandbox(t *testing.T) {
+	defer os.Unsetenv("NAMECHEAP_SANDBOX")
+
+	config := NewDefaultConfig()
+	assert.Equal(t, defaultBaseURL, config.BaseURL)
+
+	os.Setenv("NAMECHEAP_SANDBOX", "true")
+
+	config = NewDefaultConfig()
+	assert.Equal(t, sandboxBaseURL, config.BaseURL)
+}
+
 func TestDomainSplit(t *testing.T) {
 	tests := []struct {
 		domain string
@@ -198,7 +273,10 @@ func assertHdr(tc *testCase, t *testing.T, values *url.Values) {
 	assertEq(t, "TLD", values.Get("TLD"), ch.tld)
 }
 
+// mockServer mocks the Namecheap API, the host records sent by setHosts are returned by the next getHosts.
 func mockServer(tc *testCase, t *testing.T) http.Handler {
+	var hosts url.Values
+
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
@@ -208,7 +286,11 @@ func mockServer(tc *testCase, t *testing.T) http.Handler {
 			case "namecheap.domains.dns.getHosts":
 				assertHdr(tc, t, &values)
 				w.WriteHeader(http.StatusOK)
-				fmt.Fprint(w, tc.getHostsResponse)
+				if hosts != nil {
+					fmt.Fprint(w, buildGetHostsResponse(hosts))
+				} else {
+					fmt.Fprint(w, tc.getHostsResponse)
+				}
 			case "namecheap.domains.getTldList":
 				w.WriteHeader(http.StatusOK)
 				fmt.Fprint(w, responseGetTlds)
@@ -227,6 +309,10 @@ func mockServer(tc *testCase, t *testing.T) http.Handler {
 			switch cmd {
 			case "namecheap.domains.dns.setHosts":
 				assertHdr(tc, t, &values)
+				assertEq(t, "EmailType", values.Get("EmailType"), "MXE")
+				if !tc.ignoreSetHosts {
+					hosts = values
+				}
 				w.WriteHeader(http.StatusOK)
 				fmt.Fprint(w, tc.setHostsResponse)
 			default:
@@ -239,6 +325,32 @@ func mockServer(tc *testCase, t *testing.T) http.Handler {
 	})
 }
 
+// buildGetHostsResponse builds a getHosts response from the host records sent to setHosts.
+func buildGetHostsResponse(values url.Values) string {
+	var hosts []string
+	for i := 1; values.Get(fmt.Sprintf("HostName%d", i)) != ""; i++ {
+		hosts = append(hosts, fmt.Sprintf(`<host Name="%s" Type="%s" Address="%s" MXPref="%s" TTL="%s" IsActive="true" />`,
+			values.Get(fmt.Sprintf("HostName%d", i)),
+			values.Get(fmt.Sprintf("RecordType%d", i)),
+			values.Get(fmt.Sprintf("Address%d", i)),
+			values.Get(fmt.Sprintf("MXPref%d", i)),
+			values.Get(fmt.Sprintf("TTL%d", i)),
+		))
+	}
+
+	return fmt.Sprintf(`<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <Errors />
+  <Warnings />
+  <RequestedCommand>namecheap.domains.dns.getHosts</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.dns.getHosts">
+    <DomainDNSGetHostsResult Domain="example.com" EmailType="%s" IsUsingOurDNS="true">
+      %s
+    </DomainDNSGetHostsResult>
+  </CommandResponse>
+</ApiResponse>`, values.Get("EmailType"), strings.Join(hosts, "\n      "))
+}
+
 func mockDNSProvider(url string) *DNSProvider {
 	config := NewDefaultConfig()
 	config.BaseURL = url
@@ -261,6 +373,7 @@ type testCase struct {
 	errString        string
 	getHostsResponse string
 	setHostsResponse string
+	ignoreSetHosts   bool
 }
 
 var testCases = []testCase{
@@ -374,6 +487,20 @@ const responseGetHostsErrorBadAPIKey1 = `<?xml version="1.0" encoding="utf-8"?>
   <ExecutionTime>0</ExecutionTime>
 </ApiResponse>`
 
+const responseGetHostsNotUsingOurDNS = `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <Errors />
+  <Warnings />
+  <RequestedCommand>namecheap.domains.dns.getHosts</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.dns.getHosts">
+    <DomainDNSGetHostsResult Domain="example.com" EmailType="FWD" IsUsingOurDNS="false">
+    </DomainDNSGetHostsResult>
+  </CommandResponse>
+  <Server>PHX01SBAPI01</Server>
+  <GMTTimeDifference>--5:00</GMTTimeDifference>
+  <ExecutionTime>0.012</ExecutionTime>
+</ApiResponse>`
+
 const responseGetTlds = `<?xml version="1.0" encoding="utf-8"?>
 <ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
   <Errors />
@@ -0,0 +1,179 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// DefaultBaseURL the default URL of the Njalla API.
+const DefaultBaseURL = "https://njal.la/api/1/"
+
+// Request a JSON-RPC request.
+type Request struct {
+	ID     string      `json:"id,omitempty"`
+	Method string      `json:"method,omitempty"`
+	Params interface{} `json:"params,omitempty"`
+}
+
+// APIResponse a JSON-RPC response.
+type APIResponse struct {
+	ID     string          `json:"id,omitempty"`
+	RPC    string          `json:"jsonrpc,omitempty"`
+	Error  *APIError       `json:"error,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+}
+
+// APIError an error of the API.
+type APIError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (a APIError) Error() string {
+	return fmt.Sprintf("code: %d, message: %s", a.Code, a.Message)
+}
+
+// Record a DNS record.
+type Record struct {
+	ID      string `json:"id,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Domain  string `json:"domain,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Content string `json:"content,omitempty"`
+	TTL     int    `json:"ttl,omitempty"`
+}
+
+// Records a list of records.
+type Records struct {
+	Records []Record `json:"records,omitempty"`
+}
+
+// recordID the ID of a record: the API returns it as a number but expects a string.
+type recordID string
+
+func (r *recordID) UnmarshalJSON(data []byte) error {
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return err
+	}
+	*r = recordID(number)
+	return nil
+}
+
+// Client the Njalla API client.
+type Client struct {
+	token      string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a Njalla API client.
+func NewClient(token string) *Client {
+	return &Client{
+		token:      token,
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// AddRecord adds a record and returns it with its ID.
+func (c *Client) AddRecord(record Record) (*Record, error) {
+	var result struct {
+		Record
+		ID recordID `json:"id"`
+	}
+
+	err := c.do(Request{Method: "add-record", Params: record}, &result)
+	if err != nil {
+		return nil, fmt.Errorf("unable to add record: %v", err)
+	}
+
+	added := result.Record
+	added.ID = string(result.ID)
+
+	return &added, nil
+}
+
+// RemoveRecord removes a record.
+func (c *Client) RemoveRecord(id, domain string) error {
+	err := c.do(Request{Method: "remove-record", Params: Record{ID: id, Domain: domain}}, nil)
+	if err != nil {
+		return fmt.Errorf("unable to remove record %s: %v", id, err)
+	}
+
+	return nil
+}
+
+// ListRecords returns the records of a domain.
+func (c *Client) ListRecords(domain string) ([]Record, error) {
+	var result struct {
+		Records []struct {
+			Record
+			ID recordID `json:"id"`
+		} `json:"records"`
+	}
+
+	err := c.do(Request{Method: "list-records", Params: Record{Domain: domain}}, &result)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list records: %v", err)
+	}
+
+	var records []Record
+	for _, r := range result.Records {
+		record := r.Record
+		record.ID = string(r.ID)
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func (c *Client) do(data Request, result interface{}) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.BaseURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Njalla "+c.token)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	apiResp := &APIResponse{}
+	err = json.Unmarshal(raw, apiResp)
+	if err != nil {
+		return fmt.Errorf("unable to parse the response: %v: %s", err, string(raw))
+	}
+
+	if apiResp.Error != nil {
+		return apiResp.Error
+	}
+
+	if result == nil || len(apiResp.Result) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(apiResp.Result, result)
+}
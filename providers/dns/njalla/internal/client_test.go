@@ -0,0 +1,118 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, handler func(req Request, params map[string]interface{}) string) (*Client, func()) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("Authorization") != "Njalla secret" {
+			http.Error(rw, "invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		params := map[string]interface{}{}
+		apiReq := Request{Params: &params}
+		if err := json.NewDecoder(req.Body).Decode(&apiReq); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, handler(apiReq, params))
+	}))
+
+	client := NewClient("secret")
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+func TestClient_AddRecord(t *testing.T) {
+	client, tearDown := setupTest(t, func(req Request, params map[string]interface{}) string {
+		if req.Method != "add-record" {
+			return `{"jsonrpc":"2.0","error":{"code":404,"message":"method not found"}}`
+		}
+
+		return fmt.Sprintf(`{"jsonrpc":"2.0","result":{"id":1337,"name":%q,"type":%q,"content":%q,"ttl":%v}}`,
+			params["name"], params["type"], params["content"], params["ttl"])
+	})
+	defer tearDown()
+
+	record := Record{
+		Name:    "_acme-challenge",
+		Domain:  "example.com",
+		Content: "txtvalue",
+		TTL:     300,
+		Type:    "TXT",
+	}
+
+	result, err := client.AddRecord(record)
+	require.NoError(t, err)
+
+	expected := &Record{
+		ID:      "1337",
+		Name:    "_acme-challenge",
+		Content: "txtvalue",
+		TTL:     300,
+		Type:    "TXT",
+	}
+	assert.Equal(t, expected, result)
+}
+
+func TestClient_AddRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t, func(req Request, params map[string]interface{}) string {
+		return `{"jsonrpc":"2.0","error":{"code":403,"message":"permission denied"}}`
+	})
+	defer tearDown()
+
+	_, err := client.AddRecord(Record{Domain: "example.com"})
+	require.EqualError(t, err, "unable to add record: code: 403, message: permission denied")
+}
+
+func TestClient_ListRecords(t *testing.T) {
+	client, tearDown := setupTest(t, func(req Request, params map[string]interface{}) string {
+		if req.Method != "list-records" || params["domain"] != "example.com" {
+			return `{"jsonrpc":"2.0","error":{"code":404,"message":"not found"}}`
+		}
+
+		return `{"jsonrpc":"2.0","result":{"records":[{"id":1337,"name":"_acme-challenge","type":"TXT","content":"txtvalue","ttl":300}]}}`
+	})
+	defer tearDown()
+
+	records, err := client.ListRecords("example.com")
+	require.NoError(t, err)
+
+	expected := []Record{{ID: "1337", Name: "_acme-challenge", Content: "txtvalue", TTL: 300, Type: "TXT"}}
+	assert.Equal(t, expected, records)
+}
+
+func TestClient_RemoveRecord(t *testing.T) {
+	client, tearDown := setupTest(t, func(req Request, params map[string]interface{}) string {
+		if req.Method != "remove-record" || params["id"] != "1337" || params["domain"] != "example.com" {
+			return `{"jsonrpc":"2.0","error":{"code":404,"message":"record not found"}}`
+		}
+
+		return `{"jsonrpc":"2.0","result":{}}`
+	})
+	defer tearDown()
+
+	err := client.RemoveRecord("1337", "example.com")
+	require.NoError(t, err)
+
+	err = client.RemoveRecord("42", "example.com")
+	require.EqualError(t, err, "unable to remove record 42: code: 404, message: record not found")
+}
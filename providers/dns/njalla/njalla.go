@@ -0,0 +1,182 @@
+// Package njalla implements a DNS provider for solving the DNS-01 challenge using Njalla.
+package njalla
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/njalla/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL            string
+	Token              string
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString("NJALLA_ENDPOINT", internal.DefaultBaseURL),
+		TTL:                env.GetOrDefaultInt("NJALLA_TTL", 300),
+		PropagationTimeout: env.GetOrDefaultSecond("NJALLA_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("NJALLA_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("NJALLA_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses Njalla's API to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	recordIDs   map[string]string
+	recordIDsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Njalla.
+// Credentials must be passed in the environment variable: NJALLA_TOKEN.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("NJALLA_TOKEN")
+	if err != nil {
+		return nil, fmt.Errorf("njalla: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.Token = values["NJALLA_TOKEN"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Njalla.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("njalla: the configuration of the DNS provider is nil")
+	}
+
+	if config.Token == "" {
+		return nil, errors.New("njalla: missing credentials")
+	}
+
+	client := internal.NewClient(config.Token)
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{
+		config:    config,
+		client:    client,
+		recordIDs: make(map[string]string),
+	}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return fmt.Errorf("njalla: could not find zone for domain %q: %v", domain, err)
+	}
+
+	record := internal.Record{
+		Name:    extractRecordName(fqdn, authZone),
+		Domain:  dns01.UnFqdn(authZone),
+		Content: value,
+		TTL:     d.config.TTL,
+		Type:    "TXT",
+	}
+
+	resp, err := d.client.AddRecord(record)
+	if err != nil {
+		return fmt.Errorf("njalla: %v", err)
+	}
+
+	d.recordIDsMu.Lock()
+	d.recordIDs[token] = resp.ID
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return fmt.Errorf("njalla: could not find zone for domain %q: %v", domain, err)
+	}
+
+	zone := dns01.UnFqdn(authZone)
+
+	d.recordIDsMu.Lock()
+	recordID, ok := d.recordIDs[token]
+	d.recordIDsMu.Unlock()
+
+	if !ok {
+		// the record was created by another instance: the record is searched by name and value.
+		recordID, err = d.findRecordID(zone, extractRecordName(fqdn, authZone), value)
+		if err != nil {
+			return fmt.Errorf("njalla: %v", err)
+		}
+	}
+
+	err = d.client.RemoveRecord(recordID, zone)
+	if err != nil {
+		return fmt.Errorf("njalla: %v", err)
+	}
+
+	d.recordIDsMu.Lock()
+	delete(d.recordIDs, token)
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+func (d *DNSProvider) findRecordID(zone, name, value string) (string, error) {
+	records, err := d.client.ListRecords(zone)
+	if err != nil {
+		return "", err
+	}
+
+	for _, record := range records {
+		if record.Type == "TXT" && record.Name == name && record.Content == value {
+			return record.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("TXT record %s not found in %s", name, zone)
+}
+
+func extractRecordName(fqdn, authZone string) string {
+	name := dns01.UnFqdn(fqdn)
+	if idx := strings.LastIndex(name, "."+dns01.UnFqdn(authZone)); idx != -1 {
+		return name[:idx]
+	}
+	return name
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -12,6 +14,7 @@ import (
 	"github.com/go-acme/lego/v3/challenge/dns01"
 	"github.com/go-acme/lego/v3/platform/config/env"
 	"github.com/ovh/go-ovh/ovh"
+	"gopkg.in/ini.v1"
 )
 
 // OVH API reference:       https://eu.api.ovh.com/
@@ -61,53 +64,118 @@ type DNSProvider struct {
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for OVH
-// Credentials must be passed in the environment variable:
-// OVH_ENDPOINT : it must be ovh-eu or ovh-ca
+// Credentials are read from the environment variables:
+// OVH_ENDPOINT : an endpoint name (ovh-eu, ovh-ca, ovh-us, kimsufi-eu, kimsufi-ca, soyoustart-eu, soyoustart-ca) or an URL
 // OVH_APPLICATION_KEY
 // OVH_APPLICATION_SECRET
 // OVH_CONSUMER_KEY
+// The missing values are read from the OVH configuration files
+// (./ovh.conf, $HOME/.ovh.conf and /etc/ovh.conf) shared with the other OVH tools.
 func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get("OVH_ENDPOINT", "OVH_APPLICATION_KEY", "OVH_APPLICATION_SECRET", "OVH_CONSUMER_KEY")
-	if err != nil {
-		return nil, fmt.Errorf("ovh: %v", err)
-	}
-
 	config := NewDefaultConfig()
-	config.APIEndpoint = values["OVH_ENDPOINT"]
-	config.ApplicationKey = values["OVH_APPLICATION_KEY"]
-	config.ApplicationSecret = values["OVH_APPLICATION_SECRET"]
-	config.ConsumerKey = values["OVH_CONSUMER_KEY"]
+	config.APIEndpoint = env.GetOrFile("OVH_ENDPOINT")
+	config.ApplicationKey = env.GetOrFile("OVH_APPLICATION_KEY")
+	config.ApplicationSecret = env.GetOrFile("OVH_APPLICATION_SECRET")
+	config.ConsumerKey = env.GetOrFile("OVH_CONSUMER_KEY")
 
 	return NewDNSProviderConfig(config)
 }
 
 // NewDNSProviderConfig return a DNSProvider instance configured for OVH.
+// The credentials missing from the configuration are read from the OVH configuration files.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	if config == nil {
 		return nil, errors.New("ovh: the configuration of the DNS provider is nil")
 	}
 
-	if config.APIEndpoint == "" || config.ApplicationKey == "" || config.ApplicationSecret == "" || config.ConsumerKey == "" {
-		return nil, fmt.Errorf("ovh: credentials missing")
+	client, err := newClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("ovh: %v", err)
+	}
+
+	if client.ConsumerKey == "" {
+		return nil, errors.New("ovh: missing consumer key, a consumer key can be requested with RequestConsumerKey")
+	}
+
+	return &DNSProvider{
+		config:    config,
+		client:    client,
+		recordIDs: make(map[string]int),
+	}, nil
+}
+
+// RequestConsumerKey requests a new consumer key with the access rules needed by the DNS provider
+// (GET, POST, PUT and DELETE on /domain/zone).
+// The consumer key must be validated by visiting the returned validation URL before being used.
+// The consumer key of the configuration is ignored.
+func RequestConsumerKey(config *Config) (*ovh.CkValidationState, error) {
+	if config == nil {
+		return nil, errors.New("ovh: the configuration of the DNS provider is nil")
+	}
+
+	client, err := newClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("ovh: %v", err)
+	}
+
+	ckReq := client.NewCkRequest()
+	ckReq.AddRecursiveRules(ovh.ReadWrite, "/domain/zone")
+
+	state, err := ckReq.Do()
+	if err != nil {
+		return nil, fmt.Errorf("ovh: failed to request a consumer key: %v", err)
+	}
+
+	return state, nil
+}
+
+func newClient(config *Config) (*ovh.Client, error) {
+	endpoint := config.APIEndpoint
+	if endpoint == "" {
+		endpoint = defaultEndpoint()
 	}
 
 	client, err := ovh.NewClient(
-		config.APIEndpoint,
+		endpoint,
 		config.ApplicationKey,
 		config.ApplicationSecret,
 		config.ConsumerKey,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("ovh: %v", err)
+		return nil, err
 	}
 
-	client.Client = config.HTTPClient
+	if config.HTTPClient != nil {
+		client.Client = config.HTTPClient
+	}
 
-	return &DNSProvider{
-		config:    config,
-		client:    client,
-		recordIDs: make(map[string]int),
-	}, nil
+	return client, nil
+}
+
+// defaultEndpoint returns the endpoint defined in the "default" section of the OVH configuration files,
+// or "ovh-eu" if none is defined.
+func defaultEndpoint() string {
+	cfg := ini.Empty()
+
+	// by order of increasing priority.
+	paths := []string{"/etc/ovh.conf"}
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".ovh.conf"))
+	}
+	paths = append(paths, "./ovh.conf")
+
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			_ = cfg.Append(path)
+		}
+	}
+
+	endpoint := cfg.Section("default").Key("endpoint").String()
+	if endpoint == "" {
+		return "ovh-eu"
+	}
+
+	return endpoint
 }
 
 // Present creates a TXT record to fulfill the dns-01 challenge.
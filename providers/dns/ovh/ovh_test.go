@@ -1,10 +1,20 @@
 package ovh
 
 import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/ovh/go-ovh/ovh"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -31,24 +41,32 @@ func TestNewDNSProvider(t *testing.T) {
 			},
 		},
 		{
-			desc: "missing credentials",
+			desc: "success: regional endpoint",
 			envVars: map[string]string{
-				"OVH_ENDPOINT":           "",
-				"OVH_APPLICATION_KEY":    "",
-				"OVH_APPLICATION_SECRET": "",
-				"OVH_CONSUMER_KEY":       "",
+				"OVH_ENDPOINT":           "soyoustart-ca",
+				"OVH_APPLICATION_KEY":    "B",
+				"OVH_APPLICATION_SECRET": "C",
+				"OVH_CONSUMER_KEY":       "D",
 			},
-			expected: "ovh: some credentials information are missing: OVH_ENDPOINT,OVH_APPLICATION_KEY,OVH_APPLICATION_SECRET,OVH_CONSUMER_KEY",
 		},
 		{
-			desc: "missing endpoint",
+			desc: "success: default endpoint",
 			envVars: map[string]string{
 				"OVH_ENDPOINT":           "",
 				"OVH_APPLICATION_KEY":    "B",
 				"OVH_APPLICATION_SECRET": "C",
 				"OVH_CONSUMER_KEY":       "D",
 			},
-			expected: "ovh: some credentials information are missing: OVH_ENDPOINT",
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				"OVH_ENDPOINT":           "",
+				"OVH_APPLICATION_KEY":    "",
+				"OVH_APPLICATION_SECRET": "",
+				"OVH_CONSUMER_KEY":       "",
+			},
+			expected: "ovh: missing application key, please check your configuration or consult the documentation to create one",
 		},
 		{
 			desc: "missing invalid endpoint",
@@ -68,7 +86,7 @@ func TestNewDNSProvider(t *testing.T) {
 				"OVH_APPLICATION_SECRET": "C",
 				"OVH_CONSUMER_KEY":       "D",
 			},
-			expected: "ovh: some credentials information are missing: OVH_APPLICATION_KEY",
+			expected: "ovh: missing application key, please check your configuration or consult the documentation to create one",
 		},
 		{
 			desc: "missing application secret",
@@ -78,7 +96,7 @@ func TestNewDNSProvider(t *testing.T) {
 				"OVH_APPLICATION_SECRET": "",
 				"OVH_CONSUMER_KEY":       "D",
 			},
-			expected: "ovh: some credentials information are missing: OVH_APPLICATION_SECRET",
+			expected: "ovh: missing application secret, please check your configuration or consult the documentation to create one",
 		},
 		{
 			desc: "missing consumer key",
@@ -88,7 +106,7 @@ func TestNewDNSProvider(t *testing.T) {
 				"OVH_APPLICATION_SECRET": "C",
 				"OVH_CONSUMER_KEY":       "",
 			},
-			expected: "ovh: some credentials information are missing: OVH_CONSUMER_KEY",
+			expected: "ovh: missing consumer key, a consumer key can be requested with RequestConsumerKey",
 		},
 	}
 
@@ -131,16 +149,22 @@ func TestNewDNSProviderConfig(t *testing.T) {
 			consumerKey:       "D",
 		},
 		{
-			desc:     "missing credentials",
-			expected: "ovh: credentials missing",
+			desc:              "success: URL endpoint",
+			apiEndpoint:       "https://api.example.com/1.0",
+			applicationKey:    "B",
+			applicationSecret: "C",
+			consumerKey:       "D",
 		},
 		{
-			desc:              "missing api endpoint",
+			desc:              "success: default endpoint",
 			apiEndpoint:       "",
 			applicationKey:    "B",
 			applicationSecret: "C",
 			consumerKey:       "D",
-			expected:          "ovh: credentials missing",
+		},
+		{
+			desc:     "missing credentials",
+			expected: "ovh: missing application key, please check your configuration or consult the documentation to create one",
 		},
 		{
 			desc:              "missing invalid api endpoint",
@@ -156,7 +180,7 @@ func TestNewDNSProviderConfig(t *testing.T) {
 			applicationKey:    "",
 			applicationSecret: "C",
 			consumerKey:       "D",
-			expected:          "ovh: credentials missing",
+			expected:          "ovh: missing application key, please check your configuration or consult the documentation to create one",
 		},
 		{
 			desc:              "missing application secret",
@@ -164,7 +188,7 @@ func TestNewDNSProviderConfig(t *testing.T) {
 			applicationKey:    "B",
 			applicationSecret: "",
 			consumerKey:       "D",
-			expected:          "ovh: credentials missing",
+			expected:          "ovh: missing application secret, please check your configuration or consult the documentation to create one",
 		},
 		{
 			desc:              "missing consumer key",
@@ -172,12 +196,15 @@ func TestNewDNSProviderConfig(t *testing.T) {
 			applicationKey:    "B",
 			applicationSecret: "C",
 			consumerKey:       "",
-			expected:          "ovh: credentials missing",
+			expected:          "ovh: missing consumer key, a consumer key can be requested with RequestConsumerKey",
 		},
 	}
 
 	for _, test := range testCases {
 		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
 			config := NewDefaultConfig()
 			config.APIEndpoint = test.apiEndpoint
 			config.ApplicationKey = test.applicationKey
@@ -199,6 +226,104 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func TestNewDNSProvider_configFile(t *testing.T) {
+	defer envTest.RestoreEnv()
+	envTest.ClearEnv()
+
+	dir, err := ioutil.TempDir("", "lego-ovh")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	content := `[default]
+endpoint=ovh-us
+
+[ovh-us]
+application_key=B
+application_secret=C
+consumer_key=D
+`
+	err = ioutil.WriteFile(filepath.Join(dir, "ovh.conf"), []byte(content), 0600)
+	require.NoError(t, err)
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() { _ = os.Chdir(wd) }()
+
+	// the local configuration file of the OVH client is ./ovh.conf
+	err = os.Chdir(dir)
+	require.NoError(t, err)
+
+	envTest.Apply(map[string]string{"OVH_CONSUMER_KEY": "E"})
+
+	p, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	assert.Equal(t, "B", p.client.AppKey)
+	assert.Equal(t, "C", p.client.AppSecret)
+	// the environment variables take precedence over the configuration files.
+	assert.Equal(t, "E", p.client.ConsumerKey)
+}
+
+func TestRequestConsumerKey(t *testing.T) {
+	defer envTest.RestoreEnv()
+	envTest.ClearEnv()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/auth/credential", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("X-Ovh-Application") != "B" {
+			http.Error(rw, "invalid application key", http.StatusUnauthorized)
+			return
+		}
+
+		var ckReq ovh.CkRequest
+		err := json.NewDecoder(req.Body).Decode(&ckReq)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := []ovh.AccessRule{
+			{Method: "GET", Path: "/domain/zone"},
+			{Method: "POST", Path: "/domain/zone"},
+			{Method: "PUT", Path: "/domain/zone"},
+			{Method: "DELETE", Path: "/domain/zone"},
+			{Method: "GET", Path: "/domain/zone/*"},
+			{Method: "POST", Path: "/domain/zone/*"},
+			{Method: "PUT", Path: "/domain/zone/*"},
+			{Method: "DELETE", Path: "/domain/zone/*"},
+		}
+		if !reflect.DeepEqual(expected, ckReq.AccessRules) {
+			http.Error(rw, fmt.Sprintf("unexpected access rules: %v", ckReq.AccessRules), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{"consumerKey":"E","state":"pendingValidation","validationUrl":"https://eu.api.ovh.com/auth/?credentialToken=xxx"}`)
+	})
+
+	config := NewDefaultConfig()
+	config.APIEndpoint = server.URL
+	config.ApplicationKey = "B"
+	config.ApplicationSecret = "C"
+
+	state, err := RequestConsumerKey(config)
+	require.NoError(t, err)
+
+	expected := &ovh.CkValidationState{
+		ConsumerKey:   "E",
+		State:         "pendingValidation",
+		ValidationURL: "https://eu.api.ovh.com/auth/?credentialToken=xxx",
+	}
+	assert.Equal(t, expected, state)
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
@@ -0,0 +1,157 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+const agentPath = "/enterprise/control/agent.php"
+
+// Client the Plesk XML-API client.
+type Client struct {
+	username   string
+	password   string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a Plesk XML-API client, the base URL is the URL of the Plesk server (i.e. "https://plesk.example.com:8443").
+func NewClient(baseURL, username, password string) *Client {
+	return &Client{
+		username:   username,
+		password:   password,
+		BaseURL:    baseURL,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// GetSiteID returns the ID of the site (domain) matching the name, or 0 if it doesn't exist.
+func (c *Client) GetSiteID(name string) (int, error) {
+	request := RequestPacket{Site: &SiteRequest{Get: SiteGetRequest{Filter: SiteFilter{Name: name}}}}
+
+	response, err := c.do(request)
+	if err != nil {
+		return 0, fmt.Errorf("unable to get the site %s: %v", name, err)
+	}
+
+	result, err := first(response.Site.Get.Results)
+	if err != nil {
+		if apiErr, ok := err.(*APIError); ok && apiErr.Code == codeObjectNotFound {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("unable to get the site %s: %v", name, err)
+	}
+
+	return result.ID, nil
+}
+
+// AddRecord adds a record and returns its ID.
+func (c *Client) AddRecord(record AddRecordRequest) (int, error) {
+	request := RequestPacket{DNS: &DNSRequest{AddRecord: &record}}
+
+	response, err := c.do(request)
+	if err != nil {
+		return 0, fmt.Errorf("unable to add the record %s: %v", record.Host, err)
+	}
+
+	result, err := first(response.DNS.AddRecord.Results)
+	if err != nil {
+		return 0, fmt.Errorf("unable to add the record %s: %v", record.Host, err)
+	}
+
+	return result.ID, nil
+}
+
+// GetRecords returns the records of a site.
+func (c *Client) GetRecords(siteID int) (map[int]Record, error) {
+	request := RequestPacket{DNS: &DNSRequest{GetRecord: &RecordRequest{Filter: RecordFilter{SiteID: siteID}}}}
+
+	response, err := c.do(request)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the records of the site %d: %v", siteID, err)
+	}
+
+	records := make(map[int]Record)
+	for _, result := range response.DNS.GetRecord.Results {
+		if err = result.err(); err != nil {
+			return nil, fmt.Errorf("unable to get the records of the site %d: %v", siteID, err)
+		}
+
+		records[result.ID] = result.Data
+	}
+
+	return records, nil
+}
+
+// DeleteRecord deletes a record.
+func (c *Client) DeleteRecord(recordID int) error {
+	request := RequestPacket{DNS: &DNSRequest{DelRecord: &RecordRequest{Filter: RecordFilter{ID: recordID}}}}
+
+	response, err := c.do(request)
+	if err != nil {
+		return fmt.Errorf("unable to delete the record %d: %v", recordID, err)
+	}
+
+	_, err = first(response.DNS.DelRecord.Results)
+	if err != nil {
+		return fmt.Errorf("unable to delete the record %d: %v", recordID, err)
+	}
+
+	return nil
+}
+
+func (c *Client) do(request RequestPacket) (*ResponsePacket, error) {
+	body, err := xml.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.BaseURL+agentPath, bytes.NewReader(append([]byte(xml.Header), body...)))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "text/xml")
+	req.Header.Set("HTTP_AUTH_LOGIN", c.username)
+	req.Header.Set("HTTP_AUTH_PASSWD", c.password)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	response := &ResponsePacket{}
+	err = xml.Unmarshal(raw, response)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the response: %v: %s", err, string(raw))
+	}
+
+	// the errors of the authentication and of the packet are returned in a "system" element.
+	if response.System != nil {
+		return nil, response.System.err()
+	}
+
+	return response, nil
+}
+
+func first(results []Result) (Result, error) {
+	if len(results) == 0 {
+		return Result{}, errors.New("empty result")
+	}
+
+	return results[0], results[0].err()
+}
@@ -0,0 +1,149 @@
+package internal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(handler func(rw http.ResponseWriter, body string)) (*Client, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc(agentPath, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("HTTP_AUTH_LOGIN") != "user" || req.Header.Get("HTTP_AUTH_PASSWD") != "secret" {
+			fmt.Fprint(rw, `<?xml version="1.0" encoding="UTF-8"?><packet version="1.6.9.1"><system><status>error</status><errcode>1001</errcode><errtext>Authentication failed - wrong password.</errtext></system></packet>`)
+			return
+		}
+
+		raw, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		handler(rw, string(raw))
+	})
+
+	client := NewClient(server.URL, "user", "secret")
+
+	return client, server.Close
+}
+
+func TestClient_GetSiteID(t *testing.T) {
+	client, tearDown := setupTest(func(rw http.ResponseWriter, body string) {
+		expected := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+			`<packet><site><get><filter><name>example.com</name></filter><dataset><gen_info></gen_info></dataset></get></site></packet>`
+		if body != expected {
+			http.Error(rw, fmt.Sprintf("unexpected body: %s", body), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `<packet><site><get><result><status>ok</status><filter-id>example.com</filter-id><id>12</id><data><gen_info><name>example.com</name></gen_info></data></result></get></site></packet>`)
+	})
+	defer tearDown()
+
+	siteID, err := client.GetSiteID("example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, 12, siteID)
+}
+
+func TestClient_GetSiteID_notFound(t *testing.T) {
+	client, tearDown := setupTest(func(rw http.ResponseWriter, body string) {
+		fmt.Fprint(rw, `<packet><site><get><result><status>error</status><errcode>1013</errcode><errtext>Site does not exist</errtext><filter-id>example.org</filter-id></result></get></site></packet>`)
+	})
+	defer tearDown()
+
+	siteID, err := client.GetSiteID("example.org")
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, siteID)
+}
+
+func TestClient_GetSiteID_authError(t *testing.T) {
+	client, tearDown := setupTest(func(rw http.ResponseWriter, body string) {})
+	defer tearDown()
+
+	client.password = "invalid"
+
+	_, err := client.GetSiteID("example.com")
+	require.EqualError(t, err, "unable to get the site example.com: 1001: Authentication failed - wrong password.")
+}
+
+func TestClient_AddRecord(t *testing.T) {
+	client, tearDown := setupTest(func(rw http.ResponseWriter, body string) {
+		if !strings.HasSuffix(body, `<packet><dns><add_rec><site-id>12</site-id><type>TXT</type><host>_acme-challenge</host><value>value</value></add_rec></dns></packet>`) {
+			http.Error(rw, fmt.Sprintf("unexpected body: %s", body), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `<packet><dns><add_rec><result><status>ok</status><id>123</id></result></add_rec></dns></packet>`)
+	})
+	defer tearDown()
+
+	recordID, err := client.AddRecord(AddRecordRequest{SiteID: 12, Type: "TXT", Host: "_acme-challenge", Value: "value"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 123, recordID)
+}
+
+func TestClient_AddRecord_error(t *testing.T) {
+	client, tearDown := setupTest(func(rw http.ResponseWriter, body string) {
+		fmt.Fprint(rw, `<packet><dns><add_rec><result><status>error</status><errcode>1007</errcode><errtext>DNS record already exists.</errtext></result></add_rec></dns></packet>`)
+	})
+	defer tearDown()
+
+	_, err := client.AddRecord(AddRecordRequest{SiteID: 12, Type: "TXT", Host: "_acme-challenge", Value: "value"})
+	require.EqualError(t, err, "unable to add the record _acme-challenge: 1007: DNS record already exists.")
+}
+
+func TestClient_GetRecords(t *testing.T) {
+	client, tearDown := setupTest(func(rw http.ResponseWriter, body string) {
+		if !strings.HasSuffix(body, `<packet><dns><get_rec><filter><site-id>12</site-id></filter></get_rec></dns></packet>`) {
+			http.Error(rw, fmt.Sprintf("unexpected body: %s", body), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `<packet><dns><get_rec>
+<result><status>ok</status><id>122</id><data><site-id>12</site-id><type>A</type><host>example.com.</host><value>10.0.0.1</value></data></result>
+<result><status>ok</status><id>123</id><data><site-id>12</site-id><type>TXT</type><host>_acme-challenge.example.com.</host><value>value</value></data></result>
+</get_rec></dns></packet>`)
+	})
+	defer tearDown()
+
+	records, err := client.GetRecords(12)
+	require.NoError(t, err)
+
+	expected := map[int]Record{
+		122: {SiteID: 12, Type: "A", Host: "example.com.", Value: "10.0.0.1"},
+		123: {SiteID: 12, Type: "TXT", Host: "_acme-challenge.example.com.", Value: "value"},
+	}
+	assert.Equal(t, expected, records)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, tearDown := setupTest(func(rw http.ResponseWriter, body string) {
+		if !strings.HasSuffix(body, `<packet><dns><del_rec><filter><id>123</id></filter></del_rec></dns></packet>`) {
+			http.Error(rw, fmt.Sprintf("unexpected body: %s", body), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `<packet><dns><del_rec><result><status>ok</status><id>123</id></result></del_rec></dns></packet>`)
+	})
+	defer tearDown()
+
+	err := client.DeleteRecord(123)
+	require.NoError(t, err)
+}
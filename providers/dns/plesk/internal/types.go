@@ -0,0 +1,121 @@
+package internal
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// codeObjectNotFound the code of the error returned when an object doesn't exist.
+const codeObjectNotFound = 1013
+
+// RequestPacket the root element of the requests of the XML-API.
+type RequestPacket struct {
+	XMLName xml.Name `xml:"packet"`
+
+	Site *SiteRequest `xml:"site,omitempty"`
+	DNS  *DNSRequest  `xml:"dns,omitempty"`
+}
+
+// SiteRequest the operations on the sites (domains).
+type SiteRequest struct {
+	Get SiteGetRequest `xml:"get"`
+}
+
+// SiteGetRequest gets a site by name.
+type SiteGetRequest struct {
+	Filter  SiteFilter `xml:"filter"`
+	Dataset struct {
+		GenInfo struct{} `xml:"gen_info"`
+	} `xml:"dataset"`
+}
+
+// SiteFilter filters the sites by name.
+type SiteFilter struct {
+	Name string `xml:"name"`
+}
+
+// DNSRequest the operations on the DNS records.
+type DNSRequest struct {
+	AddRecord *AddRecordRequest `xml:"add_rec,omitempty"`
+	GetRecord *RecordRequest    `xml:"get_rec,omitempty"`
+	DelRecord *RecordRequest    `xml:"del_rec,omitempty"`
+}
+
+// AddRecordRequest adds a record to a site, the host is relative to the site (i.e. "_acme-challenge.sub").
+type AddRecordRequest struct {
+	SiteID int    `xml:"site-id"`
+	Type   string `xml:"type"`
+	Host   string `xml:"host"`
+	Value  string `xml:"value"`
+}
+
+// RecordRequest gets or deletes the records matching the filter.
+type RecordRequest struct {
+	Filter RecordFilter `xml:"filter"`
+}
+
+// RecordFilter filters the records by ID or by site.
+type RecordFilter struct {
+	ID     int `xml:"id,omitempty"`
+	SiteID int `xml:"site-id,omitempty"`
+}
+
+// ResponsePacket the root element of the responses of the XML-API.
+type ResponsePacket struct {
+	XMLName xml.Name `xml:"packet"`
+
+	System *Result `xml:"system"`
+
+	Site struct {
+		Get struct {
+			Results []Result `xml:"result"`
+		} `xml:"get"`
+	} `xml:"site"`
+
+	DNS struct {
+		AddRecord struct {
+			Results []Result `xml:"result"`
+		} `xml:"add_rec"`
+		GetRecord struct {
+			Results []Result `xml:"result"`
+		} `xml:"get_rec"`
+		DelRecord struct {
+			Results []Result `xml:"result"`
+		} `xml:"del_rec"`
+	} `xml:"dns"`
+}
+
+// Result the result of an operation.
+type Result struct {
+	Status  string `xml:"status"`
+	ErrCode int    `xml:"errcode"`
+	ErrText string `xml:"errtext"`
+	ID      int    `xml:"id"`
+	Data    Record `xml:"data"`
+}
+
+// Record a DNS record, the host is a FQDN (i.e. "_acme-challenge.example.com.").
+type Record struct {
+	SiteID int    `xml:"site-id"`
+	Type   string `xml:"type"`
+	Host   string `xml:"host"`
+	Value  string `xml:"value"`
+}
+
+// APIError an error of the XML-API.
+type APIError struct {
+	Code    int
+	Message string
+}
+
+func (a *APIError) Error() string {
+	return fmt.Sprintf("%d: %s", a.Code, a.Message)
+}
+
+func (r Result) err() error {
+	if r.Status == "ok" {
+		return nil
+	}
+
+	return &APIError{Code: r.ErrCode, Message: r.ErrText}
+}
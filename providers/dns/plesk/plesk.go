@@ -0,0 +1,212 @@
+// Package plesk implements a DNS provider for solving the DNS-01 challenge using Plesk.
+package plesk
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/plesk/internal"
+	"github.com/miekg/dns"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL            string
+	Username           string
+	Password           string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		PropagationTimeout: env.GetOrDefaultSecond("PLESK_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("PLESK_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("PLESK_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses the XML-API of Plesk to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	recordIDs   map[string]int
+	recordIDsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Plesk.
+// The URL of the server and the credentials must be passed in the environment variables:
+// PLESK_SERVER_BASE_URL, PLESK_USERNAME and PLESK_PASSWORD.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("PLESK_SERVER_BASE_URL", "PLESK_USERNAME", "PLESK_PASSWORD")
+	if err != nil {
+		return nil, fmt.Errorf("plesk: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.BaseURL = values["PLESK_SERVER_BASE_URL"]
+	config.Username = values["PLESK_USERNAME"]
+	config.Password = values["PLESK_PASSWORD"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Plesk.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("plesk: the configuration of the DNS provider is nil")
+	}
+
+	if config.BaseURL == "" {
+		return nil, errors.New("plesk: missing server base URL")
+	}
+
+	baseURL, err := url.Parse(config.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("plesk: %v", err)
+	}
+
+	if baseURL.Scheme == "" || baseURL.Host == "" {
+		return nil, fmt.Errorf("plesk: invalid server base URL: %s", config.BaseURL)
+	}
+
+	if config.Username == "" || config.Password == "" {
+		return nil, errors.New("plesk: credentials missing")
+	}
+
+	client := internal.NewClient(strings.TrimSuffix(config.BaseURL, "/"), config.Username, config.Password)
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{
+		config:    config,
+		client:    client,
+		recordIDs: make(map[string]int),
+	}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	siteID, siteName, err := d.findSite(fqdn)
+	if err != nil {
+		return fmt.Errorf("plesk: %v", err)
+	}
+
+	record := internal.AddRecordRequest{
+		SiteID: siteID,
+		Type:   "TXT",
+		Host:   extractRecordName(fqdn, siteName),
+		Value:  value,
+	}
+
+	recordID, err := d.client.AddRecord(record)
+	if err != nil {
+		return fmt.Errorf("plesk: %v", err)
+	}
+
+	d.recordIDsMu.Lock()
+	d.recordIDs[token] = recordID
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	// gets the record's unique ID from when we created it
+	d.recordIDsMu.Lock()
+	recordID, ok := d.recordIDs[token]
+	d.recordIDsMu.Unlock()
+
+	if !ok {
+		var err error
+		recordID, err = d.findRecordID(fqdn, value)
+		if err != nil {
+			return fmt.Errorf("plesk: %v", err)
+		}
+	}
+
+	err := d.client.DeleteRecord(recordID)
+	if err != nil {
+		return fmt.Errorf("plesk: %v", err)
+	}
+
+	// deletes record ID from map
+	d.recordIDsMu.Lock()
+	delete(d.recordIDs, token)
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// findSite returns the ID and the name of the site (domain) matching the longest part of the FQDN.
+func (d *DNSProvider) findSite(fqdn string) (int, string, error) {
+	for _, index := range dns.Split(fqdn) {
+		name := dns01.UnFqdn(fqdn[index:])
+
+		siteID, err := d.client.GetSiteID(name)
+		if err != nil {
+			return 0, "", err
+		}
+
+		if siteID != 0 {
+			return siteID, name, nil
+		}
+	}
+
+	return 0, "", fmt.Errorf("no site found for %s", fqdn)
+}
+
+// findRecordID returns the ID of the TXT record matching the FQDN and the value.
+func (d *DNSProvider) findRecordID(fqdn, value string) (int, error) {
+	siteID, _, err := d.findSite(fqdn)
+	if err != nil {
+		return 0, err
+	}
+
+	records, err := d.client.GetRecords(siteID)
+	if err != nil {
+		return 0, err
+	}
+
+	for id, record := range records {
+		if record.Type == "TXT" && strings.EqualFold(dns01.ToFqdn(record.Host), fqdn) && record.Value == value {
+			return id, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no TXT record found for %s", fqdn)
+}
+
+func extractRecordName(fqdn, zone string) string {
+	name := dns01.UnFqdn(fqdn)
+	if idx := strings.LastIndex(name, "."+zone); idx != -1 {
+		return name[:idx]
+	}
+	return name
+}
@@ -0,0 +1,237 @@
+package plesk
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest(
+	"PLESK_SERVER_BASE_URL",
+	"PLESK_USERNAME",
+	"PLESK_PASSWORD").
+	WithDomain("PLESK_DOMAIN")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"PLESK_SERVER_BASE_URL": "https://plesk.example.com:8443",
+				"PLESK_USERNAME":        "user",
+				"PLESK_PASSWORD":        "secret",
+			},
+		},
+		{
+			desc: "missing server base URL",
+			envVars: map[string]string{
+				"PLESK_SERVER_BASE_URL": "",
+				"PLESK_USERNAME":        "user",
+				"PLESK_PASSWORD":        "secret",
+			},
+			expected: "plesk: some credentials information are missing: PLESK_SERVER_BASE_URL",
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				"PLESK_SERVER_BASE_URL": "https://plesk.example.com:8443",
+				"PLESK_USERNAME":        "",
+				"PLESK_PASSWORD":        "",
+			},
+			expected: "plesk: some credentials information are missing: PLESK_USERNAME,PLESK_PASSWORD",
+		},
+		{
+			desc: "invalid server base URL",
+			envVars: map[string]string{
+				"PLESK_SERVER_BASE_URL": "plesk.example.com",
+				"PLESK_USERNAME":        "user",
+				"PLESK_PASSWORD":        "secret",
+			},
+			expected: "plesk: invalid server base URL: plesk.example.com",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		baseURL  string
+		username string
+		password string
+		expected string
+	}{
+		{
+			desc:     "success",
+			baseURL:  "https://plesk.example.com:8443",
+			username: "user",
+			password: "secret",
+		},
+		{
+			desc:     "missing server base URL",
+			username: "user",
+			password: "secret",
+			expected: "plesk: missing server base URL",
+		},
+		{
+			desc:     "missing credentials",
+			baseURL:  "https://plesk.example.com:8443",
+			expected: "plesk: credentials missing",
+		},
+		{
+			desc:     "missing password",
+			baseURL:  "https://plesk.example.com:8443",
+			username: "user",
+			expected: "plesk: credentials missing",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.BaseURL = test.baseURL
+			config.Username = test.username
+			config.Password = test.password
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func setupTest(handler func(rw http.ResponseWriter, body string)) (*DNSProvider, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		raw, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		body := string(raw)
+
+		switch {
+		case strings.Contains(body, "<site><get><filter><name>example.com</name>"):
+			fmt.Fprint(rw, `<packet><site><get><result><status>ok</status><id>12</id></result></get></site></packet>`)
+		case strings.Contains(body, "<site><get>"):
+			fmt.Fprint(rw, `<packet><site><get><result><status>error</status><errcode>1013</errcode><errtext>Site does not exist</errtext></result></get></site></packet>`)
+		default:
+			handler(rw, body)
+		}
+	}))
+
+	config := NewDefaultConfig()
+	config.BaseURL = server.URL
+	config.Username = "user"
+	config.Password = "secret"
+
+	p, err := NewDNSProviderConfig(config)
+	if err != nil {
+		panic(err)
+	}
+
+	return p, server.Close
+}
+
+func TestDNSProvider_Present(t *testing.T) {
+	provider, tearDown := setupTest(func(rw http.ResponseWriter, body string) {
+		if !strings.Contains(body, `<add_rec><site-id>12</site-id><type>TXT</type><host>_acme-challenge.sub</host><value>pW9ZKG0xz_PCriK-nCMOjADy9eJcgGWIzkkj2fN4uZM</value></add_rec>`) {
+			http.Error(rw, fmt.Sprintf("unexpected body: %s", body), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `<packet><dns><add_rec><result><status>ok</status><id>123</id></result></add_rec></dns></packet>`)
+	})
+	defer tearDown()
+
+	err := provider.Present("sub.example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Equal(t, 123, provider.recordIDs["token"])
+}
+
+func TestDNSProvider_CleanUp(t *testing.T) {
+	provider, tearDown := setupTest(func(rw http.ResponseWriter, body string) {
+		switch {
+		case strings.Contains(body, `<get_rec><filter><site-id>12</site-id></filter></get_rec>`):
+			fmt.Fprint(rw, `<packet><dns><get_rec>
+<result><status>ok</status><id>122</id><data><site-id>12</site-id><type>TXT</type><host>_acme-challenge.sub.example.com.</host><value>other</value></data></result>
+<result><status>ok</status><id>123</id><data><site-id>12</site-id><type>TXT</type><host>_acme-challenge.sub.example.com.</host><value>pW9ZKG0xz_PCriK-nCMOjADy9eJcgGWIzkkj2fN4uZM</value></data></result>
+</get_rec></dns></packet>`)
+		case strings.Contains(body, `<del_rec><filter><id>123</id></filter></del_rec>`):
+			fmt.Fprint(rw, `<packet><dns><del_rec><result><status>ok</status><id>123</id></result></del_rec></dns></packet>`)
+		default:
+			http.Error(rw, fmt.Sprintf("unexpected body: %s", body), http.StatusBadRequest)
+		}
+	})
+	defer tearDown()
+
+	err := provider.CleanUp("sub.example.com", "token", "keyAuth")
+	require.NoError(t, err)
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
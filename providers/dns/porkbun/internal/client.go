@@ -0,0 +1,139 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// DefaultBaseURL the default base URL of the Porkbun API.
+const DefaultBaseURL = "https://porkbun.com/api/json/v3"
+
+const statusSuccess = "SUCCESS"
+
+// Record a DNS record.
+type Record struct {
+	ID      string `json:"id,omitempty"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Content string `json:"content"`
+	TTL     string `json:"ttl,omitempty"`
+}
+
+type authRequest struct {
+	APIKey       string `json:"apikey"`
+	SecretAPIKey string `json:"secretapikey"`
+}
+
+type recordRequest struct {
+	authRequest
+	Record
+}
+
+type status struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+type createResponse struct {
+	status
+	ID json.Number `json:"id"`
+}
+
+type retrieveResponse struct {
+	status
+	Records []Record `json:"records"`
+}
+
+// Client the Porkbun API client.
+type Client struct {
+	apiKey       string
+	secretAPIKey string
+	BaseURL      string
+	HTTPClient   *http.Client
+}
+
+// NewClient creates a Porkbun API client.
+func NewClient(apiKey, secretAPIKey string) *Client {
+	return &Client{
+		apiKey:       apiKey,
+		secretAPIKey: secretAPIKey,
+		BaseURL:      DefaultBaseURL,
+		HTTPClient:   &http.Client{},
+	}
+}
+
+// CreateRecord creates a DNS record in the domain, the name of the record is relative to the domain.
+func (c *Client) CreateRecord(domain string, record Record) (string, error) {
+	resp := &createResponse{}
+	err := c.do("/dns/create/"+domain, recordRequest{authRequest: c.auth(), Record: record}, resp)
+	if err != nil {
+		return "", fmt.Errorf("unable to create record: %v", err)
+	}
+
+	return resp.ID.String(), nil
+}
+
+// RetrieveRecords returns the DNS records of the domain, the names of the records are fully qualified.
+func (c *Client) RetrieveRecords(domain string) ([]Record, error) {
+	resp := &retrieveResponse{}
+	err := c.do("/dns/retrieve/"+domain, c.auth(), resp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve records: %v", err)
+	}
+
+	return resp.Records, nil
+}
+
+// DeleteRecord deletes a DNS record of the domain.
+func (c *Client) DeleteRecord(domain, id string) error {
+	err := c.do("/dns/delete/"+domain+"/"+id, c.auth(), &status{})
+	if err != nil {
+		return fmt.Errorf("unable to delete record %s: %v", id, err)
+	}
+
+	return nil
+}
+
+func (c *Client) auth() authRequest {
+	return authRequest{APIKey: c.apiKey, SecretAPIKey: c.secretAPIKey}
+}
+
+// do sends a request, all the endpoints of the API use POST and the credentials are in the body.
+func (c *Client) do(uri string, payload, result interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.BaseURL+uri, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	st := &status{}
+	if err = json.Unmarshal(raw, st); err != nil {
+		return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	if resp.StatusCode != http.StatusOK || st.Status != statusSuccess {
+		return fmt.Errorf("%d: %s: %s", resp.StatusCode, st.Status, st.Message)
+	}
+
+	return json.Unmarshal(raw, result)
+}
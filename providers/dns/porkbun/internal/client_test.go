@@ -0,0 +1,100 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, path string, expected map[string]string, response string) (*Client, func()) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc(path, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		body := map[string]string{}
+		err := json.NewDecoder(req.Body).Decode(&body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if body["apikey"] != "key" || body["secretapikey"] != "secret" {
+			rw.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(rw, `{"status":"ERROR","message":"Invalid API key. (001)"}`)
+			return
+		}
+
+		for k, v := range expected {
+			if body[k] != v {
+				http.Error(rw, fmt.Sprintf("%s: got %q, want %q", k, body[k], v), http.StatusBadRequest)
+				return
+			}
+		}
+
+		fmt.Fprint(rw, response)
+	})
+
+	client := NewClient("key", "secret")
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+func TestClient_CreateRecord(t *testing.T) {
+	expected := map[string]string{
+		"name":    "_acme-challenge",
+		"type":    "TXT",
+		"content": "txtvalue",
+		"ttl":     "600",
+	}
+
+	client, tearDown := setupTest(t, "/dns/create/example.com", expected, `{"status":"SUCCESS","id":106926659}`)
+	defer tearDown()
+
+	id, err := client.CreateRecord("example.com", Record{Name: "_acme-challenge", Type: "TXT", Content: "txtvalue", TTL: "600"})
+	require.NoError(t, err)
+	assert.Equal(t, "106926659", id)
+}
+
+func TestClient_RetrieveRecords(t *testing.T) {
+	response := `{"status":"SUCCESS","records":[{"id":"106926652","name":"_acme-challenge.example.com","type":"TXT","content":"txtvalue","ttl":"600","prio":"0","notes":""}]}`
+
+	client, tearDown := setupTest(t, "/dns/retrieve/example.com", nil, response)
+	defer tearDown()
+
+	records, err := client.RetrieveRecords("example.com")
+	require.NoError(t, err)
+
+	expected := []Record{{ID: "106926652", Name: "_acme-challenge.example.com", Type: "TXT", Content: "txtvalue", TTL: "600"}}
+	assert.Equal(t, expected, records)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, tearDown := setupTest(t, "/dns/delete/example.com/106926652", nil, `{"status":"SUCCESS"}`)
+	defer tearDown()
+
+	err := client.DeleteRecord("example.com", "106926652")
+	require.NoError(t, err)
+}
+
+func TestClient_error(t *testing.T) {
+	client, tearDown := setupTest(t, "/dns/retrieve/example.com", nil, `{"status":"SUCCESS","records":[]}`)
+	defer tearDown()
+
+	client.secretAPIKey = "invalid"
+
+	_, err := client.RetrieveRecords("example.com")
+	require.EqualError(t, err, "unable to retrieve records: 400: ERROR: Invalid API key. (001)")
+}
@@ -0,0 +1,159 @@
+// Package porkbun implements a DNS provider for solving the DNS-01 challenge using Porkbun DNS.
+package porkbun
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/porkbun/internal"
+)
+
+// minTTL the minimal TTL allowed by Porkbun.
+const minTTL = 600
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL            string
+	APIKey             string
+	SecretAPIKey       string
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString("PORKBUN_ENDPOINT", internal.DefaultBaseURL),
+		TTL:                env.GetOrDefaultInt("PORKBUN_TTL", minTTL),
+		PropagationTimeout: env.GetOrDefaultSecond("PORKBUN_PROPAGATION_TIMEOUT", 10*time.Minute),
+		PollingInterval:    env.GetOrDefaultSecond("PORKBUN_POLLING_INTERVAL", 10*time.Second),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("PORKBUN_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses Porkbun's API to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Porkbun.
+// Credentials must be passed in the environment variables: PORKBUN_API_KEY and PORKBUN_SECRET_API_KEY.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("PORKBUN_API_KEY", "PORKBUN_SECRET_API_KEY")
+	if err != nil {
+		return nil, fmt.Errorf("porkbun: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIKey = values["PORKBUN_API_KEY"]
+	config.SecretAPIKey = values["PORKBUN_SECRET_API_KEY"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Porkbun.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("porkbun: the configuration of the DNS provider is nil")
+	}
+
+	if config.APIKey == "" || config.SecretAPIKey == "" {
+		return nil, errors.New("porkbun: some credentials information are missing")
+	}
+
+	if config.TTL < minTTL {
+		return nil, fmt.Errorf("porkbun: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	}
+
+	client := internal.NewClient(config.APIKey, config.SecretAPIKey)
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return fmt.Errorf("porkbun: could not find zone for domain %q: %v", domain, err)
+	}
+
+	record := internal.Record{
+		Name:    extractRecordName(fqdn, authZone),
+		Type:    "TXT",
+		Content: value,
+		TTL:     strconv.Itoa(d.config.TTL),
+	}
+
+	_, err = d.client.CreateRecord(dns01.UnFqdn(authZone), record)
+	if err != nil {
+		return fmt.Errorf("porkbun: %v", err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return fmt.Errorf("porkbun: could not find zone for domain %q: %v", domain, err)
+	}
+
+	zone := dns01.UnFqdn(authZone)
+
+	records, err := d.client.RetrieveRecords(zone)
+	if err != nil {
+		return fmt.Errorf("porkbun: %v", err)
+	}
+
+	for _, record := range records {
+		if record.Type == "TXT" && record.Name == dns01.UnFqdn(fqdn) && record.Content == value {
+			err = d.client.DeleteRecord(zone, record.ID)
+			if err != nil {
+				return fmt.Errorf("porkbun: %v", err)
+			}
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("porkbun: TXT record not found for %s", fqdn)
+}
+
+func extractRecordName(fqdn, authZone string) string {
+	name := dns01.UnFqdn(fqdn)
+	if idx := strings.LastIndex(name, "."+dns01.UnFqdn(authZone)); idx != -1 {
+		return name[:idx]
+	}
+	return name
+}
@@ -0,0 +1,117 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// DefaultBaseURL the default base URL of the RcodeZero API.
+const DefaultBaseURL = "https://my.rcodezero.at/api/v1"
+
+// The change types of the RRSets.
+const (
+	ChangeTypeUpdate = "update"
+	ChangeTypeDelete = "delete"
+)
+
+// RRSet a resource record set, the name is the FQDN (i.e. "_acme-challenge.example.com.").
+type RRSet struct {
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	TTL        int      `json:"ttl,omitempty"`
+	ChangeType string   `json:"changetype"`
+	Records    []Record `json:"records,omitempty"`
+}
+
+// Record a record of a RRSet, the content of the TXT records must be quoted.
+type Record struct {
+	Content  string `json:"content"`
+	Disabled bool   `json:"disabled"`
+}
+
+// APIResponse the response of the API.
+type APIResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// Client the RcodeZero API client.
+type Client struct {
+	token      string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a RcodeZero API client.
+func NewClient(token string) *Client {
+	return &Client{
+		token:      token,
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// UpdateRRSets applies the changes to the RRSets of a zone.
+func (c *Client) UpdateRRSets(zone string, sets []RRSet) error {
+	resp := &APIResponse{}
+	err := c.do(http.MethodPatch, fmt.Sprintf("/zones/%s/rrsets", zone), sets, resp)
+	if err != nil {
+		return fmt.Errorf("unable to update the RRSets of the zone %s: %v", zone, err)
+	}
+
+	if resp.Status != "ok" {
+		return fmt.Errorf("unable to update the RRSets of the zone %s: %s: %s", zone, resp.Status, resp.Message)
+	}
+
+	return nil
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body bytes.Buffer
+	if payload != nil {
+		err := json.NewEncoder(&body).Encode(payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+uri, &body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &APIResponse{}
+		if json.Unmarshal(raw, apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("%d: %s", resp.StatusCode, apiErr.Message)
+		}
+
+		return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
@@ -0,0 +1,133 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest() (*Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client := NewClient("secret")
+	client.BaseURL = server.URL
+
+	return client, mux, server.Close
+}
+
+func checkAuth(rw http.ResponseWriter, req *http.Request) bool {
+	if req.Header.Get("Authorization") != "Bearer secret" {
+		rw.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(rw, `{"status":"failed","message":"Unauthenticated."}`)
+		return false
+	}
+	return true
+}
+
+func TestClient_UpdateRRSets(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/zones/example.com/rrsets", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPatch {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		var sets []RRSet
+		err := json.NewDecoder(req.Body).Decode(&sets)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := []RRSet{{
+			Name:       "_acme-challenge.example.com.",
+			Type:       "TXT",
+			TTL:        120,
+			ChangeType: ChangeTypeUpdate,
+			Records:    []Record{{Content: `"value"`}},
+		}}
+		if !reflect.DeepEqual(sets, expected) {
+			http.Error(rw, fmt.Sprintf("unexpected RRSets: %+v", sets), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `{"status":"ok","message":"RRsets updated"}`)
+	})
+
+	sets := []RRSet{{
+		Name:       "_acme-challenge.example.com.",
+		Type:       "TXT",
+		TTL:        120,
+		ChangeType: ChangeTypeUpdate,
+		Records:    []Record{{Content: `"value"`}},
+	}}
+
+	err := client.UpdateRRSets("example.com", sets)
+	require.NoError(t, err)
+}
+
+func TestClient_UpdateRRSets_delete(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/zones/example.com/rrsets", func(rw http.ResponseWriter, req *http.Request) {
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		var sets []RRSet
+		err := json.NewDecoder(req.Body).Decode(&sets)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if len(sets) != 1 || sets[0].ChangeType != ChangeTypeDelete || len(sets[0].Records) != 0 {
+			http.Error(rw, fmt.Sprintf("unexpected RRSets: %+v", sets), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `{"status":"ok","message":"RRsets updated"}`)
+	})
+
+	err := client.UpdateRRSets("example.com", []RRSet{{Name: "_acme-challenge.example.com.", Type: "TXT", ChangeType: ChangeTypeDelete}})
+	require.NoError(t, err)
+}
+
+func TestClient_UpdateRRSets_error(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	client.token = "invalid"
+
+	mux.HandleFunc("/zones/example.com/rrsets", func(rw http.ResponseWriter, req *http.Request) {
+		checkAuth(rw, req)
+	})
+
+	err := client.UpdateRRSets("example.com", []RRSet{{Name: "_acme-challenge.example.com.", Type: "TXT", ChangeType: ChangeTypeDelete}})
+	require.EqualError(t, err, "unable to update the RRSets of the zone example.com: 401: Unauthenticated.")
+}
+
+func TestClient_UpdateRRSets_failed(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/zones/example.com/rrsets", func(rw http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(rw, `{"status":"failed","message":"Zone not found"}`)
+	})
+
+	err := client.UpdateRRSets("example.com", []RRSet{{Name: "_acme-challenge.example.com.", Type: "TXT", ChangeType: ChangeTypeDelete}})
+	require.EqualError(t, err, "unable to update the RRSets of the zone example.com: failed: Zone not found")
+}
@@ -0,0 +1,175 @@
+// Package rcodezero implements a DNS provider for solving the DNS-01 challenge using RcodeZero Anycast network.
+package rcodezero
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/rcodezero/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL            string
+	APIToken           string
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL: env.GetOrDefaultString("RCODEZERO_ENDPOINT", internal.DefaultBaseURL),
+		TTL:     env.GetOrDefaultInt("RCODEZERO_TTL", dns01.DefaultTTL),
+		// the changes are synchronized to the anycast nameservers with a delay.
+		PropagationTimeout: env.GetOrDefaultSecond("RCODEZERO_PROPAGATION_TIMEOUT", 240*time.Second),
+		PollingInterval:    env.GetOrDefaultSecond("RCODEZERO_POLLING_INTERVAL", 10*time.Second),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("RCODEZERO_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses the RcodeZero API to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	// values contains the TXT values presented for each FQDN:
+	// the RRSet is replaced as a whole, so it must contain all the values of the FQDN.
+	values   map[string][]string
+	valuesMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for RcodeZero.
+// Credentials must be passed in the environment variable: RCODEZERO_API_TOKEN.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("RCODEZERO_API_TOKEN")
+	if err != nil {
+		return nil, fmt.Errorf("rcodezero: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIToken = values["RCODEZERO_API_TOKEN"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for RcodeZero.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("rcodezero: the configuration of the DNS provider is nil")
+	}
+
+	if config.APIToken == "" {
+		return nil, errors.New("rcodezero: credentials missing")
+	}
+
+	client := internal.NewClient(config.APIToken)
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{
+		config: config,
+		client: client,
+		values: make(map[string][]string),
+	}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return fmt.Errorf("rcodezero: could not find zone for FQDN %q: %v", fqdn, err)
+	}
+
+	d.valuesMu.Lock()
+	defer d.valuesMu.Unlock()
+
+	values := append(d.values[fqdn], value)
+
+	err = d.client.UpdateRRSets(dns01.UnFqdn(authZone), []internal.RRSet{d.newRRSet(fqdn, values)})
+	if err != nil {
+		return fmt.Errorf("rcodezero: %v", err)
+	}
+
+	d.values[fqdn] = values
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return fmt.Errorf("rcodezero: could not find zone for FQDN %q: %v", fqdn, err)
+	}
+
+	d.valuesMu.Lock()
+	defer d.valuesMu.Unlock()
+
+	var values []string
+	for _, v := range d.values[fqdn] {
+		if v != value {
+			values = append(values, v)
+		}
+	}
+
+	rrSet := internal.RRSet{Name: fqdn, Type: "TXT", ChangeType: internal.ChangeTypeDelete}
+	if len(values) > 0 {
+		rrSet = d.newRRSet(fqdn, values)
+	}
+
+	err = d.client.UpdateRRSets(dns01.UnFqdn(authZone), []internal.RRSet{rrSet})
+	if err != nil {
+		return fmt.Errorf("rcodezero: %v", err)
+	}
+
+	if len(values) > 0 {
+		d.values[fqdn] = values
+	} else {
+		delete(d.values, fqdn)
+	}
+
+	return nil
+}
+
+func (d *DNSProvider) newRRSet(fqdn string, values []string) internal.RRSet {
+	rrSet := internal.RRSet{
+		Name:       fqdn,
+		Type:       "TXT",
+		TTL:        d.config.TTL,
+		ChangeType: internal.ChangeTypeUpdate,
+	}
+
+	for _, value := range values {
+		rrSet.Records = append(rrSet.Records, internal.Record{Content: strconv.Quote(value)})
+	}
+
+	return rrSet
+}
@@ -0,0 +1,81 @@
+package dns
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/go-acme/lego/v3/challenge"
+)
+
+// ProviderFactory creates a DNS provider, usually from the environment variables.
+type ProviderFactory func() (challenge.Provider, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]ProviderFactory)
+)
+
+// RegisterProvider makes a third-party DNS provider available by name (i.e. `lego --dns name`).
+// It's intended to be called from an init function of the package of the provider.
+//
+//	func init() {
+//		err := dns.RegisterProvider("mycustom", func() (challenge.Provider, error) {
+//			return mycustom.NewDNSProvider()
+//		})
+//		if err != nil {
+//			panic(err)
+//		}
+//	}
+//
+// It returns an error if the name is empty or used by a built-in provider, if the factory is nil,
+// or if a provider is already registered with the same name.
+func RegisterProvider(name string, factory ProviderFactory) error {
+	if name == "" {
+		return errors.New("dns: RegisterProvider with an empty name")
+	}
+
+	if factory == nil {
+		return fmt.Errorf("dns: RegisterProvider %s with a nil factory", name)
+	}
+
+	for _, builtin := range builtinProviders {
+		if name == builtin {
+			return fmt.Errorf("dns: RegisterProvider %s: the name is used by a built-in provider", name)
+		}
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, dup := registry[name]; dup {
+		return fmt.Errorf("dns: RegisterProvider called twice for provider %s", name)
+	}
+
+	registry[name] = factory
+
+	return nil
+}
+
+// RegisteredProviders returns the sorted names of the registered third-party DNS providers.
+func RegisteredProviders() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	var names []string
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func getRegisteredProvider(name string) (ProviderFactory, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	factory, ok := registry[name]
+	return factory, ok
+}
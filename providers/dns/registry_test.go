@@ -0,0 +1,124 @@
+package dns
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/go-acme/lego/v3/challenge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterProvider(t *testing.T) {
+	defer unregisterProvider("mycustom")
+
+	custom := &fakeProvider{name: "mycustom"}
+
+	err := RegisterProvider("mycustom", func() (challenge.Provider, error) {
+		return custom, nil
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, RegisteredProviders(), "mycustom")
+
+	provider, err := NewDNSChallengeProviderByName("mycustom")
+	require.NoError(t, err)
+	assert.Same(t, custom, provider)
+}
+
+func TestRegisterProvider_errors(t *testing.T) {
+	defer unregisterProvider("mycustom")
+
+	err := RegisterProvider("mycustom", func() (challenge.Provider, error) { return nil, nil })
+	require.NoError(t, err)
+
+	factory := func() (challenge.Provider, error) { return &fakeProvider{}, nil }
+
+	testCases := []struct {
+		desc     string
+		name     string
+		factory  ProviderFactory
+		expected string
+	}{
+		{
+			desc:     "empty name",
+			factory:  factory,
+			expected: "dns: RegisterProvider with an empty name",
+		},
+		{
+			desc:     "nil factory",
+			name:     "foo",
+			expected: "dns: RegisterProvider foo with a nil factory",
+		},
+		{
+			desc:     "built-in provider",
+			name:     "exec",
+			factory:  factory,
+			expected: "dns: RegisterProvider exec: the name is used by a built-in provider",
+		},
+		{
+			desc:     "duplicate",
+			name:     "mycustom",
+			factory:  factory,
+			expected: "dns: RegisterProvider called twice for provider mycustom",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			err := RegisterProvider(test.name, test.factory)
+			require.EqualError(t, err, test.expected)
+		})
+	}
+
+	assert.NotContains(t, RegisteredProviders(), "exec")
+}
+
+// Test_builtinProviders checks that builtinProviders contains the names handled by the switch of NewDNSChallengeProviderByName.
+func Test_builtinProviders(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "dns_providers.go", nil, 0)
+	require.NoError(t, err)
+
+	var names []string
+	ast.Inspect(file, func(node ast.Node) bool {
+		fn, ok := node.(*ast.FuncDecl)
+		if ok && fn.Name.Name != "NewDNSChallengeProviderByName" {
+			return false
+		}
+
+		clause, ok := node.(*ast.CaseClause)
+		if !ok {
+			return true
+		}
+
+		for _, expr := range clause.List {
+			lit, ok := expr.(*ast.BasicLit)
+			require.True(t, ok)
+
+			name, err := strconv.Unquote(lit.Value)
+			require.NoError(t, err)
+
+			names = append(names, name)
+		}
+
+		return false
+	})
+
+	sort.Strings(names)
+
+	expected := append([]string{}, builtinProviders...)
+	sort.Strings(expected)
+
+	assert.Equal(t, expected, names)
+}
+
+func unregisterProvider(name string) {
+	registryMu.Lock()
+	delete(registry, name)
+	registryMu.Unlock()
+}
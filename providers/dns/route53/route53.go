@@ -10,6 +10,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/route53"
@@ -25,6 +26,22 @@ type Config struct {
 	PropagationTimeout time.Duration
 	PollingInterval    time.Duration
 	HostedZoneID       string
+	// PrivateZone targets the private hosted zones instead of the public hosted zones.
+	PrivateZone bool
+
+	// Profile the name of the profile of the shared configuration (~/.aws/config and ~/.aws/credentials).
+	Profile string
+	// Endpoint a custom endpoint of the Route 53 API.
+	Endpoint string
+
+	// AssumeRoleArn the ARN of a role to assume with the base credentials (i.e. a role of another account).
+	AssumeRoleArn string
+	// ExternalID the external ID required by the trust policy of the role.
+	ExternalID string
+	// SessionName the name of the session of the assumed role.
+	SessionName string
+	// STSEndpoint a custom endpoint of the STS API, used to assume the role.
+	STSEndpoint string
 }
 
 // NewDefaultConfig returns a default configuration for the DNSProvider
@@ -35,6 +52,13 @@ func NewDefaultConfig() *Config {
 		PropagationTimeout: env.GetOrDefaultSecond("AWS_PROPAGATION_TIMEOUT", 2*time.Minute),
 		PollingInterval:    env.GetOrDefaultSecond("AWS_POLLING_INTERVAL", 4*time.Second),
 		HostedZoneID:       env.GetOrFile("AWS_HOSTED_ZONE_ID"),
+		PrivateZone:        env.GetOrDefaultBool("AWS_PRIVATE_ZONE", false),
+		Profile:            env.GetOrFile("AWS_PROFILE"),
+		Endpoint:           env.GetOrFile("AWS_ROUTE53_ENDPOINT"),
+		AssumeRoleArn:      env.GetOrFile("AWS_ASSUME_ROLE_ARN"),
+		ExternalID:         env.GetOrFile("AWS_EXTERNAL_ID"),
+		SessionName:        env.GetOrFile("AWS_ASSUME_ROLE_SESSION_NAME"),
+		STSEndpoint:        env.GetOrFile("AWS_STS_ENDPOINT"),
 	}
 }
 
@@ -70,10 +94,14 @@ func (d customRetryer) RetryRules(r *request.Request) time.Duration {
 // AWS Credentials are automatically detected in the following locations and prioritized in the following order:
 // 1. Environment variables: AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY,
 //    AWS_REGION, [AWS_SESSION_TOKEN]
-// 2. Shared credentials file (defaults to ~/.aws/credentials)
+// 2. Shared credentials file (defaults to ~/.aws/credentials), and shared configuration file (defaults to ~/.aws/config)
+//    with the profile AWS_PROFILE
 // 3. Amazon EC2 IAM role
 //
-// If AWS_HOSTED_ZONE_ID is not set, Lego tries to determine the correct public hosted zone via the FQDN.
+// If AWS_ASSUME_ROLE_ARN is set, these credentials are used to assume the role (with the optional AWS_EXTERNAL_ID).
+//
+// If AWS_HOSTED_ZONE_ID is not set, Lego tries to determine the correct public hosted zone via the FQDN,
+// or the correct private hosted zone if AWS_PRIVATE_ZONE is true.
 //
 // See also: https://github.com/aws/aws-sdk-go/wiki/configuring-sdk
 func NewDNSProvider() (*DNSProvider, error) {
@@ -86,17 +114,56 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("route53: the configuration of the Route53 DNS provider is nil")
 	}
 
+	sess, err := createSession(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfgs []*aws.Config
+	if config.Endpoint != "" {
+		cfgs = append(cfgs, &aws.Config{Endpoint: aws.String(config.Endpoint)})
+	}
+
+	cl := route53.New(sess, cfgs...)
+	return &DNSProvider{client: cl, config: config}, nil
+}
+
+// createSession creates a session with the shared configuration enabled,
+// the credentials of the session are the credentials of the assumed role if a role is defined.
+func createSession(config *Config) (*session.Session, error) {
 	retry := customRetryer{}
 	retry.NumMaxRetries = config.MaxRetries
 	sessionCfg := request.WithRetryer(aws.NewConfig(), retry)
 
-	sess, err := session.NewSessionWithOptions(session.Options{Config: *sessionCfg})
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *sessionCfg,
+		Profile:           config.Profile,
+		SharedConfigState: session.SharedConfigEnable,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	cl := route53.New(sess)
-	return &DNSProvider{client: cl, config: config}, nil
+	if config.AssumeRoleArn == "" {
+		return sess, nil
+	}
+
+	stsSess := sess
+	if config.STSEndpoint != "" {
+		stsSess = sess.Copy(&aws.Config{Endpoint: aws.String(config.STSEndpoint)})
+	}
+
+	creds := stscreds.NewCredentials(stsSess, config.AssumeRoleArn, func(p *stscreds.AssumeRoleProvider) {
+		if config.ExternalID != "" {
+			p.ExternalID = aws.String(config.ExternalID)
+		}
+
+		if config.SessionName != "" {
+			p.RoleSessionName = config.SessionName
+		}
+	})
+
+	return sess.Copy(&aws.Config{Credentials: creds}), nil
 }
 
 // Timeout returns the timeout and interval to use when checking for DNS
@@ -241,7 +308,7 @@ func (d *DNSProvider) getExistingRecordSets(hostedZoneID string, fqdn string) ([
 
 func (d *DNSProvider) getHostedZoneID(fqdn string) (string, error) {
 	if d.config.HostedZoneID != "" {
-		return d.config.HostedZoneID, nil
+		return strings.TrimPrefix(d.config.HostedZoneID, "/hostedzone/"), nil
 	}
 
 	authZone, err := dns01.FindZoneByFqdn(fqdn)
@@ -258,22 +325,32 @@ func (d *DNSProvider) getHostedZoneID(fqdn string) (string, error) {
 		return "", err
 	}
 
-	var hostedZoneID string
-	for _, hostedZone := range resp.HostedZones {
-		// .Name has a trailing dot
-		if !aws.BoolValue(hostedZone.Config.PrivateZone) && aws.StringValue(hostedZone.Name) == authZone {
-			hostedZoneID = aws.StringValue(hostedZone.Id)
-			break
-		}
-	}
+	hostedZoneID := findHostedZoneID(resp.HostedZones, authZone, d.config.PrivateZone)
 
 	if len(hostedZoneID) == 0 {
+		if d.config.PrivateZone {
+			return "", fmt.Errorf("private zone %s not found for domain %s", authZone, fqdn)
+		}
 		return "", fmt.Errorf("zone %s not found for domain %s", authZone, fqdn)
 	}
 
-	if strings.HasPrefix(hostedZoneID, "/hostedzone/") {
-		hostedZoneID = strings.TrimPrefix(hostedZoneID, "/hostedzone/")
+	return strings.TrimPrefix(hostedZoneID, "/hostedzone/"), nil
+}
+
+// findHostedZoneID returns the ID of the hosted zone matching the name and the visibility (private or public):
+// a private and a public hosted zones can have the same name.
+func findHostedZoneID(hostedZones []*route53.HostedZone, authZone string, private bool) string {
+	for _, hostedZone := range hostedZones {
+		// .Name has a trailing dot
+		if aws.StringValue(hostedZone.Name) != authZone {
+			continue
+		}
+
+		isPrivate := hostedZone.Config != nil && aws.BoolValue(hostedZone.Config.PrivateZone)
+		if isPrivate == private {
+			return aws.StringValue(hostedZone.Id)
+		}
 	}
 
-	return hostedZoneID, nil
+	return ""
 }
@@ -1,6 +1,8 @@
 package route53
 
 import (
+	"fmt"
+	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
@@ -20,10 +22,17 @@ var envTest = tester.NewEnvTest(
 	"AWS_SECRET_ACCESS_KEY",
 	"AWS_REGION",
 	"AWS_HOSTED_ZONE_ID",
+	"AWS_PRIVATE_ZONE",
 	"AWS_MAX_RETRIES",
 	"AWS_TTL",
 	"AWS_PROPAGATION_TIMEOUT",
-	"AWS_POLLING_INTERVAL").
+	"AWS_POLLING_INTERVAL",
+	"AWS_PROFILE",
+	"AWS_ROUTE53_ENDPOINT",
+	"AWS_ASSUME_ROLE_ARN",
+	"AWS_EXTERNAL_ID",
+	"AWS_ASSUME_ROLE_SESSION_NAME",
+	"AWS_STS_ENDPOINT").
 	WithDomain("R53_DOMAIN").
 	WithLiveTestRequirements("AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_REGION", "R53_DOMAIN")
 
@@ -101,6 +110,63 @@ func Test_getHostedZoneID_FromEnv(t *testing.T) {
 	assert.Equal(t, expectedZoneID, hostedZoneID)
 }
 
+func Test_getHostedZoneID_FromEnv_withPrefix(t *testing.T) {
+	defer envTest.RestoreEnv()
+	envTest.ClearEnv()
+
+	os.Setenv("AWS_HOSTED_ZONE_ID", "/hostedzone/zoneID")
+
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	hostedZoneID, err := provider.getHostedZoneID("whatever")
+	require.NoError(t, err, "HostedZoneID")
+
+	assert.Equal(t, "zoneID", hostedZoneID)
+}
+
+func Test_findHostedZoneID(t *testing.T) {
+	hostedZones := []*route53.HostedZone{
+		{Id: aws.String("/hostedzone/OTHER"), Name: aws.String("example.org."), Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(false)}},
+		{Id: aws.String("/hostedzone/PRIVATE"), Name: aws.String("example.com."), Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(true)}},
+		{Id: aws.String("/hostedzone/PUBLIC"), Name: aws.String("example.com."), Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(false)}},
+	}
+
+	testCases := []struct {
+		desc     string
+		authZone string
+		private  bool
+		expected string
+	}{
+		{
+			desc:     "public zone",
+			authZone: "example.com.",
+			expected: "/hostedzone/PUBLIC",
+		},
+		{
+			desc:     "private zone",
+			authZone: "example.com.",
+			private:  true,
+			expected: "/hostedzone/PRIVATE",
+		},
+		{
+			desc:     "no private zone",
+			authZone: "example.org.",
+			private:  true,
+		},
+		{
+			desc:     "unknown zone",
+			authZone: "example.net.",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, findHostedZoneID(hostedZones, test.authZone, test.private))
+		})
+	}
+}
+
 func TestNewDefaultConfig(t *testing.T) {
 	defer envTest.RestoreEnv()
 
@@ -126,6 +192,7 @@ func TestNewDefaultConfig(t *testing.T) {
 				"AWS_PROPAGATION_TIMEOUT": "60",
 				"AWS_POLLING_INTERVAL":    "60",
 				"AWS_HOSTED_ZONE_ID":      "abc123",
+				"AWS_PRIVATE_ZONE":        "true",
 			},
 			expected: &Config{
 				MaxRetries:         10,
@@ -133,6 +200,30 @@ func TestNewDefaultConfig(t *testing.T) {
 				PropagationTimeout: 60 * time.Second,
 				PollingInterval:    60 * time.Second,
 				HostedZoneID:       "abc123",
+				PrivateZone:        true,
+			},
+		},
+		{
+			desc: "assume role",
+			envVars: map[string]string{
+				"AWS_PROFILE":                  "dns",
+				"AWS_ROUTE53_ENDPOINT":         "https://route53.example.com",
+				"AWS_ASSUME_ROLE_ARN":          "arn:aws:iam::123456789012:role/lego",
+				"AWS_EXTERNAL_ID":              "external",
+				"AWS_ASSUME_ROLE_SESSION_NAME": "session",
+				"AWS_STS_ENDPOINT":             "https://sts.example.com",
+			},
+			expected: &Config{
+				MaxRetries:         5,
+				TTL:                10,
+				PropagationTimeout: 2 * time.Minute,
+				PollingInterval:    4 * time.Second,
+				Profile:            "dns",
+				Endpoint:           "https://route53.example.com",
+				AssumeRoleArn:      "arn:aws:iam::123456789012:role/lego",
+				ExternalID:         "external",
+				SessionName:        "session",
+				STSEndpoint:        "https://sts.example.com",
 			},
 		},
 	}
@@ -175,3 +266,41 @@ func TestDNSProvider_Present(t *testing.T) {
 	err := provider.Present(domain, "", keyAuth)
 	require.NoError(t, err, "Expected Present to return no error")
 }
+
+func Test_createSession_assumeRole(t *testing.T) {
+	defer envTest.RestoreEnv()
+	envTest.ClearEnv()
+
+	os.Setenv("AWS_ACCESS_KEY_ID", "123")
+	os.Setenv("AWS_SECRET_ACCESS_KEY", "456")
+	os.Setenv("AWS_REGION", "us-east-1")
+
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.PostFormValue("Action") != "AssumeRole" ||
+			req.PostFormValue("RoleArn") != "arn:aws:iam::123456789012:role/lego" ||
+			req.PostFormValue("ExternalId") != "external" ||
+			req.PostFormValue("RoleSessionName") != "session" {
+			http.Error(rw, fmt.Sprintf("unexpected request: %v", req.PostForm), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, AssumeRoleResponse)
+	}))
+	defer ts.Close()
+
+	config := NewDefaultConfig()
+	config.AssumeRoleArn = "arn:aws:iam::123456789012:role/lego"
+	config.ExternalID = "external"
+	config.SessionName = "session"
+	config.STSEndpoint = ts.URL
+
+	sess, err := createSession(config)
+	require.NoError(t, err)
+
+	value, err := sess.Config.Credentials.Get()
+	require.NoError(t, err)
+
+	assert.Equal(t, "ASIAEXAMPLE", value.AccessKeyID)
+	assert.Equal(t, "secret", value.SecretAccessKey)
+	assert.Equal(t, "token", value.SessionToken)
+}
@@ -0,0 +1,144 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// DefaultBaseURL the default base URL of the SafeDNS API.
+const DefaultBaseURL = "https://api.ukfast.io/safedns/v1"
+
+// Zone a DNS zone.
+type Zone struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Record a DNS record, the name is the full name of the record (i.e. "_acme-challenge.example.com").
+type Record struct {
+	ID      int    `json:"id,omitempty"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Content string `json:"content"`
+	TTL     int    `json:"ttl,omitempty"`
+}
+
+type zoneResponse struct {
+	Data Zone `json:"data"`
+}
+
+type recordResponse struct {
+	Data Record `json:"data"`
+}
+
+type apiError struct {
+	Message string `json:"message"`
+	Errors  []struct {
+		Title  string `json:"title"`
+		Detail string `json:"detail"`
+		Status int    `json:"status"`
+	} `json:"errors"`
+}
+
+// Client the SafeDNS API client.
+type Client struct {
+	authToken  string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a SafeDNS API client.
+func NewClient(authToken string) *Client {
+	return &Client{
+		authToken:  authToken,
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// GetZone returns a zone.
+func (c *Client) GetZone(name string) (*Zone, error) {
+	resp := &zoneResponse{}
+	err := c.do(http.MethodGet, "/zones/"+name, nil, resp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the zone %s: %v", name, err)
+	}
+
+	return &resp.Data, nil
+}
+
+// AddRecord adds a record to a zone.
+func (c *Client) AddRecord(zone string, record Record) (*Record, error) {
+	resp := &recordResponse{}
+	err := c.do(http.MethodPost, fmt.Sprintf("/zones/%s/records", zone), record, resp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to add the record %s to the zone %s: %v", record.Name, zone, err)
+	}
+
+	return &resp.Data, nil
+}
+
+// RemoveRecord removes a record of a zone.
+func (c *Client) RemoveRecord(zone string, recordID int) error {
+	err := c.do(http.MethodDelete, fmt.Sprintf("/zones/%s/records/%d", zone, recordID), nil, nil)
+	if err != nil {
+		return fmt.Errorf("unable to remove the record %d of the zone %s: %v", recordID, zone, err)
+	}
+
+	return nil
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body bytes.Buffer
+	if payload != nil {
+		err := json.NewEncoder(&body).Encode(payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+uri, &body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", c.authToken)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &apiError{}
+		if json.Unmarshal(raw, apiErr) == nil {
+			if len(apiErr.Errors) > 0 {
+				return fmt.Errorf("%d: %s: %s", resp.StatusCode, apiErr.Errors[0].Title, apiErr.Errors[0].Detail)
+			}
+			if apiErr.Message != "" {
+				return fmt.Errorf("%d: %s", resp.StatusCode, apiErr.Message)
+			}
+		}
+
+		return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
@@ -0,0 +1,122 @@
+package internal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest() (*Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client := NewClient("secret")
+	client.BaseURL = server.URL
+
+	return client, mux, server.Close
+}
+
+func checkAuth(rw http.ResponseWriter, req *http.Request) bool {
+	if req.Header.Get("Authorization") != "secret" {
+		rw.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(rw, `{"errors":[{"title":"Unauthorised","detail":"Unauthorised","status":401}]}`)
+		return false
+	}
+	return true
+}
+
+func TestClient_GetZone(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/zones/example.com", func(rw http.ResponseWriter, req *http.Request) {
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		fmt.Fprint(rw, `{"data":{"name":"example.com","description":"test"},"meta":{"location":""}}`)
+	})
+
+	zone, err := client.GetZone("example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, &Zone{Name: "example.com", Description: "test"}, zone)
+}
+
+func TestClient_GetZone_error(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	client.authToken = "invalid"
+
+	mux.HandleFunc("/zones/example.com", func(rw http.ResponseWriter, req *http.Request) {
+		checkAuth(rw, req)
+	})
+
+	_, err := client.GetZone("example.com")
+	require.EqualError(t, err, "unable to get the zone example.com: 401: Unauthorised: Unauthorised")
+}
+
+func TestClient_AddRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/zones/example.com/records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := `{"name":"_acme-challenge.example.com","type":"TXT","content":"\"value\"","ttl":120}` + "\n"
+		if string(body) != expected {
+			http.Error(rw, fmt.Sprintf("unexpected body: %s", string(body)), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		fmt.Fprint(rw, `{"data":{"id":1234567},"meta":{"location":"https://api.ukfast.io/safedns/v1/zones/example.com/records/1234567"}}`)
+	})
+
+	record := Record{Name: "_acme-challenge.example.com", Type: "TXT", Content: `"value"`, TTL: 120}
+
+	newRecord, err := client.AddRecord("example.com", record)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1234567, newRecord.ID)
+}
+
+func TestClient_RemoveRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/zones/example.com/records/1234567", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	err := client.RemoveRecord("example.com", 1234567)
+	require.NoError(t, err)
+}
@@ -0,0 +1,168 @@
+// Package safedns implements a DNS provider for solving the DNS-01 challenge using UKFast SafeDNS.
+package safedns
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/safedns/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL            string
+	AuthToken          string
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString("SAFEDNS_ENDPOINT", internal.DefaultBaseURL),
+		TTL:                env.GetOrDefaultInt("SAFEDNS_TTL", dns01.DefaultTTL),
+		PropagationTimeout: env.GetOrDefaultSecond("SAFEDNS_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("SAFEDNS_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("SAFEDNS_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses the UKFast SafeDNS API to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	recordIDs   map[string]int
+	recordIDsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for UKFast SafeDNS.
+// Credentials must be passed in the environment variable: SAFEDNS_AUTH_TOKEN.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("SAFEDNS_AUTH_TOKEN")
+	if err != nil {
+		return nil, fmt.Errorf("safedns: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.AuthToken = values["SAFEDNS_AUTH_TOKEN"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for UKFast SafeDNS.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("safedns: the configuration of the DNS provider is nil")
+	}
+
+	if config.AuthToken == "" {
+		return nil, errors.New("safedns: credentials missing")
+	}
+
+	client := internal.NewClient(config.AuthToken)
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{
+		config:    config,
+		client:    client,
+		recordIDs: make(map[string]int),
+	}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("safedns: %v", err)
+	}
+
+	record := internal.Record{
+		Name:    dns01.UnFqdn(fqdn),
+		Type:    "TXT",
+		Content: strconv.Quote(value),
+		TTL:     d.config.TTL,
+	}
+
+	newRecord, err := d.client.AddRecord(zone, record)
+	if err != nil {
+		return fmt.Errorf("safedns: %v", err)
+	}
+
+	d.recordIDsMu.Lock()
+	d.recordIDs[token] = newRecord.ID
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("safedns: %v", err)
+	}
+
+	// gets the record's unique ID from when we created it
+	d.recordIDsMu.Lock()
+	recordID, ok := d.recordIDs[token]
+	d.recordIDsMu.Unlock()
+	if !ok {
+		return fmt.Errorf("safedns: unknown record ID for '%s'", fqdn)
+	}
+
+	err = d.client.RemoveRecord(zone, recordID)
+	if err != nil {
+		return fmt.Errorf("safedns: %v", err)
+	}
+
+	// deletes record ID from map
+	d.recordIDsMu.Lock()
+	delete(d.recordIDs, token)
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// findZone returns the SafeDNS zone matching the authoritative zone of the FQDN.
+func (d *DNSProvider) findZone(fqdn string) (string, error) {
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return "", fmt.Errorf("could not find zone for FQDN %q: %v", fqdn, err)
+	}
+
+	zone, err := d.client.GetZone(dns01.UnFqdn(authZone))
+	if err != nil {
+		return "", err
+	}
+
+	return zone.Name, nil
+}
@@ -0,0 +1,121 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// DefaultBaseURL the default base URL of the Scaleway Domains and DNS API.
+const DefaultBaseURL = "https://api.scaleway.com/domain/v2beta1"
+
+// Record a DNS record.
+type Record struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Data string `json:"data"`
+	TTL  uint32 `json:"ttl,omitempty"`
+}
+
+// IDFields identifies the records to delete.
+type IDFields struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Data string `json:"data,omitempty"`
+}
+
+// AddChange adds records.
+type AddChange struct {
+	Records []Record `json:"records"`
+}
+
+// DeleteChange deletes the records matching the fields.
+type DeleteChange struct {
+	IDFields IDFields `json:"id_fields"`
+}
+
+// RecordChange a change of a batch: only one of the fields must be defined.
+type RecordChange struct {
+	Add    *AddChange    `json:"add,omitempty"`
+	Delete *DeleteChange `json:"delete,omitempty"`
+}
+
+// UpdateRecordsRequest a batch of changes applied atomically to a zone.
+type UpdateRecordsRequest struct {
+	Changes          []RecordChange `json:"changes"`
+	ReturnAllRecords bool           `json:"return_all_records"`
+}
+
+type apiError struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+}
+
+// Client the Scaleway Domains and DNS API client.
+type Client struct {
+	token      string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a Scaleway Domains and DNS API client.
+func NewClient(token string) *Client {
+	return &Client{
+		token:      token,
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// UpdateRecords applies a batch of changes to the records of a zone: all the changes are applied or none.
+func (c *Client) UpdateRecords(zone string, changes ...RecordChange) error {
+	request := UpdateRecordsRequest{Changes: changes}
+
+	err := c.do(http.MethodPatch, fmt.Sprintf("/dns-zones/%s/records", zone), request)
+	if err != nil {
+		return fmt.Errorf("unable to update the records of the zone %s: %v", zone, err)
+	}
+
+	return nil
+}
+
+func (c *Client) do(method, uri string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+uri, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Auth-Token", c.token)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	apiErr := &apiError{}
+	if json.Unmarshal(raw, apiErr) == nil && apiErr.Message != "" {
+		return fmt.Errorf("%d: %s: %s", resp.StatusCode, apiErr.Type, apiErr.Message)
+	}
+
+	return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+}
@@ -0,0 +1,87 @@
+package internal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_UpdateRecords(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		change   RecordChange
+		expected string
+	}{
+		{
+			desc: "add",
+			change: RecordChange{
+				Add: &AddChange{Records: []Record{{Name: "_acme-challenge", Type: "TXT", Data: `"value"`, TTL: 60}}},
+			},
+			expected: `{"changes":[{"add":{"records":[{"name":"_acme-challenge","type":"TXT","data":"\"value\"","ttl":60}]}}],"return_all_records":false}`,
+		},
+		{
+			desc: "delete",
+			change: RecordChange{
+				Delete: &DeleteChange{IDFields: IDFields{Name: "_acme-challenge", Type: "TXT", Data: `"value"`}},
+			},
+			expected: `{"changes":[{"delete":{"id_fields":{"name":"_acme-challenge","type":"TXT","data":"\"value\""}}}],"return_all_records":false}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			mux := http.NewServeMux()
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mux.HandleFunc("/dns-zones/example.com/records", func(rw http.ResponseWriter, req *http.Request) {
+				if req.Method != http.MethodPatch {
+					http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+					return
+				}
+
+				if req.Header.Get("X-Auth-Token") != "secret" {
+					http.Error(rw, `{"message":"authentication is denied","type":"denied_authentication"}`, http.StatusUnauthorized)
+					return
+				}
+
+				body, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					http.Error(rw, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				if string(body) != test.expected {
+					http.Error(rw, fmt.Sprintf("unexpected body: %s", string(body)), http.StatusBadRequest)
+					return
+				}
+
+				fmt.Fprint(rw, `{"records":[]}`)
+			})
+
+			client := NewClient("secret")
+			client.BaseURL = server.URL
+
+			err := client.UpdateRecords("example.com", test.change)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestClient_UpdateRecords_error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(rw, `{"message":"authentication is denied","type":"denied_authentication"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("invalid")
+	client.BaseURL = server.URL
+
+	err := client.UpdateRecords("example.com", RecordChange{})
+	require.EqualError(t, err, "unable to update the records of the zone example.com: 401: denied_authentication: authentication is denied")
+}
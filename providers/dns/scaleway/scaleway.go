@@ -0,0 +1,158 @@
+// Package scaleway implements a DNS provider for solving the DNS-01 challenge using Scaleway Domains and DNS.
+package scaleway
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/scaleway/internal"
+)
+
+// minTTL the minimal TTL allowed by Scaleway.
+const minTTL = 60
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL            string
+	Token              string
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString("SCALEWAY_ENDPOINT", internal.DefaultBaseURL),
+		TTL:                env.GetOrDefaultInt("SCALEWAY_TTL", minTTL),
+		PropagationTimeout: env.GetOrDefaultSecond("SCALEWAY_PROPAGATION_TIMEOUT", 120*time.Second),
+		PollingInterval:    env.GetOrDefaultSecond("SCALEWAY_POLLING_INTERVAL", 10*time.Second),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("SCALEWAY_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses the Scaleway Domains and DNS API to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Scaleway.
+// Credentials must be passed in the environment variable: SCALEWAY_API_TOKEN.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("SCALEWAY_API_TOKEN")
+	if err != nil {
+		return nil, fmt.Errorf("scaleway: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.Token = values["SCALEWAY_API_TOKEN"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Scaleway.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("scaleway: the configuration of the DNS provider is nil")
+	}
+
+	if config.Token == "" {
+		return nil, errors.New("scaleway: credentials missing")
+	}
+
+	if config.TTL < minTTL {
+		return nil, fmt.Errorf("scaleway: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	}
+
+	client := internal.NewClient(config.Token)
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return fmt.Errorf("scaleway: could not find zone for domain %q: %v", domain, err)
+	}
+
+	change := internal.RecordChange{
+		Add: &internal.AddChange{
+			Records: []internal.Record{{
+				Name: extractRecordName(fqdn, authZone),
+				Type: "TXT",
+				Data: strconv.Quote(value),
+				TTL:  uint32(d.config.TTL),
+			}},
+		},
+	}
+
+	err = d.client.UpdateRecords(dns01.UnFqdn(authZone), change)
+	if err != nil {
+		return fmt.Errorf("scaleway: %v", err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return fmt.Errorf("scaleway: could not find zone for domain %q: %v", domain, err)
+	}
+
+	change := internal.RecordChange{
+		Delete: &internal.DeleteChange{
+			IDFields: internal.IDFields{
+				Name: extractRecordName(fqdn, authZone),
+				Type: "TXT",
+				Data: strconv.Quote(value),
+			},
+		},
+	}
+
+	err = d.client.UpdateRecords(dns01.UnFqdn(authZone), change)
+	if err != nil {
+		return fmt.Errorf("scaleway: %v", err)
+	}
+
+	return nil
+}
+
+func extractRecordName(fqdn, authZone string) string {
+	name := dns01.UnFqdn(fqdn)
+	if idx := strings.LastIndex(name, "."+dns01.UnFqdn(authZone)); idx != -1 {
+		return name[:idx]
+	}
+	return name
+}
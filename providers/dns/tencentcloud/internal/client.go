@@ -0,0 +1,274 @@
+package internal
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// DefaultBaseURL the default base URL of the DNSPod API of Tencent Cloud (API 3.0).
+const DefaultBaseURL = "https://dnspod.tencentcloudapi.com"
+
+const (
+	service    = "dnspod"
+	apiVersion = "2021-03-23"
+	algorithm  = "TC3-HMAC-SHA256"
+)
+
+// DefaultRecordLine the default record line (i.e. all the lines).
+const DefaultRecordLine = "默认"
+
+const pageSize = 3000
+
+// Domain a domain of the account.
+type Domain struct {
+	DomainID uint64 `json:"DomainId"`
+	Name     string `json:"Name"`
+}
+
+// Record a DNS record, the name is relative to the domain (i.e. "_acme-challenge.sub").
+type Record struct {
+	RecordID uint64 `json:"RecordId"`
+	Name     string `json:"Name"`
+	Type     string `json:"Type"`
+	Value    string `json:"Value"`
+	TTL      int    `json:"TTL"`
+}
+
+// CreateRecordRequest the parameters of the CreateRecord action.
+type CreateRecordRequest struct {
+	Domain     string `json:"Domain"`
+	SubDomain  string `json:"SubDomain"`
+	RecordType string `json:"RecordType"`
+	RecordLine string `json:"RecordLine"`
+	Value      string `json:"Value"`
+	TTL        int    `json:"TTL,omitempty"`
+}
+
+// APIError an error of the API.
+type APIError struct {
+	Code      string `json:"Code"`
+	Message   string `json:"Message"`
+	RequestID string `json:"-"`
+}
+
+func (a *APIError) Error() string {
+	return fmt.Sprintf("%s: %s (request ID: %s)", a.Code, a.Message, a.RequestID)
+}
+
+type baseResponse struct {
+	Error     *APIError `json:"Error"`
+	RequestID string    `json:"RequestId"`
+}
+
+type domainListResponse struct {
+	baseResponse
+	DomainList []Domain `json:"DomainList"`
+}
+
+type recordListResponse struct {
+	baseResponse
+	RecordList []Record `json:"RecordList"`
+}
+
+type createRecordResponse struct {
+	baseResponse
+	RecordID uint64 `json:"RecordId"`
+}
+
+// Client the DNSPod API client of Tencent Cloud.
+type Client struct {
+	secretID     string
+	secretKey    string
+	region       string
+	sessionToken string
+	BaseURL      string
+	HTTPClient   *http.Client
+}
+
+// NewClient creates a DNSPod API client of Tencent Cloud.
+// The region is optional, the session token is only required with temporary credentials.
+func NewClient(secretID, secretKey, region, sessionToken string) *Client {
+	return &Client{
+		secretID:     secretID,
+		secretKey:    secretKey,
+		region:       region,
+		sessionToken: sessionToken,
+		BaseURL:      DefaultBaseURL,
+		HTTPClient:   &http.Client{},
+	}
+}
+
+// GetDomains returns all the domains of the account.
+func (c *Client) GetDomains() ([]Domain, error) {
+	var domains []Domain
+
+	for offset := 0; ; offset += pageSize {
+		payload := map[string]int{"Offset": offset, "Limit": pageSize}
+
+		resp := &domainListResponse{}
+		err := c.do("DescribeDomainList", payload, resp)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get the domains: %v", err)
+		}
+
+		domains = append(domains, resp.DomainList...)
+
+		if len(resp.DomainList) < pageSize {
+			return domains, nil
+		}
+	}
+}
+
+// GetTXTRecords returns the TXT records of a domain matching the subdomain.
+func (c *Client) GetTXTRecords(domain, subDomain string) ([]Record, error) {
+	payload := map[string]string{"Domain": domain, "Subdomain": subDomain, "RecordType": "TXT"}
+
+	resp := &recordListResponse{}
+	err := c.do("DescribeRecordList", payload, resp)
+	if err != nil {
+		if apiErr, ok := err.(*APIError); ok && apiErr.Code == "ResourceNotFound.NoDataOfRecord" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to get the records %s of the domain %s: %v", subDomain, domain, err)
+	}
+
+	return resp.RecordList, nil
+}
+
+// CreateRecord creates a record and returns its ID.
+func (c *Client) CreateRecord(request CreateRecordRequest) (uint64, error) {
+	resp := &createRecordResponse{}
+	err := c.do("CreateRecord", request, resp)
+	if err != nil {
+		return 0, fmt.Errorf("unable to create the record %s of the domain %s: %v", request.SubDomain, request.Domain, err)
+	}
+
+	return resp.RecordID, nil
+}
+
+// DeleteRecord deletes a record.
+func (c *Client) DeleteRecord(domain string, recordID uint64) error {
+	payload := map[string]interface{}{"Domain": domain, "RecordId": recordID}
+
+	err := c.do("DeleteRecord", payload, &baseResponse{})
+	if err != nil {
+		return fmt.Errorf("unable to delete the record %d of the domain %s: %v", recordID, domain, err)
+	}
+
+	return nil
+}
+
+// apiResponse is implemented by all the responses: the errors are returned with a status code 200.
+type apiResponse interface {
+	apiError() error
+}
+
+func (r *baseResponse) apiError() error {
+	if r.Error == nil {
+		return nil
+	}
+
+	r.Error.RequestID = r.RequestID
+
+	return r.Error
+}
+
+func (c *Client) do(action string, payload interface{}, result apiResponse) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.BaseURL+"/", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	timestamp := time.Now().Unix()
+
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("X-TC-Action", action)
+	req.Header.Set("X-TC-Version", apiVersion)
+	req.Header.Set("X-TC-Timestamp", strconv.FormatInt(timestamp, 10))
+	if c.region != "" {
+		req.Header.Set("X-TC-Region", c.region)
+	}
+	if c.sessionToken != "" {
+		req.Header.Set("X-TC-Token", c.sessionToken)
+	}
+	req.Header.Set("Authorization", c.sign(req.URL, body, timestamp))
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	envelope := struct {
+		Response apiResponse `json:"Response"`
+	}{Response: result}
+
+	err = json.Unmarshal(raw, &envelope)
+	if err != nil {
+		return err
+	}
+
+	return result.apiError()
+}
+
+// sign creates the value of the Authorization header (TC3-HMAC-SHA256).
+// https://www.tencentcloud.com/document/api/1157/49029
+func (c *Client) sign(endpoint *url.URL, body []byte, timestamp int64) string {
+	return fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=content-type;host, Signature=%s",
+		algorithm, c.secretID, credentialScope(timestamp), signature(c.secretKey, endpoint.Host, body, timestamp))
+}
+
+// signature computes the signature of a request:
+// only the headers "Content-Type" and "Host" are signed.
+func signature(secretKey, host string, body []byte, timestamp int64) string {
+	date := time.Unix(timestamp, 0).UTC().Format("2006-01-02")
+
+	canonicalRequest := fmt.Sprintf("POST\n/\n\ncontent-type:application/json; charset=utf-8\nhost:%s\n\ncontent-type;host\n%s",
+		host, sha256hex(body))
+
+	stringToSign := fmt.Sprintf("%s\n%d\n%s\n%s", algorithm, timestamp, credentialScope(timestamp), sha256hex([]byte(canonicalRequest)))
+
+	secretDate := hmacSHA256([]byte("TC3"+secretKey), date)
+	secretService := hmacSHA256(secretDate, service)
+	secretSigning := hmacSHA256(secretService, "tc3_request")
+
+	return hex.EncodeToString(hmacSHA256(secretSigning, stringToSign))
+}
+
+func credentialScope(timestamp int64) string {
+	return time.Unix(timestamp, 0).UTC().Format("2006-01-02") + "/" + service + "/tc3_request"
+}
+
+func sha256hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, message string) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(message))
+	return mac.Sum(nil)
+}
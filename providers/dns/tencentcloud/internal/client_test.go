@@ -0,0 +1,190 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(handler func(rw http.ResponseWriter, action string, body []byte)) (*Client, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !checkAuth(rw, req, body) {
+			return
+		}
+
+		handler(rw, req.Header.Get("X-TC-Action"), body)
+	}))
+
+	client := NewClient("id", "secret", "ap-guangzhou", "")
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+func checkAuth(rw http.ResponseWriter, req *http.Request, body []byte) bool {
+	timestamp, err := strconv.ParseInt(req.Header.Get("X-TC-Timestamp"), 10, 64)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return false
+	}
+
+	expected := fmt.Sprintf("TC3-HMAC-SHA256 Credential=id/%s, SignedHeaders=content-type;host, Signature=%s",
+		credentialScope(timestamp), signature("secret", req.Host, body, timestamp))
+
+	if req.Header.Get("Authorization") != expected || req.Header.Get("X-TC-Version") != apiVersion || req.Header.Get("X-TC-Region") != "ap-guangzhou" {
+		fmt.Fprint(rw, `{"Response":{"Error":{"Code":"AuthFailure.SignatureFailure","Message":"The provided credentials could not be validated."},"RequestId":"abc"}}`)
+		return false
+	}
+	return true
+}
+
+func Test_signature(t *testing.T) {
+	sig := signature("secret", "dnspod.tencentcloudapi.com", []byte(`{"Offset":0,"Limit":3000}`), 1580000000)
+	assert.Equal(t, "fa19f98810525f4dc194f21acad15b0de0482cf509aae037239c13c648b5a6f4", sig)
+
+	assert.Equal(t, "2020-01-26/dnspod/tc3_request", credentialScope(1580000000))
+}
+
+func TestClient_sign(t *testing.T) {
+	client := NewClient("id", "secret", "", "")
+
+	endpoint, err := url.Parse(DefaultBaseURL)
+	require.NoError(t, err)
+
+	authorization := client.sign(endpoint, []byte(`{}`), 1580000000)
+
+	assert.True(t, strings.HasPrefix(authorization, "TC3-HMAC-SHA256 Credential=id/2020-01-26/dnspod/tc3_request, SignedHeaders=content-type;host, Signature="))
+}
+
+func TestClient_GetDomains(t *testing.T) {
+	client, tearDown := setupTest(func(rw http.ResponseWriter, action string, body []byte) {
+		if action != "DescribeDomainList" || string(body) != `{"Limit":3000,"Offset":0}` {
+			http.Error(rw, fmt.Sprintf("unexpected request: %s %s", action, body), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `{"Response":{"DomainList":[{"DomainId":1,"Name":"example.com"},{"DomainId":2,"Name":"example.org"}],"RequestId":"abc"}}`)
+	})
+	defer tearDown()
+
+	domains, err := client.GetDomains()
+	require.NoError(t, err)
+
+	expected := []Domain{{DomainID: 1, Name: "example.com"}, {DomainID: 2, Name: "example.org"}}
+	assert.Equal(t, expected, domains)
+}
+
+func TestClient_GetDomains_error(t *testing.T) {
+	client, tearDown := setupTest(func(rw http.ResponseWriter, action string, body []byte) {
+		fmt.Fprint(rw, `{"Response":{"Error":{"Code":"UnauthorizedOperation","Message":"Unauthorized operation."},"RequestId":"abc"}}`)
+	})
+	defer tearDown()
+
+	_, err := client.GetDomains()
+	require.EqualError(t, err, "unable to get the domains: UnauthorizedOperation: Unauthorized operation. (request ID: abc)")
+}
+
+func TestClient_GetTXTRecords(t *testing.T) {
+	client, tearDown := setupTest(func(rw http.ResponseWriter, action string, body []byte) {
+		if action != "DescribeRecordList" || string(body) != `{"Domain":"example.com","RecordType":"TXT","Subdomain":"_acme-challenge"}` {
+			http.Error(rw, fmt.Sprintf("unexpected request: %s %s", action, body), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `{"Response":{"RecordList":[{"RecordId":123,"Name":"_acme-challenge","Type":"TXT","Value":"value","TTL":600}],"RequestId":"abc"}}`)
+	})
+	defer tearDown()
+
+	records, err := client.GetTXTRecords("example.com", "_acme-challenge")
+	require.NoError(t, err)
+
+	expected := []Record{{RecordID: 123, Name: "_acme-challenge", Type: "TXT", Value: "value", TTL: 600}}
+	assert.Equal(t, expected, records)
+}
+
+func TestClient_GetTXTRecords_notFound(t *testing.T) {
+	client, tearDown := setupTest(func(rw http.ResponseWriter, action string, body []byte) {
+		fmt.Fprint(rw, `{"Response":{"Error":{"Code":"ResourceNotFound.NoDataOfRecord","Message":"No records."},"RequestId":"abc"}}`)
+	})
+	defer tearDown()
+
+	records, err := client.GetTXTRecords("example.com", "_acme-challenge")
+	require.NoError(t, err)
+
+	assert.Empty(t, records)
+}
+
+func TestClient_CreateRecord(t *testing.T) {
+	client, tearDown := setupTest(func(rw http.ResponseWriter, action string, body []byte) {
+		request := CreateRecordRequest{}
+		err := json.Unmarshal(body, &request)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := CreateRecordRequest{
+			Domain:     "example.com",
+			SubDomain:  "_acme-challenge",
+			RecordType: "TXT",
+			RecordLine: DefaultRecordLine,
+			Value:      "value",
+			TTL:        600,
+		}
+
+		if action != "CreateRecord" || request != expected {
+			http.Error(rw, fmt.Sprintf("unexpected request: %s %s", action, body), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `{"Response":{"RecordId":123,"RequestId":"abc"}}`)
+	})
+	defer tearDown()
+
+	recordID, err := client.CreateRecord(CreateRecordRequest{
+		Domain:     "example.com",
+		SubDomain:  "_acme-challenge",
+		RecordType: "TXT",
+		RecordLine: DefaultRecordLine,
+		Value:      "value",
+		TTL:        600,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(123), recordID)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, tearDown := setupTest(func(rw http.ResponseWriter, action string, body []byte) {
+		if action != "DeleteRecord" || string(body) != `{"Domain":"example.com","RecordId":123}` {
+			http.Error(rw, fmt.Sprintf("unexpected request: %s %s", action, body), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `{"Response":{"RequestId":"abc"}}`)
+	})
+	defer tearDown()
+
+	err := client.DeleteRecord("example.com", 123)
+	require.NoError(t, err)
+}
@@ -0,0 +1,215 @@
+// Package tencentcloud implements a DNS provider for solving the DNS-01 challenge using Tencent Cloud DNSPod (API 3.0).
+package tencentcloud
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/tencentcloud/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL            string
+	SecretID           string
+	SecretKey          string
+	Region             string
+	SessionToken       string
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString("TENCENTCLOUD_ENDPOINT", internal.DefaultBaseURL),
+		TTL:                env.GetOrDefaultInt("TENCENTCLOUD_TTL", 600),
+		PropagationTimeout: env.GetOrDefaultSecond("TENCENTCLOUD_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("TENCENTCLOUD_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("TENCENTCLOUD_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses the DNSPod API of Tencent Cloud to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	recordIDs   map[string]uint64
+	recordIDsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Tencent Cloud.
+// Credentials must be passed in the environment variables: TENCENTCLOUD_SECRET_ID and TENCENTCLOUD_SECRET_KEY.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("TENCENTCLOUD_SECRET_ID", "TENCENTCLOUD_SECRET_KEY")
+	if err != nil {
+		return nil, fmt.Errorf("tencentcloud: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.SecretID = values["TENCENTCLOUD_SECRET_ID"]
+	config.SecretKey = values["TENCENTCLOUD_SECRET_KEY"]
+	config.Region = env.GetOrFile("TENCENTCLOUD_REGION")
+	config.SessionToken = env.GetOrFile("TENCENTCLOUD_SESSION_TOKEN")
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Tencent Cloud.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("tencentcloud: the configuration of the DNS provider is nil")
+	}
+
+	if config.SecretID == "" || config.SecretKey == "" {
+		return nil, errors.New("tencentcloud: credentials missing")
+	}
+
+	client := internal.NewClient(config.SecretID, config.SecretKey, config.Region, config.SessionToken)
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{
+		config:    config,
+		client:    client,
+		recordIDs: make(map[string]uint64),
+	}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("tencentcloud: %v", err)
+	}
+
+	request := internal.CreateRecordRequest{
+		Domain:     zone,
+		SubDomain:  extractRecordName(fqdn, zone),
+		RecordType: "TXT",
+		RecordLine: internal.DefaultRecordLine,
+		Value:      value,
+		TTL:        d.config.TTL,
+	}
+
+	recordID, err := d.client.CreateRecord(request)
+	if err != nil {
+		return fmt.Errorf("tencentcloud: %v", err)
+	}
+
+	d.recordIDsMu.Lock()
+	d.recordIDs[token] = recordID
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("tencentcloud: %v", err)
+	}
+
+	// gets the record's unique ID from when we created it
+	d.recordIDsMu.Lock()
+	recordID, ok := d.recordIDs[token]
+	d.recordIDsMu.Unlock()
+
+	if !ok {
+		recordID, err = d.findRecordID(zone, extractRecordName(fqdn, zone), value)
+		if err != nil {
+			return fmt.Errorf("tencentcloud: %v", err)
+		}
+	}
+
+	err = d.client.DeleteRecord(zone, recordID)
+	if err != nil {
+		return fmt.Errorf("tencentcloud: %v", err)
+	}
+
+	// deletes record ID from map
+	d.recordIDsMu.Lock()
+	delete(d.recordIDs, token)
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// findZone returns the longest domain of the account matching the FQDN.
+func (d *DNSProvider) findZone(fqdn string) (string, error) {
+	domains, err := d.client.GetDomains()
+	if err != nil {
+		return "", err
+	}
+
+	name := dns01.UnFqdn(fqdn)
+
+	var zone string
+	for _, domain := range domains {
+		if (name == domain.Name || strings.HasSuffix(name, "."+domain.Name)) && len(domain.Name) > len(zone) {
+			zone = domain.Name
+		}
+	}
+
+	if zone == "" {
+		return "", fmt.Errorf("no domain found for %s", fqdn)
+	}
+
+	return zone, nil
+}
+
+// findRecordID returns the ID of the TXT record matching the name and the value.
+func (d *DNSProvider) findRecordID(zone, recordName, value string) (uint64, error) {
+	records, err := d.client.GetTXTRecords(zone, recordName)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, record := range records {
+		if record.Name == recordName && record.Value == value {
+			return record.RecordID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no TXT record found for %s.%s", recordName, zone)
+}
+
+func extractRecordName(fqdn, zone string) string {
+	name := dns01.UnFqdn(fqdn)
+	if name == zone {
+		return "@"
+	}
+	if idx := strings.LastIndex(name, "."+zone); idx != -1 {
+		return name[:idx]
+	}
+	return name
+}
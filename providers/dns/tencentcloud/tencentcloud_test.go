@@ -0,0 +1,260 @@
+package tencentcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/go-acme/lego/v3/providers/dns/tencentcloud/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest(
+	"TENCENTCLOUD_SECRET_ID",
+	"TENCENTCLOUD_SECRET_KEY",
+	"TENCENTCLOUD_REGION",
+	"TENCENTCLOUD_SESSION_TOKEN").
+	WithDomain("TENCENTCLOUD_DOMAIN")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"TENCENTCLOUD_SECRET_ID":  "123",
+				"TENCENTCLOUD_SECRET_KEY": "456",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				"TENCENTCLOUD_SECRET_ID":  "",
+				"TENCENTCLOUD_SECRET_KEY": "",
+			},
+			expected: "tencentcloud: some credentials information are missing: TENCENTCLOUD_SECRET_ID,TENCENTCLOUD_SECRET_KEY",
+		},
+		{
+			desc: "missing secret ID",
+			envVars: map[string]string{
+				"TENCENTCLOUD_SECRET_ID":  "",
+				"TENCENTCLOUD_SECRET_KEY": "456",
+			},
+			expected: "tencentcloud: some credentials information are missing: TENCENTCLOUD_SECRET_ID",
+		},
+		{
+			desc: "missing secret key",
+			envVars: map[string]string{
+				"TENCENTCLOUD_SECRET_ID":  "123",
+				"TENCENTCLOUD_SECRET_KEY": "",
+			},
+			expected: "tencentcloud: some credentials information are missing: TENCENTCLOUD_SECRET_KEY",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		secretID  string
+		secretKey string
+		expected  string
+	}{
+		{
+			desc:      "success",
+			secretID:  "123",
+			secretKey: "456",
+		},
+		{
+			desc:     "missing credentials",
+			expected: "tencentcloud: credentials missing",
+		},
+		{
+			desc:     "missing secret key",
+			secretID: "123",
+			expected: "tencentcloud: credentials missing",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.SecretID = test.secretID
+			config.SecretKey = test.secretKey
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func setupTest(handler func(rw http.ResponseWriter, action string, body []byte)) (*DNSProvider, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		action := req.Header.Get("X-TC-Action")
+		if action == "DescribeDomainList" {
+			fmt.Fprint(rw, `{"Response":{"DomainList":[{"DomainId":1,"Name":"example.com"},{"DomainId":2,"Name":"sub.example.com"}],"RequestId":"abc"}}`)
+			return
+		}
+
+		handler(rw, action, body)
+	}))
+
+	config := NewDefaultConfig()
+	config.SecretID = "id"
+	config.SecretKey = "secret"
+	config.BaseURL = server.URL
+
+	p, err := NewDNSProviderConfig(config)
+	if err != nil {
+		panic(err)
+	}
+
+	return p, server.Close
+}
+
+func TestDNSProvider_Present(t *testing.T) {
+	var request internal.CreateRecordRequest
+
+	provider, tearDown := setupTest(func(rw http.ResponseWriter, action string, body []byte) {
+		if action != "CreateRecord" {
+			http.Error(rw, fmt.Sprintf("unexpected action: %s", action), http.StatusBadRequest)
+			return
+		}
+
+		err := json.Unmarshal(body, &request)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `{"Response":{"RecordId":123,"RequestId":"abc"}}`)
+	})
+	defer tearDown()
+
+	err := provider.Present("www.sub.example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Equal(t, "sub.example.com", request.Domain)
+	assert.Equal(t, "_acme-challenge.www", request.SubDomain)
+	assert.Equal(t, uint64(123), provider.recordIDs["token"])
+}
+
+func TestDNSProvider_CleanUp(t *testing.T) {
+	provider, tearDown := setupTest(func(rw http.ResponseWriter, action string, body []byte) {
+		switch action {
+		case "DescribeRecordList":
+			fmt.Fprint(rw, `{"Response":{"RecordList":[{"RecordId":122,"Name":"_acme-challenge","Type":"TXT","Value":"other"},{"RecordId":123,"Name":"_acme-challenge","Type":"TXT","Value":"pW9ZKG0xz_PCriK-nCMOjADy9eJcgGWIzkkj2fN4uZM"}],"RequestId":"abc"}}`)
+		case "DeleteRecord":
+			if string(body) != `{"Domain":"example.com","RecordId":123}` {
+				http.Error(rw, fmt.Sprintf("unexpected body: %s", body), http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(rw, `{"Response":{"RequestId":"abc"}}`)
+		default:
+			http.Error(rw, fmt.Sprintf("unexpected action: %s", action), http.StatusBadRequest)
+		}
+	})
+	defer tearDown()
+
+	err := provider.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+}
+
+func Test_extractRecordName(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		fqdn     string
+		zone     string
+		expected string
+	}{
+		{
+			desc:     "subdomain",
+			fqdn:     "_acme-challenge.sub.example.com.",
+			zone:     "example.com",
+			expected: "_acme-challenge.sub",
+		},
+		{
+			desc:     "apex",
+			fqdn:     "example.com.",
+			zone:     "example.com",
+			expected: "@",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, extractRecordName(test.fqdn, test.zone))
+		})
+	}
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
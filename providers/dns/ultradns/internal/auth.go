@@ -0,0 +1,68 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// passwordTokenSource an oauth2.TokenSource using the password grant:
+// the token is refreshed with the refresh token when it expires,
+// and requested again with the credentials when the refresh fails.
+type passwordTokenSource struct {
+	ctx      context.Context
+	config   *oauth2.Config
+	username string
+	password string
+
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+// Token implements oauth2.TokenSource.
+func (s *passwordTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil {
+		token, err := s.config.TokenSource(s.ctx, s.token).Token()
+		if err == nil {
+			s.token = token
+			return token, nil
+		}
+	}
+
+	token, err := s.config.PasswordCredentialsToken(s.ctx, s.username, s.password)
+	if err != nil {
+		return nil, err
+	}
+
+	s.token = token
+
+	return token, nil
+}
+
+// NewOAuthClient creates an HTTP client authenticated with the OAuth2 password grant of the UltraDNS API.
+// The HTTP client is used to request the tokens and to call the API.
+func NewOAuthClient(httpClient *http.Client, baseURL, username, password string) *http.Client {
+	ctx := context.Background()
+	if httpClient != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, httpClient)
+	}
+
+	source := &passwordTokenSource{
+		ctx: ctx,
+		config: &oauth2.Config{
+			Endpoint: oauth2.Endpoint{
+				TokenURL:  baseURL + "/v2/authorization/token",
+				AuthStyle: oauth2.AuthStyleInParams,
+			},
+		},
+		username: username,
+		password: password,
+	}
+
+	return oauth2.NewClient(ctx, source)
+}
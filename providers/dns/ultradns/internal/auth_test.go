@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOAuthClient(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	var grants []string
+	mux.HandleFunc("/v2/authorization/token", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		grant := req.PostFormValue("grant_type")
+		grants = append(grants, grant)
+
+		switch {
+		case grant == "password" && req.PostFormValue("username") == "user" && req.PostFormValue("password") == "secret":
+			rw.Header().Set("Content-Type", "application/json")
+			// the token expires before the expiry delta: the next request refreshes it.
+			fmt.Fprint(rw, `{"tokenType":"Bearer","refresh_token":"refresh","access_token":"access1","expires_in":"1"}`)
+		case grant == "refresh_token" && req.PostFormValue("refresh_token") == "refresh":
+			rw.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(rw, `{"tokenType":"Bearer","refresh_token":"refresh","access_token":"access2","expires_in":"3600"}`)
+		default:
+			rw.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(rw, `{"errorCode":60001,"errorMessage":"invalid_grant:Invalid username & password combination."}`)
+		}
+	})
+
+	var authorizations []string
+	mux.HandleFunc("/v2/zones", func(rw http.ResponseWriter, req *http.Request) {
+		authorizations = append(authorizations, req.Header.Get("Authorization"))
+		fmt.Fprint(rw, `{"zones":[]}`)
+	})
+
+	client := NewOAuthClient(server.Client(), server.URL, "user", "secret")
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL + "/v2/zones")
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	assert.Equal(t, []string{"password", "refresh_token"}, grants)
+	assert.Equal(t, []string{"Bearer access1", "Bearer access2"}, authorizations)
+}
+
+func TestNewOAuthClient_error(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/v2/authorization/token", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(rw, `{"errorCode":60001,"errorMessage":"invalid_grant:Invalid username & password combination."}`)
+	})
+
+	client := NewOAuthClient(server.Client(), server.URL, "user", "invalid")
+
+	_, err := client.Get(server.URL + "/v2/zones")
+	require.Error(t, err)
+}
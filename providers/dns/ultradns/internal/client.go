@@ -0,0 +1,199 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultBaseURL the default base URL of the UltraDNS API.
+const DefaultBaseURL = "https://api.ultradns.com"
+
+// codeNotFound the code of the error returned when a RRSet or a zone doesn't exist.
+const codeNotFound = 70002
+
+// Zone a zone of the account.
+type Zone struct {
+	Properties struct {
+		Name        string `json:"name"`
+		AccountName string `json:"accountName"`
+		Type        string `json:"type"`
+	} `json:"properties"`
+}
+
+// RRSet a resource record set.
+type RRSet struct {
+	OwnerName string   `json:"ownerName,omitempty"`
+	RRType    string   `json:"rrtype,omitempty"`
+	TTL       int      `json:"ttl"`
+	RData     []string `json:"rdata"`
+}
+
+type zonesResponse struct {
+	Zones []Zone `json:"zones"`
+}
+
+type rrSetsResponse struct {
+	RRSets []RRSet `json:"rrSets"`
+}
+
+// APIError an error of the API.
+type APIError struct {
+	StatusCode   int    `json:"-"`
+	ErrorCode    int    `json:"errorCode"`
+	ErrorMessage string `json:"errorMessage"`
+}
+
+func (a *APIError) Error() string {
+	return fmt.Sprintf("%d: %d: %s", a.StatusCode, a.ErrorCode, a.ErrorMessage)
+}
+
+// Client the UltraDNS API client.
+// The HTTP client must handle the authentication (i.e. NewOAuthClient).
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates an UltraDNS API client.
+func NewClient(httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+
+	return &Client{
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: httpClient,
+	}
+}
+
+// FindZones returns the zones matching the name, the zones can be restricted to an account.
+func (c *Client) FindZones(name, accountName string) ([]Zone, error) {
+	filters := []string{"name:" + name}
+	if accountName != "" {
+		filters = append(filters, "account_name:"+accountName)
+	}
+
+	query := url.Values{}
+	query.Set("q", strings.Join(filters, " "))
+
+	resp := &zonesResponse{}
+	err := c.do(http.MethodGet, "/v2/zones?"+query.Encode(), nil, resp)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to find the zones %s: %v", name, err)
+	}
+
+	return resp.Zones, nil
+}
+
+// GetTXTRRSet returns the TXT RRSet of the owner, or nil if it doesn't exist.
+func (c *Client) GetTXTRRSet(zone, owner string) (*RRSet, error) {
+	resp := &rrSetsResponse{}
+	err := c.do(http.MethodGet, rrSetURI(zone, owner), nil, resp)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to get the TXT RRSet %s of the zone %s: %v", owner, zone, err)
+	}
+
+	if len(resp.RRSets) == 0 {
+		return nil, nil
+	}
+
+	return &resp.RRSets[0], nil
+}
+
+// CreateTXTRRSet creates the TXT RRSet of the owner.
+func (c *Client) CreateTXTRRSet(zone, owner string, rrSet RRSet) error {
+	err := c.do(http.MethodPost, rrSetURI(zone, owner), rrSet, nil)
+	if err != nil {
+		return fmt.Errorf("unable to create the TXT RRSet %s of the zone %s: %v", owner, zone, err)
+	}
+
+	return nil
+}
+
+// UpdateTXTRRSet replaces the TXT RRSet of the owner.
+func (c *Client) UpdateTXTRRSet(zone, owner string, rrSet RRSet) error {
+	err := c.do(http.MethodPut, rrSetURI(zone, owner), rrSet, nil)
+	if err != nil {
+		return fmt.Errorf("unable to update the TXT RRSet %s of the zone %s: %v", owner, zone, err)
+	}
+
+	return nil
+}
+
+// DeleteTXTRRSet deletes the TXT RRSet of the owner.
+func (c *Client) DeleteTXTRRSet(zone, owner string) error {
+	err := c.do(http.MethodDelete, rrSetURI(zone, owner), nil, nil)
+	if err != nil {
+		return fmt.Errorf("unable to delete the TXT RRSet %s of the zone %s: %v", owner, zone, err)
+	}
+
+	return nil
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body bytes.Buffer
+	if payload != nil {
+		err := json.NewEncoder(&body).Encode(payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+uri, &body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		// the errors are returned as an array.
+		var apiErrs []APIError
+		if json.Unmarshal(raw, &apiErrs) == nil && len(apiErrs) > 0 {
+			apiErrs[0].StatusCode = resp.StatusCode
+			return &apiErrs[0]
+		}
+
+		return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
+
+func rrSetURI(zone, owner string) string {
+	return fmt.Sprintf("/v2/zones/%s/rrsets/TXT/%s", zone, owner)
+}
+
+func isNotFound(err error) bool {
+	apiErr, ok := err.(*APIError)
+	return ok && apiErr.ErrorCode == codeNotFound
+}
@@ -0,0 +1,140 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest() (*Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client := NewClient(server.Client())
+	client.BaseURL = server.URL
+
+	return client, mux, server.Close
+}
+
+func TestClient_FindZones(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/v2/zones", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.URL.Query().Get("q") != "name:example.com. account_name:acme" {
+			http.Error(rw, fmt.Sprintf("unexpected query: %s", req.URL.RawQuery), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `{"queryInfo":{"q":"name:example.com."},"resultInfo":{"totalCount":1},"zones":[{"properties":{"name":"example.com.","accountName":"acme","type":"PRIMARY"}}]}`)
+	})
+
+	zones, err := client.FindZones("example.com.", "acme")
+	require.NoError(t, err)
+
+	require.Len(t, zones, 1)
+	assert.Equal(t, "example.com.", zones[0].Properties.Name)
+	assert.Equal(t, "acme", zones[0].Properties.AccountName)
+}
+
+func TestClient_GetTXTRRSet(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/v2/zones/example.com./rrsets/TXT/_acme-challenge.example.com.", func(rw http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(rw, `{"zoneName":"example.com.","rrSets":[{"ownerName":"_acme-challenge.example.com.","rrtype":"TXT (16)","ttl":120,"rdata":["value"]}]}`)
+	})
+
+	rrSet, err := client.GetTXTRRSet("example.com.", "_acme-challenge.example.com.")
+	require.NoError(t, err)
+
+	expected := &RRSet{OwnerName: "_acme-challenge.example.com.", RRType: "TXT (16)", TTL: 120, RData: []string{"value"}}
+	assert.Equal(t, expected, rrSet)
+}
+
+func TestClient_GetTXTRRSet_notFound(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/v2/zones/example.com./rrsets/TXT/_acme-challenge.example.com.", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(rw, `[{"errorCode":70002,"errorMessage":"Data not found."}]`)
+	})
+
+	rrSet, err := client.GetTXTRRSet("example.com.", "_acme-challenge.example.com.")
+	require.NoError(t, err)
+
+	assert.Nil(t, rrSet)
+}
+
+func TestClient_GetTXTRRSet_error(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/v2/zones/example.com./rrsets/TXT/_acme-challenge.example.com.", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(rw, `[{"errorCode":60001,"errorMessage":"invalid_grant:token not found, expired or invalid"}]`)
+	})
+
+	_, err := client.GetTXTRRSet("example.com.", "_acme-challenge.example.com.")
+	require.EqualError(t, err, "unable to get the TXT RRSet _acme-challenge.example.com. of the zone example.com.: 401: 60001: invalid_grant:token not found, expired or invalid")
+}
+
+func TestClient_CreateTXTRRSet(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/v2/zones/example.com./rrsets/TXT/_acme-challenge.example.com.", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		rrSet := RRSet{}
+		err := json.NewDecoder(req.Body).Decode(&rrSet)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := RRSet{TTL: 120, RData: []string{"value"}}
+		if !reflect.DeepEqual(rrSet, expected) {
+			http.Error(rw, fmt.Sprintf("unexpected RRSet: %+v", rrSet), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		fmt.Fprint(rw, `{"message":"Successful"}`)
+	})
+
+	err := client.CreateTXTRRSet("example.com.", "_acme-challenge.example.com.", RRSet{TTL: 120, RData: []string{"value"}})
+	require.NoError(t, err)
+}
+
+func TestClient_DeleteTXTRRSet(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/v2/zones/example.com./rrsets/TXT/_acme-challenge.example.com.", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	err := client.DeleteTXTRRSet("example.com.", "_acme-challenge.example.com.")
+	require.NoError(t, err)
+}
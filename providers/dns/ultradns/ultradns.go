@@ -0,0 +1,199 @@
+// Package ultradns implements a DNS provider for solving the DNS-01 challenge using UltraDNS.
+package ultradns
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/ultradns/internal"
+	"github.com/miekg/dns"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL            string
+	Username           string
+	Password           string
+	AccountName        string
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString("ULTRADNS_ENDPOINT", internal.DefaultBaseURL),
+		TTL:                env.GetOrDefaultInt("ULTRADNS_TTL", dns01.DefaultTTL),
+		PropagationTimeout: env.GetOrDefaultSecond("ULTRADNS_PROPAGATION_TIMEOUT", 2*time.Minute),
+		PollingInterval:    env.GetOrDefaultSecond("ULTRADNS_POLLING_INTERVAL", 4*time.Second),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("ULTRADNS_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses the UltraDNS API to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	// the RRSets are updated as a whole: the read-modify-write must not be interleaved.
+	mu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for UltraDNS.
+// Credentials must be passed in the environment variables: ULTRADNS_USERNAME and ULTRADNS_PASSWORD.
+// The zones can be restricted to an account with the environment variable: ULTRADNS_ACCOUNT_NAME.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("ULTRADNS_USERNAME", "ULTRADNS_PASSWORD")
+	if err != nil {
+		return nil, fmt.Errorf("ultradns: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.Username = values["ULTRADNS_USERNAME"]
+	config.Password = values["ULTRADNS_PASSWORD"]
+	config.AccountName = env.GetOrFile("ULTRADNS_ACCOUNT_NAME")
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for UltraDNS.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("ultradns: the configuration of the DNS provider is nil")
+	}
+
+	if config.Username == "" || config.Password == "" {
+		return nil, errors.New("ultradns: credentials missing")
+	}
+
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = internal.DefaultBaseURL
+	}
+
+	client := internal.NewClient(internal.NewOAuthClient(config.HTTPClient, baseURL, config.Username, config.Password))
+	client.BaseURL = baseURL
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("ultradns: %v", err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	rrSet, err := d.client.GetTXTRRSet(zone, fqdn)
+	if err != nil {
+		return fmt.Errorf("ultradns: %v", err)
+	}
+
+	if rrSet == nil {
+		err = d.client.CreateTXTRRSet(zone, fqdn, internal.RRSet{TTL: d.config.TTL, RData: []string{value}})
+		if err != nil {
+			return fmt.Errorf("ultradns: %v", err)
+		}
+
+		return nil
+	}
+
+	for _, data := range rrSet.RData {
+		if data == value {
+			return nil
+		}
+	}
+
+	err = d.client.UpdateTXTRRSet(zone, fqdn, internal.RRSet{TTL: d.config.TTL, RData: append(rrSet.RData, value)})
+	if err != nil {
+		return fmt.Errorf("ultradns: %v", err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("ultradns: %v", err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	rrSet, err := d.client.GetTXTRRSet(zone, fqdn)
+	if err != nil {
+		return fmt.Errorf("ultradns: %v", err)
+	}
+
+	if rrSet == nil {
+		return nil
+	}
+
+	var rData []string
+	for _, data := range rrSet.RData {
+		if data != value {
+			rData = append(rData, data)
+		}
+	}
+
+	if len(rData) == len(rrSet.RData) {
+		return nil
+	}
+
+	if len(rData) == 0 {
+		err = d.client.DeleteTXTRRSet(zone, fqdn)
+	} else {
+		err = d.client.UpdateTXTRRSet(zone, fqdn, internal.RRSet{TTL: rrSet.TTL, RData: rData})
+	}
+	if err != nil {
+		return fmt.Errorf("ultradns: %v", err)
+	}
+
+	return nil
+}
+
+// findZone returns the zone of the account matching the longest part of the FQDN.
+func (d *DNSProvider) findZone(fqdn string) (string, error) {
+	for _, index := range dns.Split(fqdn) {
+		candidate := fqdn[index:]
+
+		zones, err := d.client.FindZones(candidate, d.config.AccountName)
+		if err != nil {
+			return "", err
+		}
+
+		for _, zone := range zones {
+			if strings.EqualFold(dns01.ToFqdn(zone.Properties.Name), candidate) {
+				return zone.Properties.Name, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("no zone found for %s", fqdn)
+}
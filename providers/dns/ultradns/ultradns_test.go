@@ -0,0 +1,294 @@
+package ultradns
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/go-acme/lego/v3/providers/dns/ultradns/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest(
+	"ULTRADNS_USERNAME",
+	"ULTRADNS_PASSWORD",
+	"ULTRADNS_ACCOUNT_NAME",
+	"ULTRADNS_ENDPOINT").
+	WithDomain("ULTRADNS_DOMAIN")
+
+func setupTest() (*DNSProvider, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc("/v2/authorization/token", func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(rw, `{"tokenType":"Bearer","refresh_token":"refresh","access_token":"access","expires_in":"3600"}`)
+	})
+
+	mux.HandleFunc("/v2/zones", func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("q") != "name:example.com." {
+			fmt.Fprint(rw, `{"zones":[]}`)
+			return
+		}
+
+		fmt.Fprint(rw, `{"zones":[{"properties":{"name":"example.com.","accountName":"acme","type":"PRIMARY"}}]}`)
+	})
+
+	config := NewDefaultConfig()
+	config.BaseURL = server.URL
+	config.Username = "user"
+	config.Password = "secret"
+	config.HTTPClient = server.Client()
+
+	provider, err := NewDNSProviderConfig(config)
+	if err != nil {
+		panic(err)
+	}
+
+	return provider, mux, server.Close
+}
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"ULTRADNS_USERNAME": "user",
+				"ULTRADNS_PASSWORD": "secret",
+			},
+		},
+		{
+			desc: "missing username",
+			envVars: map[string]string{
+				"ULTRADNS_USERNAME": "",
+				"ULTRADNS_PASSWORD": "secret",
+			},
+			expected: "ultradns: some credentials information are missing: ULTRADNS_USERNAME",
+		},
+		{
+			desc: "missing password",
+			envVars: map[string]string{
+				"ULTRADNS_USERNAME": "user",
+				"ULTRADNS_PASSWORD": "",
+			},
+			expected: "ultradns: some credentials information are missing: ULTRADNS_PASSWORD",
+		},
+		{
+			desc:     "missing credentials",
+			envVars:  map[string]string{},
+			expected: "ultradns: some credentials information are missing: ULTRADNS_USERNAME,ULTRADNS_PASSWORD",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		username string
+		password string
+		expected string
+	}{
+		{
+			desc:     "success",
+			username: "user",
+			password: "secret",
+		},
+		{
+			desc:     "missing username",
+			password: "secret",
+			expected: "ultradns: credentials missing",
+		},
+		{
+			desc:     "missing password",
+			username: "user",
+			expected: "ultradns: credentials missing",
+		},
+		{
+			desc:     "missing credentials",
+			expected: "ultradns: credentials missing",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.Username = test.username
+			config.Password = test.password
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestDNSProvider_Present(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	var updated internal.RRSet
+	mux.HandleFunc("/v2/zones/example.com./rrsets/TXT/_acme-challenge.example.com.", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			fmt.Fprint(rw, `{"rrSets":[{"ownerName":"_acme-challenge.example.com.","rrtype":"TXT (16)","ttl":300,"rdata":["other"]}]}`)
+		case http.MethodPut:
+			err := json.NewDecoder(req.Body).Decode(&updated)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(rw, `{"message":"Successful"}`)
+		default:
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+		}
+	})
+
+	err := provider.Present("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"other", "pW9ZKG0xz_PCriK-nCMOjADy9eJcgGWIzkkj2fN4uZM"}, updated.RData)
+}
+
+func TestDNSProvider_Present_create(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	var created internal.RRSet
+	mux.HandleFunc("/v2/zones/example.com./rrsets/TXT/_acme-challenge.sub.example.com.", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			rw.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(rw, `[{"errorCode":70002,"errorMessage":"Data not found."}]`)
+		case http.MethodPost:
+			err := json.NewDecoder(req.Body).Decode(&created)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+			rw.WriteHeader(http.StatusCreated)
+			fmt.Fprint(rw, `{"message":"Successful"}`)
+		default:
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+		}
+	})
+
+	err := provider.Present("sub.example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	expected := internal.RRSet{TTL: provider.config.TTL, RData: []string{"pW9ZKG0xz_PCriK-nCMOjADy9eJcgGWIzkkj2fN4uZM"}}
+	assert.Equal(t, expected, created)
+}
+
+func TestDNSProvider_CleanUp(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	var deleted bool
+	mux.HandleFunc("/v2/zones/example.com./rrsets/TXT/_acme-challenge.example.com.", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			fmt.Fprint(rw, `{"rrSets":[{"ownerName":"_acme-challenge.example.com.","rrtype":"TXT (16)","ttl":300,"rdata":["pW9ZKG0xz_PCriK-nCMOjADy9eJcgGWIzkkj2fN4uZM"]}]}`)
+		case http.MethodDelete:
+			deleted = true
+			rw.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+		}
+	})
+
+	err := provider.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.True(t, deleted)
+}
+
+func TestDNSProvider_CleanUp_update(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	var updated internal.RRSet
+	mux.HandleFunc("/v2/zones/example.com./rrsets/TXT/_acme-challenge.example.com.", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			fmt.Fprint(rw, `{"rrSets":[{"ownerName":"_acme-challenge.example.com.","rrtype":"TXT (16)","ttl":300,"rdata":["other","pW9ZKG0xz_PCriK-nCMOjADy9eJcgGWIzkkj2fN4uZM"]}]}`)
+		case http.MethodPut:
+			err := json.NewDecoder(req.Body).Decode(&updated)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(rw, `{"message":"Successful"}`)
+		default:
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+		}
+	})
+
+	err := provider.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Equal(t, internal.RRSet{TTL: 300, RData: []string{"other"}}, updated)
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
@@ -0,0 +1,174 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path"
+)
+
+// DefaultBaseURL the default base URL of the Variomedia API.
+const DefaultBaseURL = "https://api.variomedia.de"
+
+// The status of the queue jobs.
+const (
+	JobStatusPending = "pending"
+	JobStatusDone    = "done"
+	JobStatusFailed  = "failed"
+)
+
+const (
+	mediaTypeAccept  = "application/vnd.variomedia.v1+json"
+	mediaTypeJSONAPI = "application/vnd.api+json"
+)
+
+// DNSRecord the attributes of a DNS record, the name is relative to the domain (i.e. "_acme-challenge.sub").
+type DNSRecord struct {
+	RecordType string `json:"record_type"`
+	Name       string `json:"name"`
+	Domain     string `json:"domain"`
+	Data       string `json:"data"`
+	TTL        int    `json:"ttl"`
+}
+
+// Job a queue job, the changes of the records are applied asynchronously.
+type Job struct {
+	ID         string `json:"id"`
+	Type       string `json:"type"`
+	Attributes struct {
+		JobType string `json:"job_type"`
+		Status  string `json:"status"`
+	} `json:"attributes"`
+	Links map[string]string `json:"links"`
+}
+
+// RecordID returns the ID of the DNS record related to the job (the last segment of the "dns-record" link).
+func (j *Job) RecordID() string {
+	link, ok := j.Links["dns-record"]
+	if !ok || link == "" {
+		return ""
+	}
+
+	return path.Base(link)
+}
+
+type createRequest struct {
+	Data struct {
+		Type       string    `json:"type"`
+		Attributes DNSRecord `json:"attributes"`
+	} `json:"data"`
+}
+
+type jobResponse struct {
+	Data Job `json:"data"`
+}
+
+type apiError struct {
+	Errors []struct {
+		Status string `json:"status"`
+		Title  string `json:"title"`
+		Detail string `json:"detail"`
+	} `json:"errors"`
+}
+
+// Client the Variomedia API client.
+type Client struct {
+	token      string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a Variomedia API client.
+func NewClient(token string) *Client {
+	return &Client{
+		token:      token,
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// CreateDNSRecord creates a DNS record, the record is created asynchronously by the returned job.
+func (c *Client) CreateDNSRecord(record DNSRecord) (*Job, error) {
+	payload := createRequest{}
+	payload.Data.Type = "dns-record"
+	payload.Data.Attributes = record
+
+	resp := &jobResponse{}
+	err := c.do(http.MethodPost, "/dns-records", payload, resp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the DNS record %s: %v", record.Name, err)
+	}
+
+	return &resp.Data, nil
+}
+
+// DeleteDNSRecord deletes a DNS record, the record is deleted asynchronously by the returned job.
+func (c *Client) DeleteDNSRecord(recordID string) (*Job, error) {
+	resp := &jobResponse{}
+	err := c.do(http.MethodDelete, "/dns-records/"+recordID, nil, resp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to delete the DNS record %s: %v", recordID, err)
+	}
+
+	return &resp.Data, nil
+}
+
+// GetJob returns a queue job.
+func (c *Client) GetJob(jobID string) (*Job, error) {
+	resp := &jobResponse{}
+	err := c.do(http.MethodGet, "/queue-jobs/"+jobID, nil, resp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the job %s: %v", jobID, err)
+	}
+
+	return &resp.Data, nil
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body bytes.Buffer
+	if payload != nil {
+		err := json.NewEncoder(&body).Encode(payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+uri, &body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", mediaTypeAccept)
+	req.Header.Set("Authorization", "token token="+c.token)
+	if payload != nil {
+		req.Header.Set("Content-Type", mediaTypeJSONAPI)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &apiError{}
+		if json.Unmarshal(raw, apiErr) == nil && len(apiErr.Errors) > 0 {
+			return fmt.Errorf("%d: %s: %s", resp.StatusCode, apiErr.Errors[0].Title, apiErr.Errors[0].Detail)
+		}
+
+		return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
@@ -0,0 +1,131 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest() (*Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client := NewClient("secret")
+	client.BaseURL = server.URL
+
+	return client, mux, server.Close
+}
+
+func checkAuth(rw http.ResponseWriter, req *http.Request) bool {
+	if req.Header.Get("Authorization") != "token token=secret" {
+		rw.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(rw, `{"errors":[{"status":"401","title":"Unauthorized","detail":"Invalid API token"}]}`)
+		return false
+	}
+	return true
+}
+
+func TestClient_CreateDNSRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/dns-records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		if req.Header.Get("Content-Type") != mediaTypeJSONAPI || req.Header.Get("Accept") != mediaTypeAccept {
+			http.Error(rw, "invalid media types", http.StatusUnsupportedMediaType)
+			return
+		}
+
+		payload := createRequest{}
+		err := json.NewDecoder(req.Body).Decode(&payload)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := DNSRecord{RecordType: "TXT", Name: "_acme-challenge", Domain: "example.com", Data: "value", TTL: 300}
+		if payload.Data.Type != "dns-record" || payload.Data.Attributes != expected {
+			http.Error(rw, fmt.Sprintf("unexpected payload: %+v", payload), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		fmt.Fprint(rw, `{"data":{"type":"queue-job","id":"job1","attributes":{"job_type":"dns-record","status":"pending"},"links":{"queue-job":"https://api.variomedia.de/queue-jobs/job1"}}}`)
+	})
+
+	job, err := client.CreateDNSRecord(DNSRecord{RecordType: "TXT", Name: "_acme-challenge", Domain: "example.com", Data: "value", TTL: 300})
+	require.NoError(t, err)
+
+	assert.Equal(t, "job1", job.ID)
+	assert.Equal(t, JobStatusPending, job.Attributes.Status)
+	assert.Empty(t, job.RecordID())
+}
+
+func TestClient_CreateDNSRecord_error(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	client.token = "invalid"
+
+	mux.HandleFunc("/dns-records", func(rw http.ResponseWriter, req *http.Request) {
+		checkAuth(rw, req)
+	})
+
+	_, err := client.CreateDNSRecord(DNSRecord{RecordType: "TXT", Name: "_acme-challenge", Domain: "example.com", Data: "value", TTL: 300})
+	require.EqualError(t, err, "unable to create the DNS record _acme-challenge: 401: Unauthorized: Invalid API token")
+}
+
+func TestClient_GetJob(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/queue-jobs/job1", func(rw http.ResponseWriter, req *http.Request) {
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		fmt.Fprint(rw, `{"data":{"type":"queue-job","id":"job1","attributes":{"job_type":"dns-record","status":"done"},"links":{"dns-record":"https://api.variomedia.de/dns-records/19011938"}}}`)
+	})
+
+	job, err := client.GetJob("job1")
+	require.NoError(t, err)
+
+	assert.Equal(t, JobStatusDone, job.Attributes.Status)
+	assert.Equal(t, "19011938", job.RecordID())
+}
+
+func TestClient_DeleteDNSRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/dns-records/19011938", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		fmt.Fprint(rw, `{"data":{"type":"queue-job","id":"job2","attributes":{"job_type":"dns-record","status":"pending"}}}`)
+	})
+
+	job, err := client.DeleteDNSRecord("19011938")
+	require.NoError(t, err)
+
+	assert.Equal(t, "job2", job.ID)
+}
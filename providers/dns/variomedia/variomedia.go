@@ -0,0 +1,202 @@
+// Package variomedia implements a DNS provider for solving the DNS-01 challenge using Variomedia.
+package variomedia
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/platform/wait"
+	"github.com/go-acme/lego/v3/providers/dns/variomedia/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL            string
+	APIToken           string
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString("VARIOMEDIA_ENDPOINT", internal.DefaultBaseURL),
+		TTL:                env.GetOrDefaultInt("VARIOMEDIA_TTL", 300),
+		PropagationTimeout: env.GetOrDefaultSecond("VARIOMEDIA_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("VARIOMEDIA_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("VARIOMEDIA_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses the Variomedia API to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	recordIDs   map[string]string
+	recordIDsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Variomedia.
+// Credentials must be passed in the environment variable: VARIOMEDIA_API_TOKEN.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("VARIOMEDIA_API_TOKEN")
+	if err != nil {
+		return nil, fmt.Errorf("variomedia: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIToken = values["VARIOMEDIA_API_TOKEN"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Variomedia.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("variomedia: the configuration of the DNS provider is nil")
+	}
+
+	if config.APIToken == "" {
+		return nil, errors.New("variomedia: credentials missing")
+	}
+
+	client := internal.NewClient(config.APIToken)
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{
+		config:    config,
+		client:    client,
+		recordIDs: make(map[string]string),
+	}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return fmt.Errorf("variomedia: could not find zone for FQDN %q: %v", fqdn, err)
+	}
+
+	record := internal.DNSRecord{
+		RecordType: "TXT",
+		Name:       extractRecordName(fqdn, authZone),
+		Domain:     dns01.UnFqdn(authZone),
+		Data:       value,
+		TTL:        d.config.TTL,
+	}
+
+	job, err := d.client.CreateDNSRecord(record)
+	if err != nil {
+		return fmt.Errorf("variomedia: %v", err)
+	}
+
+	job, err = d.waitJob(job)
+	if err != nil {
+		return fmt.Errorf("variomedia: %v", err)
+	}
+
+	recordID := job.RecordID()
+	if recordID == "" {
+		return fmt.Errorf("variomedia: the job %s doesn't reference a DNS record", job.ID)
+	}
+
+	d.recordIDsMu.Lock()
+	d.recordIDs[token] = recordID
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	// gets the record's unique ID from when we created it
+	d.recordIDsMu.Lock()
+	recordID, ok := d.recordIDs[token]
+	d.recordIDsMu.Unlock()
+	if !ok {
+		return fmt.Errorf("variomedia: unknown record ID for '%s'", fqdn)
+	}
+
+	job, err := d.client.DeleteDNSRecord(recordID)
+	if err != nil {
+		return fmt.Errorf("variomedia: %v", err)
+	}
+
+	_, err = d.waitJob(job)
+	if err != nil {
+		return fmt.Errorf("variomedia: %v", err)
+	}
+
+	// deletes record ID from map
+	d.recordIDsMu.Lock()
+	delete(d.recordIDs, token)
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// waitJob waits for the end of a queue job.
+func (d *DNSProvider) waitJob(job *internal.Job) (*internal.Job, error) {
+	current := job
+
+	err := wait.For("variomedia: job "+job.ID, d.config.PropagationTimeout, d.config.PollingInterval, func() (bool, error) {
+		if current.Attributes.Status != internal.JobStatusPending {
+			return true, nil
+		}
+
+		next, err := d.client.GetJob(job.ID)
+		if err != nil {
+			return false, err
+		}
+
+		current = next
+
+		return current.Attributes.Status != internal.JobStatusPending, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if current.Attributes.Status != internal.JobStatusDone {
+		return nil, fmt.Errorf("the job %s ended with the status %q", job.ID, current.Attributes.Status)
+	}
+
+	return current, nil
+}
+
+func extractRecordName(fqdn, authZone string) string {
+	name := dns01.UnFqdn(fqdn)
+	if idx := strings.LastIndex(name, "."+dns01.UnFqdn(authZone)); idx != -1 {
+		return name[:idx]
+	}
+	return name
+}
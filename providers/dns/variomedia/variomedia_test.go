@@ -0,0 +1,209 @@
+package variomedia
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/go-acme/lego/v3/providers/dns/variomedia/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest("VARIOMEDIA_API_TOKEN").
+	WithDomain("VARIOMEDIA_DOMAIN")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"VARIOMEDIA_API_TOKEN": "123",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				"VARIOMEDIA_API_TOKEN": "",
+			},
+			expected: "variomedia: some credentials information are missing: VARIOMEDIA_API_TOKEN",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		token    string
+		expected string
+	}{
+		{
+			desc:  "success",
+			token: "123",
+		},
+		{
+			desc:     "missing credentials",
+			expected: "variomedia: credentials missing",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.APIToken = test.token
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func setupTest() (*DNSProvider, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	config := NewDefaultConfig()
+	config.APIToken = "secret"
+	config.BaseURL = server.URL
+	config.PropagationTimeout = 5 * time.Second
+	config.PollingInterval = 10 * time.Millisecond
+
+	p, err := NewDNSProviderConfig(config)
+	if err != nil {
+		panic(err)
+	}
+
+	return p, mux, server.Close
+}
+
+func TestDNSProvider_waitJob(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	var calls int
+	mux.HandleFunc("/queue-jobs/job1", func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+		if calls < 3 {
+			fmt.Fprint(rw, `{"data":{"type":"queue-job","id":"job1","attributes":{"job_type":"dns-record","status":"pending"},"links":{"self":"https://api.variomedia.de/queue-jobs/job1"}}}`)
+			return
+		}
+
+		fmt.Fprint(rw, `{"data":{"type":"queue-job","id":"job1","attributes":{"job_type":"dns-record","status":"done"},"links":{"self":"https://api.variomedia.de/queue-jobs/job1","dns-record":"https://api.variomedia.de/dns-records/19011938"}}}`)
+	})
+
+	job := &internal.Job{ID: "job1"}
+	job.Attributes.Status = internal.JobStatusPending
+
+	job, err := provider.waitJob(job)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, "19011938", job.RecordID())
+}
+
+func TestDNSProvider_waitJob_failed(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/queue-jobs/job1", func(rw http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(rw, `{"data":{"type":"queue-job","id":"job1","attributes":{"job_type":"dns-record","status":"failed"}}}`)
+	})
+
+	job := &internal.Job{ID: "job1"}
+	job.Attributes.Status = internal.JobStatusPending
+
+	_, err := provider.waitJob(job)
+	require.EqualError(t, err, `the job job1 ended with the status "failed"`)
+}
+
+func TestDNSProvider_CleanUp(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/dns-records/19011938", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		fmt.Fprint(rw, `{"data":{"type":"queue-job","id":"job2","attributes":{"job_type":"dns-record","status":"pending"}}}`)
+	})
+	mux.HandleFunc("/queue-jobs/job2", func(rw http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(rw, `{"data":{"type":"queue-job","id":"job2","attributes":{"job_type":"dns-record","status":"done"}}}`)
+	})
+
+	provider.recordIDs["token"] = "19011938"
+
+	err := provider.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Empty(t, provider.recordIDs)
+}
+
+func Test_extractRecordName(t *testing.T) {
+	assert.Equal(t, "_acme-challenge", extractRecordName("_acme-challenge.example.com.", "example.com."))
+	assert.Equal(t, "_acme-challenge.sub", extractRecordName("_acme-challenge.sub.example.com.", "example.com."))
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
@@ -0,0 +1,133 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// DefaultBaseURL the default base URL of the Vercel API.
+const DefaultBaseURL = "https://api.vercel.com"
+
+// Record a DNS record, the name is relative to the domain (i.e. "_acme-challenge.sub").
+type Record struct {
+	ID    string `json:"id,omitempty"`
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	TTL   int    `json:"ttl,omitempty"`
+}
+
+// CreateRecordResponse the response of the record creation.
+type CreateRecordResponse struct {
+	UID     string `json:"uid"`
+	Updated int    `json:"updated"`
+}
+
+type apiError struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Client the Vercel API client.
+type Client struct {
+	authToken  string
+	teamID     string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a Vercel API client.
+// The team ID is optional, it's required to manage the domains of a team.
+func NewClient(authToken, teamID string) *Client {
+	return &Client{
+		authToken:  authToken,
+		teamID:     teamID,
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// CreateRecord creates a DNS record of a domain.
+func (c *Client) CreateRecord(domain string, record Record) (*CreateRecordResponse, error) {
+	resp := &CreateRecordResponse{}
+	err := c.do(http.MethodPost, fmt.Sprintf("/v2/domains/%s/records", domain), record, resp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the record %s of the domain %s: %v", record.Name, domain, err)
+	}
+
+	return resp, nil
+}
+
+// DeleteRecord deletes a DNS record of a domain.
+func (c *Client) DeleteRecord(domain, recordID string) error {
+	err := c.do(http.MethodDelete, fmt.Sprintf("/v2/domains/%s/records/%s", domain, recordID), nil, nil)
+	if err != nil {
+		return fmt.Errorf("unable to delete the record %s of the domain %s: %v", recordID, domain, err)
+	}
+
+	return nil
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	endpoint, err := url.Parse(c.BaseURL + uri)
+	if err != nil {
+		return err
+	}
+
+	if c.teamID != "" {
+		query := endpoint.Query()
+		query.Set("teamId", c.teamID)
+		endpoint.RawQuery = query.Encode()
+	}
+
+	var body bytes.Buffer
+	if payload != nil {
+		err = json.NewEncoder(&body).Encode(payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, endpoint.String(), &body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.authToken)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &apiError{}
+		if json.Unmarshal(raw, apiErr) == nil && apiErr.Error.Message != "" {
+			return fmt.Errorf("%d: %s: %s", resp.StatusCode, apiErr.Error.Code, apiErr.Error.Message)
+		}
+
+		return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
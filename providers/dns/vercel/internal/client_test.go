@@ -0,0 +1,112 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(teamID string) (*Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client := NewClient("secret", teamID)
+	client.BaseURL = server.URL
+
+	return client, mux, server.Close
+}
+
+func checkAuth(rw http.ResponseWriter, req *http.Request) bool {
+	if req.Header.Get("Authorization") != "Bearer secret" {
+		rw.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(rw, `{"error":{"code":"forbidden","message":"Not authorized"}}`)
+		return false
+	}
+	return true
+}
+
+func TestClient_CreateRecord(t *testing.T) {
+	client, mux, tearDown := setupTest("")
+	defer tearDown()
+
+	mux.HandleFunc("/v2/domains/example.com/records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		if req.URL.RawQuery != "" {
+			http.Error(rw, fmt.Sprintf("unexpected query: %s", req.URL.RawQuery), http.StatusBadRequest)
+			return
+		}
+
+		record := Record{}
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := Record{Name: "_acme-challenge", Type: "TXT", Value: "value", TTL: 60}
+		if record != expected {
+			http.Error(rw, fmt.Sprintf("unexpected record: %+v", record), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `{"uid":"rec_123","updated":1588000000000}`)
+	})
+
+	resp, err := client.CreateRecord("example.com", Record{Name: "_acme-challenge", Type: "TXT", Value: "value", TTL: 60})
+	require.NoError(t, err)
+
+	assert.Equal(t, &CreateRecordResponse{UID: "rec_123", Updated: 1588000000000}, resp)
+}
+
+func TestClient_CreateRecord_error(t *testing.T) {
+	client, mux, tearDown := setupTest("")
+	defer tearDown()
+
+	client.authToken = "invalid"
+
+	mux.HandleFunc("/v2/domains/example.com/records", func(rw http.ResponseWriter, req *http.Request) {
+		checkAuth(rw, req)
+	})
+
+	_, err := client.CreateRecord("example.com", Record{Name: "_acme-challenge", Type: "TXT", Value: "value"})
+	require.EqualError(t, err, "unable to create the record _acme-challenge of the domain example.com: 403: forbidden: Not authorized")
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, mux, tearDown := setupTest("team_123")
+	defer tearDown()
+
+	mux.HandleFunc("/v2/domains/example.com/records/rec_123", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		if req.URL.Query().Get("teamId") != "team_123" {
+			http.Error(rw, fmt.Sprintf("unexpected query: %s", req.URL.RawQuery), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `{}`)
+	})
+
+	err := client.DeleteRecord("example.com", "rec_123")
+	require.NoError(t, err)
+}
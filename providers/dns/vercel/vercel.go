@@ -0,0 +1,170 @@
+// Package vercel implements a DNS provider for solving the DNS-01 challenge using Vercel DNS.
+package vercel
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/vercel/internal"
+)
+
+const minTTL = 60
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL            string
+	AuthToken          string
+	TeamID             string
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString("VERCEL_ENDPOINT", internal.DefaultBaseURL),
+		TTL:                env.GetOrDefaultInt("VERCEL_TTL", minTTL),
+		PropagationTimeout: env.GetOrDefaultSecond("VERCEL_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("VERCEL_POLLING_INTERVAL", 5*time.Second),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("VERCEL_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses the Vercel API to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	recordIDs   map[string]string
+	recordIDsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Vercel.
+// Credentials must be passed in the environment variable: VERCEL_API_TOKEN.
+// The team ID can be passed in the environment variable: VERCEL_TEAM_ID.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("VERCEL_API_TOKEN")
+	if err != nil {
+		return nil, fmt.Errorf("vercel: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.AuthToken = values["VERCEL_API_TOKEN"]
+	config.TeamID = env.GetOrFile("VERCEL_TEAM_ID")
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Vercel.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("vercel: the configuration of the DNS provider is nil")
+	}
+
+	if config.AuthToken == "" {
+		return nil, errors.New("vercel: credentials missing")
+	}
+
+	if config.TTL < minTTL {
+		return nil, fmt.Errorf("vercel: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	}
+
+	client := internal.NewClient(config.AuthToken, config.TeamID)
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{
+		config:    config,
+		client:    client,
+		recordIDs: make(map[string]string),
+	}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return fmt.Errorf("vercel: could not find zone for FQDN %q: %v", fqdn, err)
+	}
+
+	record := internal.Record{
+		Name:  extractRecordName(fqdn, authZone),
+		Type:  "TXT",
+		Value: value,
+		TTL:   d.config.TTL,
+	}
+
+	resp, err := d.client.CreateRecord(dns01.UnFqdn(authZone), record)
+	if err != nil {
+		return fmt.Errorf("vercel: %v", err)
+	}
+
+	d.recordIDsMu.Lock()
+	d.recordIDs[token] = resp.UID
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return fmt.Errorf("vercel: could not find zone for FQDN %q: %v", fqdn, err)
+	}
+
+	// gets the record's unique ID from when we created it
+	d.recordIDsMu.Lock()
+	recordID, ok := d.recordIDs[token]
+	d.recordIDsMu.Unlock()
+	if !ok {
+		return fmt.Errorf("vercel: unknown record ID for '%s'", fqdn)
+	}
+
+	err = d.client.DeleteRecord(dns01.UnFqdn(authZone), recordID)
+	if err != nil {
+		return fmt.Errorf("vercel: %v", err)
+	}
+
+	// deletes record ID from map
+	d.recordIDsMu.Lock()
+	delete(d.recordIDs, token)
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+func extractRecordName(fqdn, authZone string) string {
+	name := dns01.UnFqdn(fqdn)
+	if idx := strings.LastIndex(name, "."+dns01.UnFqdn(authZone)); idx != -1 {
+		return name[:idx]
+	}
+	return name
+}
@@ -0,0 +1,197 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// DefaultBaseURL the default base URL of the Vultr API v2.
+const DefaultBaseURL = "https://api.vultr.com/v2"
+
+// perPage the number of items per page of the lists.
+const perPage = 100
+
+// Domain a DNS domain.
+type Domain struct {
+	Domain      string `json:"domain"`
+	DateCreated string `json:"date_created,omitempty"`
+}
+
+// Record a DNS record, the name is relative to the domain (i.e. "_acme-challenge.sub").
+type Record struct {
+	ID       string `json:"id,omitempty"`
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Data     string `json:"data"`
+	Priority int    `json:"priority,omitempty"`
+	TTL      int    `json:"ttl,omitempty"`
+}
+
+type meta struct {
+	Total int `json:"total"`
+	Links struct {
+		Next string `json:"next"`
+		Prev string `json:"prev"`
+	} `json:"links"`
+}
+
+type domainsResponse struct {
+	Domains []Domain `json:"domains"`
+	Meta    meta     `json:"meta"`
+}
+
+type recordsResponse struct {
+	Records []Record `json:"records"`
+	Meta    meta     `json:"meta"`
+}
+
+type recordResponse struct {
+	Record Record `json:"record"`
+}
+
+type apiError struct {
+	Error  string `json:"error"`
+	Status int    `json:"status"`
+}
+
+// Client the Vultr API v2 client.
+type Client struct {
+	apiKey     string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a Vultr API v2 client.
+func NewClient(apiKey string) *Client {
+	return &Client{
+		apiKey:     apiKey,
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// ListDomains returns all the DNS domains of the account.
+func (c *Client) ListDomains() ([]Domain, error) {
+	var domains []Domain
+
+	cursor := ""
+	for {
+		var result domainsResponse
+		err := c.do(http.MethodGet, "/domains"+pageQuery(cursor), nil, &result)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list the domains: %v", err)
+		}
+
+		domains = append(domains, result.Domains...)
+
+		cursor = result.Meta.Links.Next
+		if cursor == "" {
+			return domains, nil
+		}
+	}
+}
+
+// ListRecords returns all the records of a domain.
+func (c *Client) ListRecords(domain string) ([]Record, error) {
+	var records []Record
+
+	cursor := ""
+	for {
+		var result recordsResponse
+		err := c.do(http.MethodGet, fmt.Sprintf("/domains/%s/records", domain)+pageQuery(cursor), nil, &result)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list the records of the domain %s: %v", domain, err)
+		}
+
+		records = append(records, result.Records...)
+
+		cursor = result.Meta.Links.Next
+		if cursor == "" {
+			return records, nil
+		}
+	}
+}
+
+// CreateRecord creates a record in a domain.
+func (c *Client) CreateRecord(domain string, record Record) (*Record, error) {
+	var result recordResponse
+	err := c.do(http.MethodPost, fmt.Sprintf("/domains/%s/records", domain), record, &result)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the record %s in the domain %s: %v", record.Name, domain, err)
+	}
+
+	return &result.Record, nil
+}
+
+// DeleteRecord deletes a record of a domain.
+func (c *Client) DeleteRecord(domain, recordID string) error {
+	err := c.do(http.MethodDelete, fmt.Sprintf("/domains/%s/records/%s", domain, recordID), nil, nil)
+	if err != nil {
+		return fmt.Errorf("unable to delete the record %s of the domain %s: %v", recordID, domain, err)
+	}
+
+	return nil
+}
+
+func pageQuery(cursor string) string {
+	query := url.Values{}
+	query.Set("per_page", strconv.Itoa(perPage))
+
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+
+	return "?" + query.Encode()
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body bytes.Buffer
+	if payload != nil {
+		err := json.NewEncoder(&body).Encode(payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+uri, &body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &apiError{}
+		if json.Unmarshal(raw, apiErr) == nil && apiErr.Error != "" {
+			return fmt.Errorf("%d: %s", resp.StatusCode, apiErr.Error)
+		}
+
+		return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	if result == nil || len(raw) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
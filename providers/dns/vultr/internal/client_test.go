@@ -0,0 +1,177 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest() (*Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client := NewClient("secret")
+	client.BaseURL = server.URL
+
+	return client, mux, server.Close
+}
+
+func checkAuth(rw http.ResponseWriter, req *http.Request) bool {
+	if req.Header.Get("Authorization") != "Bearer secret" {
+		rw.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(rw, `{"error":"Invalid API token.","status":401}`)
+		return false
+	}
+	return true
+}
+
+func TestClient_ListDomains(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/domains", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		if req.URL.Query().Get("per_page") != "100" {
+			http.Error(rw, fmt.Sprintf("invalid per_page: %s", req.URL.RawQuery), http.StatusBadRequest)
+			return
+		}
+
+		switch req.URL.Query().Get("cursor") {
+		case "":
+			fmt.Fprint(rw, `{"domains":[{"domain":"example.com","date_created":"2020-01-01T00:00:00+00:00"}],"meta":{"total":2,"links":{"next":"bmV4dA==","prev":""}}}`)
+		case "bmV4dA==":
+			fmt.Fprint(rw, `{"domains":[{"domain":"example.org","date_created":"2020-01-02T00:00:00+00:00"}],"meta":{"total":2,"links":{"next":"","prev":"cHJldg=="}}}`)
+		default:
+			http.Error(rw, "invalid cursor", http.StatusBadRequest)
+		}
+	})
+
+	domains, err := client.ListDomains()
+	require.NoError(t, err)
+
+	expected := []Domain{
+		{Domain: "example.com", DateCreated: "2020-01-01T00:00:00+00:00"},
+		{Domain: "example.org", DateCreated: "2020-01-02T00:00:00+00:00"},
+	}
+	assert.Equal(t, expected, domains)
+}
+
+func TestClient_ListDomains_error(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	client.apiKey = "invalid"
+
+	mux.HandleFunc("/domains", func(rw http.ResponseWriter, req *http.Request) {
+		checkAuth(rw, req)
+	})
+
+	_, err := client.ListDomains()
+	require.EqualError(t, err, "unable to list the domains: 401: Invalid API token.")
+}
+
+func TestClient_ListRecords(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/domains/example.com/records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		switch req.URL.Query().Get("cursor") {
+		case "":
+			fmt.Fprint(rw, `{"records":[{"id":"a","type":"A","name":"www","data":"10.0.0.1","priority":-1,"ttl":300}],"meta":{"total":2,"links":{"next":"bmV4dA==","prev":""}}}`)
+		case "bmV4dA==":
+			fmt.Fprint(rw, `{"records":[{"id":"b","type":"TXT","name":"_acme-challenge","data":"\"value\"","priority":-1,"ttl":120}],"meta":{"total":2,"links":{"next":"","prev":"cHJldg=="}}}`)
+		default:
+			http.Error(rw, "invalid cursor", http.StatusBadRequest)
+		}
+	})
+
+	records, err := client.ListRecords("example.com")
+	require.NoError(t, err)
+
+	expected := []Record{
+		{ID: "a", Type: "A", Name: "www", Data: "10.0.0.1", Priority: -1, TTL: 300},
+		{ID: "b", Type: "TXT", Name: "_acme-challenge", Data: `"value"`, Priority: -1, TTL: 120},
+	}
+	assert.Equal(t, expected, records)
+}
+
+func TestClient_CreateRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/domains/example.com/records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		record := Record{}
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := Record{Type: "TXT", Name: "_acme-challenge", Data: `"value"`, TTL: 120}
+		if record != expected {
+			http.Error(rw, fmt.Sprintf("unexpected record: %+v", record), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		fmt.Fprint(rw, `{"record":{"id":"abc","type":"TXT","name":"_acme-challenge","data":"\"value\"","priority":0,"ttl":120}}`)
+	})
+
+	record, err := client.CreateRecord("example.com", Record{Type: "TXT", Name: "_acme-challenge", Data: `"value"`, TTL: 120})
+	require.NoError(t, err)
+
+	expected := &Record{ID: "abc", Type: "TXT", Name: "_acme-challenge", Data: `"value"`, TTL: 120}
+	assert.Equal(t, expected, record)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/domains/example.com/records/abc", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	err := client.DeleteRecord("example.com", "abc")
+	require.NoError(t, err)
+}
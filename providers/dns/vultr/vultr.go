@@ -1,24 +1,23 @@
 // Package vultr implements a DNS provider for solving the DNS-01 challenge using the Vultr DNS.
-// See https://www.vultr.com/api/#dns
+// See https://www.vultr.com/api/#tag/dns
 package vultr
 
 import (
-	"context"
-	"crypto/tls"
 	"errors"
 	"fmt"
 	"net/http"
-	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v3/challenge/dns01"
 	"github.com/go-acme/lego/v3/platform/config/env"
-	"github.com/vultr/govultr"
+	"github.com/go-acme/lego/v3/providers/dns/vultr/internal"
 )
 
 // Config is used to configure the creation of the DNSProvider
 type Config struct {
+	BaseURL            string
 	APIKey             string
 	PropagationTimeout time.Duration
 	PollingInterval    time.Duration
@@ -29,23 +28,28 @@ type Config struct {
 // NewDefaultConfig returns a default configuration for the DNSProvider
 func NewDefaultConfig() *Config {
 	return &Config{
+		BaseURL:            env.GetOrDefaultString("VULTR_ENDPOINT", internal.DefaultBaseURL),
 		TTL:                env.GetOrDefaultInt("VULTR_TTL", dns01.DefaultTTL),
 		PropagationTimeout: env.GetOrDefaultSecond("VULTR_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
 		PollingInterval:    env.GetOrDefaultSecond("VULTR_POLLING_INTERVAL", dns01.DefaultPollingInterval),
 		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond("VULTR_HTTP_TIMEOUT", 0),
-			// from Vultr Client
-			Transport: &http.Transport{
-				TLSNextProto: make(map[string]func(string, *tls.Conn) http.RoundTripper),
-			},
+			Timeout: env.GetOrDefaultSecond("VULTR_HTTP_TIMEOUT", 30*time.Second),
 		},
 	}
 }
 
+type recordRef struct {
+	domain   string
+	recordID string
+}
+
 // DNSProvider is an implementation of the acme.ChallengeProvider interface.
 type DNSProvider struct {
 	config *Config
-	client *govultr.Client
+	client *internal.Client
+
+	records   map[string]recordRef
+	recordsMu sync.Mutex
 }
 
 // NewDNSProvider returns a DNSProvider instance with a configured Vultr client.
@@ -72,55 +76,78 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, fmt.Errorf("vultr: credentials missing")
 	}
 
-	client := govultr.NewClient(config.HTTPClient, config.APIKey)
+	client := internal.NewClient(config.APIKey)
 
-	return &DNSProvider{client: client, config: config}, nil
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{
+		config:  config,
+		client:  client,
+		records: make(map[string]recordRef),
+	}, nil
 }
 
 // Present creates a TXT record to fulfill the DNS-01 challenge.
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
-	ctx := context.Background()
-
 	fqdn, value := dns01.GetRecord(domain, keyAuth)
 
-	zoneDomain, err := d.getHostedZone(ctx, domain)
+	zoneDomain, err := d.getHostedZone(fqdn)
 	if err != nil {
 		return fmt.Errorf("vultr: %v", err)
 	}
 
-	name := d.extractRecordName(fqdn, zoneDomain)
+	record := internal.Record{
+		Type: "TXT",
+		Name: d.extractRecordName(fqdn, zoneDomain),
+		Data: `"` + value + `"`,
+		TTL:  d.config.TTL,
+	}
 
-	err = d.client.DNSRecord.Create(ctx, zoneDomain, "TXT", name, `"`+value+`"`, d.config.TTL, 0)
+	newRecord, err := d.client.CreateRecord(zoneDomain, record)
 	if err != nil {
-		return fmt.Errorf("vultr: API call failed: %v", err)
+		return fmt.Errorf("vultr: %v", err)
 	}
 
+	d.recordsMu.Lock()
+	d.records[token] = recordRef{domain: zoneDomain, recordID: newRecord.ID}
+	d.recordsMu.Unlock()
+
 	return nil
 }
 
 // CleanUp removes the TXT record matching the specified parameters.
+// The record created by Present is deleted by its ID,
+// a record created by another instance of the provider is found by its name and its value.
 func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
-	ctx := context.Background()
-
-	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
 
-	zoneDomain, records, err := d.findTxtRecords(ctx, domain, fqdn)
-	if err != nil {
-		return fmt.Errorf("vultr: %v", err)
-	}
+	d.recordsMu.Lock()
+	ref, ok := d.records[token]
+	d.recordsMu.Unlock()
 
-	var allErr []string
-	for _, rec := range records {
-		err := d.client.DNSRecord.Delete(ctx, zoneDomain, strconv.Itoa(rec.RecordID))
+	if !ok {
+		var err error
+		ref, err = d.findTxtRecord(fqdn, value)
 		if err != nil {
-			allErr = append(allErr, err.Error())
+			return fmt.Errorf("vultr: %v", err)
 		}
 	}
 
-	if len(allErr) > 0 {
-		return errors.New(strings.Join(allErr, ": "))
+	err := d.client.DeleteRecord(ref.domain, ref.recordID)
+	if err != nil {
+		return fmt.Errorf("vultr: %v", err)
 	}
 
+	d.recordsMu.Lock()
+	delete(d.records, token)
+	d.recordsMu.Unlock()
+
 	return nil
 }
 
@@ -130,52 +157,53 @@ func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
 	return d.config.PropagationTimeout, d.config.PollingInterval
 }
 
-func (d *DNSProvider) getHostedZone(ctx context.Context, domain string) (string, error) {
-	domains, err := d.client.DNSDomain.List(ctx)
+// getHostedZone returns the longest domain of the account matching the FQDN.
+func (d *DNSProvider) getHostedZone(fqdn string) (string, error) {
+	domains, err := d.client.ListDomains()
 	if err != nil {
-		return "", fmt.Errorf("API call failed: %v", err)
+		return "", err
 	}
 
-	var hostedDomain govultr.DNSDomain
+	name := dns01.UnFqdn(fqdn)
+
+	var hostedDomain string
 	for _, dom := range domains {
-		if strings.HasSuffix(domain, dom.Domain) {
-			if len(dom.Domain) > len(hostedDomain.Domain) {
-				hostedDomain = dom
-			}
+		if (name == dom.Domain || strings.HasSuffix(name, "."+dom.Domain)) && len(dom.Domain) > len(hostedDomain) {
+			hostedDomain = dom.Domain
 		}
 	}
-	if hostedDomain.Domain == "" {
-		return "", fmt.Errorf("no matching Vultr domain found for domain %s", domain)
+
+	if hostedDomain == "" {
+		return "", fmt.Errorf("no matching Vultr domain found for %s", fqdn)
 	}
 
-	return hostedDomain.Domain, nil
+	return hostedDomain, nil
 }
 
-func (d *DNSProvider) findTxtRecords(ctx context.Context, domain, fqdn string) (string, []govultr.DNSRecord, error) {
-	zoneDomain, err := d.getHostedZone(ctx, domain)
+func (d *DNSProvider) findTxtRecord(fqdn, value string) (recordRef, error) {
+	zoneDomain, err := d.getHostedZone(fqdn)
 	if err != nil {
-		return "", nil, err
+		return recordRef{}, err
 	}
 
-	var records []govultr.DNSRecord
-	result, err := d.client.DNSRecord.List(ctx, zoneDomain)
+	records, err := d.client.ListRecords(zoneDomain)
 	if err != nil {
-		return "", records, fmt.Errorf("API call has failed: %v", err)
+		return recordRef{}, err
 	}
 
 	recordName := d.extractRecordName(fqdn, zoneDomain)
-	for _, record := range result {
-		if record.Type == "TXT" && record.Name == recordName {
-			records = append(records, record)
+	for _, record := range records {
+		if record.Type == "TXT" && record.Name == recordName && strings.Trim(record.Data, `"`) == value {
+			return recordRef{domain: zoneDomain, recordID: record.ID}, nil
 		}
 	}
 
-	return zoneDomain, records, nil
+	return recordRef{}, fmt.Errorf("no TXT record found for %s", fqdn)
 }
 
 func (d *DNSProvider) extractRecordName(fqdn, domain string) string {
 	name := dns01.UnFqdn(fqdn)
-	if idx := strings.Index(name, "."+domain); idx != -1 {
+	if idx := strings.LastIndex(name, "."+domain); idx != -1 {
 		return name[:idx]
 	}
 	return name
@@ -1,10 +1,15 @@
 package vultr
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/go-acme/lego/v3/challenge/dns01"
 	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -88,6 +93,101 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func setupTest() (*DNSProvider, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	config := NewDefaultConfig()
+	config.APIKey = "secret"
+	config.BaseURL = server.URL
+
+	p, err := NewDNSProviderConfig(config)
+	if err != nil {
+		panic(err)
+	}
+
+	mux.HandleFunc("/domains", func(rw http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(rw, `{"domains":[{"domain":"example.com"},{"domain":"sub.example.com"},{"domain":"example.org"}],"meta":{"total":3,"links":{"next":"","prev":""}}}`)
+	})
+
+	return p, mux, server.Close
+}
+
+func TestDNSProvider_Present_CleanUp(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/domains/sub.example.com/records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		fmt.Fprint(rw, `{"record":{"id":"abc","type":"TXT","name":"_acme-challenge.www","data":"\"value\"","priority":0,"ttl":120}}`)
+	})
+
+	var deleted bool
+	mux.HandleFunc("/domains/sub.example.com/records/abc", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		deleted = true
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	err := provider.Present("www.sub.example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Equal(t, recordRef{domain: "sub.example.com", recordID: "abc"}, provider.records["token"])
+
+	err = provider.CleanUp("www.sub.example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.True(t, deleted)
+	assert.Empty(t, provider.records)
+}
+
+func TestDNSProvider_CleanUp_unknownRecordID(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	_, value := dns01.GetRecord("example.com", "keyAuth")
+
+	mux.HandleFunc("/domains/example.com/records", func(rw http.ResponseWriter, req *http.Request) {
+		fmt.Fprintf(rw, `{"records":[
+{"id":"a","type":"TXT","name":"_acme-challenge","data":"\"other\"","ttl":120},
+{"id":"b","type":"TXT","name":"_acme-challenge","data":"\"%s\"","ttl":120}
+],"meta":{"total":2,"links":{"next":"","prev":""}}}`, value)
+	})
+
+	var deleted bool
+	mux.HandleFunc("/domains/example.com/records/b", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		deleted = true
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	err := provider.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.True(t, deleted)
+}
+
+func TestDNSProvider_Present_unknownDomain(t *testing.T) {
+	provider, _, tearDown := setupTest()
+	defer tearDown()
+
+	err := provider.Present("example.net", "token", "keyAuth")
+	require.EqualError(t, err, "vultr: no matching Vultr domain found for _acme-challenge.example.net.")
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
@@ -0,0 +1,158 @@
+package internal
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL the default base URL of the Websupport API.
+const DefaultBaseURL = "https://rest.websupport.sk"
+
+// StatusSuccess the status of a successful operation.
+const StatusSuccess = "success"
+
+// Record a DNS record, the name is relative to the zone (i.e. "_acme-challenge.sub").
+type Record struct {
+	ID      int    `json:"id,omitempty"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
+	TTL     int    `json:"ttl,omitempty"`
+}
+
+// Response the response of an operation on a record.
+type Response struct {
+	Status string              `json:"status"`
+	Item   *Record             `json:"item"`
+	Errors map[string][]string `json:"errors"`
+}
+
+func (r *Response) error() error {
+	if r.Status == StatusSuccess {
+		return nil
+	}
+
+	var msgs []string
+	for field, errs := range r.Errors {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", field, strings.Join(errs, ", ")))
+	}
+	sort.Strings(msgs)
+
+	return fmt.Errorf("status %s: %s", r.Status, strings.Join(msgs, "; "))
+}
+
+// Client the Websupport API client.
+type Client struct {
+	apiKey     string
+	secret     string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a Websupport API client.
+func NewClient(apiKey, secret string) *Client {
+	return &Client{
+		apiKey:     apiKey,
+		secret:     secret,
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// AddRecord adds a record to a zone.
+func (c *Client) AddRecord(zone string, record Record) (*Record, error) {
+	resp := &Response{}
+	err := c.do(http.MethodPost, fmt.Sprintf("/v1/user/self/zone/%s/record", zone), record, resp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to add the record %s to the zone %s: %v", record.Name, zone, err)
+	}
+
+	if resp.Item == nil {
+		return nil, fmt.Errorf("unable to add the record %s to the zone %s: missing record", record.Name, zone)
+	}
+
+	return resp.Item, nil
+}
+
+// DeleteRecord deletes a record of a zone.
+func (c *Client) DeleteRecord(zone string, recordID int) error {
+	err := c.do(http.MethodDelete, fmt.Sprintf("/v1/user/self/zone/%s/record/%d", zone, recordID), nil, &Response{})
+	if err != nil {
+		return fmt.Errorf("unable to delete the record %d of the zone %s: %v", recordID, zone, err)
+	}
+
+	return nil
+}
+
+func (c *Client) do(method, uri string, payload interface{}, result *Response) error {
+	var body bytes.Buffer
+	if payload != nil {
+		err := json.NewEncoder(&body).Encode(payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+uri, &body)
+	if err != nil {
+		return err
+	}
+
+	c.sign(req, time.Now())
+
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	// the validation errors are returned with the status 400 and a JSON body.
+	if resp.StatusCode/100 != 2 && resp.StatusCode != http.StatusBadRequest {
+		return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	err = json.Unmarshal(raw, result)
+	if err != nil {
+		return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	return result.error()
+}
+
+// sign adds the authentication to the request:
+// the user is the API key, and the password is the hex encoded HMAC-SHA1 (with the secret)
+// of the method, the path, and the timestamp (separated by spaces).
+// The date of the request must be the same as the timestamp.
+func (c *Client) sign(req *http.Request, now time.Time) {
+	timestamp := now.Unix()
+
+	req.SetBasicAuth(c.apiKey, signature(c.secret, req.Method, req.URL.Path, timestamp))
+	req.Header.Set("Date", now.UTC().Format(time.RFC3339))
+}
+
+func signature(secret, method, path string, timestamp int64) string {
+	mac := hmac.New(sha1.New, []byte(secret))
+	_, _ = mac.Write([]byte(method + " " + path + " " + strconv.FormatInt(timestamp, 10)))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest() (*Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client := NewClient("key", "secret")
+	client.BaseURL = server.URL
+
+	return client, mux, server.Close
+}
+
+func checkAuth(rw http.ResponseWriter, req *http.Request) bool {
+	date, err := time.Parse(time.RFC3339, req.Header.Get("Date"))
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return false
+	}
+
+	username, password, ok := req.BasicAuth()
+	if !ok || username != "key" || password != signature("secret", req.Method, req.URL.Path, date.Unix()) {
+		rw.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(rw, `{"code":401,"message":"Bad credentials"}`)
+		return false
+	}
+	return true
+}
+
+func Test_signature(t *testing.T) {
+	sig := signature("secret", http.MethodGet, "/v1/user/self", 1580000000)
+	assert.Equal(t, "adc745c36e382d054893155e6bfc4bdde814a588", sig)
+}
+
+func TestClient_AddRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/v1/user/self/zone/example.com/record", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		record := Record{}
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := Record{Type: "TXT", Name: "_acme-challenge", Content: "value", TTL: 600}
+		if record != expected {
+			http.Error(rw, fmt.Sprintf("unexpected record: %+v", record), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		fmt.Fprint(rw, `{"status":"success","item":{"id":123,"type":"TXT","name":"_acme-challenge","content":"value","ttl":600},"errors":{}}`)
+	})
+
+	record, err := client.AddRecord("example.com", Record{Type: "TXT", Name: "_acme-challenge", Content: "value", TTL: 600})
+	require.NoError(t, err)
+
+	expected := &Record{ID: 123, Type: "TXT", Name: "_acme-challenge", Content: "value", TTL: 600}
+	assert.Equal(t, expected, record)
+}
+
+func TestClient_AddRecord_validation_error(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/v1/user/self/zone/example.com/record", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(rw, `{"status":"error","item":{"type":"TXT","name":"_acme-challenge","content":"","ttl":600},"errors":{"content":["Content is required."]}}`)
+	})
+
+	_, err := client.AddRecord("example.com", Record{Type: "TXT", Name: "_acme-challenge", TTL: 600})
+	require.EqualError(t, err, "unable to add the record _acme-challenge to the zone example.com: status error: content: Content is required.")
+}
+
+func TestClient_AddRecord_auth_error(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	client.secret = "invalid"
+
+	mux.HandleFunc("/v1/user/self/zone/example.com/record", func(rw http.ResponseWriter, req *http.Request) {
+		checkAuth(rw, req)
+	})
+
+	_, err := client.AddRecord("example.com", Record{Type: "TXT", Name: "_acme-challenge", Content: "value", TTL: 600})
+	require.EqualError(t, err, `unable to add the record _acme-challenge to the zone example.com: 401: {"code":401,"message":"Bad credentials"}`)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/v1/user/self/zone/example.com/record/123", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkAuth(rw, req) {
+			return
+		}
+
+		fmt.Fprint(rw, `{"status":"success","item":{"id":123,"type":"TXT","name":"_acme-challenge","content":"value","ttl":600},"errors":{}}`)
+	})
+
+	err := client.DeleteRecord("example.com", 123)
+	require.NoError(t, err)
+}
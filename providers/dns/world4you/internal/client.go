@@ -0,0 +1,287 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io/ioutil"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// DefaultBaseURL the default base URL of the World4You customer panel.
+const DefaultBaseURL = "https://my.world4you.com/en"
+
+var (
+	inputTagRegexp   = regexp.MustCompile(`<input[^>]*>`)
+	nameAttrRegexp   = regexp.MustCompile(`\sname="([^"]*)"`)
+	valueAttrRegexp  = regexp.MustCompile(`\svalue="([^"]*)"`)
+	packageRegexp    = regexp.MustCompile(`href="[^"]*/(\d+)/dns"`)
+	recordAttrRegexp = regexp.MustCompile(`data-record="([^"]*)"`)
+	alertRegexp      = regexp.MustCompile(`(?s)<div[^>]*class="[^"]*alert-danger[^"]*"[^>]*>(.*?)</div>`)
+	tagRegexp        = regexp.MustCompile(`<[^>]*>`)
+)
+
+// Record a DNS record as displayed in the DNS page of a package, the name is the FQDN without the trailing dot.
+type Record struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// DNSPage the DNS page of a package: the records and the hidden fields of the forms.
+type DNSPage struct {
+	Records []Record
+	Fields  map[string]string
+}
+
+// Client the World4You client.
+// World4You doesn't provide an API: the client uses a session of the customer panel, and submits its forms.
+type Client struct {
+	username   string
+	password   string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a World4You client.
+// The HTTP client must keep the cookies of the session: a cookie jar is added if it doesn't have one.
+func NewClient(username, password string, httpClient *http.Client) (*Client, error) {
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+
+	if httpClient.Jar == nil {
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		client := *httpClient
+		client.Jar = jar
+		httpClient = &client
+	}
+
+	return &Client{
+		username:   username,
+		password:   password,
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: httpClient,
+	}, nil
+}
+
+// Login opens a session with the credentials.
+func (c *Client) Login() error {
+	page, err := c.get("/login")
+	if err != nil {
+		return fmt.Errorf("unable to get the login page: %v", err)
+	}
+
+	fields := extractFields(page, "_")
+
+	form := url.Values{}
+	form.Set("_username", c.username)
+	form.Set("_password", c.password)
+	form.Set("_csrf_token", fields["_csrf_token"])
+
+	page, err = c.post("/login", form)
+	if err != nil {
+		return fmt.Errorf("unable to login: %v", err)
+	}
+
+	// the login page is displayed again when the credentials are invalid.
+	if strings.Contains(page, `name="_password"`) {
+		return errors.New("unable to login: invalid credentials")
+	}
+
+	return nil
+}
+
+// FindPackageID returns the ID of the package (product) containing the zone.
+func (c *Client) FindPackageID(zone string) (string, error) {
+	page, err := c.get("/")
+	if err != nil {
+		return "", fmt.Errorf("unable to get the packages: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	for _, match := range packageRegexp.FindAllStringSubmatch(page, -1) {
+		packageID := match[1]
+		if seen[packageID] {
+			continue
+		}
+		seen[packageID] = true
+
+		dnsPage, err := c.GetDNSPage(packageID)
+		if err != nil {
+			return "", err
+		}
+
+		for _, record := range dnsPage.Records {
+			if strings.EqualFold(record.Name, zone) || strings.HasSuffix(strings.ToLower(record.Name), "."+strings.ToLower(zone)) {
+				return packageID, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("no package found for the zone %s", zone)
+}
+
+// GetDNSPage returns the DNS page of a package.
+func (c *Client) GetDNSPage(packageID string) (*DNSPage, error) {
+	page, err := c.get(fmt.Sprintf("/%s/dns", packageID))
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the DNS page of the package %s: %v", packageID, err)
+	}
+
+	records, err := extractRecords(page)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the DNS page of the package %s: %v", packageID, err)
+	}
+
+	return &DNSPage{Records: records, Fields: extractFields(page, "")}, nil
+}
+
+// AddTXTRecord adds a TXT record to a package, the name is relative to the zone (i.e. "_acme-challenge.sub").
+func (c *Client) AddTXTRecord(packageID, name, value string) error {
+	dnsPage, err := c.GetDNSPage(packageID)
+	if err != nil {
+		return err
+	}
+
+	form := url.Values{}
+	for _, field := range []string{"AddDnsRecordForm[uniqueFormIdDP]", "AddDnsRecordForm[uniqueFormIdTTL]", "AddDnsRecordForm[_token]"} {
+		form.Set(field, dnsPage.Fields[field])
+	}
+	form.Set("AddDnsRecordForm[name]", name)
+	form.Set("AddDnsRecordForm[dnsType][type]", "TXT")
+	form.Set("AddDnsRecordForm[value]", value)
+	form.Set("AddDnsRecordForm[aktivPaket]", packageID)
+
+	page, err := c.post(fmt.Sprintf("/%s/dns/record/add", packageID), form)
+	if err != nil {
+		return fmt.Errorf("unable to add the TXT record %s: %v", name, err)
+	}
+
+	if msg := extractAlert(page); msg != "" {
+		return fmt.Errorf("unable to add the TXT record %s: %s", name, msg)
+	}
+
+	return nil
+}
+
+// DeleteRecord deletes a record of a package.
+func (c *Client) DeleteRecord(packageID, recordID string) error {
+	dnsPage, err := c.GetDNSPage(packageID)
+	if err != nil {
+		return err
+	}
+
+	form := url.Values{}
+	form.Set("DeleteDnsRecordForm[recordId]", recordID)
+	form.Set("DeleteDnsRecordForm[aktivPaket]", packageID)
+	form.Set("DeleteDnsRecordForm[_token]", dnsPage.Fields["DeleteDnsRecordForm[_token]"])
+
+	page, err := c.post(fmt.Sprintf("/%s/dns/record/delete", packageID), form)
+	if err != nil {
+		return fmt.Errorf("unable to delete the record %s: %v", recordID, err)
+	}
+
+	if msg := extractAlert(page); msg != "" {
+		return fmt.Errorf("unable to delete the record %s: %s", recordID, msg)
+	}
+
+	return nil
+}
+
+func (c *Client) get(uri string) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, c.BaseURL+uri, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return c.do(req)
+}
+
+func (c *Client) post(uri string, form url.Values) (string, error) {
+	req, err := http.NewRequest(http.MethodPost, c.BaseURL+uri, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return c.do(req)
+}
+
+func (c *Client) do(req *http.Request) (string, error) {
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		return "", fmt.Errorf("%d: %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+
+	return string(raw), nil
+}
+
+// extractFields returns the names and the values of the inputs of a page, the names are filtered by prefix.
+func extractFields(page, prefix string) map[string]string {
+	fields := make(map[string]string)
+
+	for _, tag := range inputTagRegexp.FindAllString(page, -1) {
+		name := nameAttrRegexp.FindStringSubmatch(tag)
+		if name == nil || !strings.HasPrefix(html.UnescapeString(name[1]), prefix) {
+			continue
+		}
+
+		var value string
+		if match := valueAttrRegexp.FindStringSubmatch(tag); match != nil {
+			value = html.UnescapeString(match[1])
+		}
+
+		fields[html.UnescapeString(name[1])] = value
+	}
+
+	return fields
+}
+
+// extractRecords returns the records of a DNS page: each record is a JSON object in a "data-record" attribute.
+func extractRecords(page string) ([]Record, error) {
+	var records []Record
+
+	for _, match := range recordAttrRegexp.FindAllStringSubmatch(page, -1) {
+		var record Record
+		err := json.Unmarshal([]byte(html.UnescapeString(match[1])), &record)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the record %q: %v", match[1], err)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// extractAlert returns the text of the error alert of a page, if any.
+func extractAlert(page string) string {
+	match := alertRegexp.FindStringSubmatch(page)
+	if match == nil {
+		return ""
+	}
+
+	return strings.Join(strings.Fields(html.UnescapeString(tagRegexp.ReplaceAllString(match[1], " "))), " ")
+}
@@ -0,0 +1,215 @@
+package internal
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const loginPage = `<form method="post" action="/en/login">
+<input type="hidden" name="_csrf_token" value="csrf">
+<input type="text" name="_username" value="">
+<input type="password" name="_password">
+</form>`
+
+const dnsPageTemplate = `<table>
+<tr data-record="%s"><td>example.com</td></tr>
+<tr data-record="%s"><td>_acme-challenge.example.com</td></tr>
+</table>
+<form method="post" action="/en/1234/dns/record/add">
+<input type="hidden" name="AddDnsRecordForm[uniqueFormIdDP]" value="dp">
+<input type="hidden" name="AddDnsRecordForm[uniqueFormIdTTL]" value="ttl">
+<input type="hidden" name="AddDnsRecordForm[_token]" value="add-token">
+</form>
+<form method="post" action="/en/1234/dns/record/delete">
+<input type="hidden" name="DeleteDnsRecordForm[_token]" value="delete-token">
+</form>`
+
+func setupTest() (*Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc("/en/login", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodPost &&
+			req.PostFormValue("_username") == "user" && req.PostFormValue("_password") == "secret" && req.PostFormValue("_csrf_token") == "csrf" {
+			http.SetCookie(rw, &http.Cookie{Name: "session", Value: "abc", Path: "/"})
+			fmt.Fprint(rw, `<a href="/en/logout">Logout</a>`)
+			return
+		}
+
+		fmt.Fprint(rw, loginPage)
+	})
+
+	mux.HandleFunc("/en/1234/dns", func(rw http.ResponseWriter, req *http.Request) {
+		if !checkSession(rw, req) {
+			return
+		}
+
+		fmt.Fprintf(rw, dnsPageTemplate,
+			html.EscapeString(`{"id":"1","name":"example.com","type":"A","value":"10.0.0.1"}`),
+			html.EscapeString(`{"id":"2","name":"_acme-challenge.example.com","type":"TXT","value":"value"}`))
+	})
+
+	client, err := NewClient("user", "secret", server.Client())
+	if err != nil {
+		panic(err)
+	}
+
+	client.BaseURL = server.URL + "/en"
+
+	return client, mux, server.Close
+}
+
+func checkSession(rw http.ResponseWriter, req *http.Request) bool {
+	cookie, err := req.Cookie("session")
+	if err != nil || cookie.Value != "abc" {
+		fmt.Fprint(rw, loginPage)
+		return false
+	}
+	return true
+}
+
+func TestClient_Login(t *testing.T) {
+	client, _, tearDown := setupTest()
+	defer tearDown()
+
+	err := client.Login()
+	require.NoError(t, err)
+}
+
+func TestClient_Login_error(t *testing.T) {
+	client, _, tearDown := setupTest()
+	defer tearDown()
+
+	client.password = "invalid"
+
+	err := client.Login()
+	require.EqualError(t, err, "unable to login: invalid credentials")
+}
+
+func TestClient_FindPackageID(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/en/", func(rw http.ResponseWriter, req *http.Request) {
+		if !checkSession(rw, req) {
+			return
+		}
+
+		fmt.Fprint(rw, `<a href="/en/1234/dns">DNS</a><a href="/en/1234/dns">DNS</a>`)
+	})
+
+	require.NoError(t, client.Login())
+
+	packageID, err := client.FindPackageID("example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "1234", packageID)
+
+	_, err = client.FindPackageID("example.org")
+	require.EqualError(t, err, "no package found for the zone example.org")
+}
+
+func TestClient_GetDNSPage(t *testing.T) {
+	client, _, tearDown := setupTest()
+	defer tearDown()
+
+	require.NoError(t, client.Login())
+
+	dnsPage, err := client.GetDNSPage("1234")
+	require.NoError(t, err)
+
+	expected := &DNSPage{
+		Records: []Record{
+			{ID: "1", Name: "example.com", Type: "A", Value: "10.0.0.1"},
+			{ID: "2", Name: "_acme-challenge.example.com", Type: "TXT", Value: "value"},
+		},
+		Fields: map[string]string{
+			"AddDnsRecordForm[uniqueFormIdDP]":  "dp",
+			"AddDnsRecordForm[uniqueFormIdTTL]": "ttl",
+			"AddDnsRecordForm[_token]":          "add-token",
+			"DeleteDnsRecordForm[_token]":       "delete-token",
+		},
+	}
+	assert.Equal(t, expected, dnsPage)
+}
+
+func TestClient_AddTXTRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/en/1234/dns/record/add", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkSession(rw, req) {
+			return
+		}
+
+		expected := map[string]string{
+			"AddDnsRecordForm[name]":            "_acme-challenge",
+			"AddDnsRecordForm[dnsType][type]":   "TXT",
+			"AddDnsRecordForm[value]":           "value",
+			"AddDnsRecordForm[aktivPaket]":      "1234",
+			"AddDnsRecordForm[uniqueFormIdDP]":  "dp",
+			"AddDnsRecordForm[uniqueFormIdTTL]": "ttl",
+			"AddDnsRecordForm[_token]":          "add-token",
+		}
+
+		for key, value := range expected {
+			if req.PostFormValue(key) != value {
+				fmt.Fprintf(rw, `<div class="alert alert-danger"><p>Invalid %s</p></div>`, html.EscapeString(key))
+				return
+			}
+		}
+
+		fmt.Fprint(rw, `<div class="alert alert-success">The record has been created.</div>`)
+	})
+
+	require.NoError(t, client.Login())
+
+	err := client.AddTXTRecord("1234", "_acme-challenge", "value")
+	require.NoError(t, err)
+
+	err = client.AddTXTRecord("1234", "_acme-challenge", "other")
+	require.EqualError(t, err, "unable to add the TXT record _acme-challenge: Invalid AddDnsRecordForm[value]")
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/en/1234/dns/record/delete", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !checkSession(rw, req) {
+			return
+		}
+
+		if req.PostFormValue("DeleteDnsRecordForm[recordId]") != "2" ||
+			req.PostFormValue("DeleteDnsRecordForm[aktivPaket]") != "1234" ||
+			req.PostFormValue("DeleteDnsRecordForm[_token]") != "delete-token" {
+			fmt.Fprint(rw, `<div class="alert alert-danger">The record cannot be deleted.</div>`)
+			return
+		}
+
+		fmt.Fprint(rw, `<div class="alert alert-success">The record has been deleted.</div>`)
+	})
+
+	require.NoError(t, client.Login())
+
+	err := client.DeleteRecord("1234", "2")
+	require.NoError(t, err)
+
+	err = client.DeleteRecord("1234", "1")
+	require.EqualError(t, err, "unable to delete the record 1: The record cannot be deleted.")
+}
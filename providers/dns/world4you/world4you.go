@@ -0,0 +1,199 @@
+// Package world4you implements a DNS provider for solving the DNS-01 challenge using World4You.
+package world4you
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/world4you/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL            string
+	Username           string
+	Password           string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString("WORLD4YOU_ENDPOINT", internal.DefaultBaseURL),
+		PropagationTimeout: env.GetOrDefaultSecond("WORLD4YOU_PROPAGATION_TIMEOUT", 10*time.Minute),
+		PollingInterval:    env.GetOrDefaultSecond("WORLD4YOU_POLLING_INTERVAL", 10*time.Second),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("WORLD4YOU_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses the customer panel of World4You to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	// the forms of the customer panel share the session: the requests must not be interleaved.
+	mu       sync.Mutex
+	loggedIn bool
+
+	// findZoneByFqdn determines the DNS zone of an fqdn. It is overridden during tests.
+	findZoneByFqdn func(fqdn string) (string, error)
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for World4You.
+// Credentials must be passed in the environment variables: WORLD4YOU_USERNAME and WORLD4YOU_PASSWORD.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("WORLD4YOU_USERNAME", "WORLD4YOU_PASSWORD")
+	if err != nil {
+		return nil, fmt.Errorf("world4you: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.Username = values["WORLD4YOU_USERNAME"]
+	config.Password = values["WORLD4YOU_PASSWORD"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for World4You.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("world4you: the configuration of the DNS provider is nil")
+	}
+
+	if config.Username == "" || config.Password == "" {
+		return nil, errors.New("world4you: credentials missing")
+	}
+
+	client, err := internal.NewClient(config.Username, config.Password, config.HTTPClient)
+	if err != nil {
+		return nil, fmt.Errorf("world4you: %v", err)
+	}
+
+	if config.BaseURL != "" {
+		client.BaseURL = strings.TrimSuffix(config.BaseURL, "/")
+	}
+
+	return &DNSProvider{
+		config:         config,
+		client:         client,
+		findZoneByFqdn: dns01.FindZoneByFqdn,
+	}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.getZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("world4you: %v", err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	packageID, err := d.findPackageID(zone)
+	if err != nil {
+		return fmt.Errorf("world4you: %v", err)
+	}
+
+	err = d.client.AddTXTRecord(packageID, extractRecordName(fqdn, zone), value)
+	if err != nil {
+		return fmt.Errorf("world4you: %v", err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.getZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("world4you: %v", err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	packageID, err := d.findPackageID(zone)
+	if err != nil {
+		return fmt.Errorf("world4you: %v", err)
+	}
+
+	dnsPage, err := d.client.GetDNSPage(packageID)
+	if err != nil {
+		return fmt.Errorf("world4you: %v", err)
+	}
+
+	name := dns01.UnFqdn(fqdn)
+
+	var recordID string
+	for _, record := range dnsPage.Records {
+		if record.Type == "TXT" && strings.EqualFold(record.Name, name) && record.Value == value {
+			recordID = record.ID
+			break
+		}
+	}
+
+	if recordID == "" {
+		return fmt.Errorf("world4you: no TXT record found for %s", name)
+	}
+
+	err = d.client.DeleteRecord(packageID, recordID)
+	if err != nil {
+		return fmt.Errorf("world4you: %v", err)
+	}
+
+	return nil
+}
+
+// findPackageID opens a session if needed, and returns the ID of the package containing the zone.
+func (d *DNSProvider) findPackageID(zone string) (string, error) {
+	if !d.loggedIn {
+		err := d.client.Login()
+		if err != nil {
+			return "", err
+		}
+
+		d.loggedIn = true
+	}
+
+	return d.client.FindPackageID(zone)
+}
+
+// getZone returns the zone of the FQDN without the trailing dot.
+func (d *DNSProvider) getZone(fqdn string) (string, error) {
+	authZone, err := d.findZoneByFqdn(fqdn)
+	if err != nil {
+		return "", fmt.Errorf("could not find zone for FQDN %q: %v", fqdn, err)
+	}
+
+	return dns01.UnFqdn(authZone), nil
+}
+
+func extractRecordName(fqdn, zone string) string {
+	name := dns01.UnFqdn(fqdn)
+	if idx := strings.LastIndex(name, "."+zone); idx != -1 {
+		return name[:idx]
+	}
+	return name
+}
@@ -0,0 +1,228 @@
+package world4you
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest(
+	"WORLD4YOU_USERNAME",
+	"WORLD4YOU_PASSWORD").
+	WithDomain("WORLD4YOU_DOMAIN")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"WORLD4YOU_USERNAME": "user",
+				"WORLD4YOU_PASSWORD": "secret",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				"WORLD4YOU_USERNAME": "",
+				"WORLD4YOU_PASSWORD": "",
+			},
+			expected: "world4you: some credentials information are missing: WORLD4YOU_USERNAME,WORLD4YOU_PASSWORD",
+		},
+		{
+			desc: "missing username",
+			envVars: map[string]string{
+				"WORLD4YOU_USERNAME": "",
+				"WORLD4YOU_PASSWORD": "secret",
+			},
+			expected: "world4you: some credentials information are missing: WORLD4YOU_USERNAME",
+		},
+		{
+			desc: "missing password",
+			envVars: map[string]string{
+				"WORLD4YOU_USERNAME": "user",
+				"WORLD4YOU_PASSWORD": "",
+			},
+			expected: "world4you: some credentials information are missing: WORLD4YOU_PASSWORD",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		username string
+		password string
+		expected string
+	}{
+		{
+			desc:     "success",
+			username: "user",
+			password: "secret",
+		},
+		{
+			desc:     "missing credentials",
+			expected: "world4you: credentials missing",
+		},
+		{
+			desc:     "missing password",
+			username: "user",
+			expected: "world4you: credentials missing",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.Username = test.username
+			config.Password = test.password
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func setupTest() (*DNSProvider, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc("/en/login", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodPost && req.PostFormValue("_password") == "secret" {
+			fmt.Fprint(rw, `<a href="/en/logout">Logout</a>`)
+			return
+		}
+
+		fmt.Fprint(rw, `<input type="hidden" name="_csrf_token" value="csrf"><input type="password" name="_password">`)
+	})
+
+	mux.HandleFunc("/en/", func(rw http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(rw, `<a href="/en/1234/dns">DNS</a>`)
+	})
+
+	mux.HandleFunc("/en/1234/dns", func(rw http.ResponseWriter, req *http.Request) {
+		fmt.Fprintf(rw, `<tr data-record="%s"></tr><tr data-record="%s"></tr>
+<input type="hidden" name="AddDnsRecordForm[_token]" value="add-token">
+<input type="hidden" name="DeleteDnsRecordForm[_token]" value="delete-token">`,
+			html.EscapeString(`{"id":"1","name":"example.com","type":"A","value":"10.0.0.1"}`),
+			html.EscapeString(`{"id":"2","name":"_acme-challenge.example.com","type":"TXT","value":"pW9ZKG0xz_PCriK-nCMOjADy9eJcgGWIzkkj2fN4uZM"}`))
+	})
+
+	config := NewDefaultConfig()
+	config.BaseURL = server.URL + "/en"
+	config.Username = "user"
+	config.Password = "secret"
+
+	p, err := NewDNSProviderConfig(config)
+	if err != nil {
+		panic(err)
+	}
+
+	p.findZoneByFqdn = func(fqdn string) (string, error) {
+		return "example.com.", nil
+	}
+
+	return p, mux, server.Close
+}
+
+func TestDNSProvider_Present(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	var name, value string
+	mux.HandleFunc("/en/1234/dns/record/add", func(rw http.ResponseWriter, req *http.Request) {
+		name = req.PostFormValue("AddDnsRecordForm[name]")
+		value = req.PostFormValue("AddDnsRecordForm[value]")
+	})
+
+	err := provider.Present("sub.example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Equal(t, "_acme-challenge.sub", name)
+	assert.Equal(t, "pW9ZKG0xz_PCriK-nCMOjADy9eJcgGWIzkkj2fN4uZM", value)
+	assert.True(t, provider.loggedIn)
+}
+
+func TestDNSProvider_CleanUp(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	var recordID string
+	mux.HandleFunc("/en/1234/dns/record/delete", func(rw http.ResponseWriter, req *http.Request) {
+		recordID = req.PostFormValue("DeleteDnsRecordForm[recordId]")
+	})
+
+	err := provider.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Equal(t, "2", recordID)
+
+	err = provider.CleanUp("sub.example.com", "token", "keyAuth")
+	require.EqualError(t, err, "world4you: no TXT record found for _acme-challenge.sub.example.com")
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
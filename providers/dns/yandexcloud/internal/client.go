@@ -0,0 +1,157 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// DefaultBaseURL the default base URL of the Yandex Cloud DNS API.
+const DefaultBaseURL = "https://dns.api.cloud.yandex.net/dns/v1"
+
+// DNSZone a DNS zone.
+type DNSZone struct {
+	ID       string `json:"id"`
+	FolderID string `json:"folderId"`
+	Name     string `json:"name,omitempty"`
+	Zone     string `json:"zone"`
+}
+
+type zonesResponse struct {
+	DNSZones      []DNSZone `json:"dnsZones"`
+	NextPageToken string    `json:"nextPageToken"`
+}
+
+// RecordSet a set of records with the same name and type.
+type RecordSet struct {
+	Name string   `json:"name"`
+	Type string   `json:"type"`
+	TTL  string   `json:"ttl,omitempty"`
+	Data []string `json:"data"`
+}
+
+// UpsertRequest the request of the upsertRecordSets method.
+type UpsertRequest struct {
+	// Deletions removes the specified records from the record sets.
+	Deletions []RecordSet `json:"deletions,omitempty"`
+	// Merges adds the records to the existing record sets.
+	Merges []RecordSet `json:"merges,omitempty"`
+}
+
+// Operation the long running operation of a modification.
+type Operation struct {
+	ID    string `json:"id"`
+	Done  bool   `json:"done"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// Client the Yandex Cloud DNS API client.
+type Client struct {
+	tokenSource TokenSource
+	BaseURL     string
+	HTTPClient  *http.Client
+}
+
+// NewClient creates a Yandex Cloud DNS API client.
+func NewClient(tokenSource TokenSource) *Client {
+	return &Client{
+		tokenSource: tokenSource,
+		BaseURL:     DefaultBaseURL,
+		HTTPClient:  &http.Client{},
+	}
+}
+
+// ListZones returns all the DNS zones of a folder.
+func (c *Client) ListZones(folderID string) ([]DNSZone, error) {
+	var zones []DNSZone
+
+	pageToken := ""
+	for {
+		query := url.Values{}
+		query.Set("folderId", folderID)
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+
+		resp := &zonesResponse{}
+		err := c.do(http.MethodGet, "/zones?"+query.Encode(), nil, resp)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list the zones of the folder %s: %v", folderID, err)
+		}
+
+		zones = append(zones, resp.DNSZones...)
+
+		if resp.NextPageToken == "" {
+			return zones, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+// UpsertRecordSets modifies the record sets of a zone.
+func (c *Client) UpsertRecordSets(zoneID string, request UpsertRequest) (*Operation, error) {
+	operation := &Operation{}
+	err := c.do(http.MethodPost, fmt.Sprintf("/zones/%s:upsertRecordSets", zoneID), request, operation)
+	if err != nil {
+		return nil, fmt.Errorf("unable to update the record sets of the zone %s: %v", zoneID, err)
+	}
+
+	if operation.Error != nil {
+		return nil, fmt.Errorf("unable to update the record sets of the zone %s: %d: %s", zoneID, operation.Error.Code, operation.Error.Message)
+	}
+
+	return operation, nil
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body bytes.Buffer
+	if payload != nil {
+		err := json.NewEncoder(&body).Encode(payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+uri, &body)
+	if err != nil {
+		return err
+	}
+
+	token, err := c.tokenSource.Token()
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%d: %s", resp.StatusCode, string(raw))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
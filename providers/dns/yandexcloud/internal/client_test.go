@@ -0,0 +1,91 @@
+package internal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest() (*http.ServeMux, *Client, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client := NewClient(StaticToken("token"))
+	client.BaseURL = server.URL
+
+	return mux, client, server.Close
+}
+
+func TestClient_ListZones(t *testing.T) {
+	mux, client, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/zones", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer token" {
+			http.Error(rw, `{"code":16,"message":"The token is invalid"}`, http.StatusUnauthorized)
+			return
+		}
+
+		if req.URL.Query().Get("folderId") != "folder" {
+			http.Error(rw, `{"code":3,"message":"invalid folder"}`, http.StatusBadRequest)
+			return
+		}
+
+		switch req.URL.Query().Get("pageToken") {
+		case "":
+			fmt.Fprint(rw, `{"dnsZones":[{"id":"zone1","folderId":"folder","zone":"example.com."}],"nextPageToken":"next"}`)
+		case "next":
+			fmt.Fprint(rw, `{"dnsZones":[{"id":"zone2","folderId":"folder","zone":"example.org."}]}`)
+		default:
+			http.Error(rw, `{"code":3,"message":"invalid page token"}`, http.StatusBadRequest)
+		}
+	})
+
+	zones, err := client.ListZones("folder")
+	require.NoError(t, err)
+
+	expected := []DNSZone{
+		{ID: "zone1", FolderID: "folder", Zone: "example.com."},
+		{ID: "zone2", FolderID: "folder", Zone: "example.org."},
+	}
+	assert.Equal(t, expected, zones)
+}
+
+func TestClient_UpsertRecordSets(t *testing.T) {
+	mux, client, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/zones/zone1:upsertRecordSets", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := `{"merges":[{"name":"_acme-challenge.example.com.","type":"TXT","ttl":"60","data":["value"]}]}`
+		if string(body) != expected+"\n" {
+			http.Error(rw, fmt.Sprintf("unexpected body: %s", string(body)), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(rw, `{"id":"op1","done":true}`)
+	})
+
+	request := UpsertRequest{
+		Merges: []RecordSet{{Name: "_acme-challenge.example.com.", Type: "TXT", TTL: "60", Data: []string{"value"}}},
+	}
+
+	operation, err := client.UpsertRecordSets("zone1", request)
+	require.NoError(t, err)
+	assert.Equal(t, "op1", operation.ID)
+}
@@ -0,0 +1,174 @@
+package internal
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// DefaultIAMURL the default URL of the endpoint creating the IAM tokens.
+const DefaultIAMURL = "https://iam.api.cloud.yandex.net/iam/v1/tokens"
+
+// ServiceAccountKey an authorized key of a service account (the JSON file created by `yc iam key create`).
+type ServiceAccountKey struct {
+	ID               string `json:"id"`
+	ServiceAccountID string `json:"service_account_id"`
+	PrivateKey       string `json:"private_key"`
+}
+
+// TokenSource provides the IAM token used to authenticate the requests.
+type TokenSource interface {
+	Token() (string, error)
+}
+
+// StaticToken an IAM token created outside of lego (i.e. `yc iam create-token`).
+type StaticToken string
+
+// Token implements TokenSource.
+func (s StaticToken) Token() (string, error) {
+	return string(s), nil
+}
+
+// ServiceAccountTokenSource creates the IAM tokens from the authorized key of a service account.
+// The tokens are cached until they expire.
+type ServiceAccountTokenSource struct {
+	key        *ServiceAccountKey
+	privateKey *rsa.PrivateKey
+
+	IAMURL     string
+	HTTPClient *http.Client
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewServiceAccountTokenSource creates a ServiceAccountTokenSource from the JSON content of an authorized key.
+func NewServiceAccountTokenSource(rawKey []byte) (*ServiceAccountTokenSource, error) {
+	key := &ServiceAccountKey{}
+	err := json.Unmarshal(rawKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the service account key: %v", err)
+	}
+
+	if key.ID == "" || key.ServiceAccountID == "" || key.PrivateKey == "" {
+		return nil, errors.New("the service account key must contain id, service_account_id, and private_key")
+	}
+
+	privateKey, err := parsePrivateKey([]byte(key.PrivateKey))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ServiceAccountTokenSource{
+		key:        key,
+		privateKey: privateKey,
+		IAMURL:     DefaultIAMURL,
+		HTTPClient: &http.Client{},
+	}, nil
+}
+
+// Token implements TokenSource.
+func (s *ServiceAccountTokenSource) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// renews the token a little before its expiration.
+	if s.token != "" && time.Now().Add(5*time.Minute).Before(s.expiresAt) {
+		return s.token, nil
+	}
+
+	signedJWT, err := s.signJWT()
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(map[string]string{"jwt": signedJWT})
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := s.HTTPClient.Post(s.IAMURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("unable to create an IAM token: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unable to create an IAM token: %d: %s", resp.StatusCode, string(raw))
+	}
+
+	var result struct {
+		IAMToken  string    `json:"iamToken"`
+		ExpiresAt time.Time `json:"expiresAt"`
+	}
+
+	err = json.Unmarshal(raw, &result)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse the IAM token: %v", err)
+	}
+
+	s.token = result.IAMToken
+	s.expiresAt = result.ExpiresAt
+
+	return s.token, nil
+}
+
+// signJWT creates the PS256 JWT exchanged against an IAM token.
+func (s *ServiceAccountTokenSource) signJWT() (string, error) {
+	options := (&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", s.key.ID)
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.PS256, Key: s.privateKey}, options)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := jwt.Claims{
+		Issuer:   s.key.ServiceAccountID,
+		Audience: jwt.Audience{s.IAMURL},
+		IssuedAt: jwt.NewNumericDate(now),
+		Expiry:   jwt.NewNumericDate(now.Add(time.Hour)),
+	}
+
+	return jwt.Signed(signer).Claims(claims).CompactSerialize()
+}
+
+func parsePrivateKey(raw []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, errors.New("the private key of the service account key is not PEM encoded")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		rsaKey, errPKCS1 := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if errPKCS1 != nil {
+			return nil, fmt.Errorf("unable to parse the private key of the service account key: %v", err)
+		}
+		return rsaKey, nil
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("the private key of the service account key is not a RSA key")
+	}
+
+	return rsaKey, nil
+}
@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+func TestServiceAccountTokenSource_Token(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+
+	rawKey, err := json.Marshal(ServiceAccountKey{
+		ID:               "key-id",
+		ServiceAccountID: "sa-id",
+		PrivateKey:       string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+	})
+	require.NoError(t, err)
+
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+
+		var body struct {
+			JWT string `json:"jwt"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		token, err := jwt.ParseSigned(body.JWT)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		claims := jwt.Claims{}
+		if err := token.Claims(&privateKey.PublicKey, &claims); err != nil {
+			http.Error(rw, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		if claims.Issuer != "sa-id" || token.Headers[0].KeyID != "key-id" || token.Headers[0].Algorithm != "PS256" {
+			http.Error(rw, "invalid JWT", http.StatusUnauthorized)
+			return
+		}
+
+		fmt.Fprintf(rw, `{"iamToken":"t1.iam","expiresAt":%q}`, time.Now().Add(12*time.Hour).Format(time.RFC3339))
+	}))
+	defer server.Close()
+
+	source, err := NewServiceAccountTokenSource(rawKey)
+	require.NoError(t, err)
+
+	source.IAMURL = server.URL
+
+	token, err := source.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "t1.iam", token)
+
+	// the token is cached.
+	token, err = source.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "t1.iam", token)
+	assert.Equal(t, 1, calls)
+}
+
+func TestNewServiceAccountTokenSource_invalid(t *testing.T) {
+	_, err := NewServiceAccountTokenSource([]byte(`{"id":"key-id"}`))
+	require.EqualError(t, err, "the service account key must contain id, service_account_id, and private_key")
+
+	_, err = NewServiceAccountTokenSource([]byte(`{"id":"key-id","service_account_id":"sa-id","private_key":"foo"}`))
+	require.EqualError(t, err, "the private key of the service account key is not PEM encoded")
+}
@@ -0,0 +1,211 @@
+// Package yandexcloud implements a DNS provider for solving the DNS-01 challenge using Yandex Cloud DNS.
+package yandexcloud
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/providers/dns/yandexcloud/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	BaseURL string
+	// IAMToken an IAM token, ignored when ServiceAccountKey is defined.
+	IAMToken string
+	// ServiceAccountKey the JSON authorized key of a service account (raw or base64 encoded).
+	ServiceAccountKey  string
+	FolderID           string
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString("YANDEX_CLOUD_ENDPOINT", internal.DefaultBaseURL),
+		TTL:                env.GetOrDefaultInt("YANDEX_CLOUD_TTL", 60),
+		PropagationTimeout: env.GetOrDefaultSecond("YANDEX_CLOUD_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("YANDEX_CLOUD_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("YANDEX_CLOUD_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses the Yandex Cloud DNS API to manage TXT records for a domain.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Yandex Cloud.
+// The folder must be passed in the environment variable YANDEX_CLOUD_FOLDER_ID,
+// and the credentials in YANDEX_CLOUD_SERVICE_ACCOUNT_KEY or YANDEX_CLOUD_IAM_TOKEN.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("YANDEX_CLOUD_FOLDER_ID")
+	if err != nil {
+		return nil, fmt.Errorf("yandexcloud: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.FolderID = values["YANDEX_CLOUD_FOLDER_ID"]
+	config.ServiceAccountKey = env.GetOrFile("YANDEX_CLOUD_SERVICE_ACCOUNT_KEY")
+	config.IAMToken = env.GetOrFile("YANDEX_CLOUD_IAM_TOKEN")
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Yandex Cloud.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("yandexcloud: the configuration of the DNS provider is nil")
+	}
+
+	if config.FolderID == "" {
+		return nil, errors.New("yandexcloud: the folder ID is missing")
+	}
+
+	tokenSource, err := newTokenSource(config)
+	if err != nil {
+		return nil, fmt.Errorf("yandexcloud: %v", err)
+	}
+
+	client := internal.NewClient(tokenSource)
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("yandexcloud: %v", err)
+	}
+
+	request := internal.UpsertRequest{
+		Merges: []internal.RecordSet{{
+			Name: fqdn,
+			Type: "TXT",
+			TTL:  strconv.Itoa(d.config.TTL),
+			Data: []string{value},
+		}},
+	}
+
+	_, err = d.client.UpsertRecordSets(zone.ID, request)
+	if err != nil {
+		return fmt.Errorf("yandexcloud: %v", err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("yandexcloud: %v", err)
+	}
+
+	// only the value of the challenge is removed from the record set.
+	request := internal.UpsertRequest{
+		Deletions: []internal.RecordSet{{
+			Name: fqdn,
+			Type: "TXT",
+			TTL:  strconv.Itoa(d.config.TTL),
+			Data: []string{value},
+		}},
+	}
+
+	_, err = d.client.UpsertRecordSets(zone.ID, request)
+	if err != nil {
+		return fmt.Errorf("yandexcloud: %v", err)
+	}
+
+	return nil
+}
+
+// findZone returns the most specific zone of the folder containing the FQDN.
+func (d *DNSProvider) findZone(fqdn string) (*internal.DNSZone, error) {
+	zones, err := d.client.ListZones(d.config.FolderID)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *internal.DNSZone
+	for i, zone := range zones {
+		name := dns01.ToFqdn(zone.Zone)
+		if fqdn != name && !strings.HasSuffix(fqdn, "."+name) {
+			continue
+		}
+
+		if found == nil || len(name) > len(dns01.ToFqdn(found.Zone)) {
+			found = &zones[i]
+		}
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("no zone found for %s in the folder %s", fqdn, d.config.FolderID)
+	}
+
+	return found, nil
+}
+
+func newTokenSource(config *Config) (internal.TokenSource, error) {
+	if config.ServiceAccountKey != "" {
+		rawKey := []byte(config.ServiceAccountKey)
+
+		if !strings.HasPrefix(strings.TrimSpace(config.ServiceAccountKey), "{") {
+			decoded, err := base64.StdEncoding.DecodeString(config.ServiceAccountKey)
+			if err != nil {
+				return nil, fmt.Errorf("the service account key is neither a JSON nor a base64 encoded JSON: %v", err)
+			}
+			rawKey = decoded
+		}
+
+		source, err := internal.NewServiceAccountTokenSource(rawKey)
+		if err != nil {
+			return nil, err
+		}
+
+		if config.HTTPClient != nil {
+			source.HTTPClient = config.HTTPClient
+		}
+
+		return source, nil
+	}
+
+	if config.IAMToken != "" {
+		return internal.StaticToken(config.IAMToken), nil
+	}
+
+	return nil, errors.New("credentials missing: a service account key or an IAM token is required")
+}
@@ -0,0 +1,198 @@
+package yandexcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge/dns01"
+	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/go-acme/lego/v3/providers/dns/yandexcloud/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest(
+	"YANDEX_CLOUD_FOLDER_ID",
+	"YANDEX_CLOUD_IAM_TOKEN",
+	"YANDEX_CLOUD_SERVICE_ACCOUNT_KEY").
+	WithDomain("YANDEX_CLOUD_DOMAIN").
+	WithLiveTestRequirements("YANDEX_CLOUD_FOLDER_ID", "YANDEX_CLOUD_IAM_TOKEN")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success with IAM token",
+			envVars: map[string]string{
+				"YANDEX_CLOUD_FOLDER_ID": "folder",
+				"YANDEX_CLOUD_IAM_TOKEN": "t1.token",
+			},
+		},
+		{
+			desc: "missing folder",
+			envVars: map[string]string{
+				"YANDEX_CLOUD_IAM_TOKEN": "t1.token",
+			},
+			expected: "yandexcloud: some credentials information are missing: YANDEX_CLOUD_FOLDER_ID",
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				"YANDEX_CLOUD_FOLDER_ID": "folder",
+			},
+			expected: "yandexcloud: credentials missing: a service account key or an IAM token is required",
+		},
+		{
+			desc: "invalid service account key",
+			envVars: map[string]string{
+				"YANDEX_CLOUD_FOLDER_ID":           "folder",
+				"YANDEX_CLOUD_SERVICE_ACCOUNT_KEY": "eyJpZCI6ImtleS1pZCJ9",
+			},
+			expected: "yandexcloud: the service account key must contain id, service_account_id, and private_key",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		folderID string
+		iamToken string
+		expected string
+	}{
+		{
+			desc:     "success",
+			folderID: "folder",
+			iamToken: "t1.token",
+		},
+		{
+			desc:     "missing folder",
+			iamToken: "t1.token",
+			expected: "yandexcloud: the folder ID is missing",
+		},
+		{
+			desc:     "missing credentials",
+			folderID: "folder",
+			expected: "yandexcloud: credentials missing: a service account key or an IAM token is required",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.FolderID = test.folderID
+			config.IAMToken = test.iamToken
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestDNSProvider_PresentAndCleanUp(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/zones", func(rw http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(rw, `{"dnsZones":[{"id":"zone1","zone":"example.com."},{"id":"zone2","zone":"sub.example.com."},{"id":"zone3","zone":"example.org."}]}`)
+	})
+
+	var requests []internal.UpsertRequest
+	mux.HandleFunc("/zones/zone2:upsertRecordSets", func(rw http.ResponseWriter, req *http.Request) {
+		request := internal.UpsertRequest{}
+		if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		requests = append(requests, request)
+		fmt.Fprint(rw, `{"id":"op","done":true}`)
+	})
+
+	config := NewDefaultConfig()
+	config.BaseURL = server.URL
+	config.FolderID = "folder"
+	config.IAMToken = "t1.token"
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	err = provider.Present("www.sub.example.com", "", "keyAuth")
+	require.NoError(t, err)
+
+	err = provider.CleanUp("www.sub.example.com", "", "keyAuth")
+	require.NoError(t, err)
+
+	fqdn, value := dns01.GetRecord("www.sub.example.com", "keyAuth")
+	recordSets := []internal.RecordSet{{Name: fqdn, Type: "TXT", TTL: "60", Data: []string{value}}}
+
+	expected := []internal.UpsertRequest{
+		{Merges: recordSets},
+		{Deletions: recordSets},
+	}
+	assert.Equal(t, expected, requests)
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
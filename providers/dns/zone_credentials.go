@@ -0,0 +1,283 @@
+package dns
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge"
+	"github.com/go-acme/lego/v3/challenge/dns01"
+)
+
+// envMu serializes the temporary environment changes made while creating providers for a credential set.
+var envMu sync.Mutex
+
+// sequential a provider which solves the challenges sequentially (the same interface as dns01).
+type sequential interface {
+	Sequential() time.Duration
+}
+
+// ZoneProvider is a challenge.Provider that delegates to a provider chosen according to the zone of the domain.
+// It allows to use several accounts of the same DNS provider to solve the challenges of a single certificate.
+type ZoneProvider struct {
+	zones     []string
+	providers map[string]challenge.Provider
+}
+
+// SequentialZoneProvider is a ZoneProvider where at least one of the delegated providers must solve the challenges sequentially
+// (i.e. the manual provider).
+type SequentialZoneProvider struct {
+	*ZoneProvider
+}
+
+// NewZoneProvider creates a ZoneProvider from a zone to provider mapping.
+// The most specific zone matching a domain is used, the zone "." matches all the domains (default provider).
+// A SequentialZoneProvider is returned if one of the providers solves the challenges sequentially.
+func NewZoneProvider(providers map[string]challenge.Provider) (challenge.Provider, error) {
+	z, err := newZoneProvider(providers)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, provider := range z.providers {
+		if _, ok := provider.(sequential); ok {
+			return &SequentialZoneProvider{ZoneProvider: z}, nil
+		}
+	}
+
+	return z, nil
+}
+
+func newZoneProvider(providers map[string]challenge.Provider) (*ZoneProvider, error) {
+	if len(providers) == 0 {
+		return nil, errors.New("zone provider: no zone defined")
+	}
+
+	z := &ZoneProvider{providers: make(map[string]challenge.Provider)}
+
+	for zone, provider := range providers {
+		if provider == nil {
+			return nil, fmt.Errorf("zone provider: nil provider for zone %s", zone)
+		}
+
+		key := dns01.UnFqdn(strings.ToLower(zone))
+		z.providers[key] = provider
+		z.zones = append(z.zones, key)
+	}
+
+	// longest zones first to select the most specific one.
+	sort.Slice(z.zones, func(i, j int) bool {
+		if len(z.zones[i]) == len(z.zones[j]) {
+			return z.zones[i] < z.zones[j]
+		}
+		return len(z.zones[i]) > len(z.zones[j])
+	})
+
+	return z, nil
+}
+
+// NewZoneCredentialsProvider creates a ZoneProvider where every zone is handled by the provider `name`
+// configured with a dedicated credential set.
+//
+// The mapping associates a zone to the name of a credential set.
+// The environment variables of a credential set are the ones of the provider suffixed by the upper-cased set name:
+//
+//	// CLOUDFLARE_EMAIL_ACCOUNT1=foo@example.com
+//	// CLOUDFLARE_API_KEY_ACCOUNT1=xxx
+//	// CLOUDFLARE_EMAIL_ACCOUNT2=bar@example.org
+//	// CLOUDFLARE_API_KEY_ACCOUNT2=yyy
+//	dns.NewZoneCredentialsProvider("cloudflare", map[string]string{
+//		"example.com": "account1",
+//		"example.org": "account2",
+//	})
+//
+// The providers read their configuration from the environment variables:
+// the environment variables of each credential set are temporarily defined in place of the regular ones while its provider is created.
+// So this function must not be called while other goroutines read the environment variables (i.e. while creating other providers),
+// it's intended to be called once, during the initialization of the program.
+func NewZoneCredentialsProvider(name string, mapping map[string]string) (challenge.Provider, error) {
+	sets := make(map[string]challenge.Provider)
+	providers := make(map[string]challenge.Provider)
+
+	for zone, set := range mapping {
+		set = strings.ToUpper(strings.TrimSpace(set))
+		if set == "" {
+			return nil, fmt.Errorf("zone provider: empty credential set for zone %s", zone)
+		}
+
+		provider, ok := sets[set]
+		if !ok {
+			var err error
+			provider, err = newDNSChallengeProviderWithCredentialSet(name, set)
+			if err != nil {
+				return nil, fmt.Errorf("zone provider: credential set %s: %v", set, err)
+			}
+			sets[set] = provider
+		}
+
+		providers[zone] = provider
+	}
+
+	return NewZoneProvider(providers)
+}
+
+// ParseZoneCredentials parses a list of `zone:set` definitions.
+func ParseZoneCredentials(values []string) (map[string]string, error) {
+	mapping := make(map[string]string)
+
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+
+			parts := strings.SplitN(item, ":", 2)
+			if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+				return nil, fmt.Errorf("invalid zone credentials definition: %q (expected zone:set)", item)
+			}
+
+			mapping[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+		}
+	}
+
+	return mapping, nil
+}
+
+// Present creates a TXT record using the provider associated to the zone of the domain.
+func (z *ZoneProvider) Present(domain, token, keyAuth string) error {
+	provider, err := z.providerFor(domain)
+	if err != nil {
+		return err
+	}
+
+	return provider.Present(domain, token, keyAuth)
+}
+
+// CleanUp removes the TXT record using the provider associated to the zone of the domain.
+func (z *ZoneProvider) CleanUp(domain, token, keyAuth string) error {
+	provider, err := z.providerFor(domain)
+	if err != nil {
+		return err
+	}
+
+	return provider.CleanUp(domain, token, keyAuth)
+}
+
+// Timeout returns the largest timeout and the smallest interval of the delegated providers.
+func (z *ZoneProvider) Timeout() (timeout, interval time.Duration) {
+	for _, provider := range z.providers {
+		t, i := dns01.DefaultPropagationTimeout, dns01.DefaultPollingInterval
+		if p, ok := provider.(challenge.ProviderTimeout); ok {
+			t, i = p.Timeout()
+		}
+
+		if t > timeout {
+			timeout = t
+		}
+		if interval == 0 || i < interval {
+			interval = i
+		}
+	}
+
+	return timeout, interval
+}
+
+// Sequential returns the largest interval between the challenges of the delegated providers which solve the challenges sequentially.
+func (z *SequentialZoneProvider) Sequential() time.Duration {
+	var interval time.Duration
+	for _, provider := range z.providers {
+		if p, ok := provider.(sequential); ok && p.Sequential() > interval {
+			interval = p.Sequential()
+		}
+	}
+
+	return interval
+}
+
+func (z *ZoneProvider) providerFor(domain string) (challenge.Provider, error) {
+	name := dns01.UnFqdn(strings.ToLower(strings.TrimPrefix(domain, "*.")))
+
+	for _, zone := range z.zones {
+		if zone == "" || name == zone || strings.HasSuffix(name, "."+zone) {
+			return z.providers[zone], nil
+		}
+	}
+
+	return nil, fmt.Errorf("zone provider: no provider defined for the domain %s", domain)
+}
+
+// newDNSChallengeProviderWithCredentialSet creates the provider `name`
+// with the environment variables suffixed by `_<set>` in place of the regular ones.
+func newDNSChallengeProviderWithCredentialSet(name, set string) (challenge.Provider, error) {
+	envMu.Lock()
+	defer envMu.Unlock()
+
+	restore := applyCredentialSet(set)
+	defer restore()
+
+	return NewDNSChallengeProviderByName(name)
+}
+
+func applyCredentialSet(set string) func() {
+	suffix := "_" + set
+
+	overrides := make(map[string]string)
+	for _, kv := range os.Environ() {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 || !strings.HasSuffix(parts[0], suffix) || parts[0] == suffix {
+			continue
+		}
+
+		overrides[strings.TrimSuffix(parts[0], suffix)] = parts[1]
+	}
+
+	// an override of VAR must not be shadowed by VAR_FILE, and conversely.
+	unsets := make(map[string]bool)
+	for key := range overrides {
+		if strings.HasSuffix(key, "_FILE") {
+			base := strings.TrimSuffix(key, "_FILE")
+			if _, ok := overrides[base]; !ok {
+				unsets[base] = true
+			}
+		} else if _, ok := overrides[key+"_FILE"]; !ok {
+			unsets[key+"_FILE"] = true
+		}
+	}
+
+	previous := make(map[string]*string)
+	save := func(key string) {
+		if _, ok := previous[key]; ok {
+			return
+		}
+		if value, ok := os.LookupEnv(key); ok {
+			previous[key] = &value
+		} else {
+			previous[key] = nil
+		}
+	}
+
+	for key, value := range overrides {
+		save(key)
+		_ = os.Setenv(key, value)
+	}
+
+	for key := range unsets {
+		save(key)
+		_ = os.Unsetenv(key)
+	}
+
+	return func() {
+		for key, value := range previous {
+			if value == nil {
+				_ = os.Unsetenv(key)
+			} else {
+				_ = os.Setenv(key, *value)
+			}
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package dns
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v3/challenge"
+	"github.com/go-acme/lego/v3/platform/tester"
+	"github.com/go-acme/lego/v3/providers/dns/exec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var zoneEnvTest = tester.NewEnvTest(
+	"EXEC_PATH",
+	"EXEC_PROPAGATION_TIMEOUT",
+	"EXEC_PATH_ONE",
+	"EXEC_PROPAGATION_TIMEOUT_ONE",
+	"EXEC_PATH_TWO",
+	"EXEC_PROPAGATION_TIMEOUT_TWO")
+
+type fakeProvider struct {
+	name    string
+	present []string
+}
+
+func (f *fakeProvider) Present(domain, _, _ string) error {
+	f.present = append(f.present, domain)
+	return nil
+}
+
+func (f *fakeProvider) CleanUp(domain, _, _ string) error {
+	return nil
+}
+
+func TestZoneProvider_Present(t *testing.T) {
+	com := &fakeProvider{name: "com"}
+	sub := &fakeProvider{name: "sub"}
+	org := &fakeProvider{name: "org"}
+
+	provider, err := NewZoneProvider(map[string]challenge.Provider{
+		"example.com":      com,
+		"sub.example.com.": sub,
+		"Example.org":      org,
+	})
+	require.NoError(t, err)
+
+	for _, domain := range []string{"example.com", "*.example.com", "www.example.com", "a.sub.example.com", "*.example.org"} {
+		require.NoError(t, provider.Present(domain, "", ""))
+	}
+
+	assert.Equal(t, []string{"example.com", "*.example.com", "www.example.com"}, com.present)
+	assert.Equal(t, []string{"a.sub.example.com"}, sub.present)
+	assert.Equal(t, []string{"*.example.org"}, org.present)
+
+	err = provider.Present("notexample.com", "", "")
+	assert.EqualError(t, err, "zone provider: no provider defined for the domain notexample.com")
+}
+
+func TestZoneProvider_Present_defaultZone(t *testing.T) {
+	com := &fakeProvider{name: "com"}
+	other := &fakeProvider{name: "other"}
+
+	provider, err := NewZoneProvider(map[string]challenge.Provider{
+		"example.com": com,
+		".":           other,
+	})
+	require.NoError(t, err)
+
+	for _, domain := range []string{"www.example.com", "notexample.com", "example.org"} {
+		require.NoError(t, provider.Present(domain, "", ""))
+	}
+
+	assert.Equal(t, []string{"www.example.com"}, com.present)
+	assert.Equal(t, []string{"notexample.com", "example.org"}, other.present)
+}
+
+type fakeSequentialProvider struct {
+	fakeProvider
+	interval time.Duration
+}
+
+func (f *fakeSequentialProvider) Sequential() time.Duration {
+	return f.interval
+}
+
+func TestNewZoneProvider_sequential(t *testing.T) {
+	provider, err := NewZoneProvider(map[string]challenge.Provider{
+		"example.com": &fakeProvider{},
+		"example.org": &fakeSequentialProvider{interval: 10 * time.Second},
+		"example.net": &fakeSequentialProvider{interval: time.Minute},
+	})
+	require.NoError(t, err)
+	require.IsType(t, &SequentialZoneProvider{}, provider)
+
+	assert.Equal(t, time.Minute, provider.(*SequentialZoneProvider).Sequential())
+
+	provider, err = NewZoneProvider(map[string]challenge.Provider{
+		"example.com": &fakeProvider{},
+	})
+	require.NoError(t, err)
+	require.IsType(t, &ZoneProvider{}, provider)
+}
+
+func TestNewZoneProvider_empty(t *testing.T) {
+	_, err := NewZoneProvider(nil)
+	assert.EqualError(t, err, "zone provider: no zone defined")
+}
+
+func TestNewZoneCredentialsProvider(t *testing.T) {
+	defer zoneEnvTest.RestoreEnv()
+	zoneEnvTest.ClearEnv()
+	zoneEnvTest.Apply(map[string]string{
+		"EXEC_PATH":                    "default",
+		"EXEC_PATH_ONE":                "one",
+		"EXEC_PROPAGATION_TIMEOUT_ONE": "10",
+		"EXEC_PATH_TWO":                "two",
+		"EXEC_PROPAGATION_TIMEOUT_TWO": "300",
+	})
+
+	p, err := NewZoneCredentialsProvider("exec", map[string]string{
+		"example.com": "one",
+		"example.org": "two",
+		"example.net": "One",
+	})
+	require.NoError(t, err)
+
+	// the exec provider solves the challenges sequentially.
+	require.IsType(t, &SequentialZoneProvider{}, p)
+
+	provider := p.(*SequentialZoneProvider)
+
+	one, err := provider.providerFor("www.example.com")
+	require.NoError(t, err)
+	require.IsType(t, &exec.DNSProvider{}, one)
+
+	timeout, _ := one.(*exec.DNSProvider).Timeout()
+	assert.Equal(t, 10*time.Second, timeout)
+
+	net, err := provider.providerFor("example.net")
+	require.NoError(t, err)
+	assert.Same(t, one, net)
+
+	two, err := provider.providerFor("example.org")
+	require.NoError(t, err)
+
+	timeout, _ = two.(*exec.DNSProvider).Timeout()
+	assert.Equal(t, 300*time.Second, timeout)
+
+	timeout, _ = provider.Timeout()
+	assert.Equal(t, 300*time.Second, timeout)
+
+	assert.Equal(t, 300*time.Second, provider.Sequential())
+
+	// the environment must be restored.
+	assert.Equal(t, "default", os.Getenv("EXEC_PATH"))
+	_, found := os.LookupEnv("EXEC_PROPAGATION_TIMEOUT")
+	assert.False(t, found)
+}
+
+func TestNewZoneCredentialsProvider_missingCredentials(t *testing.T) {
+	defer zoneEnvTest.RestoreEnv()
+	zoneEnvTest.ClearEnv()
+	zoneEnvTest.Apply(map[string]string{
+		"EXEC_PATH_ONE": "one",
+	})
+
+	_, err := NewZoneCredentialsProvider("exec", map[string]string{
+		"example.com": "one",
+		"example.org": "two",
+	})
+	assert.EqualError(t, err, "zone provider: credential set TWO: exec: some credentials information are missing: EXEC_PATH")
+}
+
+func TestParseZoneCredentials(t *testing.T) {
+	mapping, err := ParseZoneCredentials([]string{"example.com:one, example.org:two", "example.net:one"})
+	require.NoError(t, err)
+
+	expected := map[string]string{
+		"example.com": "one",
+		"example.org": "two",
+		"example.net": "one",
+	}
+	assert.Equal(t, expected, mapping)
+
+	_, err = ParseZoneCredentials([]string{"example.com"})
+	assert.EqualError(t, err, `invalid zone credentials definition: "example.com" (expected zone:set)`)
+}
@@ -37,9 +37,13 @@ func NewDefaultConfig() *Config {
 	}
 }
 
-// DNSProvider describes a provider for acme-proxy
+// DNSProvider is an implementation of the challenge.Provider interface
+// that uses the Zone.eu API to manage TXT records for a domain.
 type DNSProvider struct {
 	config *Config
+
+	// findZoneByFqdn determines the DNS zone of an fqdn. It is overridden during tests.
+	findZoneByFqdn func(fqdn string) (string, error)
 }
 
 // NewDNSProvider returns a DNSProvider instance.
@@ -81,7 +85,10 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("zoneee: the endpoint is missing")
 	}
 
-	return &DNSProvider{config: config}, nil
+	return &DNSProvider{
+		config:         config,
+		findZoneByFqdn: dns01.FindZoneByFqdn,
+	}, nil
 }
 
 // Timeout returns the timeout and interval to use when checking for DNS propagation.
@@ -94,12 +101,17 @@ func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	fqdn, value := dns01.GetRecord(domain, keyAuth)
 
+	zone, err := d.getZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("zoneee: %v", err)
+	}
+
 	record := txtRecord{
-		Name:        fqdn[:len(fqdn)-1],
+		Name:        dns01.UnFqdn(fqdn),
 		Destination: value,
 	}
 
-	_, err := d.addTxtRecord(domain, record)
+	_, err = d.addTxtRecord(zone, record)
 	if err != nil {
 		return fmt.Errorf("zoneee: %v", err)
 	}
@@ -108,16 +120,21 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 
 // CleanUp removes the TXT record previously created
 func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
-	_, value := dns01.GetRecord(domain, keyAuth)
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
 
-	records, err := d.getTxtRecords(domain)
+	zone, err := d.getZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("zoneee: %v", err)
+	}
+
+	records, err := d.getTxtRecords(zone)
 	if err != nil {
 		return fmt.Errorf("zoneee: %v", err)
 	}
 
 	var id string
 	for _, record := range records {
-		if record.Destination == value {
+		if record.Name == dns01.UnFqdn(fqdn) && record.Destination == value {
 			id = record.ID
 		}
 	}
@@ -126,9 +143,19 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 		return fmt.Errorf("zoneee: txt record does not exist for %v", value)
 	}
 
-	if err = d.removeTxtRecord(domain, id); err != nil {
+	if err = d.removeTxtRecord(zone, id); err != nil {
 		return fmt.Errorf("zoneee: %v", err)
 	}
 
 	return nil
 }
+
+// getZone returns the zone managed by Zone.eu for the FQDN (i.e. "example.com" for "_acme-challenge.sub.example.com.").
+func (d *DNSProvider) getZone(fqdn string) (string, error) {
+	authZone, err := d.findZoneByFqdn(fqdn)
+	if err != nil {
+		return "", fmt.Errorf("could not find zone for FQDN %q: %v", fqdn, err)
+	}
+
+	return dns01.UnFqdn(authZone), nil
+}
@@ -152,7 +152,7 @@ func TestDNSProvider_Present(t *testing.T) {
 			username: "bar",
 			apiKey:   "foo",
 			handlers: map[string]http.HandlerFunc{
-				"/" + domain + "/txt": mockHandlerCreateRecord,
+				"/example.com/txt": mockHandlerCreateRecord,
 			},
 		},
 		{
@@ -160,7 +160,7 @@ func TestDNSProvider_Present(t *testing.T) {
 			username: "nope",
 			apiKey:   "foo",
 			handlers: map[string]http.HandlerFunc{
-				"/" + domain + "/txt": mockHandlerCreateRecord,
+				"/example.com/txt": mockHandlerCreateRecord,
 			},
 			expectedError: "zoneee: status code=401: Unauthorized\n",
 		},
@@ -192,6 +192,10 @@ func TestDNSProvider_Present(t *testing.T) {
 			p, err := NewDNSProviderConfig(config)
 			require.NoError(t, err)
 
+			p.findZoneByFqdn = func(fqdn string) (string, error) {
+				return "example.com.", nil
+			}
+
 			err = p.Present(domain, "token", "key")
 			if test.expectedError == "" {
 				require.NoError(t, err)
@@ -217,14 +221,14 @@ func TestDNSProvider_Cleanup(t *testing.T) {
 			username: "bar",
 			apiKey:   "foo",
 			handlers: map[string]http.HandlerFunc{
-				"/" + domain + "/txt": mockHandlerGetRecords([]txtRecord{{
+				"/example.com/txt": mockHandlerGetRecords([]txtRecord{{
 					ID:          "1234",
-					Name:        domain,
+					Name:        "_acme-challenge." + domain,
 					Destination: "LHDhK3oGRvkiefQnx7OOczTY5Tic_xZ6HcMOc_gmtoM",
 					Delete:      true,
 					Modify:      true,
 				}}),
-				"/" + domain + "/txt/1234": mockHandlerDeleteRecord,
+				"/example.com/txt/1234": mockHandlerDeleteRecord,
 			},
 		},
 		{
@@ -232,8 +236,24 @@ func TestDNSProvider_Cleanup(t *testing.T) {
 			username: "bar",
 			apiKey:   "foo",
 			handlers: map[string]http.HandlerFunc{
-				"/" + domain + "/txt":      mockHandlerGetRecords([]txtRecord{}),
-				"/" + domain + "/txt/1234": mockHandlerDeleteRecord,
+				"/example.com/txt":      mockHandlerGetRecords([]txtRecord{}),
+				"/example.com/txt/1234": mockHandlerDeleteRecord,
+			},
+			expectedError: "zoneee: txt record does not exist for LHDhK3oGRvkiefQnx7OOczTY5Tic_xZ6HcMOc_gmtoM",
+		},
+		{
+			desc:     "txt record of another name",
+			username: "bar",
+			apiKey:   "foo",
+			handlers: map[string]http.HandlerFunc{
+				"/example.com/txt": mockHandlerGetRecords([]txtRecord{{
+					ID:          "1234",
+					Name:        "_acme-challenge.example.com",
+					Destination: "LHDhK3oGRvkiefQnx7OOczTY5Tic_xZ6HcMOc_gmtoM",
+					Delete:      true,
+					Modify:      true,
+				}}),
+				"/example.com/txt/1234": mockHandlerDeleteRecord,
 			},
 			expectedError: "zoneee: txt record does not exist for LHDhK3oGRvkiefQnx7OOczTY5Tic_xZ6HcMOc_gmtoM",
 		},
@@ -242,14 +262,14 @@ func TestDNSProvider_Cleanup(t *testing.T) {
 			username: "nope",
 			apiKey:   "foo",
 			handlers: map[string]http.HandlerFunc{
-				"/" + domain + "/txt": mockHandlerGetRecords([]txtRecord{{
+				"/example.com/txt": mockHandlerGetRecords([]txtRecord{{
 					ID:          "1234",
-					Name:        domain,
+					Name:        "_acme-challenge." + domain,
 					Destination: "LHDhK3oGRvkiefQnx7OOczTY5Tic_xZ6HcMOc_gmtoM",
 					Delete:      true,
 					Modify:      true,
 				}}),
-				"/" + domain + "/txt/1234": mockHandlerDeleteRecord,
+				"/example.com/txt/1234": mockHandlerDeleteRecord,
 			},
 			expectedError: "zoneee: status code=401: Unauthorized\n",
 		},
@@ -281,6 +301,10 @@ func TestDNSProvider_Cleanup(t *testing.T) {
 			p, err := NewDNSProviderConfig(config)
 			require.NoError(t, err)
 
+			p.findZoneByFqdn = func(fqdn string) (string, error) {
+				return "example.com.", nil
+			}
+
 			err = p.CleanUp(domain, "token", "key")
 			if test.expectedError == "" {
 				require.NoError(t, err)
@@ -115,6 +115,32 @@ func (r *Registrar) QueryRegistration() (*Resource, error) {
 	}, nil
 }
 
+// UpdateRegistration updates the contact of the client's user registration on the ACME server (the email of the user).
+func (r *Registrar) UpdateRegistration() (*Resource, error) {
+	if r == nil || r.user == nil || r.user.GetRegistration() == nil {
+		return nil, errors.New("acme: cannot update the registration of a nil client or user")
+	}
+
+	accMsg := acme.AccountUpdate{Contact: []string{}}
+
+	if r.user.GetEmail() != "" {
+		accMsg.Contact = []string{"mailto:" + r.user.GetEmail()}
+	}
+
+	log.Infof("acme: Updating account %s", r.user.GetRegistration().URI)
+
+	account, err := r.core.Accounts.Update(r.user.GetRegistration().URI, accMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Resource{
+		Body: account,
+		// Location: header is not returned so this needs to be populated off of existing URI
+		URI: r.user.GetRegistration().URI,
+	}, nil
+}
+
 // DeleteRegistration deletes the client's user registration from the ACME server.
 func (r *Registrar) DeleteRegistration() error {
 	if r == nil || r.user == nil {
@@ -54,3 +54,39 @@ func TestRegistrar_ResolveAccountByKey(t *testing.T) {
 
 	assert.Equal(t, "valid", res.Body.Status, "Unexpected account status")
 }
+
+func TestRegistrar_UpdateRegistration(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	mux.HandleFunc("/account/1", func(w http.ResponseWriter, _ *http.Request) {
+		err := tester.WriteJSONResponse(w, acme.Account{
+			Status:  "valid",
+			Contact: []string{"mailto:new@test.com"},
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	})
+
+	key, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err, "Could not generate test key")
+
+	user := mockUser{
+		email:      "new@test.com",
+		regres:     &Resource{URI: apiURL + "/account/1"},
+		privatekey: key,
+	}
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", apiURL+"/account/1", key)
+	require.NoError(t, err)
+
+	registrar := NewRegistrar(core, user)
+
+	res, err := registrar.UpdateRegistration()
+	require.NoError(t, err)
+
+	assert.Equal(t, apiURL+"/account/1", res.URI)
+	assert.Equal(t, []string{"mailto:new@test.com"}, res.Body.Contact)
+}
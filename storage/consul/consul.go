@@ -0,0 +1,275 @@
+// Package consul implements a storage of the accounts and the certificates in the KV store of HashiCorp Consul.
+//
+//	import _ "github.com/go-acme/lego/v3/storage/consul"
+//
+//	// CONSUL_HTTP_ADDR=https://consul.example.com:8501 CONSUL_HTTP_TOKEN=xxx lego --storage=consul ...
+//
+// Each file is a key of the KV store (the raw content of the file), under a prefix ("lego" by default).
+// The instances of a cluster (i.e. load balancers) sharing the KV store can watch the keys of the certificates
+// to pick up the renewals.
+package consul
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/storage"
+)
+
+// Name the name of the storage (--storage option).
+const Name = "consul"
+
+func init() {
+	storage.RegisterBackend(Name, func() (storage.Backend, error) {
+		return NewBackend()
+	})
+}
+
+// Config is used to configure the creation of the Backend.
+type Config struct {
+	// Address the address of the Consul agent (scheme://host:port, http is used if the scheme is missing).
+	Address string
+	// Token the ACL token.
+	Token string
+	// Datacenter the datacenter of the KV store, the datacenter of the agent if empty.
+	Datacenter string
+	// Namespace the namespace (Consul Enterprise).
+	Namespace string
+	// Prefix the prefix of the keys.
+	Prefix     string
+	HTTPClient *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the Backend.
+// The TLS options of the client are the CA certificates (CONSUL_CACERT) and the client certificate (CONSUL_CLIENT_CERT, CONSUL_CLIENT_KEY).
+func NewDefaultConfig() (*Config, error) {
+	tlsConfig, err := newTLSConfig(env.GetOrFile("CONSUL_CACERT"), env.GetOrFile("CONSUL_CLIENT_CERT"), env.GetOrFile("CONSUL_CLIENT_KEY"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Config{
+		Address:    env.GetOrDefaultString("CONSUL_HTTP_ADDR", "http://127.0.0.1:8500"),
+		Token:      env.GetOrFile("CONSUL_HTTP_TOKEN"),
+		Datacenter: env.GetOrFile("LEGO_CONSUL_DATACENTER"),
+		Namespace:  env.GetOrFile("CONSUL_NAMESPACE"),
+		Prefix:     env.GetOrDefaultString("LEGO_CONSUL_PREFIX", "lego"),
+		HTTPClient: &http.Client{
+			Timeout:   env.GetOrDefaultSecond("CONSUL_HTTP_TIMEOUT", 30*time.Second),
+			Transport: &http.Transport{TLSClientConfig: tlsConfig, Proxy: http.ProxyFromEnvironment},
+		},
+	}, nil
+}
+
+// Backend stores the files as the keys of the KV store.
+type Backend struct {
+	config  *Config
+	baseURL *url.URL
+}
+
+// NewBackend returns a Backend configured from the environment variables:
+// CONSUL_HTTP_ADDR, CONSUL_HTTP_TOKEN, CONSUL_NAMESPACE, CONSUL_CACERT, CONSUL_CLIENT_CERT, CONSUL_CLIENT_KEY,
+// LEGO_CONSUL_DATACENTER, LEGO_CONSUL_PREFIX.
+func NewBackend() (*Backend, error) {
+	config, err := NewDefaultConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewBackendConfig(config)
+}
+
+// NewBackendConfig returns a Backend configured with a given config.
+func NewBackendConfig(config *Config) (*Backend, error) {
+	if config == nil {
+		return nil, errors.New("consul: the configuration of the storage is nil")
+	}
+
+	if config.Address == "" {
+		return nil, errors.New("consul: the address is missing")
+	}
+
+	address := config.Address
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+
+	baseURL, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("consul: invalid address: %v", err)
+	}
+
+	return &Backend{config: config, baseURL: baseURL}, nil
+}
+
+// Load returns the content of a file, storage.ErrNotFound if the key doesn't exist.
+func (b *Backend) Load(name string) ([]byte, error) {
+	return b.call(http.MethodGet, b.key(name), url.Values{"raw": {""}}, nil)
+}
+
+// Save creates or replaces a key.
+func (b *Backend) Save(name string, data []byte) error {
+	body, err := b.call(http.MethodPut, b.key(name), nil, data)
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(string(body)) != "true" {
+		return fmt.Errorf("consul: unable to write the key %s: %s", b.key(name), string(body))
+	}
+
+	return nil
+}
+
+// List returns the sorted names of the keys starting with a prefix.
+func (b *Backend) List(prefix string) ([]string, error) {
+	body, err := b.call(http.MethodGet, b.key(prefix), url.Values{"keys": {""}}, nil)
+	if err == storage.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	err = json.Unmarshal(body, &keys)
+	if err != nil {
+		return nil, fmt.Errorf("consul: unable to parse the keys: %v", err)
+	}
+
+	var names []string
+	for _, key := range keys {
+		// the "folders" created by the UI of Consul.
+		if strings.HasSuffix(key, "/") {
+			continue
+		}
+
+		names = append(names, strings.TrimPrefix(key, b.key("")))
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// Delete removes a key.
+func (b *Backend) Delete(name string) error {
+	_, err := b.call(http.MethodDelete, b.key(name), nil, nil)
+	if err == storage.ErrNotFound {
+		return nil
+	}
+
+	return err
+}
+
+// Location returns the key of a file (consul:prefix/name).
+func (b *Backend) Location(name string) string {
+	return "consul:" + b.key(name)
+}
+
+// key returns the key of a file (prefix/name).
+func (b *Backend) key(name string) string {
+	if b.config.Prefix == "" {
+		return name
+	}
+
+	return strings.TrimSuffix(b.config.Prefix, "/") + "/" + name
+}
+
+// call calls the KV API on a key, returns storage.ErrNotFound if the status is 404.
+func (b *Backend) call(method, key string, query url.Values, data []byte) ([]byte, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+
+	if b.config.Datacenter != "" {
+		query.Set("dc", b.config.Datacenter)
+	}
+
+	if b.config.Namespace != "" {
+		query.Set("ns", b.config.Namespace)
+	}
+
+	endpoint := *b.baseURL
+	endpoint.Path = path.Join("/", endpoint.Path, "v1", "kv") + "/" + key
+	endpoint.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(method, endpoint.String(), bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	if b.config.Token != "" {
+		req.Header.Set("X-Consul-Token", b.config.Token)
+	}
+
+	client := b.config.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, storage.ErrNotFound
+	}
+
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("consul: %s %s: %d: %s", method, key, resp.StatusCode, string(body))
+	}
+
+	return body, nil
+}
+
+// newTLSConfig returns the TLS configuration of the client, nil if no option is defined.
+func newTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	if caFile == "" && certFile == "" && keyFile == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{}
+
+	if caFile != "" {
+		caData, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("consul: unable to read the CA certificates: %v", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caData) {
+			return nil, fmt.Errorf("consul: invalid CA certificates: %s", caFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("consul: unable to load the client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
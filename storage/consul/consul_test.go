@@ -0,0 +1,141 @@
+package consul
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-acme/lego/v3/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConsul a Consul agent with a KV store.
+type fakeConsul struct {
+	token string
+	keys  map[string][]byte
+}
+
+func (f *fakeConsul) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if req.Header.Get("X-Consul-Token") != f.token {
+		http.Error(rw, "Permission denied", http.StatusForbidden)
+		return
+	}
+
+	key := strings.TrimPrefix(req.URL.Path, "/v1/kv/")
+	query := req.URL.Query()
+
+	switch {
+	case req.Method == http.MethodGet && query["keys"] != nil:
+		var keys []string
+		for k := range f.keys {
+			if strings.HasPrefix(k, key) {
+				keys = append(keys, k)
+			}
+		}
+
+		if len(keys) == 0 {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		sort.Strings(keys)
+		_ = json.NewEncoder(rw).Encode(keys)
+
+	case req.Method == http.MethodGet && query["raw"] != nil:
+		value, ok := f.keys[key]
+		if !ok {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = rw.Write(value)
+
+	case req.Method == http.MethodPut:
+		value, _ := ioutil.ReadAll(req.Body)
+		f.keys[key] = value
+		_, _ = rw.Write([]byte("true"))
+
+	case req.Method == http.MethodDelete:
+		delete(f.keys, key)
+		_, _ = rw.Write([]byte("true"))
+
+	default:
+		http.Error(rw, "unexpected request", http.StatusBadRequest)
+	}
+}
+
+func TestBackend(t *testing.T) {
+	fake := &fakeConsul{token: "secret", keys: map[string][]byte{"lego/": nil}}
+
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	backend, err := NewBackendConfig(&Config{Address: server.URL, Token: "secret", Prefix: "lego"})
+	require.NoError(t, err)
+
+	_, err = backend.Load("certificates/example.com.crt")
+	require.Equal(t, storage.ErrNotFound, err)
+
+	require.NoError(t, backend.Save("certificates/example.com.crt", []byte("cert")))
+	require.NoError(t, backend.Save("certificates/example.com.key", []byte("key")))
+	require.NoError(t, backend.Save("accounts/acme.example.com/foo@example.com/account.json", []byte("{}")))
+
+	assert.Equal(t, "cert", string(fake.keys["lego/certificates/example.com.crt"]))
+
+	data, err := backend.Load("certificates/example.com.crt")
+	require.NoError(t, err)
+	assert.Equal(t, "cert", string(data))
+
+	names, err := backend.List("certificates/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"certificates/example.com.crt", "certificates/example.com.key"}, names)
+
+	names, err = backend.List("certificates/example.com.k")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"certificates/example.com.key"}, names)
+
+	names, err = backend.List("archives/")
+	require.NoError(t, err)
+	assert.Empty(t, names)
+
+	require.NoError(t, storage.Move(backend, "certificates/example.com.crt", "archives/1500000000.example.com.crt"))
+
+	// the folder "lego/" is ignored.
+	names, err = backend.List("")
+	require.NoError(t, err)
+	expected := []string{
+		"accounts/acme.example.com/foo@example.com/account.json",
+		"archives/1500000000.example.com.crt",
+		"certificates/example.com.key",
+	}
+	assert.Equal(t, expected, names)
+
+	require.NoError(t, backend.Delete("certificates/example.com.crt"))
+
+	assert.Equal(t, "consul:lego/certificates/example.com.key", backend.Location("certificates/example.com.key"))
+}
+
+func TestBackend_permissionDenied(t *testing.T) {
+	server := httptest.NewServer(&fakeConsul{token: "secret", keys: map[string][]byte{}})
+	defer server.Close()
+
+	backend, err := NewBackendConfig(&Config{Address: server.URL, Token: "invalid", Prefix: "lego"})
+	require.NoError(t, err)
+
+	_, err = backend.Load("certificates/example.com.crt")
+	require.EqualError(t, err, "consul: GET lego/certificates/example.com.crt: 403: Permission denied\n")
+}
+
+func TestNewBackendConfig(t *testing.T) {
+	backend, err := NewBackendConfig(&Config{Address: "127.0.0.1:8500"})
+	require.NoError(t, err)
+	assert.Equal(t, "http://127.0.0.1:8500", backend.baseURL.String())
+
+	_, err = NewBackendConfig(&Config{})
+	require.EqualError(t, err, "consul: the address is missing")
+}
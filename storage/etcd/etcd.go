@@ -0,0 +1,395 @@
+// Package etcd implements a storage of the accounts and the certificates in etcd (version 3).
+//
+//	import _ "github.com/go-acme/lego/v3/storage/etcd"
+//
+//	// ETCDCTL_ENDPOINTS=https://etcd1.example.com:2379,https://etcd2.example.com:2379 lego --storage=etcd ...
+//
+// Each file is a key (the raw content of the file), under a prefix ("lego" by default).
+// The client uses the JSON gateway of etcd (/v3/kv/...), available since etcd 3.4.
+// The instances of a cluster (i.e. load balancers) sharing the keys can watch the keys of the certificates
+// to pick up the renewals.
+package etcd
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/storage"
+)
+
+// Name the name of the storage (--storage option).
+const Name = "etcd"
+
+func init() {
+	storage.RegisterBackend(Name, func() (storage.Backend, error) {
+		return NewBackend()
+	})
+}
+
+// Config is used to configure the creation of the Backend.
+type Config struct {
+	// Endpoints the URLs of the members of the cluster, the next member is used if a member is not reachable.
+	Endpoints []string
+	// Username the user of the authentication, the authentication is disabled if empty.
+	Username string
+	// Password the password of the user.
+	Password string
+	// Prefix the prefix of the keys.
+	Prefix     string
+	HTTPClient *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the Backend.
+// The TLS options of the client are the CA certificates (ETCDCTL_CACERT) and the client certificate (ETCDCTL_CERT, ETCDCTL_KEY).
+func NewDefaultConfig() (*Config, error) {
+	tlsConfig, err := newTLSConfig(env.GetOrFile("ETCDCTL_CACERT"), env.GetOrFile("ETCDCTL_CERT"), env.GetOrFile("ETCDCTL_KEY"))
+	if err != nil {
+		return nil, err
+	}
+
+	// like etcdctl, the user can be "username:password".
+	username, password := env.GetOrFile("ETCDCTL_USER"), env.GetOrFile("ETCDCTL_PASSWORD")
+	if password == "" && strings.Contains(username, ":") {
+		parts := strings.SplitN(username, ":", 2)
+		username, password = parts[0], parts[1]
+	}
+
+	var endpoints []string
+	for _, endpoint := range strings.Split(env.GetOrDefaultString("ETCDCTL_ENDPOINTS", "http://127.0.0.1:2379"), ",") {
+		if strings.TrimSpace(endpoint) != "" {
+			endpoints = append(endpoints, strings.TrimSpace(endpoint))
+		}
+	}
+
+	return &Config{
+		Endpoints: endpoints,
+		Username:  username,
+		Password:  password,
+		Prefix:    env.GetOrDefaultString("LEGO_ETCD_PREFIX", "lego"),
+		HTTPClient: &http.Client{
+			Timeout:   env.GetOrDefaultSecond("LEGO_ETCD_HTTP_TIMEOUT", 30*time.Second),
+			Transport: &http.Transport{TLSClientConfig: tlsConfig, Proxy: http.ProxyFromEnvironment},
+		},
+	}, nil
+}
+
+// Backend stores the files as the keys of etcd.
+type Backend struct {
+	config *Config
+
+	mu       sync.Mutex
+	token    string
+	endpoint int
+}
+
+// NewBackend returns a Backend configured from the environment variables:
+// ETCDCTL_ENDPOINTS, ETCDCTL_USER, ETCDCTL_PASSWORD, ETCDCTL_CACERT, ETCDCTL_CERT, ETCDCTL_KEY, LEGO_ETCD_PREFIX.
+func NewBackend() (*Backend, error) {
+	config, err := NewDefaultConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewBackendConfig(config)
+}
+
+// NewBackendConfig returns a Backend configured with a given config.
+func NewBackendConfig(config *Config) (*Backend, error) {
+	if config == nil {
+		return nil, errors.New("etcd: the configuration of the storage is nil")
+	}
+
+	if len(config.Endpoints) == 0 {
+		return nil, errors.New("etcd: the endpoints are missing")
+	}
+
+	backend := &Backend{config: config}
+
+	if config.Username != "" {
+		err := backend.authenticate()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return backend, nil
+}
+
+// keyValue a key-value pair of a range response, the key and the value are base64 encoded.
+type keyValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Load returns the content of a file, storage.ErrNotFound if the key doesn't exist.
+func (b *Backend) Load(name string) ([]byte, error) {
+	var result struct {
+		Kvs []keyValue `json:"kvs"`
+	}
+
+	payload := map[string]interface{}{"key": []byte(b.key(name))}
+
+	err := b.call("kv/range", payload, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Kvs) == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return base64.StdEncoding.DecodeString(result.Kvs[0].Value)
+}
+
+// Save creates or replaces a key.
+func (b *Backend) Save(name string, data []byte) error {
+	payload := map[string]interface{}{"key": []byte(b.key(name)), "value": data}
+
+	return b.call("kv/put", payload, nil)
+}
+
+// List returns the sorted names of the keys starting with a prefix.
+func (b *Backend) List(prefix string) ([]string, error) {
+	var result struct {
+		Kvs []keyValue `json:"kvs"`
+	}
+
+	key := []byte(b.key(prefix))
+	rangeEnd := prefixEnd(key)
+
+	if len(key) == 0 {
+		// all the keys.
+		key = []byte{0}
+	}
+
+	payload := map[string]interface{}{"key": key, "range_end": rangeEnd, "keys_only": true}
+
+	err := b.call("kv/range", payload, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, kv := range result.Kvs {
+		key, err := base64.StdEncoding.DecodeString(kv.Key)
+		if err != nil {
+			return nil, fmt.Errorf("etcd: invalid key: %v", err)
+		}
+
+		names = append(names, strings.TrimPrefix(string(key), b.key("")))
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// Delete removes a key.
+func (b *Backend) Delete(name string) error {
+	payload := map[string]interface{}{"key": []byte(b.key(name))}
+
+	return b.call("kv/deleterange", payload, nil)
+}
+
+// Location returns the key of a file (etcd:prefix/name).
+func (b *Backend) Location(name string) string {
+	return "etcd:" + b.key(name)
+}
+
+// key returns the key of a file (prefix/name).
+func (b *Backend) key(name string) string {
+	if b.config.Prefix == "" {
+		return name
+	}
+
+	return strings.TrimSuffix(b.config.Prefix, "/") + "/" + name
+}
+
+// authenticate gets a token with the username and the password.
+func (b *Backend) authenticate() error {
+	var result struct {
+		Token string `json:"token"`
+	}
+
+	payload := map[string]string{"name": b.config.Username, "password": b.config.Password}
+
+	status, body, err := b.send("auth/authenticate", "", payload)
+	if err != nil {
+		return err
+	}
+
+	if status != http.StatusOK {
+		return fmt.Errorf("etcd: unable to authenticate the user %s: %d: %s", b.config.Username, status, string(body))
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return fmt.Errorf("etcd: unable to parse the authentication response: %v", err)
+	}
+
+	if result.Token == "" {
+		return errors.New("etcd: the authentication response doesn't contain a token")
+	}
+
+	b.mu.Lock()
+	b.token = result.Token
+	b.mu.Unlock()
+
+	return nil
+}
+
+// call calls the API, a new token is requested if the token has expired.
+func (b *Backend) call(apiPath string, payload, result interface{}) error {
+	status, body, err := b.send(apiPath, b.getToken(), payload)
+	if err != nil {
+		return err
+	}
+
+	if status == http.StatusUnauthorized && b.config.Username != "" {
+		err = b.authenticate()
+		if err != nil {
+			return err
+		}
+
+		status, body, err = b.send(apiPath, b.getToken(), payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	if status != http.StatusOK {
+		return fmt.Errorf("etcd: %s: %d: %s", apiPath, status, string(body))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	err = json.Unmarshal(body, result)
+	if err != nil {
+		return fmt.Errorf("etcd: unable to parse the response of %s: %v", apiPath, err)
+	}
+
+	return nil
+}
+
+func (b *Backend) getToken() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.token
+}
+
+// send sends a request to the current member of the cluster, the next members are used if the member is not reachable.
+func (b *Backend) send(apiPath, token string, payload interface{}) (int, []byte, error) {
+	reqBody, err := json.Marshal(payload)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	client := b.config.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	b.mu.Lock()
+	first := b.endpoint
+	b.mu.Unlock()
+
+	var errs []string
+	for i := 0; i < len(b.config.Endpoints); i++ {
+		index := (first + i) % len(b.config.Endpoints)
+		endpoint := strings.TrimSuffix(b.config.Endpoints[index], "/") + "/v3/" + apiPath
+
+		req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(reqBody))
+		if err != nil {
+			return 0, nil, err
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+
+		body, err := ioutil.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		b.mu.Lock()
+		b.endpoint = index
+		b.mu.Unlock()
+
+		return resp.StatusCode, body, nil
+	}
+
+	return 0, nil, fmt.Errorf("etcd: no reachable endpoint: %s", strings.Join(errs, ", "))
+}
+
+// prefixEnd returns the end of the range of the keys starting with a prefix (the prefix with its last byte incremented).
+func prefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+
+	// all the keys.
+	return []byte{0}
+}
+
+// newTLSConfig returns the TLS configuration of the client, nil if no option is defined.
+func newTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	if caFile == "" && certFile == "" && keyFile == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{}
+
+	if caFile != "" {
+		caData, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("etcd: unable to read the CA certificates: %v", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caData) {
+			return nil, fmt.Errorf("etcd: invalid CA certificates: %s", caFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("etcd: unable to load the client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,172 @@
+package etcd
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/go-acme/lego/v3/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEtcd a member of an etcd cluster (JSON gateway), with the authentication enabled.
+type fakeEtcd struct {
+	keys   map[string][]byte
+	tokens map[string]bool
+	logins int
+}
+
+func (f *fakeEtcd) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	var payload struct {
+		Name     string `json:"name"`
+		Password string `json:"password"`
+		Key      []byte `json:"key"`
+		RangeEnd []byte `json:"range_end"`
+		Value    []byte `json:"value"`
+		KeysOnly bool   `json:"keys_only"`
+	}
+	_ = json.NewDecoder(req.Body).Decode(&payload)
+
+	if req.URL.Path == "/v3/auth/authenticate" {
+		if payload.Name != "lego" || payload.Password != "secret" {
+			http.Error(rw, `{"error":"etcdserver: authentication failed, invalid user ID or password","code":3}`, http.StatusBadRequest)
+			return
+		}
+
+		f.logins++
+		token := "token" + strconv.Itoa(f.logins)
+		f.tokens[token] = true
+
+		_ = json.NewEncoder(rw).Encode(map[string]string{"token": token})
+		return
+	}
+
+	if !f.tokens[req.Header.Get("Authorization")] {
+		http.Error(rw, `{"error":"etcdserver: invalid auth token","code":16}`, http.StatusUnauthorized)
+		return
+	}
+
+	switch req.URL.Path {
+	case "/v3/kv/range":
+		var kvs []map[string]string
+		for key, value := range f.keys {
+			k := []byte(key)
+
+			match := bytes.Equal(k, payload.Key)
+			if len(payload.RangeEnd) > 0 {
+				match = bytes.Compare(k, payload.Key) >= 0 && bytes.Compare(k, payload.RangeEnd) < 0
+			}
+
+			if !match {
+				continue
+			}
+
+			kv := map[string]string{"key": base64.StdEncoding.EncodeToString(k)}
+			if !payload.KeysOnly {
+				kv["value"] = base64.StdEncoding.EncodeToString(value)
+			}
+			kvs = append(kvs, kv)
+		}
+
+		sort.Slice(kvs, func(i, j int) bool { return kvs[i]["key"] < kvs[j]["key"] })
+
+		_ = json.NewEncoder(rw).Encode(map[string]interface{}{"header": map[string]string{}, "kvs": kvs})
+
+	case "/v3/kv/put":
+		f.keys[string(payload.Key)] = payload.Value
+		_ = json.NewEncoder(rw).Encode(map[string]interface{}{"header": map[string]string{}})
+
+	case "/v3/kv/deleterange":
+		delete(f.keys, string(payload.Key))
+		_ = json.NewEncoder(rw).Encode(map[string]interface{}{"header": map[string]string{}})
+
+	default:
+		http.Error(rw, `{"error":"Not Found","code":5}`, http.StatusNotFound)
+	}
+}
+
+func TestBackend(t *testing.T) {
+	fake := &fakeEtcd{keys: map[string][]byte{"other/key": []byte("other")}, tokens: map[string]bool{}}
+
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	// the first member is not reachable.
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	config := &Config{
+		Endpoints: []string{unreachable.URL, server.URL},
+		Username:  "lego",
+		Password:  "secret",
+		Prefix:    "lego",
+	}
+
+	backend, err := NewBackendConfig(config)
+	require.NoError(t, err)
+	assert.Equal(t, 1, fake.logins)
+
+	_, err = backend.Load("certificates/example.com.crt")
+	require.Equal(t, storage.ErrNotFound, err)
+
+	require.NoError(t, backend.Save("certificates/example.com.crt", []byte("cert")))
+	require.NoError(t, backend.Save("certificates/example.com.key", []byte("key")))
+	require.NoError(t, backend.Save("accounts/acme.example.com/foo@example.com/account.json", []byte("{}")))
+
+	assert.Equal(t, "cert", string(fake.keys["lego/certificates/example.com.crt"]))
+
+	data, err := backend.Load("certificates/example.com.crt")
+	require.NoError(t, err)
+	assert.Equal(t, "cert", string(data))
+
+	names, err := backend.List("certificates/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"certificates/example.com.crt", "certificates/example.com.key"}, names)
+
+	names, err = backend.List("archives/")
+	require.NoError(t, err)
+	assert.Empty(t, names)
+
+	// the token has expired: a new token is requested.
+	fake.tokens = map[string]bool{}
+
+	require.NoError(t, storage.Move(backend, "certificates/example.com.crt", "archives/1500000000.example.com.crt"))
+	assert.Equal(t, 2, fake.logins)
+
+	names, err = backend.List("")
+	require.NoError(t, err)
+	expected := []string{
+		"accounts/acme.example.com/foo@example.com/account.json",
+		"archives/1500000000.example.com.crt",
+		"certificates/example.com.key",
+	}
+	assert.Equal(t, expected, names)
+
+	require.NoError(t, backend.Delete("certificates/example.com.crt"))
+
+	assert.Equal(t, "etcd:lego/certificates/example.com.key", backend.Location("certificates/example.com.key"))
+}
+
+func TestNewBackendConfig_errors(t *testing.T) {
+	server := httptest.NewServer(&fakeEtcd{keys: map[string][]byte{}, tokens: map[string]bool{}})
+	defer server.Close()
+
+	_, err := NewBackendConfig(&Config{})
+	require.EqualError(t, err, "etcd: the endpoints are missing")
+
+	_, err = NewBackendConfig(&Config{Endpoints: []string{server.URL}, Username: "lego", Password: "invalid"})
+	require.EqualError(t, err, `etcd: unable to authenticate the user lego: 400: {"error":"etcdserver: authentication failed, invalid user ID or password","code":3}`+"\n")
+}
+
+func Test_prefixEnd(t *testing.T) {
+	assert.Equal(t, []byte("lego/certificates0"), prefixEnd([]byte("lego/certificates/")))
+	assert.Equal(t, []byte{'a', 0x01}, prefixEnd([]byte{'a', 0x00, 0xff}))
+	assert.Equal(t, []byte{0}, prefixEnd([]byte{0xff}))
+	assert.Equal(t, []byte{0}, prefixEnd(nil))
+}
@@ -0,0 +1,164 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Permissions of the files and the folders created by FileSystem.
+const (
+	filePerm   os.FileMode = 0600
+	folderPerm os.FileMode = 0700
+)
+
+// FileSystem is the default Backend: the files are stored in a local folder (the "path" option of the CLI).
+type FileSystem struct {
+	root string
+}
+
+// NewFileSystem creates a FileSystem storing the files in a folder.
+func NewFileSystem(root string) *FileSystem {
+	return &FileSystem{root: root}
+}
+
+// Load returns the content of a file, ErrNotFound if the file doesn't exist.
+func (f *FileSystem) Load(name string) ([]byte, error) {
+	data, err := ioutil.ReadFile(f.Location(name))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+
+	return data, err
+}
+
+// Save creates or replaces a file, the missing folders are created.
+// The content is written in a temporary file of the same folder, then the temporary file is renamed:
+// a crash during the writing never leaves a partially written file.
+// A replaced file keeps its permissions, and its owner and its group when possible.
+func (f *FileSystem) Save(name string, data []byte) error {
+	filename := f.Location(name)
+
+	err := os.MkdirAll(filepath.Dir(filename), folderPerm)
+	if err != nil {
+		return err
+	}
+
+	previous, err := os.Stat(filename)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// the suffix .tmp keeps the temporary file out of the lists of certificates (.crt) and accounts (.json).
+	tmp, err := ioutil.TempFile(filepath.Dir(filename), "."+filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	err = writeTempFile(tmp, data)
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	if previous != nil {
+		err = os.Chmod(tmp.Name(), previous.Mode().Perm())
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+			return err
+		}
+
+		copyOwner(tmp.Name(), previous)
+	}
+
+	err = os.Rename(tmp.Name(), filename)
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	return nil
+}
+
+// writeTempFile writes the content of a temporary file (created with the permissions filePerm), flushed to the disk before the renaming.
+func writeTempFile(tmp *os.File, data []byte) error {
+	_, err := tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+
+	if errClose := tmp.Close(); err == nil {
+		err = errClose
+	}
+
+	return err
+}
+
+// List returns the sorted names of the files starting with a prefix.
+func (f *FileSystem) List(prefix string) ([]string, error) {
+	dir := path.Dir(prefix)
+	if strings.HasSuffix(prefix, "/") {
+		dir = strings.TrimSuffix(prefix, "/")
+	}
+
+	var names []string
+
+	err := filepath.Walk(f.Location(dir), func(filename string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(f.root, filename)
+		if err != nil {
+			return err
+		}
+
+		name := filepath.ToSlash(rel)
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// Delete removes a file.
+func (f *FileSystem) Delete(name string) error {
+	err := os.Remove(f.Location(name))
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	return err
+}
+
+// Rename moves a file, the missing folders are created.
+func (f *FileSystem) Rename(oldName, newName string) error {
+	filename := f.Location(newName)
+
+	err := os.MkdirAll(filepath.Dir(filename), folderPerm)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(f.Location(oldName), filename)
+}
+
+// Location returns the path of a file.
+func (f *FileSystem) Location(name string) string {
+	return filepath.Join(f.root, filepath.FromSlash(name))
+}
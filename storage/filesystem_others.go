@@ -0,0 +1,22 @@
+//go:build !windows
+// +build !windows
+
+package storage
+
+import (
+	"os"
+	"syscall"
+)
+
+// copyOwner gives a file the owner and the group of a replaced file, when possible:
+// only root can change the owner, the group can be changed by the owner if they are a member of the group.
+func copyOwner(filename string, info os.FileInfo) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return
+	}
+
+	if err := os.Chown(filename, int(stat.Uid), int(stat.Gid)); err != nil {
+		_ = os.Chown(filename, -1, int(stat.Gid))
+	}
+}
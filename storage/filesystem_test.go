@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSystem(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-storage")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	fs := NewFileSystem(dir)
+
+	_, err = fs.Load("certificates/example.com.crt")
+	assert.Equal(t, ErrNotFound, err)
+
+	names, err := fs.List("certificates/")
+	require.NoError(t, err)
+	assert.Empty(t, names)
+
+	for _, name := range []string{"certificates/example.com.crt", "certificates/example.com.key", "certificates/example.org.crt", "archives/1.example.net.crt"} {
+		require.NoError(t, fs.Save(name, []byte(name)))
+	}
+
+	data, err := fs.Load("certificates/example.com.crt")
+	require.NoError(t, err)
+	assert.Equal(t, "certificates/example.com.crt", string(data))
+
+	info, err := os.Stat(filepath.Join(dir, "certificates", "example.com.key"))
+	require.NoError(t, err)
+	assert.Equal(t, filePerm, info.Mode().Perm())
+
+	names, err = fs.List("certificates/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"certificates/example.com.crt", "certificates/example.com.key", "certificates/example.org.crt"}, names)
+
+	names, err = fs.List("certificates/example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"certificates/example.com.crt", "certificates/example.com.key"}, names)
+
+	require.NoError(t, Move(fs, "certificates/example.org.crt", "archives/2.example.org.crt"))
+
+	names, err = fs.List("archives/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"archives/1.example.net.crt", "archives/2.example.org.crt"}, names)
+
+	require.NoError(t, fs.Delete("certificates/example.com.key"))
+	require.NoError(t, fs.Delete("certificates/example.com.key"))
+
+	exists, err := Exists(fs, "certificates/example.com.key")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	assert.Equal(t, filepath.Join(dir, "certificates", "example.com.crt"), fs.Location("certificates/example.com.crt"))
+}
+
+func TestFileSystem_Save_replace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-storage")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	fs := NewFileSystem(dir)
+
+	require.NoError(t, fs.Save("certificates/example.com.crt", []byte("old")))
+	require.NoError(t, fs.Save("certificates/example.com.crt", []byte("new")))
+
+	data, err := fs.Load("certificates/example.com.crt")
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+
+	// the temporary file has been renamed.
+	files, err := ioutil.ReadDir(filepath.Join(dir, "certificates"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "example.com.crt", files[0].Name())
+	assert.Equal(t, filePerm, files[0].Mode().Perm())
+}
+
+func TestFileSystem_Save_permissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the permissions of the files are not supported on Windows")
+	}
+
+	dir, err := ioutil.TempDir("", "lego-storage")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	fs := NewFileSystem(dir)
+
+	// a file shared with the group of a server.
+	filename := filepath.Join(dir, "certificates", "example.com.key")
+	require.NoError(t, os.MkdirAll(filepath.Dir(filename), folderPerm))
+	require.NoError(t, ioutil.WriteFile(filename, []byte("old"), 0640))
+	require.NoError(t, os.Chmod(filename, 0640))
+
+	require.NoError(t, fs.Save("certificates/example.com.key", []byte("new")))
+
+	data, err := fs.Load("certificates/example.com.key")
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+
+	info, err := os.Stat(filename)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0640), info.Mode().Perm())
+}
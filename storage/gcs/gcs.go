@@ -0,0 +1,204 @@
+// Package gcs implements a storage of the accounts and the certificates in a Google Cloud Storage bucket.
+//
+//	import _ "github.com/go-acme/lego/v3/storage/gcs"
+//
+//	// LEGO_GCS_BUCKET=my-bucket lego --storage=gcs ...
+//
+// A Service Account can be passed in the environment variable LEGO_GCS_SERVICE_ACCOUNT (or LEGO_GCS_SERVICE_ACCOUNT_FILE),
+// otherwise the Application Default Credentials are used (GOOGLE_APPLICATION_CREDENTIALS, gcloud SDK, metadata server, workload identity).
+package gcs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/storage"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	gstorage "google.golang.org/api/storage/v1"
+)
+
+// Name the name of the storage (--storage option).
+const Name = "gcs"
+
+func init() {
+	storage.RegisterBackend(Name, func() (storage.Backend, error) {
+		return NewBackend()
+	})
+}
+
+// Config is used to configure the creation of the Backend.
+type Config struct {
+	// Bucket the name of the bucket.
+	Bucket string
+	// Prefix the prefix of the names of the objects (i.e. "lego/").
+	Prefix string
+	// KMSKeyName the Cloud KMS key used to encrypt the objects (projects/p/locations/l/keyRings/r/cryptoKeys/k),
+	// the default encryption of the bucket is used if empty.
+	KMSKeyName string
+	// ServiceAccount the JSON key of a Service Account, the Application Default Credentials are used if empty.
+	ServiceAccount []byte
+	// HTTPClient the client used to call the API, the client is created from the credentials if nil.
+	HTTPClient *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the Backend.
+func NewDefaultConfig() *Config {
+	return &Config{
+		Bucket:         env.GetOrFile("LEGO_GCS_BUCKET"),
+		Prefix:         env.GetOrFile("LEGO_GCS_PREFIX"),
+		KMSKeyName:     env.GetOrFile("LEGO_GCS_KMS_KEY_NAME"),
+		ServiceAccount: []byte(env.GetOrFile("LEGO_GCS_SERVICE_ACCOUNT")),
+	}
+}
+
+// Backend stores the files as the objects of a bucket.
+type Backend struct {
+	service *gstorage.Service
+	config  *Config
+}
+
+// NewBackend returns a Backend configured from the environment variables:
+// LEGO_GCS_BUCKET, LEGO_GCS_PREFIX, LEGO_GCS_KMS_KEY_NAME, LEGO_GCS_SERVICE_ACCOUNT.
+func NewBackend() (*Backend, error) {
+	return NewBackendConfig(NewDefaultConfig())
+}
+
+// NewBackendConfig returns a Backend configured with a given config.
+func NewBackendConfig(config *Config) (*Backend, error) {
+	if config == nil {
+		return nil, errors.New("gcs: the configuration of the storage is nil")
+	}
+
+	if config.Bucket == "" {
+		return nil, errors.New("gcs: the bucket is missing")
+	}
+
+	ctx := context.Background()
+
+	client := config.HTTPClient
+	if client == nil {
+		var err error
+		client, err = newHTTPClient(ctx, config.ServiceAccount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	service, err := gstorage.NewService(ctx, option.WithHTTPClient(client))
+	if err != nil {
+		return nil, fmt.Errorf("gcs: unable to create the client: %v", err)
+	}
+
+	return &Backend{service: service, config: config}, nil
+}
+
+func newHTTPClient(ctx context.Context, serviceAccount []byte) (*http.Client, error) {
+	if len(serviceAccount) > 0 {
+		conf, err := google.JWTConfigFromJSON(serviceAccount, gstorage.DevstorageReadWriteScope)
+		if err != nil {
+			return nil, fmt.Errorf("gcs: unable to acquire config: %v", err)
+		}
+
+		return conf.Client(ctx), nil
+	}
+
+	client, err := google.DefaultClient(ctx, gstorage.DevstorageReadWriteScope)
+	if err != nil {
+		return nil, fmt.Errorf("gcs: unable to get Google Cloud client: %v", err)
+	}
+
+	return client, nil
+}
+
+// Load returns the content of an object, storage.ErrNotFound if the object doesn't exist.
+func (b *Backend) Load(name string) ([]byte, error) {
+	resp, err := b.service.Objects.Get(b.config.Bucket, b.key(name)).Download()
+	if err != nil {
+		if isNotFound(err) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, fmt.Errorf("gcs: unable to get %s: %v", b.Location(name), err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+// Save creates or replaces an object, encrypted with the KMS key of the configuration.
+func (b *Backend) Save(name string, data []byte) error {
+	call := b.service.Objects.Insert(b.config.Bucket, &gstorage.Object{Name: b.key(name)}).
+		Media(bytes.NewReader(data))
+
+	if b.config.KMSKeyName != "" {
+		call = call.KmsKeyName(b.config.KMSKeyName)
+	}
+
+	_, err := call.Do()
+	if err != nil {
+		return fmt.Errorf("gcs: unable to put %s: %v", b.Location(name), err)
+	}
+
+	return nil
+}
+
+// List returns the sorted names of the objects starting with a prefix.
+func (b *Backend) List(prefix string) ([]string, error) {
+	var names []string
+
+	call := b.service.Objects.List(b.config.Bucket).Prefix(b.key(prefix)).Fields("nextPageToken", "items/name")
+
+	err := call.Pages(context.Background(), func(objects *gstorage.Objects) error {
+		for _, object := range objects.Items {
+			names = append(names, strings.TrimPrefix(object.Name, b.prefix()))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gcs: unable to list %s: %v", b.Location(prefix), err)
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// Delete removes an object, an object which doesn't exist is not an error.
+func (b *Backend) Delete(name string) error {
+	err := b.service.Objects.Delete(b.config.Bucket, b.key(name)).Do()
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("gcs: unable to delete %s: %v", b.Location(name), err)
+	}
+
+	return nil
+}
+
+// Location returns the URL of an object (gs://bucket/name).
+func (b *Backend) Location(name string) string {
+	return "gs://" + b.config.Bucket + "/" + b.key(name)
+}
+
+func (b *Backend) key(name string) string {
+	return b.prefix() + name
+}
+
+func (b *Backend) prefix() string {
+	if b.config.Prefix == "" {
+		return ""
+	}
+
+	return strings.TrimSuffix(b.config.Prefix, "/") + "/"
+}
+
+func isNotFound(err error) bool {
+	gerr, ok := err.(*googleapi.Error)
+	return ok && gerr.Code == http.StatusNotFound
+}
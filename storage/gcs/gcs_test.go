@@ -0,0 +1,118 @@
+package gcs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-acme/lego/v3/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+	gstorage "google.golang.org/api/storage/v1"
+)
+
+func setupTest(t *testing.T, objects map[string]string) (*Backend, func()) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc("/b/my-bucket/o", func(rw http.ResponseWriter, req *http.Request) {
+		result := &gstorage.Objects{}
+		for name := range objects {
+			if strings.HasPrefix(name, req.URL.Query().Get("prefix")) {
+				result.Items = append(result.Items, &gstorage.Object{Name: name})
+			}
+		}
+
+		_ = json.NewEncoder(rw).Encode(result)
+	})
+
+	mux.HandleFunc("/b/my-bucket/o/", func(rw http.ResponseWriter, req *http.Request) {
+		name := strings.TrimPrefix(req.URL.Path, "/b/my-bucket/o/")
+
+		data, ok := objects[name]
+		if !ok {
+			http.Error(rw, `{"error":{"code":404,"message":"No such object"}}`, http.StatusNotFound)
+			return
+		}
+
+		switch req.Method {
+		case http.MethodGet:
+			_, _ = rw.Write([]byte(data))
+		case http.MethodDelete:
+			delete(objects, name)
+			rw.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(rw, "unsupported method", http.StatusMethodNotAllowed)
+		}
+	})
+
+	service, err := gstorage.NewService(context.Background(), option.WithHTTPClient(server.Client()), option.WithEndpoint(server.URL+"/"))
+	require.NoError(t, err)
+
+	backend := &Backend{service: service, config: &Config{Bucket: "my-bucket", Prefix: "lego/"}}
+
+	return backend, server.Close
+}
+
+func TestBackend_Load(t *testing.T) {
+	backend, tearDown := setupTest(t, map[string]string{
+		"lego/certificates/example.com.crt": "cert",
+	})
+	defer tearDown()
+
+	data, err := backend.Load("certificates/example.com.crt")
+	require.NoError(t, err)
+	assert.Equal(t, "cert", string(data))
+
+	_, err = backend.Load("certificates/example.org.crt")
+	require.Equal(t, storage.ErrNotFound, err)
+}
+
+func TestBackend_List(t *testing.T) {
+	backend, tearDown := setupTest(t, map[string]string{
+		"lego/certificates/example.com.key":    "key",
+		"lego/certificates/example.com.crt":    "cert",
+		"lego/archives/1500000000.example.crt": "old",
+	})
+	defer tearDown()
+
+	names, err := backend.List("certificates/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"certificates/example.com.crt", "certificates/example.com.key"}, names)
+}
+
+func TestBackend_Delete(t *testing.T) {
+	objects := map[string]string{
+		"lego/certificates/example.com.crt": "cert",
+	}
+
+	backend, tearDown := setupTest(t, objects)
+	defer tearDown()
+
+	require.NoError(t, backend.Delete("certificates/example.com.crt"))
+	assert.Empty(t, objects)
+
+	// an object which doesn't exist is not an error.
+	require.NoError(t, backend.Delete("certificates/example.com.crt"))
+}
+
+func TestBackend_Location(t *testing.T) {
+	backend := &Backend{config: &Config{Bucket: "my-bucket", Prefix: "lego"}}
+
+	assert.Equal(t, "gs://my-bucket/lego/certificates/example.com.crt", backend.Location("certificates/example.com.crt"))
+
+	backend.config.Prefix = ""
+
+	assert.Equal(t, "gs://my-bucket/certificates/example.com.crt", backend.Location("certificates/example.com.crt"))
+}
+
+func TestNewBackendConfig_missingBucket(t *testing.T) {
+	_, err := NewBackendConfig(&Config{})
+	require.EqualError(t, err, "gcs: the bucket is missing")
+}
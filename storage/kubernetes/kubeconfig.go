@@ -0,0 +1,178 @@
+package kubernetes
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+// kubeconfig the subset of a kubeconfig file used by the client.
+type kubeconfig struct {
+	CurrentContext string `yaml:"current-context"`
+	Contexts       []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster   string `yaml:"cluster"`
+			User      string `yaml:"user"`
+			Namespace string `yaml:"namespace"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+	Clusters []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthority     string `yaml:"certificate-authority"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+			InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			Token                 string      `yaml:"token"`
+			TokenFile             string      `yaml:"tokenFile"`
+			ClientCertificate     string      `yaml:"client-certificate"`
+			ClientCertificateData string      `yaml:"client-certificate-data"`
+			ClientKey             string      `yaml:"client-key"`
+			ClientKeyData         string      `yaml:"client-key-data"`
+			Username              string      `yaml:"username"`
+			Password              string      `yaml:"password"`
+			Exec                  interface{} `yaml:"exec"`
+			AuthProvider          interface{} `yaml:"auth-provider"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+}
+
+// newKubeconfigClient creates a client with the current context of a kubeconfig file.
+func newKubeconfigClient(filename string) (*Client, error) {
+	raw, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes: unable to read the kubeconfig file: %v", err)
+	}
+
+	var config kubeconfig
+	err = yaml.Unmarshal(raw, &config)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes: unable to parse the kubeconfig file %s: %v", filename, err)
+	}
+
+	if config.CurrentContext == "" {
+		return nil, fmt.Errorf("kubernetes: no current context in the kubeconfig file %s", filename)
+	}
+
+	dir := filepath.Dir(filename)
+
+	for _, kctx := range config.Contexts {
+		if kctx.Name != config.CurrentContext {
+			continue
+		}
+
+		client := &Client{namespace: kctx.Context.Namespace}
+
+		var tlsCACert []byte
+		var insecure bool
+
+		found := false
+		for _, cluster := range config.Clusters {
+			if cluster.Name != kctx.Context.Cluster {
+				continue
+			}
+
+			found = true
+			client.host = cluster.Cluster.Server
+			insecure = cluster.Cluster.InsecureSkipTLSVerify
+
+			tlsCACert, err = readData(dir, cluster.Cluster.CertificateAuthorityData, cluster.Cluster.CertificateAuthority)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if !found || client.host == "" {
+			return nil, fmt.Errorf("kubernetes: the cluster %s of the context %s is not defined", kctx.Context.Cluster, kctx.Name)
+		}
+
+		tlsConfig, err := newTLSConfig(tlsCACert, insecure)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range config.Users {
+			if user.Name != kctx.Context.User {
+				continue
+			}
+
+			if user.User.Exec != nil || user.User.AuthProvider != nil {
+				return nil, fmt.Errorf("kubernetes: the authentication of the user %s (exec or auth-provider) is not supported", user.Name)
+			}
+
+			client.token = user.User.Token
+			client.username = user.User.Username
+			client.password = user.User.Password
+
+			if user.User.TokenFile != "" {
+				client.tokenFile = resolvePath(dir, user.User.TokenFile)
+			}
+
+			certPEM, err := readData(dir, user.User.ClientCertificateData, user.User.ClientCertificate)
+			if err != nil {
+				return nil, err
+			}
+
+			keyPEM, err := readData(dir, user.User.ClientKeyData, user.User.ClientKey)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(certPEM) > 0 {
+				cert, err := tls.X509KeyPair(certPEM, keyPEM)
+				if err != nil {
+					return nil, fmt.Errorf("kubernetes: invalid client certificate of the user %s: %v", user.Name, err)
+				}
+				tlsConfig.Certificates = []tls.Certificate{cert}
+			}
+		}
+
+		client.httpClient = newHTTPClient(tlsConfig)
+
+		return client, nil
+	}
+
+	return nil, fmt.Errorf("kubernetes: the context %s is not defined", config.CurrentContext)
+}
+
+// readData returns the decoded base64 data, or the content of the file.
+func readData(dir, data, filename string) ([]byte, error) {
+	if data != "" {
+		decoded, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return nil, errors.New("kubernetes: invalid base64 data in the kubeconfig file")
+		}
+		return decoded, nil
+	}
+
+	if filename == "" {
+		return nil, nil
+	}
+
+	content, err := ioutil.ReadFile(resolvePath(dir, filename))
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes: %v", err)
+	}
+
+	return content, nil
+}
+
+// resolvePath the relative paths of a kubeconfig file are relative to the folder of the file.
+func resolvePath(dir, filename string) string {
+	if filepath.IsAbs(filename) {
+		return filename
+	}
+
+	return filepath.Join(dir, filename)
+}
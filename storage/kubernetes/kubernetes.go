@@ -0,0 +1,284 @@
+// Package kubernetes writes the certificates in Kubernetes TLS Secrets (kubernetes.io/tls).
+//
+// The client is authenticated with the service account of the pod (in-cluster), or with a kubeconfig file
+// (token, client certificate, or basic authentication).
+package kubernetes
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Data keys of the TLS Secrets.
+const (
+	TLSCertKey = "tls.crt"
+	TLSKeyKey  = "tls.key"
+	CACertKey  = "ca.crt"
+)
+
+// SecretTypeTLS the type of the TLS Secrets.
+const SecretTypeTLS = "kubernetes.io/tls"
+
+// managedByLabel the label identifying the Secrets written by lego.
+const managedByLabel = "app.kubernetes.io/managed-by"
+
+const serviceAccountPath = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// TLSSecret the content of a TLS Secret.
+type TLSSecret struct {
+	Certificate       []byte
+	PrivateKey        []byte
+	IssuerCertificate []byte
+	Annotations       map[string]string
+}
+
+// Client a minimal client of the Kubernetes API, managing the TLS Secrets.
+type Client struct {
+	host       string
+	namespace  string
+	token      string
+	tokenFile  string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// NewClient creates a client with the service account of the pod if lego runs in a cluster and kubeconfig is empty,
+// otherwise with the current context of the kubeconfig file (by default $HOME/.kube/config).
+func NewClient(kubeconfig string) (*Client, error) {
+	if kubeconfig == "" && os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
+		return newInClusterClient()
+	}
+
+	if kubeconfig == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("kubernetes: unable to find the kubeconfig file: %v", err)
+		}
+		kubeconfig = filepath.Join(home, ".kube", "config")
+	}
+
+	return newKubeconfigClient(kubeconfig)
+}
+
+func newInClusterClient() (*Client, error) {
+	host := net.JoinHostPort(os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT"))
+
+	caData, err := ioutil.ReadFile(filepath.Join(serviceAccountPath, "ca.crt"))
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes: unable to read the CA of the cluster: %v", err)
+	}
+
+	namespace, err := ioutil.ReadFile(filepath.Join(serviceAccountPath, "namespace"))
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes: unable to read the namespace of the pod: %v", err)
+	}
+
+	tlsConfig, err := newTLSConfig(caData, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		host:      "https://" + host,
+		namespace: strings.TrimSpace(string(namespace)),
+		// the token of the service account is rotated: the file is read by each request.
+		tokenFile:  filepath.Join(serviceAccountPath, "token"),
+		httpClient: newHTTPClient(tlsConfig),
+	}, nil
+}
+
+// Namespace returns the default namespace: the namespace of the pod or of the context of the kubeconfig.
+func (c *Client) Namespace() string {
+	if c.namespace == "" {
+		return "default"
+	}
+
+	return c.namespace
+}
+
+// ApplyTLSSecret creates or updates a TLS Secret.
+// An existing Secret of another type is not replaced.
+func (c *Client) ApplyTLSSecret(namespace, name string, secret *TLSSecret) error {
+	secretPath := fmt.Sprintf("/api/v1/namespaces/%s/secrets/%s", namespace, name)
+
+	var current resource
+
+	found, err := c.call(http.MethodGet, secretPath, nil, &current)
+	if err != nil {
+		return err
+	}
+
+	desired := resource{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Type:       SecretTypeTLS,
+		Metadata: metadata{
+			Name:        name,
+			Namespace:   namespace,
+			Labels:      map[string]string{managedByLabel: "lego"},
+			Annotations: secret.Annotations,
+		},
+		Data: map[string][]byte{
+			TLSCertKey: secret.Certificate,
+			TLSKeyKey:  secret.PrivateKey,
+		},
+	}
+
+	if len(secret.IssuerCertificate) > 0 {
+		desired.Data[CACertKey] = secret.IssuerCertificate
+	}
+
+	if !found {
+		_, err = c.call(http.MethodPost, fmt.Sprintf("/api/v1/namespaces/%s/secrets", namespace), desired, nil)
+		return err
+	}
+
+	if current.Type != SecretTypeTLS {
+		return fmt.Errorf("kubernetes: the secret %s/%s is not a TLS secret (type %s)", namespace, name, current.Type)
+	}
+
+	// keeps the labels and the annotations of the existing Secret.
+	for k, v := range current.Metadata.Labels {
+		if _, ok := desired.Metadata.Labels[k]; !ok {
+			desired.Metadata.Labels[k] = v
+		}
+	}
+
+	for k, v := range current.Metadata.Annotations {
+		if desired.Metadata.Annotations == nil {
+			desired.Metadata.Annotations = map[string]string{}
+		}
+		if _, ok := desired.Metadata.Annotations[k]; !ok {
+			desired.Metadata.Annotations[k] = v
+		}
+	}
+
+	desired.Metadata.ResourceVersion = current.Metadata.ResourceVersion
+
+	_, err = c.call(http.MethodPut, secretPath, desired, nil)
+	return err
+}
+
+// call calls the API, returns false if the resource is not found.
+func (c *Client) call(method, apiPath string, payload, result interface{}) (bool, error) {
+	var body []byte
+	if payload != nil {
+		var err error
+		body, err = json.Marshal(payload)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	req, err := http.NewRequest(method, strings.TrimSuffix(c.host, "/")+apiPath, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	token := c.token
+	if c.tokenFile != "" {
+		data, err := ioutil.ReadFile(c.tokenFile)
+		if err != nil {
+			return false, fmt.Errorf("kubernetes: unable to read the token: %v", err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+
+	switch {
+	case token != "":
+		req.Header.Set("Authorization", "Bearer "+token)
+	case c.username != "":
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("kubernetes: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return false, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound && method == http.MethodGet {
+		return false, nil
+	}
+
+	if resp.StatusCode/100 != 2 {
+		var status struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(respBody, &status) == nil && status.Message != "" {
+			return false, fmt.Errorf("kubernetes: %s %s: %d: %s", method, apiPath, resp.StatusCode, status.Message)
+		}
+		return false, fmt.Errorf("kubernetes: %s %s: %d: %s", method, apiPath, resp.StatusCode, string(respBody))
+	}
+
+	if result == nil {
+		return true, nil
+	}
+
+	err = json.Unmarshal(respBody, result)
+	if err != nil {
+		return false, fmt.Errorf("kubernetes: unable to parse the response of %s %s: %v", method, apiPath, err)
+	}
+
+	return true, nil
+}
+
+// resource a Secret of the Kubernetes API.
+type resource struct {
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Type       string            `json:"type"`
+	Metadata   metadata          `json:"metadata"`
+	Data       map[string][]byte `json:"data"`
+}
+
+type metadata struct {
+	Name            string            `json:"name"`
+	Namespace       string            `json:"namespace"`
+	ResourceVersion string            `json:"resourceVersion,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Annotations     map[string]string `json:"annotations,omitempty"`
+}
+
+func newTLSConfig(caData []byte, insecure bool) (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: insecure}
+
+	if len(caData) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caData) {
+			return nil, errors.New("kubernetes: invalid CA certificates")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+func newHTTPClient(tlsConfig *tls.Config) *http.Client {
+	return &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig, Proxy: http.ProxyFromEnvironment},
+	}
+}
@@ -0,0 +1,190 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, secrets map[string]*resource) (*Client, func()) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc("/api/v1/namespaces/default/secrets", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(rw, `{"message":"forbidden"}`, http.StatusForbidden)
+			return
+		}
+
+		secret := &resource{}
+		_ = json.NewDecoder(req.Body).Decode(secret)
+		secret.Metadata.ResourceVersion = "1"
+		secrets[secret.Metadata.Name] = secret
+
+		rw.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(rw).Encode(secret)
+	})
+
+	mux.HandleFunc("/api/v1/namespaces/default/secrets/", func(rw http.ResponseWriter, req *http.Request) {
+		name := filepath.Base(req.URL.Path)
+
+		switch req.Method {
+		case http.MethodGet:
+			secret, ok := secrets[name]
+			if !ok {
+				http.Error(rw, `{"message":"secrets \"`+name+`\" not found"}`, http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(rw).Encode(secret)
+
+		case http.MethodPut:
+			secret := &resource{}
+			_ = json.NewDecoder(req.Body).Decode(secret)
+
+			if secret.Metadata.ResourceVersion != secrets[name].Metadata.ResourceVersion {
+				http.Error(rw, `{"message":"conflict"}`, http.StatusConflict)
+				return
+			}
+
+			secret.Metadata.ResourceVersion = "2"
+			secrets[name] = secret
+			_ = json.NewEncoder(rw).Encode(secret)
+		}
+	})
+
+	client := &Client{host: server.URL, token: "secret", httpClient: server.Client()}
+
+	return client, server.Close
+}
+
+func TestClient_ApplyTLSSecret(t *testing.T) {
+	secrets := map[string]*resource{}
+
+	client, tearDown := setupTest(t, secrets)
+	defer tearDown()
+
+	// creation.
+	err := client.ApplyTLSSecret("default", "example.com-tls", &TLSSecret{
+		Certificate: []byte("cert"),
+		PrivateKey:  []byte("key"),
+	})
+	require.NoError(t, err)
+
+	secret := secrets["example.com-tls"]
+	require.NotNil(t, secret)
+	assert.Equal(t, SecretTypeTLS, secret.Type)
+	assert.Equal(t, map[string][]byte{TLSCertKey: []byte("cert"), TLSKeyKey: []byte("key")}, secret.Data)
+	assert.Equal(t, map[string]string{managedByLabel: "lego"}, secret.Metadata.Labels)
+
+	// update: the labels of the existing secret are kept.
+	secret.Metadata.Labels["team"] = "web"
+
+	err = client.ApplyTLSSecret("default", "example.com-tls", &TLSSecret{
+		Certificate:       []byte("new cert"),
+		PrivateKey:        []byte("new key"),
+		IssuerCertificate: []byte("issuer"),
+	})
+	require.NoError(t, err)
+
+	secret = secrets["example.com-tls"]
+	assert.Equal(t, "2", secret.Metadata.ResourceVersion)
+	assert.Equal(t, map[string][]byte{TLSCertKey: []byte("new cert"), TLSKeyKey: []byte("new key"), CACertKey: []byte("issuer")}, secret.Data)
+	assert.Equal(t, map[string]string{managedByLabel: "lego", "team": "web"}, secret.Metadata.Labels)
+}
+
+func TestClient_ApplyTLSSecret_notTLS(t *testing.T) {
+	secrets := map[string]*resource{
+		"example.com-tls": {Type: "Opaque", Metadata: metadata{Name: "example.com-tls", ResourceVersion: "1"}},
+	}
+
+	client, tearDown := setupTest(t, secrets)
+	defer tearDown()
+
+	err := client.ApplyTLSSecret("default", "example.com-tls", &TLSSecret{Certificate: []byte("cert"), PrivateKey: []byte("key")})
+	require.EqualError(t, err, "kubernetes: the secret default/example.com-tls is not a TLS secret (type Opaque)")
+}
+
+func Test_newKubeconfigClient(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-kubernetes")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	err = ioutil.WriteFile(filepath.Join(dir, "token"), []byte("file-token\n"), 0600)
+	require.NoError(t, err)
+
+	kubeconfig := `
+apiVersion: v1
+kind: Config
+current-context: prod
+contexts:
+- name: dev
+  context:
+    cluster: dev
+    user: dev
+- name: prod
+  context:
+    cluster: prod
+    user: lego
+    namespace: ingress
+clusters:
+- name: prod
+  cluster:
+    server: https://prod.example.com:6443
+    insecure-skip-tls-verify: true
+users:
+- name: lego
+  user:
+    tokenFile: token
+`
+
+	filename := filepath.Join(dir, "config")
+	err = ioutil.WriteFile(filename, []byte(kubeconfig), 0600)
+	require.NoError(t, err)
+
+	client, err := newKubeconfigClient(filename)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://prod.example.com:6443", client.host)
+	assert.Equal(t, "ingress", client.Namespace())
+	assert.Equal(t, filepath.Join(dir, "token"), client.tokenFile)
+}
+
+func Test_newKubeconfigClient_exec(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-kubernetes")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	kubeconfig := `
+current-context: eks
+contexts:
+- name: eks
+  context:
+    cluster: eks
+    user: eks
+clusters:
+- name: eks
+  cluster:
+    server: https://eks.example.com
+users:
+- name: eks
+  user:
+    exec:
+      command: aws
+`
+
+	filename := filepath.Join(dir, "config")
+	err = ioutil.WriteFile(filename, []byte(kubeconfig), 0600)
+	require.NoError(t, err)
+
+	_, err = newKubeconfigClient(filename)
+	require.EqualError(t, err, "kubernetes: the authentication of the user eks (exec or auth-provider) is not supported")
+}
@@ -0,0 +1,215 @@
+// Package s3 implements a storage of the accounts and the certificates in an Amazon S3 bucket (or an S3 compatible bucket).
+//
+//	import _ "github.com/go-acme/lego/v3/storage/s3"
+//
+//	// LEGO_S3_BUCKET=my-bucket lego --storage=s3 ...
+//
+// The AWS credentials and region are resolved with the default credential chain of the AWS SDK.
+package s3
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/storage"
+)
+
+// Name the name of the storage (--storage option).
+const Name = "s3"
+
+func init() {
+	storage.RegisterBackend(Name, func() (storage.Backend, error) {
+		return NewBackend()
+	})
+}
+
+// Config is used to configure the creation of the Backend.
+type Config struct {
+	// Bucket the name of the bucket.
+	Bucket string
+	// Prefix the prefix of the keys of the objects (i.e. "lego/").
+	Prefix string
+	// Endpoint a custom endpoint, for the S3 compatible storages (MinIO, Ceph, ...).
+	Endpoint string
+	// ForcePathStyle uses the path-style URLs (https://endpoint/bucket/key), usually required by the S3 compatible storages.
+	ForcePathStyle bool
+	// ServerSideEncryption the server-side encryption of the objects: AES256 (SSE-S3) or aws:kms (SSE-KMS).
+	ServerSideEncryption string
+	// KMSKeyID the ID or the ARN of the KMS key used by aws:kms, the default key of the account is used if empty.
+	KMSKeyID string
+}
+
+// NewDefaultConfig returns a default configuration for the Backend.
+func NewDefaultConfig() *Config {
+	return &Config{
+		Bucket:               env.GetOrFile("LEGO_S3_BUCKET"),
+		Prefix:               env.GetOrFile("LEGO_S3_PREFIX"),
+		Endpoint:             env.GetOrFile("LEGO_S3_ENDPOINT"),
+		ForcePathStyle:       env.GetOrDefaultBool("LEGO_S3_FORCE_PATH_STYLE", false),
+		ServerSideEncryption: env.GetOrFile("LEGO_S3_SSE"),
+		KMSKeyID:             env.GetOrFile("LEGO_S3_SSE_KMS_KEY_ID"),
+	}
+}
+
+// Backend stores the files as the objects of a bucket.
+type Backend struct {
+	client s3iface.S3API
+	config *Config
+}
+
+// NewBackend returns a Backend configured from the environment variables:
+// LEGO_S3_BUCKET, LEGO_S3_PREFIX, LEGO_S3_ENDPOINT, LEGO_S3_FORCE_PATH_STYLE, LEGO_S3_SSE, LEGO_S3_SSE_KMS_KEY_ID.
+func NewBackend() (*Backend, error) {
+	return NewBackendConfig(NewDefaultConfig())
+}
+
+// NewBackendConfig returns a Backend configured with a given config.
+func NewBackendConfig(config *Config) (*Backend, error) {
+	if config == nil {
+		return nil, errors.New("s3: the configuration of the storage is nil")
+	}
+
+	err := validateConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{SharedConfigState: session.SharedConfigEnable})
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &aws.Config{}
+	if config.Endpoint != "" {
+		cfg.Endpoint = aws.String(config.Endpoint)
+	}
+	if config.ForcePathStyle {
+		cfg.S3ForcePathStyle = aws.Bool(true)
+	}
+
+	return &Backend{client: s3.New(sess, cfg), config: config}, nil
+}
+
+func validateConfig(config *Config) error {
+	if config.Bucket == "" {
+		return errors.New("s3: the bucket is missing")
+	}
+
+	switch config.ServerSideEncryption {
+	case "", s3.ServerSideEncryptionAes256, s3.ServerSideEncryptionAwsKms:
+	default:
+		return fmt.Errorf("s3: unsupported server-side encryption: %s (supported: %s, %s)",
+			config.ServerSideEncryption, s3.ServerSideEncryptionAes256, s3.ServerSideEncryptionAwsKms)
+	}
+
+	if config.KMSKeyID != "" && config.ServerSideEncryption != s3.ServerSideEncryptionAwsKms {
+		return fmt.Errorf("s3: the KMS key requires the server-side encryption %s", s3.ServerSideEncryptionAwsKms)
+	}
+
+	return nil
+}
+
+// Load returns the content of an object, storage.ErrNotFound if the object doesn't exist.
+func (b *Backend) Load(name string) ([]byte, error) {
+	output, err := b.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(b.config.Bucket),
+		Key:    aws.String(b.key(name)),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, storage.ErrNotFound
+		}
+		return nil, fmt.Errorf("s3: unable to get %s: %v", b.Location(name), err)
+	}
+	defer func() { _ = output.Body.Close() }()
+
+	return ioutil.ReadAll(output.Body)
+}
+
+// Save creates or replaces an object, encrypted with the server-side encryption of the configuration.
+func (b *Backend) Save(name string, data []byte) error {
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(b.config.Bucket),
+		Key:    aws.String(b.key(name)),
+		Body:   bytes.NewReader(data),
+	}
+
+	if b.config.ServerSideEncryption != "" {
+		input.ServerSideEncryption = aws.String(b.config.ServerSideEncryption)
+	}
+
+	if b.config.KMSKeyID != "" {
+		input.SSEKMSKeyId = aws.String(b.config.KMSKeyID)
+	}
+
+	_, err := b.client.PutObject(input)
+	if err != nil {
+		return fmt.Errorf("s3: unable to put %s: %v", b.Location(name), err)
+	}
+
+	return nil
+}
+
+// List returns the sorted names of the objects starting with a prefix.
+func (b *Backend) List(prefix string) ([]string, error) {
+	var names []string
+
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(b.config.Bucket),
+		Prefix: aws.String(b.key(prefix)),
+	}
+
+	err := b.client.ListObjectsV2Pages(input, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			names = append(names, strings.TrimPrefix(aws.StringValue(object.Key), b.prefix()))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("s3: unable to list %s: %v", b.Location(prefix), err)
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// Delete removes an object.
+func (b *Backend) Delete(name string) error {
+	_, err := b.client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(b.config.Bucket),
+		Key:    aws.String(b.key(name)),
+	})
+	if err != nil {
+		return fmt.Errorf("s3: unable to delete %s: %v", b.Location(name), err)
+	}
+
+	return nil
+}
+
+// Location returns the URL of an object (s3://bucket/key).
+func (b *Backend) Location(name string) string {
+	return "s3://" + b.config.Bucket + "/" + b.key(name)
+}
+
+func (b *Backend) key(name string) string {
+	return b.prefix() + name
+}
+
+func (b *Backend) prefix() string {
+	if b.config.Prefix == "" {
+		return ""
+	}
+
+	return strings.TrimSuffix(b.config.Prefix, "/") + "/"
+}
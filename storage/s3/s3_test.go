@@ -0,0 +1,160 @@
+package s3
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/go-acme/lego/v3/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockClient struct {
+	s3iface.S3API
+	objects map[string]*s3.PutObjectInput
+}
+
+func (m mockClient) GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	object, ok := m.objects[aws.StringValue(input.Key)]
+	if !ok {
+		return nil, awserr.New(s3.ErrCodeNoSuchKey, "The specified key does not exist.", nil)
+	}
+
+	data, err := ioutil.ReadAll(object.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = object.Body.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3.GetObjectOutput{Body: ioutil.NopCloser(bytes.NewReader(data))}, nil
+}
+
+func (m mockClient) PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+	m.objects[aws.StringValue(input.Key)] = input
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (m mockClient) ListObjectsV2Pages(input *s3.ListObjectsV2Input, fn func(*s3.ListObjectsV2Output, bool) bool) error {
+	// one object per page.
+	var pages []*s3.ListObjectsV2Output
+	for key := range m.objects {
+		if strings.HasPrefix(key, aws.StringValue(input.Prefix)) {
+			pages = append(pages, &s3.ListObjectsV2Output{Contents: []*s3.Object{{Key: aws.String(key)}}})
+		}
+	}
+
+	for i, page := range pages {
+		if !fn(page, i == len(pages)-1) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (m mockClient) DeleteObject(input *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error) {
+	delete(m.objects, aws.StringValue(input.Key))
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+func TestBackend(t *testing.T) {
+	client := mockClient{objects: map[string]*s3.PutObjectInput{}}
+
+	backend := &Backend{
+		client: client,
+		config: &Config{
+			Bucket:               "my-bucket",
+			Prefix:               "lego",
+			ServerSideEncryption: s3.ServerSideEncryptionAwsKms,
+			KMSKeyID:             "alias/lego",
+		},
+	}
+
+	_, err := backend.Load("certificates/example.com.crt")
+	require.Equal(t, storage.ErrNotFound, err)
+
+	require.NoError(t, backend.Save("certificates/example.com.crt", []byte("cert")))
+	require.NoError(t, backend.Save("certificates/example.com.key", []byte("key")))
+	require.NoError(t, backend.Save("archives/1500000000.example.com.crt", []byte("old")))
+
+	object := client.objects["lego/certificates/example.com.key"]
+	require.NotNil(t, object)
+	assert.Equal(t, "my-bucket", aws.StringValue(object.Bucket))
+	assert.Equal(t, s3.ServerSideEncryptionAwsKms, aws.StringValue(object.ServerSideEncryption))
+	assert.Equal(t, "alias/lego", aws.StringValue(object.SSEKMSKeyId))
+
+	data, err := backend.Load("certificates/example.com.crt")
+	require.NoError(t, err)
+	assert.Equal(t, "cert", string(data))
+
+	names, err := backend.List("certificates/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"certificates/example.com.crt", "certificates/example.com.key"}, names)
+
+	require.NoError(t, storage.Move(backend, "certificates/example.com.crt", "archives/1600000000.example.com.crt"))
+
+	names, err = backend.List("")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"archives/1500000000.example.com.crt", "archives/1600000000.example.com.crt", "certificates/example.com.key"}, names)
+
+	assert.Equal(t, "s3://my-bucket/lego/certificates/example.com.key", backend.Location("certificates/example.com.key"))
+}
+
+func Test_validateConfig(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		config    *Config
+		expectErr string
+	}{
+		{
+			desc:   "bucket",
+			config: &Config{Bucket: "my-bucket"},
+		},
+		{
+			desc:   "SSE-S3",
+			config: &Config{Bucket: "my-bucket", ServerSideEncryption: "AES256"},
+		},
+		{
+			desc:   "SSE-KMS",
+			config: &Config{Bucket: "my-bucket", ServerSideEncryption: "aws:kms", KMSKeyID: "alias/lego"},
+		},
+		{
+			desc:      "missing bucket",
+			config:    &Config{},
+			expectErr: "s3: the bucket is missing",
+		},
+		{
+			desc:      "unsupported encryption",
+			config:    &Config{Bucket: "my-bucket", ServerSideEncryption: "foo"},
+			expectErr: "s3: unsupported server-side encryption: foo (supported: AES256, aws:kms)",
+		},
+		{
+			desc:      "KMS key without SSE-KMS",
+			config:    &Config{Bucket: "my-bucket", ServerSideEncryption: "AES256", KMSKeyID: "alias/lego"},
+			expectErr: "s3: the KMS key requires the server-side encryption aws:kms",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			err := validateConfig(test.config)
+			if test.expectErr != "" {
+				require.EqualError(t, err, test.expectErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
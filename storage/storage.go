@@ -0,0 +1,132 @@
+// Package storage defines the backends storing the accounts and the certificates of the CLI.
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ErrNotFound is returned by Backend.Load when the file doesn't exist.
+var ErrNotFound = errors.New("storage: file not found")
+
+// Backend stores the files of the accounts and the certificates.
+//
+// The files are identified by a slash-separated name relative to the root of the storage:
+//
+//	certificates/example.com.crt
+//	archives/1500000000.example.com.crt
+//	accounts/acme-v02.api.letsencrypt.org/foo@bar.com/account.json
+//	accounts/acme-v02.api.letsencrypt.org/foo@bar.com/keys/foo@bar.com.key
+type Backend interface {
+	// Load returns the content of a file, ErrNotFound if the file doesn't exist.
+	Load(name string) ([]byte, error)
+	// Save creates or replaces a file.
+	Save(name string, data []byte) error
+	// List returns the sorted names of the files starting with a prefix (i.e. "certificates/").
+	List(prefix string) ([]string, error)
+	// Delete removes a file, a file which doesn't exist is not an error.
+	Delete(name string) error
+	// Location returns where a file is stored (a path, a URL), used by the messages and the results of the commands.
+	Location(name string) string
+}
+
+// Renamer allows for implementing a Backend able to move a file without copying its content.
+// If a Backend doesn't provide a Rename method, the file is copied then deleted.
+type Renamer interface {
+	Backend
+	Rename(oldName, newName string) error
+}
+
+// Exists returns true if a file exists.
+func Exists(backend Backend, name string) (bool, error) {
+	_, err := backend.Load(name)
+	if err == ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Move moves a file.
+func Move(backend Backend, oldName, newName string) error {
+	if renamer, ok := backend.(Renamer); ok {
+		return renamer.Rename(oldName, newName)
+	}
+
+	data, err := backend.Load(oldName)
+	if err != nil {
+		return err
+	}
+
+	err = backend.Save(newName, data)
+	if err != nil {
+		return err
+	}
+
+	return backend.Delete(oldName)
+}
+
+// BackendFactory creates a Backend, usually from the environment variables.
+type BackendFactory func() (Backend, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]BackendFactory)
+)
+
+// RegisterBackend makes a third-party Backend available by name (i.e. `lego --storage name`).
+// It's intended to be called from an init function of the package of the backend.
+//
+//	func init() {
+//		storage.RegisterBackend("mycustom", func() (storage.Backend, error) {
+//			return mycustom.NewBackend()
+//		})
+//	}
+//
+// It panics if the factory is nil or if a backend is already registered with the same name.
+func RegisterBackend(name string, factory BackendFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if name == "" {
+		panic("storage: RegisterBackend with an empty name")
+	}
+
+	if factory == nil {
+		panic(fmt.Sprintf("storage: RegisterBackend %s with a nil factory", name))
+	}
+
+	if _, dup := registry[name]; dup {
+		panic(fmt.Sprintf("storage: RegisterBackend called twice for backend %s", name))
+	}
+
+	registry[name] = factory
+}
+
+// RegisteredBackends returns the sorted names of the registered third-party backends.
+func RegisteredBackends() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	var names []string
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// LookupBackend returns the factory of a registered third-party backend.
+func LookupBackend(name string) (BackendFactory, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	factory, ok := registry[name]
+	return factory, ok
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryBackend a Backend without Rename.
+type memoryBackend struct {
+	files map[string][]byte
+}
+
+func (m *memoryBackend) Load(name string) ([]byte, error) {
+	data, ok := m.files[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return data, nil
+}
+
+func (m *memoryBackend) Save(name string, data []byte) error {
+	m.files[name] = data
+	return nil
+}
+
+func (m *memoryBackend) List(prefix string) ([]string, error) {
+	var names []string
+	for name := range m.files {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (m *memoryBackend) Delete(name string) error {
+	delete(m.files, name)
+	return nil
+}
+
+func (m *memoryBackend) Location(name string) string {
+	return "memory://" + name
+}
+
+func TestExists(t *testing.T) {
+	backend := &memoryBackend{files: map[string][]byte{"certificates/example.com.crt": []byte("crt")}}
+
+	exists, err := Exists(backend, "certificates/example.com.crt")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = Exists(backend, "certificates/example.org.crt")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestMove(t *testing.T) {
+	backend := &memoryBackend{files: map[string][]byte{"certificates/example.com.crt": []byte("crt")}}
+
+	err := Move(backend, "certificates/example.com.crt", "archives/1500000000.example.com.crt")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string][]byte{"archives/1500000000.example.com.crt": []byte("crt")}, backend.files)
+
+	err = Move(backend, "certificates/example.com.crt", "archives/1500000000.example.com.crt")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestRegisterBackend(t *testing.T) {
+	defer unregisterBackend("mycustom")
+
+	custom := &memoryBackend{}
+
+	RegisterBackend("mycustom", func() (Backend, error) {
+		return custom, nil
+	})
+
+	assert.Contains(t, RegisteredBackends(), "mycustom")
+
+	factory, ok := LookupBackend("mycustom")
+	require.True(t, ok)
+
+	backend, err := factory()
+	require.NoError(t, err)
+	assert.Same(t, custom, backend)
+
+	_, ok = LookupBackend("unknown")
+	assert.False(t, ok)
+
+	assert.Panics(t, func() {
+		RegisterBackend("mycustom", func() (Backend, error) { return nil, nil })
+	})
+}
+
+func TestRegisterBackend_nil(t *testing.T) {
+	assert.Panics(t, func() {
+		RegisterBackend("mycustom", nil)
+	})
+
+	assert.Panics(t, func() {
+		RegisterBackend("", func() (Backend, error) { return nil, nil })
+	})
+}
+
+func unregisterBackend(name string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	delete(registry, name)
+}
@@ -0,0 +1,454 @@
+// Package vault implements a storage of the accounts and the certificates in HashiCorp Vault (KV secrets engine).
+//
+//	import _ "github.com/go-acme/lego/v3/storage/vault"
+//
+//	// VAULT_ADDR=https://vault.example.com:8200 VAULT_TOKEN=s.xxx lego --storage=vault ...
+//
+// Each file is a secret of the KV secrets engine (version 1 or 2), the content of the file is in the field "content" (base64).
+// If a transit key is defined, the private keys of the accounts are encrypted by the transit secrets engine,
+// the ciphertext is in the field "ciphertext".
+//
+// The client is authenticated with a token (VAULT_TOKEN), or with an AppRole (VAULT_ROLE_ID and VAULT_SECRET_ID).
+package vault
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v3/platform/config/env"
+	"github.com/go-acme/lego/v3/storage"
+)
+
+// Name the name of the storage (--storage option).
+const Name = "vault"
+
+func init() {
+	storage.RegisterBackend(Name, func() (storage.Backend, error) {
+		return NewBackend()
+	})
+}
+
+// Config is used to configure the creation of the Backend.
+type Config struct {
+	// Address the address of the Vault server.
+	Address string
+	// Token the token of the client, an AppRole is used if empty.
+	Token string
+	// RoleID the role ID of the AppRole.
+	RoleID string
+	// SecretID the secret ID of the AppRole.
+	SecretID string
+	// AppRoleMount the path of the AppRole auth method.
+	AppRoleMount string
+	// Namespace the namespace (Vault Enterprise).
+	Namespace string
+	// Mount the path of the KV secrets engine.
+	Mount string
+	// KVVersion the version of the KV secrets engine (1 or 2).
+	KVVersion int
+	// Prefix the path of the secrets in the KV secrets engine.
+	Prefix string
+	// TransitMount the path of the transit secrets engine.
+	TransitMount string
+	// TransitKey the name of the transit key encrypting the private keys of the accounts, the keys are not encrypted if empty.
+	TransitKey string
+	HTTPClient *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the Backend.
+func NewDefaultConfig() *Config {
+	return &Config{
+		Address:      env.GetOrFile("VAULT_ADDR"),
+		Token:        env.GetOrFile("VAULT_TOKEN"),
+		RoleID:       env.GetOrFile("VAULT_ROLE_ID"),
+		SecretID:     env.GetOrFile("VAULT_SECRET_ID"),
+		AppRoleMount: env.GetOrDefaultString("VAULT_APPROLE_MOUNT", "approle"),
+		Namespace:    env.GetOrFile("VAULT_NAMESPACE"),
+		Mount:        env.GetOrDefaultString("LEGO_VAULT_MOUNT", "secret"),
+		KVVersion:    env.GetOrDefaultInt("LEGO_VAULT_KV_VERSION", 2),
+		Prefix:       env.GetOrDefaultString("LEGO_VAULT_PREFIX", "lego"),
+		TransitMount: env.GetOrDefaultString("LEGO_VAULT_TRANSIT_MOUNT", "transit"),
+		TransitKey:   env.GetOrFile("LEGO_VAULT_TRANSIT_KEY"),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("VAULT_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// Backend stores the files as the secrets of a KV secrets engine.
+type Backend struct {
+	config *Config
+
+	mu    sync.Mutex
+	token string
+}
+
+// NewBackend returns a Backend configured from the environment variables:
+// VAULT_ADDR, VAULT_TOKEN, VAULT_ROLE_ID, VAULT_SECRET_ID, VAULT_APPROLE_MOUNT, VAULT_NAMESPACE,
+// LEGO_VAULT_MOUNT, LEGO_VAULT_KV_VERSION, LEGO_VAULT_PREFIX, LEGO_VAULT_TRANSIT_MOUNT, LEGO_VAULT_TRANSIT_KEY.
+func NewBackend() (*Backend, error) {
+	return NewBackendConfig(NewDefaultConfig())
+}
+
+// NewBackendConfig returns a Backend configured with a given config.
+func NewBackendConfig(config *Config) (*Backend, error) {
+	if config == nil {
+		return nil, errors.New("vault: the configuration of the storage is nil")
+	}
+
+	if config.Address == "" {
+		return nil, errors.New("vault: the address is missing")
+	}
+
+	if config.Token == "" && (config.RoleID == "" || config.SecretID == "") {
+		return nil, errors.New("vault: the credentials are missing: a token, or the role ID and the secret ID of an AppRole")
+	}
+
+	if config.KVVersion != 1 && config.KVVersion != 2 {
+		return nil, fmt.Errorf("vault: unsupported KV version: %d (supported: 1, 2)", config.KVVersion)
+	}
+
+	backend := &Backend{config: config, token: config.Token}
+
+	if backend.token == "" {
+		err := backend.login()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return backend, nil
+}
+
+// Load returns the content of a file, storage.ErrNotFound if the secret doesn't exist.
+func (b *Backend) Load(name string) ([]byte, error) {
+	var secret struct {
+		Data map[string]interface{} `json:"data"`
+	}
+
+	err := b.call(http.MethodGet, b.dataPath(b.key(name)), nil, &secret)
+	if err != nil {
+		return nil, err
+	}
+
+	data := secret.Data
+	if b.config.KVVersion == 2 {
+		data, _ = secret.Data["data"].(map[string]interface{})
+	}
+
+	if ciphertext, ok := data["ciphertext"].(string); ok {
+		return b.decrypt(ciphertext)
+	}
+
+	content, ok := data["content"].(string)
+	if !ok {
+		return nil, fmt.Errorf("vault: the field content is missing in %s", b.Location(name))
+	}
+
+	return base64.StdEncoding.DecodeString(content)
+}
+
+// Save creates or replaces a secret, the private keys of the accounts are encrypted with the transit key.
+func (b *Backend) Save(name string, data []byte) error {
+	fields := map[string]interface{}{}
+
+	if b.config.TransitKey != "" && isAccountKey(name) {
+		ciphertext, err := b.encrypt(data)
+		if err != nil {
+			return err
+		}
+		fields["ciphertext"] = ciphertext
+	} else {
+		fields["content"] = base64.StdEncoding.EncodeToString(data)
+	}
+
+	var payload interface{} = fields
+	if b.config.KVVersion == 2 {
+		payload = map[string]interface{}{"data": fields}
+	}
+
+	return b.call(http.MethodPost, b.dataPath(b.key(name)), payload, nil)
+}
+
+// List returns the sorted names of the secrets starting with a prefix.
+func (b *Backend) List(prefix string) ([]string, error) {
+	dir := path.Dir(prefix)
+	if strings.HasSuffix(prefix, "/") {
+		dir = strings.TrimSuffix(prefix, "/")
+	}
+	if dir == "." {
+		dir = ""
+	}
+
+	names, err := b.walk(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []string
+	for _, name := range names {
+		if strings.HasPrefix(name, prefix) {
+			matches = append(matches, name)
+		}
+	}
+
+	sort.Strings(matches)
+
+	return matches, nil
+}
+
+// walk lists recursively the secrets of a directory.
+func (b *Backend) walk(dir string) ([]string, error) {
+	var result struct {
+		Data struct {
+			Keys []string `json:"keys"`
+		} `json:"data"`
+	}
+
+	err := b.call(http.MethodGet, b.metadataPath(b.key(dir))+"?list=true", nil, &result)
+	if err == storage.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, key := range result.Data.Keys {
+		name := strings.TrimPrefix(path.Join(dir, key), "/")
+
+		if !strings.HasSuffix(key, "/") {
+			names = append(names, name)
+			continue
+		}
+
+		children, err := b.walk(name)
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, children...)
+	}
+
+	return names, nil
+}
+
+// Delete removes a secret (all the versions with the KV version 2).
+func (b *Backend) Delete(name string) error {
+	err := b.call(http.MethodDelete, b.metadataPath(b.key(name)), nil, nil)
+	if err == storage.ErrNotFound {
+		return nil
+	}
+
+	return err
+}
+
+// Location returns the path of a secret (vault:mount/prefix/name).
+func (b *Backend) Location(name string) string {
+	return "vault:" + path.Join(b.config.Mount, b.key(name))
+}
+
+func (b *Backend) key(name string) string {
+	return path.Join(b.config.Prefix, name)
+}
+
+func (b *Backend) dataPath(key string) string {
+	if b.config.KVVersion == 2 {
+		return path.Join(b.config.Mount, "data", key)
+	}
+
+	return path.Join(b.config.Mount, key)
+}
+
+func (b *Backend) metadataPath(key string) string {
+	if b.config.KVVersion == 2 {
+		return path.Join(b.config.Mount, "metadata", key)
+	}
+
+	return path.Join(b.config.Mount, key)
+}
+
+// encrypt encrypts a private key with the transit key.
+func (b *Backend) encrypt(data []byte) (string, error) {
+	var result struct {
+		Data struct {
+			Ciphertext string `json:"ciphertext"`
+		} `json:"data"`
+	}
+
+	payload := map[string]string{"plaintext": base64.StdEncoding.EncodeToString(data)}
+
+	err := b.call(http.MethodPost, path.Join(b.config.TransitMount, "encrypt", b.config.TransitKey), payload, &result)
+	if err != nil {
+		return "", err
+	}
+
+	return result.Data.Ciphertext, nil
+}
+
+// decrypt decrypts a private key with the transit key.
+func (b *Backend) decrypt(ciphertext string) ([]byte, error) {
+	if b.config.TransitKey == "" {
+		return nil, errors.New("vault: the secret is encrypted, but the transit key is not defined")
+	}
+
+	var result struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+	}
+
+	payload := map[string]string{"ciphertext": ciphertext}
+
+	err := b.call(http.MethodPost, path.Join(b.config.TransitMount, "decrypt", b.config.TransitKey), payload, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return base64.StdEncoding.DecodeString(result.Data.Plaintext)
+}
+
+// login gets a token with the AppRole.
+func (b *Backend) login() error {
+	var result struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+
+	payload := map[string]string{"role_id": b.config.RoleID, "secret_id": b.config.SecretID}
+
+	status, body, err := b.send(http.MethodPost, path.Join("auth", b.config.AppRoleMount, "login"), "", payload)
+	if err != nil {
+		return err
+	}
+
+	if status != http.StatusOK {
+		return fmt.Errorf("vault: unable to login with the AppRole: %d: %s", status, string(body))
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return fmt.Errorf("vault: unable to parse the login response: %v", err)
+	}
+
+	if result.Auth.ClientToken == "" {
+		return errors.New("vault: the login response doesn't contain a token")
+	}
+
+	b.mu.Lock()
+	b.token = result.Auth.ClientToken
+	b.mu.Unlock()
+
+	return nil
+}
+
+// call calls the API, with the AppRole a new token is requested if the token has expired.
+// Returns storage.ErrNotFound if the status is 404.
+func (b *Backend) call(method, apiPath string, payload, result interface{}) error {
+	status, body, err := b.send(method, apiPath, b.getToken(), payload)
+	if err != nil {
+		return err
+	}
+
+	if status == http.StatusForbidden && b.config.Token == "" {
+		err = b.login()
+		if err != nil {
+			return err
+		}
+
+		status, body, err = b.send(method, apiPath, b.getToken(), payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	if status == http.StatusNotFound {
+		return storage.ErrNotFound
+	}
+
+	if status/100 != 2 {
+		return fmt.Errorf("vault: %s %s: %d: %s", method, apiPath, status, string(body))
+	}
+
+	if result == nil || len(body) == 0 {
+		return nil
+	}
+
+	err = json.Unmarshal(body, result)
+	if err != nil {
+		return fmt.Errorf("vault: unable to parse the response of %s %s: %v", method, apiPath, err)
+	}
+
+	return nil
+}
+
+func (b *Backend) getToken() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.token
+}
+
+func (b *Backend) send(method, apiPath, token string, payload interface{}) (int, []byte, error) {
+	var reqBody []byte
+	if payload != nil {
+		var err error
+		reqBody, err = json.Marshal(payload)
+		if err != nil {
+			return 0, nil, err
+		}
+	}
+
+	endpoint := strings.TrimSuffix(b.config.Address, "/") + "/v1/" + apiPath
+
+	req, err := http.NewRequest(method, endpoint, bytes.NewReader(reqBody))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+
+	if b.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", b.config.Namespace)
+	}
+
+	client := b.config.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return resp.StatusCode, body, nil
+}
+
+// isAccountKey returns true if the file is the private key of an account (accounts/<server>/<email>/keys/<email>.key).
+func isAccountKey(name string) bool {
+	return strings.HasPrefix(name, "accounts/") && path.Base(path.Dir(name)) == "keys" && strings.HasSuffix(name, ".key")
+}
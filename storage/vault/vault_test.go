@@ -0,0 +1,274 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-acme/lego/v3/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVault a Vault server with a KV secrets engine (mounted on secret/), a transit secrets engine, and the AppRole auth method.
+type fakeVault struct {
+	kvVersion int
+	secrets   map[string]map[string]interface{}
+	tokens    map[string]bool
+	logins    int
+}
+
+func (f *fakeVault) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	apiPath := strings.TrimPrefix(req.URL.Path, "/v1/")
+
+	if apiPath == "auth/approle/login" {
+		var payload map[string]string
+		_ = json.NewDecoder(req.Body).Decode(&payload)
+
+		if payload["role_id"] != "role" || payload["secret_id"] != "secret" {
+			http.Error(rw, `{"errors":["invalid role or secret ID"]}`, http.StatusBadRequest)
+			return
+		}
+
+		f.logins++
+		token := "token" + strings.Repeat("+", f.logins)
+		f.tokens[token] = true
+
+		_ = json.NewEncoder(rw).Encode(map[string]interface{}{"auth": map[string]string{"client_token": token}})
+		return
+	}
+
+	if !f.tokens[req.Header.Get("X-Vault-Token")] {
+		http.Error(rw, `{"errors":["permission denied"]}`, http.StatusForbidden)
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(apiPath, "transit/encrypt/lego"):
+		var payload map[string]string
+		_ = json.NewDecoder(req.Body).Decode(&payload)
+		_ = json.NewEncoder(rw).Encode(map[string]interface{}{"data": map[string]string{"ciphertext": "vault:v1:" + payload["plaintext"]}})
+
+	case strings.HasPrefix(apiPath, "transit/decrypt/lego"):
+		var payload map[string]string
+		_ = json.NewDecoder(req.Body).Decode(&payload)
+		_ = json.NewEncoder(rw).Encode(map[string]interface{}{"data": map[string]string{"plaintext": strings.TrimPrefix(payload["ciphertext"], "vault:v1:")}})
+
+	case req.URL.Query().Get("list") == "true":
+		f.list(rw, f.secretPath(apiPath, "metadata"))
+
+	case req.Method == http.MethodGet:
+		secret, ok := f.secrets[f.secretPath(apiPath, "data")]
+		if !ok {
+			http.Error(rw, `{"errors":[]}`, http.StatusNotFound)
+			return
+		}
+
+		data := secret
+		if f.kvVersion == 2 {
+			data = map[string]interface{}{"data": secret, "metadata": map[string]interface{}{}}
+		}
+
+		_ = json.NewEncoder(rw).Encode(map[string]interface{}{"data": data})
+
+	case req.Method == http.MethodPost:
+		var payload map[string]interface{}
+		_ = json.NewDecoder(req.Body).Decode(&payload)
+
+		if f.kvVersion == 2 {
+			payload = payload["data"].(map[string]interface{})
+		}
+
+		f.secrets[f.secretPath(apiPath, "data")] = payload
+		rw.WriteHeader(http.StatusNoContent)
+
+	case req.Method == http.MethodDelete:
+		delete(f.secrets, f.secretPath(apiPath, "metadata"))
+		rw.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (f *fakeVault) secretPath(apiPath, kind string) string {
+	if f.kvVersion == 2 {
+		return strings.TrimPrefix(apiPath, "secret/"+kind+"/")
+	}
+
+	return strings.TrimPrefix(apiPath, "secret/")
+}
+
+func (f *fakeVault) list(rw http.ResponseWriter, dir string) {
+	keys := map[string]bool{}
+	for name := range f.secrets {
+		if !strings.HasPrefix(name, dir+"/") {
+			continue
+		}
+
+		rel := strings.TrimPrefix(name, dir+"/")
+		if i := strings.Index(rel, "/"); i >= 0 {
+			rel = rel[:i+1]
+		}
+		keys[rel] = true
+	}
+
+	if len(keys) == 0 {
+		http.Error(rw, `{"errors":[]}`, http.StatusNotFound)
+		return
+	}
+
+	var result []string
+	for key := range keys {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+
+	_ = json.NewEncoder(rw).Encode(map[string]interface{}{"data": map[string]interface{}{"keys": result}})
+}
+
+func setupTest(t *testing.T, kvVersion int) (*fakeVault, *Config, func()) {
+	t.Helper()
+
+	fake := &fakeVault{
+		kvVersion: kvVersion,
+		secrets:   map[string]map[string]interface{}{},
+		tokens:    map[string]bool{"root": true},
+	}
+
+	server := httptest.NewServer(fake)
+
+	config := &Config{
+		Address:      server.URL,
+		AppRoleMount: "approle",
+		Mount:        "secret",
+		KVVersion:    kvVersion,
+		Prefix:       "lego",
+		TransitMount: "transit",
+	}
+
+	return fake, config, server.Close
+}
+
+func TestBackend(t *testing.T) {
+	for _, kvVersion := range []int{1, 2} {
+		kvVersion := kvVersion
+
+		t.Run(fmt.Sprintf("KV version %d", kvVersion), func(t *testing.T) {
+			fake, config, tearDown := setupTest(t, kvVersion)
+			defer tearDown()
+
+			config.Token = "root"
+
+			backend, err := NewBackendConfig(config)
+			require.NoError(t, err)
+
+			_, err = backend.Load("certificates/example.com.crt")
+			require.Equal(t, storage.ErrNotFound, err)
+
+			require.NoError(t, backend.Save("certificates/example.com.crt", []byte("cert")))
+			require.NoError(t, backend.Save("certificates/example.com.key", []byte("key")))
+			require.NoError(t, backend.Save("accounts/acme.example.com/foo@example.com/account.json", []byte("{}")))
+
+			assert.Equal(t, map[string]interface{}{"content": "Y2VydA=="}, fake.secrets["lego/certificates/example.com.crt"])
+
+			data, err := backend.Load("certificates/example.com.crt")
+			require.NoError(t, err)
+			assert.Equal(t, "cert", string(data))
+
+			names, err := backend.List("certificates/")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"certificates/example.com.crt", "certificates/example.com.key"}, names)
+
+			names, err = backend.List("accounts/")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"accounts/acme.example.com/foo@example.com/account.json"}, names)
+
+			names, err = backend.List("archives/")
+			require.NoError(t, err)
+			assert.Empty(t, names)
+
+			require.NoError(t, storage.Move(backend, "certificates/example.com.crt", "archives/1500000000.example.com.crt"))
+
+			names, err = backend.List("")
+			require.NoError(t, err)
+			expected := []string{
+				"accounts/acme.example.com/foo@example.com/account.json",
+				"archives/1500000000.example.com.crt",
+				"certificates/example.com.key",
+			}
+			assert.Equal(t, expected, names)
+
+			require.NoError(t, backend.Delete("certificates/example.com.crt"))
+
+			assert.Equal(t, "vault:secret/lego/certificates/example.com.key", backend.Location("certificates/example.com.key"))
+		})
+	}
+}
+
+func TestBackend_appRoleTransit(t *testing.T) {
+	fake, config, tearDown := setupTest(t, 2)
+	defer tearDown()
+
+	config.RoleID = "role"
+	config.SecretID = "secret"
+	config.TransitKey = "lego"
+
+	backend, err := NewBackendConfig(config)
+	require.NoError(t, err)
+	assert.Equal(t, 1, fake.logins)
+
+	keyName := "accounts/acme.example.com/foo@example.com/keys/foo@example.com.key"
+
+	require.NoError(t, backend.Save(keyName, []byte("account key")))
+
+	// the private key of the account is encrypted by the transit secrets engine.
+	assert.Equal(t, map[string]interface{}{"ciphertext": "vault:v1:YWNjb3VudCBrZXk="}, fake.secrets["lego/"+keyName])
+
+	// the token has expired: a new token is requested.
+	fake.tokens = map[string]bool{}
+
+	data, err := backend.Load(keyName)
+	require.NoError(t, err)
+	assert.Equal(t, "account key", string(data))
+	assert.Equal(t, 2, fake.logins)
+}
+
+func TestNewBackendConfig_errors(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		config    *Config
+		expectErr string
+	}{
+		{
+			desc:      "missing address",
+			config:    &Config{Token: "root", KVVersion: 2},
+			expectErr: "vault: the address is missing",
+		},
+		{
+			desc:      "missing credentials",
+			config:    &Config{Address: "http://localhost:8200", RoleID: "role", KVVersion: 2},
+			expectErr: "vault: the credentials are missing: a token, or the role ID and the secret ID of an AppRole",
+		},
+		{
+			desc:      "unsupported KV version",
+			config:    &Config{Address: "http://localhost:8200", Token: "root", KVVersion: 3},
+			expectErr: "vault: unsupported KV version: 3 (supported: 1, 2)",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := NewBackendConfig(test.config)
+			require.EqualError(t, err, test.expectErr)
+		})
+	}
+}
+
+func Test_isAccountKey(t *testing.T) {
+	assert.True(t, isAccountKey("accounts/acme.example.com/foo@example.com/keys/foo@example.com.key"))
+	assert.False(t, isAccountKey("certificates/example.com.key"))
+	assert.False(t, isAccountKey("accounts/acme.example.com/foo@example.com/account.json"))
+}